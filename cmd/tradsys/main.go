@@ -13,21 +13,28 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"github.com/abdoElHodaky/tradSys/internal/api/handlers"
+	"github.com/abdoElHodaky/tradSys/internal/auth"
 	"github.com/abdoElHodaky/tradSys/internal/compliance"
 	"github.com/abdoElHodaky/tradSys/internal/config"
 	"github.com/abdoElHodaky/tradSys/internal/connectivity"
 	order_matching "github.com/abdoElHodaky/tradSys/internal/core/matching"
 	"github.com/abdoElHodaky/tradSys/internal/core/settlement"
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
 	"github.com/abdoElHodaky/tradSys/internal/gateway"
 	"github.com/abdoElHodaky/tradSys/internal/marketdata"
 	"github.com/abdoElHodaky/tradSys/internal/orders"
 	"github.com/abdoElHodaky/tradSys/internal/risk"
 	"github.com/abdoElHodaky/tradSys/internal/strategies"
+	"github.com/abdoElHodaky/tradSys/internal/trading/execution/algo"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/abdoElHodaky/tradSys/internal/ws"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
 	orders_proto "github.com/abdoElHodaky/tradSys/proto/orders"
 	riskpb "github.com/abdoElHodaky/tradSys/proto/risk"
 )
@@ -116,8 +123,32 @@ func runServer() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Build the order service behind authenticated order entry, persisting
+	// its orders to the database and trading the instruments stored there
+	// as well as those in config, with the risk service and a margin engine
+	// that liquidates through it, and the execution algorithms placing their
+	// child orders through it
+	var orderService *orders.OrderService
+	var sequencer *matching.Sequencer
+	var matchingEngine *order_matching.Engine
+	orderApp := newOrderApp(cfg, logger,
+		db.Module,
+		repositories.OrderRepositoryModule,
+		repositories.InstrumentRepositoryModule,
+		fx.Provide(order_matching.NewEngine),
+		risk.RiskManagementModule,
+		risk.RiskModule,
+		fx.Invoke(func(*risk.MarginEngine) {}),
+		algo.Module,
+		fx.Invoke(func(*algo.Service) {}),
+		fx.Populate(&orderService, &sequencer, &matchingEngine),
+	)
+	if err := orderApp.Err(); err != nil {
+		log.Fatalf("Failed to build order service: %v", err)
+	}
+
 	// Initialize unified trading system
-	tradingSystem, err := initializeTradingSystem(cfg)
+	tradingSystem, err := initializeTradingSystem(cfg, matchingEngine, sequencer)
 	if err != nil {
 		log.Fatalf("Failed to initialize trading system: %v", err)
 	}
@@ -127,10 +158,32 @@ func runServer() {
 	router := gin.New()
 	router.Use(gin.Logger(), gin.Recovery())
 
+	// Recover the order books and start the order service
+	startCtx, cancelStart := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelStart()
+	if err := orderApp.Start(startCtx); err != nil {
+		log.Fatalf("Failed to start order service: %v", err)
+	}
+
+	jwtService := newJWTService(cfg)
+
 	// Setup API routes
 	api := router.Group("/api/v1")
 	handlers.SetupRoutes(api, tradingSystem)
 
+	// Order entry and control endpoints act on behalf of the token's user
+	secured := api.Group("", auth.NewMiddleware(jwtService, logger).JWTAuth())
+	handlers.NewOrderHandlers(orderService, logger).RegisterRoutes(secured)
+	handlers.NewOrderControlHandlers(orderService, logger).RegisterRoutes(secured)
+
+	// Order entry and control over WebSocket
+	wsServer := ws.NewAuthenticatedServer(logger, jwtService)
+	ws.NewOrderEntryHandler(orderService, logger).Register(wsServer)
+	ws.NewOrderControlHandler(orderService, logger).Register(wsServer)
+	router.GET("/ws", func(c *gin.Context) {
+		wsServer.HandleWebSocket(c.Writer, c.Request)
+	})
+
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -191,6 +244,9 @@ func runServer() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if err := orderApp.Stop(ctx); err != nil {
+		log.Printf("Error during order service shutdown: %v", err)
+	}
 
 	log.Println("Server exited")
 }
@@ -240,24 +296,73 @@ func runOrderService() {
 	}
 	defer logger.Sync()
 
-	// Create order handler for gRPC
-	orderHandler := orders.NewHandler(orders.HandlerParams{
-		Logger: logger,
-	})
+	if err := loadTradingRules(cfg); err != nil {
+		log.Fatalf("Failed to load trading rules: %v", err)
+	}
 
-	// Start gRPC server for order service
-	grpcServer := grpc.NewServer()
-	orders_proto.RegisterOrderServiceServer(grpcServer, orderHandler)
+	// Run the order service and its gRPC handler until interrupted
+	app := newOrderApp(cfg, logger,
+		db.Module,
+		repositories.OrderRepositoryModule,
+		repositories.InstrumentRepositoryModule,
+		fx.Invoke(serveOrderGRPC),
+	)
+	app.Run()
+}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Service.GRPCPort))
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
-	}
+// newOrderApp builds the fx application behind order entry: the journaled
+// sequencer in front of matching and the order service with its gRPC
+// handler. The trading rules must be loaded before the app starts, as
+// recovery replays the journal against them.
+func newOrderApp(cfg *config.Config, logger *zap.Logger, opts ...fx.Option) *fx.App {
+	return fx.New(
+		fx.Supply(cfg, logger),
+		matching.SequencerModule,
+		orders.OrdersModule,
+		fx.Options(opts...),
+	)
+}
 
-	log.Printf("Order service listening on port %d", cfg.Service.GRPCPort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
-	}
+// serveOrderGRPC serves the order handler over gRPC for the app's lifetime.
+// Every call must carry a bearer token in its authorization metadata.
+func serveOrderGRPC(lifecycle fx.Lifecycle, cfg *config.Config, handler *orders.Handler) {
+	jwtService := newJWTService(cfg)
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(jwtService.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(jwtService.StreamServerInterceptor()),
+	)
+	orders_proto.RegisterOrderServiceServer(grpcServer, handler)
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Service.GRPCPort))
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+
+			log.Printf("Order service listening on port %d", cfg.Service.GRPCPort)
+			go func() {
+				if err := grpcServer.Serve(lis); err != nil {
+					log.Printf("Order service stopped serving: %v", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			grpcServer.GracefulStop()
+			return nil
+		},
+	})
+}
+
+// newJWTService creates the token service shared by the REST, WebSocket and
+// gRPC endpoints
+func newJWTService(cfg *config.Config) *auth.JWTService {
+	return auth.NewJWTService(auth.JWTConfig{
+		SecretKey:     cfg.JWT.SecretKey,
+		TokenDuration: cfg.JWT.TokenDuration,
+		Issuer:        cfg.JWT.Issuer,
+	})
 }
 
 func runRiskService() {
@@ -276,28 +381,48 @@ func runRiskService() {
 	}
 	defer logger.Sync()
 
-	// All services will be created in initializeTradingSystem
-
-	// Risk service will be created in initializeTradingSystem
+	if err := loadTradingRules(cfg); err != nil {
+		log.Fatalf("Failed to load trading rules: %v", err)
+	}
 
-	// Create risk handler for gRPC
-	riskHandler := risk.NewHandler(risk.HandlerParams{
-		Logger: logger,
-	})
+	// Run the risk service, its calculator, stress and margin engines and
+	// their gRPC handler until interrupted. Without a local order service
+	// margin calls are raised but positions are not liquidated.
+	app := fx.New(
+		fx.Supply(cfg, logger),
+		fx.Provide(order_matching.NewEngine),
+		risk.RiskManagementModule,
+		risk.RiskModule,
+		fx.Invoke(serveRiskGRPC),
+	)
+	app.Run()
+}
 
-	// Start gRPC server for risk service
+// serveRiskGRPC serves the risk handler over gRPC for the app's lifetime
+func serveRiskGRPC(lifecycle fx.Lifecycle, cfg *config.Config, handler *risk.Handler) {
 	grpcServer := grpc.NewServer()
-	riskpb.RegisterRiskServiceServer(grpcServer, riskHandler)
-
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Service.GRPCPort+1))
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
-	}
-
-	log.Printf("Risk service listening on port %d", cfg.Service.GRPCPort+1)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
-	}
+	riskpb.RegisterRiskServiceServer(grpcServer, handler)
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Service.GRPCPort+1))
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+
+			log.Printf("Risk service listening on port %d", cfg.Service.GRPCPort+1)
+			go func() {
+				if err := grpcServer.Serve(lis); err != nil {
+					log.Printf("Risk service stopped serving: %v", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			grpcServer.GracefulStop()
+			return nil
+		},
+	})
 }
 
 func runMarketDataService() {
@@ -374,20 +499,78 @@ func runWebSocketService() {
 	}
 }
 
-// initializeTradingSystem initializes all trading system components
-func initializeTradingSystem(cfg *config.Config) (*TradingSystem, error) {
+// loadTradingRules registers the exchange schedules, instruments and
+// self-trade prevention modes matching enforces
+func loadTradingRules(cfg *config.Config) error {
+	// Load exchange schedules before the instruments that reference them
+	if err := cfg.Exchanges.RegisterSchedules(types.Schedules); err != nil {
+		return fmt.Errorf("failed to load exchange schedules: %w", err)
+	}
+	if err := cfg.Trading.RegisterInstruments(types.Instruments); err != nil {
+		return fmt.Errorf("failed to load instruments: %w", err)
+	}
+	if err := cfg.Trading.SelfTradePrevention.Apply(types.SelfTradePolicies); err != nil {
+		return fmt.Errorf("failed to load self-trade prevention: %w", err)
+	}
+	return nil
+}
+
+// initializeTradingSystem initializes all trading system components around
+// matchingEngine. Circuit breaker halts are also sequenced through
+// sequencer so they are journaled with the orders they affect.
+func initializeTradingSystem(cfg *config.Config, matchingEngine *order_matching.Engine, sequencer *matching.Sequencer) (*TradingSystem, error) {
 	// Initialize logger
 	logger, err := zap.NewProduction()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize logger: %w", err)
 	}
 
-	// Initialize matching engine
-	matchingEngine := order_matching.NewEngine(logger)
+	if err := loadTradingRules(cfg); err != nil {
+		return nil, err
+	}
 
 	// Initialize risk engine
 	riskEngine := risk.NewRiskEngine(logger)
 
+	// Initialize circuit breakers; halts are enforced by both matching engines
+	circuitBreakers := risk.NewCircuitBreakerSystem(logger)
+	circuitBreakers.SetHaltHandlers(
+		func(symbol string, reason risk.HaltReason, message string) {
+			if symbol == "" {
+				matchingEngine.HaltMarket(string(reason))
+				if err := sequencer.HaltMarket(string(reason)); err != nil {
+					logger.Error("Failed to sequence market halt", zap.Error(err))
+				}
+				return
+			}
+			matchingEngine.Halt(symbol, string(reason))
+			if err := sequencer.Halt(symbol, string(reason)); err != nil {
+				logger.Error("Failed to sequence halt",
+					zap.String("symbol", symbol),
+					zap.Error(err))
+			}
+		},
+		func(symbol string) {
+			if symbol == "" {
+				matchingEngine.ResumeMarket()
+				if _, err := sequencer.ResumeMarket(); err != nil {
+					logger.Error("Failed to sequence market resumption", zap.Error(err))
+				}
+				return
+			}
+			if _, err := matchingEngine.Resume(symbol); err != nil {
+				logger.Warn("Failed to resume trading",
+					zap.String("symbol", symbol),
+					zap.Error(err))
+			}
+			if _, err := sequencer.Resume(symbol); err != nil {
+				logger.Warn("Failed to sequence resumption",
+					zap.String("symbol", symbol),
+					zap.Error(err))
+			}
+		},
+	)
+
 	// Initialize settlement processor
 	settlementProcessor := settlement.NewProcessor(logger)
 
@@ -403,6 +586,7 @@ func initializeTradingSystem(cfg *config.Config) (*TradingSystem, error) {
 	return &TradingSystem{
 		MatchingEngine:      matchingEngine,
 		RiskEngine:          riskEngine,
+		CircuitBreakers:     circuitBreakers,
 		SettlementProcessor: settlementProcessor,
 		ConnectivityManager: connManager,
 		ComplianceEngine:    complianceEngine,
@@ -415,6 +599,7 @@ func initializeTradingSystem(cfg *config.Config) (*TradingSystem, error) {
 type TradingSystem struct {
 	MatchingEngine      *order_matching.Engine
 	RiskEngine          *risk.RiskEngine
+	CircuitBreakers     *risk.CircuitBreakerSystem
 	SettlementProcessor *settlement.Processor
 	ConnectivityManager *connectivity.Manager
 	ComplianceEngine    *compliance.Engine
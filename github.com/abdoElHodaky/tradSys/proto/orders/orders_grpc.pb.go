@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_CreateOrder_FullMethodName  = "/orders.OrderService/CreateOrder"
-	OrderService_GetOrder_FullMethodName     = "/orders.OrderService/GetOrder"
-	OrderService_CancelOrder_FullMethodName  = "/orders.OrderService/CancelOrder"
-	OrderService_GetOrders_FullMethodName    = "/orders.OrderService/GetOrders"
-	OrderService_StreamOrders_FullMethodName = "/orders.OrderService/StreamOrders"
+	OrderService_CreateOrder_FullMethodName      = "/orders.OrderService/CreateOrder"
+	OrderService_GetOrder_FullMethodName         = "/orders.OrderService/GetOrder"
+	OrderService_CancelOrder_FullMethodName      = "/orders.OrderService/CancelOrder"
+	OrderService_AmendOrder_FullMethodName       = "/orders.OrderService/AmendOrder"
+	OrderService_GetOrders_FullMethodName        = "/orders.OrderService/GetOrders"
+	OrderService_StreamOrders_FullMethodName     = "/orders.OrderService/StreamOrders"
+	OrderService_CreateOrderGroup_FullMethodName = "/orders.OrderService/CreateOrderGroup"
+	OrderService_GetOrderGroup_FullMethodName    = "/orders.OrderService/GetOrderGroup"
+	OrderService_CancelOrderGroup_FullMethodName = "/orders.OrderService/CancelOrderGroup"
+	OrderService_MassCancel_FullMethodName       = "/orders.OrderService/MassCancel"
+	OrderService_SetKillSwitch_FullMethodName    = "/orders.OrderService/SetKillSwitch"
+	OrderService_GetKillSwitches_FullMethodName  = "/orders.OrderService/GetKillSwitches"
+	OrderService_GetOrderHistory_FullMethodName  = "/orders.OrderService/GetOrderHistory"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -38,10 +46,26 @@ type OrderServiceClient interface {
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	// CancelOrder cancels an order
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	// AmendOrder changes the price or quantity of a resting order
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	// GetOrders gets orders for a user
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
-	// StreamOrders streams orders for a user
+	// StreamOrders streams execution reports for a user's orders
 	StreamOrders(ctx context.Context, in *StreamOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderResponse], error)
+	// CreateOrderGroup places linked OCO, OTO or bracket orders
+	CreateOrderGroup(ctx context.Context, in *CreateOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error)
+	// GetOrderGroup gets an order group by ID
+	GetOrderGroup(ctx context.Context, in *GetOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error)
+	// CancelOrderGroup cancels every open order of a group
+	CancelOrderGroup(ctx context.Context, in *CancelOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error)
+	// MassCancel cancels every open order of a user, account, symbol or strategy
+	MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+	// SetKillSwitch blocks or lets through new orders of an entity
+	SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*KillSwitchResponse, error)
+	// GetKillSwitches lists the engaged kill switches
+	GetKillSwitches(ctx context.Context, in *GetKillSwitchesRequest, opts ...grpc.CallOption) (*GetKillSwitchesResponse, error)
+	// GetOrderHistory lists the status changes of an order with their reasons
+	GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error)
 }
 
 type orderServiceClient struct {
@@ -82,6 +106,16 @@ func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderReq
 	return out, nil
 }
 
+func (c *orderServiceClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetOrdersResponse)
@@ -111,6 +145,76 @@ func (c *orderServiceClient) StreamOrders(ctx context.Context, in *StreamOrdersR
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type OrderService_StreamOrdersClient = grpc.ServerStreamingClient[OrderResponse]
 
+func (c *orderServiceClient) CreateOrderGroup(ctx context.Context, in *CreateOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderGroupResponse)
+	err := c.cc.Invoke(ctx, OrderService_CreateOrderGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderGroup(ctx context.Context, in *GetOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderGroupResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetOrderGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelOrderGroup(ctx context.Context, in *CancelOrderGroupRequest, opts ...grpc.CallOption) (*OrderGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderGroupResponse)
+	err := c.cc.Invoke(ctx, OrderService_CancelOrderGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MassCancelResponse)
+	err := c.cc.Invoke(ctx, OrderService_MassCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*KillSwitchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KillSwitchResponse)
+	err := c.cc.Invoke(ctx, OrderService_SetKillSwitch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetKillSwitches(ctx context.Context, in *GetKillSwitchesRequest, opts ...grpc.CallOption) (*GetKillSwitchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKillSwitchesResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetKillSwitches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderHistoryResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetOrderHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -123,10 +227,26 @@ type OrderServiceServer interface {
 	GetOrder(context.Context, *GetOrderRequest) (*OrderResponse, error)
 	// CancelOrder cancels an order
 	CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error)
+	// AmendOrder changes the price or quantity of a resting order
+	AmendOrder(context.Context, *AmendOrderRequest) (*OrderResponse, error)
 	// GetOrders gets orders for a user
 	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
-	// StreamOrders streams orders for a user
+	// StreamOrders streams execution reports for a user's orders
 	StreamOrders(*StreamOrdersRequest, grpc.ServerStreamingServer[OrderResponse]) error
+	// CreateOrderGroup places linked OCO, OTO or bracket orders
+	CreateOrderGroup(context.Context, *CreateOrderGroupRequest) (*OrderGroupResponse, error)
+	// GetOrderGroup gets an order group by ID
+	GetOrderGroup(context.Context, *GetOrderGroupRequest) (*OrderGroupResponse, error)
+	// CancelOrderGroup cancels every open order of a group
+	CancelOrderGroup(context.Context, *CancelOrderGroupRequest) (*OrderGroupResponse, error)
+	// MassCancel cancels every open order of a user, account, symbol or strategy
+	MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error)
+	// SetKillSwitch blocks or lets through new orders of an entity
+	SetKillSwitch(context.Context, *SetKillSwitchRequest) (*KillSwitchResponse, error)
+	// GetKillSwitches lists the engaged kill switches
+	GetKillSwitches(context.Context, *GetKillSwitchesRequest) (*GetKillSwitchesResponse, error)
+	// GetOrderHistory lists the status changes of an order with their reasons
+	GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -146,12 +266,36 @@ func (UnimplementedOrderServiceServer) GetOrder(context.Context, *GetOrderReques
 func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) AmendOrder(context.Context, *AmendOrderRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
 func (UnimplementedOrderServiceServer) StreamOrders(*StreamOrdersRequest, grpc.ServerStreamingServer[OrderResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamOrders not implemented")
 }
+func (UnimplementedOrderServiceServer) CreateOrderGroup(context.Context, *CreateOrderGroupRequest) (*OrderGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrderGroup not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderGroup(context.Context, *GetOrderGroupRequest) (*OrderGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderGroup not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelOrderGroup(context.Context, *CancelOrderGroupRequest) (*OrderGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrderGroup not implemented")
+}
+func (UnimplementedOrderServiceServer) MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MassCancel not implemented")
+}
+func (UnimplementedOrderServiceServer) SetKillSwitch(context.Context, *SetKillSwitchRequest) (*KillSwitchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKillSwitch not implemented")
+}
+func (UnimplementedOrderServiceServer) GetKillSwitches(context.Context, *GetKillSwitchesRequest) (*GetKillSwitchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKillSwitches not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -227,6 +371,24 @@ func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOrdersRequest)
 	if err := dec(in); err != nil {
@@ -256,6 +418,132 @@ func _OrderService_StreamOrders_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type OrderService_StreamOrdersServer = grpc.ServerStreamingServer[OrderResponse]
 
+func _OrderService_CreateOrderGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateOrderGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateOrderGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateOrderGroup(ctx, req.(*CreateOrderGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrderGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderGroup(ctx, req.(*GetOrderGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrderGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrderGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelOrderGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrderGroup(ctx, req.(*CancelOrderGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_MassCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MassCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).MassCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_MassCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).MassCancel(ctx, req.(*MassCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetKillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKillSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetKillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SetKillSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetKillSwitch(ctx, req.(*SetKillSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetKillSwitches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKillSwitchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetKillSwitches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetKillSwitches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetKillSwitches(ctx, req.(*GetKillSwitchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, req.(*GetOrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,10 +563,42 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _OrderService_CancelOrder_Handler,
 		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _OrderService_AmendOrder_Handler,
+		},
 		{
 			MethodName: "GetOrders",
 			Handler:    _OrderService_GetOrders_Handler,
 		},
+		{
+			MethodName: "CreateOrderGroup",
+			Handler:    _OrderService_CreateOrderGroup_Handler,
+		},
+		{
+			MethodName: "GetOrderGroup",
+			Handler:    _OrderService_GetOrderGroup_Handler,
+		},
+		{
+			MethodName: "CancelOrderGroup",
+			Handler:    _OrderService_CancelOrderGroup_Handler,
+		},
+		{
+			MethodName: "MassCancel",
+			Handler:    _OrderService_MassCancel_Handler,
+		},
+		{
+			MethodName: "SetKillSwitch",
+			Handler:    _OrderService_SetKillSwitch_Handler,
+		},
+		{
+			MethodName: "GetKillSwitches",
+			Handler:    _OrderService_GetKillSwitches_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderService_GetOrderHistory_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
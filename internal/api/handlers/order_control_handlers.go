@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OrderControlHandlers handles the mass cancel and kill switch endpoints
+// used by risk officers. Users with a control role may act on any entity;
+// other users may only mass cancel their own orders.
+type OrderControlHandlers struct {
+	orderService *orders.OrderService
+	logger       *zap.Logger
+	controlRoles []string
+}
+
+// NewOrderControlHandlers creates a new order control handlers instance for
+// the roles allowed to act on any entity, by default auth.ControlRoles
+func NewOrderControlHandlers(orderService *orders.OrderService, logger *zap.Logger, controlRoles ...string) *OrderControlHandlers {
+	if len(controlRoles) == 0 {
+		controlRoles = auth.ControlRoles
+	}
+	return &OrderControlHandlers{
+		orderService: orderService,
+		logger:       logger,
+		controlRoles: controlRoles,
+	}
+}
+
+// MassCancelRequest represents the request body for a mass cancel
+type MassCancelRequest struct {
+	UserID    string `json:"user_id"`
+	AccountID string `json:"account_id"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`
+	Strategy  string `json:"strategy"`
+	Reason    string `json:"reason"`
+}
+
+// KillSwitchRequest represents the request body for enabling a kill switch
+type KillSwitchRequest struct {
+	Scope            string `json:"scope" binding:"required"`
+	Value            string `json:"value" binding:"required"`
+	Reason           string `json:"reason"`
+	CancelOpenOrders bool   `json:"cancel_open_orders"`
+}
+
+// KillSwitchResponse represents an engaged kill switch
+type KillSwitchResponse struct {
+	Scope             string    `json:"scope"`
+	Value             string    `json:"value"`
+	Actor             string    `json:"actor"`
+	Reason            string    `json:"reason"`
+	EnabledAt         time.Time `json:"enabled_at"`
+	CancelledOrderIDs []string  `json:"cancelled_order_ids,omitempty"`
+}
+
+// RegisterRoutes registers the order control endpoints on router
+func (h *OrderControlHandlers) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/orders/mass-cancel", h.MassCancel)
+	router.GET("/kill-switches", h.GetKillSwitches)
+	router.POST("/kill-switches", h.EnableKillSwitch)
+	router.DELETE("/kill-switches/:scope/:value", h.DisableKillSwitch)
+}
+
+// MassCancel cancels every open order matching the filters. Without a
+// control role only the caller's own orders are cancelled.
+// @Summary Mass cancel orders
+// @Description Cancel every open order of a user, account, symbol or strategy, optionally on one side
+// @Tags OrderControl
+// @Accept json
+// @Produce json
+// @Param request body MassCancelRequest true "Mass cancel request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/orders/mass-cancel [post]
+func (h *OrderControlHandlers) MassCancel(c *gin.Context) {
+	var req MassCancelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid mass cancel request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !auth.HasRole(c.GetString("role"), h.controlRoles...) {
+		req.UserID = c.GetString("userID")
+	}
+
+	cancelled, err := h.orderService.MassCancel(c.Request.Context(), &orders.MassCancelRequest{
+		UserID:       req.UserID,
+		AccountGroup: req.AccountID,
+		Symbol:       req.Symbol,
+		Side:         orders.OrderSide(req.Side),
+		Strategy:     req.Strategy,
+		Actor:        c.GetString("username"),
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]string, 0, len(cancelled))
+	for _, order := range cancelled {
+		ids = append(ids, order.ID)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"cancelled_order_ids": ids,
+		"total":               len(ids),
+	})
+}
+
+// GetKillSwitches lists the engaged kill switches
+// @Summary List kill switches
+// @Description List the engaged kill switches, oldest first
+// @Tags OrderControl
+// @Produce json
+// @Success 200 {array} KillSwitchResponse
+// @Router /api/v1/kill-switches [get]
+func (h *OrderControlHandlers) GetKillSwitches(c *gin.Context) {
+	killSwitches := h.orderService.GetKillSwitches()
+	rsp := make([]KillSwitchResponse, 0, len(killSwitches))
+	for _, killSwitch := range killSwitches {
+		rsp = append(rsp, killSwitchResponse(killSwitch))
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// EnableKillSwitch blocks new orders of an entity
+// @Summary Enable kill switch
+// @Description Block new orders of a user, account, symbol or strategy until the switch is disabled
+// @Tags OrderControl
+// @Accept json
+// @Produce json
+// @Param request body KillSwitchRequest true "Kill switch request"
+// @Success 200 {object} KillSwitchResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /api/v1/kill-switches [post]
+func (h *OrderControlHandlers) EnableKillSwitch(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	var req KillSwitchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid kill switch request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	killSwitch, cancelled, err := h.orderService.EnableKillSwitch(c.Request.Context(), &orders.KillSwitchRequest{
+		Scope:            orders.KillSwitchScope(req.Scope),
+		Value:            req.Value,
+		Actor:            c.GetString("username"),
+		Reason:           req.Reason,
+		CancelOpenOrders: req.CancelOpenOrders,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsp := killSwitchResponse(killSwitch)
+	for _, order := range cancelled {
+		rsp.CancelledOrderIDs = append(rsp.CancelledOrderIDs, order.ID)
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DisableKillSwitch lets an entity place orders again
+// @Summary Disable kill switch
+// @Description Let a user, account, symbol or strategy place orders again
+// @Tags OrderControl
+// @Produce json
+// @Param scope path string true "Kill switch scope"
+// @Param value path string true "Entity ID"
+// @Param reason query string false "Reason"
+// @Success 200 {object} KillSwitchResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/kill-switches/{scope}/{value} [delete]
+func (h *OrderControlHandlers) DisableKillSwitch(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	killSwitch, err := h.orderService.DisableKillSwitch(c.Request.Context(),
+		orders.KillSwitchScope(c.Param("scope")), c.Param("value"),
+		c.GetString("username"), c.Query("reason"))
+	if errors.Is(err, orders.ErrKillSwitchNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, killSwitchResponse(killSwitch))
+}
+
+// authorize rejects callers without a control role
+func (h *OrderControlHandlers) authorize(c *gin.Context) bool {
+	if auth.HasRole(c.GetString("role"), h.controlRoles...) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	return false
+}
+
+// killSwitchResponse converts a kill switch to its response
+func killSwitchResponse(killSwitch *orders.KillSwitch) KillSwitchResponse {
+	return KillSwitchResponse{
+		Scope:     string(killSwitch.Scope),
+		Value:     killSwitch.Value,
+		Actor:     killSwitch.Actor,
+		Reason:    killSwitch.Reason,
+		EnabledAt: killSwitch.EnabledAt,
+	}
+}
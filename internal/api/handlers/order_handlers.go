@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OrderHandlers handles order entry backed by the order service
+type OrderHandlers struct {
+	orderService *orders.OrderService
+	logger       *zap.Logger
+	supportRoles []string
+}
+
+// NewOrderHandlers creates a new order handlers instance. Users with one of
+// supportRoles, by default auth.SupportRoles, may look up any user's order
+// history.
+func NewOrderHandlers(orderService *orders.OrderService, logger *zap.Logger, supportRoles ...string) *OrderHandlers {
+	if len(supportRoles) == 0 {
+		supportRoles = auth.SupportRoles
+	}
+	return &OrderHandlers{
+		orderService: orderService,
+		logger:       logger,
+		supportRoles: supportRoles,
+	}
+}
+
+// PlaceOrderRequest represents the request body for placing an order
+type PlaceOrderRequest struct {
+	ClientOrderID string            `json:"client_order_id"`
+	AccountID     string            `json:"account_id"`
+	Symbol        string            `json:"symbol" binding:"required"`
+	Side          string            `json:"side" binding:"required"`
+	Type          string            `json:"type" binding:"required"`
+	Quantity      types.Decimal     `json:"quantity" binding:"required"`
+	Price         types.Decimal     `json:"price"`
+	StopPrice     types.Decimal     `json:"stop_price"`
+	TimeInForce   string            `json:"time_in_force"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	Tags          map[string]string `json:"tags"`
+}
+
+// OrderResponse represents an order placed through the order service
+type OrderResponse struct {
+	ID             string        `json:"id"`
+	ClientOrderID  string        `json:"client_order_id,omitempty"`
+	Symbol         string        `json:"symbol"`
+	Side           string        `json:"side"`
+	Type           string        `json:"type"`
+	Price          types.Decimal `json:"price"`
+	Quantity       types.Decimal `json:"quantity"`
+	FilledQuantity types.Decimal `json:"filled_quantity"`
+	Status         string        `json:"status"`
+	RejectReason   string        `json:"reject_reason,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Replayed       bool          `json:"replayed,omitempty"`
+}
+
+// OrderTransitionResponse represents one status change of an order
+type OrderTransitionResponse struct {
+	Sequence       int           `json:"sequence"`
+	FromStatus     string        `json:"from_status,omitempty"`
+	ToStatus       string        `json:"to_status"`
+	Reason         string        `json:"reason"`
+	RejectReason   string        `json:"reject_reason,omitempty"`
+	FilledQuantity types.Decimal `json:"filled_quantity"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
+
+// RegisterRoutes registers the order entry endpoints on router
+func (h *OrderHandlers) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/orders", h.PlaceOrder)
+	router.GET("/orders/:id/history", h.GetOrderHistory)
+}
+
+// PlaceOrder places an order for the authenticated user
+// @Summary Place order
+// @Description Place an order. Retrying with the same client_order_id returns the original order instead of placing a new one.
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param request body PlaceOrderRequest true "Order request"
+// @Success 201 {object} OrderResponse
+// @Success 200 {object} OrderResponse "Replayed order"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /api/v1/orders [post]
+func (h *OrderHandlers) PlaceOrder(c *gin.Context) {
+	var req PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid order request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timeInForce := orders.TimeInForceGTC
+	if req.TimeInForce != "" {
+		timeInForce = orders.TimeInForce(req.TimeInForce)
+	}
+
+	order, replayed, err := h.orderService.PlaceOrder(c.Request.Context(), &orders.OrderRequest{
+		UserID:        c.GetString("userID"),
+		AccountGroup:  req.AccountID,
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		Side:          orders.OrderSide(req.Side),
+		Type:          orders.OrderType(req.Type),
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+		Quantity:      req.Quantity,
+		TimeInForce:   timeInForce,
+		ExpiresAt:     req.ExpiresAt,
+		Tags:          req.Tags,
+	})
+	switch {
+	case errors.Is(err, orders.ErrKillSwitchEngaged):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	case err != nil && order != nil:
+		h.logger.Error("Failed to place order", zap.String("order_id", order.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsp := OrderResponse{
+		ID:             order.ID,
+		ClientOrderID:  order.ClientOrderID,
+		Symbol:         order.Symbol,
+		Side:           string(order.Side),
+		Type:           string(order.Type),
+		Price:          order.Price,
+		Quantity:       order.Quantity,
+		FilledQuantity: order.FilledQuantity,
+		Status:         string(order.Status),
+		RejectReason:   string(order.RejectReason),
+		CreatedAt:      order.CreatedAt,
+		Replayed:       replayed,
+	}
+	if replayed {
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+// GetOrderHistory lists the status changes of an order
+// @Summary Get order history
+// @Description List the status changes of an order with the reason code for each, oldest first. Support staff may look up any user's order.
+// @Tags Order
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} OrderTransitionResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/orders/{id}/history [get]
+func (h *OrderHandlers) GetOrderHistory(c *gin.Context) {
+	userID := c.GetString("userID")
+	if auth.HasRole(c.GetString("role"), h.supportRoles...) {
+		userID = ""
+	}
+
+	history, err := h.orderService.GetOrderHistory(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, orders.ErrOrderNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get order history", zap.String("order_id", c.Param("id")), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsp := make([]OrderTransitionResponse, 0, len(history))
+	for _, change := range history {
+		rsp = append(rsp, OrderTransitionResponse{
+			Sequence:       change.Sequence,
+			FromStatus:     string(change.FromStatus),
+			ToStatus:       string(change.ToStatus),
+			Reason:         string(change.Reason),
+			RejectReason:   string(change.RejectReason),
+			FilledQuantity: change.FilledQuantity,
+			Timestamp:      change.Timestamp,
+		})
+	}
+	c.JSON(http.StatusOK, rsp)
+}
@@ -72,12 +72,12 @@ func SetupRoutes(router *gin.RouterGroup, tradingSystem TradingSystemInterface)
 func createOrderHandler(ts TradingSystemInterface) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			Symbol    string  `json:"symbol" binding:"required"`
-			Side      string  `json:"side" binding:"required"`
-			Type      string  `json:"type" binding:"required"`
-			Quantity  float64 `json:"quantity" binding:"required"`
-			Price     float64 `json:"price"`
-			StopPrice float64 `json:"stop_price"`
+			Symbol    string        `json:"symbol" binding:"required"`
+			Side      string        `json:"side" binding:"required"`
+			Type      string        `json:"type" binding:"required"`
+			Quantity  types.Decimal `json:"quantity" binding:"required"`
+			Price     types.Decimal `json:"price"`
+			StopPrice types.Decimal `json:"stop_price"`
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
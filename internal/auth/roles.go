@@ -0,0 +1,18 @@
+package auth
+
+// ControlRoles are the roles allowed to mass cancel any user's orders and
+// to enable or disable kill switches
+var ControlRoles = []string{"admin", "risk"}
+
+// SupportRoles are the roles allowed to look up any user's order history
+var SupportRoles = []string{"admin", "support"}
+
+// HasRole reports whether role is one of roles
+func HasRole(role string, roles ...string) bool {
+	for _, allowed := range roles {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
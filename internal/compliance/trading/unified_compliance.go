@@ -568,7 +568,7 @@ func (r *RuleEngine) checkRule(rule ComplianceRule, order *types.Order, userID s
 	switch rule.RuleType {
 	case RuleTypeOrderSize:
 		maxSize, ok := rule.Parameters["max_order_size"].(float64)
-		if ok && order.Quantity > maxSize {
+		if ok && order.Quantity.GreaterThan(types.QuantityFromFloat(order.Symbol, maxSize)) {
 			return &ComplianceViolation{
 				ID:          fmt.Sprintf("violation_%d", time.Now().UnixNano()),
 				RuleID:      rule.ID,
@@ -576,7 +576,7 @@ func (r *RuleEngine) checkRule(rule ComplianceRule, order *types.Order, userID s
 				UserID:      userID,
 				Symbol:      order.Symbol,
 				Severity:    rule.Severity,
-				Description: fmt.Sprintf("Order size %.2f exceeds maximum allowed size %.2f", order.Quantity, maxSize),
+				Description: fmt.Sprintf("Order size %s exceeds maximum allowed size %.2f", order.Quantity, maxSize),
 				Details: map[string]interface{}{
 					"order_size": order.Quantity,
 					"max_size":   maxSize,
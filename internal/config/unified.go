@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/trading/memory"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 )
 
 // UnifiedConfig represents the main application configuration
@@ -113,6 +116,125 @@ type TradingConfig struct {
 	OrderBook  OrderBookConfig     `yaml:"order_book"`
 	Execution  ExecutionConfig     `yaml:"execution"`
 	Settlement SettlementConfig    `yaml:"settlement"`
+	// Instruments holds per-symbol reference data enforced by matching
+	Instruments []InstrumentConfig `yaml:"instruments"`
+	// SelfTradePrevention configures how matching treats orders that would
+	// trade with the same user or account group
+	SelfTradePrevention SelfTradePreventionConfig `yaml:"self_trade_prevention"`
+	// Sequencer configures the journaled sequencer in front of matching
+	Sequencer SequencerConfig `yaml:"sequencer"`
+}
+
+// SequencerConfig contains the sequencer's partitioning, journal and
+// snapshot settings
+type SequencerConfig struct {
+	// Partitions is the number of single-threaded engines symbols are
+	// hashed to
+	Partitions int `yaml:"partitions" default:"4"`
+	// DataDir holds the journal and the snapshot
+	DataDir string `yaml:"data_dir" default:"data/sequencer"`
+	// SyncJournal fsyncs every journal record before the command returns
+	SyncJournal bool `yaml:"sync_journal" default:"true"`
+	// SnapshotInterval is how often the books are snapshotted and the
+	// journal compacted
+	SnapshotInterval time.Duration `yaml:"snapshot_interval" default:"1m"`
+}
+
+// SelfTradePreventionConfig contains the default self-trade prevention mode
+// and overrides keyed by account group and user ID
+type SelfTradePreventionConfig struct {
+	DefaultMode   types.SelfTradePrevention            `yaml:"default_mode"`
+	AccountGroups map[string]types.SelfTradePrevention `yaml:"account_groups"`
+	Users         map[string]types.SelfTradePrevention `yaml:"users"`
+}
+
+// Apply loads the configured modes into a self-trade prevention policy
+func (c SelfTradePreventionConfig) Apply(policy *types.SelfTradePolicy) error {
+	if err := policy.SetDefault(c.DefaultMode); err != nil {
+		return err
+	}
+	for group, mode := range c.AccountGroups {
+		if err := policy.SetGroupMode(group, mode); err != nil {
+			return fmt.Errorf("account group %q: %w", group, err)
+		}
+	}
+	for userID, mode := range c.Users {
+		if err := policy.SetUserMode(userID, mode); err != nil {
+			return fmt.Errorf("user %q: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// InstrumentConfig contains reference data for a single instrument.
+// Zero-valued limits are not enforced.
+type InstrumentConfig struct {
+	Symbol         string          `yaml:"symbol"`
+	AssetType      types.AssetType `yaml:"asset_type"`
+	Currency       string          `yaml:"currency"`
+	Exchange       string          `yaml:"exchange"`
+	TradingEnabled *bool           `yaml:"trading_enabled"`
+	TickSize       types.Decimal   `yaml:"tick_size"`
+	LotSize        types.Decimal   `yaml:"lot_size"`
+	MinQuantity    types.Decimal   `yaml:"min_quantity"`
+	MaxQuantity    types.Decimal   `yaml:"max_quantity"`
+	MinNotional    types.Decimal   `yaml:"min_notional"`
+	MaxNotional    types.Decimal   `yaml:"max_notional"`
+	MinPrice       types.Decimal   `yaml:"min_price"`
+	MaxPrice       types.Decimal   `yaml:"max_price"`
+	DynamicBand    types.Decimal   `yaml:"dynamic_band"`
+	// StopTrigger is LAST, MARK or BID_ASK; empty means LAST
+	StopTrigger types.StopTrigger `yaml:"stop_trigger"`
+	// MatchingAlgorithm is PRICE_TIME, PRO_RATA or PRO_RATA_TOP_ORDER;
+	// empty means PRICE_TIME
+	MatchingAlgorithm types.MatchingAlgorithm `yaml:"matching_algorithm"`
+	// MinAllocation is the smallest pro-rata share a resting order receives
+	MinAllocation types.Decimal `yaml:"min_allocation"`
+	// IcebergPeakVariance randomises each displayed iceberg slice by up to
+	// this fraction of the display quantity
+	IcebergPeakVariance types.Decimal `yaml:"iceberg_peak_variance"`
+	// MidpointHalfTick lets midpoint pegs trade at half-tick prices
+	MidpointHalfTick bool `yaml:"midpoint_half_tick"`
+}
+
+// Instrument converts the configuration to instrument reference data
+func (c InstrumentConfig) Instrument() *types.Instrument {
+	enabled := true
+	if c.TradingEnabled != nil {
+		enabled = *c.TradingEnabled
+	}
+
+	return &types.Instrument{
+		Symbol:              c.Symbol,
+		AssetType:           c.AssetType,
+		Currency:            c.Currency,
+		TradingEnabled:      enabled,
+		TickSize:            c.TickSize,
+		LotSize:             c.LotSize,
+		MinQuantity:         c.MinQuantity,
+		MaxQuantity:         c.MaxQuantity,
+		MinNotional:         c.MinNotional,
+		MaxNotional:         c.MaxNotional,
+		MinPrice:            c.MinPrice,
+		MaxPrice:            c.MaxPrice,
+		DynamicBand:         c.DynamicBand,
+		Schedule:            types.ScheduleForExchange(c.Exchange),
+		StopTrigger:         c.StopTrigger,
+		MatchingAlgorithm:   c.MatchingAlgorithm,
+		MinAllocation:       c.MinAllocation,
+		IcebergPeakVariance: c.IcebergPeakVariance,
+		MidpointHalfTick:    c.MidpointHalfTick,
+	}
+}
+
+// RegisterInstruments loads the configured instruments into a registry
+func (c TradingConfig) RegisterInstruments(registry *types.InstrumentRegistry) error {
+	for _, instrumentConfig := range c.Instruments {
+		if err := registry.Register(instrumentConfig.Instrument()); err != nil {
+			return fmt.Errorf("instrument %q: %w", instrumentConfig.Symbol, err)
+		}
+	}
+	return nil
 }
 
 // TradingEngineConfig contains trading engine specific settings
@@ -200,6 +322,8 @@ type ExchangesConfig struct {
 	Binance  ExchangeConfig `yaml:"binance"`
 	Coinbase ExchangeConfig `yaml:"coinbase"`
 	Kraken   ExchangeConfig `yaml:"kraken"`
+	EGX      ExchangeConfig `yaml:"egx"`
+	ADX      ExchangeConfig `yaml:"adx"`
 }
 
 // ExchangeConfig contains individual exchange settings
@@ -209,6 +333,107 @@ type ExchangeConfig struct {
 	APISecret string `yaml:"api_secret"`
 	Sandbox   bool   `yaml:"sandbox" default:"true"`
 	RateLimit int    `yaml:"rate_limit" default:"10"`
+	// Session is the daily trading session; nil means a continuous market
+	Session *SessionConfig `yaml:"session"`
+}
+
+// SessionConfig contains the daily trading session of an exchange. Times
+// are HH:MM in the exchange time zone and holidays are YYYY-MM-DD dates.
+type SessionConfig struct {
+	Timezone string `yaml:"timezone"`
+	// UTCOffset is used when the time zone database is unavailable
+	UTCOffset   time.Duration `yaml:"utc_offset"`
+	PreOpen     string        `yaml:"pre_open"`
+	Open        string        `yaml:"open"`
+	PreClose    string        `yaml:"pre_close"`
+	Close       string        `yaml:"close"`
+	TradingDays []string      `yaml:"trading_days"`
+	Holidays    []string      `yaml:"holidays"`
+}
+
+// weekdays maps configured day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"SUN": time.Sunday, "MON": time.Monday, "TUE": time.Tuesday, "WED": time.Wednesday,
+	"THU": time.Thursday, "FRI": time.Friday, "SAT": time.Saturday,
+}
+
+// sessionTime parses an HH:MM time of day as an offset from midnight; an
+// empty value means the phase is not used
+func sessionTime(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid session time %q", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Schedule converts the session configuration to the trading schedule of
+// an exchange
+func (c SessionConfig) Schedule(exchange string) (*types.TradingSchedule, error) {
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		if c.UTCOffset == 0 {
+			return nil, fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+		}
+		location = time.FixedZone(c.Timezone, int(c.UTCOffset.Seconds()))
+	}
+
+	schedule := &types.TradingSchedule{
+		Exchange: exchange,
+		Location: location,
+	}
+	if schedule.PreOpen, err = sessionTime(c.PreOpen); err != nil {
+		return nil, err
+	}
+	if schedule.Open, err = sessionTime(c.Open); err != nil {
+		return nil, err
+	}
+	if schedule.PreClose, err = sessionTime(c.PreClose); err != nil {
+		return nil, err
+	}
+	if schedule.Close, err = sessionTime(c.Close); err != nil {
+		return nil, err
+	}
+	for _, name := range c.TradingDays {
+		day, exists := weekdays[strings.ToUpper(name)]
+		if !exists {
+			return nil, fmt.Errorf("invalid trading day %q", name)
+		}
+		schedule.TradingDays = append(schedule.TradingDays, day)
+	}
+	for _, value := range c.Holidays {
+		holiday, err := time.ParseInLocation("2006-01-02", value, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q", value)
+		}
+		schedule.Holidays = append(schedule.Holidays, holiday)
+	}
+	return schedule, nil
+}
+
+// RegisterSchedules loads the session of every exchange that has one into
+// a schedule registry. Schedules must be registered before instruments,
+// which resolve their exchange schedule when converted.
+func (c ExchangesConfig) RegisterSchedules(registry *types.ScheduleRegistry) error {
+	for exchange, exchangeConfig := range map[string]ExchangeConfig{
+		"EGX": c.EGX,
+		"ADX": c.ADX,
+	} {
+		if exchangeConfig.Session == nil {
+			continue
+		}
+		schedule, err := exchangeConfig.Session.Schedule(exchange)
+		if err != nil {
+			return fmt.Errorf("exchange %q: %w", exchange, err)
+		}
+		if err := registry.Register(schedule); err != nil {
+			return fmt.Errorf("exchange %q: %w", exchange, err)
+		}
+	}
+	return nil
 }
 
 // WebSocketConfig contains WebSocket server settings
@@ -354,6 +579,28 @@ func LoadConfig(configPath string) (*UnifiedConfig, error) {
 	config.Resilience.RateLimitingEnabled = true
 	config.Registry.Enabled = false
 	config.Registry.Type = "consul"
+	config.Trading.Sequencer.Partitions = 4
+	config.Trading.Sequencer.DataDir = "data/sequencer"
+	config.Trading.Sequencer.SyncJournal = true
+	config.Trading.Sequencer.SnapshotInterval = time.Minute
+	config.Exchanges.EGX.Session = &SessionConfig{
+		Timezone:    "Africa/Cairo",
+		UTCOffset:   2 * time.Hour,
+		PreOpen:     "09:30",
+		Open:        "10:00",
+		PreClose:    "14:20",
+		Close:       "14:30",
+		TradingDays: []string{"SUN", "MON", "TUE", "WED", "THU"},
+	}
+	config.Exchanges.ADX.Session = &SessionConfig{
+		Timezone:    "Asia/Dubai",
+		UTCOffset:   4 * time.Hour,
+		PreOpen:     "09:30",
+		Open:        "10:00",
+		PreClose:    "14:45",
+		Close:       "15:00",
+		TradingDays: []string{"MON", "TUE", "WED", "THU", "FRI"},
+	}
 
 	GlobalConfig = config
 	return config, nil
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangesConfig_RegisterSchedules(t *testing.T) {
+	cfg, err := LoadConfig("config/tradsys.yaml")
+	require.NoError(t, err)
+
+	registry := types.NewScheduleRegistry()
+	require.NoError(t, cfg.Exchanges.RegisterSchedules(registry))
+
+	egx, exists := registry.Get("EGX")
+	require.True(t, exists)
+	assert.Equal(t, 9*time.Hour+30*time.Minute, egx.PreOpen)
+	assert.Equal(t, 10*time.Hour, egx.Open)
+	assert.Equal(t, 14*time.Hour+20*time.Minute, egx.PreClose)
+	assert.Equal(t, 14*time.Hour+30*time.Minute, egx.Close)
+	assert.NotContains(t, egx.TradingDays, time.Friday)
+
+	adx, exists := registry.Get("ADX")
+	require.True(t, exists)
+	assert.Equal(t, 15*time.Hour, adx.Close)
+	assert.Equal(t, []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+	}, adx.TradingDays)
+
+	_, exists = registry.Get("BINANCE")
+	assert.False(t, exists)
+}
+
+func TestSessionConfig_Schedule(t *testing.T) {
+	session := SessionConfig{
+		Timezone:    "Africa/Cairo",
+		UTCOffset:   2 * time.Hour,
+		Open:        "10:00",
+		Close:       "14:30",
+		TradingDays: []string{"sun", "MON"},
+		Holidays:    []string{"2026-10-04"},
+	}
+	schedule, err := session.Schedule("EGX")
+	require.NoError(t, err)
+	assert.Zero(t, schedule.PreOpen)
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Monday}, schedule.TradingDays)
+	assert.False(t, schedule.IsTradingDay(time.Date(2026, time.October, 4, 12, 0, 0, 0, schedule.Location)))
+	assert.True(t, schedule.IsTradingDay(time.Date(2026, time.October, 5, 12, 0, 0, 0, schedule.Location)))
+
+	session.Close = "2:30pm"
+	_, err = session.Schedule("EGX")
+	assert.Error(t, err)
+
+	session.Close, session.TradingDays = "14:30", []string{"FUNDAY"}
+	_, err = session.Schedule("EGX")
+	assert.Error(t, err)
+}
@@ -0,0 +1,289 @@
+package order_matching
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// collectAuctionOrder queues an order during an auction call without
+// matching it and publishes the new indicative price
+func (ob *OrderBook) collectAuctionOrder(order *Order) error {
+	if err := order.ValidateForPhase(ob.Phase); err != nil {
+		order.Status = OrderStatusRejected
+		return err
+	}
+
+	order.Status = OrderStatusNew
+	ob.Orders[order.ID] = order
+	ob.rest(order)
+	if !order.IsStop() {
+		ob.publishIndicative()
+	}
+	return nil
+}
+
+// IndicativePrice returns the price and volume the book would uncross at
+// now. It returns false if the book does not cross.
+func (ob *OrderBook) IndicativePrice() (types.AuctionResult, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.equilibrium()
+}
+
+// equilibrium computes the auction price of the resting limit orders
+func (ob *OrderBook) equilibrium() (types.AuctionResult, bool) {
+	return types.EquilibriumPrice(ob.auctionLevels(ob.Bids, ob.HiddenBids), ob.auctionLevels(ob.Asks, ob.HiddenAsks), ob.LastPrice)
+}
+
+// publishIndicative emits the indicative auction price and volume; both are
+// zero while the book does not cross
+func (ob *OrderBook) publishIndicative() {
+	if ob.eventHandler == nil {
+		return
+	}
+	result, _ := ob.equilibrium()
+	ob.eventHandler(&MatchingEvent{
+		Type:      EventIndicativePrice,
+		Symbol:    ob.Symbol,
+		Price:     result.Price,
+		Quantity:  result.Volume,
+		Timestamp: time.Now(),
+	})
+}
+
+// SetPhase moves the book to a trading phase. Leaving an auction call
+// uncrosses the book at its equilibrium price first, and entering
+// continuous trading releases the stops the auction price elected. A halted
+// book records the phase and enters it when trading resumes.
+func (ob *OrderBook) SetPhase(phase types.TradingPhase) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.schedulePhase = phase
+	return ob.updatePhase()
+}
+
+// updatePhase moves the book to its scheduled phase, or to the halted phase
+// while a symbol or market-wide halt is in force
+func (ob *OrderBook) updatePhase() []*Trade {
+	phase := ob.schedulePhase
+	if ob.halted || ob.marketHalted {
+		phase = types.TradingPhaseHalted
+	}
+
+	previous := ob.Phase
+	if phase == previous {
+		return nil
+	}
+	ob.Phase = phase
+
+	ob.logger.Info("Trading phase changed",
+		zap.String("symbol", ob.Symbol),
+		zap.String("from", string(previous)),
+		zap.String("to", string(phase)))
+
+	switch {
+	case phase == types.TradingPhaseHalted:
+		ob.emitStatus(EventTradingHalted)
+	case previous == types.TradingPhaseHalted:
+		ob.emitStatus(EventTradingResumed)
+	}
+
+	var trades []*Trade
+	if previous.Uncrosses(phase) {
+		trades = ob.uncross()
+	}
+
+	if phase.IsCall() {
+		ob.publishIndicative()
+	} else {
+		trades = append(trades, ob.triggerStops()...)
+		ob.repricePegs()
+	}
+	return trades
+}
+
+// uncross executes the auction: the best bids and asks trade in priority
+// order at the single equilibrium price until its executable volume is
+// filled. Hidden orders trade after the displayed orders at their price
+// and iceberg slices are refreshed as they fill. Self-trade prevention and
+// expiry apply to continuous matching only.
+func (ob *OrderBook) uncross() []*Trade {
+	result, crossed := ob.equilibrium()
+	if !crossed {
+		return nil
+	}
+
+	var trades []*Trade
+	remaining := result.Volume
+	for remaining.IsPositive() {
+		bids, buy := ob.best(ob.Bids, ob.HiddenBids)
+		asks, sell := ob.best(ob.Asks, ob.HiddenAsks)
+		if buy == nil || sell == nil {
+			break
+		}
+
+		quantity := types.MinDecimal(remaining, types.MinDecimal(buy.RemainingQuantity(), sell.RemainingQuantity()))
+		trades = append(trades, ob.executeAuctionTrade(buy, sell, result.Price, quantity))
+		remaining = remaining.Sub(quantity)
+
+		ob.settleAuctionOrder(bids, buy, quantity)
+		ob.settleAuctionOrder(asks, sell, quantity)
+	}
+	ob.LastPrice = result.Price
+
+	ob.logger.Info("Auction uncrossed",
+		zap.String("symbol", ob.Symbol),
+		zap.Stringer("price", result.Price),
+		zap.Stringer("volume", result.Volume),
+		zap.Stringer("surplus", result.Surplus))
+
+	if ob.eventHandler != nil {
+		ob.eventHandler(&MatchingEvent{
+			Type:      EventAuctionUncrossed,
+			Symbol:    ob.Symbol,
+			Price:     result.Price,
+			Quantity:  result.Volume,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return trades
+}
+
+// settleAuctionOrder updates the top order of a heap after it traded in the
+// uncross, removing it from the book once filled. An iceberg slice passes
+// the fill to its parent and is refreshed.
+func (ob *OrderBook) settleAuctionOrder(h *OrderHeap, order *Order, quantity Decimal) {
+	if order.IsIcebergChild {
+		ob.fillSlice(h, order, quantity)
+		if parent, exists := ob.Orders[order.ParentOrderID]; exists {
+			if parent.Status == OrderStatusFilled {
+				delete(ob.Orders, parent.ID)
+			}
+			ob.emit(EventOrderFilled, parent)
+		}
+		return
+	}
+
+	if order.IsFilled() {
+		heap.Pop(h)
+		delete(ob.Orders, order.ID)
+		order.Status = OrderStatusFilled
+	} else {
+		order.Status = OrderStatusPartiallyFilled
+	}
+	ob.emit(EventOrderFilled, order)
+}
+
+// executeAuctionTrade records an auction execution between two resting
+// orders; neither side took liquidity
+func (ob *OrderBook) executeAuctionTrade(buy, sell *Order, price, quantity Decimal) *Trade {
+	now := time.Now()
+	buy.FilledQuantity = buy.FilledQuantity.Add(quantity)
+	sell.FilledQuantity = sell.FilledQuantity.Add(quantity)
+	buy.UpdatedAt = now
+	sell.UpdatedAt = now
+
+	trade := &Trade{
+		ID:          uuid.New().String(),
+		Symbol:      ob.Symbol,
+		Price:       price,
+		Quantity:    quantity,
+		BuyOrderID:  orderID(buy),
+		SellOrderID: orderID(sell),
+		Timestamp:   now,
+		TakerFee:    types.Zero, // Fees would be calculated based on fee schedule
+		MakerFee:    types.Zero, // Fees would be calculated based on fee schedule
+	}
+
+	ob.logger.Debug("Auction trade executed",
+		zap.String("trade_id", trade.ID),
+		zap.Stringer("price", price),
+		zap.Stringer("quantity", quantity),
+		zap.String("buy_order_id", buy.ID),
+		zap.String("sell_order_id", sell.ID))
+
+	return trade
+}
+
+// SetPhase moves a symbol's book to a trading phase and publishes the
+// auction trades
+func (e *Engine) SetPhase(symbol string, phase types.TradingPhase) ([]*Trade, error) {
+	e.mu.RLock()
+	orderBook, exists := e.OrderBooks[symbol]
+	e.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrSymbolNotFound
+	}
+
+	trades := orderBook.SetPhase(phase)
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	return trades, nil
+}
+
+// UpdatePhases moves every book to the phase its exchange schedule gives
+// at now and returns the auction trades
+func (e *Engine) UpdatePhases(now time.Time) []*Trade {
+	e.mu.RLock()
+	books := make([]*OrderBook, 0, len(e.OrderBooks))
+	for _, orderBook := range e.OrderBooks {
+		books = append(books, orderBook)
+	}
+	e.mu.RUnlock()
+
+	var trades []*Trade
+	for _, orderBook := range books {
+		trades = append(trades, orderBook.SetPhase(types.PhaseFor(orderBook.Symbol, now))...)
+	}
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	return trades
+}
+
+// RunPhases follows the exchange schedules every interval until the
+// context is done
+func (e *Engine) RunPhases(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if trades := e.UpdatePhases(now); len(trades) > 0 {
+				e.logger.Info("Auction trades executed",
+					zap.Int("count", len(trades)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package order_matching
+
+import (
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// Halt halts trading in the book. Matching stops, resting limit orders
+// queue for the re-opening auction, and market and immediate orders are
+// rejected until trading resumes.
+func (ob *OrderBook) Halt(reason string) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.halted = true
+	ob.haltReason = reason
+	ob.updatePhase()
+}
+
+// Resume lifts a symbol halt. Unless a market-wide halt is still in force
+// the book re-opens into its scheduled phase, uncrossing the orders queued
+// during the halt when that phase is continuous trading.
+func (ob *OrderBook) Resume() []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.halted = false
+	return ob.updatePhase()
+}
+
+// setMarketHalt applies or lifts a market-wide halt
+func (ob *OrderBook) setMarketHalt(halted bool, reason string) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.marketHalted = halted
+	if halted && !ob.halted {
+		ob.haltReason = reason
+	}
+	return ob.updatePhase()
+}
+
+// IsHalted returns true while a symbol or market-wide halt is in force
+func (ob *OrderBook) IsHalted() bool {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.halted || ob.marketHalted
+}
+
+// CurrentPhase returns the book's trading phase
+func (ob *OrderBook) CurrentPhase() types.TradingPhase {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.Phase
+}
+
+// emitStatus reports a halt or resumption of the book
+func (ob *OrderBook) emitStatus(eventType MatchingEventType) {
+	if ob.eventHandler == nil {
+		return
+	}
+	event := &MatchingEvent{
+		Type:      eventType,
+		Symbol:    ob.Symbol,
+		Phase:     ob.Phase,
+		Timestamp: time.Now(),
+	}
+	if eventType == EventTradingHalted {
+		event.Reason = ob.haltReason
+	}
+	ob.eventHandler(event)
+}
+
+// OnTradingStatus registers a listener for trading halts and resumptions,
+// such as the WebSocket and market data broadcasters
+func (e *Engine) OnTradingStatus(listener func(types.TradingStatus)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.statusListeners = append(e.statusListeners, listener)
+}
+
+// notifyStatus passes a trading status change to every listener
+func (e *Engine) notifyStatus(status types.TradingStatus) {
+	e.mu.RLock()
+	listeners := e.statusListeners
+	e.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(status)
+	}
+}
+
+// Halt halts trading in a symbol; see OrderBook.Halt
+func (e *Engine) Halt(symbol, reason string) {
+	e.CreateOrderBook(symbol).Halt(reason)
+
+	e.logger.Warn("Trading halted",
+		zap.String("symbol", symbol),
+		zap.String("reason", reason))
+
+	e.notifyStatus(types.TradingStatus{
+		Symbol:    symbol,
+		Phase:     types.TradingPhaseHalted,
+		Halted:    true,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	})
+}
+
+// Resume lifts a symbol halt and publishes the trades of the re-opening
+// auction
+func (e *Engine) Resume(symbol string) ([]*Trade, error) {
+	e.mu.RLock()
+	orderBook, exists := e.OrderBooks[symbol]
+	e.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrSymbolNotFound
+	}
+
+	trades := orderBook.Resume()
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	phase := orderBook.CurrentPhase()
+	e.logger.Info("Trading resumed",
+		zap.String("symbol", symbol),
+		zap.String("phase", string(phase)),
+		zap.Int("auction_trades", len(trades)))
+
+	e.notifyStatus(types.TradingStatus{
+		Symbol:    symbol,
+		Phase:     phase,
+		Halted:    phase == types.TradingPhaseHalted,
+		Timestamp: time.Now(),
+	})
+
+	return trades, nil
+}
+
+// HaltMarket halts trading in every symbol, including books created
+// during the halt
+func (e *Engine) HaltMarket(reason string) {
+	e.mu.Lock()
+	e.marketHalted = true
+	e.marketHaltReason = reason
+	books := make([]*OrderBook, 0, len(e.OrderBooks))
+	for _, orderBook := range e.OrderBooks {
+		books = append(books, orderBook)
+	}
+	e.mu.Unlock()
+
+	for _, orderBook := range books {
+		orderBook.setMarketHalt(true, reason)
+	}
+
+	e.logger.Warn("Market-wide trading halt",
+		zap.String("reason", reason))
+
+	e.notifyStatus(types.TradingStatus{
+		Halted:    true,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	})
+}
+
+// ResumeMarket lifts a market-wide halt and publishes the trades of the
+// re-opening auctions. Symbols with their own halt stay halted.
+func (e *Engine) ResumeMarket() []*Trade {
+	e.mu.Lock()
+	e.marketHalted = false
+	e.marketHaltReason = ""
+	books := make([]*OrderBook, 0, len(e.OrderBooks))
+	for _, orderBook := range e.OrderBooks {
+		books = append(books, orderBook)
+	}
+	e.mu.Unlock()
+
+	var trades []*Trade
+	for _, orderBook := range books {
+		trades = append(trades, orderBook.setMarketHalt(false, "")...)
+	}
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	e.logger.Info("Market-wide trading resumed",
+		zap.Int("auction_trades", len(trades)))
+
+	e.notifyStatus(types.TradingStatus{
+		Timestamp: time.Now(),
+	})
+
+	return trades
+}
+
+// IsHalted returns true if trading in a symbol is halted
+func (e *Engine) IsHalted(symbol string) bool {
+	e.mu.RLock()
+	orderBook, exists := e.OrderBooks[symbol]
+	marketHalted := e.marketHalted
+	e.mu.RUnlock()
+
+	if !exists {
+		return marketHalted
+	}
+	return orderBook.IsHalted()
+}
@@ -0,0 +1,227 @@
+package order_matching
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// rest queues the unfilled remainder of an order in its heap. Hidden orders
+// rest in the hidden heaps, iceberg orders show their first slice and
+// pegged orders are tracked for repricing.
+func (ob *OrderBook) rest(order *Order) {
+	if order.IsPegged() {
+		ob.pegs[order.ID] = order
+	}
+	if !order.IsIceberg() {
+		heap.Push(ob.restingHeap(order), order)
+		return
+	}
+
+	iceberg := &IcebergOrder{
+		ParentOrder:   order,
+		DisplaySize:   order.DisplayQuantity,
+		TotalSize:     order.Quantity,
+		RemainingSize: order.RemainingQuantity(),
+	}
+	ob.icebergs[order.ID] = iceberg
+	ob.showSlice(iceberg)
+}
+
+// best returns the first order a taker meets on one side of the book and
+// the heap it rests in. Hidden orders trade after the displayed orders at
+// the same price but ahead of worse prices.
+func (ob *OrderBook) best(lit, hidden *OrderHeap) (*OrderHeap, *Order) {
+	order, hiddenOrder := lit.Peek(), hidden.Peek()
+	if hiddenOrder != nil && (order == nil || hidden.better(hiddenOrder.Price, order.Price)) {
+		return hidden, hiddenOrder
+	}
+	return lit, order
+}
+
+// better returns true if price a ranks ahead of price b in the heap
+func (h *OrderHeap) better(a, b Decimal) bool {
+	if (h.Side == OrderSideBuy) != h.Stop {
+		return a.GreaterThan(b)
+	}
+	return a.LessThan(b)
+}
+
+// showSlice queues the next slice of an iceberg order behind the orders
+// already at its price, so every refill takes new time priority. Slice IDs
+// are derived from the parent ID.
+func (ob *OrderBook) showSlice(iceberg *IcebergOrder) {
+	parent := iceberg.ParentOrder
+	iceberg.Slices++
+	size := types.IcebergPeak(parent, iceberg.Slices, iceberg.RemainingSize)
+
+	slice := *parent
+	slice.ID = fmt.Sprintf("%s/%d", parent.ID, iceberg.Slices)
+	slice.Quantity = size
+	slice.FilledQuantity = types.Zero
+	slice.DisplayQuantity = types.Zero
+	slice.ParentOrderID = parent.ID
+	slice.IsIcebergChild = true
+	slice.Status = OrderStatusNew
+	slice.QueuedAt = time.Now()
+
+	iceberg.RemainingSize = iceberg.RemainingSize.Sub(size)
+	iceberg.RefreshSize = size
+	iceberg.CurrentOrder = &slice
+	heap.Push(ob.restingHeap(&slice), &slice)
+}
+
+// settleMaker removes a maker from its heap once filled. Fills of an
+// iceberg slice go to its parent.
+func (ob *OrderBook) settleMaker(h *OrderHeap, maker *Order, quantity Decimal) {
+	if maker.IsIcebergChild {
+		ob.fillSlice(h, maker, quantity)
+		return
+	}
+	if maker.Status == OrderStatusFilled {
+		heap.Remove(h, maker.Index)
+	}
+}
+
+// fillSlice passes a fill of an iceberg slice to its parent and shows the
+// next slice once the current one is filled
+func (ob *OrderBook) fillSlice(h *OrderHeap, slice *Order, quantity Decimal) {
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return
+	}
+	parent := iceberg.ParentOrder
+	parent.FilledQuantity = parent.FilledQuantity.Add(quantity)
+	parent.UpdatedAt = time.Now()
+	parent.Status = OrderStatusPartiallyFilled
+
+	if slice.RemainingQuantity().IsPositive() {
+		return
+	}
+	heap.Remove(h, slice.Index)
+	slice.Status = OrderStatusFilled
+
+	if !iceberg.RemainingSize.IsPositive() {
+		delete(ob.icebergs, parent.ID)
+		parent.Status = OrderStatusFilled
+		return
+	}
+
+	ob.showSlice(iceberg)
+
+	ob.logger.Debug("Iceberg order refreshed",
+		zap.String("order_id", parent.ID),
+		zap.String("slice_id", iceberg.CurrentOrder.ID),
+		zap.Stringer("display_quantity", iceberg.RefreshSize),
+		zap.Stringer("reserve_quantity", iceberg.RemainingSize))
+
+	ob.emit(EventIcebergRefreshed, iceberg.CurrentOrder)
+}
+
+// decrementSlice shrinks an iceberg order by a quantity self-trade
+// prevention took from its slice. An exhausted slice is replaced by the
+// next one, and the iceberg is cancelled when nothing is left to show.
+func (ob *OrderBook) decrementSlice(h *OrderHeap, slice *Order, quantity Decimal) {
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return
+	}
+	parent := iceberg.ParentOrder
+	parent.Quantity = parent.Quantity.Sub(quantity)
+
+	if slice.RemainingQuantity().IsPositive() {
+		return
+	}
+	heap.Remove(h, slice.Index)
+	slice.Status = OrderStatusCancelled
+
+	if !iceberg.RemainingSize.IsPositive() {
+		ob.cancelSelfTrade(slice)
+		return
+	}
+	ob.showSlice(iceberg)
+}
+
+// dropIceberg ends an iceberg order whose slice left the book unfilled and
+// returns the parent, which takes the slice's final status
+func (ob *OrderBook) dropIceberg(slice *Order, status OrderStatus) *Order {
+	slice.Status = status
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return slice
+	}
+	delete(ob.icebergs, slice.ParentOrderID)
+	return iceberg.ParentOrder
+}
+
+// unqueue removes a resting order from its heap, taking an iceberg's
+// displayed slice with it
+func (ob *OrderBook) unqueue(order *Order) {
+	iceberg, exists := ob.icebergs[order.ID]
+	if !exists {
+		h := ob.restingHeap(order)
+		if index := h.find(order.ID); index >= 0 {
+			heap.Remove(h, index)
+		}
+		return
+	}
+
+	slice := iceberg.CurrentOrder
+	if index := ob.restingHeap(slice).find(slice.ID); index >= 0 {
+		heap.Remove(ob.restingHeap(slice), index)
+	}
+	ob.dropIceberg(slice, OrderStatusCancelled)
+}
+
+// reserve returns the quantity an order adds to its price level beyond
+// what is displayed: the undisplayed rest of an iceberg order
+func (ob *OrderBook) reserve(order *Order) Decimal {
+	if !order.IsIcebergChild {
+		return types.Zero
+	}
+	if iceberg, exists := ob.icebergs[order.ParentOrderID]; exists {
+		return iceberg.RemainingSize
+	}
+	return types.Zero
+}
+
+// orderID returns the ID a trade reports for an order: the parent's for an
+// iceberg slice
+func orderID(order *Order) string {
+	if order.IsIcebergChild {
+		return order.ParentOrderID
+	}
+	return order.ID
+}
+
+// auctionLevels returns the price levels of one side of the book, best
+// first, including hidden orders and iceberg reserves, so the uncross
+// leaves no executable liquidity behind
+func (ob *OrderBook) auctionLevels(lit, hidden *OrderHeap) []types.AuctionLevel {
+	levels := make([]types.AuctionLevel, 0, lit.Len()+hidden.Len())
+	for _, h := range []*OrderHeap{lit, hidden} {
+		for _, order := range h.Orders {
+			quantity := order.RemainingQuantity().Add(ob.reserve(order))
+			levels = append(levels, types.AuctionLevel{Price: order.Price, Quantity: quantity})
+		}
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		return lit.better(levels[i].Price, levels[j].Price)
+	})
+
+	merged := levels[:0]
+	for _, level := range levels {
+		n := len(merged)
+		if n > 0 && merged[n-1].Price.Equal(level.Price) {
+			merged[n-1].Quantity = merged[n-1].Quantity.Add(level.Quantity)
+			continue
+		}
+		merged = append(merged, level)
+	}
+	return merged
+}
@@ -0,0 +1,56 @@
+package order_matching
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+)
+
+// matchProRata allocates the taker across every order at the top price of
+// the opposite heap by the symbol's allocation rule, trading each maker's
+// share in time priority. Expired makers are dropped first; makers that
+// would self-trade are left out of the allocation and handled by price-time
+// matching once they reach the top of the heap.
+func (ob *OrderBook) matchProRata(rule types.AllocationRule, mode types.SelfTradePrevention, order *Order, opposite *OrderHeap) []*Trade {
+	price := opposite.Peek().Price
+
+	var level []*Order
+	for _, maker := range opposite.Orders {
+		if maker.Price.Equal(price) {
+			level = append(level, maker)
+		}
+	}
+	sort.Slice(level, func(i, j int) bool { return level[i].QueueTime().Before(level[j].QueueTime()) })
+
+	var makers []*Order
+	var resting []Decimal
+	for _, maker := range level {
+		if maker.IsExpired() {
+			heap.Remove(opposite, maker.Index)
+			ob.expireOrder(maker)
+			continue
+		}
+		if mode != types.SelfTradePreventionNone && types.IsSelfTrade(order, maker) {
+			continue
+		}
+		makers = append(makers, maker)
+		resting = append(resting, maker.RemainingQuantity())
+	}
+
+	var trades []*Trade
+	for i, share := range rule.Allocate(order.RemainingQuantity(), resting) {
+		if !share.IsPositive() {
+			continue
+		}
+
+		maker := makers[i]
+		trade := ob.matchOrders(order, maker, share)
+		trades = append(trades, trade)
+
+		// Remove filled makers from the book
+		ob.settleMaker(opposite, maker, trade.Quantity)
+	}
+
+	return trades
+}
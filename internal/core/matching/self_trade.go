@@ -0,0 +1,138 @@
+package order_matching
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// preventSelfTrade applies a self-trade prevention mode instead of trading
+// the taker against a maker from the same user or account group. The maker
+// must be the top of the opposite heap, displayed or hidden. It returns true if the taker was
+// cancelled and must stop matching.
+func (ob *OrderBook) preventSelfTrade(mode types.SelfTradePrevention, taker, maker *Order, opposite *OrderHeap) bool {
+	takerCancelled := false
+	quantity := types.MinDecimal(taker.RemainingQuantity(), maker.RemainingQuantity())
+
+	switch mode {
+	case types.SelfTradePreventionCancelOldest:
+		heap.Pop(opposite)
+		ob.cancelSelfTrade(maker)
+	case types.SelfTradePreventionCancelBoth:
+		heap.Pop(opposite)
+		ob.cancelSelfTrade(maker)
+		ob.cancelSelfTrade(taker)
+		takerCancelled = true
+	case types.SelfTradePreventionDecrementAndCancel:
+		taker.Quantity = taker.Quantity.Sub(quantity)
+		maker.Quantity = maker.Quantity.Sub(quantity)
+		if maker.IsIcebergChild {
+			ob.decrementSlice(opposite, maker, quantity)
+		} else if !maker.RemainingQuantity().IsPositive() {
+			heap.Pop(opposite)
+			ob.cancelSelfTrade(maker)
+		}
+		if !taker.RemainingQuantity().IsPositive() {
+			ob.cancelSelfTrade(taker)
+			takerCancelled = true
+		}
+	default:
+		ob.cancelSelfTrade(taker)
+		takerCancelled = true
+	}
+
+	ob.logger.Debug("Self-trade prevented",
+		zap.String("mode", string(mode)),
+		zap.String("taker_order_id", taker.ID),
+		zap.String("maker_order_id", maker.ID),
+		zap.Stringer("quantity", quantity))
+
+	if ob.eventHandler != nil {
+		ob.eventHandler(&MatchingEvent{
+			Type:        EventSelfTradePrevented,
+			Symbol:      ob.Symbol,
+			Order:       taker,
+			ContraOrder: maker,
+			Quantity:    quantity,
+			Timestamp:   time.Now(),
+		})
+	}
+
+	return takerCancelled
+}
+
+// cancelSelfTrade cancels an order removed by self-trade prevention. An
+// iceberg slice cancels the whole iceberg order.
+func (ob *OrderBook) cancelSelfTrade(order *Order) {
+	if order.IsIcebergChild {
+		order = ob.dropIceberg(order, OrderStatusCancelled)
+	}
+	delete(ob.Orders, order.ID)
+	order.Status = OrderStatusCancelled
+}
+
+// preventSelfTradeFast applies self-trade prevention when the taker would
+// trade with the first order of a price level. It returns false if the orders
+// may trade.
+func (ob *HFTOrderBook) preventSelfTradeFast(taker *FastOrder, tree *PriceLevelTree, node *PriceLevelNode) bool {
+	maker := node.orders[0]
+	if !types.IsSelfTrade(&taker.Order, maker) {
+		return false
+	}
+	mode := types.SelfTradePolicies.ModeFor(&taker.Order)
+	if mode == types.SelfTradePreventionNone {
+		return false
+	}
+
+	quantity := types.MinDecimal(taker.RemainingQuantity(), maker.RemainingQuantity())
+
+	switch mode {
+	case types.SelfTradePreventionCancelOldest:
+		tree.removeFront(node)
+		maker.Status = OrderStatusCancelled
+	case types.SelfTradePreventionCancelBoth:
+		tree.removeFront(node)
+		maker.Status = OrderStatusCancelled
+		taker.Status = OrderStatusCancelled
+	case types.SelfTradePreventionDecrementAndCancel:
+		taker.Quantity = taker.Quantity.Sub(quantity)
+		maker.Quantity = maker.Quantity.Sub(quantity)
+		if !maker.RemainingQuantity().IsPositive() {
+			tree.removeFront(node)
+			maker.Status = OrderStatusCancelled
+		}
+		if !taker.RemainingQuantity().IsPositive() {
+			taker.Status = OrderStatusCancelled
+		}
+	default:
+		taker.Status = OrderStatusCancelled
+	}
+
+	if ob.eventHandler != nil {
+		// The taker returns to the pool after matching, so publish a copy
+		takerOrder := taker.Order
+		ob.eventHandler(&MatchingEvent{
+			Type:        EventSelfTradePrevented,
+			Symbol:      ob.Symbol,
+			Order:       &takerOrder,
+			ContraOrder: maker,
+			Quantity:    quantity,
+			Timestamp:   time.Now(),
+		})
+	}
+
+	return true
+}
+
+// removeFront removes the oldest order of a price level and drops the level
+// once it is empty
+func (tree *PriceLevelTree) removeFront(node *PriceLevelNode) {
+	node.orders = node.orders[1:]
+	node.orderCount--
+
+	if len(node.orders) == 0 {
+		tree.removeNode(node)
+	}
+}
@@ -0,0 +1,97 @@
+package order_matching
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_SelfTradePrevention(t *testing.T) {
+	tests := []struct {
+		mode types.SelfTradePrevention
+		// fills are the quantities the taker buys from bob
+		fills []string
+		// taker and maker are the final statuses of alice's orders
+		taker, maker OrderStatus
+		// takerQuantity is the taker's quantity after prevention
+		takerQuantity string
+	}{
+		{types.SelfTradePreventionCancelNewest, nil, OrderStatusCancelled, OrderStatusNew, "50"},
+		{types.SelfTradePreventionCancelOldest, []string{"50"}, OrderStatusFilled, OrderStatusCancelled, "50"},
+		{types.SelfTradePreventionCancelBoth, nil, OrderStatusCancelled, OrderStatusCancelled, "50"},
+		{types.SelfTradePreventionDecrementAndCancel, []string{"20"}, OrderStatusFilled, OrderStatusCancelled, "20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			engine := newTestEngine(t)
+
+			maker := newLimitOrder("maker", OrderSideSell, "100", "30")
+			maker.UserID = "alice"
+			bob := newLimitOrder("bob", OrderSideSell, "100", "50")
+			for _, order := range []*Order{maker, bob} {
+				_, err := engine.PlaceOrder(order)
+				require.NoError(t, err)
+			}
+
+			taker := newLimitOrder("taker", OrderSideBuy, "100", "50")
+			taker.UserID = "alice"
+			taker.SelfTradePrevention = tt.mode
+			trades, err := engine.PlaceOrder(taker)
+			require.NoError(t, err)
+
+			var fills []string
+			for _, trade := range trades {
+				assert.Equal(t, "bob", trade.SellOrderID)
+				fills = append(fills, trade.Quantity.String())
+			}
+			assert.Equal(t, tt.fills, fills)
+			assert.Equal(t, tt.taker, taker.Status)
+			assert.Equal(t, tt.maker, maker.Status)
+			assert.Equal(t, tt.takerQuantity, taker.Quantity.String())
+			assert.Contains(t, drainEvents(engine), EventSelfTradePrevented)
+		})
+	}
+
+	t.Run("account groups", func(t *testing.T) {
+		engine := newTestEngine(t)
+		require.NoError(t, types.SelfTradePolicies.SetGroupMode("desk", types.SelfTradePreventionCancelNewest))
+
+		maker := newLimitOrder("maker", OrderSideSell, "100", "30")
+		maker.AccountGroup = "desk"
+		_, err := engine.PlaceOrder(maker)
+		require.NoError(t, err)
+
+		taker := newLimitOrder("taker", OrderSideBuy, "100", "30")
+		taker.AccountGroup = "desk"
+		trades, err := engine.PlaceOrder(taker)
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+		assert.Equal(t, OrderStatusCancelled, taker.Status)
+
+		// Outside the group the same order trades
+		outsider := newLimitOrder("outsider", OrderSideBuy, "100", "30")
+		trades, err = engine.PlaceOrder(outsider)
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, OrderStatusFilled, maker.Status)
+	})
+
+	t.Run("self-trades are allowed without a mode", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		maker := newLimitOrder("maker", OrderSideSell, "100", "30")
+		maker.UserID = "alice"
+		_, err := engine.PlaceOrder(maker)
+		require.NoError(t, err)
+
+		taker := newLimitOrder("taker", OrderSideBuy, "100", "30")
+		taker.UserID = "alice"
+		trades, err := engine.PlaceOrder(taker)
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Empty(t, drainEvents(engine))
+	})
+}
@@ -0,0 +1,151 @@
+package order_matching
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// lessStop orders stop orders in the order they trigger: lowest stop price
+// first for buy stops, highest first for sell stops, then by queue time
+func (h OrderHeap) lessStop(i, j int) bool {
+	if h.Orders[i].StopPrice.Equal(h.Orders[j].StopPrice) {
+		return h.Orders[i].QueueTime().Before(h.Orders[j].QueueTime())
+	}
+	if h.Side == OrderSideBuy {
+		return h.Orders[i].StopPrice.LessThan(h.Orders[j].StopPrice)
+	}
+	return h.Orders[i].StopPrice.GreaterThan(h.Orders[j].StopPrice)
+}
+
+// triggerStops releases every stop order elected by the current trigger
+// prices. Elected orders re-enter matching one at a time, nearest stop price
+// first and then by queue time, and the trades of each one may elect further
+// stops, so a cascade resolves within a single call. Stops wait out auction
+// calls and are released when continuous trading resumes.
+func (ob *OrderBook) triggerStops() []*Trade {
+	if ob.Phase.IsCall() {
+		return nil
+	}
+
+	var trades []*Trade
+
+	for {
+		order := ob.nextElectedStop()
+		if order == nil {
+			return trades
+		}
+		elected, err := ob.electStop(order)
+		if err != nil {
+			ob.logger.Error("Failed to process triggered stop order",
+				zap.String("order_id", order.ID),
+				zap.Error(err))
+		}
+		trades = append(trades, elected...)
+	}
+}
+
+// nextElectedStop pops the next elected stop order. When both sides have
+// one, the order queued first goes first.
+func (ob *OrderBook) nextElectedStop() *Order {
+	buy := ob.StopBids.Peek()
+	if buy != nil && !buy.StopElected(ob.stopTriggerPrice(OrderSideBuy)) {
+		buy = nil
+	}
+	sell := ob.StopAsks.Peek()
+	if sell != nil && !sell.StopElected(ob.stopTriggerPrice(OrderSideSell)) {
+		sell = nil
+	}
+
+	switch {
+	case buy == nil && sell == nil:
+		return nil
+	case sell == nil || (buy != nil && !sell.QueueTime().Before(buy.QueueTime())):
+		return heap.Pop(ob.StopBids).(*Order)
+	default:
+		return heap.Pop(ob.StopAsks).(*Order)
+	}
+}
+
+// electStop converts an elected stop order to a market or limit order and
+// matches it
+func (ob *OrderBook) electStop(order *Order) ([]*Trade, error) {
+	if order.IsExpired() {
+		ob.expireOrder(order)
+		return nil, nil
+	}
+
+	now := time.Now()
+	order.Elect()
+	order.QueuedAt = now
+	order.UpdatedAt = now
+
+	ob.logger.Debug("Stop order triggered",
+		zap.String("order_id", order.ID),
+		zap.String("type", string(order.Type)),
+		zap.Stringer("stop_price", order.StopPrice))
+	ob.emit(EventStopTriggered, order)
+
+	return ob.processOrder(order)
+}
+
+// stopTriggerPrice returns the price that elects stop orders on a side
+// under the symbol's stop trigger: buy stops compare against the best ask
+// and sell stops against the best bid for BID_ASK
+func (ob *OrderBook) stopTriggerPrice(side OrderSide) Decimal {
+	switch types.StopTriggerFor(ob.Symbol) {
+	case types.StopTriggerMark:
+		return ob.MarkPrice
+	case types.StopTriggerBidAsk:
+		best := ob.Asks.Peek()
+		if side == OrderSideSell {
+			best = ob.Bids.Peek()
+		}
+		if best == nil {
+			return types.Zero
+		}
+		return best.Price
+	default:
+		return ob.LastPrice
+	}
+}
+
+// SetMarkPrice updates the mark price and releases the stops it elects
+func (ob *OrderBook) SetMarkPrice(price Decimal) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.MarkPrice = price
+	trades := ob.triggerStops()
+	ob.repricePegs()
+	return trades
+}
+
+// SetMarkPrice updates a symbol's mark price and publishes the trades of
+// the stops it elects
+func (e *Engine) SetMarkPrice(symbol string, price Decimal) ([]*Trade, error) {
+	e.mu.RLock()
+	orderBook, exists := e.OrderBooks[symbol]
+	e.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrSymbolNotFound
+	}
+
+	trades := orderBook.SetMarkPrice(price)
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	return trades, nil
+}
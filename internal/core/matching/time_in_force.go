@@ -0,0 +1,233 @@
+package order_matching
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// TimeInForce is the time in force from the shared types package
+type TimeInForce = types.TimeInForce
+
+// Time in force constants from types package
+const (
+	TimeInForceGTC = types.TimeInForceGTC
+	TimeInForceIOC = types.TimeInForceIOC
+	TimeInForceFOK = types.TimeInForceFOK
+	TimeInForceDay = types.TimeInForceDay
+	TimeInForceGTD = types.TimeInForceGTD
+)
+
+// SetEventHandler sets the callback that receives order lifecycle events
+// raised inside the book (IOC cancels, FOK kills and expiries)
+func (ob *OrderBook) SetEventHandler(handler func(*MatchingEvent)) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.eventHandler = handler
+}
+
+// emit raises an order event; the caller must hold the book lock
+func (ob *OrderBook) emit(eventType MatchingEventType, order *Order) {
+	if ob.eventHandler == nil {
+		return
+	}
+	ob.eventHandler(&MatchingEvent{
+		Type:      eventType,
+		Symbol:    ob.Symbol,
+		Order:     order,
+		Timestamp: time.Now(),
+	})
+}
+
+// applyTimeInForce validates the time in force and stamps DAY orders with
+// the session close of the instrument's exchange schedule
+func (ob *OrderBook) applyTimeInForce(order *Order, now time.Time) error {
+	if err := order.ValidateTimeInForce(now); err != nil {
+		return err
+	}
+	if order.TimeInForce == TimeInForceDay && order.ExpireTime.IsZero() {
+		order.ExpireTime = types.SessionCloseFor(order.Symbol, now)
+	}
+	return nil
+}
+
+// canFill returns true if the resting liquidity the order may trade against,
+// hidden orders and iceberg reserves included, covers its whole remaining
+// quantity
+func (ob *OrderBook) canFill(order *Order, now time.Time) bool {
+	opposite, hidden := ob.Asks, ob.HiddenAsks
+	if order.Side == OrderSideSell {
+		opposite, hidden = ob.Bids, ob.HiddenBids
+	}
+
+	required := order.RemainingQuantity()
+	available := types.Zero
+	for _, h := range []*OrderHeap{opposite, hidden} {
+		for _, maker := range h.Orders {
+			if maker.IsExpiredAt(now) || !crosses(order, maker) {
+				continue
+			}
+			available = available.Add(maker.RemainingQuantity()).Add(ob.reserve(maker))
+			if available.GreaterThanOrEqual(required) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crosses returns true if the taker may trade at the maker's price
+func crosses(taker, maker *Order) bool {
+	if taker.Type == OrderTypeMarket {
+		return true
+	}
+	if taker.Side == OrderSideBuy {
+		return maker.Price.LessThanOrEqual(taker.Price)
+	}
+	return maker.Price.GreaterThanOrEqual(taker.Price)
+}
+
+// killOrder rejects a fill-or-kill order the book cannot fill completely
+func (ob *OrderBook) killOrder(order *Order) {
+	delete(ob.Orders, order.ID)
+	order.Status = OrderStatusRejected
+
+	ob.logger.Debug("Fill-or-kill order killed",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", ob.Symbol))
+
+	ob.emit(EventOrderFOKKilled, order)
+}
+
+// cancelRemainder cancels the unfilled remainder of an immediate-or-cancel order
+func (ob *OrderBook) cancelRemainder(order *Order) {
+	delete(ob.Orders, order.ID)
+	order.Status = OrderStatusCancelled
+
+	ob.logger.Debug("Immediate-or-cancel remainder cancelled",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", ob.Symbol),
+		zap.Stringer("filled_quantity", order.FilledQuantity))
+
+	ob.emit(EventOrderIOCCanceled, order)
+}
+
+// expireOrder marks a resting order that was already removed from its heap
+// as expired and returns it. An expired iceberg slice expires the whole
+// iceberg order, which is returned instead.
+func (ob *OrderBook) expireOrder(order *Order) *Order {
+	if order.IsIcebergChild {
+		order = ob.dropIceberg(order, OrderStatusExpired)
+	}
+	delete(ob.Orders, order.ID)
+	order.Status = OrderStatusExpired
+
+	ob.logger.Debug("Order expired",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", ob.Symbol),
+		zap.String("time_in_force", string(order.TimeInForce)))
+
+	if order.TimeInForce == TimeInForceDay {
+		ob.emit(EventOrderDayExpired, order)
+	} else {
+		ob.emit(EventOrderGTDExpired, order)
+	}
+	return order
+}
+
+// ExpireOrders removes every resting order whose expire time has passed,
+// including DAY orders at the session close, and returns them. Orders stay
+// in the book through the opening and closing calls so they can take part
+// in the uncross.
+func (ob *OrderBook) ExpireOrders(now time.Time) []*Order {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if ob.Phase == types.TradingPhasePreOpen || ob.Phase == types.TradingPhasePreClose {
+		return nil
+	}
+
+	var expired []*Order
+	for _, h := range []*OrderHeap{ob.Bids, ob.Asks, ob.HiddenBids, ob.HiddenAsks, ob.StopBids, ob.StopAsks} {
+		expired = append(expired, h.removeExpired(now)...)
+	}
+
+	for i, order := range expired {
+		expired[i] = ob.expireOrder(order)
+	}
+	ob.repricePegs()
+
+	return expired
+}
+
+// removeExpired drops expired orders from the heap and restores heap order
+func (h *OrderHeap) removeExpired(now time.Time) []*Order {
+	var expired []*Order
+	kept := h.Orders[:0]
+	for _, order := range h.Orders {
+		if order.IsExpiredAt(now) {
+			expired = append(expired, order)
+		} else {
+			kept = append(kept, order)
+		}
+	}
+
+	if len(expired) > 0 {
+		for i := len(kept); i < len(h.Orders); i++ {
+			h.Orders[i] = nil
+		}
+		h.Orders = kept
+		heap.Init(h)
+	}
+
+	return expired
+}
+
+// publishEvent forwards an order book event to the event channel
+func (e *Engine) publishEvent(event *MatchingEvent) {
+	select {
+	case e.EventChannel <- event:
+	default:
+		e.logger.Warn("Event channel full, dropping event",
+			zap.String("event_type", string(event.Type)),
+			zap.String("symbol", event.Symbol))
+	}
+}
+
+// ExpireOrders expires DAY and GTD orders across all order books
+func (e *Engine) ExpireOrders(now time.Time) []*Order {
+	e.mu.RLock()
+	books := make([]*OrderBook, 0, len(e.OrderBooks))
+	for _, orderBook := range e.OrderBooks {
+		books = append(books, orderBook)
+	}
+	e.mu.RUnlock()
+
+	var expired []*Order
+	for _, orderBook := range books {
+		expired = append(expired, orderBook.ExpireOrders(now)...)
+	}
+	return expired
+}
+
+// RunExpiry expires DAY and GTD orders every interval until the context is done
+func (e *Engine) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if expired := e.ExpireOrders(now); len(expired) > 0 {
+				e.logger.Info("Expired orders",
+					zap.Int("count", len(expired)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
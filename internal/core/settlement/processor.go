@@ -7,24 +7,25 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/zap"
 )
 
 // SettlementRequest represents a settlement request
 type SettlementRequest struct {
-	ID          string    `json:"id"`
-	TradeID     string    `json:"trade_id"`
-	BuyerID     string    `json:"buyer_id"`
-	SellerID    string    `json:"seller_id"`
-	Symbol      string    `json:"symbol"`
-	Quantity    float64   `json:"quantity"`
-	Price       float64   `json:"price"`
-	Fee         float64   `json:"fee"`
-	Commission  float64   `json:"commission"`
-	Status      string    `json:"status"` // "pending", "processing", "settled", "failed"
-	CreatedAt   time.Time `json:"created_at"`
-	ProcessedAt time.Time `json:"processed_at,omitempty"`
-	RetryCount  int       `json:"retry_count"`
+	ID          string        `json:"id"`
+	TradeID     string        `json:"trade_id"`
+	BuyerID     string        `json:"buyer_id"`
+	SellerID    string        `json:"seller_id"`
+	Symbol      string        `json:"symbol"`
+	Quantity    types.Decimal `json:"quantity"`
+	Price       types.Decimal `json:"price"`
+	Fee         types.Decimal `json:"fee"`
+	Commission  types.Decimal `json:"commission"`
+	Status      string        `json:"status"` // "pending", "processing", "settled", "failed"
+	CreatedAt   time.Time     `json:"created_at"`
+	ProcessedAt time.Time     `json:"processed_at,omitempty"`
+	RetryCount  int           `json:"retry_count"`
 }
 
 // SettlementResult represents the result of a settlement
@@ -391,7 +392,7 @@ func (sp *Processor) GetPendingSettlements() []*SettlementRequest {
 }
 
 // ProcessTrade processes a trade for settlement (simplified interface for unified engine)
-func (sp *Processor) ProcessTrade(tradeID, symbol string, quantity, price float64) error {
+func (sp *Processor) ProcessTrade(tradeID, symbol string, quantity, price types.Decimal) error {
 	request := &SettlementRequest{
 		TradeID:   tradeID,
 		Symbol:    symbol,
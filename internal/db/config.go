@@ -110,6 +110,10 @@ func runMigrations(db *gorm.DB, logger *zap.Logger) error {
 	// Auto-migrate models
 	if err := db.AutoMigrate(
 		&Order{},
+		&OrderGroup{},
+		&AuditRecord{},
+		&OrderTransition{},
+		&KillSwitch{},
 		&Trade{},
 		&Position{},
 		&RiskLimit{},
@@ -3,29 +3,84 @@ package db
 import (
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"gorm.io/gorm"
 )
 
-// Order represents an order in the database
+// Order represents an order in the database, with prices and quantities
+// stored as exact decimal digits
 type Order struct {
 	gorm.Model
-	ID             string `gorm:"primaryKey;type:uuid"`
-	UserID         string `gorm:"index"`
-	ClientOrderID  string `gorm:"index"`
-	Symbol         string `gorm:"index"`
-	Side           string `gorm:"index"`
-	Type           string `gorm:"index"`
-	Price          float64
-	StopPrice      float64
-	Quantity       float64
-	FilledQuantity float64
-	Status         string `gorm:"index"`
+	ID             string        `gorm:"primaryKey;type:uuid"`
+	UserID         string        `gorm:"index"`
+	ClientOrderID  string        `gorm:"index"`
+	Symbol         string        `gorm:"index"`
+	Side           string        `gorm:"index"`
+	Type           string        `gorm:"index"`
+	Price          types.Decimal `gorm:"type:numeric"`
+	StopPrice      types.Decimal `gorm:"type:numeric"`
+	Quantity       types.Decimal `gorm:"type:numeric"`
+	FilledQuantity types.Decimal `gorm:"type:numeric"`
+	Status         string        `gorm:"index"`
 	TimeInForce    string
 	ExpiresAt      time.Time
+	GroupID        string  `gorm:"index"`
+	ParentOrderID  string  `gorm:"index"`
 	Trades         []Trade `gorm:"foreignKey:OrderID"`
 	Metadata       string  `gorm:"type:jsonb"`
 }
 
+// OrderGroup represents a contingent order group in the database
+type OrderGroup struct {
+	gorm.Model
+	ID             string `gorm:"primaryKey;type:uuid"`
+	UserID         string `gorm:"index"`
+	Type           string `gorm:"index"`
+	Status         string `gorm:"index"`
+	PrimaryOrderID string `gorm:"index"`
+	LegOrderIDs    string `gorm:"type:jsonb"`
+}
+
+// AuditRecord represents an order control action in the database, such as
+// a mass cancel or a kill switch change
+type AuditRecord struct {
+	gorm.Model
+	ID      string `gorm:"primaryKey;type:uuid"`
+	Action  string `gorm:"index"`
+	Actor   string `gorm:"index"`
+	Scope   string `gorm:"index"`
+	Value   string `gorm:"index"`
+	Reason  string
+	Details string `gorm:"type:jsonb"`
+}
+
+// OrderTransition represents an order status change in the database, with
+// the reason code explaining it
+type OrderTransition struct {
+	gorm.Model
+	ID             string `gorm:"primaryKey;type:uuid"`
+	OrderID        string `gorm:"index"`
+	UserID         string `gorm:"index"`
+	Sequence       int
+	FromStatus     string
+	ToStatus       string `gorm:"index"`
+	Reason         string `gorm:"index"`
+	RejectReason   string
+	FilledQuantity types.Decimal `gorm:"type:numeric"`
+}
+
+// KillSwitch represents an engaged kill switch in the database. The ID is
+// the scope and value of the entity blocked.
+type KillSwitch struct {
+	gorm.Model
+	ID        string `gorm:"primaryKey"`
+	Scope     string `gorm:"index"`
+	Value     string `gorm:"index"`
+	Actor     string
+	Reason    string
+	EnabledAt time.Time
+}
+
 // Trade represents a trade in the database
 type Trade struct {
 	gorm.Model
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/trading/types"
@@ -223,3 +224,51 @@ func (am *AssetMetadata) RequiresSpecialHandling() bool {
 func (am *AssetMetadata) GetTradingHours() string {
 	return am.AssetType.GetTradingHours()
 }
+
+// Instrument builds matching reference data from the asset metadata and its
+// asset-type configuration. Per-symbol attributes (tick_size, lot_size,
+// min_notional, max_notional, min_price, max_price, dynamic_band) override
+// the asset-type defaults.
+func (am *AssetMetadata) Instrument(config *AssetConfiguration) *types.Instrument {
+	instrument := &types.Instrument{
+		Symbol:         am.Symbol,
+		AssetType:      am.AssetType,
+		Currency:       am.Currency,
+		TradingEnabled: am.IsActive,
+		Schedule:       types.ScheduleForExchange(am.Exchange),
+	}
+
+	if config != nil {
+		instrument.TradingEnabled = instrument.TradingEnabled && config.TradingEnabled
+		instrument.TickSize = decimalFromFloat(config.PriceIncrement)
+		instrument.LotSize = decimalFromFloat(config.QuantityIncrement)
+		instrument.MinQuantity = decimalFromFloat(config.MinOrderSize)
+		instrument.MaxQuantity = decimalFromFloat(config.MaxOrderSize)
+	}
+
+	overrides := map[string]*types.Decimal{
+		"tick_size":    &instrument.TickSize,
+		"lot_size":     &instrument.LotSize,
+		"min_notional": &instrument.MinNotional,
+		"max_notional": &instrument.MaxNotional,
+		"min_price":    &instrument.MinPrice,
+		"max_price":    &instrument.MaxPrice,
+		"dynamic_band": &instrument.DynamicBand,
+	}
+	for key, field := range overrides {
+		if value, ok := am.Attributes.GetFloatAttribute(key); ok {
+			*field = decimalFromFloat(value)
+		}
+	}
+
+	return instrument
+}
+
+// decimalFromFloat converts a stored float using its shortest exact representation
+func decimalFromFloat(value float64) types.Decimal {
+	parsed, err := types.ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return types.Zero
+	}
+	return parsed
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/abdoElHodaky/tradSys/internal/db/models"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// InstrumentRepository loads instrument reference data from the asset tables
+type InstrumentRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewInstrumentRepository creates a new instrument repository
+func NewInstrumentRepository(db *gorm.DB, logger *zap.Logger) *InstrumentRepository {
+	return &InstrumentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetInstruments builds instruments from every asset and its asset-type configuration
+func (r *InstrumentRepository) GetInstruments(ctx context.Context) ([]*types.Instrument, error) {
+	var assets []*models.AssetMetadata
+	if result := r.db.WithContext(ctx).Find(&assets); result.Error != nil {
+		r.logger.Error("Failed to get asset metadata", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	var configs []*models.AssetConfiguration
+	if result := r.db.WithContext(ctx).Find(&configs); result.Error != nil {
+		r.logger.Error("Failed to get asset configurations", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	configsByType := make(map[types.AssetType]*models.AssetConfiguration, len(configs))
+	for _, config := range configs {
+		configsByType[config.AssetType] = config
+	}
+
+	instruments := make([]*types.Instrument, 0, len(assets))
+	for _, asset := range assets {
+		instruments = append(instruments, asset.Instrument(configsByType[asset.AssetType]))
+	}
+	return instruments, nil
+}
+
+// LoadInstruments registers every stored instrument in the registry
+func (r *InstrumentRepository) LoadInstruments(ctx context.Context, registry *types.InstrumentRegistry) error {
+	instruments, err := r.GetInstruments(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, instrument := range instruments {
+		if err := registry.Register(instrument); err != nil {
+			r.logger.Warn("Skipping invalid instrument",
+				zap.Error(err),
+				zap.String("symbol", instrument.Symbol))
+		}
+	}
+
+	r.logger.Info("Loaded instrument reference data", zap.Int("count", len(instruments)))
+	return nil
+}
@@ -1,6 +1,9 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -13,6 +16,7 @@ var RepositoriesModule = fx.Options(
 	fx.Provide(NewPositionRepository),
 	fx.Provide(NewRiskRepository),
 	fx.Provide(NewMarketDataRepository),
+	fx.Provide(NewInstrumentRepository),
 )
 
 // Individual repository modules for specific services
@@ -28,6 +32,24 @@ var MarketDataRepositoryModule = fx.Options(
 	fx.Provide(NewMarketDataRepository),
 )
 
+// InstrumentRepositoryModule loads the stored instruments into the registry
+// as the application starts, over those registered from config. Include it
+// ahead of modules that recover order books so recovery sees them.
+var InstrumentRepositoryModule = fx.Options(
+	fx.Provide(NewInstrumentRepository),
+	fx.Invoke(loadInstrumentsOnStart),
+)
+
+// loadInstrumentsOnStart registers the stored instruments when the
+// application starts
+func loadInstrumentsOnStart(lifecycle fx.Lifecycle, repository *InstrumentRepository) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return repository.LoadInstruments(ctx, types.Instruments)
+		},
+	})
+}
+
 // Repositories contains all repositories
 type Repositories struct {
 	OrderRepository      *OrderRepository
@@ -124,6 +124,78 @@ func (r *OrderRepository) GetOrdersBySymbol(ctx context.Context, symbol string,
 	return orders, nil
 }
 
+// OrderQuery selects orders by their indexed columns. Empty fields match
+// every order; a zero Limit returns every match.
+type OrderQuery struct {
+	UserID        string
+	ClientOrderID string
+	Symbol        string
+	Side          string
+	Type          string
+	Status        string
+	StartTime     time.Time
+	EndTime       time.Time
+	Limit         int
+	Offset        int
+}
+
+// FindOrders gets the orders matching a query, newest first
+func (r *OrderRepository) FindOrders(ctx context.Context, query *OrderQuery) ([]*db.Order, error) {
+	tx := r.db.WithContext(ctx)
+	for _, filter := range []struct{ column, value string }{
+		{"user_id", query.UserID},
+		{"client_order_id", query.ClientOrderID},
+		{"symbol", query.Symbol},
+		{"side", query.Side},
+		{"type", query.Type},
+		{"status", query.Status},
+	} {
+		if filter.value != "" {
+			tx = tx.Where(filter.column+" = ?", filter.value)
+		}
+	}
+	if !query.StartTime.IsZero() {
+		tx = tx.Where("created_at >= ?", query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		tx = tx.Where("created_at <= ?", query.EndTime)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	var orders []*db.Order
+	result := tx.Order("created_at DESC").Find(&orders)
+	if result.Error != nil {
+		r.logger.Error("Failed to find orders",
+			zap.Error(result.Error),
+			zap.String("user_id", query.UserID),
+			zap.String("symbol", query.Symbol))
+		return nil, result.Error
+	}
+	return orders, nil
+}
+
+// GetActiveOrders gets the orders of every user that can still trade
+func (r *OrderRepository) GetActiveOrders(ctx context.Context) ([]*db.Order, error) {
+	var orders []*db.Order
+	result := r.db.WithContext(ctx).
+		Where("status IN ?", []string{
+			"new", "pending_new", "pending", "partially_filled",
+			"pending_cancel", "pending_replace", "suspended", "done_for_day",
+		}).
+		Order("created_at ASC").
+		Find(&orders)
+	if result.Error != nil {
+		r.logger.Error("Failed to get active orders", zap.Error(result.Error))
+		return nil, result.Error
+	}
+	return orders, nil
+}
+
 // GetActiveOrdersByUserID gets active orders by user ID
 func (r *OrderRepository) GetActiveOrdersByUserID(ctx context.Context, userID string) ([]*db.Order, error) {
 	var orders []*db.Order
@@ -200,3 +272,136 @@ func (r *OrderRepository) GetOrdersWithTrades(ctx context.Context, userID string
 	}
 	return orders, nil
 }
+
+// SaveOrderGroup creates or updates a contingent order group
+func (r *OrderRepository) SaveOrderGroup(ctx context.Context, group *db.OrderGroup) error {
+	result := r.db.WithContext(ctx).Save(group)
+	if result.Error != nil {
+		r.logger.Error("Failed to save order group", zap.Error(result.Error), zap.String("group_id", group.ID))
+		return result.Error
+	}
+	return nil
+}
+
+// GetOrderGroup gets a contingent order group by ID
+func (r *OrderRepository) GetOrderGroup(ctx context.Context, id string) (*db.OrderGroup, error) {
+	var group db.OrderGroup
+	result := r.db.WithContext(ctx).First(&group, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get order group by ID", zap.Error(result.Error), zap.String("group_id", id))
+		return nil, result.Error
+	}
+	return &group, nil
+}
+
+// GetOpenOrderGroupsByUserID gets the pending and active order groups of a user
+func (r *OrderRepository) GetOpenOrderGroupsByUserID(ctx context.Context, userID string) ([]*db.OrderGroup, error) {
+	var groups []*db.OrderGroup
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, []string{"pending", "active"}).
+		Order("created_at DESC").
+		Find(&groups)
+	if result.Error != nil {
+		r.logger.Error("Failed to get open order groups by user ID",
+			zap.Error(result.Error),
+			zap.String("user_id", userID))
+		return nil, result.Error
+	}
+	return groups, nil
+}
+
+// CreateAuditRecord records an order control action
+func (r *OrderRepository) CreateAuditRecord(ctx context.Context, record *db.AuditRecord) error {
+	result := r.db.WithContext(ctx).Create(record)
+	if result.Error != nil {
+		r.logger.Error("Failed to create audit record",
+			zap.Error(result.Error),
+			zap.String("action", record.Action))
+		return result.Error
+	}
+	return nil
+}
+
+// GetAuditRecords gets the order control actions taken on an entity, newest
+// first. An empty scope returns the actions on every entity.
+func (r *OrderRepository) GetAuditRecords(ctx context.Context, scope, value string, limit int) ([]*db.AuditRecord, error) {
+	var records []*db.AuditRecord
+	query := r.db.WithContext(ctx)
+	if scope != "" {
+		query = query.Where("scope = ? AND value = ?", scope, value)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	result := query.Order("created_at DESC").Find(&records)
+	if result.Error != nil {
+		r.logger.Error("Failed to get audit records",
+			zap.Error(result.Error),
+			zap.String("scope", scope),
+			zap.String("value", value))
+		return nil, result.Error
+	}
+	return records, nil
+}
+
+// SaveKillSwitch creates or updates an engaged kill switch
+func (r *OrderRepository) SaveKillSwitch(ctx context.Context, killSwitch *db.KillSwitch) error {
+	result := r.db.WithContext(ctx).Save(killSwitch)
+	if result.Error != nil {
+		r.logger.Error("Failed to save kill switch", zap.Error(result.Error), zap.String("kill_switch_id", killSwitch.ID))
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteKillSwitch removes a disengaged kill switch
+func (r *OrderRepository) DeleteKillSwitch(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&db.KillSwitch{}, "id = ?", id)
+	if result.Error != nil {
+		r.logger.Error("Failed to delete kill switch", zap.Error(result.Error), zap.String("kill_switch_id", id))
+		return result.Error
+	}
+	return nil
+}
+
+// GetKillSwitches gets the engaged kill switches, oldest first
+func (r *OrderRepository) GetKillSwitches(ctx context.Context) ([]*db.KillSwitch, error) {
+	var killSwitches []*db.KillSwitch
+	result := r.db.WithContext(ctx).Order("enabled_at ASC").Find(&killSwitches)
+	if result.Error != nil {
+		r.logger.Error("Failed to get kill switches", zap.Error(result.Error))
+		return nil, result.Error
+	}
+	return killSwitches, nil
+}
+
+// CreateOrderTransition records an order status change
+func (r *OrderRepository) CreateOrderTransition(ctx context.Context, transition *db.OrderTransition) error {
+	result := r.db.WithContext(ctx).Create(transition)
+	if result.Error != nil {
+		r.logger.Error("Failed to create order transition",
+			zap.Error(result.Error),
+			zap.String("order_id", transition.OrderID))
+		return result.Error
+	}
+	return nil
+}
+
+// GetOrderTransitions gets the status changes of an order, oldest first
+func (r *OrderRepository) GetOrderTransitions(ctx context.Context, orderID string) ([]*db.OrderTransition, error) {
+	var transitions []*db.OrderTransition
+	result := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, sequence ASC").
+		Find(&transitions)
+	if result.Error != nil {
+		r.logger.Error("Failed to get order transitions",
+			zap.Error(result.Error),
+			zap.String("order_id", orderID))
+		return nil, result.Error
+	}
+	return transitions, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/abdoElHodaky/tradSys/internal/core/matching"
 	"github.com/abdoElHodaky/tradSys/internal/marketdata/external"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -23,6 +24,8 @@ const (
 	MarketDataTypeTicker MarketDataType = "ticker"
 	// MarketDataTypeOHLCV represents OHLCV data
 	MarketDataTypeOHLCV MarketDataType = "ohlcv"
+	// MarketDataTypeTradingStatus represents trading halts and resumptions
+	MarketDataTypeTradingStatus MarketDataType = "trading_status"
 )
 
 // OrderBookUpdate represents an order book update
@@ -30,9 +33,9 @@ type OrderBookUpdate struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Bids is the bids
-	Bids [][]float64
+	Bids [][]types.Decimal
 	// Asks is the asks
-	Asks [][]float64
+	Asks [][]types.Decimal
 	// Timestamp is the time of the update
 	Timestamp time.Time
 }
@@ -42,9 +45,9 @@ type TradeUpdate struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Price is the price of the trade
-	Price float64
+	Price types.Decimal
 	// Quantity is the quantity of the trade
-	Quantity float64
+	Quantity types.Decimal
 	// Side is the side of the trade
 	Side string
 	// Timestamp is the time of the trade
@@ -136,6 +139,7 @@ func NewHandler(engine *order_matching.Engine, logger *zap.Logger) *Handler {
 	handler.TypeSubscriptions[MarketDataTypeTrade] = make(map[string]*Subscription)
 	handler.TypeSubscriptions[MarketDataTypeTicker] = make(map[string]*Subscription)
 	handler.TypeSubscriptions[MarketDataTypeOHLCV] = make(map[string]*Subscription)
+	handler.TypeSubscriptions[MarketDataTypeTradingStatus] = make(map[string]*Subscription)
 
 	// Start trade processor
 	go handler.processTrades()
@@ -149,6 +153,9 @@ func NewHandler(engine *order_matching.Engine, logger *zap.Logger) *Handler {
 	// Start OHLCV processor
 	go handler.processOHLCV()
 
+	// Follow trading halts and resumptions
+	engine.OnTradingStatus(handler.publishTradingStatus)
+
 	return handler
 }
 
@@ -226,10 +233,10 @@ func (h *Handler) processTrades() {
 			}
 
 			// Update ticker
-			h.updateTicker(trade.Symbol, trade.Price, trade.Quantity)
+			h.updateTicker(trade.Symbol, trade.Price.Float64(), trade.Quantity.Float64())
 
 			// Update OHLCV
-			h.updateOHLCV(trade.Symbol, trade.Price, trade.Quantity, trade.Timestamp)
+			h.updateOHLCV(trade.Symbol, trade.Price.Float64(), trade.Quantity.Float64(), trade.Timestamp)
 
 			// Send to subscribers
 			h.mu.RLock()
@@ -455,6 +462,26 @@ func (h *Handler) updateOHLCV(symbol string, price, quantity float64, timestamp
 	}
 }
 
+// publishTradingStatus sends a trading halt or resumption to the symbol's
+// status subscribers, or to every status subscriber for market-wide halts
+func (h *Handler) publishTradingStatus(status types.TradingStatus) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, sub := range h.TypeSubscriptions[MarketDataTypeTradingStatus] {
+		if status.Symbol != "" && sub.Symbol != status.Symbol {
+			continue
+		}
+		select {
+		case sub.Channel <- status:
+		default:
+			h.logger.Warn("Trading status channel full, dropping update",
+				zap.String("subscription_id", sub.ID),
+				zap.String("symbol", status.Symbol))
+		}
+	}
+}
+
 // Stop stops the handler
 func (h *Handler) Stop() {
 	h.cancel()
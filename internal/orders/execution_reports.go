@@ -0,0 +1,298 @@
+package orders
+
+import (
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+)
+
+// ExecType identifies the event an execution report describes. Each type
+// has an EXEC_ counterpart in the proto ExecType enum.
+type ExecType string
+
+const (
+	// ExecNew reports an order accepted into the book
+	ExecNew ExecType = "new"
+	// ExecPartialFill reports a fill leaving part of the order open
+	ExecPartialFill ExecType = "partial_fill"
+	// ExecFill reports the fill completing the order
+	ExecFill ExecType = "fill"
+	// ExecCancelled reports a cancelled order
+	ExecCancelled ExecType = "cancelled"
+	// ExecRejected reports a rejected order
+	ExecRejected ExecType = "rejected"
+	// ExecExpired reports an expired order
+	ExecExpired ExecType = "expired"
+	// ExecPendingNew reports an order sent to the matching engine
+	ExecPendingNew ExecType = "pending_new"
+	// ExecPendingCancel reports a cancel request awaiting the engine
+	ExecPendingCancel ExecType = "pending_cancel"
+	// ExecPendingReplace reports an amend request awaiting the engine
+	ExecPendingReplace ExecType = "pending_replace"
+	// ExecReplaced reports an applied amend
+	ExecReplaced ExecType = "replaced"
+	// ExecSuspended reports an order suspended by a trading halt
+	ExecSuspended ExecType = "suspended"
+	// ExecDoneForDay reports an order held at the end of the trading day
+	ExecDoneForDay ExecType = "done_for_day"
+	// ExecRestated reports any other status change, such as an order going
+	// back to work after a halt
+	ExecRestated ExecType = "restated"
+)
+
+const (
+	// DefaultExecutionBacklog is how many execution reports are kept for
+	// subscribers resuming after a reconnect
+	DefaultExecutionBacklog = 10000
+	// executionBuffer is how many reports a subscriber may fall behind
+	// before it is dropped
+	executionBuffer = 1000
+)
+
+// ExecutionReport describes one event in the life of an order. Sequence
+// numbers the reports of a service in the order they were published and
+// restarts with the service; Session tells the runs apart.
+type ExecutionReport struct {
+	// Session identifies the run of the service that published the report
+	Session string
+	// Sequence is the position of the report in the session's feed
+	Sequence uint64
+	// ExecType is the event reported
+	ExecType ExecType
+	// Reason is why the order changed status
+	Reason TransitionReason
+	// Order is the order as the event left it, without its tags and metadata
+	Order Order
+	// TradeID is the trade behind a fill
+	TradeID string
+	// LastQuantity is the quantity of a fill
+	LastQuantity types.Decimal
+	// LastPrice is the price of a fill
+	LastPrice types.Decimal
+	// Timestamp is the time of the event
+	Timestamp time.Time
+}
+
+// ExecutionFilter selects the execution reports of a subscription. Every
+// field set must match the order.
+type ExecutionFilter struct {
+	// UserID is the user whose orders are reported
+	UserID string
+	// AccountGroup is the account group whose orders are reported
+	AccountGroup string
+	// Symbol is the symbol whose orders are reported
+	Symbol string
+}
+
+// matches reports whether an execution report falls under the filter
+func (f ExecutionFilter) matches(report *ExecutionReport) bool {
+	return (f.UserID == "" || report.Order.UserID == f.UserID) &&
+		(f.AccountGroup == "" || report.Order.AccountGroup == f.AccountGroup) &&
+		(f.Symbol == "" || report.Order.Symbol == f.Symbol)
+}
+
+// ExecutionSubscription delivers the execution reports a filter selects.
+// The channel is closed when the subscription is closed or falls too far
+// behind.
+type ExecutionSubscription struct {
+	feed    *executionFeed
+	filter  ExecutionFilter
+	reports chan *ExecutionReport
+}
+
+// Reports returns the channel delivering the subscription's reports
+func (s *ExecutionSubscription) Reports() <-chan *ExecutionReport {
+	return s.reports
+}
+
+// Close ends the subscription
+func (s *ExecutionSubscription) Close() {
+	s.feed.unsubscribe(s)
+}
+
+// executionFeed numbers execution reports within a session, keeps the
+// latest for replay and fans them out to subscribers
+type executionFeed struct {
+	mu          sync.Mutex
+	session     string
+	sequence    uint64
+	backlog     []*ExecutionReport
+	capacity    int
+	subscribers map[*ExecutionSubscription]struct{}
+}
+
+// newExecutionFeed creates a feed for a new session keeping capacity
+// reports for replay
+func newExecutionFeed(capacity int) *executionFeed {
+	return &executionFeed{
+		session:     uuid.New().String(),
+		capacity:    capacity,
+		subscribers: make(map[*ExecutionSubscription]struct{}),
+	}
+}
+
+// publish numbers a report and delivers it to the subscribers it matches.
+// A subscriber whose buffer is full is dropped rather than blocking the
+// order flow.
+func (f *executionFeed) publish(report *ExecutionReport) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sequence++
+	report.Session = f.session
+	report.Sequence = f.sequence
+	f.backlog = append(f.backlog, report)
+	if len(f.backlog) >= 2*f.capacity {
+		f.backlog = append([]*ExecutionReport(nil), f.backlog[len(f.backlog)-f.capacity:]...)
+	}
+
+	for subscriber := range f.subscribers {
+		if !subscriber.filter.matches(report) {
+			continue
+		}
+		select {
+		case subscriber.reports <- report:
+		default:
+			delete(f.subscribers, subscriber)
+			close(subscriber.reports)
+		}
+	}
+}
+
+// subscribe registers a subscriber, first queueing the reports published
+// after fromSequence in session. A zero fromSequence delivers only new
+// reports.
+func (f *executionFeed) subscribe(filter ExecutionFilter, session string, fromSequence uint64) (*ExecutionSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var replay []*ExecutionReport
+	if fromSequence > 0 {
+		if session != f.session || fromSequence > f.sequence {
+			return nil, ErrSequenceUnavailable
+		}
+		if fromSequence < f.sequence {
+			oldest := f.backlog[0].Sequence
+			if fromSequence+1 < oldest {
+				return nil, ErrSequenceUnavailable
+			}
+			for _, report := range f.backlog[fromSequence+1-oldest:] {
+				if filter.matches(report) {
+					replay = append(replay, report)
+				}
+			}
+		}
+	}
+
+	subscription := &ExecutionSubscription{
+		feed:    f,
+		filter:  filter,
+		reports: make(chan *ExecutionReport, len(replay)+executionBuffer),
+	}
+	for _, report := range replay {
+		subscription.reports <- report
+	}
+	f.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// unsubscribe removes a subscriber and closes its channel
+func (f *executionFeed) unsubscribe(subscription *ExecutionSubscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, exists := f.subscribers[subscription]; exists {
+		delete(f.subscribers, subscription)
+		close(subscription.reports)
+	}
+}
+
+// SubscribeExecutions streams the execution reports of the orders filter
+// selects. A client reconnecting passes the session and sequence of the
+// last report it received to get the reports it missed first;
+// ErrSequenceUnavailable means they are no longer kept, or were published
+// before the service restarted, and the client must reload its orders.
+func (s *OrderService) SubscribeExecutions(filter ExecutionFilter, session string, fromSequence uint64) (*ExecutionSubscription, error) {
+	return s.executions.subscribe(filter, session, fromSequence)
+}
+
+// reportFill publishes the execution of an order in a trade. Call it once
+// the trade is booked on the order, before its status follows.
+func (s *OrderService) reportFill(order *Order, trade *Trade) {
+	status, reason := workingStatus(order)
+	execType := ExecPartialFill
+	if status == OrderStatusFilled {
+		execType = ExecFill
+	}
+
+	report := &ExecutionReport{
+		ExecType:     execType,
+		Reason:       reason,
+		Order:        snapshotOrder(order),
+		TradeID:      trade.ID,
+		LastQuantity: trade.Quantity,
+		LastPrice:    trade.Price,
+		Timestamp:    trade.ExecutedAt,
+	}
+	report.Order.Status = status
+	s.executions.publish(report)
+}
+
+// reportStateChange publishes an order status change. Changes made by
+// fills were reported with the fill.
+func (s *OrderService) reportStateChange(order *Order, change *OrderStateChange) {
+	if change.Reason == ReasonPartiallyFilled || change.Reason == ReasonFullyFilled {
+		return
+	}
+
+	report := &ExecutionReport{
+		ExecType:  execTypeFor(change),
+		Reason:    change.Reason,
+		Order:     snapshotOrder(order),
+		Timestamp: change.Timestamp,
+	}
+	report.Order.Status = change.ToStatus
+	s.executions.publish(report)
+}
+
+// execTypeFor returns the execution report type of a status change
+func execTypeFor(change *OrderStateChange) ExecType {
+	switch change.ToStatus {
+	case OrderStatusPendingNew:
+		return ExecPendingNew
+	case OrderStatusPendingCancel:
+		return ExecPendingCancel
+	case OrderStatusPendingReplace:
+		return ExecPendingReplace
+	case OrderStatusCancelled:
+		return ExecCancelled
+	case OrderStatusRejected:
+		return ExecRejected
+	case OrderStatusExpired:
+		return ExecExpired
+	case OrderStatusSuspended:
+		return ExecSuspended
+	case OrderStatusDoneForDay:
+		return ExecDoneForDay
+	}
+	switch change.Reason {
+	case ReasonAccepted:
+		return ExecNew
+	case ReasonReplaced:
+		return ExecReplaced
+	default:
+		return ExecRestated
+	}
+}
+
+// snapshotOrder copies an order for a report, leaving out its tags and
+// metadata
+func snapshotOrder(order *Order) Order {
+	snapshot := *order
+	snapshot.Trades = append([]*Trade(nil), order.Trades...)
+	snapshot.Tags = nil
+	snapshot.Metadata = nil
+	return snapshot
+}
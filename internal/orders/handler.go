@@ -2,8 +2,15 @@ package orders
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/db"
 	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/abdoElHodaky/tradSys/proto/orders"
 	"github.com/abdoElHodaky/tradSys/proto/risk"
 	"github.com/google/uuid"
@@ -21,6 +28,7 @@ type HandlerParams struct {
 	Logger          *zap.Logger
 	Repository      *repositories.OrderRepository `optional:"true"`
 	RiskServiceConn *grpc.ClientConn              `optional:"true" name:"riskService"`
+	Service         *OrderService                 `optional:"true"`
 }
 
 // Handler implements the OrderService handler
@@ -29,6 +37,7 @@ type Handler struct {
 	logger     *zap.Logger
 	repository *repositories.OrderRepository
 	riskClient risk.RiskServiceClient
+	service    *OrderService
 }
 
 // NewHandler creates a new order handler with fx dependency injection
@@ -42,6 +51,7 @@ func NewHandler(p HandlerParams) *Handler {
 		logger:     p.Logger,
 		repository: p.Repository,
 		riskClient: riskClient,
+		service:    p.Service,
 	}
 }
 
@@ -75,6 +85,52 @@ func (h *Handler) CreateOrder(ctx context.Context, req *orders.CreateOrderReques
 		}
 	}
 
+	// Reject orders that break the instrument or time in force rules with a
+	// specific reason code
+	order := instrumentOrderFromRequest(req)
+	err := types.Instruments.Validate(order, types.Zero)
+	if err == nil {
+		err = order.ValidateTimeInForce(time.Now())
+	}
+	if err != nil {
+		h.logger.Warn("Order rejected by instrument rules",
+			zap.String("symbol", req.Symbol),
+			zap.Error(err))
+		return &orders.OrderResponse{
+			Id:            uuid.New().String(),
+			Symbol:        req.Symbol,
+			Type:          req.Type,
+			Side:          req.Side,
+			Status:        orders.OrderStatus_REJECTED,
+			Quantity:      req.Quantity,
+			Price:         req.Price,
+			StopPrice:     req.StopPrice,
+			ClientOrderId: req.ClientOrderId,
+			RejectReason:  rejectReasonToProto(types.RejectReasonOf(err)),
+			RejectMessage: err.Error(),
+		}, nil
+	}
+
+	// Place the order when the service is wired in. A retried request with
+	// the same client order ID gets the original order back.
+	if h.service != nil {
+		order, replayed, err := h.service.PlaceOrder(ctx, orderRequestFromProto(req.UserId, req))
+		switch {
+		case errors.Is(err, ErrKillSwitchEngaged):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case err != nil && order != nil:
+			return nil, status.Errorf(codes.Internal, "failed to place order: %v", err)
+		case err != nil:
+			return nil, status.Errorf(codes.InvalidArgument, "Order validation failed: %v", err)
+		}
+		if replayed {
+			h.logger.Info("CreateOrder replayed",
+				zap.String("order_id", order.ID),
+				zap.String("client_order_id", req.ClientOrderId))
+		}
+		return orderToProto(order), nil
+	}
+
 	// Implementation would go here
 	// For now, just return a placeholder response
 	rsp := &orders.OrderResponse{
@@ -141,6 +197,75 @@ func (h *Handler) CancelOrder(ctx context.Context, req *orders.CancelOrderReques
 	return rsp, nil
 }
 
+// AmendOrder implements the OrderService.AmendOrder method
+func (h *Handler) AmendOrder(ctx context.Context, req *orders.AmendOrderRequest) (*orders.OrderResponse, error) {
+	h.logger.Info("AmendOrder called",
+		zap.String("order_id", req.Id),
+		zap.Float64("price", req.Price),
+		zap.Float64("quantity", req.Quantity))
+
+	if req.Price < 0 || req.Quantity < 0 || (req.Price == 0 && req.Quantity == 0) {
+		return nil, status.Error(codes.InvalidArgument, "amend requires a positive price or quantity")
+	}
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	// Users can only amend their own orders
+	order, err := h.service.GetOrder(ctx, req.Id)
+	if err == nil && req.UserId != "" && order.UserID != req.UserId {
+		err = ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, amendStatus(err)
+	}
+
+	updateReq := &OrderUpdateRequest{
+		UserID:  req.UserId,
+		OrderID: req.Id,
+		Symbol:  order.Symbol,
+	}
+	if req.Price > 0 {
+		updateReq.Price = exactDecimal(req.Price)
+	}
+	if req.Quantity > 0 {
+		updateReq.Quantity = exactDecimal(req.Quantity)
+	}
+
+	order, err = h.service.UpdateOrder(ctx, updateReq)
+	if err != nil {
+		h.logger.Warn("Order amend rejected",
+			zap.String("order_id", req.Id),
+			zap.Error(err))
+		return nil, amendStatus(err)
+	}
+	return orderToProto(order), nil
+}
+
+// amendStatus maps an amend failure from the order service or the matching
+// engine to a gRPC status
+func amendStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrOrderCannotBeUpdated), errors.Is(err, ErrInvalidStatusTransition),
+		errors.Is(err, ErrReduceOnlyExceedsPosition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case types.RejectReasonOf(err) != types.RejectReasonNone:
+		return status.Errorf(codes.InvalidArgument, "amend rejected: %v", err)
+	}
+	for _, invalid := range []error{
+		ErrInvalidOrderRequest, ErrNoFieldsToUpdate, ErrQuantityBelowFilled,
+		ErrInvalidPricePrecision, ErrInvalidQuantityPrecision, ErrInvalidTimeInForce,
+		ErrOrderSizeExceedsLimit, ErrOrderSizeBelowMinimum, ErrPriceBelowMinimum, ErrPriceExceedsMaximum,
+	} {
+		if errors.Is(err, invalid) {
+			return status.Errorf(codes.InvalidArgument, "amend rejected: %v", err)
+		}
+	}
+	return status.Errorf(codes.Internal, "failed to amend order: %v", err)
+}
+
 // GetOrders implements the OrderService.GetOrders method
 func (h *Handler) GetOrders(ctx context.Context, req *orders.GetOrdersRequest) (*orders.GetOrdersResponse, error) {
 	h.logger.Info("GetOrders called",
@@ -181,7 +306,703 @@ func (h *Handler) GetOrders(ctx context.Context, req *orders.GetOrdersRequest) (
 	return rsp, nil
 }
 
-// OrdersModule provides the orders handler module for fx
+// CreateOrderGroup implements the OrderService.CreateOrderGroup method
+func (h *Handler) CreateOrderGroup(ctx context.Context, req *orders.CreateOrderGroupRequest) (*orders.OrderGroupResponse, error) {
+	h.logger.Info("CreateOrderGroup called",
+		zap.String("type", req.Type.String()),
+		zap.Int("legs", len(req.Legs)))
+
+	groupReq := orderGroupRequestFromProto(req)
+	if err := NewOrderValidator(h.logger).ValidateOrderGroupRequest(ctx, groupReq); err != nil {
+		h.logger.Warn("Order group validation failed",
+			zap.String("type", req.Type.String()),
+			zap.Error(err))
+		return nil, status.Errorf(codes.InvalidArgument, "Order group validation failed: %v", err)
+	}
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	// A group comes back with the first submission error once its orders
+	// are stored; the group already reflects the orders the error closed
+	group, err := h.service.CreateOrderGroup(ctx, groupReq)
+	if group == nil {
+		return nil, orderGroupStatus(err)
+	}
+	if err != nil {
+		h.logger.Warn("Order group submission failed",
+			zap.String("group_id", group.ID),
+			zap.Error(err))
+	}
+	return orderGroupToProto(group.record()), nil
+}
+
+// GetOrderGroup implements the OrderService.GetOrderGroup method
+func (h *Handler) GetOrderGroup(ctx context.Context, req *orders.GetOrderGroupRequest) (*orders.OrderGroupResponse, error) {
+	h.logger.Info("GetOrderGroup called",
+		zap.String("group_id", req.Id))
+
+	group, err := h.orderGroup(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return orderGroupToProto(group), nil
+}
+
+// CancelOrderGroup implements the OrderService.CancelOrderGroup method
+func (h *Handler) CancelOrderGroup(ctx context.Context, req *orders.CancelOrderGroupRequest) (*orders.OrderGroupResponse, error) {
+	h.logger.Info("CancelOrderGroup called",
+		zap.String("group_id", req.Id))
+
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	group, err := h.service.CancelOrderGroup(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, orderGroupStatus(err)
+	}
+	return orderGroupToProto(group.record()), nil
+}
+
+// StreamOrders implements the OrderService.StreamOrders method. It streams
+// execution reports for the user's orders, limited to an account and symbol
+// when set; a client reconnecting passes the session and sequence of the
+// last report it received to get the ones it missed first. The stream is the client's
+// session: when it was opened with cancel_on_disconnect, the user's open
+// orders are cancelled once it ends.
+func (h *Handler) StreamOrders(req *orders.StreamOrdersRequest, stream orders.OrderService_StreamOrdersServer) error {
+	h.logger.Info("StreamOrders called",
+		zap.String("user_id", req.UserId),
+		zap.String("session", req.Session),
+		zap.Uint64("from_sequence", req.FromSequence),
+		zap.Bool("cancel_on_disconnect", req.CancelOnDisconnect))
+
+	if req.UserId == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if h.service == nil {
+		return status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	subscription, err := h.service.SubscribeExecutions(ExecutionFilter{
+		UserID:       req.UserId,
+		AccountGroup: req.AccountId,
+		Symbol:       req.Symbol,
+	}, req.Session, req.FromSequence)
+	if errors.Is(err, ErrSequenceUnavailable) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer subscription.Close()
+
+	sessionID := "grpc:" + uuid.New().String()
+	h.service.OpenSession(sessionID, req.UserId, req.CancelOnDisconnect)
+	defer h.service.CloseSession(context.Background(), sessionID)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case report, ok := <-subscription.Reports():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "stream fell behind; reconnect with from_sequence")
+			}
+			if err := stream.Send(executionReportToProto(report)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// MassCancel implements the OrderService.MassCancel method. The actor is
+// the authenticated caller; without a control role only the caller's own
+// orders are cancelled.
+func (h *Handler) MassCancel(ctx context.Context, req *orders.MassCancelRequest) (*orders.MassCancelResponse, error) {
+	h.logger.Info("MassCancel called",
+		zap.String("user_id", req.UserId),
+		zap.String("account_id", req.AccountId),
+		zap.String("symbol", req.Symbol),
+		zap.String("strategy", req.Strategy))
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	cancelReq := &MassCancelRequest{
+		UserID:       req.UserId,
+		AccountGroup: req.AccountId,
+		Symbol:       req.Symbol,
+		Strategy:     req.Strategy,
+		Actor:        caller.Username,
+		Reason:       req.Reason,
+	}
+	if !auth.HasRole(caller.Role, auth.ControlRoles...) {
+		cancelReq.UserID = caller.UserID
+	}
+	if req.Side != nil {
+		cancelReq.Side = OrderSideBuy
+		if *req.Side == orders.OrderSide_SELL {
+			cancelReq.Side = OrderSideSell
+		}
+	}
+
+	cancelled, err := h.service.MassCancel(ctx, cancelReq)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &orders.MassCancelResponse{
+		CancelledOrderIds: idsOf(cancelled),
+		Total:             int32(len(cancelled)),
+	}, nil
+}
+
+// SetKillSwitch implements the OrderService.SetKillSwitch method. Only
+// callers with a control role may change kill switches, and the actor is
+// the authenticated caller.
+func (h *Handler) SetKillSwitch(ctx context.Context, req *orders.SetKillSwitchRequest) (*orders.KillSwitchResponse, error) {
+	h.logger.Info("SetKillSwitch called",
+		zap.String("scope", req.Scope.String()),
+		zap.String("value", req.Value),
+		zap.Bool("enabled", req.Enabled))
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.HasRole(caller.Role, auth.ControlRoles...) {
+		return nil, status.Error(codes.PermissionDenied, "not authorized for order control")
+	}
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	scope := killSwitchScopeFromProto(req.Scope)
+	if !req.Enabled {
+		killSwitch, err := h.service.DisableKillSwitch(ctx, scope, req.Value, caller.Username, req.Reason)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		rsp := killSwitchToProto(killSwitch)
+		rsp.Enabled = false
+		return rsp, nil
+	}
+
+	killSwitch, cancelled, err := h.service.EnableKillSwitch(ctx, &KillSwitchRequest{
+		Scope:            scope,
+		Value:            req.Value,
+		Actor:            caller.Username,
+		Reason:           req.Reason,
+		CancelOpenOrders: req.CancelOpenOrders,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rsp := killSwitchToProto(killSwitch)
+	rsp.CancelledOrderIds = idsOf(cancelled)
+	return rsp, nil
+}
+
+// GetKillSwitches implements the OrderService.GetKillSwitches method
+func (h *Handler) GetKillSwitches(ctx context.Context, req *orders.GetKillSwitchesRequest) (*orders.GetKillSwitchesResponse, error) {
+	h.logger.Info("GetKillSwitches called")
+
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+
+	rsp := &orders.GetKillSwitchesResponse{}
+	for _, killSwitch := range h.service.GetKillSwitches() {
+		rsp.KillSwitches = append(rsp.KillSwitches, killSwitchToProto(killSwitch))
+	}
+	return rsp, nil
+}
+
+// GetOrderHistory implements the OrderService.GetOrderHistory method. Only
+// callers with a support role may leave the user ID empty to look up any
+// user's order.
+func (h *Handler) GetOrderHistory(ctx context.Context, req *orders.GetOrderHistoryRequest) (*orders.GetOrderHistoryResponse, error) {
+	h.logger.Info("GetOrderHistory called",
+		zap.String("order_id", req.OrderId),
+		zap.String("user_id", req.UserId))
+
+	if req.UserId == "" {
+		caller, err := callerOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !auth.HasRole(caller.Role, auth.SupportRoles...) {
+			return nil, status.Error(codes.PermissionDenied, "not authorized to look up other users' orders")
+		}
+	}
+	if h.service == nil {
+		return nil, status.Error(codes.Unavailable, "order service unavailable")
+	}
+	history, err := h.service.GetOrderHistory(ctx, req.UserId, req.OrderId)
+	if errors.Is(err, ErrOrderNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order history: %v", err)
+	}
+
+	rsp := &orders.GetOrderHistoryResponse{OrderId: req.OrderId}
+	for _, change := range history {
+		rsp.Transitions = append(rsp.Transitions, stateChangeToProto(change))
+	}
+	return rsp, nil
+}
+
+// orderGroup loads a user's order group from the order service, or from
+// the repository without one
+func (h *Handler) orderGroup(ctx context.Context, groupID, userID string) (*db.OrderGroup, error) {
+	if h.service != nil {
+		group, err := h.service.GetOrderGroup(ctx, groupID)
+		if err == nil && userID != "" && group.UserID != userID {
+			err = ErrOrderGroupNotFound
+		}
+		if err != nil {
+			return nil, orderGroupStatus(err)
+		}
+		return group.record(), nil
+	}
+	if h.repository == nil {
+		return nil, status.Error(codes.NotFound, "order group not found")
+	}
+
+	group, err := h.repository.GetOrderGroup(ctx, groupID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order group: %v", err)
+	}
+	if group == nil || (userID != "" && group.UserID != userID) {
+		return nil, status.Error(codes.NotFound, "order group not found")
+	}
+	return group, nil
+}
+
+// callerOf returns the claims of the authenticated caller of a call
+func callerOf(ctx context.Context) (*auth.JWTClaims, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return claims, nil
+}
+
+// orderGroupStatus maps an order group failure from the order service to a
+// gRPC status
+func orderGroupStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrOrderGroupNotFound), errors.Is(err, ErrUnauthorizedOrderAccess):
+		return status.Error(codes.NotFound, ErrOrderGroupNotFound.Error())
+	case errors.Is(err, ErrOrderGroupClosed), errors.Is(err, ErrKillSwitchEngaged):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// orderGroupRequestFromProto converts a group request. Bracket exits are
+// built from the take-profit and stop-loss prices of the entry.
+func orderGroupRequestFromProto(req *orders.CreateOrderGroupRequest) *OrderGroupRequest {
+	groupReq := &OrderGroupRequest{
+		UserID: req.UserId,
+		Type:   OrderGroupOCO,
+	}
+	switch req.Type {
+	case orders.OrderGroupType_GROUP_OTO:
+		groupReq.Type = OrderGroupOTO
+	case orders.OrderGroupType_GROUP_BRACKET:
+		groupReq.Type = OrderGroupBracket
+	}
+
+	if req.Primary != nil {
+		groupReq.Primary = orderRequestFromProto(req.UserId, req.Primary)
+	}
+	for _, leg := range req.Legs {
+		groupReq.Legs = append(groupReq.Legs, orderRequestFromProto(req.UserId, leg))
+	}
+
+	if groupReq.Type == OrderGroupBracket && groupReq.Primary != nil && len(groupReq.Legs) == 0 {
+		entry := groupReq.Primary
+		exitSide := OrderSideSell
+		if entry.Side == OrderSideSell {
+			exitSide = OrderSideBuy
+		}
+		groupReq.Legs = []*OrderRequest{
+			{
+				UserID:      req.UserId,
+				Symbol:      entry.Symbol,
+				Side:        exitSide,
+				Type:        OrderTypeLimit,
+				Price:       exactDecimal(req.Primary.TakeProfit),
+				Quantity:    entry.Quantity,
+				TimeInForce: TimeInForceGTC,
+			},
+			{
+				UserID:      req.UserId,
+				Symbol:      entry.Symbol,
+				Side:        exitSide,
+				Type:        OrderTypeStopMarket,
+				StopPrice:   exactDecimal(req.Primary.StopLoss),
+				Quantity:    entry.Quantity,
+				TimeInForce: TimeInForceGTC,
+			},
+		}
+	}
+
+	return groupReq
+}
+
+// orderRequestFromProto converts a create request to an order request for
+// a user
+func orderRequestFromProto(userID string, req *orders.CreateOrderRequest) *OrderRequest {
+	order := instrumentOrderFromRequest(req)
+	return &OrderRequest{
+		UserID:        userID,
+		AccountGroup:  req.AccountId,
+		ClientOrderID: req.ClientOrderId,
+		Symbol:        order.Symbol,
+		Side:          OrderSide(order.Side),
+		Type:          OrderType(order.Type),
+		Price:         order.Price,
+		StopPrice:     order.StopPrice,
+		Quantity:      order.Quantity,
+		TimeInForce:   TimeInForce(order.TimeInForce),
+		ExpiresAt:     order.ExpireTime,
+		Tags:          req.Tags,
+	}
+}
+
+// killSwitchScopeFromProto converts a proto kill switch scope
+func killSwitchScopeFromProto(scope orders.KillSwitchScope) KillSwitchScope {
+	switch scope {
+	case orders.KillSwitchScope_SCOPE_ACCOUNT:
+		return KillSwitchAccount
+	case orders.KillSwitchScope_SCOPE_SYMBOL:
+		return KillSwitchSymbol
+	case orders.KillSwitchScope_SCOPE_STRATEGY:
+		return KillSwitchStrategy
+	default:
+		return KillSwitchUser
+	}
+}
+
+// killSwitchToProto converts an engaged kill switch to its proto response
+func killSwitchToProto(killSwitch *KillSwitch) *orders.KillSwitchResponse {
+	rsp := &orders.KillSwitchResponse{
+		Scope:     orders.KillSwitchScope_SCOPE_USER,
+		Value:     killSwitch.Value,
+		Enabled:   true,
+		Actor:     killSwitch.Actor,
+		Reason:    killSwitch.Reason,
+		EnabledAt: killSwitch.EnabledAt.UnixMilli(),
+	}
+	switch killSwitch.Scope {
+	case KillSwitchAccount:
+		rsp.Scope = orders.KillSwitchScope_SCOPE_ACCOUNT
+	case KillSwitchSymbol:
+		rsp.Scope = orders.KillSwitchScope_SCOPE_SYMBOL
+	case KillSwitchStrategy:
+		rsp.Scope = orders.KillSwitchScope_SCOPE_STRATEGY
+	}
+	return rsp
+}
+
+// orderToProto converts an order to its proto response
+func orderToProto(order *Order) *orders.OrderResponse {
+	rsp := &orders.OrderResponse{
+		Id:            order.ID,
+		UserId:        order.UserID,
+		AccountId:     order.AccountGroup,
+		Symbol:        order.Symbol,
+		Side:          orders.OrderSide_BUY,
+		Type:          orders.OrderType_LIMIT,
+		Quantity:      order.Quantity.Float64(),
+		Price:         order.Price.Float64(),
+		StopPrice:     order.StopPrice.Float64(),
+		TimeInForce:   orders.TimeInForce_GTC,
+		FilledQty:     order.FilledQuantity.Float64(),
+		ClientOrderId: order.ClientOrderID,
+		CreatedAt:     order.CreatedAt.UnixMilli(),
+		UpdatedAt:     order.UpdatedAt.UnixMilli(),
+		RejectReason:  rejectReasonToProto(order.RejectReason),
+		GroupId:       order.GroupID,
+		ParentOrderId: order.ParentOrderID,
+	}
+	if !order.ExpiresAt.IsZero() {
+		rsp.ExpiresAt = order.ExpiresAt.UnixMilli()
+	}
+	if order.Side == OrderSideSell {
+		rsp.Side = orders.OrderSide_SELL
+	}
+
+	switch order.Type {
+	case OrderTypeMarket:
+		rsp.Type = orders.OrderType_MARKET
+	case OrderTypeStopMarket:
+		rsp.Type = orders.OrderType_STOP
+	case OrderTypeStopLimit:
+		rsp.Type = orders.OrderType_STOP_LIMIT
+	}
+	switch order.TimeInForce {
+	case TimeInForceIOC:
+		rsp.TimeInForce = orders.TimeInForce_IOC
+	case TimeInForceFOK:
+		rsp.TimeInForce = orders.TimeInForce_FOK
+	case TimeInForceDay:
+		rsp.TimeInForce = orders.TimeInForce_DAY
+	case TimeInForceGTD:
+		rsp.TimeInForce = orders.TimeInForce_GTD
+	}
+	rsp.Status = orderStatusToProto(order.Status)
+
+	var notional, filled float64
+	for _, trade := range order.Trades {
+		notional += trade.Price.Float64() * trade.Quantity.Float64()
+		filled += trade.Quantity.Float64()
+	}
+	if filled > 0 {
+		rsp.AvgPrice = notional / filled
+	}
+	return rsp
+}
+
+// executionReportToProto converts an execution report to its proto response
+func executionReportToProto(report *ExecutionReport) *orders.OrderResponse {
+	rsp := orderToProto(&report.Order)
+	rsp.Session = report.Session
+	rsp.Sequence = report.Sequence
+	rsp.ExecType = execTypes[report.ExecType]
+	rsp.LastQty = report.LastQuantity.Float64()
+	rsp.LastPx = report.LastPrice.Float64()
+	rsp.TradeId = report.TradeID
+	rsp.Reason = transitionReasonToProto(report.Reason)
+	return rsp
+}
+
+// execTypes maps each execution report type to its proto enum
+var execTypes = map[ExecType]orders.ExecType{
+	ExecNew:            orders.ExecType_EXEC_NEW,
+	ExecPartialFill:    orders.ExecType_EXEC_PARTIAL_FILL,
+	ExecFill:           orders.ExecType_EXEC_FILL,
+	ExecCancelled:      orders.ExecType_EXEC_CANCELLED,
+	ExecRejected:       orders.ExecType_EXEC_REJECTED,
+	ExecExpired:        orders.ExecType_EXEC_EXPIRED,
+	ExecPendingNew:     orders.ExecType_EXEC_PENDING_NEW,
+	ExecPendingCancel:  orders.ExecType_EXEC_PENDING_CANCEL,
+	ExecPendingReplace: orders.ExecType_EXEC_PENDING_REPLACE,
+	ExecReplaced:       orders.ExecType_EXEC_REPLACED,
+	ExecSuspended:      orders.ExecType_EXEC_SUSPENDED,
+	ExecDoneForDay:     orders.ExecType_EXEC_DONE_FOR_DAY,
+	ExecRestated:       orders.ExecType_EXEC_RESTATED,
+}
+
+// orderStatusToProto maps an order status to its proto enum
+func orderStatusToProto(status OrderStatus) orders.OrderStatus {
+	switch status {
+	case OrderStatusPendingNew:
+		return orders.OrderStatus_PENDING_NEW
+	case OrderStatusPending:
+		return orders.OrderStatus_PENDING
+	case OrderStatusPartiallyFilled:
+		return orders.OrderStatus_PARTIAL
+	case OrderStatusFilled:
+		return orders.OrderStatus_FILLED
+	case OrderStatusPendingCancel:
+		return orders.OrderStatus_PENDING_CANCEL
+	case OrderStatusPendingReplace:
+		return orders.OrderStatus_PENDING_REPLACE
+	case OrderStatusCancelled:
+		return orders.OrderStatus_CANCELLED
+	case OrderStatusRejected:
+		return orders.OrderStatus_REJECTED
+	case OrderStatusExpired:
+		return orders.OrderStatus_EXPIRED
+	case OrderStatusSuspended:
+		return orders.OrderStatus_SUSPENDED
+	case OrderStatusDoneForDay:
+		return orders.OrderStatus_DONE_FOR_DAY
+	default:
+		return orders.OrderStatus_NEW
+	}
+}
+
+// stateChangeToProto converts an order status change to its proto message
+func stateChangeToProto(change *OrderStateChange) *orders.OrderTransition {
+	return &orders.OrderTransition{
+		Sequence:     int32(change.Sequence),
+		FromStatus:   orderStatusToProto(change.FromStatus),
+		ToStatus:     orderStatusToProto(change.ToStatus),
+		Reason:       transitionReasonToProto(change.Reason),
+		RejectReason: rejectReasonToProto(change.RejectReason),
+		FilledQty:    change.FilledQuantity.Float64(),
+		Timestamp:    change.Timestamp.UnixMilli(),
+	}
+}
+
+// orderGroupToProto converts a stored order group to its proto response
+func orderGroupToProto(group *db.OrderGroup) *orders.OrderGroupResponse {
+	rsp := &orders.OrderGroupResponse{
+		Id:             group.ID,
+		UserId:         group.UserID,
+		PrimaryOrderId: group.PrimaryOrderID,
+		CreatedAt:      group.CreatedAt.UnixMilli(),
+		UpdatedAt:      group.UpdatedAt.UnixMilli(),
+	}
+	_ = json.Unmarshal([]byte(group.LegOrderIDs), &rsp.LegOrderIds)
+
+	switch OrderGroupType(group.Type) {
+	case OrderGroupOTO:
+		rsp.Type = orders.OrderGroupType_GROUP_OTO
+	case OrderGroupBracket:
+		rsp.Type = orders.OrderGroupType_GROUP_BRACKET
+	default:
+		rsp.Type = orders.OrderGroupType_GROUP_OCO
+	}
+	switch OrderGroupStatus(group.Status) {
+	case OrderGroupStatusActive:
+		rsp.Status = orders.OrderGroupStatus_GROUP_ACTIVE
+	case OrderGroupStatusCompleted:
+		rsp.Status = orders.OrderGroupStatus_GROUP_COMPLETED
+	case OrderGroupStatusCancelled:
+		rsp.Status = orders.OrderGroupStatus_GROUP_CANCELLED
+	default:
+		rsp.Status = orders.OrderGroupStatus_GROUP_PENDING
+	}
+	return rsp
+}
+
+// instrumentOrderFromRequest converts a create request for instrument and
+// time in force validation
+func instrumentOrderFromRequest(req *orders.CreateOrderRequest) *types.Order {
+	order := &types.Order{
+		Symbol:    req.Symbol,
+		Side:      types.OrderSideBuy,
+		Type:      types.OrderTypeLimit,
+		Price:     exactDecimal(req.Price),
+		Quantity:  exactDecimal(req.Quantity),
+		StopPrice: exactDecimal(req.StopPrice),
+	}
+	if req.Side == orders.OrderSide_SELL {
+		order.Side = types.OrderSideSell
+	}
+	if req.ExpiresAt > 0 {
+		order.ExpireTime = time.UnixMilli(req.ExpiresAt)
+	}
+	switch req.TimeInForce {
+	case orders.TimeInForce_IOC:
+		order.TimeInForce = types.TimeInForceIOC
+	case orders.TimeInForce_FOK:
+		order.TimeInForce = types.TimeInForceFOK
+	case orders.TimeInForce_DAY:
+		order.TimeInForce = types.TimeInForceDay
+	case orders.TimeInForce_GTD:
+		order.TimeInForce = types.TimeInForceGTD
+	default:
+		order.TimeInForce = types.TimeInForceGTC
+	}
+	switch req.Type {
+	case orders.OrderType_MARKET:
+		order.Type = types.OrderTypeMarket
+	case orders.OrderType_STOP:
+		order.Type = types.OrderTypeStopMarket
+	case orders.OrderType_STOP_LIMIT:
+		order.Type = types.OrderTypeStopLimit
+	}
+	return order
+}
+
+// exactDecimal converts a wire float without rounding it onto the symbol
+// scale, so off-tick and off-lot values are still detected
+func exactDecimal(value float64) types.Decimal {
+	parsed, err := types.ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return types.NewDecimalFromFloat(value, types.MaxDecimalScale)
+	}
+	return parsed
+}
+
+// rejectReasonToProto maps a rejection code to its proto enum
+func rejectReasonToProto(reason types.RejectReason) orders.RejectReason {
+	switch reason {
+	case types.RejectReasonNone:
+		return orders.RejectReason_REJECT_NONE
+	case types.RejectReasonUnknownInstrument:
+		return orders.RejectReason_REJECT_UNKNOWN_INSTRUMENT
+	case types.RejectReasonInstrumentDisabled:
+		return orders.RejectReason_REJECT_INSTRUMENT_DISABLED
+	case types.RejectReasonInvalidTickSize:
+		return orders.RejectReason_REJECT_INVALID_TICK_SIZE
+	case types.RejectReasonInvalidLotSize:
+		return orders.RejectReason_REJECT_INVALID_LOT_SIZE
+	case types.RejectReasonQuantityBelowMinimum:
+		return orders.RejectReason_REJECT_QUANTITY_BELOW_MINIMUM
+	case types.RejectReasonQuantityAboveMaximum:
+		return orders.RejectReason_REJECT_QUANTITY_ABOVE_MAXIMUM
+	case types.RejectReasonNotionalBelowMinimum:
+		return orders.RejectReason_REJECT_NOTIONAL_BELOW_MINIMUM
+	case types.RejectReasonNotionalAboveMaximum:
+		return orders.RejectReason_REJECT_NOTIONAL_ABOVE_MAXIMUM
+	case types.RejectReasonPriceOutsideStaticBand:
+		return orders.RejectReason_REJECT_PRICE_OUTSIDE_STATIC_BAND
+	case types.RejectReasonPriceOutsideDynamicBand:
+		return orders.RejectReason_REJECT_PRICE_OUTSIDE_DYNAMIC_BAND
+	case types.RejectReasonInvalidTimeInForce:
+		return orders.RejectReason_REJECT_INVALID_TIME_IN_FORCE
+	default:
+		return orders.RejectReason_REJECT_INVALID_ORDER
+	}
+}
+
+// transitionReasons maps each transition reason to its proto enum
+var transitionReasons = map[TransitionReason]orders.TransitionReason{
+	ReasonOrderCreated:          orders.TransitionReason_REASON_ORDER_CREATED,
+	ReasonOrderRecovered:        orders.TransitionReason_REASON_ORDER_RECOVERED,
+	ReasonSubmitted:             orders.TransitionReason_REASON_SUBMITTED,
+	ReasonAccepted:              orders.TransitionReason_REASON_ACCEPTED,
+	ReasonPartiallyFilled:       orders.TransitionReason_REASON_PARTIALLY_FILLED,
+	ReasonFullyFilled:           orders.TransitionReason_REASON_FULLY_FILLED,
+	ReasonEngineRejected:        orders.TransitionReason_REASON_ENGINE_REJECTED,
+	ReasonCancelRequested:       orders.TransitionReason_REASON_CANCEL_REQUESTED,
+	ReasonUserCancelled:         orders.TransitionReason_REASON_USER_CANCELLED,
+	ReasonReplaceRequested:      orders.TransitionReason_REASON_REPLACE_REQUESTED,
+	ReasonReplaced:              orders.TransitionReason_REASON_REPLACED,
+	ReasonReplaceRejected:       orders.TransitionReason_REASON_REPLACE_REJECTED,
+	ReasonMassCancelled:         orders.TransitionReason_REASON_MASS_CANCELLED,
+	ReasonKillSwitch:            orders.TransitionReason_REASON_KILL_SWITCH,
+	ReasonCancelOnDisconnect:    orders.TransitionReason_REASON_CANCEL_ON_DISCONNECT,
+	ReasonIOCRemainderCancelled: orders.TransitionReason_REASON_IOC_REMAINDER_CANCELLED,
+	ReasonFOKNotFillable:        orders.TransitionReason_REASON_FOK_NOT_FILLABLE,
+	ReasonSelfTradePrevented:    orders.TransitionReason_REASON_SELF_TRADE_PREVENTED,
+	ReasonExpired:               orders.TransitionReason_REASON_EXPIRED,
+	ReasonGroupCancelled:        orders.TransitionReason_REASON_GROUP_CANCELLED,
+	ReasonGroupPrimaryClosed:    orders.TransitionReason_REASON_GROUP_PRIMARY_CLOSED,
+	ReasonGroupPrimaryFilled:    orders.TransitionReason_REASON_GROUP_PRIMARY_FILLED,
+	ReasonGroupLegClosed:        orders.TransitionReason_REASON_GROUP_LEG_CLOSED,
+	ReasonGroupLegExecuted:      orders.TransitionReason_REASON_GROUP_LEG_EXECUTED,
+	ReasonMissingAfterRecovery:  orders.TransitionReason_REASON_MISSING_AFTER_RECOVERY,
+	ReasonCancelledByEngine:     orders.TransitionReason_REASON_CANCELLED_BY_ENGINE,
+	ReasonExpiredByEngine:       orders.TransitionReason_REASON_EXPIRED_BY_ENGINE,
+	ReasonTradingHalted:         orders.TransitionReason_REASON_TRADING_HALTED,
+	ReasonTradingResumed:        orders.TransitionReason_REASON_TRADING_RESUMED,
+	ReasonTradingDayClosed:      orders.TransitionReason_REASON_TRADING_DAY_CLOSED,
+	ReasonTradingDayOpened:      orders.TransitionReason_REASON_TRADING_DAY_OPENED,
+}
+
+// transitionReasonToProto maps a transition reason to its proto enum
+func transitionReasonToProto(reason TransitionReason) orders.TransitionReason {
+	return transitionReasons[reason]
+}
+
+// OrdersModule provides the order service and its gRPC handler for fx
 var OrdersModule = fx.Options(
+	fx.Provide(NewFxOrderService),
 	fx.Provide(NewHandler),
 )
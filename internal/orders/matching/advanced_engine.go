@@ -9,7 +9,6 @@ import (
 
 	"github.com/abdoElHodaky/tradSys/internal/common/pool"
 	"github.com/abdoElHodaky/tradSys/internal/trading/types"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -34,13 +33,13 @@ type EngineConfig struct {
 	EnablePriceImprovement bool          `json:"enable_price_improvement"`
 	EnableIcebergOrders    bool          `json:"enable_iceberg_orders"`
 	EnableHiddenOrders     bool          `json:"enable_hidden_orders"`
-	TickSize               float64       `json:"tick_size"`
+	TickSize               types.Decimal `json:"tick_size"`
 }
 
 // MatchingMetrics tracks performance metrics
 type MatchingMetrics struct {
 	TotalTrades     int64         `json:"total_trades"`
-	TotalVolume     float64       `json:"total_volume"`
+	TotalVolume     types.Decimal `json:"total_volume"`
 	AverageLatency  time.Duration `json:"average_latency"`
 	MaxLatency      time.Duration `json:"max_latency"`
 	OrdersProcessed int64         `json:"orders_processed"`
@@ -50,11 +49,16 @@ type MatchingMetrics struct {
 
 // MatchingEvent represents events from the matching engine
 type MatchingEvent struct {
-	Type      MatchingEventType `json:"type"`
-	Symbol    string            `json:"symbol"`
-	Order     *types.Order      `json:"order,omitempty"`
-	Trade     *Trade            `json:"trade,omitempty"`
-	Timestamp time.Time         `json:"timestamp"`
+	Type        MatchingEventType  `json:"type"`
+	Symbol      string             `json:"symbol"`
+	Order       *types.Order       `json:"order,omitempty"`
+	ContraOrder *types.Order       `json:"contra_order,omitempty"`
+	Trade       *Trade             `json:"trade,omitempty"`
+	Quantity    types.Decimal      `json:"quantity"`
+	Price       types.Decimal      `json:"price"`
+	Phase       types.TradingPhase `json:"phase,omitempty"`
+	Reason      string             `json:"reason,omitempty"`
+	Timestamp   time.Time          `json:"timestamp"`
 }
 
 // MatchingEventType defines types of matching events
@@ -66,14 +70,41 @@ const (
 	EventOrderFilled   MatchingEventType = "order_filled"
 	EventTradeExecuted MatchingEventType = "trade_executed"
 	EventOrderRejected MatchingEventType = "order_rejected"
+
+	// Time in force outcomes
+	EventOrderIOCCanceled MatchingEventType = "order_ioc_canceled"
+	EventOrderFOKKilled   MatchingEventType = "order_fok_killed"
+	EventOrderDayExpired  MatchingEventType = "order_day_expired"
+	EventOrderGTDExpired  MatchingEventType = "order_gtd_expired"
+
+	// EventSelfTradePrevented reports a match blocked by self-trade prevention
+	EventSelfTradePrevented MatchingEventType = "self_trade_prevented"
+
+	// EventOrderAmended reports a cancel/replace of a resting order
+	EventOrderAmended MatchingEventType = "order_amended"
+
+	// EventStopTriggered reports a stop order released into matching
+	EventStopTriggered MatchingEventType = "stop_triggered"
+
+	// Auction calls
+	EventIndicativePrice  MatchingEventType = "indicative_price"
+	EventAuctionUncrossed MatchingEventType = "auction_uncrossed"
+
+	// Trading halts
+	EventTradingHalted  MatchingEventType = "trading_halted"
+	EventTradingResumed MatchingEventType = "trading_resumed"
+
+	// EventIcebergRefreshed reports the next displayed slice of an iceberg order
+	EventIcebergRefreshed MatchingEventType = "iceberg_refreshed"
+
+	// EventOrderRepriced reports a pegged order following its reference price
+	EventOrderRepriced MatchingEventType = "order_repriced"
 )
 
 // AdvancedOrderBook extends the basic order book with advanced features
 type AdvancedOrderBook struct {
 	*OrderBook
 	priceImprovement   *PriceImprovementEngine
-	icebergManager     *IcebergOrderManager
-	hiddenOrderPool    *HiddenOrderPool
 	marketImpactCalc   *MarketImpactCalculator
 	performanceTracker *PerformanceTracker
 }
@@ -82,33 +113,28 @@ type AdvancedOrderBook struct {
 type PriceImprovementEngine struct {
 	enabled          bool
 	improvementTicks int
-	minImprovement   float64
-	maxImprovement   float64
-	tickSize         float64
+	minImprovement   types.Decimal
+	maxImprovement   types.Decimal
+	tickSize         types.Decimal
 }
 
-// IcebergOrderManager handles iceberg order logic
-type IcebergOrderManager struct {
-	enabled      bool
-	activeOrders map[string]*IcebergOrder
-	mu           sync.RWMutex
-}
-
-// IcebergOrder represents an iceberg order
+// IcebergOrder represents an iceberg order resting in a book. Only the
+// current slice is queued and displayed; the parent order carries the fills
+// of every slice.
 type IcebergOrder struct {
-	ParentOrder   *types.Order
-	DisplaySize   float64
-	TotalSize     float64
-	RemainingSize float64
-	RefreshSize   float64
-	CurrentOrder  *types.Order
-}
-
-// HiddenOrderPool manages hidden orders
-type HiddenOrderPool struct {
-	enabled      bool
-	hiddenOrders map[string]*types.Order
-	mu           sync.RWMutex
+	ParentOrder *types.Order
+	// DisplaySize is the display quantity each slice is drawn from
+	DisplaySize types.Decimal
+	// TotalSize is the quantity of the parent order
+	TotalSize types.Decimal
+	// RemainingSize is the reserve not yet displayed
+	RemainingSize types.Decimal
+	// RefreshSize is the size of the current slice
+	RefreshSize types.Decimal
+	// CurrentOrder is the displayed slice
+	CurrentOrder *types.Order
+	// Slices counts the slices shown so far
+	Slices int
 }
 
 // MarketImpactCalculator calculates market impact of orders
@@ -163,6 +189,12 @@ func (e *AdvancedOrderMatchingEngine) Start() error {
 	// Start event processing goroutine
 	go e.processEvents()
 
+	// Start DAY and GTD order expiry
+	go e.expireOrders()
+
+	// Follow the exchange schedules through the auction calls
+	go e.updatePhases()
+
 	return nil
 }
 
@@ -185,21 +217,14 @@ func (e *AdvancedOrderMatchingEngine) GetOrCreateOrderBook(symbol string) *Advan
 
 	// Create new advanced order book
 	basicBook := NewOrderBook(symbol, e.logger)
+	basicBook.SetEventHandler(e.publishEvent)
 	advancedBook := &AdvancedOrderBook{
 		OrderBook: basicBook,
 		priceImprovement: &PriceImprovementEngine{
 			enabled:        e.config.EnablePriceImprovement,
 			tickSize:       e.config.TickSize,
 			minImprovement: e.config.TickSize,
-			maxImprovement: e.config.TickSize * 5,
-		},
-		icebergManager: &IcebergOrderManager{
-			enabled:      e.config.EnableIcebergOrders,
-			activeOrders: make(map[string]*IcebergOrder),
-		},
-		hiddenOrderPool: &HiddenOrderPool{
-			enabled:      e.config.EnableHiddenOrders,
-			hiddenOrders: make(map[string]*types.Order),
+			maxImprovement: e.config.TickSize.MulInt(5),
 		},
 		marketImpactCalc: &MarketImpactCalculator{
 			enabled:          true,
@@ -244,13 +269,25 @@ func (e *AdvancedOrderMatchingEngine) AddOrder(order *types.Order) ([]*Trade, er
 	// Get order book
 	book := e.GetOrCreateOrderBook(order.Symbol)
 
-	// Handle special order types
-	if order.IsIceberg() && e.config.EnableIcebergOrders {
-		return e.handleIcebergOrder(book, order)
+	// Enforce instrument reference data before special order handling
+	if err := types.Instruments.Validate(order, book.GetLastPrice()); err != nil {
+		order.Status = types.OrderStatusRejected
+		e.publishEvent(&MatchingEvent{
+			Type:      EventOrderRejected,
+			Symbol:    order.Symbol,
+			Order:     order,
+			Timestamp: time.Now(),
+		})
+		return nil, err
 	}
 
-	if order.IsHidden && e.config.EnableHiddenOrders {
-		return e.handleHiddenOrder(book, order)
+	// The book executes iceberg and hidden orders; with the feature
+	// disabled they trade as ordinary limit orders
+	if !e.config.EnableIcebergOrders {
+		order.DisplayQuantity = types.Zero
+	}
+	if !e.config.EnableHiddenOrders {
+		order.IsHidden = false
 	}
 
 	// Process regular order with enhancements
@@ -259,13 +296,15 @@ func (e *AdvancedOrderMatchingEngine) AddOrder(order *types.Order) ([]*Trade, er
 		return nil, err
 	}
 
-	// Publish events
-	e.publishEvent(&MatchingEvent{
-		Type:      EventOrderAdded,
-		Symbol:    order.Symbol,
-		Order:     order,
-		Timestamp: time.Now(),
-	})
+	// Publish events; killed and cancelled orders were reported by the book
+	if order.Status != types.OrderStatusRejected && order.Status != types.OrderStatusCancelled {
+		e.publishEvent(&MatchingEvent{
+			Type:      EventOrderAdded,
+			Symbol:    order.Symbol,
+			Order:     order,
+			Timestamp: time.Now(),
+		})
+	}
 
 	for _, trade := range trades {
 		e.publishEvent(&MatchingEvent{
@@ -280,6 +319,34 @@ func (e *AdvancedOrderMatchingEngine) AddOrder(order *types.Order) ([]*Trade, er
 	return trades, nil
 }
 
+// AmendOrder amends a resting order in its book; see OrderBook.AmendOrder
+func (e *AdvancedOrderMatchingEngine) AmendOrder(symbol, orderID string, price, quantity types.Decimal) ([]*Trade, error) {
+	if atomic.LoadInt32(&e.isRunning) != 1 {
+		return nil, fmt.Errorf("engine is not running")
+	}
+
+	book := e.GetOrderBook(symbol)
+	if book == nil {
+		return nil, fmt.Errorf("order book not found for symbol %s", symbol)
+	}
+
+	trades, err := book.AmendOrder(orderID, price, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trade := range trades {
+		e.publishEvent(&MatchingEvent{
+			Type:      EventTradeExecuted,
+			Symbol:    symbol,
+			Trade:     trade,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return trades, nil
+}
+
 // processAdvancedOrder processes an order with advanced features
 func (e *AdvancedOrderMatchingEngine) processAdvancedOrder(book *AdvancedOrderBook, order *types.Order) ([]*Trade, error) {
 	// Calculate market impact
@@ -289,7 +356,7 @@ func (e *AdvancedOrderMatchingEngine) processAdvancedOrder(book *AdvancedOrderBo
 	}
 
 	// Apply price improvement if enabled
-	if book.priceImprovement.enabled && order.Type == types.OrderTypeLimit {
+	if book.priceImprovement.enabled && order.Type == types.OrderTypeLimit && !order.IsPegged() {
 		e.applyPriceImprovement(book, order)
 	}
 
@@ -326,22 +393,26 @@ func (e *AdvancedOrderMatchingEngine) calculateMarketImpact(book *AdvancedOrderB
 	}
 
 	// Calculate average trade size
-	totalVolume := 0.0
+	totalVolume := types.Zero
 	for _, trade := range calc.historicalTrades {
-		totalVolume += trade.Quantity
+		totalVolume = totalVolume.Add(trade.Quantity)
 	}
-	avgTradeSize := totalVolume / float64(len(calc.historicalTrades))
+	avgTradeSize := totalVolume.Float64() / float64(len(calc.historicalTrades))
+	if avgTradeSize <= 0 {
+		return 0.0
+	}
+	relativeSize := order.Quantity.Float64() / avgTradeSize
 
 	// Calculate impact based on model
 	switch calc.impactModel {
 	case "linear":
-		return calc.liquidityFactor * (order.Quantity / avgTradeSize)
+		return calc.liquidityFactor * relativeSize
 	case "sqrt":
-		return calc.liquidityFactor * math.Sqrt(order.Quantity/avgTradeSize)
+		return calc.liquidityFactor * math.Sqrt(relativeSize)
 	case "log":
-		return calc.liquidityFactor * math.Log(1+order.Quantity/avgTradeSize)
+		return calc.liquidityFactor * math.Log(1+relativeSize)
 	default:
-		return calc.liquidityFactor * math.Sqrt(order.Quantity/avgTradeSize)
+		return calc.liquidityFactor * math.Sqrt(relativeSize)
 	}
 }
 
@@ -351,13 +422,13 @@ func (e *AdvancedOrderMatchingEngine) applyPriceImprovement(book *AdvancedOrderB
 
 	if order.Side == types.OrderSideBuy {
 		// For buy orders, improve by increasing the price slightly
-		maxPrice := order.Price + improvement.maxImprovement
+		maxPrice := order.Price.Add(improvement.maxImprovement)
 		if book.Asks.Len() > 0 {
 			bestAsk := book.Asks.Peek()
-			if bestAsk.Price < maxPrice {
+			if bestAsk.Price.LessThan(maxPrice) {
 				// Improve price to just below best ask
-				improvedPrice := bestAsk.Price - improvement.tickSize
-				if improvedPrice > order.Price {
+				improvedPrice := bestAsk.Price.Sub(improvement.tickSize)
+				if improvedPrice.GreaterThan(order.Price) {
 					order.Price = improvedPrice
 					order.IsPriceImproved = true
 				}
@@ -365,13 +436,13 @@ func (e *AdvancedOrderMatchingEngine) applyPriceImprovement(book *AdvancedOrderB
 		}
 	} else {
 		// For sell orders, improve by decreasing the price slightly
-		minPrice := order.Price - improvement.maxImprovement
+		minPrice := order.Price.Sub(improvement.maxImprovement)
 		if book.Bids.Len() > 0 {
 			bestBid := book.Bids.Peek()
-			if bestBid.Price > minPrice {
+			if bestBid.Price.GreaterThan(minPrice) {
 				// Improve price to just above best bid
-				improvedPrice := bestBid.Price + improvement.tickSize
-				if improvedPrice < order.Price {
+				improvedPrice := bestBid.Price.Add(improvement.tickSize)
+				if improvedPrice.LessThan(order.Price) {
 					order.Price = improvedPrice
 					order.IsPriceImproved = true
 				}
@@ -380,64 +451,15 @@ func (e *AdvancedOrderMatchingEngine) applyPriceImprovement(book *AdvancedOrderB
 	}
 }
 
-// handleIcebergOrder handles iceberg order processing
-func (e *AdvancedOrderMatchingEngine) handleIcebergOrder(book *AdvancedOrderBook, order *types.Order) ([]*Trade, error) {
-	manager := book.icebergManager
-	manager.mu.Lock()
-	defer manager.mu.Unlock()
-
-	// Create iceberg order
-	icebergOrder := &IcebergOrder{
-		ParentOrder:   order,
-		DisplaySize:   order.DisplayQuantity,
-		TotalSize:     order.Quantity,
-		RemainingSize: order.Quantity,
-		RefreshSize:   order.DisplayQuantity,
-	}
-
-	// Create first visible order
-	visibleOrder := e.createVisibleOrder(icebergOrder)
-	manager.activeOrders[order.ID] = icebergOrder
-
-	// Process the visible order
-	return e.processAdvancedOrder(book, visibleOrder)
-}
-
-// handleHiddenOrder handles hidden order processing
-func (e *AdvancedOrderMatchingEngine) handleHiddenOrder(book *AdvancedOrderBook, order *types.Order) ([]*Trade, error) {
-	pool := book.hiddenOrderPool
-	pool.mu.Lock()
-	defer pool.mu.Unlock()
-
-	// Store in hidden pool
-	pool.hiddenOrders[order.ID] = order
-
-	// Hidden orders don't appear in the book but can be matched
-	// This is a simplified implementation
-	return nil, nil
-}
-
-// createVisibleOrder creates a visible order from an iceberg order
-func (e *AdvancedOrderMatchingEngine) createVisibleOrder(iceberg *IcebergOrder) *types.Order {
-	visibleOrder := *iceberg.ParentOrder // Copy the parent order
-	visibleOrder.ID = uuid.New().String()
-	visibleOrder.Quantity = math.Min(iceberg.DisplaySize, iceberg.RemainingSize)
-	visibleOrder.ParentOrderID = iceberg.ParentOrder.ID
-	visibleOrder.IsIcebergChild = true
-
-	iceberg.CurrentOrder = &visibleOrder
-	return &visibleOrder
-}
-
 // validateOrder validates an order before processing
 func (e *AdvancedOrderMatchingEngine) validateOrder(order *types.Order) error {
 	if order.Symbol == "" {
 		return fmt.Errorf("order symbol cannot be empty")
 	}
-	if order.Quantity <= 0 {
+	if !order.Quantity.IsPositive() {
 		return fmt.Errorf("order quantity must be positive")
 	}
-	if order.Type == types.OrderTypeLimit && order.Price <= 0 {
+	if order.Type == types.OrderTypeLimit && !order.IsPegged() && !order.Price.IsPositive() {
 		return fmt.Errorf("limit order price must be positive")
 	}
 	if order.Side != types.OrderSideBuy && order.Side != types.OrderSideSell {
@@ -489,7 +511,7 @@ func (e *AdvancedOrderMatchingEngine) handleEvent(event *MatchingEvent) {
 	case EventTradeExecuted:
 		atomic.AddInt64(&e.metrics.TotalTrades, 1)
 		if event.Trade != nil {
-			e.metrics.TotalVolume += event.Trade.Quantity
+			e.metrics.TotalVolume = e.metrics.TotalVolume.Add(event.Trade.Quantity)
 		}
 	case EventOrderAdded:
 		// Handle order added event
@@ -499,6 +521,65 @@ func (e *AdvancedOrderMatchingEngine) handleEvent(event *MatchingEvent) {
 		// Handle order filled event
 	case EventOrderRejected:
 		// Handle order rejected event
+	case EventOrderIOCCanceled, EventOrderFOKKilled:
+		// Handle time in force cancellation
+	case EventOrderDayExpired, EventOrderGTDExpired:
+		// Handle order expiry
+	case EventSelfTradePrevented:
+		// Handle self-trade prevention
+	case EventOrderAmended:
+		// Handle order amendment
+	case EventStopTriggered:
+		// Handle stop order election
+	case EventIndicativePrice, EventAuctionUncrossed:
+		// Handle auction call progress
+	case EventTradingHalted, EventTradingResumed:
+		// Handle trading halts
+	}
+}
+
+// expireOrders periodically expires DAY and GTD orders in every book
+func (e *AdvancedOrderMatchingEngine) expireOrders() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			e.orderBooks.Range(func(_, book interface{}) bool {
+				book.(*AdvancedOrderBook).ExpireOrders(now)
+				return true
+			})
+		case <-e.stopChannel:
+			return
+		}
+	}
+}
+
+// updatePhases periodically moves every book to its scheduled trading
+// phase and publishes the auction trades
+func (e *AdvancedOrderMatchingEngine) updatePhases() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			e.orderBooks.Range(func(_, value interface{}) bool {
+				book := value.(*AdvancedOrderBook)
+				for _, trade := range book.SetPhase(types.PhaseFor(book.Symbol, now)) {
+					e.publishEvent(&MatchingEvent{
+						Type:      EventTradeExecuted,
+						Symbol:    book.Symbol,
+						Trade:     trade,
+						Timestamp: time.Now(),
+					})
+				}
+				return true
+			})
+		case <-e.stopChannel:
+			return
+		}
 	}
 }
 
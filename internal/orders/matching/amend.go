@@ -0,0 +1,149 @@
+package order_matching
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// AmendOrder atomically replaces the price and quantity of a resting order;
+// zero values keep the current ones. Quantity reductions at the same price
+// keep time priority. Price changes and quantity increases lose it, and an
+// amended price that crosses the book trades immediately outside an
+// auction call. Pegged orders take their price from the book and can only
+// change quantity.
+func (ob *OrderBook) AmendOrder(orderID string, price, quantity Decimal) ([]*Trade, error) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	order, exists := ob.Orders[orderID]
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+	if order.Status != OrderStatusNew && order.Status != OrderStatusPartiallyFilled {
+		return nil, ErrInvalidOrderStatus
+	}
+	if _, iceberg := ob.icebergs[orderID]; iceberg {
+		return nil, types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"iceberg orders cannot be amended")
+	}
+	resting := ob.restingHeap(order)
+	index := resting.find(orderID)
+	if index < 0 {
+		return nil, ErrOrderNotFound
+	}
+
+	price, quantity = order.Amend(price, quantity)
+	if err := order.ValidateAmend(price, quantity); err != nil {
+		return nil, err
+	}
+	if order.IsPegged() && !price.Equal(order.Price) {
+		return nil, types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"pegged orders cannot change price")
+	}
+	amended := *order
+	amended.Price = price
+	amended.Quantity = quantity
+	if err := types.Instruments.Validate(&amended, ob.LastPrice); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	keepsPriority := order.KeepsPriority(price, quantity)
+
+	ob.logger.Debug("Amending order",
+		zap.String("order_id", orderID),
+		zap.Stringer("price", price),
+		zap.Stringer("quantity", quantity),
+		zap.Bool("keeps_priority", keepsPriority))
+
+	if keepsPriority {
+		order.Quantity = quantity
+		order.UpdatedAt = now
+		ob.emit(EventOrderAmended, order)
+		return nil, nil
+	}
+
+	// Requeue at the back of the new price level
+	heap.Remove(resting, index)
+	order.Price = price
+	order.Quantity = quantity
+	order.QueuedAt = now
+	order.UpdatedAt = now
+	ob.emit(EventOrderAmended, order)
+
+	if order.Type != OrderTypeLimit || ob.Phase.IsCall() {
+		heap.Push(resting, order)
+		if ob.Phase.IsCall() {
+			ob.publishIndicative()
+		}
+		return nil, nil
+	}
+
+	trades, err := ob.processOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	trades = append(trades, ob.triggerStops()...)
+	ob.repricePegs()
+	return trades, nil
+}
+
+// restingHeap returns the heap a resting order is queued in
+func (ob *OrderBook) restingHeap(order *Order) *OrderHeap {
+	switch {
+	case order.IsStop() && order.Side == OrderSideBuy:
+		return ob.StopBids
+	case order.IsStop():
+		return ob.StopAsks
+	case order.IsHidden && order.Side == OrderSideBuy:
+		return ob.HiddenBids
+	case order.IsHidden:
+		return ob.HiddenAsks
+	case order.Side == OrderSideBuy:
+		return ob.Bids
+	default:
+		return ob.Asks
+	}
+}
+
+// find returns the heap index of an order, or -1 if it is not queued
+func (h *OrderHeap) find(orderID string) int {
+	for i, order := range h.Orders {
+		if order.ID == orderID {
+			return i
+		}
+	}
+	return -1
+}
+
+// AmendOrder amends a resting order and publishes any resulting trades
+func (e *Engine) AmendOrder(symbol, orderID string, price, quantity Decimal) ([]*Trade, error) {
+	e.mu.RLock()
+	orderBook, exists := e.OrderBooks[symbol]
+	e.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrSymbolNotFound
+	}
+
+	trades, err := orderBook.AmendOrder(orderID, price, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send trades to trade channel
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID),
+				zap.String("symbol", trade.Symbol))
+		}
+	}
+
+	return trades, nil
+}
@@ -2,6 +2,7 @@ package order_matching
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ type OrderType = types.OrderType
 type OrderSide = types.OrderSide
 type OrderStatus = types.OrderStatus
 type Order = types.Order
+type Decimal = types.Decimal
 
 // Constants from types package
 const (
@@ -41,9 +43,9 @@ type Trade struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Price is the price of the trade
-	Price float64
+	Price Decimal
 	// Quantity is the quantity of the trade
-	Quantity float64
+	Quantity Decimal
 	// BuyOrderID is the buy order ID
 	BuyOrderID string
 	// SellOrderID is the sell order ID
@@ -55,9 +57,9 @@ type Trade struct {
 	// MakerSide is the side of the maker
 	MakerSide OrderSide
 	// TakerFee is the fee for the taker
-	TakerFee float64
+	TakerFee Decimal
 	// MakerFee is the fee for the maker
-	MakerFee float64
+	MakerFee Decimal
 }
 
 // OrderBook represents an order book for a symbol
@@ -74,8 +76,28 @@ type OrderBook struct {
 	StopBids *OrderHeap
 	// StopAsks is the stop sell orders
 	StopAsks *OrderHeap
+	// HiddenBids and HiddenAsks are the hidden orders, which trade after
+	// the displayed orders at their price and never appear in market data
+	HiddenBids *OrderHeap
+	HiddenAsks *OrderHeap
+	// icebergs maps an iceberg order ID to the slice it displays
+	icebergs map[string]*IcebergOrder
+	// pegs are the resting pegged orders by ID, repriced as the book moves
+	pegs map[string]*Order
 	// LastPrice is the last traded price
-	LastPrice float64
+	LastPrice Decimal
+	// MarkPrice is the reference price for stops triggered on MARK
+	MarkPrice Decimal
+	// Phase is the trading phase; call phases collect orders for an auction
+	Phase types.TradingPhase
+	// schedulePhase is the phase the exchange schedule gives; halts override it
+	schedulePhase types.TradingPhase
+	// halted and marketHalted record symbol and market-wide halts
+	halted       bool
+	marketHalted bool
+	haltReason   string
+	// eventHandler receives time in force and expiry events
+	eventHandler func(*MatchingEvent)
 	// Mutex for thread safety
 	mu sync.RWMutex
 	// Logger
@@ -88,6 +110,8 @@ type OrderHeap struct {
 	Orders []*Order
 	// Side is the side of the orders
 	Side OrderSide
+	// Stop orders the heap by stop price in the order the stops trigger
+	Stop bool
 }
 
 // Len returns the length of the heap
@@ -95,20 +119,23 @@ func (h OrderHeap) Len() int { return len(h.Orders) }
 
 // Less returns whether the order at index i is less than the order at index j
 func (h OrderHeap) Less(i, j int) bool {
+	if h.Stop {
+		return h.lessStop(i, j)
+	}
 	if h.Side == OrderSideBuy {
 		// For buy orders, higher prices have higher priority
-		if h.Orders[i].Price == h.Orders[j].Price {
+		if h.Orders[i].Price.Equal(h.Orders[j].Price) {
 			// If prices are equal, older orders have higher priority
-			return h.Orders[i].CreatedAt.Before(h.Orders[j].CreatedAt)
+			return h.Orders[i].QueueTime().Before(h.Orders[j].QueueTime())
 		}
-		return h.Orders[i].Price > h.Orders[j].Price
+		return h.Orders[i].Price.GreaterThan(h.Orders[j].Price)
 	}
 	// For sell orders, lower prices have higher priority
-	if h.Orders[i].Price == h.Orders[j].Price {
+	if h.Orders[i].Price.Equal(h.Orders[j].Price) {
 		// If prices are equal, older orders have higher priority
-		return h.Orders[i].CreatedAt.Before(h.Orders[j].CreatedAt)
+		return h.Orders[i].QueueTime().Before(h.Orders[j].QueueTime())
 	}
-	return h.Orders[i].Price < h.Orders[j].Price
+	return h.Orders[i].Price.LessThan(h.Orders[j].Price)
 }
 
 // Swap swaps the orders at indices i and j
@@ -158,25 +185,44 @@ func NewOrderBook(symbol string, logger *zap.Logger) *OrderBook {
 	stopBids := &OrderHeap{
 		Orders: make([]*Order, 0),
 		Side:   OrderSideBuy,
+		Stop:   true,
 	}
 	stopAsks := &OrderHeap{
 		Orders: make([]*Order, 0),
 		Side:   OrderSideSell,
+		Stop:   true,
+	}
+	hiddenBids := &OrderHeap{
+		Orders: make([]*Order, 0),
+		Side:   OrderSideBuy,
+	}
+	hiddenAsks := &OrderHeap{
+		Orders: make([]*Order, 0),
+		Side:   OrderSideSell,
 	}
 	heap.Init(bids)
 	heap.Init(asks)
 	heap.Init(stopBids)
 	heap.Init(stopAsks)
+	heap.Init(hiddenBids)
+	heap.Init(hiddenAsks)
 
+	phase := types.PhaseFor(symbol, time.Now())
 	return &OrderBook{
-		Symbol:    symbol,
-		Bids:      bids,
-		Asks:      asks,
-		Orders:    make(map[string]*Order),
-		StopBids:  stopBids,
-		StopAsks:  stopAsks,
-		LastPrice: 0,
-		logger:    logger,
+		Symbol:        symbol,
+		Bids:          bids,
+		Asks:          asks,
+		Orders:        make(map[string]*Order),
+		StopBids:      stopBids,
+		StopAsks:      stopAsks,
+		HiddenBids:    hiddenBids,
+		HiddenAsks:    hiddenAsks,
+		icebergs:      make(map[string]*IcebergOrder),
+		pegs:          make(map[string]*Order),
+		LastPrice:     types.Zero,
+		Phase:         phase,
+		schedulePhase: phase,
+		logger:        logger,
 	}
 }
 
@@ -198,182 +244,182 @@ func (ob *OrderBook) AddOrder(order *Order) ([]*Trade, error) {
 	// Set updated time
 	order.UpdatedAt = time.Now()
 
+	// Price pegged orders from the book, then reject orders that break the
+	// instrument rules before they can match or rest
+	if err := ob.pricePeg(order); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := types.Instruments.Validate(order, ob.LastPrice); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := order.ValidateStop(); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := order.ValidateDisplay(); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
+	// Reject unsupported time in force and stamp DAY orders with the session close
+	if err := ob.applyTimeInForce(order, order.UpdatedAt); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
+	// Queue orders for the uncross during an auction call
+	if ob.Phase.IsCall() {
+		return nil, ob.collectAuctionOrder(order)
+	}
+
 	// Set status to new
 	order.Status = OrderStatusNew
 
 	// Add to orders map
 	ob.Orders[order.ID] = order
 
-	// Handle stop orders
-	if order.Type == OrderTypeStopLimit || order.Type == OrderTypeStopMarket {
+	// Park stop orders until they are elected
+	if order.IsStop() {
 		if order.Side == OrderSideBuy {
-			if ob.LastPrice > 0 && order.StopPrice <= ob.LastPrice {
-				// Stop price triggered, convert to limit/market order
-				if order.Type == OrderTypeStopLimit {
-					order.Type = OrderTypeLimit
-				} else {
-					order.Type = OrderTypeMarket
-				}
-			} else {
-				// Add to stop bids
-				heap.Push(ob.StopBids, order)
-				return nil, nil
-			}
+			heap.Push(ob.StopBids, order)
 		} else {
-			if ob.LastPrice > 0 && order.StopPrice >= ob.LastPrice {
-				// Stop price triggered, convert to limit/market order
-				if order.Type == OrderTypeStopLimit {
-					order.Type = OrderTypeLimit
-				} else {
-					order.Type = OrderTypeMarket
-				}
-			} else {
-				// Add to stop asks
-				heap.Push(ob.StopAsks, order)
-				return nil, nil
-			}
+			heap.Push(ob.StopAsks, order)
 		}
+		trades := ob.triggerStops()
+		ob.repricePegs()
+		return trades, nil
 	}
 
-	// Process the order
-	return ob.processOrder(order)
+	// Process the order, release the stops it elects and follow the new
+	// prices with the pegged orders
+	trades, err := ob.processOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	trades = append(trades, ob.triggerStops()...)
+	ob.repricePegs()
+	return trades, nil
 }
 
 // processOrder processes an order and returns any trades that were executed
 func (ob *OrderBook) processOrder(order *Order) ([]*Trade, error) {
 	trades := make([]*Trade, 0)
 
-	// Handle market orders
-	if order.Type == OrderTypeMarket {
-		if order.Side == OrderSideBuy {
-			// Process market buy order
-			for ob.Asks.Len() > 0 && order.Quantity > order.FilledQuantity {
-				bestAsk := ob.Asks.Peek()
-				trade := ob.matchOrders(order, bestAsk)
-				trades = append(trades, trade)
-
-				// Update order status
-				if bestAsk.Status == OrderStatusFilled {
-					heap.Pop(ob.Asks)
-				}
-			}
-		} else {
-			// Process market sell order
-			for ob.Bids.Len() > 0 && order.Quantity > order.FilledQuantity {
-				bestBid := ob.Bids.Peek()
-				trade := ob.matchOrders(order, bestBid)
-				trades = append(trades, trade)
-
-				// Update order status
-				if bestBid.Status == OrderStatusFilled {
-					heap.Pop(ob.Bids)
-				}
-			}
-		}
+	// Kill fill-or-kill orders the book cannot fill completely
+	if order.TimeInForce == TimeInForceFOK && !ob.canFill(order, time.Now()) {
+		ob.killOrder(order)
+		return trades, nil
+	}
+
+	if order.Type != OrderTypeMarket && order.Type != OrderTypeLimit {
+		return trades, nil
+	}
+
+	trades, cancelled := ob.match(order, trades)
 
+	switch {
+	case cancelled:
+		// Self-trade prevention cancelled the order
+	case order.Type == OrderTypeMarket:
 		// If market order is not fully filled, cancel the remaining quantity
-		if order.Quantity > order.FilledQuantity {
+		if order.RemainingQuantity().IsPositive() {
 			order.Status = OrderStatusPartiallyFilled
 			ob.logger.Warn("Market order not fully filled",
 				zap.String("order_id", order.ID),
-				zap.Float64("quantity", order.Quantity),
-				zap.Float64("filled_quantity", order.FilledQuantity))
+				zap.Stringer("quantity", order.Quantity),
+				zap.Stringer("filled_quantity", order.FilledQuantity))
 		} else {
 			order.Status = OrderStatusFilled
 		}
-	} else if order.Type == OrderTypeLimit {
-		// Handle limit orders
-		if order.Side == OrderSideBuy {
-			// Process limit buy order
-			for ob.Asks.Len() > 0 && order.Quantity > order.FilledQuantity {
-				bestAsk := ob.Asks.Peek()
-				// Check if the best ask price is less than or equal to the buy price
-				if bestAsk.Price <= order.Price {
-					trade := ob.matchOrders(order, bestAsk)
-					trades = append(trades, trade)
-
-					// Update order status
-					if bestAsk.Status == OrderStatusFilled {
-						heap.Pop(ob.Asks)
-					}
-				} else {
-					break
-				}
-			}
-
-			// If limit order is not fully filled, add it to the order book
-			if order.Quantity > order.FilledQuantity {
-				if order.FilledQuantity > 0 {
-					order.Status = OrderStatusPartiallyFilled
-				}
-				heap.Push(ob.Bids, order)
-			} else {
-				order.Status = OrderStatusFilled
-			}
-		} else {
-			// Process limit sell order
-			for ob.Bids.Len() > 0 && order.Quantity > order.FilledQuantity {
-				bestBid := ob.Bids.Peek()
-				// Check if the best bid price is greater than or equal to the sell price
-				if bestBid.Price >= order.Price {
-					trade := ob.matchOrders(order, bestBid)
-					trades = append(trades, trade)
-
-					// Update order status
-					if bestBid.Status == OrderStatusFilled {
-						heap.Pop(ob.Bids)
-					}
-				} else {
-					break
-				}
-			}
-
-			// If limit order is not fully filled, add it to the order book
-			if order.Quantity > order.FilledQuantity {
-				if order.FilledQuantity > 0 {
-					order.Status = OrderStatusPartiallyFilled
-				}
-				heap.Push(ob.Asks, order)
-			} else {
-				order.Status = OrderStatusFilled
-			}
+	case order.RemainingQuantity().IsPositive() && order.IsImmediate():
+		// Cancel the unfilled remainder of IOC orders
+		ob.cancelRemainder(order)
+	case order.RemainingQuantity().IsPositive():
+		// If limit order is not fully filled, add it to the order book
+		if order.FilledQuantity.IsPositive() {
+			order.Status = OrderStatusPartiallyFilled
 		}
+		ob.rest(order)
+	default:
+		order.Status = OrderStatusFilled
 	}
 
 	// Update last price if trades were executed
 	if len(trades) > 0 {
 		ob.LastPrice = trades[len(trades)-1].Price
-		// Check stop orders
-		ob.checkStopOrders()
 	}
 
 	return trades, nil
 }
 
-// matchOrders matches two orders and creates a trade
-func (ob *OrderBook) matchOrders(taker *Order, maker *Order) *Trade {
-	// Calculate the trade quantity
-	tradeQuantity := taker.Quantity - taker.FilledQuantity
-	remainingMakerQuantity := maker.Quantity - maker.FilledQuantity
-	if tradeQuantity > remainingMakerQuantity {
-		tradeQuantity = remainingMakerQuantity
+// match trades an order against the opposite side while prices cross,
+// taking hidden orders after the displayed ones at each price and
+// appending to trades. It reports whether self-trade prevention cancelled
+// the order.
+func (ob *OrderBook) match(order *Order, trades []*Trade) ([]*Trade, bool) {
+	opposite, hidden := ob.Asks, ob.HiddenAsks
+	if order.Side == OrderSideSell {
+		opposite, hidden = ob.Bids, ob.HiddenBids
+	}
+	mode := types.SelfTradePolicies.ModeFor(order)
+	rule, proRata := types.AllocationRuleFor(ob.Symbol)
+
+	for order.RemainingQuantity().IsPositive() {
+		side, maker := ob.best(opposite, hidden)
+		if maker == nil {
+			break
+		}
+		if maker.IsExpired() {
+			heap.Pop(side)
+			ob.expireOrder(maker)
+			continue
+		}
+		if !crosses(order, maker) {
+			break
+		}
+		if mode != types.SelfTradePreventionNone && types.IsSelfTrade(order, maker) {
+			if ob.preventSelfTrade(mode, order, maker, side) {
+				return trades, true
+			}
+			continue
+		}
+		if proRata && side == opposite {
+			trades = append(trades, ob.matchProRata(rule, mode, order, opposite)...)
+			continue
+		}
+
+		trade := ob.matchOrders(order, maker, order.RemainingQuantity())
+		trades = append(trades, trade)
+
+		// Remove filled makers from the book
+		ob.settleMaker(side, maker, trade.Quantity)
 	}
 
+	return trades, false
+}
+
+// matchOrders matches up to quantity of two orders and creates a trade
+func (ob *OrderBook) matchOrders(taker *Order, maker *Order, quantity Decimal) *Trade {
+	// Calculate the trade quantity
+	tradeQuantity := types.MinDecimal(quantity, types.MinDecimal(taker.RemainingQuantity(), maker.RemainingQuantity()))
+
 	// Calculate the trade price (maker's price)
 	tradePrice := maker.Price
 
 	// Update filled quantities
-	taker.FilledQuantity += tradeQuantity
-	maker.FilledQuantity += tradeQuantity
+	taker.FilledQuantity = taker.FilledQuantity.Add(tradeQuantity)
+	maker.FilledQuantity = maker.FilledQuantity.Add(tradeQuantity)
 
 	// Update order statuses
-	if maker.FilledQuantity >= maker.Quantity {
+	if maker.IsFilled() {
 		maker.Status = OrderStatusFilled
 	} else {
 		maker.Status = OrderStatusPartiallyFilled
 	}
 
-	if taker.FilledQuantity >= taker.Quantity {
+	if taker.IsFilled() {
 		taker.Status = OrderStatusFilled
 	} else {
 		taker.Status = OrderStatusPartiallyFilled
@@ -395,70 +441,22 @@ func (ob *OrderBook) matchOrders(taker *Order, maker *Order) *Trade {
 		Timestamp:   now,
 		TakerSide:   taker.Side,
 		MakerSide:   maker.Side,
-		TakerFee:    0, // Fees would be calculated based on fee schedule
-		MakerFee:    0, // Fees would be calculated based on fee schedule
+		TakerFee:    types.Zero, // Fees would be calculated based on fee schedule
+		MakerFee:    types.Zero, // Fees would be calculated based on fee schedule
 	}
 
 	// Set buy and sell order IDs
 	if taker.Side == OrderSideBuy {
 		trade.BuyOrderID = taker.ID
-		trade.SellOrderID = maker.ID
+		trade.SellOrderID = orderID(maker)
 	} else {
-		trade.BuyOrderID = maker.ID
+		trade.BuyOrderID = orderID(maker)
 		trade.SellOrderID = taker.ID
 	}
 
 	return trade
 }
 
-// checkStopOrders checks if any stop orders should be triggered
-func (ob *OrderBook) checkStopOrders() {
-	// Check stop buy orders
-	triggeredStopBuys := make([]*Order, 0)
-	for ob.StopBids.Len() > 0 {
-		stopBuy := ob.StopBids.Peek()
-		if stopBuy.StopPrice <= ob.LastPrice {
-			// Stop price triggered
-			heap.Pop(ob.StopBids)
-			triggeredStopBuys = append(triggeredStopBuys, stopBuy)
-		} else {
-			break
-		}
-	}
-
-	// Check stop sell orders
-	triggeredStopSells := make([]*Order, 0)
-	for ob.StopAsks.Len() > 0 {
-		stopSell := ob.StopAsks.Peek()
-		if stopSell.StopPrice >= ob.LastPrice {
-			// Stop price triggered
-			heap.Pop(ob.StopAsks)
-			triggeredStopSells = append(triggeredStopSells, stopSell)
-		} else {
-			break
-		}
-	}
-
-	// Process triggered stop orders
-	for _, stopBuy := range triggeredStopBuys {
-		if stopBuy.Type == OrderTypeStopLimit {
-			stopBuy.Type = OrderTypeLimit
-		} else {
-			stopBuy.Type = OrderTypeMarket
-		}
-		ob.processOrder(stopBuy)
-	}
-
-	for _, stopSell := range triggeredStopSells {
-		if stopSell.Type == OrderTypeStopLimit {
-			stopSell.Type = OrderTypeLimit
-		} else {
-			stopSell.Type = OrderTypeMarket
-		}
-		ob.processOrder(stopSell)
-	}
-}
-
 // CancelOrder cancels an order
 func (ob *OrderBook) CancelOrder(orderID string) error {
 	ob.mu.Lock()
@@ -477,44 +475,13 @@ func (ob *OrderBook) CancelOrder(orderID string) error {
 	order.Status = OrderStatusCancelled
 	order.UpdatedAt = time.Now()
 
-	// Remove from appropriate heap
-	if order.Type == OrderTypeLimit {
-		if order.Side == OrderSideBuy {
-			// Find and remove from bids
-			for i, o := range ob.Bids.Orders {
-				if o.ID == orderID {
-					heap.Remove(ob.Bids, i)
-					break
-				}
-			}
-		} else {
-			// Find and remove from asks
-			for i, o := range ob.Asks.Orders {
-				if o.ID == orderID {
-					heap.Remove(ob.Asks, i)
-					break
-				}
-			}
-		}
-	} else if order.Type == OrderTypeStopLimit || order.Type == OrderTypeStopMarket {
-		if order.Side == OrderSideBuy {
-			// Find and remove from stop bids
-			for i, o := range ob.StopBids.Orders {
-				if o.ID == orderID {
-					heap.Remove(ob.StopBids, i)
-					break
-				}
-			}
-		} else {
-			// Find and remove from stop asks
-			for i, o := range ob.StopAsks.Orders {
-				if o.ID == orderID {
-					heap.Remove(ob.StopAsks, i)
-					break
-				}
-			}
-		}
+	// Remove from the heap the order rests in
+	ob.unqueue(order)
+
+	if ob.Phase.IsCall() {
+		ob.publishIndicative()
 	}
+	ob.repricePegs()
 
 	return nil
 }
@@ -532,70 +499,46 @@ func (ob *OrderBook) GetOrder(orderID string) (*Order, error) {
 	return order, nil
 }
 
-// GetOrderBook gets the order book
-func (ob *OrderBook) GetOrderBook(depth int) ([][]float64, [][]float64) {
+// GetLastPrice returns the last traded price
+func (ob *OrderBook) GetLastPrice() Decimal {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
-	// Create a copy of the bids and asks
-	bids := make([]*Order, len(ob.Bids.Orders))
-	asks := make([]*Order, len(ob.Asks.Orders))
-	copy(bids, ob.Bids.Orders)
-	copy(asks, ob.Asks.Orders)
-
-	// Sort bids and asks
-	bidPrices := make(map[float64]float64)
-	askPrices := make(map[float64]float64)
-
-	for _, bid := range bids {
-		bidPrices[bid.Price] += bid.Quantity - bid.FilledQuantity
-	}
-
-	for _, ask := range asks {
-		askPrices[ask.Price] += ask.Quantity - ask.FilledQuantity
-	}
-
-	// Convert to arrays
-	bidArray := make([][]float64, 0, len(bidPrices))
-	askArray := make([][]float64, 0, len(askPrices))
-
-	for price, quantity := range bidPrices {
-		bidArray = append(bidArray, []float64{price, quantity})
-	}
-
-	for price, quantity := range askPrices {
-		askArray = append(askArray, []float64{price, quantity})
-	}
+	return ob.LastPrice
+}
 
-	// Sort bids in descending order
-	for i := 0; i < len(bidArray); i++ {
-		for j := i + 1; j < len(bidArray); j++ {
-			if bidArray[i][0] < bidArray[j][0] {
-				bidArray[i], bidArray[j] = bidArray[j], bidArray[i]
-			}
-		}
-	}
+// GetOrderBook gets the order book
+func (ob *OrderBook) GetOrderBook(depth int) ([][]Decimal, [][]Decimal) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
 
-	// Sort asks in ascending order
-	for i := 0; i < len(askArray); i++ {
-		for j := i + 1; j < len(askArray); j++ {
-			if askArray[i][0] > askArray[j][0] {
-				askArray[i], askArray[j] = askArray[j], askArray[i]
-			}
-		}
-	}
+	return aggregateLevels(ob.Bids, depth), aggregateLevels(ob.Asks, depth)
+}
 
-	// Limit to depth
-	if depth > 0 {
-		if len(bidArray) > depth {
-			bidArray = bidArray[:depth]
+// aggregateLevels sums resting quantity per price in priority order,
+// returning [price, quantity] pairs limited to depth levels when depth > 0
+func aggregateLevels(h *OrderHeap, depth int) [][]Decimal {
+	sorted := &OrderHeap{
+		Orders: make([]*Order, len(h.Orders)),
+		Side:   h.Side,
+	}
+	copy(sorted.Orders, h.Orders)
+	sort.SliceStable(sorted.Orders, sorted.Less)
+
+	levels := make([][]Decimal, 0)
+	for _, order := range sorted.Orders {
+		n := len(levels)
+		if n > 0 && levels[n-1][0].Equal(order.Price) {
+			levels[n-1][1] = levels[n-1][1].Add(order.RemainingQuantity())
+			continue
 		}
-		if len(askArray) > depth {
-			askArray = askArray[:depth]
+		if depth > 0 && n >= depth {
+			break
 		}
+		levels = append(levels, []Decimal{order.Price, order.RemainingQuantity()})
 	}
 
-	return bidArray, askArray
+	return levels
 }
 
 // Engine represents an order matching engine
@@ -608,6 +551,13 @@ type Engine struct {
 	logger *zap.Logger
 	// Trade channel
 	TradeChannel chan *Trade
+	// Event channel for time in force and expiry events
+	EventChannel chan *MatchingEvent
+	// marketHalted and marketHaltReason record a market-wide halt
+	marketHalted     bool
+	marketHaltReason string
+	// statusListeners receive trading halts and resumptions
+	statusListeners []func(types.TradingStatus)
 }
 
 // NewEngine creates a new order matching engine
@@ -616,6 +566,7 @@ func NewEngine(logger *zap.Logger) *Engine {
 		OrderBooks:   make(map[string]*OrderBook),
 		logger:       logger,
 		TradeChannel: make(chan *Trade, 1000),
+		EventChannel: make(chan *MatchingEvent, 1000),
 	}
 }
 
@@ -643,6 +594,10 @@ func (e *Engine) CreateOrderBook(symbol string) *OrderBook {
 	}
 
 	orderBook = NewOrderBook(symbol, e.logger)
+	orderBook.eventHandler = e.publishEvent
+	if e.marketHalted {
+		orderBook.setMarketHalt(true, e.marketHaltReason)
+	}
 	e.OrderBooks[symbol] = orderBook
 
 	return orderBook
@@ -655,10 +610,7 @@ func (e *Engine) PlaceOrder(order *Order) ([]*Trade, error) {
 	e.mu.RUnlock()
 
 	if !exists {
-		e.mu.Lock()
-		orderBook = NewOrderBook(order.Symbol, e.logger)
-		e.OrderBooks[order.Symbol] = orderBook
-		e.mu.Unlock()
+		orderBook = e.CreateOrderBook(order.Symbol)
 	}
 
 	trades, err := orderBook.AddOrder(order)
@@ -674,8 +626,8 @@ func (e *Engine) PlaceOrder(order *Order) ([]*Trade, error) {
 			e.logger.Warn("Trade channel full, dropping trade",
 				zap.String("trade_id", trade.ID),
 				zap.String("symbol", trade.Symbol),
-				zap.Float64("price", trade.Price),
-				zap.Float64("quantity", trade.Quantity))
+				zap.Stringer("price", trade.Price),
+				zap.Stringer("quantity", trade.Quantity))
 		}
 	}
 
@@ -709,13 +661,13 @@ func (e *Engine) GetOrder(symbol, orderID string) (*Order, error) {
 }
 
 // GetMarketData gets market data for a symbol
-func (e *Engine) GetMarketData(symbol string, depth int) ([][]float64, [][]float64, float64, error) {
+func (e *Engine) GetMarketData(symbol string, depth int) ([][]Decimal, [][]Decimal, Decimal, error) {
 	e.mu.RLock()
 	orderBook, exists := e.OrderBooks[symbol]
 	e.mu.RUnlock()
 
 	if !exists {
-		return nil, nil, 0, ErrSymbolNotFound
+		return nil, nil, types.Zero, ErrSymbolNotFound
 	}
 
 	bids, asks := orderBook.GetOrderBook(depth)
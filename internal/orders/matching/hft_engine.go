@@ -9,10 +9,14 @@ import (
 	"unsafe"
 
 	"github.com/abdoElHodaky/tradSys/internal/common/pool"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// hftPriceScale is the fixed-point scale used for atomic price snapshots
+const hftPriceScale = 8
+
 // HFTEngine represents a high-frequency trading optimized order matching engine
 type HFTEngine struct {
 	// OrderBooks is a map of symbol to order book (lock-free access)
@@ -21,6 +25,9 @@ type HFTEngine struct {
 	// Trade channel with buffering for high throughput
 	TradeChannel chan *Trade
 
+	// Event channel for self-trade prevention events
+	EventChannel chan *MatchingEvent
+
 	// Order pools for zero-allocation order processing
 	fastOrderPool *pool.FastOrderPool
 	tradePool     *pool.TradePool
@@ -67,13 +74,16 @@ type HFTOrderBook struct {
 	orders sync.Map // map[string]*Order
 
 	// Last trade price (atomic)
-	lastPrice uint64 // float64 as uint64 for atomic operations
+	lastPrice int64 // unscaled price at hftPriceScale for atomic operations
 
 	// Performance counters
 	orderCount  uint64
 	tradeCount  uint64
 	lastUpdated int64 // Unix nanoseconds
 
+	// Callback for self-trade prevention events
+	eventHandler func(*MatchingEvent)
+
 	// Logger
 	logger *zap.Logger
 }
@@ -96,13 +106,13 @@ type PriceLevelTree struct {
 // PriceLevelNode represents a node in the price level tree
 type PriceLevelNode struct {
 	// Price level
-	price float64
+	price Decimal
 
 	// Orders at this price level (FIFO queue)
 	orders []*Order
 
 	// Total quantity at this price level
-	totalQuantity float64
+	totalQuantity Decimal
 
 	// Tree structure
 	left   *PriceLevelNode
@@ -125,9 +135,10 @@ func NewHFTEngine(logger *zap.Logger) *HFTEngine {
 	orderBooksMap := make(map[string]*HFTOrderBook)
 
 	engine := &HFTEngine{
-		TradeChannel:  make(chan *Trade, 10000), // Large buffer for high throughput
-		fastOrderPool: pool.NewFastOrderPool(),  // Fast order pool for zero-allocation processing
-		tradePool:     pool.NewTradePool(1000),  // Pre-allocate 1000 trades
+		TradeChannel:  make(chan *Trade, 10000),        // Large buffer for high throughput
+		EventChannel:  make(chan *MatchingEvent, 1000), // Self-trade prevention events
+		fastOrderPool: pool.NewFastOrderPool(),         // Fast order pool for zero-allocation processing
+		tradePool:     pool.NewTradePool(1000),         // Pre-allocate 1000 trades
 		logger:        logger,
 		ctx:           ctx,
 		cancel:        cancel,
@@ -156,19 +167,30 @@ func (e *HFTEngine) PlaceOrderFast(order *Order) ([]*Trade, error) {
 	// Get or create order book
 	orderBook := e.getOrCreateOrderBook(order.Symbol)
 
+	// Reject orders that break the instrument rules
+	if err := types.Instruments.Validate(order, orderBook.GetLastPrice()); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
 	// Convert to fast order for better performance
 	fastOrder := e.fastOrderPool.Get()
 	defer e.fastOrderPool.Put(fastOrder) // Return to pool when done
 
 	fastOrder.Order = *order
-	fastOrder.PriceInt64 = int64(order.Price * 100000000) // 8 decimal places precision
-	fastOrder.QuantityInt64 = int64(order.Quantity * 100000000)
+	fastOrder.PriceInt64 = order.Price.Rescale(hftPriceScale).Unscaled()
+	fastOrder.QuantityInt64 = order.Quantity.Rescale(hftPriceScale).Unscaled()
 	fastOrder.CreatedAtNano = startTime.UnixNano()
 	fastOrder.UpdatedAtNano = startTime.UnixNano()
 
 	// Process order
 	trades, err := orderBook.processOrderFast(fastOrder)
 
+	// Report the matching outcome on the caller's order
+	order.Status = fastOrder.Status
+	order.Quantity = fastOrder.Quantity
+	order.FilledQuantity = fastOrder.FilledQuantity
+
 	// Update performance metrics
 	latency := uint64(time.Since(startTime).Nanoseconds())
 	e.updateLatencyStats(latency)
@@ -206,9 +228,10 @@ func (e *HFTEngine) getOrCreateOrderBook(symbol string) *HFTOrderBook {
 
 	// Create new order book
 	newOrderBook := &HFTOrderBook{
-		Symbol:      symbol,
-		logger:      e.logger,
-		lastUpdated: time.Now().UnixNano(),
+		Symbol:       symbol,
+		eventHandler: e.publishEvent,
+		logger:       e.logger,
+		lastUpdated:  time.Now().UnixNano(),
 	}
 
 	// Initialize price level trees
@@ -234,6 +257,19 @@ func (e *HFTEngine) getOrCreateOrderBook(symbol string) *HFTOrderBook {
 func (ob *HFTOrderBook) processOrderFast(order *FastOrder) ([]*Trade, error) {
 	trades := make([]*Trade, 0, 4) // Pre-allocate for common case
 
+	// Kill fill-or-kill orders the book cannot fill completely
+	if order.TimeInForce == TimeInForceFOK {
+		opposite := (*PriceLevelTree)(atomic.LoadPointer(&ob.asks))
+		if order.Side == OrderSideSell {
+			opposite = (*PriceLevelTree)(atomic.LoadPointer(&ob.bids))
+		}
+		required := order.RemainingQuantity()
+		if opposite.availableQuantity(&order.Order, required).LessThan(required) {
+			order.Status = OrderStatusRejected
+			return trades, nil
+		}
+	}
+
 	// Handle market orders with optimized matching
 	if order.Type == OrderTypeMarket {
 		if order.Side == OrderSideBuy {
@@ -249,6 +285,11 @@ func (ob *HFTOrderBook) processOrderFast(order *FastOrder) ([]*Trade, error) {
 		}
 	}
 
+	// Cancel the unfilled remainder of IOC orders
+	if order.Type == OrderTypeLimit && order.IsImmediate() && order.RemainingQuantity().IsPositive() {
+		order.Status = OrderStatusCancelled
+	}
+
 	// Update order book statistics
 	atomic.AddUint64(&ob.orderCount, 1)
 	atomic.StoreInt64(&ob.lastUpdated, time.Now().UnixNano())
@@ -265,20 +306,24 @@ func (ob *HFTOrderBook) matchMarketBuyOrder(order *FastOrder, trades []*Trade) [
 	defer asksTree.mu.Unlock()
 
 	// Find best ask prices and match
-	for order.FilledQuantity < order.Quantity && asksTree.root != nil {
+	for order.RemainingQuantity().IsPositive() && asksTree.root != nil && order.Status != OrderStatusCancelled {
 		bestAsk := asksTree.findBestPrice()
 		if bestAsk == nil {
 			break
 		}
 
+		// Apply self-trade prevention instead of trading with our own order
+		if ob.preventSelfTradeFast(order, asksTree, bestAsk) {
+			continue
+		}
+
 		// Match with best ask
 		trade := ob.executeTradeOptimized(order, bestAsk.orders[0])
 		if trade != nil {
 			trades = append(trades, trade)
 
 			// Update last price atomically
-			priceUint64 := *(*uint64)(unsafe.Pointer(&trade.Price))
-			atomic.StoreUint64(&ob.lastPrice, priceUint64)
+			atomic.StoreInt64(&ob.lastPrice, trade.Price.Rescale(hftPriceScale).Unscaled())
 		}
 
 		// Remove filled orders
@@ -305,20 +350,24 @@ func (ob *HFTOrderBook) matchMarketSellOrder(order *FastOrder, trades []*Trade)
 	defer bidsTree.mu.Unlock()
 
 	// Find best bid prices and match
-	for order.FilledQuantity < order.Quantity && bidsTree.root != nil {
+	for order.RemainingQuantity().IsPositive() && bidsTree.root != nil && order.Status != OrderStatusCancelled {
 		bestBid := bidsTree.findBestPrice()
 		if bestBid == nil {
 			break
 		}
 
+		// Apply self-trade prevention instead of trading with our own order
+		if ob.preventSelfTradeFast(order, bidsTree, bestBid) {
+			continue
+		}
+
 		// Match with best bid
 		trade := ob.executeTradeOptimized(order, bestBid.orders[0])
 		if trade != nil {
 			trades = append(trades, trade)
 
 			// Update last price atomically
-			priceUint64 := *(*uint64)(unsafe.Pointer(&trade.Price))
-			atomic.StoreUint64(&ob.lastPrice, priceUint64)
+			atomic.StoreInt64(&ob.lastPrice, trade.Price.Rescale(hftPriceScale).Unscaled())
 		}
 
 		// Remove filled orders
@@ -345,20 +394,24 @@ func (ob *HFTOrderBook) matchLimitBuyOrder(order *FastOrder, trades []*Trade) []
 	defer asksTree.mu.Unlock()
 
 	// Match against asks at or below the limit price
-	for order.FilledQuantity < order.Quantity {
+	for order.RemainingQuantity().IsPositive() && order.Status != OrderStatusCancelled {
 		bestAsk := asksTree.findBestPrice()
-		if bestAsk == nil || bestAsk.price > order.Price {
+		if bestAsk == nil || bestAsk.price.GreaterThan(order.Price) {
 			break
 		}
 
+		// Apply self-trade prevention instead of trading with our own order
+		if ob.preventSelfTradeFast(order, asksTree, bestAsk) {
+			continue
+		}
+
 		// Match with best ask
 		trade := ob.executeTradeOptimized(order, bestAsk.orders[0])
 		if trade != nil {
 			trades = append(trades, trade)
 
 			// Update last price atomically
-			priceUint64 := *(*uint64)(unsafe.Pointer(&trade.Price))
-			atomic.StoreUint64(&ob.lastPrice, priceUint64)
+			atomic.StoreInt64(&ob.lastPrice, trade.Price.Rescale(hftPriceScale).Unscaled())
 		}
 
 		// Remove filled orders
@@ -374,10 +427,12 @@ func (ob *HFTOrderBook) matchLimitBuyOrder(order *FastOrder, trades []*Trade) []
 	}
 
 	// Add remaining quantity to order book if not fully filled
-	if order.FilledQuantity < order.Quantity {
+	if order.RemainingQuantity().IsPositive() && !order.IsImmediate() && order.Status != OrderStatusCancelled {
 		bidsPtr := atomic.LoadPointer(&ob.bids)
 		bidsTree := (*PriceLevelTree)(bidsPtr)
-		bidsTree.addOrder(&order.Order)
+		// Rest a copy since the fast order returns to the pool
+		resting := order.Order
+		bidsTree.addOrder(&resting)
 	}
 
 	return trades
@@ -392,20 +447,24 @@ func (ob *HFTOrderBook) matchLimitSellOrder(order *FastOrder, trades []*Trade) [
 	defer bidsTree.mu.Unlock()
 
 	// Match against bids at or above the limit price
-	for order.FilledQuantity < order.Quantity {
+	for order.RemainingQuantity().IsPositive() && order.Status != OrderStatusCancelled {
 		bestBid := bidsTree.findBestPrice()
-		if bestBid == nil || bestBid.price < order.Price {
+		if bestBid == nil || bestBid.price.LessThan(order.Price) {
 			break
 		}
 
+		// Apply self-trade prevention instead of trading with our own order
+		if ob.preventSelfTradeFast(order, bidsTree, bestBid) {
+			continue
+		}
+
 		// Match with best bid
 		trade := ob.executeTradeOptimized(order, bestBid.orders[0])
 		if trade != nil {
 			trades = append(trades, trade)
 
 			// Update last price atomically
-			priceUint64 := *(*uint64)(unsafe.Pointer(&trade.Price))
-			atomic.StoreUint64(&ob.lastPrice, priceUint64)
+			atomic.StoreInt64(&ob.lastPrice, trade.Price.Rescale(hftPriceScale).Unscaled())
 		}
 
 		// Remove filled orders
@@ -421,10 +480,12 @@ func (ob *HFTOrderBook) matchLimitSellOrder(order *FastOrder, trades []*Trade) [
 	}
 
 	// Add remaining quantity to order book if not fully filled
-	if order.FilledQuantity < order.Quantity {
+	if order.RemainingQuantity().IsPositive() && !order.IsImmediate() && order.Status != OrderStatusCancelled {
 		asksPtr := atomic.LoadPointer(&ob.asks)
 		asksTree := (*PriceLevelTree)(asksPtr)
-		asksTree.addOrder(&order.Order)
+		// Rest a copy since the fast order returns to the pool
+		resting := order.Order
+		asksTree.addOrder(&resting)
 	}
 
 	return trades
@@ -433,28 +494,23 @@ func (ob *HFTOrderBook) matchLimitSellOrder(order *FastOrder, trades []*Trade) [
 // executeTradeOptimized executes a trade with optimizations
 func (ob *HFTOrderBook) executeTradeOptimized(taker *FastOrder, maker *Order) *Trade {
 	// Calculate trade quantity (minimum of remaining quantities)
-	takerRemaining := taker.Quantity - taker.FilledQuantity
-	makerRemaining := maker.Quantity - maker.FilledQuantity
-	tradeQuantity := takerRemaining
-	if makerRemaining < tradeQuantity {
-		tradeQuantity = makerRemaining
-	}
+	tradeQuantity := types.MinDecimal(taker.RemainingQuantity(), maker.RemainingQuantity())
 
 	// Trade price is the maker's price (price-time priority)
 	tradePrice := maker.Price
 
 	// Update order quantities
-	taker.FilledQuantity += tradeQuantity
-	maker.FilledQuantity += tradeQuantity
+	taker.FilledQuantity = taker.FilledQuantity.Add(tradeQuantity)
+	maker.FilledQuantity = maker.FilledQuantity.Add(tradeQuantity)
 
 	// Update order statuses
-	if taker.FilledQuantity >= taker.Quantity {
+	if taker.IsFilled() {
 		taker.Status = OrderStatusFilled
 	} else {
 		taker.Status = OrderStatusPartiallyFilled
 	}
 
-	if maker.FilledQuantity >= maker.Quantity {
+	if maker.IsFilled() {
 		maker.Status = OrderStatusFilled
 	} else {
 		maker.Status = OrderStatusPartiallyFilled
@@ -514,6 +570,39 @@ func (tree *PriceLevelTree) findBestPrice() *PriceLevelNode {
 	}
 }
 
+// availableQuantity sums the resting quantity at prices the order may trade
+// against, stopping once required is covered
+func (tree *PriceLevelTree) availableQuantity(order *Order, required Decimal) Decimal {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	available := types.Zero
+	var walk func(node *PriceLevelNode) bool
+	walk = func(node *PriceLevelNode) bool {
+		if node == nil {
+			return false
+		}
+		if walk(node.left) {
+			return true
+		}
+		tradable := order.Type == OrderTypeMarket ||
+			(order.Side == OrderSideBuy && node.price.LessThanOrEqual(order.Price)) ||
+			(order.Side == OrderSideSell && node.price.GreaterThanOrEqual(order.Price))
+		if tradable {
+			for _, resting := range node.orders {
+				available = available.Add(resting.RemainingQuantity())
+			}
+			if available.GreaterThanOrEqual(required) {
+				return true
+			}
+		}
+		return walk(node.right)
+	}
+	walk(tree.root)
+
+	return available
+}
+
 // addOrder adds an order to the price level tree
 func (tree *PriceLevelTree) addOrder(order *Order) {
 	tree.mu.Lock()
@@ -533,23 +622,23 @@ func (tree *PriceLevelTree) addOrder(order *Order) {
 	// Find or create price level
 	node := tree.findOrCreatePriceLevel(order.Price)
 	node.orders = append(node.orders, order)
-	node.totalQuantity += order.Quantity
+	node.totalQuantity = node.totalQuantity.Add(order.Quantity)
 	node.orderCount++
 }
 
 // findOrCreatePriceLevel finds or creates a price level node
-func (tree *PriceLevelTree) findOrCreatePriceLevel(price float64) *PriceLevelNode {
+func (tree *PriceLevelTree) findOrCreatePriceLevel(price Decimal) *PriceLevelNode {
 	node := tree.root
 
 	for {
-		if price == node.price {
+		if price.Equal(node.price) {
 			return node
-		} else if price < node.price {
+		} else if price.LessThan(node.price) {
 			if node.left == nil {
 				node.left = &PriceLevelNode{
 					price:         price,
 					orders:        make([]*Order, 0, 4),
-					totalQuantity: 0,
+					totalQuantity: types.Zero,
 					parent:        node,
 					orderCount:    0,
 				}
@@ -562,7 +651,7 @@ func (tree *PriceLevelTree) findOrCreatePriceLevel(price float64) *PriceLevelNod
 				node.right = &PriceLevelNode{
 					price:         price,
 					orders:        make([]*Order, 0, 4),
-					totalQuantity: 0,
+					totalQuantity: types.Zero,
 					parent:        node,
 					orderCount:    0,
 				}
@@ -707,14 +796,25 @@ func (e *HFTEngine) processTradesAsync() {
 			e.logger.Debug("Trade executed",
 				zap.String("trade_id", trade.ID),
 				zap.String("symbol", trade.Symbol),
-				zap.Float64("price", trade.Price),
-				zap.Float64("quantity", trade.Quantity),
+				zap.Stringer("price", trade.Price),
+				zap.Stringer("quantity", trade.Quantity),
 				zap.String("taker_side", string(trade.TakerSide)),
 			)
 		}
 	}
 }
 
+// publishEvent forwards an order book event to the event channel
+func (e *HFTEngine) publishEvent(event *MatchingEvent) {
+	select {
+	case e.EventChannel <- event:
+	default:
+		e.logger.Warn("Event channel full, dropping event",
+			zap.String("event_type", string(event.Type)),
+			zap.String("symbol", event.Symbol))
+	}
+}
+
 // GetStats returns current engine statistics
 func (e *HFTEngine) GetStats() *EngineStats {
 	return &EngineStats{
@@ -747,9 +847,8 @@ func (e *HFTEngine) Stop() {
 }
 
 // GetLastPrice returns the last traded price for a symbol
-func (ob *HFTOrderBook) GetLastPrice() float64 {
-	priceUint64 := atomic.LoadUint64(&ob.lastPrice)
-	return *(*float64)(unsafe.Pointer(&priceUint64))
+func (ob *HFTOrderBook) GetLastPrice() Decimal {
+	return types.NewDecimal(atomic.LoadInt64(&ob.lastPrice), hftPriceScale)
 }
 
 // GetOrderCount returns the total number of orders processed
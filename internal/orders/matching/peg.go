@@ -0,0 +1,116 @@
+package order_matching
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// pricePeg sets the price of an arriving pegged order from the book, so
+// the instrument checks and matching see the price it will trade at.
+// Pegged orders are accepted in continuous trading only and need their
+// reference price to be available.
+func (ob *OrderBook) pricePeg(order *Order) error {
+	if err := order.ValidatePeg(); err != nil {
+		return err
+	}
+	if !order.IsPegged() {
+		return nil
+	}
+	if ob.Phase.IsCall() {
+		return types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"pegged orders are not accepted in the %s phase", ob.Phase)
+	}
+
+	price, priced := order.PegPrice(pegReference(ob.Bids), pegReference(ob.Asks))
+	if !priced {
+		return types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"no reference price for %s peg", order.PegType)
+	}
+	order.Price = price
+	return nil
+}
+
+// pegReference returns the best price in a heap among the orders that are
+// not themselves pegged, or zero if there is none. Hidden orders never set
+// a peg reference.
+func pegReference(h *OrderHeap) Decimal {
+	reference := types.Zero
+	for _, order := range h.Orders {
+		if order.IsPegged() {
+			continue
+		}
+		if reference.IsZero() || h.better(order.Price, reference) {
+			reference = order.Price
+		}
+	}
+	return reference
+}
+
+// repricePegs moves the resting pegged orders to the prices their
+// references now give, oldest first. A repriced order queues behind the
+// orders already at its new price and stops short of the best opposite
+// order, so repricing never trades. Orders keep their price while their
+// reference is missing, and pegs wait out auction calls.
+func (ob *OrderBook) repricePegs() {
+	if len(ob.pegs) == 0 || ob.Phase.IsCall() {
+		return
+	}
+
+	pegs := make([]*Order, 0, len(ob.pegs))
+	for id, order := range ob.pegs {
+		if ob.restingHeap(order).find(id) < 0 {
+			delete(ob.pegs, id)
+			continue
+		}
+		pegs = append(pegs, order)
+	}
+	sort.Slice(pegs, func(i, j int) bool {
+		if !pegs[i].QueueTime().Equal(pegs[j].QueueTime()) {
+			return pegs[i].QueueTime().Before(pegs[j].QueueTime())
+		}
+		return pegs[i].ID < pegs[j].ID
+	})
+
+	bid, ask := pegReference(ob.Bids), pegReference(ob.Asks)
+	now := time.Now()
+	for _, order := range pegs {
+		price, priced := order.PegPrice(bid, ask)
+		if !priced {
+			continue
+		}
+		price = order.PassivePegPrice(price, ob.bestOpposite(order))
+		if !price.IsPositive() || price.Equal(order.Price) {
+			continue
+		}
+
+		h := ob.restingHeap(order)
+		heap.Remove(h, h.find(order.ID))
+		order.Price = price
+		order.QueuedAt = now
+		order.UpdatedAt = now
+		heap.Push(h, order)
+
+		ob.logger.Debug("Pegged order repriced",
+			zap.String("order_id", order.ID),
+			zap.String("peg_type", string(order.PegType)),
+			zap.Stringer("price", price))
+		ob.emit(EventOrderRepriced, order)
+	}
+}
+
+// bestOpposite returns the best price an order faces, displayed or hidden,
+// or zero if the opposite side is empty
+func (ob *OrderBook) bestOpposite(order *Order) Decimal {
+	opposite, hidden := ob.Asks, ob.HiddenAsks
+	if order.Side == OrderSideSell {
+		opposite, hidden = ob.Bids, ob.HiddenBids
+	}
+	if _, best := ob.best(opposite, hidden); best != nil {
+		return best.Price
+	}
+	return types.Zero
+}
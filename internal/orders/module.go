@@ -3,6 +3,8 @@ package orders
 import (
 	"context"
 	"github.com/abdoElHodaky/tradSys/internal/core/matching"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -34,3 +36,43 @@ func NewFxService(
 
 	return service
 }
+
+// OrderServiceParams contains the parameters for creating an order service
+type OrderServiceParams struct {
+	fx.In
+
+	Lifecycle  fx.Lifecycle
+	Logger     *zap.Logger
+	Engine     matching.OrderEngine
+	Repository *repositories.OrderRepository `optional:"true"`
+}
+
+// NewFxOrderService creates the order service behind order entry for the
+// fx application. With a repository, orders, their history, groups and
+// control actions are persisted, and the active orders are recovered on
+// start. The engine is started first, so its books are rebuilt from its
+// snapshot and journal before the orders are reconciled against them.
+func NewFxOrderService(p OrderServiceParams) *OrderService {
+	service := NewOrderService(p.Engine, p.Logger)
+	if p.Repository != nil {
+		service.SetOrderRepository(p.Repository)
+		service.SetHistoryRepository(p.Repository)
+		service.SetGroupRepository(p.Repository)
+		service.SetAuditRepository(p.Repository)
+		service.SetKillSwitchRepository(p.Repository)
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if _, err := service.Recover(ctx); err != nil {
+				return err
+			}
+			return service.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return service.Stop()
+		},
+	})
+
+	return service
+}
@@ -0,0 +1,529 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// KillSwitchScope is the kind of entity a kill switch or mass cancel targets
+type KillSwitchScope string
+
+const (
+	// KillSwitchUser targets the orders of a user
+	KillSwitchUser KillSwitchScope = "user"
+	// KillSwitchAccount targets the orders of an account group
+	KillSwitchAccount KillSwitchScope = "account"
+	// KillSwitchSymbol targets the orders for a symbol
+	KillSwitchSymbol KillSwitchScope = "symbol"
+	// KillSwitchStrategy targets the orders tagged with a strategy
+	KillSwitchStrategy KillSwitchScope = "strategy"
+)
+
+// IsValid reports whether the scope is known
+func (s KillSwitchScope) IsValid() bool {
+	switch s {
+	case KillSwitchUser, KillSwitchAccount, KillSwitchSymbol, KillSwitchStrategy:
+		return true
+	}
+	return false
+}
+
+// StrategyTag is the order tag naming the strategy that placed an order
+const StrategyTag = "strategy"
+
+// Order control actions recorded in the audit trail
+const (
+	AuditActionMassCancel         = "mass_cancel"
+	AuditActionKillSwitchEnabled  = "kill_switch_enabled"
+	AuditActionKillSwitchDisabled = "kill_switch_disabled"
+	AuditActionCancelOnDisconnect = "cancel_on_disconnect"
+)
+
+// KillSwitch blocks new orders for an entity until it is disabled
+type KillSwitch struct {
+	// Scope is the kind of entity blocked
+	Scope KillSwitchScope
+	// Value identifies the entity, such as the user ID or symbol
+	Value string
+	// Actor is who enabled the switch
+	Actor string
+	// Reason is why the switch was enabled
+	Reason string
+	// EnabledAt is the time the switch was enabled
+	EnabledAt time.Time
+}
+
+// KillSwitchRequest represents a request to enable a kill switch
+type KillSwitchRequest struct {
+	// Scope is the kind of entity to block
+	Scope KillSwitchScope
+	// Value identifies the entity
+	Value string
+	// Actor is who enables the switch
+	Actor string
+	// Reason is why the switch is enabled
+	Reason string
+	// CancelOpenOrders also cancels the open orders of the entity
+	CancelOpenOrders bool
+}
+
+// MassCancelRequest selects the open orders to cancel. Every filter set
+// must match; at least one of the user, account, symbol or strategy is
+// required.
+type MassCancelRequest struct {
+	// UserID is the user whose orders are cancelled
+	UserID string
+	// AccountGroup is the account group whose orders are cancelled
+	AccountGroup string
+	// Symbol is the symbol whose orders are cancelled
+	Symbol string
+	// Side limits the cancel to one side of the book
+	Side OrderSide
+	// Strategy is the strategy tag of the orders cancelled
+	Strategy string
+	// Actor is who requests the cancel
+	Actor string
+	// Reason is why the orders are cancelled
+	Reason string
+}
+
+// matches reports whether an order falls under the request
+func (r *MassCancelRequest) matches(order *Order) bool {
+	return (r.UserID == "" || order.UserID == r.UserID) &&
+		(r.AccountGroup == "" || order.AccountGroup == r.AccountGroup) &&
+		(r.Symbol == "" || order.Symbol == r.Symbol) &&
+		(r.Side == "" || order.Side == r.Side) &&
+		(r.Strategy == "" || order.Tags[StrategyTag] == r.Strategy)
+}
+
+// entity returns the broadest entity the request targets, for the audit trail
+func (r *MassCancelRequest) entity() (KillSwitchScope, string) {
+	switch {
+	case r.UserID != "":
+		return KillSwitchUser, r.UserID
+	case r.AccountGroup != "":
+		return KillSwitchAccount, r.AccountGroup
+	case r.Symbol != "":
+		return KillSwitchSymbol, r.Symbol
+	default:
+		return KillSwitchStrategy, r.Strategy
+	}
+}
+
+// massCancelFor selects every open order of an entity
+func massCancelFor(scope KillSwitchScope, value string) *MassCancelRequest {
+	switch scope {
+	case KillSwitchUser:
+		return &MassCancelRequest{UserID: value}
+	case KillSwitchAccount:
+		return &MassCancelRequest{AccountGroup: value}
+	case KillSwitchSymbol:
+		return &MassCancelRequest{Symbol: value}
+	default:
+		return &MassCancelRequest{Strategy: value}
+	}
+}
+
+// AuditRepository records order control actions
+type AuditRepository interface {
+	CreateAuditRecord(ctx context.Context, record *db.AuditRecord) error
+}
+
+// KillSwitchRepository persists the engaged kill switches so they survive
+// restarts
+type KillSwitchRepository interface {
+	SaveKillSwitch(ctx context.Context, killSwitch *db.KillSwitch) error
+	DeleteKillSwitch(ctx context.Context, id string) error
+	GetKillSwitches(ctx context.Context) ([]*db.KillSwitch, error)
+}
+
+// record converts the kill switch to its database model
+func (k *KillSwitch) record() *db.KillSwitch {
+	return &db.KillSwitch{
+		ID:        killSwitchKey(k.Scope, k.Value),
+		Scope:     string(k.Scope),
+		Value:     k.Value,
+		Actor:     k.Actor,
+		Reason:    k.Reason,
+		EnabledAt: k.EnabledAt,
+	}
+}
+
+// orderSession is a connected client of a user
+type orderSession struct {
+	userID             string
+	cancelOnDisconnect bool
+}
+
+// orderControls holds the kill switches and client sessions of a service
+type orderControls struct {
+	killSwitches map[string]*KillSwitch
+	sessions     map[string]*orderSession
+	audit        AuditRepository
+	store        KillSwitchRepository
+	mu           sync.RWMutex
+}
+
+// newOrderControls creates empty order controls
+func newOrderControls() *orderControls {
+	return &orderControls{
+		killSwitches: make(map[string]*KillSwitch),
+		sessions:     make(map[string]*orderSession),
+	}
+}
+
+// killSwitchKey indexes a kill switch by its entity
+func killSwitchKey(scope KillSwitchScope, value string) string {
+	return string(scope) + ":" + value
+}
+
+// killSwitchFor returns the kill switch blocking an order request of a user
+func (c *orderControls) killSwitchFor(userID string, req *OrderRequest) *KillSwitch {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.killSwitches) == 0 {
+		return nil
+	}
+
+	entities := []struct {
+		scope KillSwitchScope
+		value string
+	}{
+		{KillSwitchUser, userID},
+		{KillSwitchAccount, req.AccountGroup},
+		{KillSwitchSymbol, req.Symbol},
+		{KillSwitchStrategy, req.Tags[StrategyTag]},
+	}
+	for _, entity := range entities {
+		if entity.value == "" {
+			continue
+		}
+		if killSwitch, exists := c.killSwitches[killSwitchKey(entity.scope, entity.value)]; exists {
+			return killSwitch
+		}
+	}
+	return nil
+}
+
+// SetAuditRepository records every order control action through repository
+func (s *OrderService) SetAuditRepository(repository AuditRepository) {
+	s.controls.mu.Lock()
+	defer s.controls.mu.Unlock()
+
+	s.controls.audit = repository
+}
+
+// SetKillSwitchRepository persists the engaged kill switches through
+// repository; Recover engages them again after a restart
+func (s *OrderService) SetKillSwitchRepository(repository KillSwitchRepository) {
+	s.controls.mu.Lock()
+	defer s.controls.mu.Unlock()
+
+	s.controls.store = repository
+}
+
+// recoverKillSwitches engages the kill switches stored in the repository
+// and returns how many there are
+func (s *OrderService) recoverKillSwitches(ctx context.Context) (int, error) {
+	s.controls.mu.RLock()
+	store := s.controls.store
+	s.controls.mu.RUnlock()
+	if store == nil {
+		return 0, nil
+	}
+
+	records, err := store.GetKillSwitches(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.controls.mu.Lock()
+	defer s.controls.mu.Unlock()
+	for _, record := range records {
+		scope := KillSwitchScope(record.Scope)
+		s.controls.killSwitches[killSwitchKey(scope, record.Value)] = &KillSwitch{
+			Scope:     scope,
+			Value:     record.Value,
+			Actor:     record.Actor,
+			Reason:    record.Reason,
+			EnabledAt: record.EnabledAt,
+		}
+	}
+	return len(records), nil
+}
+
+// CheckKillSwitches returns ErrKillSwitchEngaged when a kill switch blocks
+// the order request
+func (s *OrderService) CheckKillSwitches(req *OrderRequest) error {
+	return s.checkKillSwitches(req.UserID, req)
+}
+
+// checkKillSwitches checks an order request placed for a user
+func (s *OrderService) checkKillSwitches(userID string, req *OrderRequest) error {
+	killSwitch := s.controls.killSwitchFor(userID, req)
+	if killSwitch == nil {
+		return nil
+	}
+
+	s.logger.Warn("Order blocked by kill switch",
+		zap.String("user_id", userID),
+		zap.String("symbol", req.Symbol),
+		zap.String("scope", string(killSwitch.Scope)),
+		zap.String("value", killSwitch.Value))
+	return ErrKillSwitchEngaged
+}
+
+// EnableKillSwitch blocks new orders for an entity until the switch is
+// disabled, and cancels the entity's open orders when asked to. Enabling an
+// engaged switch replaces its actor and reason. It returns the switch and
+// the orders cancelled.
+func (s *OrderService) EnableKillSwitch(ctx context.Context, req *KillSwitchRequest) (*KillSwitch, []*Order, error) {
+	if req == nil || !req.Scope.IsValid() || req.Value == "" {
+		return nil, nil, ErrInvalidKillSwitch
+	}
+
+	killSwitch := &KillSwitch{
+		Scope:     req.Scope,
+		Value:     req.Value,
+		Actor:     req.Actor,
+		Reason:    req.Reason,
+		EnabledAt: time.Now(),
+	}
+	s.controls.mu.Lock()
+	s.controls.killSwitches[killSwitchKey(req.Scope, req.Value)] = killSwitch
+	store := s.controls.store
+	s.controls.mu.Unlock()
+
+	if store != nil {
+		if err := store.SaveKillSwitch(ctx, killSwitch.record()); err != nil {
+			s.logger.Error("Failed to save kill switch",
+				zap.String("scope", string(req.Scope)),
+				zap.String("value", req.Value),
+				zap.Error(err))
+		}
+	}
+
+	var cancelled []*Order
+	if req.CancelOpenOrders {
+		cancelled = s.cancelMatching(massCancelFor(req.Scope, req.Value), ReasonKillSwitch)
+	}
+
+	s.recordAudit(ctx, AuditActionKillSwitchEnabled, req.Actor, req.Scope, req.Value, req.Reason,
+		map[string]interface{}{"cancelled_order_ids": idsOf(cancelled)})
+
+	enabled := *killSwitch
+	return &enabled, cancelled, nil
+}
+
+// DisableKillSwitch lets an entity place orders again
+func (s *OrderService) DisableKillSwitch(ctx context.Context, scope KillSwitchScope, value, actor, reason string) (*KillSwitch, error) {
+	key := killSwitchKey(scope, value)
+
+	s.controls.mu.Lock()
+	killSwitch, exists := s.controls.killSwitches[key]
+	delete(s.controls.killSwitches, key)
+	store := s.controls.store
+	s.controls.mu.Unlock()
+	if !exists {
+		return nil, ErrKillSwitchNotFound
+	}
+
+	if store != nil {
+		if err := store.DeleteKillSwitch(ctx, key); err != nil {
+			s.logger.Error("Failed to delete kill switch",
+				zap.String("scope", string(scope)),
+				zap.String("value", value),
+				zap.Error(err))
+		}
+	}
+
+	s.recordAudit(ctx, AuditActionKillSwitchDisabled, actor, scope, value, reason,
+		map[string]interface{}{"enabled_by": killSwitch.Actor, "enabled_at": killSwitch.EnabledAt})
+
+	return killSwitch, nil
+}
+
+// GetKillSwitches returns the engaged kill switches, oldest first
+func (s *OrderService) GetKillSwitches() []*KillSwitch {
+	s.controls.mu.RLock()
+	killSwitches := make([]*KillSwitch, 0, len(s.controls.killSwitches))
+	for _, killSwitch := range s.controls.killSwitches {
+		engaged := *killSwitch
+		killSwitches = append(killSwitches, &engaged)
+	}
+	s.controls.mu.RUnlock()
+
+	sort.Slice(killSwitches, func(i, j int) bool {
+		return killSwitches[i].EnabledAt.Before(killSwitches[j].EnabledAt)
+	})
+	return killSwitches
+}
+
+// MassCancel cancels every open order matching the request and returns
+// the orders cancelled
+func (s *OrderService) MassCancel(ctx context.Context, req *MassCancelRequest) ([]*Order, error) {
+	if req == nil || (req.UserID == "" && req.AccountGroup == "" && req.Symbol == "" && req.Strategy == "") {
+		return nil, ErrInvalidMassCancel
+	}
+	if req.Side != "" && req.Side != OrderSideBuy && req.Side != OrderSideSell {
+		return nil, ErrInvalidOrderSide
+	}
+
+	cancelled := s.cancelMatching(req, ReasonMassCancelled)
+
+	scope, value := req.entity()
+	s.recordAudit(ctx, AuditActionMassCancel, req.Actor, scope, value, req.Reason,
+		map[string]interface{}{
+			"user_id":             req.UserID,
+			"account_group":       req.AccountGroup,
+			"symbol":              req.Symbol,
+			"side":                req.Side,
+			"strategy":            req.Strategy,
+			"cancelled_order_ids": idsOf(cancelled),
+		})
+
+	return cancelled, nil
+}
+
+// OpenSession registers a client session of a user. When the last session
+// of a user opened with cancelOnDisconnect closes, the user's open orders
+// are cancelled. Opening a session again replaces its settings.
+func (s *OrderService) OpenSession(sessionID, userID string, cancelOnDisconnect bool) {
+	s.controls.mu.Lock()
+	defer s.controls.mu.Unlock()
+
+	s.controls.sessions[sessionID] = &orderSession{
+		userID:             userID,
+		cancelOnDisconnect: cancelOnDisconnect,
+	}
+}
+
+// CloseSession ends a client session and returns the orders cancelled
+// because it disconnected
+func (s *OrderService) CloseSession(ctx context.Context, sessionID string) []*Order {
+	s.controls.mu.Lock()
+	session, exists := s.controls.sessions[sessionID]
+	delete(s.controls.sessions, sessionID)
+	cancel := exists && session.cancelOnDisconnect
+	for _, other := range s.controls.sessions {
+		if cancel && other.userID == session.userID && other.cancelOnDisconnect {
+			cancel = false
+		}
+	}
+	s.controls.mu.Unlock()
+	if !cancel {
+		return nil
+	}
+
+	cancelled := s.cancelMatching(&MassCancelRequest{UserID: session.userID}, ReasonCancelOnDisconnect)
+
+	s.recordAudit(ctx, AuditActionCancelOnDisconnect, session.userID, KillSwitchUser, session.userID, "session disconnected",
+		map[string]interface{}{"session_id": sessionID, "cancelled_order_ids": idsOf(cancelled)})
+
+	return cancelled
+}
+
+// cancelMatching cancels the open orders a request selects, oldest first
+func (s *OrderService) cancelMatching(req *MassCancelRequest, reason TransitionReason) []*Order {
+	s.mu.RLock()
+	var matched []*Order
+	for _, order := range s.Orders {
+		if !isClosedStatus(order.Status) && req.matches(order) {
+			matched = append(matched, order)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID < matched[j].ID
+		}
+		return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+	})
+
+	cancelled := make([]*Order, 0, len(matched))
+	for _, order := range matched {
+		if s.cancelOpenOrder(order, reason) {
+			cancelled = append(cancelled, order)
+		}
+	}
+	return cancelled
+}
+
+// cancelOpenOrder pulls an order from the book and cancels it. Orders a
+// group sibling closed in the meantime, and orders that left the book by
+// executing, are left alone.
+func (s *OrderService) cancelOpenOrder(order *Order, reason TransitionReason) bool {
+	if isClosedStatus(order.Status) {
+		return false
+	}
+
+	// Held contingent legs have not reached the book yet
+	if order.Status != OrderStatusNew && !s.MatchingEngine.CancelOrder(order.Symbol, order.ID) {
+		s.logger.Warn("Order left the book before it could be cancelled",
+			zap.String("order_id", order.ID),
+			zap.String("reason", string(reason)))
+		return false
+	}
+
+	if err := s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, reason); err != nil {
+		s.logger.Error("Failed to cancel order",
+			zap.String("order_id", order.ID),
+			zap.String("reason", string(reason)),
+			zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// recordAudit logs an order control action and records it through the
+// audit repository, if one is set
+func (s *OrderService) recordAudit(ctx context.Context, action, actor string, scope KillSwitchScope, value, reason string, details map[string]interface{}) {
+	s.logger.Info("Order control action",
+		zap.String("action", action),
+		zap.String("actor", actor),
+		zap.String("scope", string(scope)),
+		zap.String("value", value),
+		zap.String("reason", reason))
+
+	s.controls.mu.RLock()
+	audit := s.controls.audit
+	s.controls.mu.RUnlock()
+	if audit == nil {
+		return
+	}
+
+	encoded, _ := json.Marshal(details)
+	record := &db.AuditRecord{
+		ID:      uuid.New().String(),
+		Action:  action,
+		Actor:   actor,
+		Scope:   string(scope),
+		Value:   value,
+		Reason:  reason,
+		Details: string(encoded),
+	}
+	record.CreatedAt = time.Now()
+
+	if err := audit.CreateAuditRecord(ctx, record); err != nil {
+		s.logger.Error("Failed to record order control action",
+			zap.String("action", action),
+			zap.Error(err))
+	}
+}
+
+// idsOf returns the IDs of orders
+func idsOf(orders []*Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
@@ -0,0 +1,501 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OrderGroupType is the kind of link between the orders of a contingent group
+type OrderGroupType string
+
+const (
+	// OrderGroupOCO cancels the other legs once one leg executes or closes
+	OrderGroupOCO OrderGroupType = "oco"
+	// OrderGroupOTO holds the legs until the primary order fills
+	OrderGroupOTO OrderGroupType = "oto"
+	// OrderGroupBracket holds a take-profit and a stop-loss leg until the
+	// entry fills; the two exits then cancel each other
+	OrderGroupBracket OrderGroupType = "bracket"
+)
+
+// OrderGroupStatus represents the status of a contingent order group
+type OrderGroupStatus string
+
+const (
+	// OrderGroupStatusPending waits for the primary order to fill
+	OrderGroupStatusPending OrderGroupStatus = "pending"
+	// OrderGroupStatusActive has its legs working in the market
+	OrderGroupStatusActive OrderGroupStatus = "active"
+	// OrderGroupStatusCompleted was resolved by an execution
+	OrderGroupStatusCompleted OrderGroupStatus = "completed"
+	// OrderGroupStatusCancelled was cancelled or closed without an execution
+	OrderGroupStatusCancelled OrderGroupStatus = "cancelled"
+)
+
+// IsOpen reports whether the group still links its orders
+func (s OrderGroupStatus) IsOpen() bool {
+	return s == OrderGroupStatusPending || s == OrderGroupStatusActive
+}
+
+// OrderGroup links orders that cancel or release each other
+type OrderGroup struct {
+	// ID is the unique identifier for the group
+	ID string
+	// Type is the kind of link between the orders
+	Type OrderGroupType
+	// UserID is the user ID
+	UserID string
+	// Status is the status of the group
+	Status OrderGroupStatus
+	// PrimaryOrderID is the order whose fill releases the legs of an OTO
+	// or bracket group
+	PrimaryOrderID string
+	// LegOrderIDs are the OCO legs or the contingent legs; a bracket lists
+	// its take-profit first and its stop-loss second
+	LegOrderIDs []string
+	// CreatedAt is the time the group was created
+	CreatedAt time.Time
+	// UpdatedAt is the time the group was last updated
+	UpdatedAt time.Time
+}
+
+// OrderGroupRequest represents a request to place a contingent order group.
+// Every order of the group belongs to the group's user.
+type OrderGroupRequest struct {
+	// UserID is the user ID
+	UserID string
+	// Type is the kind of link between the orders
+	Type OrderGroupType
+	// Primary is the order releasing the legs of an OTO or bracket group
+	Primary *OrderRequest
+	// Legs are the OCO legs or the contingent legs; a bracket takes the
+	// take-profit first and the stop-loss second
+	Legs []*OrderRequest
+}
+
+// OrderGroupRepository persists contingent order groups
+type OrderGroupRepository interface {
+	SaveOrderGroup(ctx context.Context, group *db.OrderGroup) error
+}
+
+// orderIDs returns the IDs of every order in the group
+func (g *OrderGroup) orderIDs() []string {
+	if g.PrimaryOrderID == "" {
+		return g.LegOrderIDs
+	}
+	return append([]string{g.PrimaryOrderID}, g.LegOrderIDs...)
+}
+
+// copy returns a copy of the group safe to hand out
+func (g *OrderGroup) copy() *OrderGroup {
+	group := *g
+	group.LegOrderIDs = append([]string(nil), g.LegOrderIDs...)
+	return &group
+}
+
+// record converts the group to its database model
+func (g *OrderGroup) record() *db.OrderGroup {
+	legs, _ := json.Marshal(g.LegOrderIDs)
+	record := &db.OrderGroup{
+		ID:             g.ID,
+		UserID:         g.UserID,
+		Type:           string(g.Type),
+		Status:         string(g.Status),
+		PrimaryOrderID: g.PrimaryOrderID,
+		LegOrderIDs:    string(legs),
+	}
+	record.CreatedAt = g.CreatedAt
+	record.UpdatedAt = g.UpdatedAt
+	return record
+}
+
+// groupActions are the orders a group outcome cancels or releases
+type groupActions struct {
+	cancel   []*Order
+	activate []*Order
+	reason   TransitionReason
+	record   *db.OrderGroup
+}
+
+// SetGroupRepository persists every group state change through repository
+func (ol *OrderLifecycle) SetGroupRepository(repository OrderGroupRepository) {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	ol.groupStore = repository
+}
+
+// InitializeGroup starts linking the orders of a group. The orders must
+// have been initialized already.
+func (ol *OrderLifecycle) InitializeGroup(ctx context.Context, group *OrderGroup) error {
+	ol.mu.Lock()
+	for _, orderID := range group.orderIDs() {
+		if _, exists := ol.orderStates[orderID]; !exists {
+			ol.mu.Unlock()
+			return ErrOrderStateNotFound
+		}
+	}
+
+	ol.groups[group.ID] = group
+	for _, orderID := range group.orderIDs() {
+		ol.orderGroups[orderID] = group.ID
+	}
+	record := group.record()
+	ol.mu.Unlock()
+
+	ol.saveGroup(ctx, record)
+
+	ol.logger.Debug("Order group initialized",
+		zap.String("group_id", group.ID),
+		zap.String("type", string(group.Type)),
+		zap.Int("orders", len(group.orderIDs())))
+
+	return nil
+}
+
+// GetOrderGroup returns a copy of a group
+func (ol *OrderLifecycle) GetOrderGroup(groupID string) (*OrderGroup, error) {
+	ol.mu.RLock()
+	defer ol.mu.RUnlock()
+
+	group, exists := ol.groups[groupID]
+	if !exists {
+		return nil, ErrOrderGroupNotFound
+	}
+	return group.copy(), nil
+}
+
+// CancelGroup cancels every open order of a group
+func (ol *OrderLifecycle) CancelGroup(ctx context.Context, groupID string) (*OrderGroup, error) {
+	ol.mu.Lock()
+	group, exists := ol.groups[groupID]
+	if !exists {
+		ol.mu.Unlock()
+		return nil, ErrOrderGroupNotFound
+	}
+	if !group.Status.IsOpen() {
+		ol.mu.Unlock()
+		return nil, ErrOrderGroupClosed
+	}
+
+	group.Status = OrderGroupStatusCancelled
+	group.UpdatedAt = time.Now()
+	actions := &groupActions{
+		cancel: ol.openGroupOrders(group.orderIDs(), ""),
+		reason: ReasonGroupCancelled,
+		record: group.record(),
+	}
+	cancelled := group.copy()
+	ol.mu.Unlock()
+
+	ol.runGroupActions(actions)
+	return cancelled, nil
+}
+
+// resolveGroup decides how the group of an order follows the order's new
+// status. A filled primary order releases the legs, and a primary order
+// closing without a fill cancels them; a bracket entry closing after a
+// partial fill releases exits resized to the filled quantity. Once working,
+// the first leg to execute or close cancels the others. Callers hold ol.mu.
+func (ol *OrderLifecycle) resolveGroup(order *Order) *groupActions {
+	groupID, grouped := ol.orderGroups[order.ID]
+	if !grouped {
+		return nil
+	}
+	group := ol.groups[groupID]
+	if !group.Status.IsOpen() {
+		return nil
+	}
+
+	executed := order.FilledQuantity.IsPositive()
+	closed := isClosedStatus(order.Status)
+	actions := &groupActions{}
+
+	switch {
+	case order.ID == group.PrimaryOrderID:
+		if !closed {
+			return nil
+		}
+		if !executed {
+			actions.cancel = ol.openGroupOrders(group.LegOrderIDs, "")
+			actions.reason = ReasonGroupPrimaryClosed
+			group.Status = OrderGroupStatusCancelled
+			break
+		}
+
+		actions.activate = ol.heldGroupOrders(group.LegOrderIDs)
+		actions.reason = ReasonGroupPrimaryFilled
+		group.Status = OrderGroupStatusCompleted
+		if group.Type == OrderGroupBracket {
+			for _, exit := range actions.activate {
+				exit.Quantity = types.MinDecimal(exit.Quantity, order.FilledQuantity)
+			}
+			group.Status = OrderGroupStatusActive
+		}
+
+	case group.Status == OrderGroupStatusActive:
+		if !executed && !closed {
+			return nil
+		}
+		actions.cancel = ol.openGroupOrders(group.LegOrderIDs, order.ID)
+		actions.reason = ReasonGroupLegClosed
+		group.Status = OrderGroupStatusCancelled
+		if executed {
+			actions.reason = ReasonGroupLegExecuted
+			group.Status = OrderGroupStatusCompleted
+		}
+
+	default:
+		// Contingent legs wait for the primary order
+		return nil
+	}
+
+	group.UpdatedAt = time.Now()
+	actions.record = group.record()
+
+	ol.logger.Info("Order group resolved",
+		zap.String("group_id", group.ID),
+		zap.String("order_id", order.ID),
+		zap.String("status", string(group.Status)),
+		zap.String("reason", string(actions.reason)))
+
+	return actions
+}
+
+// runGroupActions cancels and releases the orders a group outcome names.
+// Orders a sibling's execution cancelled in the meantime stay unreleased.
+func (ol *OrderLifecycle) runGroupActions(actions *groupActions) {
+	if actions == nil {
+		return
+	}
+
+	ctx := context.Background()
+	ol.saveGroup(ctx, actions.record)
+
+	for _, order := range actions.cancel {
+		ol.cancelGroupOrder(order, actions.reason)
+	}
+	for _, order := range actions.activate {
+		if order.Status != OrderStatusNew {
+			continue
+		}
+		if err := ol.orderService.SubmitOrder(ctx, order); err != nil {
+			ol.logger.Warn("Failed to release contingent order",
+				zap.String("order_id", order.ID),
+				zap.String("group_id", order.GroupID),
+				zap.Error(err))
+		}
+	}
+}
+
+// cancelGroupOrder pulls an order of a group from the book and cancels it.
+// An order the book no longer holds has executed and is left alone.
+func (ol *OrderLifecycle) cancelGroupOrder(order *Order, reason TransitionReason) {
+	if order.Status != OrderStatusNew &&
+		!ol.orderService.MatchingEngine.CancelOrder(order.Symbol, order.ID) {
+		ol.logger.Warn("Group order left the book before it could be cancelled",
+			zap.String("order_id", order.ID),
+			zap.String("group_id", order.GroupID))
+		return
+	}
+
+	if err := ol.changeOrderStatus(order, OrderStatusCancelled, reason); err != nil {
+		ol.logger.Error("Failed to cancel group order",
+			zap.String("order_id", order.ID),
+			zap.String("group_id", order.GroupID),
+			zap.Error(err))
+	}
+}
+
+// openGroupOrders returns the orders among orderIDs that are still open,
+// leaving out except. Callers hold ol.mu.
+func (ol *OrderLifecycle) openGroupOrders(orderIDs []string, except string) []*Order {
+	var orders []*Order
+	for _, orderID := range orderIDs {
+		state, exists := ol.orderStates[orderID]
+		if !exists || orderID == except || isClosedStatus(state.CurrentStatus) {
+			continue
+		}
+		orders = append(orders, state.order)
+	}
+	return orders
+}
+
+// heldGroupOrders returns the orders among orderIDs that have not been
+// submitted yet. Callers hold ol.mu.
+func (ol *OrderLifecycle) heldGroupOrders(orderIDs []string) []*Order {
+	var orders []*Order
+	for _, orderID := range orderIDs {
+		if state, exists := ol.orderStates[orderID]; exists && state.CurrentStatus == OrderStatusNew {
+			orders = append(orders, state.order)
+		}
+	}
+	return orders
+}
+
+// saveGroup persists a group record when a repository is set
+func (ol *OrderLifecycle) saveGroup(ctx context.Context, record *db.OrderGroup) {
+	ol.mu.RLock()
+	store := ol.groupStore
+	ol.mu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	if err := store.SaveOrderGroup(ctx, record); err != nil {
+		ol.logger.Error("Failed to save order group",
+			zap.String("group_id", record.ID),
+			zap.Error(err))
+	}
+}
+
+// isClosedStatus reports whether an order has left the market for good
+func isClosedStatus(status OrderStatus) bool {
+	return status == OrderStatusFilled ||
+		status == OrderStatusCancelled ||
+		status == OrderStatusRejected ||
+		status == OrderStatusExpired
+}
+
+// CreateOrderGroup places a contingent order group. OCO legs are submitted
+// straight away; the legs of OTO and bracket groups are held until the
+// primary order fills. The group is returned with the first submission
+// error, if any, and already reflects the orders the error closed.
+func (s *OrderService) CreateOrderGroup(ctx context.Context, req *OrderGroupRequest) (*OrderGroup, error) {
+	if err := s.validator.ValidateOrderGroupRequest(ctx, req); err != nil {
+		s.logger.Error("Order group validation failed",
+			zap.String("user_id", req.UserID),
+			zap.String("type", string(req.Type)),
+			zap.Error(err))
+		return nil, err
+	}
+	orderReqs := req.Legs
+	if req.Primary != nil {
+		orderReqs = append([]*OrderRequest{req.Primary}, req.Legs...)
+	}
+	for _, orderReq := range orderReqs {
+		if err := s.checkKillSwitches(req.UserID, orderReq); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	group, submit, err := s.createOrderGroup(ctx, req)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var submitErr error
+	for _, order := range submit {
+		if order.Status != OrderStatusNew {
+			continue
+		}
+		if err := s.SubmitOrder(ctx, order); err != nil && submitErr == nil {
+			submitErr = err
+		}
+	}
+
+	s.logger.Info("Order group created",
+		zap.String("group_id", group.ID),
+		zap.String("user_id", group.UserID),
+		zap.String("type", string(group.Type)))
+
+	latest, err := s.lifecycle.GetOrderGroup(group.ID)
+	if err != nil {
+		return nil, err
+	}
+	return latest, submitErr
+}
+
+// createOrderGroup stores the orders of a group and links them, returning
+// the orders to submit. Callers hold s.mu.
+func (s *OrderService) createOrderGroup(ctx context.Context, req *OrderGroupRequest) (*OrderGroup, []*Order, error) {
+	now := time.Now()
+	group := &OrderGroup{
+		ID:        uuid.New().String(),
+		Type:      req.Type,
+		UserID:    req.UserID,
+		Status:    OrderGroupStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req.Type == OrderGroupOCO {
+		group.Status = OrderGroupStatusActive
+	}
+
+	create := func(orderReq *OrderRequest) (*Order, error) {
+		order := s.newOrder(orderReq)
+		order.UserID = req.UserID
+		order.GroupID = group.ID
+		order.ParentOrderID = group.PrimaryOrderID
+		s.storeOrder(order)
+		return order, s.lifecycle.InitializeOrder(ctx, order)
+	}
+
+	var primary *Order
+	if req.Primary != nil {
+		var err error
+		if primary, err = create(req.Primary); err != nil {
+			return nil, nil, err
+		}
+		group.PrimaryOrderID = primary.ID
+	}
+
+	legs := make([]*Order, 0, len(req.Legs))
+	for _, legReq := range req.Legs {
+		leg, err := create(legReq)
+		if err != nil {
+			return nil, nil, err
+		}
+		legs = append(legs, leg)
+		group.LegOrderIDs = append(group.LegOrderIDs, leg.ID)
+	}
+
+	if err := s.lifecycle.InitializeGroup(ctx, group); err != nil {
+		return nil, nil, err
+	}
+
+	if primary != nil {
+		return group, []*Order{primary}, nil
+	}
+	return group, legs, nil
+}
+
+// GetOrderGroup retrieves a contingent order group by ID
+func (s *OrderService) GetOrderGroup(ctx context.Context, groupID string) (*OrderGroup, error) {
+	return s.lifecycle.GetOrderGroup(groupID)
+}
+
+// CancelOrderGroup cancels every open order of a user's group
+func (s *OrderService) CancelOrderGroup(ctx context.Context, userID, groupID string) (*OrderGroup, error) {
+	group, err := s.lifecycle.GetOrderGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.UserID != userID {
+		return nil, ErrUnauthorizedOrderAccess
+	}
+
+	cancelled, err := s.lifecycle.CancelGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Order group cancelled",
+		zap.String("group_id", groupID),
+		zap.String("user_id", userID))
+
+	return cancelled, nil
+}
+
+// SetGroupRepository persists contingent order groups through repository
+func (s *OrderService) SetGroupRepository(repository OrderGroupRepository) {
+	s.lifecycle.SetGroupRepository(repository)
+}
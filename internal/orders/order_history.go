@@ -0,0 +1,203 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TransitionReason is a machine-readable code explaining an order status
+// change. Each code has a REASON_ counterpart in the proto TransitionReason
+// enum.
+type TransitionReason string
+
+const (
+	// ReasonOrderCreated indicates the order was accepted by the service
+	ReasonOrderCreated TransitionReason = "order_created"
+	// ReasonOrderRecovered indicates the order was reloaded from the repository after a restart
+	ReasonOrderRecovered TransitionReason = "order_recovered"
+	// ReasonSubmitted indicates the order was sent to the matching engine
+	ReasonSubmitted TransitionReason = "submitted"
+	// ReasonAccepted indicates the matching engine accepted the order and it rests in the book
+	ReasonAccepted TransitionReason = "accepted"
+	// ReasonPartiallyFilled indicates part of the order executed
+	ReasonPartiallyFilled TransitionReason = "partially_filled"
+	// ReasonFullyFilled indicates the whole order executed
+	ReasonFullyFilled TransitionReason = "fully_filled"
+	// ReasonEngineRejected indicates the matching engine rejected the order; see the reject reason
+	ReasonEngineRejected TransitionReason = "engine_rejected"
+	// ReasonCancelRequested indicates the user asked to cancel the order
+	ReasonCancelRequested TransitionReason = "cancel_requested"
+	// ReasonUserCancelled indicates the order was cancelled at the user's request
+	ReasonUserCancelled TransitionReason = "user_cancelled"
+	// ReasonReplaceRequested indicates the user asked to amend the order
+	ReasonReplaceRequested TransitionReason = "replace_requested"
+	// ReasonReplaced indicates the amend was applied
+	ReasonReplaced TransitionReason = "replaced"
+	// ReasonReplaceRejected indicates the matching engine refused the amend
+	ReasonReplaceRejected TransitionReason = "replace_rejected"
+	// ReasonMassCancelled indicates the order was cancelled by a mass cancel
+	ReasonMassCancelled TransitionReason = "mass_cancelled"
+	// ReasonKillSwitch indicates the order was cancelled by a kill switch
+	ReasonKillSwitch TransitionReason = "kill_switch"
+	// ReasonCancelOnDisconnect indicates the order was cancelled when its user's last session closed
+	ReasonCancelOnDisconnect TransitionReason = "cancel_on_disconnect"
+	// ReasonIOCRemainderCancelled indicates the unfilled rest of an IOC order was cancelled
+	ReasonIOCRemainderCancelled TransitionReason = "ioc_remainder_cancelled"
+	// ReasonFOKNotFillable indicates a FOK order could not be filled in full
+	ReasonFOKNotFillable TransitionReason = "fok_not_fillable"
+	// ReasonSelfTradePrevented indicates self-trade prevention cancelled the order
+	ReasonSelfTradePrevented TransitionReason = "self_trade_prevented"
+	// ReasonExpired indicates the order reached its expiry time
+	ReasonExpired TransitionReason = "expired"
+	// ReasonGroupCancelled indicates the user cancelled the order's contingent group
+	ReasonGroupCancelled TransitionReason = "group_cancelled"
+	// ReasonGroupPrimaryClosed indicates the group's primary order closed without a fill
+	ReasonGroupPrimaryClosed TransitionReason = "group_primary_closed"
+	// ReasonGroupPrimaryFilled indicates the group's primary order filled
+	ReasonGroupPrimaryFilled TransitionReason = "group_primary_filled"
+	// ReasonGroupLegClosed indicates another leg of the group closed
+	ReasonGroupLegClosed TransitionReason = "group_leg_closed"
+	// ReasonGroupLegExecuted indicates another leg of the group executed
+	ReasonGroupLegExecuted TransitionReason = "group_leg_executed"
+	// ReasonMissingAfterRecovery indicates a recovered order was no longer in any book
+	ReasonMissingAfterRecovery TransitionReason = "missing_after_recovery"
+	// ReasonCancelledByEngine indicates the book had cancelled a recovered order
+	ReasonCancelledByEngine TransitionReason = "cancelled_by_engine"
+	// ReasonExpiredByEngine indicates the book had expired a recovered order
+	ReasonExpiredByEngine TransitionReason = "expired_by_engine"
+	// ReasonTradingHalted indicates trading in the symbol was halted
+	ReasonTradingHalted TransitionReason = "trading_halted"
+	// ReasonTradingResumed indicates trading in the symbol resumed
+	ReasonTradingResumed TransitionReason = "trading_resumed"
+	// ReasonTradingDayClosed indicates the trading day ended with the order still open
+	ReasonTradingDayClosed TransitionReason = "trading_day_closed"
+	// ReasonTradingDayOpened indicates a new trading day started
+	ReasonTradingDayOpened TransitionReason = "trading_day_opened"
+)
+
+// OrderHistoryRepository persists order status changes
+type OrderHistoryRepository interface {
+	CreateOrderTransition(ctx context.Context, transition *db.OrderTransition) error
+	GetOrderTransitions(ctx context.Context, orderID string) ([]*db.OrderTransition, error)
+}
+
+// record converts the state change to its database model
+func (c *OrderStateChange) record() *db.OrderTransition {
+	record := &db.OrderTransition{
+		ID:             uuid.New().String(),
+		OrderID:        c.OrderID,
+		UserID:         c.UserID,
+		Sequence:       c.Sequence,
+		FromStatus:     string(c.FromStatus),
+		ToStatus:       string(c.ToStatus),
+		Reason:         string(c.Reason),
+		RejectReason:   string(c.RejectReason),
+		FilledQuantity: c.FilledQuantity,
+	}
+	record.CreatedAt = c.Timestamp
+	return record
+}
+
+// stateChangeFromRecord converts a database transition back to a state change
+func stateChangeFromRecord(record *db.OrderTransition) *OrderStateChange {
+	return &OrderStateChange{
+		OrderID:        record.OrderID,
+		UserID:         record.UserID,
+		Sequence:       record.Sequence,
+		FromStatus:     OrderStatus(record.FromStatus),
+		ToStatus:       OrderStatus(record.ToStatus),
+		Reason:         TransitionReason(record.Reason),
+		RejectReason:   types.RejectReason(record.RejectReason),
+		FilledQuantity: record.FilledQuantity,
+		Timestamp:      record.CreatedAt,
+	}
+}
+
+// SetHistoryRepository writes every order status change through to
+// repository and serves order histories from it
+func (ol *OrderLifecycle) SetHistoryRepository(repository OrderHistoryRepository) {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	ol.historyStore = repository
+}
+
+// recordStateChange numbers a state change, adds it to the order's history
+// and emits it. Callers hold ol.mu.
+func (ol *OrderLifecycle) recordStateChange(change *OrderStateChange) {
+	change.Sequence = len(ol.history[change.OrderID])
+	ol.history[change.OrderID] = append(ol.history[change.OrderID], change)
+	ol.emitStateChange(change)
+}
+
+// saveStateChange writes a state change through to the history repository,
+// if one is set
+func (ol *OrderLifecycle) saveStateChange(ctx context.Context, change *OrderStateChange) {
+	ol.mu.RLock()
+	store := ol.historyStore
+	ol.mu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	if err := store.CreateOrderTransition(ctx, change.record()); err != nil {
+		ol.logger.Error("Failed to save order transition",
+			zap.String("order_id", change.OrderID),
+			zap.String("to_status", string(change.ToStatus)),
+			zap.Error(err))
+	}
+}
+
+// GetOrderHistory returns the status changes of an order, oldest first.
+// With a history repository set the history includes the changes made
+// before the last restart; the changes held in memory are returned when the
+// repository cannot be read.
+func (ol *OrderLifecycle) GetOrderHistory(ctx context.Context, orderID string) ([]*OrderStateChange, error) {
+	ol.mu.RLock()
+	store := ol.historyStore
+	history := make([]*OrderStateChange, len(ol.history[orderID]))
+	copy(history, ol.history[orderID])
+	ol.mu.RUnlock()
+
+	if store == nil {
+		return history, nil
+	}
+
+	records, err := store.GetOrderTransitions(ctx, orderID)
+	if err != nil {
+		ol.logger.Warn("Failed to load order history, using the changes held in memory",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return history, nil
+	}
+
+	history = make([]*OrderStateChange, 0, len(records))
+	for _, record := range records {
+		history = append(history, stateChangeFromRecord(record))
+	}
+	return history, nil
+}
+
+// SetHistoryRepository persists order status changes through repository, so
+// order histories survive restarts
+func (s *OrderService) SetHistoryRepository(repository OrderHistoryRepository) {
+	s.lifecycle.SetHistoryRepository(repository)
+}
+
+// GetOrderHistory returns the status changes of an order with the reason
+// for each, oldest first, so every order outcome can be explained. A
+// non-empty userID limits the lookup to that user's orders.
+func (s *OrderService) GetOrderHistory(ctx context.Context, userID, orderID string) ([]*OrderStateChange, error) {
+	history, err := s.lifecycle.GetOrderHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 || (userID != "" && history[0].UserID != userID) {
+		return nil, ErrOrderNotFound
+	}
+	return history, nil
+}
@@ -0,0 +1,101 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"go.uber.org/zap"
+)
+
+// DefaultDedupWindow is how long a client order ID is replayed by default
+const DefaultDedupWindow = 24 * time.Hour
+
+// SetDedupWindow sets how long after an order is placed a request with the
+// same client order ID returns it instead of placing a new order. A window
+// of zero turns replay off.
+func (s *OrderService) SetDedupWindow(window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dedupWindow = window
+}
+
+// PlaceOrder creates an order and submits it to the matching engine. A
+// replayed request submits nothing and returns the original order with
+// replayed set. Orders the engine rejects are returned with their rejected
+// status rather than an error.
+func (s *OrderService) PlaceOrder(ctx context.Context, req *OrderRequest) (order *Order, replayed bool, err error) {
+	order, err = s.CreateOrder(ctx, req)
+	if errors.Is(err, ErrDuplicateClientOrderID) {
+		return order, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.SubmitOrder(ctx, order); err != nil && order.Status != OrderStatusRejected {
+		return order, false, err
+	}
+	return order, false, nil
+}
+
+// replayedOrder returns the user's order placed with the request's client
+// order ID within the dedup window, and whether the client order ID is held
+// in memory at all. Callers hold s.mu.
+func (s *OrderService) replayedOrder(req *OrderRequest) (*Order, bool) {
+	if req.ClientOrderID == "" || s.dedupWindow <= 0 {
+		return nil, false
+	}
+
+	orderID, exists := s.clientOrders[clientOrderKey(req.UserID, req.ClientOrderID)]
+	if !exists {
+		return nil, false
+	}
+	if order, exists := s.Orders[orderID]; exists && order.CreatedAt.After(time.Now().Add(-s.dedupWindow)) {
+		return order, true
+	}
+	return nil, true
+}
+
+// storedOrder looks up the user's order placed with the request's client
+// order ID within the dedup window in the repository, for orders no longer
+// held in memory such as those placed before a restart. It runs without
+// s.mu so a slow repository does not stall the service.
+func (s *OrderService) storedOrder(ctx context.Context, req *OrderRequest) (*Order, error) {
+	if req.ClientOrderID == "" {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	window, store := s.dedupWindow, s.store
+	_, known := s.clientOrders[clientOrderKey(req.UserID, req.ClientOrderID)]
+	s.mu.RUnlock()
+	if window <= 0 || store == nil || known {
+		return nil, nil
+	}
+
+	records, err := store.FindOrders(ctx, &repositories.OrderQuery{
+		UserID:        req.UserID,
+		ClientOrderID: req.ClientOrderID,
+		StartTime:     time.Now().Add(-window),
+		Limit:         1,
+	})
+	if err != nil {
+		s.logger.Error("Failed to look up client order ID",
+			zap.String("user_id", req.UserID),
+			zap.String("client_order_id", req.ClientOrderID),
+			zap.Error(err))
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return orderFromRecord(records[0]), nil
+}
+
+// clientOrderKey indexes a client order ID by its user
+func clientOrderKey(userID, clientOrderID string) string {
+	return userID + "/" + clientOrderID
+}
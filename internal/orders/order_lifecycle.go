@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +19,16 @@ type OrderLifecycle struct {
 	
 	// Lifecycle tracking
 	orderStates map[string]*OrderState
+	history     map[string][]*OrderStateChange
+	
+	// Contingent order groups and the group of each grouped order
+	groups      map[string]*OrderGroup
+	orderGroups map[string]string
+	groupStore  OrderGroupRepository
+	
+	// Write-through stores for order state changes
+	orderStore   OrderRepository
+	historyStore OrderHistoryRepository
 	
 	// Background processing
 	ctx    context.Context
@@ -35,16 +47,23 @@ type OrderState struct {
 	StateChangedAt time.Time
 	ExpiresAt     time.Time
 	Metadata      map[string]interface{}
+
+	order *Order
 }
 
-// OrderStateChange represents a state change event
+// OrderStateChange represents a state change event. Sequence numbers the
+// changes of an order in the order they were made.
 type OrderStateChange struct {
-	OrderID       string
-	FromStatus    OrderStatus
-	ToStatus      OrderStatus
-	Reason        string
-	Timestamp     time.Time
-	Metadata      map[string]interface{}
+	OrderID        string
+	UserID         string
+	Sequence       int
+	FromStatus     OrderStatus
+	ToStatus       OrderStatus
+	Reason         TransitionReason
+	RejectReason   types.RejectReason
+	FilledQuantity types.Decimal
+	Timestamp      time.Time
+	Metadata       map[string]interface{}
 }
 
 // OrderExpiration represents an order expiration event
@@ -61,6 +80,9 @@ func NewOrderLifecycle(orderService *OrderService, logger *zap.Logger) *OrderLif
 		orderService:    orderService,
 		logger:          logger,
 		orderStates:     make(map[string]*OrderState),
+		history:         make(map[string][]*OrderStateChange),
+		groups:          make(map[string]*OrderGroup),
+		orderGroups:     make(map[string]string),
 		ctx:             ctx,
 		cancel:          cancel,
 		stateChangeChan: make(chan *OrderStateChange, 1000),
@@ -70,8 +92,13 @@ func NewOrderLifecycle(orderService *OrderService, logger *zap.Logger) *OrderLif
 
 // InitializeOrder initializes the lifecycle for a new order
 func (ol *OrderLifecycle) InitializeOrder(ctx context.Context, order *Order) error {
+	return ol.initializeOrder(ctx, order, ReasonOrderCreated)
+}
+
+// initializeOrder starts tracking an order in its current status, recording
+// reason as the first change of its history
+func (ol *OrderLifecycle) initializeOrder(ctx context.Context, order *Order, reason TransitionReason) error {
 	ol.mu.Lock()
-	defer ol.mu.Unlock()
 
 	// Create order state
 	state := &OrderState{
@@ -81,6 +108,7 @@ func (ol *OrderLifecycle) InitializeOrder(ctx context.Context, order *Order) err
 		StateChangedAt: time.Now(),
 		ExpiresAt:      order.ExpiresAt,
 		Metadata:       make(map[string]interface{}),
+		order:          order,
 	}
 
 	ol.orderStates[order.ID] = state
@@ -90,30 +118,39 @@ func (ol *OrderLifecycle) InitializeOrder(ctx context.Context, order *Order) err
 		ol.scheduleExpiration(order.ID, order.ExpiresAt)
 	}
 
-	// Emit state change event
-	ol.emitStateChange(&OrderStateChange{
-		OrderID:    order.ID,
-		FromStatus: "",
-		ToStatus:   order.Status,
-		Reason:     "order_created",
-		Timestamp:  time.Now(),
-		Metadata:   map[string]interface{}{"user_id": order.UserID, "symbol": order.Symbol},
-	})
+	// Record and emit state change event
+	change := &OrderStateChange{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		FromStatus:     "",
+		ToStatus:       order.Status,
+		Reason:         reason,
+		FilledQuantity: order.FilledQuantity,
+		Timestamp:      time.Now(),
+		Metadata:       map[string]interface{}{"user_id": order.UserID, "symbol": order.Symbol},
+	}
+	ol.recordStateChange(change)
 
 	ol.logger.Debug("Order lifecycle initialized",
 		zap.String("order_id", order.ID),
-		zap.String("status", string(order.Status)))
+		zap.String("status", string(order.Status)),
+		zap.String("reason", string(reason)))
+
+	record := order.record()
+	ol.mu.Unlock()
 
+	ol.saveOrder(ctx, record)
+	ol.saveStateChange(ctx, change)
 	return nil
 }
 
 // UpdateOrder updates the lifecycle state when an order is modified
 func (ol *OrderLifecycle) UpdateOrder(ctx context.Context, order *Order) error {
 	ol.mu.Lock()
-	defer ol.mu.Unlock()
 
 	state, exists := ol.orderStates[order.ID]
 	if !exists {
+		ol.mu.Unlock()
 		return ErrOrderStateNotFound
 	}
 
@@ -126,29 +163,65 @@ func (ol *OrderLifecycle) UpdateOrder(ctx context.Context, order *Order) error {
 	ol.logger.Debug("Order lifecycle updated",
 		zap.String("order_id", order.ID))
 
+	record := order.record()
+	ol.mu.Unlock()
+
+	ol.saveOrder(ctx, record)
 	return nil
 }
 
+// RequestCancel holds an order in pending cancel while the book removes it
+func (ol *OrderLifecycle) RequestCancel(ctx context.Context, order *Order) error {
+	return ol.changeOrderStatus(order, OrderStatusPendingCancel, ReasonCancelRequested)
+}
+
 // CancelOrder handles order cancellation in the lifecycle
 func (ol *OrderLifecycle) CancelOrder(ctx context.Context, order *Order) error {
-	return ol.changeOrderStatus(order, OrderStatusCancelled, "user_cancelled")
+	return ol.changeOrderStatus(order, OrderStatusCancelled, ReasonUserCancelled)
+}
+
+// RequestReplace holds a working order in pending replace while the book
+// applies an amend
+func (ol *OrderLifecycle) RequestReplace(ctx context.Context, order *Order) error {
+	return ol.changeOrderStatus(order, OrderStatusPendingReplace, ReasonReplaceRequested)
+}
+
+// SubmitOrder holds an order in pending new while the matching engine
+// takes it
+func (ol *OrderLifecycle) SubmitOrder(ctx context.Context, order *Order) error {
+	return ol.changeOrderStatus(order, OrderStatusPendingNew, ReasonSubmitted)
+}
+
+// workingStatus returns the status an order in the book has for its fills
+func workingStatus(order *Order) (OrderStatus, TransitionReason) {
+	switch {
+	case order.FilledQuantity.GreaterThanOrEqual(order.Quantity):
+		return OrderStatusFilled, ReasonFullyFilled
+	case order.FilledQuantity.IsPositive():
+		return OrderStatusPartiallyFilled, ReasonPartiallyFilled
+	default:
+		return OrderStatusPending, ReasonAccepted
+	}
 }
 
 // UpdateOrderAfterExecution updates order status after execution
 func (ol *OrderLifecycle) UpdateOrderAfterExecution(ctx context.Context, order *Order) error {
 	// Determine new status based on fill
-	var newStatus OrderStatus
-	var reason string
-
-	if order.FilledQuantity >= order.Quantity {
-		newStatus = OrderStatusFilled
-		reason = "fully_filled"
-	} else if order.FilledQuantity > 0 {
-		newStatus = OrderStatusPartiallyFilled
-		reason = "partially_filled"
-	} else {
-		newStatus = OrderStatusPending
-		reason = "submitted_to_market"
+	newStatus, reason := workingStatus(order)
+
+	// Further fills of a partially filled order keep its status; only the
+	// filled quantity needs saving
+	ol.mu.RLock()
+	state, exists := ol.orderStates[order.ID]
+	unchanged := exists && state.CurrentStatus == newStatus
+	var record *db.Order
+	if unchanged {
+		record = order.record()
+	}
+	ol.mu.RUnlock()
+	if unchanged {
+		ol.saveOrder(ctx, record)
+		return nil
 	}
 
 	return ol.changeOrderStatus(order, newStatus, reason)
@@ -172,29 +245,32 @@ func (ol *OrderLifecycle) ExpireOrder(ctx context.Context, orderID string) error
 
 	// Only expire orders that can be expired
 	if ol.canOrderExpire(state.CurrentStatus) {
-		return ol.changeOrderStatus(order, OrderStatusExpired, "expired")
+		return ol.changeOrderStatus(order, OrderStatusExpired, ReasonExpired)
 	}
 
 	return nil
 }
 
-// RejectOrder handles order rejection
-func (ol *OrderLifecycle) RejectOrder(ctx context.Context, order *Order, reason string) error {
+// RejectOrder handles order rejection. The order's reject reason is recorded
+// with the change.
+func (ol *OrderLifecycle) RejectOrder(ctx context.Context, order *Order, reason TransitionReason) error {
 	return ol.changeOrderStatus(order, OrderStatusRejected, reason)
 }
 
-// changeOrderStatus changes the status of an order
-func (ol *OrderLifecycle) changeOrderStatus(order *Order, newStatus OrderStatus, reason string) error {
+// changeOrderStatus changes the status of an order and then cancels or
+// releases the other orders of its contingent group as the change requires
+func (ol *OrderLifecycle) changeOrderStatus(order *Order, newStatus OrderStatus, reason TransitionReason) error {
 	ol.mu.Lock()
-	defer ol.mu.Unlock()
 
 	state, exists := ol.orderStates[order.ID]
 	if !exists {
+		ol.mu.Unlock()
 		return ErrOrderStateNotFound
 	}
 
 	// Check if status change is valid
 	if !ol.isValidStatusTransition(state.CurrentStatus, newStatus) {
+		ol.mu.Unlock()
 		return ErrInvalidStatusTransition
 	}
 
@@ -208,54 +284,123 @@ func (ol *OrderLifecycle) changeOrderStatus(order *Order, newStatus OrderStatus,
 	order.Status = newStatus
 	order.UpdatedAt = time.Now()
 
-	// Emit state change event
-	ol.emitStateChange(&OrderStateChange{
-		OrderID:    order.ID,
-		FromStatus: previousStatus,
-		ToStatus:   newStatus,
-		Reason:     reason,
-		Timestamp:  time.Now(),
-		Metadata:   map[string]interface{}{"user_id": order.UserID, "symbol": order.Symbol},
-	})
+	// Record and emit state change event
+	change := &OrderStateChange{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		FromStatus:     previousStatus,
+		ToStatus:       newStatus,
+		Reason:         reason,
+		FilledQuantity: order.FilledQuantity,
+		Timestamp:      order.UpdatedAt,
+		Metadata:       map[string]interface{}{"user_id": order.UserID, "symbol": order.Symbol},
+	}
+	if newStatus == OrderStatusRejected {
+		change.RejectReason = order.RejectReason
+	}
+	ol.recordStateChange(change)
 
 	ol.logger.Info("Order status changed",
 		zap.String("order_id", order.ID),
 		zap.String("from_status", string(previousStatus)),
 		zap.String("to_status", string(newStatus)),
-		zap.String("reason", reason))
-
+		zap.String("reason", string(reason)))
+
+	// Decide the group outcome under the same lock as the status change,
+	// so two legs cannot both win, then act on the other orders
+	actions := ol.resolveGroup(order)
+	record := order.record()
+	ol.mu.Unlock()
+
+	ol.saveOrder(context.Background(), record)
+	ol.saveStateChange(context.Background(), change)
+	ol.orderService.reportStateChange(order, change)
+	ol.runGroupActions(actions)
 	return nil
 }
 
+// validTransitions lists the statuses each order status can move to
+var validTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusNew: {
+		OrderStatusPendingNew,
+		OrderStatusPending,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusPendingCancel,
+		OrderStatusCancelled,
+		OrderStatusRejected,
+		OrderStatusExpired,
+	},
+	// Sent to the book: the engine accepts, fills or rejects it
+	OrderStatusPendingNew: {
+		OrderStatusPending,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusCancelled,
+		OrderStatusRejected,
+		OrderStatusExpired,
+	},
+	OrderStatusPending: {
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusPendingCancel,
+		OrderStatusPendingReplace,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+		OrderStatusSuspended,
+		OrderStatusDoneForDay,
+	},
+	OrderStatusPartiallyFilled: {
+		OrderStatusFilled,
+		OrderStatusPendingCancel,
+		OrderStatusPendingReplace,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+		OrderStatusSuspended,
+		OrderStatusDoneForDay,
+	},
+	// Fills can still arrive while a cancel or an amend is in progress
+	OrderStatusPendingCancel: {
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusCancelled,
+	},
+	OrderStatusPendingReplace: {
+		OrderStatusPending,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusCancelled,
+	},
+	// Held by a halt, or done until the next trading day: the order goes
+	// back to work, or is cancelled or expires, and a re-opening auction
+	// may fill it
+	OrderStatusSuspended: {
+		OrderStatusPending,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusPendingCancel,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+		OrderStatusDoneForDay,
+	},
+	OrderStatusDoneForDay: {
+		OrderStatusPending,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusPendingCancel,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+		OrderStatusSuspended,
+	},
+	// Terminal states - no transitions allowed
+	OrderStatusFilled:    {},
+	OrderStatusCancelled: {},
+	OrderStatusRejected:  {},
+	OrderStatusExpired:   {},
+}
+
 // isValidStatusTransition checks if a status transition is valid
 func (ol *OrderLifecycle) isValidStatusTransition(from, to OrderStatus) bool {
-	validTransitions := map[OrderStatus][]OrderStatus{
-		OrderStatusNew: {
-			OrderStatusPending,
-			OrderStatusPartiallyFilled,
-			OrderStatusFilled,
-			OrderStatusCancelled,
-			OrderStatusRejected,
-			OrderStatusExpired,
-		},
-		OrderStatusPending: {
-			OrderStatusPartiallyFilled,
-			OrderStatusFilled,
-			OrderStatusCancelled,
-			OrderStatusExpired,
-		},
-		OrderStatusPartiallyFilled: {
-			OrderStatusFilled,
-			OrderStatusCancelled,
-			OrderStatusExpired,
-		},
-		// Terminal states - no transitions allowed
-		OrderStatusFilled:    {},
-		OrderStatusCancelled: {},
-		OrderStatusRejected:  {},
-		OrderStatusExpired:   {},
-	}
-
 	allowedTransitions, exists := validTransitions[from]
 	if !exists {
 		return false
@@ -273,8 +418,11 @@ func (ol *OrderLifecycle) isValidStatusTransition(from, to OrderStatus) bool {
 // canOrderExpire checks if an order can expire
 func (ol *OrderLifecycle) canOrderExpire(status OrderStatus) bool {
 	return status == OrderStatusNew || 
+		   status == OrderStatusPendingNew || 
 		   status == OrderStatusPending || 
-		   status == OrderStatusPartiallyFilled
+		   status == OrderStatusPartiallyFilled || 
+		   status == OrderStatusSuspended || 
+		   status == OrderStatusDoneForDay
 }
 
 // scheduleExpiration schedules an order for expiration
@@ -369,7 +517,7 @@ func (ol *OrderLifecycle) handleStateChange(change *OrderStateChange) {
 		zap.String("order_id", change.OrderID),
 		zap.String("from_status", string(change.FromStatus)),
 		zap.String("to_status", string(change.ToStatus)),
-		zap.String("reason", change.Reason))
+		zap.String("reason", string(change.Reason)))
 
 	// Perform any side effects based on state change
 	switch change.ToStatus {
@@ -418,7 +566,8 @@ func (ol *OrderLifecycle) handleOrderRejected(change *OrderStateChange) {
 	// Perform any cleanup for rejected orders
 	ol.logger.Info("Order rejected",
 		zap.String("order_id", change.OrderID),
-		zap.String("reason", change.Reason))
+		zap.String("reason", string(change.Reason)),
+		zap.String("reject_reason", string(change.RejectReason)))
 }
 
 // handleOrderExpired handles when an order expires
@@ -449,11 +598,15 @@ func (ol *OrderLifecycle) GetOrderState(orderID string) (*OrderState, error) {
 	}, nil
 }
 
-// GetOrderHistory returns the state change history for an order
-func (ol *OrderLifecycle) GetOrderHistory(orderID string) ([]*OrderStateChange, error) {
-	// In a production system, this would query a persistent store
-	// For now, return empty history
-	return []*OrderStateChange{}, nil
+// trackedOrder returns the order the lifecycle tracks under an ID, or nil
+func (ol *OrderLifecycle) trackedOrder(orderID string) *Order {
+	ol.mu.RLock()
+	defer ol.mu.RUnlock()
+
+	if state, exists := ol.orderStates[orderID]; exists {
+		return state.order
+	}
+	return nil
 }
 
 // GetStats returns lifecycle statistics
@@ -487,4 +640,6 @@ type LifecycleStats struct {
 var (
 	ErrOrderStateNotFound      = errors.New("order state not found")
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrOrderGroupNotFound      = errors.New("order group not found")
+	ErrOrderGroupClosed        = errors.New("order group is closed")
 )
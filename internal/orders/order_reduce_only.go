@@ -0,0 +1,62 @@
+package orders
+
+import (
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// PositionProvider reports the net position of a user in a symbol,
+// positive when long and negative when short
+type PositionProvider interface {
+	NetPosition(userID, symbol string) float64
+}
+
+// SetPositionProvider checks reduce-only orders against the positions
+// provider reports. Without a provider reduce-only orders are not checked.
+func (s *OrderService) SetPositionProvider(provider PositionProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.positions = provider
+}
+
+// checkReduceOnly rejects a reduce-only request that, with the user's open
+// reduce-only orders on the same side, would close more than the user's
+// position. Callers hold s.mu.
+func (s *OrderService) checkReduceOnly(req *OrderRequest) error {
+	if !req.ReduceOnly || s.positions == nil {
+		return nil
+	}
+	return s.checkReduceOnlyQuantity(req.UserID, req.Symbol, req.Side, req.Quantity, "")
+}
+
+// checkReduceOnlyQuantity checks that quantity more on a side, with the
+// open reduce-only orders other than excluded, stays within the position it
+// closes. Callers hold s.mu.
+func (s *OrderService) checkReduceOnlyQuantity(userID, symbol string, side OrderSide, quantity types.Decimal, excluded string) error {
+	closable := s.positions.NetPosition(userID, symbol)
+	if side == OrderSideBuy {
+		closable = -closable
+	}
+
+	pending := quantity
+	for _, orderID := range s.UserOrders[userID] {
+		order, exists := s.Orders[orderID]
+		if !exists || order.ID == excluded || !order.ReduceOnly || order.Symbol != symbol ||
+			order.Side != side || isClosedStatus(order.Status) {
+			continue
+		}
+		pending = pending.Add(order.Quantity.Sub(order.FilledQuantity))
+	}
+
+	if closable <= 0 || pending.GreaterThan(types.QuantityFromFloat(symbol, closable)) {
+		s.logger.Warn("Reduce-only order exceeds position",
+			zap.String("user_id", userID),
+			zap.String("symbol", symbol),
+			zap.String("side", string(side)),
+			zap.Float64("position", closable),
+			zap.Stringer("pending", pending))
+		return ErrReduceOnlyExceedsPosition
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package orders
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/abdoElHodaky/tradSys/pkg/matching"
 	"github.com/google/uuid"
 	cache "github.com/patrickmn/go-cache"
@@ -13,8 +16,9 @@ import (
 
 // OrderService handles core order management operations
 type OrderService struct {
-	// MatchingEngine is the order matching engine
-	MatchingEngine *matching.MatchingEngine
+	// MatchingEngine is the order matching engine, or the sequencer in
+	// front of it
+	MatchingEngine matching.OrderEngine
 	// Orders is a map of order ID to order
 	Orders map[string]*Order
 	// UserOrders is a map of user ID to order IDs
@@ -33,14 +37,28 @@ type OrderService struct {
 	ctx context.Context
 	// Cancel function
 	cancel context.CancelFunc
+	// Background workers started by Start
+	workers sync.WaitGroup
 	// Order lifecycle manager
 	lifecycle *OrderLifecycle
 	// Order validator
 	validator *OrderValidator
+	// Order repository, when orders are persisted
+	store OrderRepository
+	// Kill switches and client sessions
+	controls *orderControls
+	// clientOrders maps a user's client order ID to its order ID
+	clientOrders map[string]string
+	// dedupWindow is how long a client order ID is replayed
+	dedupWindow time.Duration
+	// executions feeds execution reports to subscribers
+	executions *executionFeed
+	// positions reports the positions reduce-only orders are checked against
+	positions PositionProvider
 }
 
 // NewOrderService creates a new order service
-func NewOrderService(matchingEngine *matching.MatchingEngine, logger *zap.Logger) *OrderService {
+func NewOrderService(matchingEngine matching.OrderEngine, logger *zap.Logger) *OrderService {
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	service := &OrderService{
@@ -53,6 +71,10 @@ func NewOrderService(matchingEngine *matching.MatchingEngine, logger *zap.Logger
 		logger:         logger,
 		ctx:            ctx,
 		cancel:         cancel,
+		controls:       newOrderControls(),
+		clientOrders:   make(map[string]string),
+		dedupWindow:    DefaultDedupWindow,
+		executions:     newExecutionFeed(DefaultExecutionBacklog),
 	}
 	
 	// Initialize components
@@ -62,11 +84,34 @@ func NewOrderService(matchingEngine *matching.MatchingEngine, logger *zap.Logger
 	return service
 }
 
-// CreateOrder creates a new order
+// CreateOrder creates a new order. A request repeating the client order
+// ID of one of the user's orders within the dedup window creates nothing;
+// the original order is returned with ErrDuplicateClientOrderID.
 func (s *OrderService) CreateOrder(ctx context.Context, req *OrderRequest) (*Order, error) {
+	// Look a retried request up in the repository before taking the lock
+	stored, err := s.storedOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// A retried request gets the order it placed the first time. The
+	// in-memory check is repeated under the lock so a concurrent request
+	// with the same client order ID placed during the lookup is found.
+	original, known := s.replayedOrder(req)
+	if !known {
+		original = stored
+	}
+	if original != nil {
+		s.logger.Info("Order replayed",
+			zap.String("order_id", original.ID),
+			zap.String("user_id", req.UserID),
+			zap.String("client_order_id", req.ClientOrderID))
+		return original, ErrDuplicateClientOrderID
+	}
+
 	// Validate order request
 	if err := s.validator.ValidateOrderRequest(ctx, req); err != nil {
 		s.logger.Error("Order validation failed",
@@ -75,35 +120,16 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *OrderRequest) (*Ord
 			zap.Error(err))
 		return nil, err
 	}
+	if err := s.CheckKillSwitches(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkReduceOnly(req); err != nil {
+		return nil, err
+	}
 
-	// Create order
-	order := &Order{
-		ID:              uuid.New().String(),
-		UserID:          req.UserID,
-		ClientOrderID:   req.ClientOrderID,
-		Symbol:          req.Symbol,
-		Side:            req.Side,
-		Type:            req.Type,
-		Price:           req.Price,
-		StopPrice:       req.StopPrice,
-		Quantity:        req.Quantity,
-		FilledQuantity:  0,
-		Status:          OrderStatusNew,
-		TimeInForce:     req.TimeInForce,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
-		ExpiresAt:       req.ExpiresAt,
-		Trades:          make([]*Trade, 0),
-		Metadata:        make(map[string]interface{}),
-	}
-
-	// Store order
-	s.Orders[order.ID] = order
-	s.addOrderToUserIndex(order.UserID, order.ID)
-	s.addOrderToSymbolIndex(order.Symbol, order.ID)
-
-	// Cache order
-	s.OrderCache.Set(order.ID, order, cache.DefaultExpiration)
+	// Create and store order
+	order := s.newOrder(req)
+	s.storeOrder(order)
 
 	// Initialize order lifecycle
 	if err := s.lifecycle.InitializeOrder(ctx, order); err != nil {
@@ -119,12 +145,51 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *OrderRequest) (*Ord
 		zap.String("symbol", order.Symbol),
 		zap.String("side", string(order.Side)),
 		zap.String("type", string(order.Type)),
-		zap.Float64("price", order.Price),
-		zap.Float64("quantity", order.Quantity))
+		zap.Stringer("price", order.Price),
+		zap.Stringer("quantity", order.Quantity))
 
 	return order, nil
 }
 
+// newOrder creates a new order from a validated request
+func (s *OrderService) newOrder(req *OrderRequest) *Order {
+	return &Order{
+		ID:                  uuid.New().String(),
+		UserID:              req.UserID,
+		AccountGroup:        req.AccountGroup,
+		ClientOrderID:       req.ClientOrderID,
+		Symbol:              req.Symbol,
+		Side:                req.Side,
+		Type:                req.Type,
+		Price:               req.Price,
+		StopPrice:           req.StopPrice,
+		Quantity:            req.Quantity,
+		FilledQuantity:      types.Zero,
+		Status:              OrderStatusNew,
+		TimeInForce:         req.TimeInForce,
+		SelfTradePrevention: req.SelfTradePrevention,
+		ReduceOnly:          req.ReduceOnly,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		ExpiresAt:           req.ExpiresAt,
+		Trades:              make([]*Trade, 0),
+		Tags:                req.Tags,
+		Metadata:            make(map[string]interface{}),
+	}
+}
+
+// storeOrder indexes and caches a new order
+func (s *OrderService) storeOrder(order *Order) {
+	s.Orders[order.ID] = order
+	s.addOrderToUserIndex(order.UserID, order.ID)
+	s.addOrderToSymbolIndex(order.Symbol, order.ID)
+	if order.ClientOrderID != "" {
+		s.clientOrders[clientOrderKey(order.UserID, order.ClientOrderID)] = order.ID
+	}
+
+	s.OrderCache.Set(order.ID, order, cache.DefaultExpiration)
+}
+
 // GetOrder retrieves an order by ID
 func (s *OrderService) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 	// Try cache first
@@ -146,47 +211,49 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID string) (*Order, er
 	return order, nil
 }
 
-// GetOrdersByUser retrieves orders for a user
+// GetOrdersByUser retrieves orders for a user, newest first. With a
+// repository set the query runs against the stored orders.
 func (s *OrderService) GetOrdersByUser(ctx context.Context, userID string, filter *OrderFilter) ([]*Order, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	orderIDs, exists := s.UserOrders[userID]
-	if !exists {
-		return []*Order{}, nil
+	store := s.store
+	s.mu.RUnlock()
+	if store != nil {
+		query := orderQuery(filter)
+		query.UserID = userID
+		return s.findOrders(ctx, store, query)
 	}
 
-	orders := make([]*Order, 0, len(orderIDs))
-	for _, orderID := range orderIDs {
-		order, exists := s.Orders[orderID]
-		if !exists {
-			continue
-		}
-
-		// Apply filter
-		if filter != nil && !s.matchesFilter(order, filter) {
-			continue
-		}
-
-		orders = append(orders, order)
-	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return orders, nil
+	return s.filterOrders(s.UserOrders[userID], filter), nil
 }
 
-// GetOrdersBySymbol retrieves orders for a symbol
+// GetOrdersBySymbol retrieves orders for a symbol, newest first. With a
+// repository set the query runs against the stored orders.
 func (s *OrderService) GetOrdersBySymbol(ctx context.Context, symbol string, filter *OrderFilter) ([]*Order, error) {
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	if store != nil {
+		query := orderQuery(filter)
+		query.Symbol = symbol
+		return s.findOrders(ctx, store, query)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	orderIDs, exists := s.SymbolOrders[symbol]
-	if !exists {
-		return []*Order{}, nil
-	}
+	return s.filterOrders(s.SymbolOrders[symbol], filter), nil
+}
 
+// filterOrders returns the page of indexed orders matching the filter,
+// newest first
+func (s *OrderService) filterOrders(orderIDs []string, filter *OrderFilter) []*Order {
 	orders := make([]*Order, 0, len(orderIDs))
-	for _, orderID := range orderIDs {
-		order, exists := s.Orders[orderID]
+	skipped := 0
+	for i := len(orderIDs) - 1; i >= 0; i-- {
+		order, exists := s.Orders[orderIDs[i]]
 		if !exists {
 			continue
 		}
@@ -196,10 +263,18 @@ func (s *OrderService) GetOrdersBySymbol(ctx context.Context, symbol string, fil
 			continue
 		}
 
+		// Apply pagination
+		if filter != nil && skipped < filter.Offset {
+			skipped++
+			continue
+		}
 		orders = append(orders, order)
+		if filter != nil && filter.Limit > 0 && len(orders) == filter.Limit {
+			break
+		}
 	}
 
-	return orders, nil
+	return orders
 }
 
 // UpdateOrder updates an order
@@ -219,15 +294,59 @@ func (s *OrderService) UpdateOrder(ctx context.Context, req *OrderUpdateRequest)
 			zap.Error(err))
 		return nil, err
 	}
+	if order.ReduceOnly && s.positions != nil && req.Quantity.GreaterThan(order.Quantity) {
+		if err := s.checkReduceOnlyQuantity(order.UserID, order.Symbol, order.Side, req.Quantity.Sub(order.FilledQuantity), order.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Hold a working order in pending replace while the book applies the
+	// amend
+	replacing := order.Status != OrderStatusNew
+	if replacing {
+		if err := s.lifecycle.RequestReplace(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	// Amend price and quantity in the book first; quantity reductions keep
+	// their time priority and a marketable price trades immediately
+	var trades []*matching.Trade
+	if req.Price.IsPositive() || req.Quantity.IsPositive() {
+		amendTrades, err := s.MatchingEngine.AmendOrder(order.Symbol, order.ID, req.Price, req.Quantity)
+		if errors.Is(err, matching.ErrOrderNotFound) || errors.Is(err, matching.ErrOrderBookNotFound) {
+			// An order never submitted has nothing in the book to amend,
+			// while a working order missing from the book has already left
+			// it and cannot take new terms
+			if replacing {
+				err = fmt.Errorf("%w: %w", ErrOrderNotFound, err)
+			} else {
+				err = nil
+			}
+		}
+		if err != nil {
+			s.logger.Warn("Order amend rejected by matching engine",
+				zap.String("order_id", req.OrderID),
+				zap.Error(err))
+			status, _ := workingStatus(order)
+			if restoreErr := s.lifecycle.changeOrderStatus(order, status, ReasonReplaceRejected); restoreErr != nil {
+				s.logger.Error("Failed to restore order after rejected amend",
+					zap.String("order_id", req.OrderID),
+					zap.Error(restoreErr))
+			}
+			return nil, err
+		}
+		trades = amendTrades
+	}
 
 	// Update order fields
-	if req.Price > 0 {
+	if req.Price.IsPositive() {
 		order.Price = req.Price
 	}
-	if req.Quantity > 0 {
+	if req.Quantity.IsPositive() {
 		order.Quantity = req.Quantity
 	}
-	if req.StopPrice > 0 {
+	if req.StopPrice.IsPositive() {
 		order.StopPrice = req.StopPrice
 	}
 	if req.TimeInForce != "" {
@@ -240,6 +359,16 @@ func (s *OrderService) UpdateOrder(ctx context.Context, req *OrderUpdateRequest)
 
 	order.UpdatedAt = time.Now()
 
+	// Put the order back to work with its new terms, then process trades
+	// from a marketable amend
+	if replacing {
+		status, _ := workingStatus(order)
+		if err := s.lifecycle.changeOrderStatus(order, status, ReasonReplaced); err != nil {
+			return nil, err
+		}
+	}
+	s.applyTrades(ctx, trades, order)
+
 	// Update cache
 	s.OrderCache.Set(order.ID, order, cache.DefaultExpiration)
 
@@ -250,6 +379,11 @@ func (s *OrderService) UpdateOrder(ctx context.Context, req *OrderUpdateRequest)
 			zap.Error(err))
 		return nil, err
 	}
+	if len(trades) > 0 {
+		if err := s.lifecycle.UpdateOrderAfterExecution(ctx, order); err != nil {
+			return nil, err
+		}
+	}
 
 	s.logger.Info("Order updated",
 		zap.String("order_id", order.ID),
@@ -276,8 +410,18 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *OrderCancelRequest)
 		return nil, err
 	}
 
+	// Hold the order in pending cancel while the book removes it; orders
+	// never submitted have nothing in the book
+	submitted := order.Status != OrderStatusNew
+	if err := s.lifecycle.RequestCancel(ctx, order); err != nil {
+		s.logger.Error("Failed to request order cancel in lifecycle",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
 	// Cancel order in matching engine
-	if order.Status == OrderStatusNew || order.Status == OrderStatusPending {
+	if submitted {
 		success := s.MatchingEngine.CancelOrder(order.Symbol, order.ID)
 		if !success {
 			s.logger.Warn("Failed to cancel order in matching engine",
@@ -305,19 +449,51 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *OrderCancelRequest)
 
 // SubmitOrder submits an order to the matching engine
 func (s *OrderService) SubmitOrder(ctx context.Context, order *Order) error {
+	if order.Status == OrderStatusNew {
+		if err := s.lifecycle.SubmitOrder(ctx, order); err != nil {
+			return err
+		}
+	}
+
 	// Convert to matching engine order format
 	matchingOrder := s.convertToMatchingOrder(order)
 
 	// Submit to matching engine
-	trades := s.MatchingEngine.AddOrder(matchingOrder)
+	trades, err := s.MatchingEngine.AddOrder(matchingOrder)
+	if err != nil {
+		order.RejectReason = rejectReasonFor(err)
+		s.logger.Warn("Order rejected by matching engine",
+			zap.String("order_id", order.ID),
+			zap.String("reason", string(order.RejectReason)),
+			zap.Error(err))
+		// Reject through the lifecycle so contingent groups follow
+		if rejectErr := s.lifecycle.RejectOrder(ctx, order, ReasonEngineRejected); rejectErr != nil {
+			order.Status = OrderStatusRejected
+			order.UpdatedAt = time.Now()
+		}
+		return err
+	}
 
 	// Process resulting trades
-	for _, trade := range trades {
-		if err := s.processTrade(ctx, trade, order); err != nil {
-			s.logger.Error("Failed to process trade",
-				zap.String("trade_id", trade.ID),
-				zap.String("order_id", order.ID),
-				zap.Error(err))
+	s.applyTrades(ctx, trades, order)
+
+	// Close IOC remainders, killed FOK orders and orders cancelled by
+	// self-trade prevention as the engine did
+	order.Quantity = matchingOrder.Quantity
+	switch {
+	case order.TimeInForce == TimeInForceIOC && matchingOrder.Status == matching.OrderStatusCancelled:
+		return s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, ReasonIOCRemainderCancelled)
+	case order.TimeInForce == TimeInForceFOK && matchingOrder.Status == matching.OrderStatusRejected:
+		return s.lifecycle.changeOrderStatus(order, OrderStatusRejected, ReasonFOKNotFillable)
+	case matchingOrder.Status == matching.OrderStatusCancelled:
+		return s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, ReasonSelfTradePrevented)
+	}
+
+	// Schedule DAY orders to expire at the session close chosen by the engine
+	if order.ExpiresAt.IsZero() && !matchingOrder.ExpireTime.IsZero() {
+		order.ExpiresAt = matchingOrder.ExpireTime
+		if err := s.lifecycle.UpdateOrder(ctx, order); err != nil {
+			return err
 		}
 	}
 
@@ -329,9 +505,58 @@ func (s *OrderService) SubmitOrder(ctx context.Context, order *Order) error {
 		return err
 	}
 
+	// Orders queued in a halted book wait for the re-opening auction
+	s.suspendIfHalted(order)
+
 	return nil
 }
 
+// applyTrades books trades on the submitted or amended order and on the
+// other orders of this service they executed: resting orders it traded
+// against and stop orders its trades elected. Those orders move to their
+// new status straight away, so contingent groups follow their fills.
+func (s *OrderService) applyTrades(ctx context.Context, trades []*matching.Trade, order *Order) {
+	var executed []*Order
+	for _, trade := range trades {
+		for _, orderID := range []string{trade.BuyOrderID, trade.SellOrderID} {
+			target := order
+			if orderID != order.ID {
+				if target = s.lifecycle.trackedOrder(orderID); target == nil {
+					continue
+				}
+				if !containsOrder(executed, target) {
+					executed = append(executed, target)
+				}
+			}
+
+			if err := s.processTrade(ctx, trade, target); err != nil {
+				s.logger.Error("Failed to process trade",
+					zap.String("trade_id", trade.ID),
+					zap.String("order_id", target.ID),
+					zap.Error(err))
+			}
+		}
+	}
+
+	for _, contra := range executed {
+		if err := s.lifecycle.UpdateOrderAfterExecution(ctx, contra); err != nil {
+			s.logger.Error("Failed to update order after execution",
+				zap.String("order_id", contra.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+// containsOrder reports whether orders holds order
+func containsOrder(orders []*Order, order *Order) bool {
+	for _, o := range orders {
+		if o == order {
+			return true
+		}
+	}
+	return false
+}
+
 // processTrade processes a trade from the matching engine
 func (s *OrderService) processTrade(ctx context.Context, matchingTrade *matching.Trade, order *Order) error {
 	trade := &Trade{
@@ -350,17 +575,18 @@ func (s *OrderService) processTrade(ctx context.Context, matchingTrade *matching
 
 	// Add trade to order
 	order.Trades = append(order.Trades, trade)
-	order.FilledQuantity += trade.Quantity
+	order.FilledQuantity = order.FilledQuantity.Add(trade.Quantity)
 	order.UpdatedAt = time.Now()
 
 	// Cache trade
 	s.TradeCache.Set(trade.ID, trade, cache.DefaultExpiration)
+	s.reportFill(order, trade)
 
 	s.logger.Info("Trade processed",
 		zap.String("trade_id", trade.ID),
 		zap.String("order_id", order.ID),
-		zap.Float64("price", trade.Price),
-		zap.Float64("quantity", trade.Quantity))
+		zap.Stringer("price", trade.Price),
+		zap.Stringer("quantity", trade.Quantity))
 
 	return nil
 }
@@ -374,8 +600,16 @@ func (s *OrderService) convertToMatchingOrder(order *Order) *matching.Order {
 		Type:      matching.OrderType(order.Type),
 		Price:     order.Price,
 		Quantity:  order.Quantity,
+		StopPrice: order.StopPrice,
 		CreatedAt: order.CreatedAt,
 		UserID:    order.UserID,
+
+		TimeInForce: types.TimeInForce(order.TimeInForce),
+		ExpireTime:  order.ExpiresAt,
+
+		AccountGroup:        order.AccountGroup,
+		SelfTradePrevention: order.SelfTradePrevention,
+		Tags:                order.Tags,
 	}
 }
 
@@ -439,15 +673,133 @@ func (s *OrderService) Start() error {
 	if err := s.lifecycle.Start(); err != nil {
 		return err
 	}
+
+	s.workers.Add(3)
+
+	// Follow resting orders cancelled or filled inside the matching engine
+	go func() {
+		defer s.workers.Done()
+		s.processMatchingEvents()
+	}()
+
+	// Move the books through their scheduled auction calls
+	go func() {
+		defer s.workers.Done()
+		s.MatchingEngine.RunPhases(s.ctx, time.Second)
+	}()
+
+	// Expire DAY orders at the session close and GTD orders at their time
+	go func() {
+		defer s.workers.Done()
+		s.MatchingEngine.RunExpiry(s.ctx, time.Second)
+	}()
 	
 	return nil
 }
 
+// processMatchingEvents cancels resting orders that self-trade prevention
+// removed from the book, fills resting orders executed in an auction,
+// expires resting orders the book expired and suspends and resumes resting
+// orders as trading halts and resumes
+func (s *OrderService) processMatchingEvents() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case event := <-s.MatchingEngine.Events():
+			switch {
+			case event.Type == matching.EventSelfTradePrevented && event.ContraOrder != nil:
+				s.applySelfTradePrevention(event.ContraOrder)
+			case event.Type == matching.EventOrderFilled && event.Order != nil:
+				s.applyAuctionFill(event.Order, event.Trade)
+			case (event.Type == matching.EventOrderDayExpired || event.Type == matching.EventOrderGTDExpired) && event.Order != nil:
+				s.applyExpiry(event.Order)
+			case event.Type == matching.EventTradingHalted:
+				s.suspendOrders(event.Symbol)
+			case event.Type == matching.EventTradingResumed:
+				s.resumeOrders(event.Symbol)
+			}
+		}
+	}
+}
+
+// applyAuctionFill copies the execution of a resting order in an auction
+// uncross
+func (s *OrderService) applyAuctionFill(matchingOrder *matching.Order, matchingTrade *matching.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, exists := s.Orders[matchingOrder.ID]
+	if !exists {
+		return
+	}
+
+	order.FilledQuantity = matchingOrder.FilledQuantity
+	order.UpdatedAt = time.Now()
+	if matchingTrade != nil {
+		s.reportFill(order, &Trade{
+			ID:         matchingTrade.ID,
+			OrderID:    order.ID,
+			Symbol:     matchingTrade.Symbol,
+			Side:       order.Side,
+			Price:      matchingTrade.Price,
+			Quantity:   matchingTrade.Quantity,
+			ExecutedAt: matchingTrade.Timestamp,
+		})
+	}
+	if err := s.lifecycle.UpdateOrderAfterExecution(s.ctx, order); err != nil {
+		s.logger.Error("Failed to update order after auction",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+	}
+}
+
+// applyExpiry expires a resting order the book expired. The lifecycle may
+// have expired the order on its own timer already.
+func (s *OrderService) applyExpiry(matchingOrder *matching.Order) {
+	s.mu.RLock()
+	order, exists := s.Orders[matchingOrder.ID]
+	s.mu.RUnlock()
+	if !exists {
+		return
+	}
+
+	err := s.lifecycle.changeOrderStatus(order, OrderStatusExpired, ReasonExpired)
+	if err != nil && !errors.Is(err, ErrInvalidStatusTransition) {
+		s.logger.Error("Failed to expire order",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+	}
+}
+
+// applySelfTradePrevention copies the engine's view of a resting order
+// involved in a prevented self-trade
+func (s *OrderService) applySelfTradePrevention(matchingOrder *matching.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, exists := s.Orders[matchingOrder.ID]
+	if !exists {
+		return
+	}
+
+	order.Quantity = matchingOrder.Quantity
+	if matchingOrder.Status != matching.OrderStatusCancelled {
+		return
+	}
+	if err := s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, ReasonSelfTradePrevented); err != nil {
+		s.logger.Error("Failed to cancel order after self-trade prevention",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+	}
+}
+
 // Stop stops the order service
 func (s *OrderService) Stop() error {
 	s.logger.Info("Stopping order service")
 	
 	s.cancel()
+	s.workers.Wait()
 	
 	// Stop lifecycle manager
 	if err := s.lifecycle.Stop(); err != nil {
@@ -0,0 +1,264 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"go.uber.org/zap"
+)
+
+// OrderRepository persists orders and serves the indexed order queries
+type OrderRepository interface {
+	Update(ctx context.Context, order *db.Order) error
+	FindOrders(ctx context.Context, query *repositories.OrderQuery) ([]*db.Order, error)
+	GetActiveOrders(ctx context.Context) ([]*db.Order, error)
+}
+
+// orderMetadata holds the order fields without a column of their own
+type orderMetadata struct {
+	AccountGroup        string                    `json:"account_group,omitempty"`
+	RejectReason        types.RejectReason        `json:"reject_reason,omitempty"`
+	SelfTradePrevention types.SelfTradePrevention `json:"self_trade_prevention,omitempty"`
+	ReduceOnly          bool                      `json:"reduce_only,omitempty"`
+	Tags                map[string]string         `json:"tags,omitempty"`
+	Metadata            map[string]interface{}    `json:"metadata,omitempty"`
+}
+
+// record converts the order to its database model
+func (o *Order) record() *db.Order {
+	metadata, _ := json.Marshal(orderMetadata{
+		AccountGroup:        o.AccountGroup,
+		RejectReason:        o.RejectReason,
+		SelfTradePrevention: o.SelfTradePrevention,
+		ReduceOnly:          o.ReduceOnly,
+		Tags:                o.Tags,
+		Metadata:            o.Metadata,
+	})
+	record := &db.Order{
+		ID:             o.ID,
+		UserID:         o.UserID,
+		ClientOrderID:  o.ClientOrderID,
+		Symbol:         o.Symbol,
+		Side:           string(o.Side),
+		Type:           string(o.Type),
+		Price:          o.Price,
+		StopPrice:      o.StopPrice,
+		Quantity:       o.Quantity,
+		FilledQuantity: o.FilledQuantity,
+		Status:         string(o.Status),
+		TimeInForce:    string(o.TimeInForce),
+		ExpiresAt:      o.ExpiresAt,
+		GroupID:        o.GroupID,
+		ParentOrderID:  o.ParentOrderID,
+		Metadata:       string(metadata),
+	}
+	record.CreatedAt = o.CreatedAt
+	record.UpdatedAt = o.UpdatedAt
+	return record
+}
+
+// orderFromRecord converts a database order back to an order
+func orderFromRecord(record *db.Order) *Order {
+	var metadata orderMetadata
+	if record.Metadata != "" {
+		_ = json.Unmarshal([]byte(record.Metadata), &metadata)
+	}
+	if metadata.Metadata == nil {
+		metadata.Metadata = make(map[string]interface{})
+	}
+
+	return &Order{
+		ID:                  record.ID,
+		UserID:              record.UserID,
+		AccountGroup:        metadata.AccountGroup,
+		ClientOrderID:       record.ClientOrderID,
+		Symbol:              record.Symbol,
+		Side:                OrderSide(record.Side),
+		Type:                OrderType(record.Type),
+		Price:               record.Price,
+		StopPrice:           record.StopPrice,
+		Quantity:            record.Quantity,
+		FilledQuantity:      record.FilledQuantity,
+		Status:              OrderStatus(record.Status),
+		RejectReason:        metadata.RejectReason,
+		TimeInForce:         TimeInForce(record.TimeInForce),
+		SelfTradePrevention: metadata.SelfTradePrevention,
+		ReduceOnly:          metadata.ReduceOnly,
+		CreatedAt:           record.CreatedAt,
+		UpdatedAt:           record.UpdatedAt,
+		ExpiresAt:           record.ExpiresAt,
+		GroupID:             record.GroupID,
+		ParentOrderID:       record.ParentOrderID,
+		Trades:              make([]*Trade, 0),
+		Tags:                metadata.Tags,
+		Metadata:            metadata.Metadata,
+	}
+}
+
+// SetOrderRepository writes every order state change through to repository
+func (ol *OrderLifecycle) SetOrderRepository(repository OrderRepository) {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	ol.orderStore = repository
+}
+
+// saveOrder writes an order record through to the repository, if one is set
+func (ol *OrderLifecycle) saveOrder(ctx context.Context, record *db.Order) {
+	ol.mu.RLock()
+	store := ol.orderStore
+	ol.mu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	if err := store.Update(ctx, record); err != nil {
+		ol.logger.Error("Failed to save order",
+			zap.String("order_id", record.ID),
+			zap.String("status", record.Status),
+			zap.Error(err))
+	}
+}
+
+// SetOrderRepository persists orders through repository and serves order
+// queries from it, so history survives restarts
+func (s *OrderService) SetOrderRepository(repository OrderRepository) {
+	s.mu.Lock()
+	s.store = repository
+	s.mu.Unlock()
+
+	s.lifecycle.SetOrderRepository(repository)
+}
+
+// findOrders serves an order query from the repository. Orders this
+// service holds in memory are returned as the live instances.
+func (s *OrderService) findOrders(ctx context.Context, store OrderRepository, query *repositories.OrderQuery) ([]*Order, error) {
+	records, err := store.FindOrders(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orders := make([]*Order, 0, len(records))
+	for _, record := range records {
+		if order, exists := s.Orders[record.ID]; exists {
+			orders = append(orders, order)
+			continue
+		}
+		orders = append(orders, orderFromRecord(record))
+	}
+	return orders, nil
+}
+
+// orderQuery converts a filter to a repository query
+func orderQuery(filter *OrderFilter) *repositories.OrderQuery {
+	if filter == nil {
+		return &repositories.OrderQuery{}
+	}
+	return &repositories.OrderQuery{
+		UserID:    filter.UserID,
+		Symbol:    filter.Symbol,
+		Side:      string(filter.Side),
+		Type:      string(filter.Type),
+		Status:    string(filter.Status),
+		StartTime: filter.StartTime,
+		EndTime:   filter.EndTime,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	}
+}
+
+// Recover engages the stored kill switches, then loads the active orders
+// from the repository and reconciles them with the matching engine, whose
+// books must already be rebuilt. Orders still in a book take its fills and
+// quantity; orders the book filled or closed take its final status; orders
+// missing from every book are cancelled. It returns the number of orders
+// restored.
+func (s *OrderService) Recover(ctx context.Context) (int, error) {
+	killSwitches, err := s.recoverKillSwitches(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if killSwitches > 0 {
+		s.logger.Info("Recovered kill switches", zap.Int("engaged", killSwitches))
+	}
+
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	if store == nil {
+		return 0, nil
+	}
+
+	records, err := store.GetActiveOrders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, record := range records {
+		order := orderFromRecord(record)
+
+		s.mu.Lock()
+		if _, exists := s.Orders[order.ID]; exists {
+			s.mu.Unlock()
+			continue
+		}
+		s.storeOrder(order)
+		s.mu.Unlock()
+
+		if err := s.lifecycle.initializeOrder(ctx, order, ReasonOrderRecovered); err != nil {
+			return restored, err
+		}
+		s.reconcileOrder(ctx, order)
+		restored++
+	}
+
+	s.logger.Info("Recovered orders",
+		zap.Int("active", len(records)),
+		zap.Int("restored", restored))
+
+	return restored, nil
+}
+
+// reconcileOrder brings a recovered order in line with the matching engine
+func (s *OrderService) reconcileOrder(ctx context.Context, order *Order) {
+	booked, exists := s.MatchingEngine.GetOrder(order.Symbol, order.ID)
+	if !exists {
+		s.logger.Warn("Recovered order is not in the order book",
+			zap.String("order_id", order.ID),
+			zap.String("symbol", order.Symbol))
+		if err := s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, ReasonMissingAfterRecovery); err != nil {
+			s.logger.Error("Failed to cancel recovered order",
+				zap.String("order_id", order.ID),
+				zap.Error(err))
+		}
+		return
+	}
+
+	order.Quantity = booked.Quantity
+	order.FilledQuantity = booked.FilledQuantity
+	order.UpdatedAt = time.Now()
+
+	var err error
+	switch booked.Status {
+	case matching.OrderStatusCancelled, matching.OrderStatusCanceled:
+		err = s.lifecycle.changeOrderStatus(order, OrderStatusCancelled, ReasonCancelledByEngine)
+	case matching.OrderStatusExpired:
+		err = s.lifecycle.changeOrderStatus(order, OrderStatusExpired, ReasonExpiredByEngine)
+	default:
+		err = s.lifecycle.UpdateOrderAfterExecution(ctx, order)
+	}
+	if err != nil {
+		s.logger.Error("Failed to reconcile recovered order",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+	}
+}
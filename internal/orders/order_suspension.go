@@ -0,0 +1,131 @@
+package orders
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// CloseTradingDay marks the working and suspended orders of a symbol, or of
+// every symbol when symbol is empty, done for the day. They keep their
+// place in the book and go back to work with OpenTradingDay.
+func (s *OrderService) CloseTradingDay(ctx context.Context, symbol string) []*Order {
+	return s.holdOrders(symbol, OrderStatusDoneForDay, ReasonTradingDayClosed,
+		OrderStatusPending, OrderStatusPartiallyFilled, OrderStatusSuspended)
+}
+
+// OpenTradingDay puts the orders done for the day back to work. Orders of
+// a halted symbol are suspended until trading resumes.
+func (s *OrderService) OpenTradingDay(ctx context.Context, symbol string) []*Order {
+	released := s.releaseOrders(symbol, OrderStatusDoneForDay, ReasonTradingDayOpened)
+	for _, order := range released {
+		s.suspendIfHalted(order)
+	}
+	return released
+}
+
+// suspendOrders suspends the working orders of a halted symbol
+func (s *OrderService) suspendOrders(symbol string) {
+	suspended := s.holdOrders(symbol, OrderStatusSuspended, ReasonTradingHalted,
+		OrderStatusPending, OrderStatusPartiallyFilled)
+	if len(suspended) > 0 {
+		s.logger.Info("Suspended orders for trading halt",
+			zap.String("symbol", symbol),
+			zap.Int("orders", len(suspended)))
+	}
+}
+
+// resumeOrders puts the suspended orders of a symbol back to work
+func (s *OrderService) resumeOrders(symbol string) {
+	resumed := s.releaseOrders(symbol, OrderStatusSuspended, ReasonTradingResumed)
+	if len(resumed) > 0 {
+		s.logger.Info("Resumed orders after trading halt",
+			zap.String("symbol", symbol),
+			zap.Int("orders", len(resumed)))
+	}
+}
+
+// suspendIfHalted suspends a working order queued in a halted book. The
+// halt is checked again once the order is suspended, since the resumption
+// may have been handled in between.
+func (s *OrderService) suspendIfHalted(order *Order) {
+	if order.Status != OrderStatusPending && order.Status != OrderStatusPartiallyFilled {
+		return
+	}
+	if !s.MatchingEngine.IsHalted(order.Symbol) {
+		return
+	}
+	if !s.changeStatus(order, OrderStatusSuspended, ReasonTradingHalted) {
+		return
+	}
+	if !s.MatchingEngine.IsHalted(order.Symbol) {
+		working, _ := workingStatus(order)
+		s.changeStatus(order, working, ReasonTradingResumed)
+	}
+}
+
+// holdOrders moves the orders of a symbol in one of the from statuses to
+// status, oldest first, and returns the orders moved
+func (s *OrderService) holdOrders(symbol string, status OrderStatus, reason TransitionReason, from ...OrderStatus) []*Order {
+	var held []*Order
+	for _, order := range s.ordersInStatus(symbol, from...) {
+		if s.changeStatus(order, status, reason) {
+			held = append(held, order)
+		}
+	}
+	return held
+}
+
+// releaseOrders moves the orders of a symbol in status back to the status
+// their fills give them, oldest first, and returns the orders moved
+func (s *OrderService) releaseOrders(symbol string, status OrderStatus, reason TransitionReason) []*Order {
+	var released []*Order
+	for _, order := range s.ordersInStatus(symbol, status) {
+		working, _ := workingStatus(order)
+		if s.changeStatus(order, working, reason) {
+			released = append(released, order)
+		}
+	}
+	return released
+}
+
+// changeStatus changes the status of an order, logging a failure
+func (s *OrderService) changeStatus(order *Order, status OrderStatus, reason TransitionReason) bool {
+	if err := s.lifecycle.changeOrderStatus(order, status, reason); err != nil {
+		s.logger.Error("Failed to change order status",
+			zap.String("order_id", order.ID),
+			zap.String("status", string(status)),
+			zap.String("reason", string(reason)),
+			zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// ordersInStatus returns the orders of a symbol, or of every symbol when
+// symbol is empty, in one of statuses, oldest first
+func (s *OrderService) ordersInStatus(symbol string, statuses ...OrderStatus) []*Order {
+	s.mu.RLock()
+	var matched []*Order
+	for _, order := range s.Orders {
+		if symbol != "" && order.Symbol != symbol {
+			continue
+		}
+		for _, status := range statuses {
+			if order.Status == status {
+				matched = append(matched, order)
+				break
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID < matched[j].ID
+		}
+		return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+	})
+	return matched
+}
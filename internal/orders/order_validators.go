@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/zap"
 )
 
@@ -110,6 +111,99 @@ func (v *OrderValidator) ValidateOrderCancellation(ctx context.Context, order *O
 	return nil
 }
 
+// ValidateOrderGroupRequest validates a contingent order group request and
+// each of its orders
+func (v *OrderValidator) ValidateOrderGroupRequest(ctx context.Context, req *OrderGroupRequest) error {
+	if req == nil {
+		return ErrInvalidOrderRequest
+	}
+
+	if strings.TrimSpace(req.UserID) == "" {
+		return ErrMissingUserID
+	}
+
+	// Validate the orders the group type needs
+	if err := v.validateGroupShape(req); err != nil {
+		return err
+	}
+
+	// Validate each order as the group's user
+	orders := req.Legs
+	if req.Primary != nil {
+		orders = append([]*OrderRequest{req.Primary}, req.Legs...)
+	}
+	for _, order := range orders {
+		groupOrder := *order
+		groupOrder.UserID = req.UserID
+		if err := v.ValidateOrderRequest(ctx, &groupOrder); err != nil {
+			return err
+		}
+	}
+
+	v.logger.Debug("Order group request validated successfully",
+		zap.String("user_id", req.UserID),
+		zap.String("type", string(req.Type)),
+		zap.Int("orders", len(orders)))
+
+	return nil
+}
+
+// validateGroupShape validates the orders a group type needs: two or more
+// OCO legs, a primary order and at least one leg for OTO, and an entry
+// with its two exits for a bracket
+func (v *OrderValidator) validateGroupShape(req *OrderGroupRequest) error {
+	for _, leg := range req.Legs {
+		if leg == nil {
+			return ErrInvalidOrderRequest
+		}
+	}
+
+	switch req.Type {
+	case OrderGroupOCO:
+		if req.Primary != nil || len(req.Legs) < 2 {
+			return ErrInvalidOrderGroup
+		}
+	case OrderGroupOTO:
+		if req.Primary == nil || len(req.Legs) == 0 {
+			return ErrInvalidOrderGroup
+		}
+	case OrderGroupBracket:
+		if req.Primary == nil || len(req.Legs) != 2 {
+			return ErrInvalidOrderGroup
+		}
+		return v.validateBracket(req.Primary, req.Legs[0], req.Legs[1])
+	default:
+		return ErrInvalidOrderGroup
+	}
+
+	return nil
+}
+
+// validateBracket validates that the exits close the entry: a take-profit
+// limit and a stop-loss stop on the other side for the same quantity, with
+// the take-profit on the profitable side of the stop-loss
+func (v *OrderValidator) validateBracket(entry, takeProfit, stopLoss *OrderRequest) error {
+	for _, exit := range []*OrderRequest{takeProfit, stopLoss} {
+		if exit.Symbol != entry.Symbol || exit.Side == entry.Side || !exit.Quantity.Equal(entry.Quantity) {
+			return ErrInvalidBracket
+		}
+	}
+
+	if takeProfit.Type != OrderTypeLimit ||
+		(stopLoss.Type != OrderTypeStopMarket && stopLoss.Type != OrderTypeStopLimit) {
+		return ErrInvalidBracket
+	}
+
+	if entry.Side == OrderSideBuy && !takeProfit.Price.GreaterThan(stopLoss.StopPrice) {
+		return ErrInvalidBracket
+	}
+	if entry.Side == OrderSideSell && !takeProfit.Price.LessThan(stopLoss.StopPrice) {
+		return ErrInvalidBracket
+	}
+
+	return nil
+}
+
 // validateRequiredFields validates required fields in order request
 func (v *OrderValidator) validateRequiredFields(req *OrderRequest) error {
 	if strings.TrimSpace(req.UserID) == "" {
@@ -128,17 +222,17 @@ func (v *OrderValidator) validateRequiredFields(req *OrderRequest) error {
 		return ErrMissingOrderType
 	}
 
-	if req.Quantity <= 0 {
+	if !req.Quantity.IsPositive() {
 		return ErrInvalidQuantity
 	}
 
 	// Price is required for limit orders
-	if (req.Type == OrderTypeLimit || req.Type == OrderTypeStopLimit) && req.Price <= 0 {
+	if (req.Type == OrderTypeLimit || req.Type == OrderTypeStopLimit) && !req.Price.IsPositive() {
 		return ErrMissingPrice
 	}
 
 	// Stop price is required for stop orders
-	if (req.Type == OrderTypeStopLimit || req.Type == OrderTypeStopMarket) && req.StopPrice <= 0 {
+	if (req.Type == OrderTypeStopLimit || req.Type == OrderTypeStopMarket) && !req.StopPrice.IsPositive() {
 		return ErrMissingStopPrice
 	}
 
@@ -168,12 +262,12 @@ func (v *OrderValidator) validateFieldValues(req *OrderRequest) error {
 	}
 
 	// Validate price precision
-	if req.Price > 0 && !v.isValidPricePrecision(req.Price) {
+	if req.Price.IsPositive() && !v.isValidPricePrecision(req.Symbol, req.Price) {
 		return ErrInvalidPricePrecision
 	}
 
 	// Validate quantity precision
-	if !v.isValidQuantityPrecision(req.Quantity) {
+	if !v.isValidQuantityPrecision(req.Symbol, req.Quantity) {
 		return ErrInvalidQuantityPrecision
 	}
 
@@ -182,6 +276,11 @@ func (v *OrderValidator) validateFieldValues(req *OrderRequest) error {
 
 // validateBusinessRules validates business rules for order request
 func (v *OrderValidator) validateBusinessRules(ctx context.Context, req *OrderRequest) error {
+	// Validate instrument reference data
+	if err := v.validateInstrumentRules(req); err != nil {
+		return err
+	}
+
 	// Validate order size limits
 	if err := v.validateOrderSizeLimits(req); err != nil {
 		return err
@@ -208,17 +307,17 @@ func (v *OrderValidator) validateBusinessRules(ctx context.Context, req *OrderRe
 // validateUpdateFields validates fields in order update request
 func (v *OrderValidator) validateUpdateFields(req *OrderUpdateRequest) error {
 	// At least one field must be updated
-	if req.Price <= 0 && req.Quantity <= 0 && req.StopPrice <= 0 && 
+	if !req.Price.IsPositive() && !req.Quantity.IsPositive() && !req.StopPrice.IsPositive() && 
 		req.TimeInForce == "" && req.ExpiresAt.IsZero() {
 		return ErrNoFieldsToUpdate
 	}
 
 	// Validate updated values
-	if req.Price > 0 && !v.isValidPricePrecision(req.Price) {
+	if req.Price.IsPositive() && !v.isValidPricePrecision(req.Symbol, req.Price) {
 		return ErrInvalidPricePrecision
 	}
 
-	if req.Quantity > 0 && !v.isValidQuantityPrecision(req.Quantity) {
+	if req.Quantity.IsPositive() && !v.isValidQuantityPrecision(req.Symbol, req.Quantity) {
 		return ErrInvalidQuantityPrecision
 	}
 
@@ -232,12 +331,12 @@ func (v *OrderValidator) validateUpdateFields(req *OrderUpdateRequest) error {
 // validateUpdateBusinessRules validates business rules for order updates
 func (v *OrderValidator) validateUpdateBusinessRules(ctx context.Context, order *Order, req *OrderUpdateRequest) error {
 	// Cannot reduce quantity below filled quantity
-	if req.Quantity > 0 && req.Quantity < order.FilledQuantity {
+	if req.Quantity.IsPositive() && req.Quantity.LessThan(order.FilledQuantity) {
 		return ErrQuantityBelowFilled
 	}
 
 	// Validate updated order size limits
-	if req.Quantity > 0 {
+	if req.Quantity.IsPositive() {
 		tempReq := &OrderRequest{
 			UserID:   order.UserID,
 			Symbol:   order.Symbol,
@@ -249,7 +348,7 @@ func (v *OrderValidator) validateUpdateBusinessRules(ctx context.Context, order
 	}
 
 	// Validate updated price limits
-	if req.Price > 0 {
+	if req.Price.IsPositive() {
 		tempReq := &OrderRequest{
 			Symbol: order.Symbol,
 			Price:  req.Price,
@@ -270,10 +369,13 @@ func (v *OrderValidator) canOrderBeUpdated(order *Order) bool {
 
 // canOrderBeCancelled checks if an order can be cancelled
 func (v *OrderValidator) canOrderBeCancelled(order *Order) bool {
-	// Only new, pending, and partially filled orders can be cancelled
+	// Only new, pending, partially filled, suspended and done for day
+	// orders can be cancelled
 	return order.Status == OrderStatusNew || 
 		   order.Status == OrderStatusPending || 
-		   order.Status == OrderStatusPartiallyFilled
+		   order.Status == OrderStatusPartiallyFilled || 
+		   order.Status == OrderStatusSuspended || 
+		   order.Status == OrderStatusDoneForDay
 }
 
 // isValidOrderSide checks if order side is valid
@@ -305,6 +407,7 @@ func (v *OrderValidator) isValidTimeInForce(tif TimeInForce) bool {
 		TimeInForceIOC,
 		TimeInForceFOK,
 		TimeInForceDay,
+		TimeInForceGTD,
 	}
 
 	for _, validTif := range validTifs {
@@ -331,37 +434,59 @@ func (v *OrderValidator) isValidSymbol(symbol string) bool {
 	return true
 }
 
-// isValidPricePrecision checks if price has valid precision
-func (v *OrderValidator) isValidPricePrecision(price float64) bool {
-	// Allow up to 4 decimal places
-	return price > 0 && price < 1000000 // Basic range check
+// Basic range bounds for price and quantity precision checks
+var (
+	maxPricePrecisionRange    = types.NewDecimalFromInt(1000000)
+	maxQuantityPrecisionRange = types.NewDecimalFromInt(1000000000)
+)
+
+// isValidPricePrecision checks if price is a multiple of the instrument tick size
+func (v *OrderValidator) isValidPricePrecision(symbol string, price types.Decimal) bool {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.TickSize.IsPositive() {
+		return price.IsPositive() && price.IsMultipleOf(instrument.TickSize)
+	}
+	return price.IsPositive() && price.LessThan(maxPricePrecisionRange) // Basic range check
+}
+
+// isValidQuantityPrecision checks if quantity is a multiple of the instrument lot size
+func (v *OrderValidator) isValidQuantityPrecision(symbol string, quantity types.Decimal) bool {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.LotSize.IsPositive() {
+		return quantity.IsPositive() && quantity.IsMultipleOf(instrument.LotSize)
+	}
+	return quantity.IsPositive() && quantity.LessThan(maxQuantityPrecisionRange) // Basic range check
 }
 
-// isValidQuantityPrecision checks if quantity has valid precision
-func (v *OrderValidator) isValidQuantityPrecision(quantity float64) bool {
-	// Allow up to 8 decimal places for crypto, 0 for stocks
-	return quantity > 0 && quantity < 1000000000 // Basic range check
+// validateInstrumentRules checks the request against the instrument registry
+func (v *OrderValidator) validateInstrumentRules(req *OrderRequest) error {
+	return types.Instruments.Validate(&types.Order{
+		Symbol:    req.Symbol,
+		Side:      types.OrderSide(req.Side),
+		Type:      types.OrderType(req.Type),
+		Price:     req.Price,
+		Quantity:  req.Quantity,
+		StopPrice: req.StopPrice,
+	}, types.Zero)
 }
 
 // validateOrderSizeLimits validates order size limits
 func (v *OrderValidator) validateOrderSizeLimits(req *OrderRequest) error {
 	// Maximum order size limits
 	maxOrderSize := v.getMaxOrderSize(req.Symbol)
-	if req.Quantity > maxOrderSize {
+	if req.Quantity.GreaterThan(maxOrderSize) {
 		return ErrOrderSizeExceedsLimit
 	}
 
 	// Minimum order size limits
 	minOrderSize := v.getMinOrderSize(req.Symbol)
-	if req.Quantity < minOrderSize {
+	if req.Quantity.LessThan(minOrderSize) {
 		return ErrOrderSizeBelowMinimum
 	}
 
 	// Order value limits
-	if req.Price > 0 {
-		orderValue := req.Price * req.Quantity
+	if req.Price.IsPositive() {
+		orderValue := req.Price.Mul(req.Quantity)
 		maxOrderValue := v.getMaxOrderValue(req.Symbol)
-		if orderValue > maxOrderValue {
+		if orderValue.GreaterThan(maxOrderValue) {
 			return ErrOrderValueExceedsLimit
 		}
 	}
@@ -371,7 +496,7 @@ func (v *OrderValidator) validateOrderSizeLimits(req *OrderRequest) error {
 
 // validatePriceLimits validates price limits
 func (v *OrderValidator) validatePriceLimits(req *OrderRequest) error {
-	if req.Price <= 0 {
+	if !req.Price.IsPositive() {
 		return nil // No price to validate
 	}
 
@@ -379,11 +504,11 @@ func (v *OrderValidator) validatePriceLimits(req *OrderRequest) error {
 	minPrice := v.getMinPrice(req.Symbol)
 	maxPrice := v.getMaxPrice(req.Symbol)
 
-	if req.Price < minPrice {
+	if req.Price.LessThan(minPrice) {
 		return ErrPriceBelowMinimum
 	}
 
-	if req.Price > maxPrice {
+	if req.Price.GreaterThan(maxPrice) {
 		return ErrPriceExceedsMaximum
 	}
 
@@ -393,6 +518,9 @@ func (v *OrderValidator) validatePriceLimits(req *OrderRequest) error {
 // validateExpirationTime validates order expiration time
 func (v *OrderValidator) validateExpirationTime(req *OrderRequest) error {
 	if req.ExpiresAt.IsZero() {
+		if req.TimeInForce == TimeInForceGTD {
+			return ErrExpirationTimeRequired
+		}
 		return nil // No expiration time set
 	}
 
@@ -422,30 +550,45 @@ func (v *OrderValidator) validateMarketHours(ctx context.Context, req *OrderRequ
 	return nil
 }
 
-// Helper methods to get limits (would be configurable in production)
-func (v *OrderValidator) getMaxOrderSize(symbol string) float64 {
+// Helper methods to get limits, preferring the instrument registry over defaults
+func (v *OrderValidator) getMaxOrderSize(symbol string) types.Decimal {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.MaxQuantity.IsPositive() {
+		return instrument.MaxQuantity
+	}
 	// Default max order size
-	return 1000000
+	return types.NewDecimalFromInt(1000000)
 }
 
-func (v *OrderValidator) getMinOrderSize(symbol string) float64 {
+func (v *OrderValidator) getMinOrderSize(symbol string) types.Decimal {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.MinQuantity.IsPositive() {
+		return instrument.MinQuantity
+	}
 	// Default min order size
-	return 0.001
+	return types.NewDecimal(1, 3)
 }
 
-func (v *OrderValidator) getMaxOrderValue(symbol string) float64 {
+func (v *OrderValidator) getMaxOrderValue(symbol string) types.Decimal {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.MaxNotional.IsPositive() {
+		return instrument.MaxNotional
+	}
 	// Default max order value
-	return 10000000
+	return types.NewDecimalFromInt(10000000)
 }
 
-func (v *OrderValidator) getMinPrice(symbol string) float64 {
+func (v *OrderValidator) getMinPrice(symbol string) types.Decimal {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.MinPrice.IsPositive() {
+		return instrument.MinPrice
+	}
 	// Default min price
-	return 0.0001
+	return types.NewDecimal(1, 4)
 }
 
-func (v *OrderValidator) getMaxPrice(symbol string) float64 {
+func (v *OrderValidator) getMaxPrice(symbol string) types.Decimal {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.MaxPrice.IsPositive() {
+		return instrument.MaxPrice
+	}
 	// Default max price
-	return 1000000
+	return types.NewDecimalFromInt(1000000)
 }
 
 func (v *OrderValidator) isMarketOpen(symbol string) bool {
@@ -457,6 +600,32 @@ func (v *OrderValidator) isMarketOpen(symbol string) bool {
 	return hour >= 9 && hour < 16
 }
 
+// rejectReasonFor maps a validation or matching error to a rejection code
+func rejectReasonFor(err error) types.RejectReason {
+	if reason := types.RejectReasonOf(err); reason != types.RejectReasonNone {
+		return reason
+	}
+
+	switch err {
+	case nil:
+		return types.RejectReasonNone
+	case ErrInvalidPricePrecision:
+		return types.RejectReasonInvalidTickSize
+	case ErrInvalidQuantityPrecision:
+		return types.RejectReasonInvalidLotSize
+	case ErrOrderSizeBelowMinimum:
+		return types.RejectReasonQuantityBelowMinimum
+	case ErrOrderSizeExceedsLimit:
+		return types.RejectReasonQuantityAboveMaximum
+	case ErrOrderValueExceedsLimit:
+		return types.RejectReasonNotionalAboveMaximum
+	case ErrPriceBelowMinimum, ErrPriceExceedsMaximum:
+		return types.RejectReasonPriceOutsideStaticBand
+	default:
+		return types.RejectReasonInvalidOrder
+	}
+}
+
 // Error definitions for validation
 var (
 	ErrInvalidOrderRequest      = errors.New("invalid order request")
@@ -479,6 +648,7 @@ var (
 	ErrPriceBelowMinimum       = errors.New("price below minimum")
 	ErrPriceExceedsMaximum     = errors.New("price exceeds maximum")
 	ErrExpirationTimeInPast    = errors.New("expiration time is in the past")
+	ErrExpirationTimeRequired  = errors.New("expiration time is required for GTD orders")
 	ErrExpirationTimeTooFar    = errors.New("expiration time is too far in the future")
 	ErrMarketClosed            = errors.New("market is closed")
 	ErrOrderCannotBeUpdated    = errors.New("order cannot be updated")
@@ -487,4 +657,12 @@ var (
 	ErrNoFieldsToUpdate        = errors.New("no fields to update")
 	ErrQuantityBelowFilled     = errors.New("quantity cannot be below filled quantity")
 	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidOrderGroup       = errors.New("invalid order group")
+	ErrInvalidBracket          = errors.New("bracket exits do not close the entry")
+	ErrInvalidMassCancel       = errors.New("mass cancel requires a user, account, symbol or strategy")
+	ErrInvalidKillSwitch       = errors.New("invalid kill switch")
+	ErrKillSwitchEngaged       = errors.New("kill switch engaged")
+	ErrKillSwitchNotFound      = errors.New("kill switch not found")
+	ErrSequenceUnavailable     = errors.New("execution report sequence no longer available")
+	ErrReduceOnlyExceedsPosition = errors.New("reduce-only order exceeds the position it closes")
 )
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/core/matching"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/google/uuid"
 	cache "github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
@@ -30,6 +31,16 @@ const (
 	OrderStatusRejected OrderStatus = "rejected"
 	// OrderStatusExpired represents an expired order
 	OrderStatusExpired OrderStatus = "expired"
+	// OrderStatusPendingNew represents an order sent to the book and not yet acknowledged
+	OrderStatusPendingNew OrderStatus = "pending_new"
+	// OrderStatusPendingCancel represents an order whose cancel is in progress
+	OrderStatusPendingCancel OrderStatus = "pending_cancel"
+	// OrderStatusPendingReplace represents an order whose amend is in progress
+	OrderStatusPendingReplace OrderStatus = "pending_replace"
+	// OrderStatusSuspended represents a resting order held by a trading halt
+	OrderStatusSuspended OrderStatus = "suspended"
+	// OrderStatusDoneForDay represents an order that stops working until the next trading day
+	OrderStatusDoneForDay OrderStatus = "done_for_day"
 )
 
 // OrderType represents the type of order
@@ -68,6 +79,8 @@ const (
 	TimeInForceFOK TimeInForce = "FOK"
 	// TimeInForceDay represents a day order
 	TimeInForceDay TimeInForce = "DAY"
+	// TimeInForceGTD represents a good-till-date order
+	TimeInForceGTD TimeInForce = "GTD"
 )
 
 // Order represents an order
@@ -76,6 +89,8 @@ type Order struct {
 	ID string
 	// UserID is the user ID
 	UserID string
+	// AccountGroup groups accounts for self-trade prevention
+	AccountGroup string
 	// ClientOrderID is the client order ID
 	ClientOrderID string
 	// Symbol is the trading symbol
@@ -85,25 +100,38 @@ type Order struct {
 	// Type is the type of the order
 	Type OrderType
 	// Price is the price of the order
-	Price float64
+	Price types.Decimal
 	// StopPrice is the stop price for stop orders
-	StopPrice float64
+	StopPrice types.Decimal
 	// Quantity is the quantity of the order
-	Quantity float64
+	Quantity types.Decimal
 	// FilledQuantity is the filled quantity of the order
-	FilledQuantity float64
+	FilledQuantity types.Decimal
 	// Status is the status of the order
 	Status OrderStatus
+	// RejectReason explains why the order was rejected
+	RejectReason types.RejectReason
 	// TimeInForce is the time in force of the order
 	TimeInForce TimeInForce
+	// SelfTradePrevention overrides the self-trade prevention mode
+	SelfTradePrevention types.SelfTradePrevention
+	// ReduceOnly orders may only close the user's position, never open or
+	// grow one
+	ReduceOnly bool
 	// CreatedAt is the time the order was created
 	CreatedAt time.Time
 	// UpdatedAt is the time the order was last updated
 	UpdatedAt time.Time
 	// ExpiresAt is the time the order expires
 	ExpiresAt time.Time
+	// GroupID is the contingent order group the order belongs to
+	GroupID string
+	// ParentOrderID is the order whose fill releases this order
+	ParentOrderID string
 	// Trades is the trades associated with the order
 	Trades []*Trade
+	// Tags are custom tags for the order, such as the placing strategy
+	Tags map[string]string
 	// Metadata is additional metadata for the order
 	Metadata map[string]interface{}
 }
@@ -119,13 +147,13 @@ type Trade struct {
 	// Side is the side of the trade (buy or sell)
 	Side OrderSide
 	// Price is the price of the trade
-	Price float64
+	Price types.Decimal
 	// Quantity is the quantity of the trade
-	Quantity float64
+	Quantity types.Decimal
 	// ExecutedAt is the time the trade was executed
 	ExecutedAt time.Time
 	// Fee is the fee for the trade
-	Fee float64
+	Fee types.Decimal
 	// FeeCurrency is the currency of the fee
 	FeeCurrency string
 	// CounterPartyOrderID is the counter party order ID
@@ -150,12 +178,19 @@ type OrderFilter struct {
 	StartTime time.Time
 	// EndTime is the end time for the filter
 	EndTime time.Time
+	// Limit caps the number of orders returned, newest first; zero returns
+	// every match
+	Limit int
+	// Offset skips the newest matching orders
+	Offset int
 }
 
 // OrderRequest represents an order request
 type OrderRequest struct {
 	// UserID is the user ID
 	UserID string
+	// AccountGroup groups accounts for self-trade prevention
+	AccountGroup string
 	// ClientOrderID is the client order ID
 	ClientOrderID string
 	// Symbol is the trading symbol
@@ -165,15 +200,21 @@ type OrderRequest struct {
 	// Type is the type of the order
 	Type OrderType
 	// Price is the price of the order
-	Price float64
+	Price types.Decimal
 	// StopPrice is the stop price for stop orders
-	StopPrice float64
+	StopPrice types.Decimal
 	// Quantity is the quantity of the order
-	Quantity float64
+	Quantity types.Decimal
 	// TimeInForce is the time in force of the order
 	TimeInForce TimeInForce
+	// SelfTradePrevention overrides the self-trade prevention mode
+	SelfTradePrevention types.SelfTradePrevention
+	// ReduceOnly rejects the order if it would open or grow a position
+	ReduceOnly bool
 	// ExpiresAt is the time the order expires
 	ExpiresAt time.Time
+	// Tags are custom tags for the order, such as the placing strategy
+	Tags map[string]string
 	// Metadata is additional metadata for the order
 	Metadata map[string]interface{}
 }
@@ -201,11 +242,11 @@ type OrderUpdateRequest struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Price is the price of the order
-	Price float64
+	Price types.Decimal
 	// StopPrice is the stop price for stop orders
-	StopPrice float64
+	StopPrice types.Decimal
 	// Quantity is the quantity of the order
-	Quantity float64
+	Quantity types.Decimal
 	// TimeInForce is the time in force of the order
 	TimeInForce TimeInForce
 	// ExpiresAt is the time the order expires
@@ -272,6 +313,12 @@ func NewService(engine *order_matching.Engine, logger *zap.Logger) *Service {
 	// Start order expiry checker
 	go service.checkOrderExpiry()
 
+	// Start trading phase scheduler
+	go service.followTradingPhases()
+
+	// Start matching engine event processor
+	go service.processEngineEvents()
+
 	// Start batch processor
 	go service.processBatchOperations()
 
@@ -449,30 +496,25 @@ func (s *Service) PlaceOrder(ctx context.Context, request *OrderRequest) (*Order
 
 	// Create order
 	order := &Order{
-		ID:             uuid.New().String(),
-		UserID:         request.UserID,
-		ClientOrderID:  request.ClientOrderID,
-		Symbol:         request.Symbol,
-		Side:           request.Side,
-		Type:           request.Type,
-		Price:          request.Price,
-		StopPrice:      request.StopPrice,
-		Quantity:       request.Quantity,
-		FilledQuantity: 0,
-		Status:         OrderStatusNew,
-		TimeInForce:    request.TimeInForce,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-		ExpiresAt:      request.ExpiresAt,
-		Trades:         make([]*Trade, 0),
-		Metadata:       request.Metadata,
-	}
-
-	// Set expiry time for day orders
-	if order.TimeInForce == TimeInForceDay && order.ExpiresAt.IsZero() {
-		// Set expiry time to end of day
-		now := time.Now()
-		order.ExpiresAt = time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
+		ID:                  uuid.New().String(),
+		UserID:              request.UserID,
+		AccountGroup:        request.AccountGroup,
+		ClientOrderID:       request.ClientOrderID,
+		Symbol:              request.Symbol,
+		Side:                request.Side,
+		Type:                request.Type,
+		Price:               request.Price,
+		StopPrice:           request.StopPrice,
+		Quantity:            request.Quantity,
+		FilledQuantity:      types.Zero,
+		Status:              OrderStatusNew,
+		TimeInForce:         request.TimeInForce,
+		SelfTradePrevention: request.SelfTradePrevention,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		ExpiresAt:           request.ExpiresAt,
+		Trades:              make([]*Trade, 0),
+		Metadata:            request.Metadata,
 	}
 
 	// Use batch processing for better performance
@@ -498,85 +540,28 @@ func (s *Service) PlaceOrder(ctx context.Context, request *OrderRequest) (*Order
 
 	// Place order in matching engine
 	engineOrder := &order_matching.Order{
-		ID:             order.ID,
-		Symbol:         order.Symbol,
-		Side:           order_matching.OrderSide(order.Side),
-		Type:           order_matching.OrderType(order.Type),
-		Price:          order.Price,
-		Quantity:       order.Quantity,
-		FilledQuantity: 0,
-		Status:         order_matching.OrderStatus(order.Status),
-		CreatedAt:      order.CreatedAt,
-		UpdatedAt:      order.UpdatedAt,
-		ClientOrderID:  order.ClientOrderID,
-		UserID:         order.UserID,
-		StopPrice:      order.StopPrice,
-		TimeInForce:    string(order.TimeInForce),
-	}
-
-	// Handle immediate-or-cancel and fill-or-kill orders
-	if order.TimeInForce == TimeInForceIOC || order.TimeInForce == TimeInForceFOK {
-		// Place order in matching engine
-		trades, err := s.Engine.PlaceOrder(engineOrder)
-		if err != nil {
-			// Remove order from maps
-			s.mu.Lock()
-			delete(s.Orders, order.ID)
-			delete(s.UserOrders[order.UserID], order.ID)
-			delete(s.SymbolOrders[order.Symbol], order.ID)
-			if order.ClientOrderID != "" {
-				delete(s.ClientOrderIDs, order.ClientOrderID)
-			}
-			s.mu.Unlock()
-			return nil, err
-		}
-
-		// Update order with trades
-		s.mu.Lock()
-		order.FilledQuantity = engineOrder.FilledQuantity
-		order.Status = OrderStatus(engineOrder.Status)
-		order.UpdatedAt = time.Now()
-
-		// Add trades to order
-		for _, trade := range trades {
-			orderTrade := &Trade{
-				ID:          trade.ID,
-				OrderID:     order.ID,
-				Symbol:      trade.Symbol,
-				Side:        OrderSide(trade.TakerSide),
-				Price:       trade.Price,
-				Quantity:    trade.Quantity,
-				ExecutedAt:  trade.Timestamp,
-				Fee:         trade.TakerFee,
-				FeeCurrency: order.Symbol,
-				CounterPartyOrderID: func() string {
-					if trade.MakerSide == order_matching.OrderSide(order.Side) {
-						return trade.BuyOrderID
-					}
-					return trade.SellOrderID
-				}(),
-				Metadata: make(map[string]interface{}),
-			}
-			order.Trades = append(order.Trades, orderTrade)
-		}
-
-		// Cancel unfilled quantity for IOC orders
-		if order.TimeInForce == TimeInForceIOC && order.FilledQuantity < order.Quantity {
-			order.Status = OrderStatusCancelled
-		}
-
-		// Cancel order for FOK orders if not fully filled
-		if order.TimeInForce == TimeInForceFOK && order.FilledQuantity < order.Quantity {
-			order.Status = OrderStatusCancelled
-			// Cancel order in matching engine
-			s.Engine.CancelOrder(order.Symbol, order.ID)
-		}
-		s.mu.Unlock()
-
-		return order, nil
+		ID:                  order.ID,
+		Symbol:              order.Symbol,
+		Side:                order_matching.OrderSide(order.Side),
+		Type:                order_matching.OrderType(order.Type),
+		Price:               order.Price,
+		Quantity:            order.Quantity,
+		FilledQuantity:      types.Zero,
+		Status:              order_matching.OrderStatus(order.Status),
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+		ClientOrderID:       order.ClientOrderID,
+		UserID:              order.UserID,
+		StopPrice:           order.StopPrice,
+		TimeInForce:         types.TimeInForce(order.TimeInForce),
+		ExpireTime:          order.ExpiresAt,
+		AccountGroup:        order.AccountGroup,
+		SelfTradePrevention: order.SelfTradePrevention,
 	}
 
-	// Place order in matching engine
+	// Place order in matching engine; it enforces time in force, cancelling
+	// IOC remainders, killing unfillable FOK orders and stamping DAY orders
+	// with the session close
 	trades, err := s.Engine.PlaceOrder(engineOrder)
 	if err != nil {
 		// Remove order from maps
@@ -593,8 +578,10 @@ func (s *Service) PlaceOrder(ctx context.Context, request *OrderRequest) (*Order
 
 	// Update order with trades
 	s.mu.Lock()
+	order.Quantity = engineOrder.Quantity
 	order.FilledQuantity = engineOrder.FilledQuantity
 	order.Status = OrderStatus(engineOrder.Status)
+	order.ExpiresAt = engineOrder.ExpireTime
 	order.UpdatedAt = time.Now()
 
 	// Add trades to order
@@ -845,6 +832,12 @@ func (s *Service) UpdateOrder(ctx context.Context, request *OrderUpdateRequest)
 		return nil, ErrUnauthorized
 	}
 
+	// Amend price and quantity in place so that quantity reductions keep
+	// their time priority in the book
+	if request.StopPrice.IsZero() && request.TimeInForce == "" && request.ExpiresAt.IsZero() {
+		return s.amendOrder(order, request)
+	}
+
 	// Check if order can be updated
 	if order.Status != OrderStatusNew {
 		return nil, ErrInvalidOrderStatus
@@ -860,17 +853,17 @@ func (s *Service) UpdateOrder(ctx context.Context, request *OrderUpdateRequest)
 	s.mu.Lock()
 
 	// Update price if provided
-	if request.Price > 0 {
+	if request.Price.IsPositive() {
 		order.Price = request.Price
 	}
 
 	// Update stop price if provided
-	if request.StopPrice > 0 {
+	if request.StopPrice.IsPositive() {
 		order.StopPrice = request.StopPrice
 	}
 
 	// Update quantity if provided
-	if request.Quantity > 0 {
+	if request.Quantity.IsPositive() {
 		order.Quantity = request.Quantity
 	}
 
@@ -891,20 +884,23 @@ func (s *Service) UpdateOrder(ctx context.Context, request *OrderUpdateRequest)
 
 	// Place updated order in matching engine
 	engineOrder := &order_matching.Order{
-		ID:             order.ID,
-		Symbol:         order.Symbol,
-		Side:           order_matching.OrderSide(order.Side),
-		Type:           order_matching.OrderType(order.Type),
-		Price:          order.Price,
-		Quantity:       order.Quantity,
-		FilledQuantity: order.FilledQuantity,
-		Status:         order_matching.OrderStatus(order.Status),
-		CreatedAt:      order.CreatedAt,
-		UpdatedAt:      order.UpdatedAt,
-		ClientOrderID:  order.ClientOrderID,
-		UserID:         order.UserID,
-		StopPrice:      order.StopPrice,
-		TimeInForce:    string(order.TimeInForce),
+		ID:                  order.ID,
+		Symbol:              order.Symbol,
+		Side:                order_matching.OrderSide(order.Side),
+		Type:                order_matching.OrderType(order.Type),
+		Price:               order.Price,
+		Quantity:            order.Quantity,
+		FilledQuantity:      order.FilledQuantity,
+		Status:              order_matching.OrderStatus(order.Status),
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+		ClientOrderID:       order.ClientOrderID,
+		UserID:              order.UserID,
+		StopPrice:           order.StopPrice,
+		TimeInForce:         types.TimeInForce(order.TimeInForce),
+		ExpireTime:          order.ExpiresAt,
+		AccountGroup:        order.AccountGroup,
+		SelfTradePrevention: order.SelfTradePrevention,
 	}
 
 	// Place order in matching engine
@@ -915,11 +911,53 @@ func (s *Service) UpdateOrder(ctx context.Context, request *OrderUpdateRequest)
 
 	// Update order with trades
 	s.mu.Lock()
+	order.Quantity = engineOrder.Quantity
 	order.FilledQuantity = engineOrder.FilledQuantity
 	order.Status = OrderStatus(engineOrder.Status)
+	order.ExpiresAt = engineOrder.ExpireTime
 	order.UpdatedAt = time.Now()
 
 	// Add trades to order
+	appendOrderTrades(order, trades)
+	s.mu.Unlock()
+
+	return order, nil
+}
+
+// amendOrder changes the price and quantity of a resting order in the
+// matching engine, which decides whether it keeps time priority
+func (s *Service) amendOrder(order *Order, request *OrderUpdateRequest) (*Order, error) {
+	if order.Status != OrderStatusNew && order.Status != OrderStatusPartiallyFilled {
+		return nil, ErrInvalidOrderStatus
+	}
+
+	trades, err := s.Engine.AmendOrder(order.Symbol, order.ID, request.Price, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	engineOrder, err := s.Engine.GetOrder(order.Symbol, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update order with the amended values and trades
+	s.mu.Lock()
+	order.Price = engineOrder.Price
+	order.Quantity = engineOrder.Quantity
+	order.FilledQuantity = engineOrder.FilledQuantity
+	order.Status = OrderStatus(engineOrder.Status)
+	order.UpdatedAt = time.Now()
+	appendOrderTrades(order, trades)
+	s.mu.Unlock()
+
+	s.OrderCache.Set(order.ID, order, cache.DefaultExpiration)
+
+	return order, nil
+}
+
+// appendOrderTrades records matching engine trades on the taker order
+func appendOrderTrades(order *Order, trades []*order_matching.Trade) {
 	for _, trade := range trades {
 		orderTrade := &Trade{
 			ID:          trade.ID,
@@ -941,9 +979,6 @@ func (s *Service) UpdateOrder(ctx context.Context, request *OrderUpdateRequest)
 		}
 		order.Trades = append(order.Trades, orderTrade)
 	}
-	s.mu.Unlock()
-
-	return order, nil
 }
 
 // validateOrderRequest validates an order request
@@ -961,113 +996,103 @@ func (s *Service) validateOrderRequest(request *OrderRequest) error {
 	if request.Type != OrderTypeLimit && request.Type != OrderTypeMarket && request.Type != OrderTypeStopLimit && request.Type != OrderTypeStopMarket {
 		return ErrInvalidRequest
 	}
-	if request.Quantity <= 0 {
+	if !request.Quantity.IsPositive() {
 		return ErrInvalidRequest
 	}
 
 	// Check price for limit orders
-	if (request.Type == OrderTypeLimit || request.Type == OrderTypeStopLimit) && request.Price <= 0 {
+	if (request.Type == OrderTypeLimit || request.Type == OrderTypeStopLimit) && !request.Price.IsPositive() {
 		return ErrInvalidRequest
 	}
 
 	// Check stop price for stop orders
-	if (request.Type == OrderTypeStopLimit || request.Type == OrderTypeStopMarket) && request.StopPrice <= 0 {
+	if (request.Type == OrderTypeStopLimit || request.Type == OrderTypeStopMarket) && !request.StopPrice.IsPositive() {
 		return ErrInvalidRequest
 	}
 
 	// Check time in force
 	if request.TimeInForce == "" {
 		request.TimeInForce = TimeInForceGTC
-	} else if request.TimeInForce != TimeInForceGTC && request.TimeInForce != TimeInForceIOC && request.TimeInForce != TimeInForceFOK && request.TimeInForce != TimeInForceDay {
+	} else if request.TimeInForce != TimeInForceGTC && request.TimeInForce != TimeInForceIOC && request.TimeInForce != TimeInForceFOK && request.TimeInForce != TimeInForceDay && request.TimeInForce != TimeInForceGTD {
+		return ErrInvalidRequest
+	}
+
+	// Good-till-date orders need an expiry
+	if request.TimeInForce == TimeInForceGTD && request.ExpiresAt.IsZero() {
 		return ErrInvalidRequest
 	}
 
 	return nil
 }
 
-// checkOrderExpiry checks for expired orders
+// checkOrderExpiry expires DAY and GTD orders in the matching engine; the
+// resulting events are applied by processEngineEvents
 func (s *Service) checkOrderExpiry() {
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
+	s.Engine.RunExpiry(s.ctx, time.Second)
+}
+
+// followTradingPhases moves the matching engine's books through their
+// auction calls; auction fills are applied by processEngineEvents
+func (s *Service) followTradingPhases() {
+	s.Engine.RunPhases(s.ctx, time.Second)
+}
 
+// processEngineEvents applies time in force, self-trade prevention and
+// auction outcomes reported by the matching engine
+func (s *Service) processEngineEvents() {
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-ticker.C:
-			now := time.Now()
-
-			// Get expired orders
-			s.mu.RLock()
-			expiredOrders := make([]*Order, 0)
-			for _, order := range s.Orders {
-				if !order.ExpiresAt.IsZero() && now.After(order.ExpiresAt) && (order.Status == OrderStatusNew || order.Status == OrderStatusPartiallyFilled) {
-					expiredOrders = append(expiredOrders, order)
-				}
-			}
-			s.mu.RUnlock()
-
-			// Process in batches for better performance
-			if len(expiredOrders) > 0 {
-				batchSize := 100
-				for i := 0; i < len(expiredOrders); i += batchSize {
-					end := i + batchSize
-					if end > len(expiredOrders) {
-						end = len(expiredOrders)
-					}
-
-					batch := expiredOrders[i:end]
-					s.processExpiredOrdersBatch(batch, now)
-				}
-			}
+		case event := <-s.Engine.EventChannel:
+			s.applyEngineEvent(event)
 		}
 	}
 }
 
-// processExpiredOrdersBatch processes a batch of expired orders
-func (s *Service) processExpiredOrdersBatch(orders []*Order, now time.Time) {
-	// Create a wait group for concurrent processing
-	var wg sync.WaitGroup
-	wg.Add(len(orders))
-
-	// Process orders concurrently
-	for _, order := range orders {
-		go func(order *Order) {
-			defer wg.Done()
-
-			// Cancel order in matching engine
-			err := s.Engine.CancelOrder(order.Symbol, order.ID)
-			if err != nil {
-				s.logger.Error("Failed to cancel expired order",
-					zap.String("order_id", order.ID),
-					zap.Error(err))
-				return
-			}
-
-			// Update order status using batch operation
-			resultCh := make(chan orderOperationResult, 1)
-			order.Status = OrderStatusExpired
-			order.UpdatedAt = now
+// applyEngineEvent updates the orders behind an IOC cancel, FOK kill, expiry,
+// self-trade prevention or auction fill event
+func (s *Service) applyEngineEvent(event *order_matching.MatchingEvent) {
+	for _, engineOrder := range []*order_matching.Order{event.Order, event.ContraOrder} {
+		if engineOrder != nil {
+			s.applyEngineOrder(event, engineOrder)
+		}
+	}
+}
 
-			s.orderBatchChan <- orderOperation{
-				opType:    "update",
-				order:     order,
-				requestID: order.ID,
-				resultCh:  resultCh,
-			}
+// applyEngineOrder copies the outcome of an engine event onto a service order
+func (s *Service) applyEngineOrder(event *order_matching.MatchingEvent, engineOrder *order_matching.Order) {
+	s.mu.RLock()
+	order, exists := s.Orders[engineOrder.ID]
+	s.mu.RUnlock()
+	if !exists {
+		return
+	}
 
-			// Wait for result
-			<-resultCh
+	// Update order status using batch operation
+	resultCh := make(chan orderOperationResult, 1)
+	s.mu.Lock()
+	order.Status = OrderStatus(engineOrder.Status)
+	order.Quantity = engineOrder.Quantity
+	order.FilledQuantity = engineOrder.FilledQuantity
+	order.UpdatedAt = event.Timestamp
+	s.mu.Unlock()
 
-			s.logger.Info("Order expired",
-				zap.String("order_id", order.ID),
-				zap.String("symbol", order.Symbol),
-				zap.String("user_id", order.UserID))
-		}(order)
+	s.orderBatchChan <- orderOperation{
+		opType:    "update",
+		order:     order,
+		requestID: order.ID,
+		resultCh:  resultCh,
 	}
 
-	// Wait for all orders to be processed
-	wg.Wait()
+	// Wait for result
+	<-resultCh
+
+	s.logger.Info("Order updated by matching engine",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.String("user_id", order.UserID),
+		zap.String("event", string(event.Type)))
 }
 
 // Stop stops the service
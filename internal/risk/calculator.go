@@ -6,32 +6,136 @@ import (
 	"sync"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
 	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
 // Calculator handles risk calculations and metrics
 type Calculator struct {
-	logger *zap.Logger
-	mu     sync.RWMutex
+	logger        *zap.Logger
+	mu            sync.RWMutex
+	history       PriceHistory
+	varConfig     VaRConfig
+	optionsConfig OptionsConfig
+	marginConfig  MarginConfig
 }
 
 // NewCalculator creates a new risk calculator
 func NewCalculator(logger *zap.Logger) *Calculator {
 	return &Calculator{
-		logger: logger,
+		logger:        logger,
+		varConfig:     DefaultVaRConfig(),
+		optionsConfig: DefaultOptionsConfig(),
+		marginConfig:  DefaultMarginConfig(),
 	}
 }
 
-// CalculatePositionRisk calculates risk metrics for a position
-func (c *Calculator) CalculatePositionRisk(ctx context.Context, position *Position, currentPrice float64) (*PositionRiskMetrics, error) {
+// CalculatorParams contains the parameters for creating a risk calculator
+type CalculatorParams struct {
+	fx.In
+
+	Logger     *zap.Logger
+	MarketData *repositories.MarketDataRepository `optional:"true"`
+}
+
+// NewFxCalculator creates a risk calculator estimating VaR from the stored
+// market data, when there is a market data repository
+func NewFxCalculator(p CalculatorParams) *Calculator {
+	calculator := NewCalculator(p.Logger)
+	if p.MarketData != nil {
+		calculator.SetPriceHistory(p.MarketData)
+	}
+	return calculator
+}
+
+// SetPriceHistory estimates VaR and Expected Shortfall from the returns in
+// history. Without it a flat daily volatility is assumed.
+func (c *Calculator) SetPriceHistory(history PriceHistory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.history = history
+}
+
+// SetVaRConfig sets the method, confidence and horizon of VaR estimates
+func (c *Calculator) SetVaRConfig(config VaRConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.varConfig = config
+	return nil
+}
+
+// VaRConfig returns the configuration of VaR estimates
+func (c *Calculator) VaRConfig() VaRConfig {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	return c.varConfig
+}
+
+// SetOptionsConfig sets the rate, dividend yields and default volatility
+// option positions are valued with
+func (c *Calculator) SetOptionsConfig(config OptionsConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.optionsConfig = config
+	return nil
+}
+
+// OptionsConfig returns the configuration option positions are valued with
+func (c *Calculator) OptionsConfig() OptionsConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.optionsConfig
+}
+
+// SetMarginConfig sets the margin rates order margin requirements are
+// taken at
+func (c *Calculator) SetMarginConfig(config MarginConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.marginConfig = config
+	return nil
+}
+
+// CalculatePositionRisk calculates risk metrics for a position. An option
+// position is valued at its UnderlyingPrice.
+func (c *Calculator) CalculatePositionRisk(ctx context.Context, position *Position, currentPrice float64) (*PositionRiskMetrics, error) {
 	if position == nil {
 		return nil, ErrInvalidPosition
 	}
 
+	config := c.VaRConfig()
+	series := c.returnsFor(ctx, config, []string{position.Symbol})
+	var results []*VaRResult
+	if series != nil {
+		results = valueAtRisk(series, []float64{position.Quantity * currentPrice}, config, 0.95, 0.99)
+	}
+	return c.positionRisk(position, currentPrice, position.UnderlyingPrice, config, results, 0), nil
+}
+
+// positionRisk calculates the risk metrics of a position over the horizon
+// of config. results hold the 95% and 99% estimates of a portfolio with the
+// position at index; without them a flat daily volatility is assumed.
+func (c *Calculator) positionRisk(position *Position, currentPrice, underlyingPrice float64, config VaRConfig, results []*VaRResult, index int) *PositionRiskMetrics {
 	metrics := &PositionRiskMetrics{
 		Symbol:        position.Symbol,
 		UserID:        position.UserID,
@@ -51,18 +155,20 @@ func (c *Calculator) CalculatePositionRisk(ctx context.Context, position *Positi
 	metrics.MarketValue = math.Abs(position.Quantity) * currentPrice
 
 	// Calculate risk metrics
-	metrics.VaR95 = c.calculateVaR(position, currentPrice, 0.95)
-	metrics.VaR99 = c.calculateVaR(position, currentPrice, 0.99)
-	metrics.ExpectedShortfall = c.calculateExpectedShortfall(position, currentPrice, 0.95)
-
-	// Calculate Greeks (for options positions)
-	if position.InstrumentType == "option" {
-		metrics.Delta = c.calculateDelta(position, currentPrice)
-		metrics.Gamma = c.calculateGamma(position, currentPrice)
-		metrics.Theta = c.calculateTheta(position, currentPrice)
-		metrics.Vega = c.calculateVega(position, currentPrice)
+	if len(results) == 2 {
+		metrics.VaR95 = results[0].Positions[index].VaR
+		metrics.VaR99 = results[1].Positions[index].VaR
+		metrics.ExpectedShortfall = results[0].Positions[index].ExpectedShortfall
+	} else {
+		horizon := math.Sqrt(float64(config.HorizonDays))
+		metrics.VaR95 = c.calculateVaR(position, currentPrice, 0.95) * horizon
+		metrics.VaR99 = c.calculateVaR(position, currentPrice, 0.99) * horizon
+		metrics.ExpectedShortfall = c.calculateExpectedShortfall(position, currentPrice, 0.95) * horizon
 	}
 
+	// Calculate Greeks
+	c.positionGreeks(metrics, position, underlyingPrice)
+
 	// Determine risk level
 	metrics.RiskLevel = c.determineRiskLevel(metrics)
 
@@ -73,14 +179,23 @@ func (c *Calculator) CalculatePositionRisk(ctx context.Context, position *Positi
 		zap.Float64("var_95", metrics.VaR95),
 		zap.String("risk_level", string(metrics.RiskLevel)))
 
-	return metrics, nil
+	return metrics
 }
 
-// CalculateAccountRisk calculates overall account risk
+// CalculateAccountRisk calculates overall account risk with the configured
+// VaR method, confidence and horizon
 func (c *Calculator) CalculateAccountRisk(ctx context.Context, userID string, positions []*Position, prices map[string]float64) (*AccountRiskMetrics, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	return c.CalculateAccountRiskWith(ctx, c.VaRConfig(), userID, positions, prices)
+}
 
+// CalculateAccountRiskWith calculates overall account risk with config.
+// With a price history the portfolio VaR and Expected Shortfall account
+// for the covariance of the positions, and each position carries its
+// component and marginal VaR; otherwise the position VaRs are added up.
+func (c *Calculator) CalculateAccountRiskWith(ctx context.Context, config VaRConfig, userID string, positions []*Position, prices map[string]float64) (*AccountRiskMetrics, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 	if len(positions) == 0 {
 		return &AccountRiskMetrics{
 			UserID:       userID,
@@ -99,22 +214,65 @@ func (c *Calculator) CalculateAccountRisk(ctx context.Context, userID string, po
 	var totalMarketValue float64
 	var totalVaR95 float64
 	var totalVaR99 float64
+	var totalVaR float64
+	var totalES float64
 	var maxPositionRisk RiskLevel = RiskLevelLow
 
-	// Calculate risk for each position
+	// Load the returns of each symbol once, then give every priced
+	// position its column
+	var priced []*Position
+	var symbols []string
+	var columns []int
+	var exposures []float64
+	index := make(map[string]int)
 	for _, position := range positions {
+		if position == nil {
+			continue
+		}
 		currentPrice, exists := prices[position.Symbol]
 		if !exists {
 			c.logger.Warn("Price not available for symbol", zap.String("symbol", position.Symbol))
 			continue
 		}
+		if _, exists := index[position.Symbol]; !exists {
+			index[position.Symbol] = len(symbols)
+			symbols = append(symbols, position.Symbol)
+		}
+		priced = append(priced, position)
+		columns = append(columns, index[position.Symbol])
+		exposures = append(exposures, position.Quantity*currentPrice)
+	}
 
-		positionRisk, err := c.CalculatePositionRisk(ctx, position, currentPrice)
-		if err != nil {
-			c.logger.Error("Failed to calculate position risk",
-				zap.String("symbol", position.Symbol),
-				zap.Error(err))
-			continue
+	var results []*VaRResult
+	series := c.returnsFor(ctx, config, symbols)
+	if series != nil {
+		results = valueAtRisk(series.columns(columns), exposures, config, 0.95, 0.99, config.Confidence)
+	}
+
+	// Calculate risk for each position
+	for i, position := range priced {
+		currentPrice := prices[position.Symbol]
+		underlyingPrice := position.UnderlyingPrice
+		if position.Option != nil {
+			if price, exists := prices[position.Option.Underlying]; exists {
+				underlyingPrice = price
+			}
+		}
+
+		var positionRisk *PositionRiskMetrics
+		if results != nil {
+			positionRisk = c.positionRisk(position, currentPrice, underlyingPrice, config, results[:2], i)
+			contribution := results[2].Positions[i]
+			positionRisk.VaR = contribution.VaR
+			positionRisk.ComponentVaR = contribution.ComponentVaR
+			positionRisk.MarginalVaR = contribution.MarginalVaR
+			positionRisk.ComponentES = contribution.ComponentES
+		} else {
+			positionRisk = c.positionRisk(position, currentPrice, underlyingPrice, config, nil, i)
+			horizon := math.Sqrt(float64(config.HorizonDays))
+			positionRisk.VaR = c.calculateVaR(position, currentPrice, config.Confidence) * horizon
+			totalVaR += positionRisk.VaR
+			totalES += c.calculateExpectedShortfall(position, currentPrice, config.Confidence) * horizon
 		}
 
 		metrics.Positions = append(metrics.Positions, positionRisk)
@@ -129,11 +287,33 @@ func (c *Calculator) CalculateAccountRisk(ctx context.Context, userID string, po
 		}
 	}
 
-	// Set aggregate metrics
+	// Set aggregate metrics. Without a price history the position VaRs are
+	// added up, as if the positions moved together.
 	metrics.TotalUnrealizedPnL = totalUnrealizedPnL
 	metrics.TotalMarketValue = totalMarketValue
-	metrics.PortfolioVaR95 = totalVaR95
-	metrics.PortfolioVaR99 = totalVaR99
+	metrics.VaRConfidence = config.Confidence
+	metrics.VaRHorizonDays = config.HorizonDays
+	if results != nil {
+		metrics.VaRMethod = config.Method
+		metrics.PortfolioVaR95 = results[0].VaR
+		metrics.PortfolioVaR99 = results[1].VaR
+		metrics.PortfolioVaR = results[2].VaR
+		metrics.PortfolioES = results[2].ExpectedShortfall
+	} else {
+		metrics.VaRMethod = VaRMethodParametric
+		metrics.PortfolioVaR95 = totalVaR95
+		metrics.PortfolioVaR99 = totalVaR99
+		metrics.PortfolioVaR = totalVaR
+		metrics.PortfolioES = totalES
+	}
+
+	// Net the Greeks of each underlying
+	metrics.Greeks = aggregateGreeks(metrics.Positions)
+	for _, exposure := range metrics.Greeks {
+		metrics.NetDollarDelta += exposure.DollarDelta
+		metrics.NetTheta += exposure.Theta
+		metrics.NetVega += exposure.Vega
+	}
 
 	// Calculate portfolio-level metrics
 	if totalMarketValue > 0 {
@@ -145,6 +325,9 @@ func (c *Calculator) CalculateAccountRisk(ctx context.Context, userID string, po
 
 	// Calculate correlation risk
 	metrics.CorrelationRisk = c.calculateCorrelationRisk(metrics.Positions)
+	if series != nil {
+		metrics.CorrelationRisk = averageCorrelation(series.covariance())
+	}
 
 	// Determine overall account risk level
 	metrics.RiskLevel = c.determineAccountRiskLevel(metrics, maxPositionRisk)
@@ -172,18 +355,18 @@ func (c *Calculator) CalculateOrderRisk(ctx context.Context, order *orders.Order
 		Symbol:       order.Symbol,
 		UserID:       order.UserID,
 		Side:         string(order.Side),
-		Quantity:     order.Quantity,
-		Price:        order.Price,
+		Quantity:     order.Quantity.Float64(),
+		Price:        order.Price.Float64(),
 		CurrentPrice: currentPrice,
 		CalculatedAt: time.Now(),
 	}
 
 	// Calculate order value
-	orderPrice := order.Price
+	orderPrice := order.Price.Float64()
 	if order.Type == orders.OrderTypeMarket {
 		orderPrice = currentPrice
 	}
-	metrics.OrderValue = order.Quantity * orderPrice
+	metrics.OrderValue = order.Quantity.Float64() * orderPrice
 
 	// Calculate position impact
 	if currentPosition != nil {
@@ -192,9 +375,9 @@ func (c *Calculator) CalculateOrderRisk(ctx context.Context, order *orders.Order
 		// Calculate new position after order execution
 		newQuantity := currentPosition.Quantity
 		if order.Side == orders.OrderSideBuy {
-			newQuantity += order.Quantity
+			newQuantity += order.Quantity.Float64()
 		} else {
-			newQuantity -= order.Quantity
+			newQuantity -= order.Quantity.Float64()
 		}
 		metrics.NewPosition = newQuantity
 
@@ -204,9 +387,9 @@ func (c *Calculator) CalculateOrderRisk(ctx context.Context, order *orders.Order
 	} else {
 		// New position
 		if order.Side == orders.OrderSideBuy {
-			metrics.NewPosition = order.Quantity
+			metrics.NewPosition = order.Quantity.Float64()
 		} else {
-			metrics.NewPosition = -order.Quantity
+			metrics.NewPosition = -order.Quantity.Float64()
 		}
 		metrics.PositionChange = metrics.NewPosition
 		metrics.PositionChangePercent = 100 // 100% change for new position
@@ -234,76 +417,78 @@ func (c *Calculator) CalculateOrderRisk(ctx context.Context, order *orders.Order
 	return metrics, nil
 }
 
-// calculateVaR calculates Value at Risk for a position
-func (c *Calculator) calculateVaR(position *Position, currentPrice float64, confidence float64) float64 {
-	// Simplified VaR calculation using historical volatility
-	// In production, this would use more sophisticated models
-	
-	volatility := c.getHistoricalVolatility(position.Symbol)
-	if volatility == 0 {
-		volatility = 0.02 // Default 2% daily volatility
-	}
-
-	// Z-score for confidence level
-	var zScore float64
-	switch confidence {
-	case 0.95:
-		zScore = 1.645
-	case 0.99:
-		zScore = 2.326
-	default:
-		zScore = 1.645
-	}
+// defaultDailyVolatility is the daily volatility assumed for a symbol
+// without price history
+const defaultDailyVolatility = 0.02
 
+// calculateVaR calculates the one-day parametric Value at Risk of a
+// position without price history
+func (c *Calculator) calculateVaR(position *Position, currentPrice float64, confidence float64) float64 {
 	marketValue := math.Abs(position.Quantity) * currentPrice
-	return marketValue * volatility * zScore
+	return marketValue * defaultDailyVolatility * normalQuantile(confidence)
 }
 
-// calculateExpectedShortfall calculates Expected Shortfall (Conditional VaR)
+// calculateExpectedShortfall calculates the one-day parametric Expected
+// Shortfall (Conditional VaR) of a position without price history
 func (c *Calculator) calculateExpectedShortfall(position *Position, currentPrice float64, confidence float64) float64 {
-	// Simplified ES calculation
-	var95 := c.calculateVaR(position, currentPrice, confidence)
-	return var95 * 1.3 // Approximate ES as 1.3 times VaR for normal distribution
+	marketValue := math.Abs(position.Quantity) * currentPrice
+	return marketValue * defaultDailyVolatility * normalDensity(normalQuantile(confidence)) / (1 - confidence)
 }
 
-// calculateDelta calculates option delta
-func (c *Calculator) calculateDelta(position *Position, currentPrice float64) float64 {
-	// Simplified delta calculation - in production would use Black-Scholes
-	if position.InstrumentType != "option" {
-		return 0
+// returnsFor loads the returns of symbols from the price history. It
+// returns nil, falling back to a flat volatility, when there is no history
+// or too little of it.
+func (c *Calculator) returnsFor(ctx context.Context, config VaRConfig, symbols []string) *returnSeries {
+	c.mu.RLock()
+	history := c.history
+	c.mu.RUnlock()
+	if history == nil || len(symbols) == 0 {
+		return nil
 	}
-	// Placeholder implementation
-	return 0.5
-}
 
-// calculateGamma calculates option gamma
-func (c *Calculator) calculateGamma(position *Position, currentPrice float64) float64 {
-	// Simplified gamma calculation
-	if position.InstrumentType != "option" {
-		return 0
+	series, err := loadReturns(ctx, history, config, symbols, time.Now())
+	if err != nil {
+		c.logger.Warn("Price history unavailable, assuming a flat volatility",
+			zap.Strings("symbols", symbols),
+			zap.Error(err))
+		return nil
 	}
-	// Placeholder implementation
-	return 0.01
+	return series
 }
 
-// calculateTheta calculates option theta
-func (c *Calculator) calculateTheta(position *Position, currentPrice float64) float64 {
-	// Simplified theta calculation
-	if position.InstrumentType != "option" {
-		return 0
-	}
-	// Placeholder implementation
-	return -0.05
-}
-
-// calculateVega calculates option vega
-func (c *Calculator) calculateVega(position *Position, currentPrice float64) float64 {
-	// Simplified vega calculation
-	if position.InstrumentType != "option" {
-		return 0
-	}
-	// Placeholder implementation
-	return 0.1
+// positionGreeks sets the Greeks of a position. A position in anything
+// but an option has the delta of its quantity in its own symbol; an option
+// position is valued against its underlying with the options config.
+func (c *Calculator) positionGreeks(metrics *PositionRiskMetrics, position *Position, underlyingPrice float64) {
+	if position.Option == nil && position.InstrumentType != "option" {
+		metrics.Underlying = position.Symbol
+		metrics.UnderlyingPrice = metrics.CurrentPrice
+		metrics.Delta = position.Quantity
+		return
+	}
+	if position.Option == nil || underlyingPrice <= 0 {
+		c.logger.Warn("Option position without a contract or underlying price",
+			zap.String("symbol", position.Symbol),
+			zap.String("user_id", position.UserID))
+		return
+	}
+
+	greeks, volatility, err := optionGreeks(position.Option, position.Quantity, metrics.CurrentPrice, underlyingPrice, c.OptionsConfig(), metrics.CalculatedAt)
+	if err != nil {
+		c.logger.Warn("Failed to value option position",
+			zap.String("symbol", position.Symbol),
+			zap.String("user_id", position.UserID),
+			zap.Error(err))
+		return
+	}
+	metrics.Underlying = position.Option.Underlying
+	metrics.UnderlyingPrice = underlyingPrice
+	metrics.ImpliedVolatility = volatility
+	metrics.Delta = greeks.Delta
+	metrics.Gamma = greeks.Gamma
+	metrics.Theta = greeks.Theta
+	metrics.Vega = greeks.Vega
+	metrics.Rho = greeks.Rho
 }
 
 // determineRiskLevel determines risk level based on metrics
@@ -391,23 +576,21 @@ func (c *Calculator) calculateCorrelationRisk(positions []*PositionRiskMetrics)
 // calculateLeverageImpact calculates leverage impact of an order
 func (c *Calculator) calculateLeverageImpact(order *orders.Order, currentPosition *Position) float64 {
 	// Simplified leverage calculation
-	orderValue := order.Quantity * order.Price
+	orderValue := order.Quantity.Float64() * order.Price.Float64()
 	
 	if currentPosition != nil {
-		currentValue := math.Abs(currentPosition.Quantity) * order.Price
+		currentValue := math.Abs(currentPosition.Quantity) * order.Price.Float64()
 		return orderValue / math.Max(currentValue, 1000) // Avoid division by zero
 	}
 	
 	return orderValue / 1000 // Normalized impact
 }
 
-// calculateMarginRequirement calculates margin requirement for an order
+// calculateMarginRequirement calculates the initial margin of an order at
+// the rate of its symbol's asset class. The caller holds the read lock.
 func (c *Calculator) calculateMarginRequirement(order *orders.Order, currentPrice float64) float64 {
-	// Simplified margin calculation - typically 10-50% of order value
-	orderValue := order.Quantity * currentPrice
-	marginRate := 0.2 // 20% margin requirement
-	
-	return orderValue * marginRate
+	orderValue := order.Quantity.Float64() * currentPrice
+	return orderValue * c.marginConfig.RatesFor(order.Symbol).Initial
 }
 
 // calculateMaxLossPotential calculates maximum potential loss for an order
@@ -417,29 +600,12 @@ func (c *Calculator) calculateMaxLossPotential(order *orders.Order, currentPrice
 	
 	if order.Type == orders.OrderTypeMarket {
 		slippage := 0.02
-		return order.Quantity * currentPrice * slippage
+		return order.Quantity.Float64() * currentPrice * slippage
 	}
 	
 	// For limit orders, max loss is the difference between limit and current price
-	priceDiff := math.Abs(order.Price - currentPrice)
-	return order.Quantity * priceDiff
-}
-
-// getHistoricalVolatility gets historical volatility for a symbol
-func (c *Calculator) getHistoricalVolatility(symbol string) float64 {
-	// Placeholder - in production would fetch from market data service
-	volatilityMap := map[string]float64{
-		"AAPL": 0.25,
-		"GOOGL": 0.30,
-		"TSLA": 0.45,
-		"SPY": 0.15,
-	}
-	
-	if vol, exists := volatilityMap[symbol]; exists {
-		return vol
-	}
-	
-	return 0.20 // Default volatility
+	priceDiff := math.Abs(order.Price.Float64() - currentPrice)
+	return order.Quantity.Float64() * priceDiff
 }
 
 // compareRiskLevels compares two risk levels and returns:
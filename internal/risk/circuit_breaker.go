@@ -85,6 +85,10 @@ type CircuitBreakerSystem struct {
 	globalHalt       bool
 	globalHaltTime   *time.Time
 	globalHaltReason HaltReason
+
+	// Matching layer callbacks; symbol is empty for global halts
+	onHalt   func(symbol string, reason HaltReason, message string)
+	onResume func(symbol string)
 }
 
 // NewCircuitBreakerSystem creates a new circuit breaker system
@@ -117,6 +121,18 @@ func (cbs *CircuitBreakerSystem) AddCircuitBreaker(symbol string, config *Circui
 	)
 }
 
+// SetHaltHandlers connects the circuit breakers to the matching layer,
+// which owns the book state: onHalt is called when a breaker opens or a
+// global halt starts, onResume when trading may resume. The symbol is empty
+// for global halts.
+func (cbs *CircuitBreakerSystem) SetHaltHandlers(onHalt func(symbol string, reason HaltReason, message string), onResume func(symbol string)) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	cbs.onHalt = onHalt
+	cbs.onResume = onResume
+}
+
 // UpdatePriceData updates price data and checks for circuit breaker triggers
 func (cbs *CircuitBreakerSystem) UpdatePriceData(data *PriceData) error {
 	cbs.mu.Lock()
@@ -350,6 +366,10 @@ func (cbs *CircuitBreakerSystem) triggerCircuitBreaker(symbol string, reason Hal
 		zap.Time("halted_at", now),
 	)
 
+	if cbs.onHalt != nil {
+		cbs.onHalt(symbol, reason, message)
+	}
+
 	return nil
 }
 
@@ -372,6 +392,11 @@ func (cbs *CircuitBreakerSystem) attemptRecovery(symbol string, config *CircuitB
 	cbs.logger.Info("Circuit breaker entering half-open state",
 		zap.String("symbol", symbol),
 	)
+
+	// Test orders need an open book
+	if cbs.onResume != nil {
+		cbs.onResume(symbol)
+	}
 }
 
 // TestOrder tests an order in half-open state
@@ -400,6 +425,11 @@ func (cbs *CircuitBreakerSystem) resumeTrading(symbol string, reason string) {
 	breaker := cbs.breakers[symbol]
 	now := time.Now()
 
+	// A half-open breaker already reopened the book
+	if breaker.State == CircuitBreakerOpen && cbs.onResume != nil {
+		cbs.onResume(symbol)
+	}
+
 	if breaker.HaltedAt != nil {
 		breaker.LastHaltDuration = now.Sub(*breaker.HaltedAt)
 		cbs.avgHaltDuration = (cbs.avgHaltDuration + breaker.LastHaltDuration) / 2
@@ -482,6 +512,10 @@ func (cbs *CircuitBreakerSystem) GlobalHalt(reason HaltReason, message string) {
 		zap.String("reason", string(reason)),
 		zap.String("message", message),
 	)
+
+	if cbs.onHalt != nil {
+		cbs.onHalt("", reason, message)
+	}
 }
 
 // GlobalResume resumes all trading
@@ -494,6 +528,10 @@ func (cbs *CircuitBreakerSystem) GlobalResume() {
 	cbs.globalHaltReason = ""
 
 	cbs.logger.Info("Global trading resumed")
+
+	if cbs.onResume != nil {
+		cbs.onResume("")
+	}
 }
 
 // GetStatus returns the status of a circuit breaker
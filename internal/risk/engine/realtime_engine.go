@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/common/pool"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
 	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/zap"
 )
@@ -66,19 +67,23 @@ type PositionManager struct {
 	mu            sync.RWMutex
 }
 
-// Position represents a trading position
+// Position represents a trading position. Its Greeks are those of the
+// whole position: delta in units of the underlying, theta per day and vega
+// per volatility point.
 type Position struct {
-	Symbol         string    `json:"symbol"`
-	Quantity       float64   `json:"quantity"`
-	AveragePrice   float64   `json:"average_price"`
-	MarketPrice    float64   `json:"market_price"`
-	UnrealizedPnL  float64   `json:"unrealized_pnl"`
-	RealizedPnL    float64   `json:"realized_pnl"`
-	Delta          float64   `json:"delta"`
-	Gamma          float64   `json:"gamma"`
-	Vega           float64   `json:"vega"`
-	Theta          float64   `json:"theta"`
-	LastUpdateTime time.Time `json:"last_update_time"`
+	Symbol            string            `json:"symbol"`
+	Quantity          float64           `json:"quantity"`
+	AveragePrice      float64           `json:"average_price"`
+	MarketPrice       float64           `json:"market_price"`
+	UnrealizedPnL     float64           `json:"unrealized_pnl"`
+	RealizedPnL       float64           `json:"realized_pnl"`
+	Option            *options.Contract `json:"option,omitempty"`
+	ImpliedVolatility float64           `json:"implied_volatility,omitempty"`
+	Delta             float64           `json:"delta"`
+	Gamma             float64           `json:"gamma"`
+	Vega              float64           `json:"vega"`
+	Theta             float64           `json:"theta"`
+	LastUpdateTime    time.Time         `json:"last_update_time"`
 }
 
 // LimitManager manages trading limits
@@ -280,7 +285,7 @@ func (e *RealTimeRiskEngine) PreTradeCheck(order *types.Order) (*RiskCheck, erro
 		return &RiskCheck{
 			CheckType:    "order_size",
 			Passed:       false,
-			CurrentValue: order.Quantity,
+			CurrentValue: order.Quantity.Float64(),
 			LimitValue:   e.limitManager.orderLimits[order.Symbol],
 			Message:      err.Error(),
 			Latency:      time.Since(startTime),
@@ -366,9 +371,9 @@ func (e *RealTimeRiskEngine) checkOrderSizeLimits(order *types.Order) error {
 		limit = e.config.MaxOrderSize
 	}
 
-	if order.Quantity > limit {
+	if order.Quantity.Float64() > limit {
 		atomic.AddInt64(&e.metrics.RejectedOrders, 1)
-		return fmt.Errorf("order size %f exceeds limit %f for symbol %s",
+		return fmt.Errorf("order size %s exceeds limit %f for symbol %s",
 			order.Quantity, limit, order.Symbol)
 	}
 
@@ -383,9 +388,9 @@ func (e *RealTimeRiskEngine) checkPositionLimits(order *types.Order) error {
 	// Calculate new position after order
 	var newQuantity float64
 	if order.Side == types.OrderSideBuy {
-		newQuantity = position.Quantity + order.Quantity
+		newQuantity = position.Quantity + order.Quantity.Float64()
 	} else {
-		newQuantity = position.Quantity - order.Quantity
+		newQuantity = position.Quantity - order.Quantity.Float64()
 	}
 
 	// Check against position limits
@@ -633,15 +633,15 @@ func (s *Service) processTrade(trade *order_matching.Trade) {
 	}
 
 	// Update buy position
-	s.updatePosition(buyOrder.UserID, trade.Symbol, trade.Quantity, trade.Price)
+	s.updatePosition(buyOrder.UserID, trade.Symbol, trade.Quantity.Float64(), trade.Price.Float64())
 
 	// Update sell position
-	s.updatePosition(sellOrder.UserID, trade.Symbol, -trade.Quantity, trade.Price)
+	s.updatePosition(sellOrder.UserID, trade.Symbol, trade.Quantity.Neg().Float64(), trade.Price.Float64())
 
 	// Update market data
 	s.marketDataChan <- MarketDataUpdate{
 		Symbol:    trade.Symbol,
-		Price:     trade.Price,
+		Price:     trade.Price.Float64(),
 		Timestamp: trade.Timestamp,
 	}
 }
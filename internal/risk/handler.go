@@ -2,11 +2,15 @@ package risk
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
 	"github.com/abdoElHodaky/tradSys/proto/risk"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // HandlerParams contains the parameters for creating a risk handler
@@ -15,6 +19,10 @@ type HandlerParams struct {
 
 	Logger     *zap.Logger
 	Repository *repositories.RiskRepository `optional:"true"`
+	Service    *Service                     `optional:"true"`
+	Calculator *Calculator                  `optional:"true"`
+	Stress     *StressEngine                `optional:"true"`
+	Margin     *MarginEngine                `optional:"true"`
 }
 
 // Handler implements the RiskService handler
@@ -22,6 +30,10 @@ type Handler struct {
 	risk.UnimplementedRiskServiceServer
 	logger     *zap.Logger
 	repository *repositories.RiskRepository
+	service    *Service
+	calculator *Calculator
+	stress     *StressEngine
+	margin     *MarginEngine
 }
 
 // NewHandler creates a new risk handler with fx dependency injection
@@ -29,6 +41,10 @@ func NewHandler(p HandlerParams) *Handler {
 	return &Handler{
 		logger:     p.Logger,
 		repository: p.Repository,
+		service:    p.Service,
+		calculator: p.Calculator,
+		stress:     p.Stress,
+		margin:     p.Margin,
 	}
 }
 
@@ -49,44 +65,70 @@ func (h *Handler) ValidateOrder(ctx context.Context, req *risk.ValidateOrderRequ
 	return rsp, nil
 }
 
-// GetAccountRisk implements the RiskService.GetAccountRisk method
+// GetAccountRisk implements the RiskService.GetAccountRisk method. The
+// account's positions are those the risk service holds for the account ID,
+// valued at the last prices it has seen. VaR and Expected Shortfall use the
+// calculator's method, confidence and horizon unless the request overrides
+// them. Margin comes from the margin engine, when there is one; margin
+// levels are equity as a percentage of initial margin.
 func (h *Handler) GetAccountRisk(ctx context.Context, req *risk.AccountRiskRequest) (*risk.AccountRiskResponse, error) {
 	h.logger.Info("GetAccountRisk called",
-		zap.String("account_id", req.AccountId))
+		zap.String("account_id", req.AccountId),
+		zap.String("var_method", req.VarMethod.String()))
 
-	// Implementation would go here
-	// For now, just return placeholder risk data
-	rsp := &risk.AccountRiskResponse{
-		AccountId:        req.AccountId,
-		TotalValue:       100000.0,
-		AvailableMargin:  50000.0,
-		UsedMargin:       25000.0,
-		MarginLevel:      200.0,
-		MarginCallLevel:  120.0,
-		LiquidationLevel: 100.0,
-		DailyPnl:         1500.0,
-		TotalPnl:         5000.0,
-		RiskLevel:        risk.RiskLevel_MEDIUM,
-		Positions: []*risk.Position{
-			{
-				Symbol:        "BTC-USD",
-				Size:          1.5,
-				EntryPrice:    48000.0,
-				CurrentPrice:  50000.0,
-				UnrealizedPnl: 3000.0,
-				RealizedPnl:   1000.0,
-			},
-			{
-				Symbol:        "ETH-USD",
-				Size:          10.0,
-				EntryPrice:    3200.0,
-				CurrentPrice:  3500.0,
-				UnrealizedPnl: 3000.0,
-				RealizedPnl:   2000.0,
-			},
-		},
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if h.service == nil || h.calculator == nil {
+		return nil, status.Error(codes.Unavailable, "risk service unavailable")
+	}
+
+	config := h.calculator.VaRConfig()
+	if method, exists := varMethods[req.VarMethod]; exists {
+		config.Method = method
+	}
+	if req.Confidence != 0 {
+		config.Confidence = req.Confidence
+	}
+	if req.HorizonDays != 0 {
+		config.HorizonDays = int(req.HorizonDays)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	held, err := h.service.GetPositions(ctx, req.AccountId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	positions := make([]*Position, 0, len(held))
+	prices := h.service.LastPrices()
+	for _, position := range held {
+		converted := &Position{
+			UserID:       req.AccountId,
+			Symbol:       position.Symbol,
+			Quantity:     position.Quantity,
+			AveragePrice: position.AveragePrice,
+			RealizedPnL:  position.RealizedPnL,
+			Option:       position.Option,
+		}
+		if position.Option != nil {
+			converted.InstrumentType = "option"
+		}
+		positions = append(positions, converted)
+		if _, exists := prices[position.Symbol]; !exists {
+			prices[position.Symbol] = position.AveragePrice
+		}
+	}
+
+	metrics, err := h.calculator.CalculateAccountRiskWith(ctx, config, req.AccountId, positions, prices)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	rsp := accountRiskToProto(req.AccountId, metrics, positions)
+	if h.margin != nil {
+		marginToProto(rsp, h.margin.AccountMargin(req.AccountId))
+	}
 	return rsp, nil
 }
 
@@ -177,6 +219,185 @@ func (h *Handler) UpdateRiskLimits(ctx context.Context, req *risk.UpdateRiskLimi
 	return rsp, nil
 }
 
+// StressTest implements the RiskService.StressTest method. It runs the
+// named server scenarios followed by those in the request, every server
+// scenario when the request names and defines none.
+func (h *Handler) StressTest(ctx context.Context, req *risk.StressTestRequest) (*risk.StressTestResponse, error) {
+	h.logger.Info("StressTest called",
+		zap.Strings("account_ids", req.AccountIds),
+		zap.Strings("scenario_names", req.ScenarioNames),
+		zap.Int("scenarios", len(req.Scenarios)))
+
+	if h.stress == nil {
+		return nil, status.Error(codes.Unavailable, "stress engine unavailable")
+	}
+
+	var scenarios []StressScenario
+	for _, name := range req.ScenarioNames {
+		scenario, exists := h.stress.Scenario(name)
+		if !exists {
+			return nil, status.Errorf(codes.NotFound, "%s: %s", ErrStressScenarioNotFound, name)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	for _, scenario := range req.Scenarios {
+		scenarios = append(scenarios, stressScenarioFromProto(scenario))
+	}
+	if len(req.ScenarioNames) == 0 && len(req.Scenarios) == 0 {
+		scenarios = h.stress.Scenarios()
+	}
+	if len(scenarios) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no stress scenarios")
+	}
+
+	results, err := h.stress.StressTest(ctx, scenarios, req.AccountIds...)
+	if errors.Is(err, ErrInvalidStressScenario) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	rsp := &risk.StressTestResponse{}
+	for _, result := range results {
+		converted := &risk.StressResult{
+			Scenario:        result.Scenario,
+			Probability:     result.Probability,
+			Pnl:             result.PnL,
+			PnlByAccount:    result.ByAccount,
+			PnlBySymbol:     result.BySymbol,
+			PnlByAssetClass: result.ByAssetClass,
+		}
+		for _, position := range result.Positions {
+			converted.Positions = append(converted.Positions, &risk.StressPnL{
+				AccountId:     position.AccountID,
+				Symbol:        position.Symbol,
+				AssetClass:    position.AssetClass,
+				Value:         position.Value,
+				StressedValue: position.StressedValue,
+				Pnl:           position.PnL,
+			})
+		}
+		rsp.Results = append(rsp.Results, converted)
+	}
+	return rsp, nil
+}
+
+// stressScenarioFromProto converts a proto stress scenario
+func stressScenarioFromProto(scenario *risk.StressScenario) StressScenario {
+	converted := StressScenario{
+		Name:             scenario.Name,
+		Description:      scenario.Description,
+		Probability:      scenario.Probability,
+		PriceShocks:      scenario.PriceShocks,
+		VolatilityShocks: scenario.VolatilityShocks,
+		FXShocks:         scenario.FxShocks,
+		CorrelationShock: scenario.CorrelationShock,
+		Propagate:        scenario.Propagate,
+	}
+	if scenario.ReplayStart != 0 || scenario.ReplayEnd != 0 {
+		converted.Replay = &ReplayWindow{
+			Start: time.UnixMilli(scenario.ReplayStart),
+			End:   time.UnixMilli(scenario.ReplayEnd),
+		}
+	}
+	return converted
+}
+
+// varMethods maps each proto VaR method to the calculator's
+var varMethods = map[risk.VaRMethod]VaRMethod{
+	risk.VaRMethod_VAR_PARAMETRIC:  VaRMethodParametric,
+	risk.VaRMethod_VAR_HISTORICAL:  VaRMethodHistorical,
+	risk.VaRMethod_VAR_MONTE_CARLO: VaRMethodMonteCarlo,
+}
+
+// marginToProto sets the margin fields of an account risk response
+func marginToProto(rsp *risk.AccountRiskResponse, margin *AccountMargin) {
+	rsp.UsedMargin = margin.InitialMargin
+	rsp.AvailableMargin = margin.Excess
+	if margin.InitialMargin > 0 {
+		rsp.MarginLevel = margin.Equity / margin.InitialMargin * 100
+		rsp.MarginCallLevel = 100
+		rsp.LiquidationLevel = margin.MaintenanceMargin / margin.InitialMargin * 100
+	}
+}
+
+// accountRiskToProto converts account risk metrics to their proto response
+func accountRiskToProto(accountID string, metrics *AccountRiskMetrics, positions []*Position) *risk.AccountRiskResponse {
+	rsp := &risk.AccountRiskResponse{
+		AccountId:         accountID,
+		TotalValue:        metrics.TotalMarketValue,
+		TotalPnl:          metrics.TotalUnrealizedPnL,
+		RiskLevel:         riskLevelToProto(metrics.RiskLevel),
+		Var_95:            metrics.PortfolioVaR95,
+		Var_99:            metrics.PortfolioVaR99,
+		Var:               metrics.PortfolioVaR,
+		ExpectedShortfall: metrics.PortfolioES,
+		VarConfidence:     metrics.VaRConfidence,
+		VarHorizonDays:    int32(metrics.VaRHorizonDays),
+	}
+	for method, name := range varMethods {
+		if name == metrics.VaRMethod {
+			rsp.VarMethod = method
+		}
+	}
+
+	realized := make(map[string]float64, len(positions))
+	for _, position := range positions {
+		realized[position.Symbol] = position.RealizedPnL
+		rsp.TotalPnl += position.RealizedPnL
+	}
+	for _, position := range metrics.Positions {
+		rsp.Positions = append(rsp.Positions, &risk.Position{
+			Symbol:            position.Symbol,
+			Size:              position.Quantity,
+			EntryPrice:        position.AveragePrice,
+			CurrentPrice:      position.CurrentPrice,
+			UnrealizedPnl:     position.UnrealizedPnL,
+			RealizedPnl:       realized[position.Symbol],
+			Var:               position.VaR,
+			ComponentVar:      position.ComponentVaR,
+			MarginalVar:       position.MarginalVaR,
+			ComponentEs:       position.ComponentES,
+			Delta:             position.Delta,
+			Gamma:             position.Gamma,
+			Theta:             position.Theta,
+			Vega:              position.Vega,
+			ImpliedVolatility: position.ImpliedVolatility,
+			Underlying:        position.Underlying,
+		})
+	}
+
+	rsp.NetDollarDelta = metrics.NetDollarDelta
+	rsp.NetTheta = metrics.NetTheta
+	rsp.NetVega = metrics.NetVega
+	for _, exposure := range metrics.Greeks {
+		rsp.Greeks = append(rsp.Greeks, &risk.GreekExposure{
+			Underlying:  exposure.Underlying,
+			Delta:       exposure.Delta,
+			DollarDelta: exposure.DollarDelta,
+			Gamma:       exposure.Gamma,
+			Theta:       exposure.Theta,
+			Vega:        exposure.Vega,
+		})
+	}
+	return rsp
+}
+
+// riskLevelToProto maps a risk level to its proto enum
+func riskLevelToProto(level RiskLevel) risk.RiskLevel {
+	switch level {
+	case RiskLevelMedium:
+		return risk.RiskLevel_MEDIUM
+	case RiskLevelHigh:
+		return risk.RiskLevel_HIGH
+	case RiskLevelCritical:
+		return risk.RiskLevel_CRITICAL
+	default:
+		return risk.RiskLevel_LOW
+	}
+}
+
 // getMarginRate returns the margin rate for a given symbol
 func (h *Handler) getMarginRate(symbol string) float64 {
 	// In production, this would come from configuration or database
@@ -234,5 +455,8 @@ func (h *Handler) isOrderAllowed(riskLevel risk.RiskLevel, marginLevel, availabl
 
 // RiskModule provides the risk handler module for fx
 var RiskModule = fx.Options(
+	fx.Provide(NewFxCalculator),
+	fx.Provide(NewFxStressEngine),
+	fx.Provide(NewFxMarginEngine),
 	fx.Provide(NewHandler),
 )
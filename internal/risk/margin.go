@@ -0,0 +1,884 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	riskengine "github.com/abdoElHodaky/tradSys/internal/risk/engine"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Margin actions recorded in the audit trail, with the account as their
+// value
+const (
+	AuditActionMarginCall        = "margin_call"
+	AuditActionMarginCallMet     = "margin_call_met"
+	AuditActionLiquidationOrder  = "liquidation_order"
+	AuditActionLiquidationFailed = "liquidation_failed"
+)
+
+const (
+	// MarginEngineActor is the actor of the actions the margin engine takes
+	MarginEngineActor = "margin_engine"
+	// AuditScopeAccount is the audit scope of margin actions
+	AuditScopeAccount = "account"
+	// LiquidationStrategy is the strategy tag of liquidation orders
+	LiquidationStrategy = "liquidation"
+	// MarginCallTag tags liquidation orders with the margin call they answer
+	MarginCallTag = "margin_call_id"
+)
+
+// MarginRates are the fractions of a position's exposure held as margin
+type MarginRates struct {
+	// Initial is the margin positions are opened with; equity below it
+	// raises a margin call
+	Initial float64 `json:"initial"`
+	// Maintenance is the margin below which positions are liquidated
+	Maintenance float64 `json:"maintenance"`
+}
+
+// LiquidationConfig controls the automatic liquidation of accounts whose
+// equity falls below maintenance margin
+type LiquidationConfig struct {
+	// Enabled turns automatic liquidation on
+	Enabled bool
+	// GracePeriod is how long after its margin call an account below
+	// maintenance has to post collateral before it is liquidated
+	GracePeriod time.Duration
+	// Interval is the least time between two liquidation rounds of an
+	// account, so the fills of one round are booked before the next
+	Interval time.Duration
+}
+
+// MarginConfig holds the margin rates, hedge offsets and liquidation
+// settings of a margin engine
+type MarginConfig struct {
+	// Rates are the margin rates of each asset class
+	Rates map[string]MarginRates
+	// DefaultRates apply to asset classes without rates of their own
+	DefaultRates MarginRates
+	// HedgeOffset is the share of margin credited back on the hedged part
+	// of opposite positions in an underlying or hedge group, from zero for
+	// gross margin to one for margin on the net exposure only
+	HedgeOffset float64
+	// HedgeGroups puts underlyings that hedge each other, such as an index
+	// and its ETF, in one group. Other underlyings are a group of their own.
+	HedgeGroups map[string]string
+	// Liquidation controls automatic liquidation
+	Liquidation LiquidationConfig
+}
+
+// DefaultMarginConfig returns Reg T style equity margin, lower rates for
+// bonds and FX, higher ones for crypto, half the margin of hedged
+// positions offset and liquidation every 30 seconds while below
+// maintenance
+func DefaultMarginConfig() MarginConfig {
+	return MarginConfig{
+		Rates: map[string]MarginRates{
+			string(types.AssetTypeStock):     {Initial: 0.5, Maintenance: 0.25},
+			string(types.AssetTypeETF):       {Initial: 0.5, Maintenance: 0.25},
+			string(types.AssetTypeREIT):      {Initial: 0.5, Maintenance: 0.3},
+			string(types.AssetTypeBond):      {Initial: 0.1, Maintenance: 0.05},
+			string(types.AssetTypeCrypto):    {Initial: 0.5, Maintenance: 0.35},
+			string(types.AssetTypeForex):     {Initial: 0.05, Maintenance: 0.03},
+			string(types.AssetTypeCommodity): {Initial: 0.15, Maintenance: 0.1},
+		},
+		DefaultRates: MarginRates{Initial: 0.5, Maintenance: 0.3},
+		HedgeOffset:  0.5,
+		Liquidation: LiquidationConfig{
+			Enabled:  true,
+			Interval: 30 * time.Second,
+		},
+	}
+}
+
+// Validate checks the configuration can margin accounts
+func (c MarginConfig) Validate() error {
+	if !c.DefaultRates.valid() || c.HedgeOffset < 0 || c.HedgeOffset > 1 ||
+		c.Liquidation.GracePeriod < 0 || c.Liquidation.Interval < 0 {
+		return ErrInvalidMarginConfig
+	}
+	for _, rates := range c.Rates {
+		if !rates.valid() {
+			return ErrInvalidMarginConfig
+		}
+	}
+	return nil
+}
+
+// valid reports whether maintenance margin is positive and at most the
+// initial margin, itself at most the whole exposure
+func (r MarginRates) valid() bool {
+	return r.Maintenance > 0 && r.Maintenance <= r.Initial && r.Initial <= 1
+}
+
+// RatesFor returns the margin rates of a symbol's asset class
+func (c MarginConfig) RatesFor(symbol string) MarginRates {
+	if rates, exists := c.Rates[assetClass(symbol)]; exists {
+		return rates
+	}
+	return c.DefaultRates
+}
+
+// hedgeGroup returns the group an underlying's positions offset within
+func (c MarginConfig) hedgeGroup(underlying string) string {
+	if group, exists := c.HedgeGroups[underlying]; exists {
+		return group
+	}
+	return underlying
+}
+
+// MarginStatus is the standing of an account's equity against its margin
+type MarginStatus string
+
+const (
+	// MarginStatusHealthy means equity covers initial margin
+	MarginStatusHealthy MarginStatus = "healthy"
+	// MarginStatusCall means equity is below initial margin
+	MarginStatusCall MarginStatus = "margin_call"
+	// MarginStatusLiquidation means equity is below maintenance margin
+	MarginStatusLiquidation MarginStatus = "liquidation"
+)
+
+// PositionMargin is the margin a position needs on its own
+type PositionMargin struct {
+	Symbol string `json:"symbol"`
+	// Underlying is the symbol the position's exposure is to, the position's
+	// own for anything but options
+	Underlying string  `json:"underlying"`
+	AssetClass string  `json:"asset_class"`
+	Quantity   float64 `json:"quantity"`
+	// Exposure is the signed value of the position in the reporting
+	// currency, delta-adjusted for options
+	Exposure          float64 `json:"exposure"`
+	UnrealizedPnL     float64 `json:"unrealized_pnl"`
+	InitialMargin     float64 `json:"initial_margin"`
+	MaintenanceMargin float64 `json:"maintenance_margin"`
+}
+
+// AccountMargin is the margin an account needs and the equity covering it
+type AccountMargin struct {
+	AccountID     string  `json:"account_id"`
+	Collateral    float64 `json:"collateral"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+	// Equity is the collateral plus the unrealized PnL of the positions
+	Equity float64 `json:"equity"`
+	// InitialMargin and MaintenanceMargin are the account requirements,
+	// net of the hedge offset
+	InitialMargin     float64 `json:"initial_margin"`
+	MaintenanceMargin float64 `json:"maintenance_margin"`
+	// HedgeOffset is the initial margin credited back on hedged positions
+	HedgeOffset float64 `json:"hedge_offset"`
+	// Excess is the equity above initial margin; a negative excess is the
+	// deficit of a margin call
+	Excess       float64           `json:"excess"`
+	Status       MarginStatus      `json:"status"`
+	Positions    []*PositionMargin `json:"positions"`
+	CalculatedAt time.Time         `json:"calculated_at"`
+}
+
+// MarginCall is raised when an account's equity falls below initial
+// margin, and is met once it recovers
+type MarginCall struct {
+	ID        string    `json:"id"`
+	AccountID string    `json:"account_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	// Deficit is the collateral that brings equity back to initial margin,
+	// as of the last check
+	Deficit           float64 `json:"deficit"`
+	Equity            float64 `json:"equity"`
+	InitialMargin     float64 `json:"initial_margin"`
+	MaintenanceMargin float64 `json:"maintenance_margin"`
+	// Liquidations is the number of liquidation rounds run for the call
+	Liquidations int `json:"liquidations"`
+	// OrderIDs are the liquidation orders placed for the call
+	OrderIDs []string `json:"order_ids,omitempty"`
+	// MetAt is when equity recovered to initial margin, zero while open
+	MetAt time.Time `json:"met_at,omitempty"`
+}
+
+// OrderRouter places the reduce-only orders of liquidations
+type OrderRouter interface {
+	PlaceOrder(ctx context.Context, req *orders.OrderRequest) (order *orders.Order, replayed bool, err error)
+}
+
+// MarginEngine margins the live positions of a book against the
+// collateral of each account. It raises margin calls when equity falls
+// below initial margin and, below maintenance margin, liquidates positions
+// with reduce-only orders until initial margin is covered again.
+type MarginEngine struct {
+	logger          *zap.Logger
+	book            PositionBook
+	router          OrderRouter
+	mu              sync.RWMutex
+	audit           orders.AuditRepository
+	config          MarginConfig
+	optionsConfig   OptionsConfig
+	fxRates         map[string]float64
+	collateral      map[string]float64
+	calls           map[string]*MarginCall
+	lastLiquidation map[string]time.Time
+}
+
+// NewMarginEngine creates a margin engine for the positions of book,
+// liquidating through router
+func NewMarginEngine(book PositionBook, router OrderRouter, logger *zap.Logger) *MarginEngine {
+	return &MarginEngine{
+		logger:          logger,
+		book:            book,
+		router:          router,
+		config:          DefaultMarginConfig(),
+		optionsConfig:   DefaultOptionsConfig(),
+		fxRates:         make(map[string]float64),
+		collateral:      make(map[string]float64),
+		calls:           make(map[string]*MarginCall),
+		lastLiquidation: make(map[string]time.Time),
+	}
+}
+
+// SetConfig sets the margin rates, hedge offsets and liquidation settings
+func (e *MarginEngine) SetConfig(config MarginConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.config = config
+	return nil
+}
+
+// Config returns the margin configuration
+func (e *MarginEngine) Config() MarginConfig {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.config
+}
+
+// SetOptionsConfig sets the rate, dividend yields and default volatility
+// the deltas of option positions are taken at
+func (e *MarginEngine) SetOptionsConfig(config OptionsConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.optionsConfig = config
+	return nil
+}
+
+// SetFXRates sets the value of one unit of each currency in the reporting
+// currency. Currencies without a rate are taken at one.
+func (e *MarginEngine) SetFXRates(rates map[string]float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.fxRates = make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		e.fxRates[currency] = rate
+	}
+}
+
+// SetAuditRepository records every margin call and liquidation through
+// repository
+func (e *MarginEngine) SetAuditRepository(repository orders.AuditRepository) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.audit = repository
+}
+
+// SetCollateral sets the collateral of an account in the reporting
+// currency: its cash, realized PnL included. Accounts without collateral
+// set are margined but never called or liquidated.
+func (e *MarginEngine) SetCollateral(accountID string, amount float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.collateral[accountID] = amount
+}
+
+// AccountMargin returns the margin of an account at the last prices,
+// without raising calls or liquidating
+func (e *MarginEngine) AccountMargin(accountID string) *AccountMargin {
+	positions := e.book.AccountPositions(accountID)[accountID]
+	return e.calculate(accountID, positions, e.book.LastPrices(), time.Now())
+}
+
+// MarginCalls returns the open margin calls, oldest first
+func (e *MarginEngine) MarginCalls() []*MarginCall {
+	e.mu.RLock()
+	calls := make([]*MarginCall, 0, len(e.calls))
+	for _, call := range e.calls {
+		calls = append(calls, call.copy())
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].IssuedAt.Before(calls[j].IssuedAt)
+	})
+	return calls
+}
+
+// MarginCall returns the open margin call of an account
+func (e *MarginEngine) MarginCall(accountID string) (*MarginCall, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	call, exists := e.calls[accountID]
+	if !exists {
+		return nil, false
+	}
+	return call.copy(), true
+}
+
+// OnPrice checks the accounts holding a symbol, or options on it, at a new
+// price of the symbol. It is registered as a price listener of the risk
+// service so margin is watched on every tick.
+func (e *MarginEngine) OnPrice(ctx context.Context, symbol string, price float64) {
+	book := e.book.AccountPositions()
+	exposed := make(map[string][]*riskengine.Position)
+	for account, positions := range book {
+		for _, position := range positions {
+			if position.Symbol == symbol || (position.Option != nil && position.Option.Underlying == symbol) {
+				exposed[account] = positions
+				break
+			}
+		}
+	}
+	if len(exposed) > 0 {
+		e.check(ctx, exposed, e.book.LastPrices())
+	}
+}
+
+// Check margins accounts, every account with positions or an open call
+// when none are given, raising and clearing margin calls and liquidating
+// accounts below maintenance margin. It returns the margin of each account
+// checked, ordered by account.
+func (e *MarginEngine) Check(ctx context.Context, accounts ...string) []*AccountMargin {
+	book := make(map[string][]*riskengine.Position)
+	for account, positions := range e.book.AccountPositions(accounts...) {
+		book[account] = positions
+	}
+	if len(accounts) == 0 {
+		e.mu.RLock()
+		for account := range e.calls {
+			accounts = append(accounts, account)
+		}
+		e.mu.RUnlock()
+	}
+	// Accounts without positions are margined on their collateral alone
+	for _, account := range accounts {
+		if _, exists := book[account]; !exists {
+			book[account] = nil
+		}
+	}
+	return e.check(ctx, book, e.book.LastPrices())
+}
+
+// check margins the positions of each account and acts on the results
+func (e *MarginEngine) check(ctx context.Context, book map[string][]*riskengine.Position, prices map[string]float64) []*AccountMargin {
+	accounts := make([]string, 0, len(book))
+	for account := range book {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	now := time.Now()
+	margins := make([]*AccountMargin, 0, len(accounts))
+	for _, account := range accounts {
+		margin := e.calculate(account, book[account], prices, now)
+		e.enforce(ctx, margin, now)
+		margins = append(margins, margin)
+	}
+	return margins
+}
+
+// calculate margins the positions of an account
+func (e *MarginEngine) calculate(accountID string, positions []*riskengine.Position, prices map[string]float64, now time.Time) *AccountMargin {
+	e.mu.RLock()
+	config := e.config
+	optionsConfig := e.optionsConfig
+	fxRates := e.fxRates
+	collateral := e.collateral[accountID]
+	e.mu.RUnlock()
+
+	margin := &AccountMargin{
+		AccountID:    accountID,
+		Collateral:   collateral,
+		CalculatedAt: now,
+	}
+	for _, position := range positions {
+		positionMargin, err := marginPosition(position, prices, fxRates, config, optionsConfig, now)
+		if err != nil {
+			e.logger.Warn("Failed to margin position",
+				zap.String("account_id", accountID),
+				zap.String("symbol", position.Symbol),
+				zap.Error(err))
+			continue
+		}
+		margin.Positions = append(margin.Positions, positionMargin)
+		margin.UnrealizedPnL += positionMargin.UnrealizedPnL
+	}
+	sort.Slice(margin.Positions, func(i, j int) bool {
+		return margin.Positions[i].Symbol < margin.Positions[j].Symbol
+	})
+
+	margin.Equity = margin.Collateral + margin.UnrealizedPnL
+	margin.InitialMargin, margin.MaintenanceMargin, margin.HedgeOffset = offsetMargin(margin.Positions, config, -1)
+	margin.Excess = margin.Equity - margin.InitialMargin
+	switch {
+	case margin.Equity < margin.MaintenanceMargin:
+		margin.Status = MarginStatusLiquidation
+	case margin.Equity < margin.InitialMargin:
+		margin.Status = MarginStatusCall
+	default:
+		margin.Status = MarginStatusHealthy
+	}
+	return margin
+}
+
+// marginPosition values a position and the margin it needs on its own.
+// Options count at their delta-equivalent exposure to the underlying, and
+// take the margin rates of the underlying.
+func marginPosition(position *riskengine.Position, prices, fxRates map[string]float64, config MarginConfig, optionsConfig OptionsConfig, now time.Time) (*PositionMargin, error) {
+	margin := &PositionMargin{
+		Symbol:     position.Symbol,
+		Underlying: position.Symbol,
+		Quantity:   position.Quantity,
+	}
+
+	price, exists := prices[position.Symbol]
+	if !exists {
+		price = position.MarketPrice
+	}
+	positionCurrency := currency(position.Symbol)
+	if position.Option == nil {
+		if price == 0 {
+			price = position.AveragePrice
+		}
+		margin.Exposure = position.Quantity * price
+		margin.UnrealizedPnL = position.Quantity * (price - position.AveragePrice)
+	} else {
+		contract := position.Option
+		spot, exists := prices[contract.Underlying]
+		if !exists || spot <= 0 {
+			return nil, ErrPriceUnavailable
+		}
+		volatility := position.ImpliedVolatility
+		if volatility <= 0 {
+			volatility = optionsConfig.DefaultVolatility
+		}
+		greeks, err := options.Value(contract, options.Market{
+			Spot:          spot,
+			Rate:          optionsConfig.RiskFreeRate,
+			DividendYield: optionsConfig.DividendYields[contract.Underlying],
+			Volatility:    volatility,
+		}, now)
+		if err != nil {
+			return nil, err
+		}
+		if price == 0 {
+			price = greeks.Price
+		}
+
+		units := contract.Units(position.Quantity)
+		margin.Underlying = contract.Underlying
+		margin.Exposure = greeks.Delta * units * spot
+		margin.UnrealizedPnL = units * (price - position.AveragePrice)
+		if positionCurrency == "" {
+			positionCurrency = currency(contract.Underlying)
+		}
+	}
+
+	rate, exists := fxRates[positionCurrency]
+	if !exists || positionCurrency == "" {
+		rate = 1
+	}
+	margin.Exposure *= rate
+	margin.UnrealizedPnL *= rate
+
+	rates := config.RatesFor(margin.Underlying)
+	margin.AssetClass = assetClass(margin.Underlying)
+	margin.InitialMargin = rates.Initial * math.Abs(margin.Exposure)
+	margin.MaintenanceMargin = rates.Maintenance * math.Abs(margin.Exposure)
+	return margin, nil
+}
+
+// offsetMargin adds up the margin of positions, leaving out the one at
+// skip, crediting back HedgeOffset of the margin on the hedged part of
+// each hedge group: the smaller of its long and short margins on each
+// side. It returns the initial and maintenance margins and the initial
+// margin credited.
+func offsetMargin(positions []*PositionMargin, config MarginConfig, skip int) (initial, maintenance, offset float64) {
+	type sides struct {
+		longInitial, shortInitial, longMaintenance, shortMaintenance float64
+	}
+	groups := make(map[string]*sides)
+	for i, position := range positions {
+		if i == skip {
+			continue
+		}
+		group := config.hedgeGroup(position.Underlying)
+		margin, exists := groups[group]
+		if !exists {
+			margin = &sides{}
+			groups[group] = margin
+		}
+		if position.Exposure >= 0 {
+			margin.longInitial += position.InitialMargin
+			margin.longMaintenance += position.MaintenanceMargin
+		} else {
+			margin.shortInitial += position.InitialMargin
+			margin.shortMaintenance += position.MaintenanceMargin
+		}
+	}
+
+	for _, margin := range groups {
+		initialCredit := config.HedgeOffset * 2 * math.Min(margin.longInitial, margin.shortInitial)
+		maintenanceCredit := config.HedgeOffset * 2 * math.Min(margin.longMaintenance, margin.shortMaintenance)
+		initial += margin.longInitial + margin.shortInitial - initialCredit
+		maintenance += margin.longMaintenance + margin.shortMaintenance - maintenanceCredit
+		offset += initialCredit
+	}
+	return initial, maintenance, offset
+}
+
+// enforce raises, updates and clears the margin call of an account and
+// liquidates it when it is due. Accounts without collateral set are left
+// alone.
+func (e *MarginEngine) enforce(ctx context.Context, margin *AccountMargin, now time.Time) {
+	e.mu.Lock()
+	if _, exists := e.collateral[margin.AccountID]; !exists {
+		e.mu.Unlock()
+		return
+	}
+	config := e.config
+	call, exists := e.calls[margin.AccountID]
+	if margin.Status == MarginStatusHealthy {
+		if exists {
+			delete(e.calls, margin.AccountID)
+			call.MetAt = now
+		}
+		e.mu.Unlock()
+
+		if exists {
+			e.logger.Info("Margin call met",
+				zap.String("account_id", margin.AccountID),
+				zap.String("margin_call_id", call.ID),
+				zap.Float64("equity", margin.Equity))
+			e.recordAudit(ctx, AuditActionMarginCallMet, margin.AccountID, "equity covers initial margin",
+				marginDetails(call, margin))
+		}
+		return
+	}
+
+	issued := !exists
+	if issued {
+		call = &MarginCall{
+			ID:        uuid.New().String(),
+			AccountID: margin.AccountID,
+			IssuedAt:  now,
+		}
+		e.calls[margin.AccountID] = call
+	}
+	call.Deficit = -margin.Excess
+	call.Equity = margin.Equity
+	call.InitialMargin = margin.InitialMargin
+	call.MaintenanceMargin = margin.MaintenanceMargin
+
+	liquidate := margin.Status == MarginStatusLiquidation && config.Liquidation.Enabled &&
+		now.Sub(call.IssuedAt) >= config.Liquidation.GracePeriod &&
+		now.Sub(e.lastLiquidation[margin.AccountID]) >= config.Liquidation.Interval
+	if liquidate {
+		e.lastLiquidation[margin.AccountID] = now
+		call.Liquidations++
+	}
+	snapshot := call.copy()
+	e.mu.Unlock()
+
+	if issued {
+		e.logger.Warn("Margin call issued",
+			zap.String("account_id", margin.AccountID),
+			zap.String("margin_call_id", snapshot.ID),
+			zap.Float64("equity", margin.Equity),
+			zap.Float64("initial_margin", margin.InitialMargin),
+			zap.Float64("deficit", snapshot.Deficit))
+		e.recordAudit(ctx, AuditActionMarginCall, margin.AccountID, "equity below initial margin",
+			marginDetails(snapshot, margin))
+	}
+	if liquidate {
+		e.liquidate(ctx, snapshot, margin, config)
+	}
+}
+
+// liquidate places the reduce-only orders that bring an account back to
+// initial margin and records them on its margin call
+func (e *MarginEngine) liquidate(ctx context.Context, call *MarginCall, margin *AccountMargin, config MarginConfig) {
+	if e.router == nil {
+		e.logger.Error("No order router to liquidate account",
+			zap.String("account_id", margin.AccountID),
+			zap.String("margin_call_id", call.ID))
+		return
+	}
+
+	e.logger.Warn("Liquidating account below maintenance margin",
+		zap.String("account_id", margin.AccountID),
+		zap.String("margin_call_id", call.ID),
+		zap.Int("round", call.Liquidations),
+		zap.Float64("equity", margin.Equity),
+		zap.Float64("maintenance_margin", margin.MaintenanceMargin))
+
+	var placed []string
+	for _, req := range liquidationOrders(call, margin, config) {
+		details := marginDetails(call, margin)
+		details["symbol"] = req.Symbol
+		details["side"] = req.Side
+		details["quantity"] = req.Quantity.String()
+
+		order, _, err := e.router.PlaceOrder(ctx, req)
+		if err != nil {
+			e.logger.Error("Failed to place liquidation order",
+				zap.String("account_id", margin.AccountID),
+				zap.String("symbol", req.Symbol),
+				zap.Error(err))
+			details["error"] = err.Error()
+			e.recordAudit(ctx, AuditActionLiquidationFailed, margin.AccountID, "liquidation order not placed", details)
+			continue
+		}
+		if order.Status == orders.OrderStatusRejected {
+			e.logger.Error("Liquidation order rejected",
+				zap.String("account_id", margin.AccountID),
+				zap.String("order_id", order.ID),
+				zap.String("reason", string(order.RejectReason)))
+			details["order_id"] = order.ID
+			details["reject_reason"] = order.RejectReason
+			e.recordAudit(ctx, AuditActionLiquidationFailed, margin.AccountID, "liquidation order rejected", details)
+			continue
+		}
+
+		placed = append(placed, order.ID)
+		details["order_id"] = order.ID
+		details["status"] = order.Status
+		e.recordAudit(ctx, AuditActionLiquidationOrder, margin.AccountID, "equity below maintenance margin", details)
+	}
+
+	e.mu.Lock()
+	if open, exists := e.calls[margin.AccountID]; exists && open.ID == call.ID {
+		open.OrderIDs = append(open.OrderIDs, placed...)
+	}
+	e.mu.Unlock()
+}
+
+// liquidationOrders picks the reduce-only orders that release the initial
+// margin equity falls short of. Positions are closed largest margin first,
+// each only as far as closing it releases margin, so hedges are kept
+// while they offset, and rounded up to whole lots.
+func liquidationOrders(call *MarginCall, margin *AccountMargin, config MarginConfig) []*orders.OrderRequest {
+	candidates := make([]int, len(margin.Positions))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return margin.Positions[candidates[i]].InitialMargin > margin.Positions[candidates[j]].InitialMargin
+	})
+
+	remaining := margin.InitialMargin - margin.Equity
+	var requests []*orders.OrderRequest
+	for _, i := range candidates {
+		if remaining <= 0 {
+			break
+		}
+		position := margin.Positions[i]
+		fraction, release := closingFraction(margin, config, i, remaining)
+		if release <= 0 || position.Quantity == 0 {
+			continue
+		}
+
+		quantity := types.QuantityFromFloat(position.Symbol, closeQuantity(position.Symbol, math.Abs(position.Quantity), fraction))
+		if !quantity.IsPositive() {
+			continue
+		}
+		remaining -= release
+
+		side := orders.OrderSideSell
+		if position.Quantity < 0 {
+			side = orders.OrderSideBuy
+		}
+		requests = append(requests, &orders.OrderRequest{
+			UserID:        margin.AccountID,
+			ClientOrderID: call.ID + "/" + strconv.Itoa(call.Liquidations) + "/" + position.Symbol,
+			Symbol:        position.Symbol,
+			Side:          side,
+			Type:          orders.OrderTypeMarket,
+			Quantity:      quantity,
+			TimeInForce:   orders.TimeInForceIOC,
+			ReduceOnly:    true,
+			Tags: map[string]string{
+				orders.StrategyTag: LiquidationStrategy,
+				MarginCallTag:      call.ID,
+			},
+		})
+	}
+	return requests
+}
+
+// closingFraction returns the least fraction of the position at index i
+// whose closing releases the needed initial margin, or as much as closing
+// it can, and the margin it releases. The margin released is concave in
+// the fraction closed: it grows until the position's hedge group balances
+// and, past that, stays flat or falls as the offset is lost.
+func closingFraction(margin *AccountMargin, config MarginConfig, i int, needed float64) (fraction, release float64) {
+	positions := append([]*PositionMargin(nil), margin.Positions...)
+	original := margin.Positions[i]
+	releaseAt := func(f float64) float64 {
+		scaled := *original
+		scaled.Exposure *= 1 - f
+		scaled.InitialMargin *= 1 - f
+		scaled.MaintenanceMargin *= 1 - f
+		positions[i] = &scaled
+		initial, _, _ := offsetMargin(positions, config, -1)
+		return margin.InitialMargin - initial
+	}
+
+	// Find the fraction releasing the most, then the least one releasing
+	// what is needed of it
+	low, high := 0.0, 1.0
+	for iteration := 0; iteration < 60; iteration++ {
+		left, right := low+(high-low)/3, high-(high-low)/3
+		if releaseAt(left) < releaseAt(right) {
+			low = left
+		} else {
+			high = right
+		}
+	}
+	best := high
+	if releaseAt(1) >= releaseAt(best) {
+		best = 1
+	}
+	target := math.Min(needed, releaseAt(best))
+	if target <= 0 {
+		return 0, 0
+	}
+
+	low, high = 0, best
+	for iteration := 0; iteration < 60; iteration++ {
+		middle := (low + high) / 2
+		if releaseAt(middle) >= target {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+	return high, target
+}
+
+// closeQuantity returns the part of a position to close, rounded up to the
+// symbol's lot size and at most the whole position
+func closeQuantity(symbol string, quantity, fraction float64) float64 {
+	closed := quantity * fraction
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.LotSize.IsPositive() {
+		lot := instrument.LotSize.Float64()
+		closed = math.Ceil(closed/lot-1e-9) * lot
+	}
+	return math.Min(closed, quantity)
+}
+
+// marginDetails describes a margin call for the audit trail
+func marginDetails(call *MarginCall, margin *AccountMargin) map[string]interface{} {
+	return map[string]interface{}{
+		"margin_call_id":     call.ID,
+		"equity":             margin.Equity,
+		"collateral":         margin.Collateral,
+		"initial_margin":     margin.InitialMargin,
+		"maintenance_margin": margin.MaintenanceMargin,
+		"deficit":            -margin.Excess,
+		"round":              call.Liquidations,
+	}
+}
+
+// recordAudit records a margin action through the audit repository, if one
+// is set
+func (e *MarginEngine) recordAudit(ctx context.Context, action, accountID, reason string, details map[string]interface{}) {
+	e.mu.RLock()
+	audit := e.audit
+	e.mu.RUnlock()
+	if audit == nil {
+		return
+	}
+
+	encoded, _ := json.Marshal(details)
+	record := &db.AuditRecord{
+		ID:      uuid.New().String(),
+		Action:  action,
+		Actor:   MarginEngineActor,
+		Scope:   AuditScopeAccount,
+		Value:   accountID,
+		Reason:  reason,
+		Details: string(encoded),
+	}
+	record.CreatedAt = time.Now()
+
+	if err := audit.CreateAuditRecord(ctx, record); err != nil {
+		e.logger.Error("Failed to record margin action",
+			zap.String("action", action),
+			zap.String("account_id", accountID),
+			zap.Error(err))
+	}
+}
+
+// copy returns a copy of the call safe to hand out
+func (c *MarginCall) copy() *MarginCall {
+	copied := *c
+	copied.OrderIDs = append([]string(nil), c.OrderIDs...)
+	return &copied
+}
+
+// MarginEngineParams contains the parameters for creating a margin engine
+type MarginEngineParams struct {
+	fx.In
+
+	Logger       *zap.Logger
+	Service      *Service                      `optional:"true"`
+	OrderService *orders.OrderService          `optional:"true"`
+	Audit        *repositories.OrderRepository `optional:"true"`
+}
+
+// NewFxMarginEngine creates a margin engine for the positions of the risk
+// service that checks margin on every price the service marks. Liquidation
+// orders go through the order service, which checks reduce-only orders
+// against the risk service's positions. There is no engine without a risk
+// service.
+func NewFxMarginEngine(p MarginEngineParams) *MarginEngine {
+	if p.Service == nil {
+		return nil
+	}
+
+	var router OrderRouter
+	if p.OrderService != nil {
+		router = p.OrderService
+		p.OrderService.SetPositionProvider(p.Service)
+	}
+	engine := NewMarginEngine(p.Service, router, p.Logger)
+	if p.Audit != nil {
+		engine.SetAuditRepository(p.Audit)
+	}
+	p.Service.AddPriceListener(engine.OnPrice)
+	return engine
+}
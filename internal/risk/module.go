@@ -10,25 +10,30 @@ import (
 
 // RiskManagementModule provides the risk management module for the fx application
 var RiskManagementModule = fx.Options(
-	fx.Provide(NewService),
+	fx.Provide(NewFxService),
 )
 
+// ServiceParams contains the parameters for creating a risk management service
+type ServiceParams struct {
+	fx.In
+
+	Lifecycle    fx.Lifecycle
+	Logger       *zap.Logger
+	OrderEngine  *order_matching.Engine
+	OrderService *orders.Service `optional:"true"`
+}
+
 // NewFxService creates a new risk management service for the fx application
-func NewFxService(
-	lifecycle fx.Lifecycle,
-	logger *zap.Logger,
-	orderEngine *order_matching.Engine,
-	orderService *orders.Service,
-) *Service {
-	service := NewService(orderEngine, orderService, logger)
+func NewFxService(p ServiceParams) *Service {
+	service := NewService(p.OrderEngine, p.OrderService, p.Logger)
 
-	lifecycle.Append(fx.Hook{
+	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			logger.Info("Starting risk management service")
+			p.Logger.Info("Starting risk management service")
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			logger.Info("Stopping risk management service")
+			p.Logger.Info("Stopping risk management service")
 			service.Stop()
 			return nil
 		},
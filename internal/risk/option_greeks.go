@@ -0,0 +1,94 @@
+package risk
+
+import (
+	"sort"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+)
+
+// OptionsConfig holds the market inputs option positions are valued with
+// besides the prices of the options and their underlyings
+type OptionsConfig struct {
+	// RiskFreeRate is the continuously compounded annual risk-free rate
+	RiskFreeRate float64
+	// DividendYields is the continuous dividend yield of each underlying
+	DividendYields map[string]float64
+	// DefaultVolatility values options without a price, or whose price
+	// implies no volatility
+	DefaultVolatility float64
+}
+
+// DefaultOptionsConfig returns a 5% risk-free rate, no dividends and a 30%
+// default volatility
+func DefaultOptionsConfig() OptionsConfig {
+	return OptionsConfig{
+		RiskFreeRate:      0.05,
+		DefaultVolatility: 0.3,
+	}
+}
+
+// Validate checks the configuration can value options
+func (c OptionsConfig) Validate() error {
+	if c.DefaultVolatility <= 0 {
+		return ErrInvalidOptionsConfig
+	}
+	for _, yield := range c.DividendYields {
+		if yield < 0 {
+			return ErrInvalidOptionsConfig
+		}
+	}
+	return nil
+}
+
+// optionGreeks values quantity contracts of an option at the volatility
+// its price implies, or the default volatility when it has no price or
+// implies none. It returns the Greeks of the position and the volatility
+// used.
+func optionGreeks(contract *options.Contract, quantity, price, underlyingPrice float64, config OptionsConfig, now time.Time) (options.Greeks, float64, error) {
+	market := options.Market{
+		Spot:          underlyingPrice,
+		Rate:          config.RiskFreeRate,
+		DividendYield: config.DividendYields[contract.Underlying],
+		Volatility:    config.DefaultVolatility,
+	}
+	if price > 0 {
+		if volatility, err := options.ImpliedVolatility(contract, market, price, now); err == nil {
+			market.Volatility = volatility
+		}
+	}
+
+	greeks, err := options.Value(contract, market, now)
+	if err != nil {
+		return options.Greeks{}, 0, err
+	}
+	return greeks.Scale(contract.Units(quantity)), market.Volatility, nil
+}
+
+// aggregateGreeks nets the Greeks of positions by underlying, ordered by
+// underlying
+func aggregateGreeks(positions []*PositionRiskMetrics) []*GreekExposure {
+	byUnderlying := make(map[string]*GreekExposure)
+	var exposures []*GreekExposure
+	for _, position := range positions {
+		if position.Underlying == "" {
+			continue
+		}
+		exposure, exists := byUnderlying[position.Underlying]
+		if !exists {
+			exposure = &GreekExposure{Underlying: position.Underlying}
+			byUnderlying[position.Underlying] = exposure
+			exposures = append(exposures, exposure)
+		}
+		exposure.Delta += position.Delta
+		exposure.DollarDelta += position.Delta * position.UnderlyingPrice
+		exposure.Gamma += position.Gamma
+		exposure.Theta += position.Theta
+		exposure.Vega += position.Vega
+	}
+
+	sort.Slice(exposures, func(i, j int) bool {
+		return exposures[i].Underlying < exposures[j].Underlying
+	})
+	return exposures
+}
@@ -0,0 +1,102 @@
+package options
+
+import "math"
+
+// Binomial values an option years from expiry on a Cox-Ross-Rubinstein
+// tree of steps, exercising American options early wherever that is worth
+// more than holding them. Delta, gamma and theta are read off the first
+// steps of the tree; vega and rho come from revaluing it with the
+// volatility and the rate bumped either way.
+func Binomial(contract *Contract, market Market, years float64, steps int) (Greeks, error) {
+	if years <= 0 {
+		return expired(contract, market.Spot), nil
+	}
+	if steps < 2 {
+		steps = 2
+	}
+
+	levels, err := rollback(contract, market, years, steps)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	dt := years / float64(steps)
+	u := math.Exp(market.Volatility * math.Sqrt(dt))
+	spot := market.Spot
+	up, down := spot*u, spot/u
+	upUp, downDown := spot*u*u, spot/(u*u)
+
+	greeks := Greeks{
+		Price: levels[0][0],
+		Delta: (levels[1][1] - levels[1][0]) / (up - down),
+		Gamma: ((levels[2][2]-levels[2][1])/(upUp-spot) - (levels[2][1]-levels[2][0])/(spot-downDown)) / ((upUp - downDown) / 2),
+		Theta: (levels[2][1] - levels[0][0]) / (2 * dt) / daysPerYear,
+	}
+
+	volBump := math.Min(0.01, market.Volatility/2)
+	higher, lower := market, market
+	higher.Volatility += volBump
+	lower.Volatility -= volBump
+	if greeks.Vega, err = bumped(contract, higher, lower, years, steps, volBump); err != nil {
+		return Greeks{}, err
+	}
+
+	const rateBump = 0.0001
+	higher, lower = market, market
+	higher.Rate += rateBump
+	lower.Rate -= rateBump
+	if greeks.Rho, err = bumped(contract, higher, lower, years, steps, rateBump); err != nil {
+		return Greeks{}, err
+	}
+	return greeks, nil
+}
+
+// bumped returns the change in value per percentage point between the
+// markets higher and lower, bump either side of the valuation's
+func bumped(contract *Contract, higher, lower Market, years float64, steps int, bump float64) (float64, error) {
+	high, err := rollback(contract, higher, years, steps)
+	if err != nil {
+		return 0, err
+	}
+	low, err := rollback(contract, lower, years, steps)
+	if err != nil {
+		return 0, err
+	}
+	return (high[0][0] - low[0][0]) / (2 * bump) / 100, nil
+}
+
+// rollback values the option back through the tree, returning the node
+// values of its first three levels
+func rollback(contract *Contract, market Market, years float64, steps int) ([3][]float64, error) {
+	var levels [3][]float64
+
+	dt := years / float64(steps)
+	u := math.Exp(market.Volatility * math.Sqrt(dt))
+	d := 1 / u
+	p := (math.Exp((market.Rate-market.DividendYield)*dt) - d) / (u - d)
+	if !(p > 0 && p < 1) {
+		// The drift outruns the volatility; the tree would need negative
+		// probabilities
+		return levels, ErrInvalidMarket
+	}
+	discount := math.Exp(-market.Rate * dt)
+	american := contract.Style == American
+
+	values := make([]float64, steps+1)
+	for j := 0; j <= steps; j++ {
+		values[j] = contract.intrinsic(market.Spot * math.Pow(u, float64(2*j-steps)))
+	}
+	for i := steps - 1; i >= 0; i-- {
+		for j := 0; j <= i; j++ {
+			value := discount * (p*values[j+1] + (1-p)*values[j])
+			if american {
+				value = math.Max(value, contract.intrinsic(market.Spot*math.Pow(u, float64(2*j-i))))
+			}
+			values[j] = value
+		}
+		if i < len(levels) {
+			levels[i] = append([]float64(nil), values[:i+1]...)
+		}
+	}
+	return levels, nil
+}
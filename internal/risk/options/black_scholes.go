@@ -0,0 +1,37 @@
+package options
+
+import "math"
+
+// BlackScholes values a European option years from expiry with the
+// Black-Scholes-Merton model, which treats the dividend yield as a
+// continuous carry
+func BlackScholes(contract *Contract, market Market, years float64) Greeks {
+	if years <= 0 {
+		return expired(contract, market.Spot)
+	}
+
+	spot, strike, vol := market.Spot, contract.Strike, market.Volatility
+	sqrtT := math.Sqrt(years)
+	d1 := (math.Log(spot/strike) + (market.Rate-market.DividendYield+vol*vol/2)*years) / (vol * sqrtT)
+	d2 := d1 - vol*sqrtT
+	carry := math.Exp(-market.DividendYield * years)
+	discount := math.Exp(-market.Rate * years)
+
+	greeks := Greeks{
+		Gamma: carry * normalPDF(d1) / (spot * vol * sqrtT),
+		Vega:  spot * carry * normalPDF(d1) * sqrtT / 100,
+	}
+	decay := -spot * carry * normalPDF(d1) * vol / (2 * sqrtT)
+	if contract.Type == Call {
+		greeks.Price = spot*carry*normalCDF(d1) - strike*discount*normalCDF(d2)
+		greeks.Delta = carry * normalCDF(d1)
+		greeks.Theta = (decay - market.Rate*strike*discount*normalCDF(d2) + market.DividendYield*spot*carry*normalCDF(d1)) / daysPerYear
+		greeks.Rho = strike * years * discount * normalCDF(d2) / 100
+	} else {
+		greeks.Price = strike*discount*normalCDF(-d2) - spot*carry*normalCDF(-d1)
+		greeks.Delta = -carry * normalCDF(-d1)
+		greeks.Theta = (decay + market.Rate*strike*discount*normalCDF(-d2) - market.DividendYield*spot*carry*normalCDF(-d1)) / daysPerYear
+		greeks.Rho = -strike * years * discount * normalCDF(-d2) / 100
+	}
+	return greeks
+}
@@ -0,0 +1,90 @@
+package options
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// minVolatility and maxVolatility bound the implied volatilities solved
+	minVolatility = 0.01
+	maxVolatility = 5.0
+	// priceTolerance is how close to the quote a solved price must come
+	priceTolerance = 1e-6
+	// maxIterations bounds the search for an implied volatility
+	maxIterations = 100
+)
+
+// ImpliedVolatility solves the volatility at which the contract is worth
+// price at now, valuing it the way Value does. The volatility of market is
+// ignored. ErrNoImpliedVolatility means the price is outside what any
+// volatility between 1% and 500% gives, e.g. below intrinsic value.
+func ImpliedVolatility(contract *Contract, market Market, price float64, now time.Time) (float64, error) {
+	if err := contract.Validate(); err != nil {
+		return 0, err
+	}
+	market.Volatility = minVolatility
+	if err := market.Validate(); err != nil {
+		return 0, err
+	}
+	years := contract.YearsToExpiry(now)
+	if years == 0 || price <= 0 {
+		return 0, ErrNoImpliedVolatility
+	}
+
+	value := func(volatility float64) (Greeks, error) {
+		trial := market
+		trial.Volatility = volatility
+		if earlyExerciseWorthless(contract, trial) {
+			return BlackScholes(contract, trial, years), nil
+		}
+		return Binomial(contract, trial, years, DefaultSteps)
+	}
+
+	// A tree too coarse for the lowest volatility leaves the intrinsic
+	// value as the lower bound of the price
+	lowest := contract.intrinsic(market.Spot)
+	if greeks, err := value(minVolatility); err == nil {
+		lowest = greeks.Price
+	}
+	highest, err := value(maxVolatility)
+	if err != nil {
+		return 0, err
+	}
+	if price < lowest-priceTolerance || price > highest.Price+priceTolerance {
+		return 0, ErrNoImpliedVolatility
+	}
+
+	// Newton's method on vega, falling back to bisection whenever a step
+	// would leave the bracket around the solution
+	low, high := minVolatility, maxVolatility
+	volatility := 0.3
+	for i := 0; i < maxIterations; i++ {
+		greeks, err := value(volatility)
+		if err != nil {
+			low = volatility
+			volatility = (low + high) / 2
+			continue
+		}
+
+		diff := greeks.Price - price
+		if math.Abs(diff) < priceTolerance {
+			return volatility, nil
+		}
+		if diff > 0 {
+			high = volatility
+		} else {
+			low = volatility
+		}
+
+		next := volatility - diff/(greeks.Vega*100)
+		if greeks.Vega <= 0 || !(next > low && next < high) {
+			next = (low + high) / 2
+		}
+		volatility = next
+		if high-low < 1e-10 {
+			return volatility, nil
+		}
+	}
+	return 0, ErrNoImpliedVolatility
+}
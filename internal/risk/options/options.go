@@ -0,0 +1,195 @@
+// Package options values listed options and their Greeks. European
+// options are priced with Black-Scholes-Merton, American options on a
+// Cox-Ross-Rubinstein binomial tree, and implied volatilities are solved
+// from market quotes with either model.
+package options
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// OptionType is the right an option gives its holder
+type OptionType string
+
+const (
+	// Call is the right to buy the underlying at the strike
+	Call OptionType = "call"
+	// Put is the right to sell the underlying at the strike
+	Put OptionType = "put"
+)
+
+// ExerciseStyle is when an option may be exercised
+type ExerciseStyle string
+
+const (
+	// European options are exercised at expiry only
+	European ExerciseStyle = "european"
+	// American options may be exercised at any time until expiry
+	American ExerciseStyle = "american"
+)
+
+const (
+	// DefaultSteps is the number of steps of the binomial tree valuing
+	// American options
+	DefaultSteps = 200
+	// daysPerYear converts times to expiry to years and theta to days
+	daysPerYear = 365.0
+)
+
+// Error definitions
+var (
+	ErrInvalidContract     = errors.New("invalid option contract")
+	ErrInvalidMarket       = errors.New("invalid option market inputs")
+	ErrNoImpliedVolatility = errors.New("no volatility matches the option price")
+)
+
+// Contract describes a listed option
+type Contract struct {
+	// Underlying is the symbol of the underlying instrument
+	Underlying string `json:"underlying"`
+	// Type is call or put
+	Type OptionType `json:"type"`
+	// Style is European or American exercise
+	Style ExerciseStyle `json:"style"`
+	// Strike is the exercise price
+	Strike float64 `json:"strike"`
+	// Expiry is the time the option expires
+	Expiry time.Time `json:"expiry"`
+	// Multiplier is the number of underlying units per contract, 1 if unset
+	Multiplier float64 `json:"multiplier,omitempty"`
+}
+
+// Validate checks the contract can be valued
+func (c *Contract) Validate() error {
+	if c == nil || c.Underlying == "" || c.Strike <= 0 || c.Expiry.IsZero() || c.Multiplier < 0 {
+		return ErrInvalidContract
+	}
+	if c.Type != Call && c.Type != Put {
+		return ErrInvalidContract
+	}
+	if c.Style != European && c.Style != American {
+		return ErrInvalidContract
+	}
+	return nil
+}
+
+// YearsToExpiry returns the time left until expiry in years of 365 days,
+// zero once the option has expired
+func (c *Contract) YearsToExpiry(now time.Time) float64 {
+	return math.Max(c.Expiry.Sub(now).Hours()/24/daysPerYear, 0)
+}
+
+// Units returns the number of underlying units quantity contracts cover
+func (c *Contract) Units(quantity float64) float64 {
+	if c.Multiplier == 0 {
+		return quantity
+	}
+	return quantity * c.Multiplier
+}
+
+// intrinsic returns the value of exercising the option at spot
+func (c *Contract) intrinsic(spot float64) float64 {
+	if c.Type == Call {
+		return math.Max(spot-c.Strike, 0)
+	}
+	return math.Max(c.Strike-spot, 0)
+}
+
+// Market holds the inputs of a valuation other than the contract. Rates
+// and yields are continuously compounded and annual, like the volatility.
+type Market struct {
+	// Spot is the price of the underlying
+	Spot float64
+	// Rate is the risk-free interest rate
+	Rate float64
+	// DividendYield is the dividend yield, or foreign rate, of the underlying
+	DividendYield float64
+	// Volatility is the volatility of the underlying
+	Volatility float64
+}
+
+// Validate checks the inputs can value an option
+func (m Market) Validate() error {
+	if m.Spot <= 0 || m.Volatility <= 0 || math.IsNaN(m.Rate) || math.IsNaN(m.DividendYield) {
+		return ErrInvalidMarket
+	}
+	return nil
+}
+
+// Greeks holds the value of one option and its sensitivities. Theta is
+// per calendar day, vega per volatility point and rho per percentage
+// point of the rate.
+type Greeks struct {
+	Price float64 `json:"price"`
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+// Scale returns the Greeks of units underlying units of the option, e.g.
+// Units(quantity) of a position. Delta becomes the underlying units the
+// position is equivalent to and gamma their change per unit of spot.
+func (g Greeks) Scale(units float64) Greeks {
+	return Greeks{
+		Price: g.Price * units,
+		Delta: g.Delta * units,
+		Gamma: g.Gamma * units,
+		Theta: g.Theta * units,
+		Vega:  g.Vega * units,
+		Rho:   g.Rho * units,
+	}
+}
+
+// Value prices the contract and its Greeks at now: European options with
+// Black-Scholes-Merton, American options on a binomial tree of
+// DefaultSteps. An expired option is worth its intrinsic value.
+func Value(contract *Contract, market Market, now time.Time) (Greeks, error) {
+	if err := contract.Validate(); err != nil {
+		return Greeks{}, err
+	}
+	if err := market.Validate(); err != nil {
+		return Greeks{}, err
+	}
+
+	years := contract.YearsToExpiry(now)
+	if years == 0 {
+		return expired(contract, market.Spot), nil
+	}
+	if earlyExerciseWorthless(contract, market) {
+		return BlackScholes(contract, market, years), nil
+	}
+	return Binomial(contract, market, years, DefaultSteps)
+}
+
+// earlyExerciseWorthless reports whether an option is worth no more than
+// its European counterpart: European options, and American calls on an
+// underlying paying no dividend
+func earlyExerciseWorthless(contract *Contract, market Market) bool {
+	return contract.Style == European || (contract.Type == Call && market.DividendYield <= 0)
+}
+
+// expired returns the Greeks of an option at expiry
+func expired(contract *Contract, spot float64) Greeks {
+	greeks := Greeks{Price: contract.intrinsic(spot)}
+	switch {
+	case contract.Type == Call && spot > contract.Strike:
+		greeks.Delta = 1
+	case contract.Type == Put && spot < contract.Strike:
+		greeks.Delta = -1
+	}
+	return greeks
+}
+
+// normalCDF is the standard normal cumulative distribution function
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normalPDF is the standard normal density
+func normalPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
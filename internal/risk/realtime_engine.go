@@ -277,7 +277,7 @@ func (e *RealTimeRiskEngine) PreTradeCheck(order *types.Order) (*RiskCheck, erro
 		return &RiskCheck{
 			CheckType:    "order_size",
 			Passed:       false,
-			CurrentValue: order.Quantity,
+			CurrentValue: order.Quantity.Float64(),
 			LimitValue:   e.limitManager.orderLimits[order.Symbol],
 			Message:      err.Error(),
 			Latency:      time.Since(startTime),
@@ -363,9 +363,9 @@ func (e *RealTimeRiskEngine) checkOrderSizeLimits(order *types.Order) error {
 		limit = e.config.MaxOrderSize
 	}
 
-	if order.Quantity > limit {
+	if order.Quantity.Float64() > limit {
 		atomic.AddInt64(&e.metrics.RejectedOrders, 1)
-		return fmt.Errorf("order size %f exceeds limit %f for symbol %s",
+		return fmt.Errorf("order size %s exceeds limit %f for symbol %s",
 			order.Quantity, limit, order.Symbol)
 	}
 
@@ -380,9 +380,9 @@ func (e *RealTimeRiskEngine) checkPositionLimits(order *types.Order) error {
 	// Calculate new position after order
 	var newQuantity float64
 	if order.Side == types.OrderSideBuy {
-		newQuantity = position.Quantity + order.Quantity
+		newQuantity = position.Quantity + order.Quantity.Float64()
 	} else {
-		newQuantity = position.Quantity - order.Quantity
+		newQuantity = position.Quantity - order.Quantity.Float64()
 	}
 
 	// Check against position limits
@@ -9,6 +9,7 @@ import (
 	"github.com/abdoElHodaky/tradSys/internal/core/matching"
 	"github.com/abdoElHodaky/tradSys/internal/orders"
 	riskengine "github.com/abdoElHodaky/tradSys/internal/risk/engine"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
 	"github.com/google/uuid"
 	"github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
@@ -88,8 +89,19 @@ type Service struct {
 	riskBatchChan chan RiskOperation
 	// Market data channel for price updates
 	marketDataChan chan MarketDataUpdate
+	// Last price of each symbol
+	prices map[string]float64
+	// Contract of each option symbol
+	contracts map[string]*options.Contract
+	// Inputs option positions are valued with
+	optionsConfig OptionsConfig
+	// Listeners called with each price positions are marked at
+	priceListeners []PriceListener
 }
 
+// PriceListener is called with each price the service marks positions at
+type PriceListener func(ctx context.Context, symbol string, price float64)
+
 // MarketDataUpdate represents a market data update
 type MarketDataUpdate struct {
 	// Symbol is the trading symbol
@@ -117,6 +129,9 @@ func NewService(orderEngine *order_matching.Engine, orderService *orders.Service
 		cancel:          cancel,
 		riskBatchChan:   make(chan RiskOperation, 1000),
 		marketDataChan:  make(chan MarketDataUpdate, 1000),
+		prices:          make(map[string]float64),
+		contracts:       make(map[string]*options.Contract),
+		optionsConfig:   DefaultOptionsConfig(),
 	}
 
 	// Start batch processor
@@ -223,6 +238,7 @@ func (s *Service) processUpdatePositionBatch(ops []RiskOperation) {
 				AveragePrice:   0,
 				UnrealizedPnL:  0,
 				RealizedPnL:    0,
+				Option:         s.contracts[symbol],
 				LastUpdateTime: time.Now(),
 			}
 			userPositions[symbol] = position
@@ -261,11 +277,8 @@ func (s *Service) processUpdatePositionBatch(ops []RiskOperation) {
 					position.AveragePrice = 0
 				}
 
-				// Update last updated time
-				position.LastUpdateTime = time.Now()
-
-				// Update position in cache
-				s.PositionCache.Set(userID+":"+symbol, position, cache.DefaultExpiration)
+				// Revalue the position, which also updates the cache
+				s.markPosition(userID, position, time.Now())
 			}
 		}
 
@@ -474,6 +487,7 @@ func (s *Service) processMarketData() {
 		case update := <-s.marketDataChan:
 			// Update unrealized PnL for all positions in this symbol
 			s.updateUnrealizedPnL(update.Symbol, update.Price)
+			s.notifyPrice(update.Symbol, update.Price)
 
 			// Check circuit breakers
 			s.checkCircuitBreaker(update.Symbol, update.Price, update.Timestamp)
@@ -481,24 +495,161 @@ func (s *Service) processMarketData() {
 	}
 }
 
-// updateUnrealizedPnL updates the unrealized PnL for all positions in a symbol
+// updateUnrealizedPnL records the price of a symbol and revalues the
+// positions in it, along with the option positions it underlies
 func (s *Service) updateUnrealizedPnL(symbol string, price float64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.prices[symbol] = price
+	now := time.Now()
 	for userID, userPositions := range s.Positions {
-		position, exists := userPositions[symbol]
-		if exists && position.Quantity != 0 {
-			// Calculate unrealized PnL
-			position.UnrealizedPnL = position.Quantity * (price - position.AveragePrice)
-			position.LastUpdateTime = time.Now()
-
-			// Update position in cache
-			s.PositionCache.Set(userID+":"+symbol, position, cache.DefaultExpiration)
+		for _, position := range userPositions {
+			if position.Quantity == 0 {
+				continue
+			}
+			if position.Symbol == symbol || (position.Option != nil && position.Option.Underlying == symbol) {
+				s.markPosition(userID, position, now)
+			}
 		}
 	}
 }
 
+// markPosition revalues a position at the last prices: its market price,
+// unrealized PnL and Greeks. An option position is valued once its
+// underlying has a price. The caller must hold the lock.
+func (s *Service) markPosition(userID string, position *riskengine.Position, now time.Time) {
+	if price, exists := s.prices[position.Symbol]; exists {
+		position.MarketPrice = price
+		position.UnrealizedPnL = position.Quantity * (price - position.AveragePrice)
+	}
+
+	if position.Option == nil {
+		position.Delta = position.Quantity
+	} else if underlyingPrice, exists := s.prices[position.Option.Underlying]; exists {
+		greeks, volatility, err := optionGreeks(position.Option, position.Quantity, position.MarketPrice, underlyingPrice, s.optionsConfig, now)
+		if err != nil {
+			s.logger.Warn("Failed to value option position",
+				zap.String("user_id", userID),
+				zap.String("symbol", position.Symbol),
+				zap.Error(err))
+		} else {
+			position.ImpliedVolatility = volatility
+			position.Delta = greeks.Delta
+			position.Gamma = greeks.Gamma
+			position.Vega = greeks.Vega
+			position.Theta = greeks.Theta
+		}
+	}
+
+	position.LastUpdateTime = now
+	s.PositionCache.Set(userID+":"+position.Symbol, position, cache.DefaultExpiration)
+}
+
+// RegisterOption records the contract of an option symbol, so positions in
+// it carry their Greeks
+func (s *Service) RegisterOption(symbol string, contract *options.Contract) error {
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contracts[symbol] = contract
+	now := time.Now()
+	for userID, userPositions := range s.Positions {
+		if position, exists := userPositions[symbol]; exists {
+			position.Option = contract
+			s.markPosition(userID, position, now)
+		}
+	}
+	return nil
+}
+
+// SetOptionsConfig sets the rate, dividend yields and default volatility
+// option positions are valued with
+func (s *Service) SetOptionsConfig(config OptionsConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.optionsConfig = config
+	return nil
+}
+
+// AccountPositions returns copies of the positions of the accounts by
+// account, those of every account when none are given
+func (s *Service) AccountPositions(accounts ...string) map[string][]*riskengine.Position {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(accounts) == 0 {
+		for userID := range s.Positions {
+			accounts = append(accounts, userID)
+		}
+	}
+
+	positions := make(map[string][]*riskengine.Position, len(accounts))
+	for _, userID := range accounts {
+		for _, position := range s.Positions[userID] {
+			if position.Quantity == 0 {
+				continue
+			}
+			copied := *position
+			positions[userID] = append(positions[userID], &copied)
+		}
+	}
+	return positions
+}
+
+// AddPriceListener calls listener with every price positions are marked
+// at, once the positions are revalued
+func (s *Service) AddPriceListener(listener PriceListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.priceListeners = append(s.priceListeners, listener)
+}
+
+// notifyPrice calls the price listeners with a new price
+func (s *Service) notifyPrice(symbol string, price float64) {
+	s.mu.RLock()
+	listeners := s.priceListeners
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(s.ctx, symbol, price)
+	}
+}
+
+// NetPosition returns the quantity a user holds in a symbol, negative when
+// short
+func (s *Service) NetPosition(userID, symbol string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if position, exists := s.Positions[userID][symbol]; exists {
+		return position.Quantity
+	}
+	return 0
+}
+
+// LastPrices returns the last price of each symbol
+func (s *Service) LastPrices() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prices := make(map[string]float64, len(s.prices))
+	for symbol, price := range s.prices {
+		prices[symbol] = price
+	}
+	return prices
+}
+
 // checkCircuitBreakers periodically checks circuit breakers
 func (s *Service) checkCircuitBreakers() {
 	ticker := time.NewTicker(1 * time.Second)
@@ -597,15 +748,15 @@ func (s *Service) processTrade(trade *order_matching.Trade) {
 	}
 
 	// Update buy position
-	s.updatePosition(buyOrder.UserID, trade.Symbol, trade.Quantity, trade.Price)
+	s.updatePosition(buyOrder.UserID, trade.Symbol, trade.Quantity.Float64(), trade.Price.Float64())
 
 	// Update sell position
-	s.updatePosition(sellOrder.UserID, trade.Symbol, -trade.Quantity, trade.Price)
+	s.updatePosition(sellOrder.UserID, trade.Symbol, trade.Quantity.Neg().Float64(), trade.Price.Float64())
 
 	// Update market data
 	s.marketDataChan <- MarketDataUpdate{
 		Symbol:    trade.Symbol,
-		Price:     trade.Price,
+		Price:     trade.Price.Float64(),
 		Timestamp: trade.Timestamp,
 	}
 }
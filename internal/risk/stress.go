@@ -0,0 +1,704 @@
+package risk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	riskengine "github.com/abdoElHodaky/tradSys/internal/risk/engine"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// AnyShock keys the shock applied to symbols without one of their own
+	// or of their asset class
+	AnyShock = "*"
+	// AssetClassOption is the asset class stress results report option
+	// positions under
+	AssetClassOption = "OPTION"
+	// AssetClassUnclassified is the asset class of symbols missing from
+	// the instrument registry
+	AssetClassUnclassified = "UNCLASSIFIED"
+	// DefaultStressScenariosPath is where the stress scenarios are loaded
+	// from at startup
+	DefaultStressScenariosPath = "config/stress_scenarios.yaml"
+)
+
+// StressScenario describes market moves to revalue positions under. Price
+// and volatility shocks are looked up by symbol, then asset class, then
+// AnyShock; a symbol matching none of them does not move unless the
+// scenario replays a window or propagates the moves it makes.
+type StressScenario struct {
+	// Name identifies the scenario
+	Name string `yaml:"name"`
+	// Description explains the scenario
+	Description string `yaml:"description,omitempty"`
+	// Probability is the estimated probability of the scenario
+	Probability float64 `yaml:"probability,omitempty"`
+	// PriceShocks are relative price moves, e.g. -0.3 for a 30% fall
+	PriceShocks map[string]float64 `yaml:"price_shocks,omitempty"`
+	// VolatilityShocks scale the volatility options are valued at, keyed by
+	// underlying, e.g. 2 to double it
+	VolatilityShocks map[string]float64 `yaml:"volatility_shocks,omitempty"`
+	// FXShocks are relative moves of currencies against the reporting
+	// currency, e.g. -0.2 for a 20% devaluation
+	FXShocks map[string]float64 `yaml:"fx_shocks,omitempty"`
+	// CorrelationShock moves the correlations propagation uses towards one
+	// when positive, towards zero when negative
+	CorrelationShock float64 `yaml:"correlation_shock,omitempty"`
+	// Propagate moves the symbols the scenario does not shock with those it
+	// does, by the covariance of their stored returns
+	Propagate bool `yaml:"propagate,omitempty"`
+	// Replay applies the price moves of a historical window to symbols
+	// without a shock of their own
+	Replay *ReplayWindow `yaml:"replay,omitempty"`
+}
+
+// ReplayWindow is a historical window whose price moves a scenario replays
+type ReplayWindow struct {
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+}
+
+// Validate checks the scenario can be run
+func (s *StressScenario) Validate() error {
+	if s.Name == "" || s.Probability < 0 || s.Probability > 1 {
+		return ErrInvalidStressScenario
+	}
+	if s.CorrelationShock < -1 || s.CorrelationShock > 1 {
+		return ErrInvalidStressScenario
+	}
+	for _, shock := range s.PriceShocks {
+		if shock <= -1 {
+			return ErrInvalidStressScenario
+		}
+	}
+	for _, shock := range s.VolatilityShocks {
+		if shock <= 0 {
+			return ErrInvalidStressScenario
+		}
+	}
+	for _, shock := range s.FXShocks {
+		if shock <= -1 {
+			return ErrInvalidStressScenario
+		}
+	}
+	if s.Replay != nil && !s.Replay.Start.Before(s.Replay.End) {
+		return ErrInvalidStressScenario
+	}
+	return nil
+}
+
+// ParseStressScenarios reads scenarios from YAML holding a scenarios list
+func ParseStressScenarios(data []byte) ([]StressScenario, error) {
+	var file struct {
+		Scenarios []StressScenario `yaml:"scenarios"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+	for i := range file.Scenarios {
+		if err := file.Scenarios[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return file.Scenarios, nil
+}
+
+// LoadStressScenarios reads scenarios from a YAML file
+func LoadStressScenarios(path string) ([]StressScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStressScenarios(data)
+}
+
+// StressPnL is the P&L of one position under a scenario. Values are in the
+// reporting currency.
+type StressPnL struct {
+	AccountID     string  `json:"account_id"`
+	Symbol        string  `json:"symbol"`
+	AssetClass    string  `json:"asset_class"`
+	Value         float64 `json:"value"`
+	StressedValue float64 `json:"stressed_value"`
+	PnL           float64 `json:"pnl"`
+}
+
+// StressResult is the P&L of the positions stressed under a scenario, in
+// total and broken down by account, symbol and asset class
+type StressResult struct {
+	Scenario     string             `json:"scenario"`
+	Probability  float64            `json:"probability"`
+	PnL          float64            `json:"pnl"`
+	ByAccount    map[string]float64 `json:"by_account"`
+	BySymbol     map[string]float64 `json:"by_symbol"`
+	ByAssetClass map[string]float64 `json:"by_asset_class"`
+	Positions    []*StressPnL       `json:"positions"`
+}
+
+// PositionBook holds the live positions of accounts
+type PositionBook interface {
+	// AccountPositions returns the positions of the accounts by account,
+	// those of every account when none are given
+	AccountPositions(accounts ...string) map[string][]*riskengine.Position
+	// LastPrices returns the last price of each symbol
+	LastPrices() map[string]float64
+}
+
+// StressEngine revalues the live positions of a book under stress
+// scenarios
+type StressEngine struct {
+	logger        *zap.Logger
+	book          PositionBook
+	mu            sync.RWMutex
+	history       PriceHistory
+	scenarios     []StressScenario
+	fxRates       map[string]float64
+	optionsConfig OptionsConfig
+	returnsConfig VaRConfig
+}
+
+// NewStressEngine creates a stress engine for the positions of book
+func NewStressEngine(book PositionBook, logger *zap.Logger) *StressEngine {
+	return &StressEngine{
+		logger:        logger,
+		book:          book,
+		fxRates:       make(map[string]float64),
+		optionsConfig: DefaultOptionsConfig(),
+		returnsConfig: DefaultVaRConfig(),
+	}
+}
+
+// SetPriceHistory sets the price history replays and propagation read
+func (e *StressEngine) SetPriceHistory(history PriceHistory) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.history = history
+}
+
+// SetFXRates sets the value of one unit of each currency in the reporting
+// currency. Currencies without a rate are taken at one.
+func (e *StressEngine) SetFXRates(rates map[string]float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.fxRates = make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		e.fxRates[currency] = rate
+	}
+}
+
+// SetOptionsConfig sets the rate, dividend yields and default volatility
+// option positions are valued with
+func (e *StressEngine) SetOptionsConfig(config OptionsConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.optionsConfig = config
+	return nil
+}
+
+// AddScenarios validates scenarios and keeps them for RunScenarios,
+// replacing any of the same name
+func (e *StressEngine) AddScenarios(scenarios ...StressScenario) error {
+	for i := range scenarios {
+		if err := scenarios[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, scenario := range scenarios {
+		replaced := false
+		for i := range e.scenarios {
+			if e.scenarios[i].Name == scenario.Name {
+				e.scenarios[i] = scenario
+				replaced = true
+			}
+		}
+		if !replaced {
+			e.scenarios = append(e.scenarios, scenario)
+		}
+	}
+	return nil
+}
+
+// Scenarios returns the kept scenarios in the order they were added
+func (e *StressEngine) Scenarios() []StressScenario {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return append([]StressScenario(nil), e.scenarios...)
+}
+
+// Scenario returns the kept scenario of a name
+func (e *StressEngine) Scenario(name string) (StressScenario, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, scenario := range e.scenarios {
+		if scenario.Name == name {
+			return scenario, true
+		}
+	}
+	return StressScenario{}, false
+}
+
+// RunScenarios runs the kept scenarios of names, all of them when no names
+// are given, against the positions of accounts
+func (e *StressEngine) RunScenarios(ctx context.Context, names []string, accounts ...string) ([]*StressResult, error) {
+	if len(names) == 0 {
+		return e.StressTest(ctx, e.Scenarios(), accounts...)
+	}
+
+	scenarios := make([]StressScenario, 0, len(names))
+	for _, name := range names {
+		scenario, exists := e.Scenario(name)
+		if !exists {
+			return nil, ErrStressScenarioNotFound
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return e.StressTest(ctx, scenarios, accounts...)
+}
+
+// StressTest revalues the live positions of accounts, every account when
+// none are given, under each scenario. Option positions are revalued at
+// their shocked underlying price and volatility, and every position is
+// converted to the reporting currency at its shocked FX rate.
+func (e *StressEngine) StressTest(ctx context.Context, scenarios []StressScenario, accounts ...string) ([]*StressResult, error) {
+	for i := range scenarios {
+		if err := scenarios[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	e.mu.RLock()
+	history := e.history
+	fxRates := e.fxRates
+	optionsConfig := e.optionsConfig
+	returnsConfig := e.returnsConfig
+	e.mu.RUnlock()
+
+	book := e.book.AccountPositions(accounts...)
+	prices := e.book.LastPrices()
+	now := time.Now()
+
+	// The symbols whose prices move: those held, and the underlyings of the
+	// options held
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, positions := range book {
+		for _, position := range positions {
+			symbol := position.Symbol
+			if position.Option != nil {
+				symbol = position.Option.Underlying
+			}
+			if !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	sort.Strings(symbols)
+
+	results := make([]*StressResult, 0, len(scenarios))
+	for i := range scenarios {
+		scenario := &scenarios[i]
+		moves := e.priceMoves(ctx, scenario, symbols, history, returnsConfig, now)
+
+		result := &StressResult{
+			Scenario:     scenario.Name,
+			Probability:  scenario.Probability,
+			ByAccount:    make(map[string]float64),
+			BySymbol:     make(map[string]float64),
+			ByAssetClass: make(map[string]float64),
+		}
+		for account, positions := range book {
+			for _, position := range positions {
+				pnl, err := stressPosition(scenario, position, prices, moves, fxRates, optionsConfig, now)
+				if err != nil {
+					e.logger.Warn("Failed to stress position",
+						zap.String("scenario", scenario.Name),
+						zap.String("account_id", account),
+						zap.String("symbol", position.Symbol),
+						zap.Error(err))
+					continue
+				}
+				pnl.AccountID = account
+				result.Positions = append(result.Positions, pnl)
+				result.PnL += pnl.PnL
+				result.ByAccount[account] += pnl.PnL
+				result.BySymbol[pnl.Symbol] += pnl.PnL
+				result.ByAssetClass[pnl.AssetClass] += pnl.PnL
+			}
+		}
+		sort.Slice(result.Positions, func(i, j int) bool {
+			if result.Positions[i].AccountID != result.Positions[j].AccountID {
+				return result.Positions[i].AccountID < result.Positions[j].AccountID
+			}
+			return result.Positions[i].Symbol < result.Positions[j].Symbol
+		})
+
+		e.logger.Debug("Stress scenario run",
+			zap.String("scenario", scenario.Name),
+			zap.Int("positions", len(result.Positions)),
+			zap.Float64("pnl", result.PnL))
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// priceMoves returns the relative price move of each symbol under a
+// scenario: its own shock, else its replayed move, else the shock of its
+// asset class or AnyShock, else the move propagated from the others
+func (e *StressEngine) priceMoves(ctx context.Context, scenario *StressScenario, symbols []string, history PriceHistory, config VaRConfig, now time.Time) map[string]float64 {
+	moves := make(map[string]float64, len(symbols))
+	var unshocked []string
+	for _, symbol := range symbols {
+		if shock, exists := scenario.PriceShocks[symbol]; exists {
+			moves[symbol] = shock
+			continue
+		}
+		if scenario.Replay != nil && history != nil {
+			move, err := replayMove(ctx, history, config.Interval, symbol, scenario.Replay)
+			if err == nil {
+				moves[symbol] = move
+				continue
+			}
+			e.logger.Warn("No price history to replay",
+				zap.String("scenario", scenario.Name),
+				zap.String("symbol", symbol),
+				zap.Error(err))
+		}
+		if shock, exists := lookupShock(scenario.PriceShocks, symbol); exists {
+			moves[symbol] = shock
+			continue
+		}
+		unshocked = append(unshocked, symbol)
+	}
+
+	if !scenario.Propagate || history == nil || len(unshocked) == 0 || len(moves) == 0 {
+		return moves
+	}
+
+	// Symbols without stored returns would leave no bars in common, so only
+	// those with history drive or follow the others
+	var drivers []string
+	for _, symbol := range symbols {
+		if _, exists := moves[symbol]; exists {
+			drivers = append(drivers, symbol)
+		}
+	}
+	drivers = withHistory(ctx, history, config, drivers, now)
+	unshocked = withHistory(ctx, history, config, unshocked, now)
+	if len(drivers) == 0 || len(unshocked) == 0 {
+		return moves
+	}
+	series, err := loadReturns(ctx, history, config, append(append([]string(nil), drivers...), unshocked...), now)
+	if err == nil {
+		var propagated []float64
+		propagated, err = propagate(series.covariance(), drivers, moves, scenario.CorrelationShock)
+		for i, symbol := range unshocked {
+			if err == nil {
+				moves[symbol] = propagated[i]
+			}
+		}
+	}
+	if err != nil {
+		e.logger.Warn("Failed to propagate stress moves",
+			zap.String("scenario", scenario.Name),
+			zap.Strings("symbols", unshocked),
+			zap.Error(err))
+	}
+	return moves
+}
+
+// withHistory returns the symbols with bars in the lookback window
+func withHistory(ctx context.Context, history PriceHistory, config VaRConfig, symbols []string, now time.Time) []string {
+	var kept []string
+	for _, symbol := range symbols {
+		bars, err := history.GetOHLCVBySymbolAndTimeRange(ctx, symbol, config.Interval, now.Add(-config.Lookback), now)
+		if err == nil && len(bars) > 0 {
+			kept = append(kept, symbol)
+		}
+	}
+	return kept
+}
+
+// lookupShock returns the shock of a symbol's asset class, or AnyShock
+func lookupShock(shocks map[string]float64, symbol string) (float64, bool) {
+	if shock, exists := shocks[assetClass(symbol)]; exists {
+		return shock, true
+	}
+	shock, exists := shocks[AnyShock]
+	return shock, exists
+}
+
+// assetClass returns the asset type of a registered instrument
+func assetClass(symbol string) string {
+	if instrument, exists := types.Instruments.Get(symbol); exists && instrument.AssetType != "" {
+		return string(instrument.AssetType)
+	}
+	return AssetClassUnclassified
+}
+
+// currency returns the trading currency of a registered instrument
+func currency(symbol string) string {
+	if instrument, exists := types.Instruments.Get(symbol); exists {
+		return instrument.Currency
+	}
+	return ""
+}
+
+// replayMove returns the relative move of a symbol's price between the
+// first and last bars of a window
+func replayMove(ctx context.Context, history PriceHistory, interval, symbol string, window *ReplayWindow) (float64, error) {
+	bars, err := history.GetOHLCVBySymbolAndTimeRange(ctx, symbol, interval, window.Start, window.End)
+	if err != nil {
+		return 0, err
+	}
+
+	var first, last float64
+	var firstAt, lastAt time.Time
+	for _, bar := range bars {
+		price := bar.Close
+		if price == 0 {
+			price = bar.Price
+		}
+		if price <= 0 {
+			continue
+		}
+		if first == 0 || bar.Timestamp.Before(firstAt) {
+			first, firstAt = price, bar.Timestamp
+		}
+		if last == 0 || bar.Timestamp.After(lastAt) {
+			last, lastAt = price, bar.Timestamp
+		}
+	}
+	if first == 0 || !lastAt.After(firstAt) {
+		return 0, ErrInsufficientHistory
+	}
+	return last/first - 1, nil
+}
+
+// propagate returns the expected moves of the symbols after the drivers in
+// cov given the drivers' moves, E[others | drivers] of jointly normal
+// returns, with the correlations shocked first
+func propagate(cov [][]float64, drivers []string, moves map[string]float64, correlationShock float64) ([]float64, error) {
+	stressed := shockCorrelations(cov, correlationShock)
+	d := len(drivers)
+	n := len(stressed)
+
+	driverCov := make([][]float64, d)
+	driverMoves := make([]float64, d)
+	for i, symbol := range drivers {
+		driverCov[i] = append([]float64(nil), stressed[i][:d]...)
+		driverMoves[i] = moves[symbol]
+	}
+	weights, err := solve(driverCov, driverMoves)
+	if err != nil {
+		return nil, err
+	}
+
+	propagated := make([]float64, n-d)
+	for i := d; i < n; i++ {
+		for j := 0; j < d; j++ {
+			propagated[i-d] += stressed[i][j] * weights[j]
+		}
+	}
+	return propagated, nil
+}
+
+// shockCorrelations returns cov with its correlations moved towards one
+// by a positive shock, or towards zero by a negative one, keeping the
+// volatilities
+func shockCorrelations(cov [][]float64, shock float64) [][]float64 {
+	n := len(cov)
+	stressed := make([][]float64, n)
+	for i := range cov {
+		stressed[i] = make([]float64, n)
+		for j := range cov {
+			if i == j || cov[i][i] <= 0 || cov[j][j] <= 0 {
+				stressed[i][j] = cov[i][j]
+				continue
+			}
+			scale := math.Sqrt(cov[i][i] * cov[j][j])
+			correlation := cov[i][j] / scale
+			if shock >= 0 {
+				correlation += shock * (1 - correlation)
+			} else {
+				correlation *= 1 + shock
+			}
+			stressed[i][j] = correlation * scale
+		}
+	}
+	return stressed
+}
+
+// solve solves a x = b by Gaussian elimination with partial pivoting
+func solve(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range a {
+		m[i] = append(append([]float64(nil), a[i]...), b[i])
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-18 {
+			return nil, ErrInsufficientHistory
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := m[row][n]
+		for k := row + 1; k < n; k++ {
+			sum -= m[row][k] * x[k]
+		}
+		x[row] = sum / m[row][row]
+	}
+	return x, nil
+}
+
+// stressPosition values a position before and after a scenario's moves
+func stressPosition(scenario *StressScenario, position *riskengine.Position, prices, moves, fxRates map[string]float64, config OptionsConfig, now time.Time) (*StressPnL, error) {
+	pnl := &StressPnL{Symbol: position.Symbol}
+	var value, stressed float64
+	var positionCurrency string
+
+	if position.Option == nil {
+		price, exists := prices[position.Symbol]
+		if !exists {
+			price = position.MarketPrice
+		}
+		if price == 0 {
+			price = position.AveragePrice
+		}
+		pnl.AssetClass = assetClass(position.Symbol)
+		positionCurrency = currency(position.Symbol)
+		value = position.Quantity * price
+		stressed = value * (1 + moves[position.Symbol])
+	} else {
+		contract := position.Option
+		spot, exists := prices[contract.Underlying]
+		if !exists || spot <= 0 {
+			return nil, ErrPriceUnavailable
+		}
+		volatility := position.ImpliedVolatility
+		if volatility <= 0 {
+			volatility = config.DefaultVolatility
+		}
+		volatilityShock, exists := scenario.VolatilityShocks[contract.Underlying]
+		if !exists {
+			if volatilityShock, exists = lookupShock(scenario.VolatilityShocks, contract.Underlying); !exists {
+				volatilityShock = 1
+			}
+		}
+
+		market := options.Market{
+			Spot:          spot,
+			Rate:          config.RiskFreeRate,
+			DividendYield: config.DividendYields[contract.Underlying],
+			Volatility:    volatility,
+		}
+		before, err := options.Value(contract, market, now)
+		if err != nil {
+			return nil, err
+		}
+		market.Spot = spot * (1 + moves[contract.Underlying])
+		market.Volatility = volatility * volatilityShock
+		after, err := options.Value(contract, market, now)
+		if err != nil {
+			return nil, err
+		}
+
+		units := contract.Units(position.Quantity)
+		pnl.AssetClass = AssetClassOption
+		positionCurrency = currency(position.Symbol)
+		if positionCurrency == "" {
+			positionCurrency = currency(contract.Underlying)
+		}
+		value = before.Price * units
+		stressed = after.Price * units
+	}
+
+	rate, exists := fxRates[positionCurrency]
+	if !exists || positionCurrency == "" {
+		rate = 1
+	}
+	pnl.Value = value * rate
+	pnl.StressedValue = stressed * rate * (1 + scenario.FXShocks[positionCurrency])
+	pnl.PnL = pnl.StressedValue - pnl.Value
+	return pnl, nil
+}
+
+// StressEngineParams contains the parameters for creating a stress engine
+type StressEngineParams struct {
+	fx.In
+
+	Logger     *zap.Logger
+	Service    *Service                           `optional:"true"`
+	MarketData *repositories.MarketDataRepository `optional:"true"`
+}
+
+// NewFxStressEngine creates a stress engine for the positions of the risk
+// service, with the scenarios of DefaultStressScenariosPath when the file
+// exists. There is no engine without a risk service.
+func NewFxStressEngine(p StressEngineParams) (*StressEngine, error) {
+	if p.Service == nil {
+		return nil, nil
+	}
+
+	engine := NewStressEngine(p.Service, p.Logger)
+	if p.MarketData != nil {
+		engine.SetPriceHistory(p.MarketData)
+	}
+
+	scenarios, err := LoadStressScenarios(DefaultStressScenariosPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		p.Logger.Info("No stress scenarios file", zap.String("path", DefaultStressScenariosPath))
+		return engine, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.AddScenarios(scenarios...); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
@@ -3,6 +3,8 @@ package risk
 import (
 	"errors"
 	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
 )
 
 // RiskLevel represents the risk level of an operation
@@ -37,17 +39,19 @@ const (
 
 // Position represents a trading position
 type Position struct {
-	ID             string    `json:"id"`
-	UserID         string    `json:"user_id"`
-	Symbol         string    `json:"symbol"`
-	Quantity       float64   `json:"quantity"`
-	AveragePrice   float64   `json:"average_price"`
-	MarketValue    float64   `json:"market_value"`
-	UnrealizedPnL  float64   `json:"unrealized_pnl"`
-	RealizedPnL    float64   `json:"realized_pnl"`
-	InstrumentType string    `json:"instrument_type"` // "stock", "option", "future", etc.
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID              string            `json:"id"`
+	UserID          string            `json:"user_id"`
+	Symbol          string            `json:"symbol"`
+	Quantity        float64           `json:"quantity"`
+	AveragePrice    float64           `json:"average_price"`
+	MarketValue     float64           `json:"market_value"`
+	UnrealizedPnL   float64           `json:"unrealized_pnl"`
+	RealizedPnL     float64           `json:"realized_pnl"`
+	InstrumentType  string            `json:"instrument_type"`            // "stock", "option", "future", etc.
+	Option          *options.Contract `json:"option,omitempty"`           // contract of an option position
+	UnderlyingPrice float64           `json:"underlying_price,omitempty"` // underlying price when not among the prices given
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
 }
 
 // RemainingQuantity returns the remaining quantity of the position
@@ -78,10 +82,18 @@ type PositionRiskMetrics struct {
 	VaR95                 float64   `json:"var_95"`
 	VaR99                 float64   `json:"var_99"`
 	ExpectedShortfall     float64   `json:"expected_shortfall"`
+	VaR                   float64   `json:"var,omitempty"`
+	ComponentVaR          float64   `json:"component_var,omitempty"`
+	MarginalVaR           float64   `json:"marginal_var,omitempty"`
+	ComponentES           float64   `json:"component_es,omitempty"`
 	Delta                 float64   `json:"delta,omitempty"`
 	Gamma                 float64   `json:"gamma,omitempty"`
 	Theta                 float64   `json:"theta,omitempty"`
 	Vega                  float64   `json:"vega,omitempty"`
+	Rho                   float64   `json:"rho,omitempty"`
+	Underlying            string    `json:"underlying,omitempty"`
+	UnderlyingPrice       float64   `json:"underlying_price,omitempty"`
+	ImpliedVolatility     float64   `json:"implied_volatility,omitempty"`
 	RiskLevel             RiskLevel `json:"risk_level"`
 	CalculatedAt          time.Time `json:"calculated_at"`
 }
@@ -94,13 +106,34 @@ type AccountRiskMetrics struct {
 	TotalMarketValue           float64                  `json:"total_market_value"`
 	PortfolioVaR95             float64                  `json:"portfolio_var_95"`
 	PortfolioVaR99             float64                  `json:"portfolio_var_99"`
+	PortfolioVaR               float64                  `json:"portfolio_var"`
+	PortfolioES                float64                  `json:"portfolio_expected_shortfall"`
+	VaRMethod                  VaRMethod                `json:"var_method"`
+	VaRConfidence              float64                  `json:"var_confidence"`
+	VaRHorizonDays             int                      `json:"var_horizon_days"`
 	ConcentrationRisk          float64                  `json:"concentration_risk"`
 	CorrelationRisk            float64                  `json:"correlation_risk"`
+	NetDollarDelta             float64                  `json:"net_dollar_delta"`
+	NetTheta                   float64                  `json:"net_theta"`
+	NetVega                    float64                  `json:"net_vega"`
+	Greeks                     []*GreekExposure         `json:"greeks,omitempty"`
 	RiskLevel                  RiskLevel                `json:"risk_level"`
 	Positions                  []*PositionRiskMetrics   `json:"positions"`
 	CalculatedAt               time.Time                `json:"calculated_at"`
 }
 
+// GreekExposure represents the net Greeks of an account in one underlying.
+// Delta is in units of the underlying, theta per day and vega per
+// volatility point.
+type GreekExposure struct {
+	Underlying  string  `json:"underlying"`
+	Delta       float64 `json:"delta"`
+	DollarDelta float64 `json:"dollar_delta"`
+	Gamma       float64 `json:"gamma"`
+	Theta       float64 `json:"theta"`
+	Vega        float64 `json:"vega"`
+}
+
 // OrderRiskMetrics represents risk metrics for an order
 type OrderRiskMetrics struct {
 	OrderID               string    `json:"order_id"`
@@ -133,4 +166,11 @@ var (
 	ErrRiskLimitNotFound   = errors.New("risk limit not found")
 	ErrInvalidRiskLevel    = errors.New("invalid risk level")
 	ErrRiskCheckFailed     = errors.New("risk check failed")
+	ErrInsufficientHistory = errors.New("insufficient price history")
+	ErrInvalidVaRConfig    = errors.New("invalid VaR configuration")
+	ErrInvalidOptionsConfig = errors.New("invalid options configuration")
+	ErrInvalidStressScenario = errors.New("invalid stress scenario")
+	ErrStressScenarioNotFound = errors.New("stress scenario not found")
+	ErrPriceUnavailable = errors.New("price unavailable")
+	ErrInvalidMarginConfig = errors.New("invalid margin configuration")
 )
@@ -0,0 +1,502 @@
+package risk
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+)
+
+// VaRMethod selects how Value at Risk is estimated
+type VaRMethod string
+
+const (
+	// VaRMethodParametric assumes normally distributed returns with the
+	// covariance observed in the price history
+	VaRMethodParametric VaRMethod = "parametric"
+	// VaRMethodHistorical replays the returns observed in the price history
+	VaRMethodHistorical VaRMethod = "historical"
+	// VaRMethodMonteCarlo simulates correlated normal returns with the
+	// covariance observed in the price history
+	VaRMethodMonteCarlo VaRMethod = "monte_carlo"
+)
+
+// IsValid reports whether the method is known
+func (m VaRMethod) IsValid() bool {
+	switch m {
+	case VaRMethodParametric, VaRMethodHistorical, VaRMethodMonteCarlo:
+		return true
+	}
+	return false
+}
+
+// VaRConfig configures Value at Risk and Expected Shortfall estimates
+type VaRConfig struct {
+	// Method is how the loss distribution is built
+	Method VaRMethod
+	// Confidence is the confidence level, such as 0.99
+	Confidence float64
+	// HorizonDays is the holding period; one-day returns are scaled by its
+	// square root
+	HorizonDays int
+	// Lookback is how far back the price history is read
+	Lookback time.Duration
+	// Interval is the OHLCV bar interval of the price history
+	Interval string
+	// MinObservations is the fewest returns an estimate is made from
+	MinObservations int
+	// Simulations is the number of Monte Carlo scenarios
+	Simulations int
+	// Seed seeds the Monte Carlo scenarios, so estimates are repeatable
+	Seed int64
+}
+
+// DefaultVaRConfig returns a one-day 99% historical simulation over a year
+// of daily bars
+func DefaultVaRConfig() VaRConfig {
+	return VaRConfig{
+		Method:          VaRMethodHistorical,
+		Confidence:      0.99,
+		HorizonDays:     1,
+		Lookback:        365 * 24 * time.Hour,
+		Interval:        "1d",
+		MinObservations: 30,
+		Simulations:     10000,
+		Seed:            1,
+	}
+}
+
+// Validate checks the configuration
+func (c VaRConfig) Validate() error {
+	if !c.Method.IsValid() {
+		return ErrInvalidVaRConfig
+	}
+	if c.Confidence <= 0 || c.Confidence >= 1 || c.HorizonDays < 1 || c.Lookback <= 0 {
+		return ErrInvalidVaRConfig
+	}
+	if c.Method == VaRMethodMonteCarlo && c.Simulations < 1 {
+		return ErrInvalidVaRConfig
+	}
+	return nil
+}
+
+// PriceHistory reads stored OHLCV bars, such as the market data repository
+type PriceHistory interface {
+	GetOHLCVBySymbolAndTimeRange(ctx context.Context, symbol string, interval string, start, end time.Time) ([]*db.MarketData, error)
+}
+
+// PositionVaR is the share of a portfolio's risk carried by one position
+type PositionVaR struct {
+	// Symbol is the position's symbol
+	Symbol string `json:"symbol"`
+	// Exposure is the signed market value of the position
+	Exposure float64 `json:"exposure"`
+	// VaR is the position's VaR held on its own
+	VaR float64 `json:"var"`
+	// ExpectedShortfall is the position's Expected Shortfall held on its own
+	ExpectedShortfall float64 `json:"expected_shortfall"`
+	// ComponentVaR is the position's contribution to the portfolio VaR; the
+	// components add up to the portfolio VaR
+	ComponentVaR float64 `json:"component_var"`
+	// MarginalVaR is the change in portfolio VaR per unit of exposure added
+	// to the position
+	MarginalVaR float64 `json:"marginal_var"`
+	// ComponentES is the position's contribution to the portfolio Expected
+	// Shortfall
+	ComponentES float64 `json:"component_es"`
+}
+
+// VaRResult is a portfolio Value at Risk estimate
+type VaRResult struct {
+	Method            VaRMethod      `json:"method"`
+	Confidence        float64        `json:"confidence"`
+	HorizonDays       int            `json:"horizon_days"`
+	Observations      int            `json:"observations"`
+	VaR               float64        `json:"var"`
+	ExpectedShortfall float64        `json:"expected_shortfall"`
+	Positions         []*PositionVaR `json:"positions"`
+}
+
+// returnSeries holds one-period returns of several symbols observed at the
+// same times
+type returnSeries struct {
+	symbols []string
+	// returns holds one row per observation and one column per symbol
+	returns [][]float64
+}
+
+// loadReturns reads the price history of symbols and returns their simple
+// returns between the bars all of them have
+func loadReturns(ctx context.Context, history PriceHistory, config VaRConfig, symbols []string, now time.Time) (*returnSeries, error) {
+	start := now.Add(-config.Lookback)
+	closes := make([]map[int64]float64, len(symbols))
+	var common []int64
+	for i, symbol := range symbols {
+		bars, err := history.GetOHLCVBySymbolAndTimeRange(ctx, symbol, config.Interval, start, now)
+		if err != nil {
+			return nil, err
+		}
+
+		closes[i] = make(map[int64]float64, len(bars))
+		for _, bar := range bars {
+			price := bar.Close
+			if price == 0 {
+				price = bar.Price
+			}
+			if price > 0 {
+				closes[i][bar.Timestamp.UnixNano()] = price
+			}
+		}
+
+		if i == 0 {
+			for at := range closes[i] {
+				common = append(common, at)
+			}
+			continue
+		}
+		shared := common[:0]
+		for _, at := range common {
+			if _, exists := closes[i][at]; exists {
+				shared = append(shared, at)
+			}
+		}
+		common = shared
+	}
+	sort.Slice(common, func(i, j int) bool { return common[i] < common[j] })
+
+	series := &returnSeries{symbols: symbols}
+	for t := 1; t < len(common); t++ {
+		row := make([]float64, len(symbols))
+		for i := range symbols {
+			row[i] = closes[i][common[t]]/closes[i][common[t-1]] - 1
+		}
+		series.returns = append(series.returns, row)
+	}
+	if len(series.returns) < config.MinObservations || len(series.returns) < 2 {
+		return nil, ErrInsufficientHistory
+	}
+	return series, nil
+}
+
+// columns returns a series with the given columns of s, in order. A
+// column may be taken more than once.
+func (s *returnSeries) columns(columns []int) *returnSeries {
+	picked := &returnSeries{
+		symbols: make([]string, len(columns)),
+		returns: make([][]float64, len(s.returns)),
+	}
+	for i, column := range columns {
+		picked.symbols[i] = s.symbols[column]
+	}
+	for t, row := range s.returns {
+		picked.returns[t] = make([]float64, len(columns))
+		for i, column := range columns {
+			picked.returns[t][i] = row[column]
+		}
+	}
+	return picked
+}
+
+// covariance returns the sample covariance matrix of the returns
+func (s *returnSeries) covariance() [][]float64 {
+	n := len(s.symbols)
+	observations := float64(len(s.returns))
+
+	means := make([]float64, n)
+	for _, row := range s.returns {
+		for i, r := range row {
+			means[i] += r / observations
+		}
+	}
+
+	cov := make([][]float64, n)
+	for i := range cov {
+		cov[i] = make([]float64, n)
+	}
+	for _, row := range s.returns {
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				cov[i][j] += (row[i] - means[i]) * (row[j] - means[j]) / (observations - 1)
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			cov[i][j] = cov[j][i]
+		}
+	}
+	return cov
+}
+
+// averageCorrelation returns the mean absolute correlation between the
+// symbols, a measure of how little the portfolio diversifies
+func averageCorrelation(cov [][]float64) float64 {
+	var total float64
+	var pairs int
+	for i := range cov {
+		for j := i + 1; j < len(cov); j++ {
+			if cov[i][i] > 0 && cov[j][j] > 0 {
+				total += math.Abs(cov[i][j] / math.Sqrt(cov[i][i]*cov[j][j]))
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+// valueAtRisk estimates the VaR and Expected Shortfall of holding exposures,
+// the signed market value of each symbol of the series, at each confidence
+// level
+func valueAtRisk(series *returnSeries, exposures []float64, config VaRConfig, confidences ...float64) []*VaRResult {
+	scale := math.Sqrt(float64(config.HorizonDays))
+	cov := series.covariance()
+
+	results := make([]*VaRResult, 0, len(confidences))
+	if config.Method == VaRMethodParametric {
+		for _, confidence := range confidences {
+			results = append(results, parametricVaR(series.symbols, exposures, cov, confidence, scale))
+		}
+	} else {
+		var losses [][]float64
+		if config.Method == VaRMethodMonteCarlo {
+			losses = simulatedLosses(exposures, cov, config.Simulations, config.Seed, scale)
+		} else {
+			losses = historicalLosses(series, exposures, scale)
+		}
+		for _, confidence := range confidences {
+			results = append(results, scenarioVaR(series.symbols, exposures, losses, confidence))
+		}
+	}
+
+	for _, result := range results {
+		result.Method = config.Method
+		result.HorizonDays = config.HorizonDays
+		result.Observations = len(series.returns)
+	}
+	return results
+}
+
+// parametricVaR is the VaR of normal returns with covariance cov. The VaR
+// is homogeneous in the exposures, so its components follow from the
+// gradient.
+func parametricVaR(symbols []string, exposures []float64, cov [][]float64, confidence, scale float64) *VaRResult {
+	z := normalQuantile(confidence)
+	tail := normalDensity(z) / (1 - confidence)
+
+	covExposure := make([]float64, len(exposures))
+	var variance float64
+	for i := range exposures {
+		for j := range exposures {
+			covExposure[i] += cov[i][j] * exposures[j]
+		}
+		variance += exposures[i] * covExposure[i]
+	}
+	sigma := math.Sqrt(math.Max(variance, 0))
+
+	result := &VaRResult{
+		Confidence:        confidence,
+		VaR:               z * sigma * scale,
+		ExpectedShortfall: tail * sigma * scale,
+	}
+	for i, symbol := range symbols {
+		standalone := math.Abs(exposures[i]) * math.Sqrt(cov[i][i]) * scale
+		position := &PositionVaR{
+			Symbol:            symbol,
+			Exposure:          exposures[i],
+			VaR:               z * standalone,
+			ExpectedShortfall: tail * standalone,
+		}
+		if sigma > 0 {
+			position.MarginalVaR = z * covExposure[i] / sigma * scale
+			position.ComponentVaR = exposures[i] * position.MarginalVaR
+			position.ComponentES = tail * exposures[i] * covExposure[i] / sigma * scale
+		}
+		result.Positions = append(result.Positions, position)
+	}
+	return result
+}
+
+// historicalLosses returns the loss of each position in each observed
+// period
+func historicalLosses(series *returnSeries, exposures []float64, scale float64) [][]float64 {
+	losses := make([][]float64, len(series.returns))
+	for k, row := range series.returns {
+		losses[k] = make([]float64, len(exposures))
+		for i, r := range row {
+			losses[k][i] = -exposures[i] * r * scale
+		}
+	}
+	return losses
+}
+
+// simulatedLosses returns the loss of each position in scenarios drawn
+// from a normal distribution with covariance cov
+func simulatedLosses(exposures []float64, cov [][]float64, simulations int, seed int64, scale float64) [][]float64 {
+	lower := cholesky(cov)
+	random := rand.New(rand.NewSource(seed))
+
+	n := len(exposures)
+	draws := make([]float64, n)
+	losses := make([][]float64, simulations)
+	for k := range losses {
+		for i := range draws {
+			draws[i] = random.NormFloat64()
+		}
+		losses[k] = make([]float64, n)
+		for i := 0; i < n; i++ {
+			var r float64
+			for j := 0; j <= i; j++ {
+				r += lower[i][j] * draws[j]
+			}
+			losses[k][i] = -exposures[i] * r * scale
+		}
+	}
+	return losses
+}
+
+// scenarioVaR reads the VaR and Expected Shortfall off the portfolio
+// losses of the scenarios. Component VaR averages the position losses of
+// the scenarios nearest the VaR quantile, scaled to add up to the VaR;
+// component ES averages the position losses of the tail.
+func scenarioVaR(symbols []string, exposures []float64, losses [][]float64, confidence float64) *VaRResult {
+	n := len(losses)
+	totals := make([]float64, n)
+	for k, scenario := range losses {
+		for _, loss := range scenario {
+			totals[k] += loss
+		}
+	}
+	order := make([]int, n)
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool { return totals[order[a]] < totals[order[b]] })
+
+	cutoff := quantileIndex(n, confidence)
+	result := &VaRResult{
+		Confidence: confidence,
+		VaR:        totals[order[cutoff]],
+	}
+	tail := order[cutoff:]
+	for _, k := range tail {
+		result.ExpectedShortfall += totals[k] / float64(len(tail))
+	}
+
+	width := n / 100
+	if width < 1 {
+		width = 1
+	}
+	near := order[maxInt(cutoff-width, 0):minInt(cutoff+width+1, n)]
+	var nearTotal float64
+	for _, k := range near {
+		nearTotal += totals[k] / float64(len(near))
+	}
+
+	for i, symbol := range symbols {
+		own := make([]float64, n)
+		var component, componentES float64
+		for k, scenario := range losses {
+			own[k] = scenario[i]
+		}
+		for _, k := range near {
+			component += losses[k][i] / float64(len(near))
+		}
+		for _, k := range tail {
+			componentES += losses[k][i] / float64(len(tail))
+		}
+		if nearTotal != 0 {
+			component *= result.VaR / nearTotal
+		}
+
+		standaloneVaR, standaloneES := lossQuantile(own, confidence)
+		position := &PositionVaR{
+			Symbol:            symbol,
+			Exposure:          exposures[i],
+			VaR:               standaloneVaR,
+			ExpectedShortfall: standaloneES,
+			ComponentVaR:      component,
+			ComponentES:       componentES,
+		}
+		if exposures[i] != 0 {
+			position.MarginalVaR = component / exposures[i]
+		}
+		result.Positions = append(result.Positions, position)
+	}
+	return result
+}
+
+// lossQuantile returns the VaR and Expected Shortfall of a set of losses
+func lossQuantile(losses []float64, confidence float64) (float64, float64) {
+	sorted := append([]float64(nil), losses...)
+	sort.Float64s(sorted)
+
+	cutoff := quantileIndex(len(sorted), confidence)
+	var shortfall float64
+	for _, loss := range sorted[cutoff:] {
+		shortfall += loss / float64(len(sorted)-cutoff)
+	}
+	return sorted[cutoff], shortfall
+}
+
+// quantileIndex returns the index of the confidence quantile among n
+// sorted values
+func quantileIndex(n int, confidence float64) int {
+	index := int(math.Ceil(confidence*float64(n))) - 1
+	return maxInt(minInt(index, n-1), 0)
+}
+
+// cholesky returns the lower triangular factor of a covariance matrix.
+// Directions without variance, such as a symbol whose price never moved,
+// get a zero column instead of failing the factorisation.
+func cholesky(cov [][]float64) [][]float64 {
+	n := len(cov)
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := cov[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+			switch {
+			case i == j && sum > 0:
+				lower[i][j] = math.Sqrt(sum)
+			case i != j && lower[j][j] > 0:
+				lower[i][j] = sum / lower[j][j]
+			}
+		}
+	}
+	return lower
+}
+
+// normalQuantile returns the standard normal quantile of p
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// normalDensity returns the standard normal density at x
+func normalDensity(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 )
 
 // OrderService defines the interface for order management operations
@@ -130,25 +132,25 @@ type ExecutionResult struct {
 
 // Trade represents a completed trade
 type Trade struct {
-	ID         string    `json:"id"`
-	OrderID    string    `json:"order_id"`
-	Symbol     string    `json:"symbol"`
-	Side       string    `json:"side"`
-	Quantity   float64   `json:"quantity"`
-	Price      float64   `json:"price"`
-	Commission float64   `json:"commission"`
-	Timestamp  time.Time `json:"timestamp"`
+	ID         string        `json:"id"`
+	OrderID    string        `json:"order_id"`
+	Symbol     string        `json:"symbol"`
+	Side       string        `json:"side"`
+	Quantity   types.Decimal `json:"quantity"`
+	Price      types.Decimal `json:"price"`
+	Commission types.Decimal `json:"commission"`
+	Timestamp  time.Time     `json:"timestamp"`
 }
 
 // Settlement represents a trade settlement
 type Settlement struct {
-	ID          string     `json:"id"`
-	TradeID     string     `json:"trade_id"`
-	Status      string     `json:"status"`
-	Amount      float64    `json:"amount"`
-	Currency    string     `json:"currency"`
-	ProcessedAt *time.Time `json:"processed_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
+	ID          string        `json:"id"`
+	TradeID     string        `json:"trade_id"`
+	Status      string        `json:"status"`
+	Amount      types.Decimal `json:"amount"`
+	Currency    string        `json:"currency"`
+	ProcessedAt *time.Time    `json:"processed_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 // SettlementFilter represents settlement filtering parameters
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 )
 
 // PairsServiceImpl implements the PairsService interface
@@ -257,9 +259,9 @@ func (s *PairsServiceImpl) generateRecentTrades(symbol string, limit int) []*Tra
 			ID:         fmt.Sprintf("trade_%s_%d", symbol, i),
 			Symbol:     symbol,
 			Side:       side,
-			Quantity:   quantity,
-			Price:      price,
-			Commission: price * quantity * 0.001, // 0.1% commission
+			Quantity:   types.QuantityFromFloat(symbol, quantity),
+			Price:      types.PriceFromFloat(symbol, price),
+			Commission: types.PriceFromFloat(symbol, price*quantity*0.001), // 0.1% commission
 			Timestamp:  time.Now().Add(-time.Duration(i) * time.Minute),
 		}
 
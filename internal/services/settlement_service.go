@@ -30,7 +30,7 @@ func (s *SettlementServiceImpl) ProcessSettlement(ctx context.Context, trade *Tr
 		ID:        uuid.New().String(),
 		TradeID:   trade.ID,
 		Status:    "pending",
-		Amount:    trade.Quantity * trade.Price,
+		Amount:    trade.Quantity.Mul(trade.Price),
 		Currency:  "USD", // Default currency
 		CreatedAt: time.Now(),
 	}
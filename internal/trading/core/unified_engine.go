@@ -347,8 +347,8 @@ func (e *UnifiedTradingEngine) ProcessOrder(ctx context.Context, request *OrderR
 		unifiedTrades[i] = &Trade{
 			ID:          trade.ID,
 			Symbol:      trade.Symbol,
-			Price:       trade.Price,
-			Quantity:    trade.Quantity,
+			Price:       trade.Price.Float64(),
+			Quantity:    trade.Quantity.Float64(),
 			BuyOrderID:  trade.BuyOrderID,
 			SellOrderID: trade.SellOrderID,
 			TakerSide:   trade.TakerSide,
@@ -376,8 +376,8 @@ func (e *UnifiedTradingEngine) ProcessOrder(ctx context.Context, request *OrderR
 			riskTrades[i] = &risk.Trade{
 				ID:        trade.ID,
 				Symbol:    trade.Symbol,
-				Price:     trade.Price,
-				Quantity:  trade.Quantity,
+				Price:     trade.Price.Float64(),
+				Quantity:  trade.Quantity.Float64(),
 				TakerSide: trade.TakerSide,
 				Timestamp: trade.Timestamp,
 			}
@@ -431,7 +431,7 @@ func (e *UnifiedTradingEngine) processTradeSettlement(trade *Trade) error {
 	if e.config.Settlement.EnableT0Settlement {
 		// Immediate settlement
 		return e.settlementProcessor.ProcessTrade(trade.ID, trade.Symbol,
-			trade.Quantity, trade.Price)
+			types.QuantityFromFloat(trade.Symbol, trade.Quantity), types.PriceFromFloat(trade.Symbol, trade.Price))
 	}
 
 	// Delayed settlement
@@ -440,7 +440,7 @@ func (e *UnifiedTradingEngine) processTradeSettlement(trade *Trade) error {
 	}
 
 	return e.settlementProcessor.ProcessTrade(trade.ID, trade.Symbol,
-		trade.Quantity, trade.Price)
+		types.QuantityFromFloat(trade.Symbol, trade.Quantity), types.PriceFromFloat(trade.Symbol, trade.Price))
 }
 
 // Subscribe subscribes to trading events
@@ -0,0 +1,175 @@
+// Package algo works parent orders over time by slicing them into child
+// orders with an execution algorithm
+package algo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+)
+
+// Algorithm is the execution algorithm that schedules a parent order
+type Algorithm string
+
+const (
+	// AlgorithmTWAP spreads the parent evenly across its time window
+	AlgorithmTWAP Algorithm = "TWAP"
+	// AlgorithmVWAP follows the historical intraday volume curve
+	AlgorithmVWAP Algorithm = "VWAP"
+	// AlgorithmPOV trades a fixed share of the volume printed in the market
+	AlgorithmPOV Algorithm = "POV"
+)
+
+// ParentStatus is the state of a parent order
+type ParentStatus string
+
+const (
+	// ParentStatusRunning parents send child orders on schedule
+	ParentStatusRunning ParentStatus = "RUNNING"
+	// ParentStatusPaused parents keep their progress but send nothing
+	ParentStatusPaused ParentStatus = "PAUSED"
+	// ParentStatusCompleted parents are fully filled
+	ParentStatusCompleted ParentStatus = "COMPLETED"
+	// ParentStatusExpired parents reached their end time with quantity left
+	ParentStatusExpired ParentStatus = "EXPIRED"
+	// ParentStatusCancelled parents were cancelled by the user
+	ParentStatusCancelled ParentStatus = "CANCELLED"
+)
+
+// IsOpen reports whether a parent in this status can still trade
+func (s ParentStatus) IsOpen() bool {
+	return s == ParentStatusRunning || s == ParentStatusPaused
+}
+
+// Errors
+var (
+	ErrParentNotFound = errors.New("parent order not found")
+	ErrParentClosed   = errors.New("parent order is closed")
+	ErrInvalidParent  = errors.New("invalid parent order")
+	ErrUnknownVenue   = errors.New("unknown venue")
+	ErrNoVolumeCurve  = errors.New("no historical volume for VWAP")
+	ErrChildNotFound  = errors.New("child order not found")
+)
+
+// ParentRequest asks for a parent order to be worked by an algorithm
+type ParentRequest struct {
+	// UserID owns the parent and every child order
+	UserID string
+	// Symbol is the instrument to trade
+	Symbol string
+	// Side is the side of every child order
+	Side types.OrderSide
+	// Quantity is the total quantity to execute
+	Quantity types.Decimal
+	// LimitPrice caps child prices; zero sends market children
+	LimitPrice types.Decimal
+	// Algorithm schedules the child orders
+	Algorithm Algorithm
+	// StartTime is when slicing begins; zero starts immediately
+	StartTime time.Time
+	// EndTime is when TWAP and VWAP parents must be done; optional for POV
+	EndTime time.Time
+	// ParticipationRate is the share of market volume a POV parent targets
+	ParticipationRate float64
+	// ArrivalPrice overrides the last traded price as the slippage benchmark
+	ArrivalPrice types.Decimal
+	// VolumeCurve overrides the curve a VWAP parent loads from history
+	VolumeCurve *VolumeCurve
+	// Venue names the venue for child orders; empty uses the default venue
+	Venue string
+}
+
+// ChildOrder is a slice of a parent order sent to a venue
+type ChildOrder struct {
+	ID             string
+	ParentID       string
+	UserID         string
+	Symbol         string
+	Side           types.OrderSide
+	Price          types.Decimal
+	Quantity       types.Decimal
+	FilledQuantity types.Decimal
+	Status         types.OrderStatus
+	Venue          string
+	// VenueOrderID is the identifier the venue assigned to the child
+	VenueOrderID string
+	SentAt       time.Time
+}
+
+// IsWorking reports whether the child can still be filled at its venue
+func (c *ChildOrder) IsWorking() bool {
+	return c.Status == types.OrderStatusNew || c.Status == types.OrderStatusPartiallyFilled
+}
+
+// ParentOrder is a parent order being worked by an algorithm
+type ParentOrder struct {
+	ID                string
+	UserID            string
+	Symbol            string
+	Side              types.OrderSide
+	Quantity          types.Decimal
+	LimitPrice        types.Decimal
+	Algorithm         Algorithm
+	StartTime         time.Time
+	EndTime           time.Time
+	ParticipationRate float64
+	Venue             string
+	Status            ParentStatus
+	// ArrivalPrice is the last traded price when the parent arrived
+	ArrivalPrice types.Decimal
+	// FilledQuantity and AveragePrice summarise the child fills
+	FilledQuantity types.Decimal
+	AveragePrice   types.Decimal
+	// MarketVolume is the volume printed since the parent started
+	MarketVolume types.Decimal
+	Children     []*ChildOrder
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	notional    types.Decimal
+	curve       *VolumeCurve
+	nextSliceAt time.Time
+}
+
+// RemainingQuantity returns the quantity still to be filled
+func (p *ParentOrder) RemainingQuantity() types.Decimal {
+	return types.MaxDecimal(p.Quantity.Sub(p.FilledQuantity), types.Zero)
+}
+
+// SlippageBps returns the execution cost against the arrival price in basis
+// points; positive means the parent paid more (or sold for less) than the
+// arrival price
+func (p *ParentOrder) SlippageBps() float64 {
+	if !p.ArrivalPrice.IsPositive() || !p.FilledQuantity.IsPositive() {
+		return 0
+	}
+	arrival := p.ArrivalPrice.Float64()
+	slippage := (p.AveragePrice.Float64() - arrival) / arrival * 10000
+	if p.Side == types.OrderSideSell {
+		return -slippage
+	}
+	return slippage
+}
+
+// workingQuantity returns the unfilled quantity of children still working
+func (p *ParentOrder) workingQuantity() types.Decimal {
+	working := types.Zero
+	for _, child := range p.Children {
+		if child.IsWorking() {
+			working = working.Add(child.Quantity.Sub(child.FilledQuantity))
+		}
+	}
+	return working
+}
+
+// snapshot copies the parent and its children for callers outside the lock
+func (p *ParentOrder) snapshot() *ParentOrder {
+	parent := *p
+	parent.Children = make([]*ChildOrder, len(p.Children))
+	for i, child := range p.Children {
+		copied := *child
+		parent.Children[i] = &copied
+	}
+	return &parent
+}
@@ -0,0 +1,63 @@
+package algo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the execution algorithm service for fx
+var Module = fx.Options(
+	fx.Provide(NewFxService),
+)
+
+// ServiceParams contains the parameters for creating an execution
+// algorithm service
+type ServiceParams struct {
+	fx.In
+
+	Lifecycle    fx.Lifecycle
+	Logger       *zap.Logger
+	OrderService *orders.OrderService
+	History      HistoricalDataSource `optional:"true"`
+}
+
+// NewFxService creates the execution algorithm service for the fx
+// application. Child orders are placed through the order service, whose
+// trades feed the market volume of POV parents. While the app runs, the
+// service steps its parents every slice interval.
+func NewFxService(p ServiceParams) *Service {
+	service := NewService(NewEngineVenue(p.OrderService), p.History, DefaultConfig(), p.Logger)
+
+	var cancel context.CancelFunc
+	var wg sync.WaitGroup
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				service.Run(ctx)
+			}()
+			go func() {
+				defer wg.Done()
+				if err := service.FollowTrades(ctx, p.OrderService); err != nil {
+					p.Logger.Error("Trade feed stopped", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			wg.Wait()
+			return nil
+		},
+	})
+
+	return service
+}
@@ -0,0 +1,475 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// rateScale is the precision participation rates and schedule fractions are
+// applied with
+const rateScale = 6
+
+// averagePriceDigits is how many digits average prices carry beyond the
+// scale of the fill prices
+const averagePriceDigits = 4
+
+// HistoricalDataSource provides the OHLCV history VWAP curves are built
+// from; the market data service implements it
+type HistoricalDataSource interface {
+	GetHistoricalOHLCV(ctx context.Context, symbol, interval string, start, end time.Time) ([]*db.MarketData, error)
+}
+
+// Config configures the execution algorithm service
+type Config struct {
+	// SliceInterval is the minimum time between child orders of a parent
+	SliceInterval time.Duration
+	// CurveInterval is the OHLCV interval VWAP curves are built from
+	CurveInterval string
+	// CurveLookback is how much history VWAP curves are built from
+	CurveLookback time.Duration
+}
+
+// DefaultConfig returns the default service configuration
+func DefaultConfig() Config {
+	return Config{
+		SliceInterval: 30 * time.Second,
+		CurveInterval: "5m",
+		CurveLookback: 20 * 24 * time.Hour,
+	}
+}
+
+// Service works parent orders with execution algorithms, sending their
+// child orders to the matching engine or external venues
+type Service struct {
+	config     Config
+	venue      Venue
+	venues     map[string]Venue
+	history    HistoricalDataSource
+	parents    map[string]*ParentOrder
+	children   map[string]*ChildOrder
+	lastPrices map[string]types.Decimal
+	logger     *zap.Logger
+	mu         sync.Mutex
+}
+
+// NewService creates a service sending children to the default venue. The
+// history source may be nil when VWAP parents bring their own curve.
+func NewService(venue Venue, history HistoricalDataSource, config Config, logger *zap.Logger) *Service {
+	return &Service{
+		config:     config,
+		venue:      venue,
+		venues:     make(map[string]Venue),
+		history:    history,
+		parents:    make(map[string]*ParentOrder),
+		children:   make(map[string]*ChildOrder),
+		lastPrices: make(map[string]types.Decimal),
+		logger:     logger,
+	}
+}
+
+// RegisterVenue makes a named venue available to parent requests
+func (s *Service) RegisterVenue(name string, venue Venue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.venues[name] = venue
+}
+
+// Submit validates a parent request and starts working it
+func (s *Service) Submit(ctx context.Context, req *ParentRequest) (*ParentOrder, error) {
+	now := time.Now()
+	start := req.StartTime
+	if start.IsZero() {
+		start = now
+	}
+	if err := validateRequest(req, start); err != nil {
+		return nil, err
+	}
+
+	curve := req.VolumeCurve
+	if req.Algorithm == AlgorithmVWAP && curve == nil {
+		var err error
+		if curve, err = s.loadVolumeCurve(ctx, req.Symbol, start); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Venue != "" {
+		if _, exists := s.venues[req.Venue]; !exists {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownVenue, req.Venue)
+		}
+	}
+
+	arrival := req.ArrivalPrice
+	if !arrival.IsPositive() {
+		arrival = s.lastPrices[req.Symbol]
+	}
+
+	parent := &ParentOrder{
+		ID:                uuid.New().String(),
+		UserID:            req.UserID,
+		Symbol:            req.Symbol,
+		Side:              req.Side,
+		Quantity:          req.Quantity,
+		LimitPrice:        req.LimitPrice,
+		Algorithm:         req.Algorithm,
+		StartTime:         start,
+		EndTime:           req.EndTime,
+		ParticipationRate: req.ParticipationRate,
+		Venue:             req.Venue,
+		Status:            ParentStatusRunning,
+		ArrivalPrice:      arrival,
+		FilledQuantity:    types.Zero,
+		AveragePrice:      types.Zero,
+		MarketVolume:      types.Zero,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		notional:          types.Zero,
+		curve:             curve,
+	}
+	s.parents[parent.ID] = parent
+
+	s.logger.Info("Parent order submitted",
+		zap.String("parent_id", parent.ID),
+		zap.String("symbol", parent.Symbol),
+		zap.String("algorithm", string(parent.Algorithm)),
+		zap.String("quantity", parent.Quantity.String()))
+
+	return parent.snapshot(), nil
+}
+
+// GetParent returns a copy of a parent order
+func (s *Service) GetParent(parentID string) (*ParentOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent, exists := s.parents[parentID]
+	if !exists {
+		return nil, ErrParentNotFound
+	}
+	return parent.snapshot(), nil
+}
+
+// Pause stops a running parent from sending children and cancels the ones
+// still working
+func (s *Service) Pause(ctx context.Context, parentID string) (*ParentOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent, err := s.openParent(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Status == ParentStatusRunning {
+		s.cancelChildren(ctx, parent)
+		parent.Status = ParentStatusPaused
+		parent.UpdatedAt = time.Now()
+	}
+	return parent.snapshot(), nil
+}
+
+// Resume restarts a paused parent; it catches up with its schedule on the
+// next step
+func (s *Service) Resume(ctx context.Context, parentID string) (*ParentOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent, err := s.openParent(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Status == ParentStatusPaused {
+		parent.Status = ParentStatusRunning
+		parent.nextSliceAt = time.Time{}
+		parent.UpdatedAt = time.Now()
+	}
+	return parent.snapshot(), nil
+}
+
+// Cancel cancels a parent and the children still working
+func (s *Service) Cancel(ctx context.Context, parentID string) (*ParentOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent, err := s.openParent(parentID)
+	if err != nil {
+		return nil, err
+	}
+	s.cancelChildren(ctx, parent)
+	parent.Status = ParentStatusCancelled
+	parent.UpdatedAt = time.Now()
+
+	s.logger.Info("Parent order cancelled",
+		zap.String("parent_id", parent.ID),
+		zap.String("filled_quantity", parent.FilledQuantity.String()))
+
+	return parent.snapshot(), nil
+}
+
+// OnTrade records a trade printed in the market. It sets the arrival price
+// of parents that arrived before the first print and counts towards the
+// volume POV parents participate in, including their own fills.
+func (s *Service) OnTrade(symbol string, price, quantity types.Decimal, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastPrices[symbol] = price
+	for _, parent := range s.parents {
+		if parent.Symbol != symbol || !parent.Status.IsOpen() {
+			continue
+		}
+		if !parent.ArrivalPrice.IsPositive() {
+			parent.ArrivalPrice = price
+		}
+		if !at.Before(parent.StartTime) {
+			parent.MarketVolume = parent.MarketVolume.Add(quantity)
+		}
+	}
+}
+
+// OnFill applies a fill an asynchronous venue reported for a child order
+func (s *Service) OnFill(fill Fill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	child, exists := s.children[fill.ChildID]
+	if !exists {
+		return ErrChildNotFound
+	}
+	parent := s.parents[child.ParentID]
+	s.applyFill(parent, child, fill)
+	return nil
+}
+
+// Step sends the child orders every running parent is due at the given
+// time. Run calls it on a ticker; backtests call it with simulated time.
+func (s *Service) Step(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, parent := range s.parents {
+		if parent.Status == ParentStatusRunning {
+			s.stepParent(ctx, parent, now)
+		}
+	}
+}
+
+// Run steps the service every slice interval until the context is done
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.SliceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.Step(ctx, now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stepParent sends the parent's next child if one is due and closes the
+// parent once its window has passed
+func (s *Service) stepParent(ctx context.Context, parent *ParentOrder, now time.Time) {
+	if now.Before(parent.StartTime) || now.Before(parent.nextSliceAt) {
+		return
+	}
+
+	ended := !parent.EndTime.IsZero() && !now.Before(parent.EndTime)
+	slice := s.target(parent, now, ended).
+		Sub(parent.FilledQuantity).
+		Sub(parent.workingQuantity()).
+		Truncate(parent.Quantity.Scale())
+	if slice.IsPositive() {
+		s.sendChild(ctx, parent, slice, now)
+		parent.nextSliceAt = now.Add(s.config.SliceInterval)
+	}
+
+	if ended && parent.Status == ParentStatusRunning && parent.workingQuantity().IsZero() {
+		parent.Status = ParentStatusExpired
+		parent.UpdatedAt = now
+		s.logger.Info("Parent order expired",
+			zap.String("parent_id", parent.ID),
+			zap.String("remaining_quantity", parent.RemainingQuantity().String()),
+			zap.Float64("slippage_bps", parent.SlippageBps()))
+	}
+}
+
+// target returns the cumulative quantity the parent should have executed by
+// now
+func (s *Service) target(parent *ParentOrder, now time.Time, ended bool) types.Decimal {
+	var fraction float64
+	switch parent.Algorithm {
+	case AlgorithmTWAP:
+		fraction = 1
+		if !ended {
+			fraction = float64(now.Sub(parent.StartTime)) / float64(parent.EndTime.Sub(parent.StartTime))
+		}
+	case AlgorithmVWAP:
+		fraction = parent.curve.Fraction(parent.StartTime, parent.EndTime, now)
+	case AlgorithmPOV:
+		rate := types.NewDecimalFromFloat(parent.ParticipationRate, rateScale)
+		return types.MinDecimal(parent.MarketVolume.Mul(rate), parent.Quantity)
+	}
+	return parent.Quantity.Mul(types.NewDecimalFromFloat(fraction, rateScale))
+}
+
+// sendChild sends a child order for the given quantity to the parent's venue
+func (s *Service) sendChild(ctx context.Context, parent *ParentOrder, quantity types.Decimal, now time.Time) {
+	child := &ChildOrder{
+		ID:             fmt.Sprintf("%s-%d", parent.ID, len(parent.Children)+1),
+		ParentID:       parent.ID,
+		UserID:         parent.UserID,
+		Symbol:         parent.Symbol,
+		Side:           parent.Side,
+		Price:          parent.LimitPrice,
+		Quantity:       quantity,
+		FilledQuantity: types.Zero,
+		Status:         types.OrderStatusNew,
+		Venue:          parent.Venue,
+		SentAt:         now,
+	}
+	parent.Children = append(parent.Children, child)
+	s.children[child.ID] = child
+
+	fills, err := s.venueFor(parent).SubmitChild(ctx, child)
+	if err != nil {
+		child.Status = types.OrderStatusRejected
+		s.logger.Warn("Child order rejected",
+			zap.String("parent_id", parent.ID),
+			zap.String("child_id", child.ID),
+			zap.Error(err))
+		return
+	}
+	for _, fill := range fills {
+		s.applyFill(parent, child, fill)
+	}
+}
+
+// applyFill books a child fill on the child and its parent
+func (s *Service) applyFill(parent *ParentOrder, child *ChildOrder, fill Fill) {
+	child.FilledQuantity = child.FilledQuantity.Add(fill.Quantity)
+	if child.FilledQuantity.GreaterThanOrEqual(child.Quantity) {
+		child.Status = types.OrderStatusFilled
+	} else if child.IsWorking() {
+		child.Status = types.OrderStatusPartiallyFilled
+	}
+
+	parent.FilledQuantity = parent.FilledQuantity.Add(fill.Quantity)
+	parent.notional = parent.notional.Add(fill.Price.Mul(fill.Quantity))
+	if average, err := parent.notional.Div(parent.FilledQuantity, fill.Price.Scale()+averagePriceDigits); err == nil {
+		parent.AveragePrice = average
+	}
+	if !parent.ArrivalPrice.IsPositive() {
+		parent.ArrivalPrice = fill.Price
+	}
+	parent.UpdatedAt = fill.Timestamp
+
+	if parent.Status.IsOpen() && parent.FilledQuantity.GreaterThanOrEqual(parent.Quantity) {
+		parent.Status = ParentStatusCompleted
+		s.logger.Info("Parent order completed",
+			zap.String("parent_id", parent.ID),
+			zap.String("average_price", parent.AveragePrice.String()),
+			zap.Float64("slippage_bps", parent.SlippageBps()))
+	}
+}
+
+// cancelChildren cancels the parent's working children at their venue
+func (s *Service) cancelChildren(ctx context.Context, parent *ParentOrder) {
+	venue := s.venueFor(parent)
+	for _, child := range parent.Children {
+		if !child.IsWorking() {
+			continue
+		}
+		if err := venue.CancelChild(ctx, child); err != nil {
+			s.logger.Warn("Failed to cancel child order",
+				zap.String("parent_id", parent.ID),
+				zap.String("child_id", child.ID),
+				zap.Error(err))
+			continue
+		}
+		child.Status = types.OrderStatusCancelled
+	}
+}
+
+// openParent returns a parent that can still be paused, resumed or cancelled
+func (s *Service) openParent(parentID string) (*ParentOrder, error) {
+	parent, exists := s.parents[parentID]
+	if !exists {
+		return nil, ErrParentNotFound
+	}
+	if !parent.Status.IsOpen() {
+		return nil, ErrParentClosed
+	}
+	return parent, nil
+}
+
+// venueFor returns the venue the parent's children go to
+func (s *Service) venueFor(parent *ParentOrder) Venue {
+	if parent.Venue != "" {
+		return s.venues[parent.Venue]
+	}
+	return s.venue
+}
+
+// loadVolumeCurve builds a VWAP curve from the history before the start
+func (s *Service) loadVolumeCurve(ctx context.Context, symbol string, start time.Time) (*VolumeCurve, error) {
+	if s.history == nil {
+		return nil, ErrNoVolumeCurve
+	}
+	bucket, err := time.ParseDuration(s.config.CurveInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid curve interval %q: %w", s.config.CurveInterval, err)
+	}
+
+	bars, err := s.history.GetHistoricalOHLCV(ctx, symbol, s.config.CurveInterval, start.Add(-s.config.CurveLookback), start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load volume history: %w", err)
+	}
+	curve := VolumeCurveFromOHLCV(bars, bucket)
+	if curve.Total() <= 0 {
+		return nil, ErrNoVolumeCurve
+	}
+	return curve, nil
+}
+
+// validateRequest checks a parent request starting at the given time
+func validateRequest(req *ParentRequest, start time.Time) error {
+	switch {
+	case req.Symbol == "":
+		return fmt.Errorf("%w: symbol is required", ErrInvalidParent)
+	case req.Side != types.OrderSideBuy && req.Side != types.OrderSideSell:
+		return fmt.Errorf("%w: invalid side %q", ErrInvalidParent, req.Side)
+	case !req.Quantity.IsPositive():
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidParent)
+	case req.LimitPrice.IsNegative():
+		return fmt.Errorf("%w: limit price cannot be negative", ErrInvalidParent)
+	}
+
+	switch req.Algorithm {
+	case AlgorithmTWAP, AlgorithmVWAP:
+		if !req.EndTime.After(start) {
+			return fmt.Errorf("%w: %s needs an end time after the start", ErrInvalidParent, req.Algorithm)
+		}
+	case AlgorithmPOV:
+		if req.ParticipationRate <= 0 || req.ParticipationRate > 1 {
+			return fmt.Errorf("%w: participation rate must be in (0, 1]", ErrInvalidParent)
+		}
+		if !req.EndTime.IsZero() && !req.EndTime.After(start) {
+			return fmt.Errorf("%w: end time must be after the start", ErrInvalidParent)
+		}
+	default:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidParent, req.Algorithm)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package algo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"go.uber.org/zap"
+)
+
+// TradeFeed streams the execution reports of order entry; the order
+// service implements it
+type TradeFeed interface {
+	SubscribeExecutions(filter orders.ExecutionFilter, session string, fromSequence uint64) (*orders.ExecutionSubscription, error)
+}
+
+// FollowTrades records the trades printed through order entry until the
+// context is done, so POV parents see the market volume. Each trade is
+// reported for both of its orders and is counted once, from its buy side.
+// A subscription dropped for falling behind is resumed where it stopped.
+func (s *Service) FollowTrades(ctx context.Context, feed TradeFeed) error {
+	var session string
+	var sequence uint64
+	for {
+		subscription, err := feed.SubscribeExecutions(orders.ExecutionFilter{}, session, sequence)
+		if errors.Is(err, orders.ErrSequenceUnavailable) {
+			s.logger.Warn("Missed trades while resubscribing to the trade feed",
+				zap.Uint64("sequence", sequence))
+			subscription, err = feed.SubscribeExecutions(orders.ExecutionFilter{}, "", 0)
+		}
+		if err != nil {
+			return err
+		}
+
+		session, sequence = s.followTrades(ctx, subscription, session, sequence)
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// followTrades records the trades of one subscription until it is dropped
+// or the context is done, returning the session and sequence of the last
+// report received
+func (s *Service) followTrades(ctx context.Context, subscription *orders.ExecutionSubscription, session string, sequence uint64) (string, uint64) {
+	defer subscription.Close()
+
+	for {
+		select {
+		case report, ok := <-subscription.Reports():
+			if !ok {
+				return session, sequence
+			}
+			session, sequence = report.Session, report.Sequence
+
+			if report.ExecType != orders.ExecFill && report.ExecType != orders.ExecPartialFill {
+				continue
+			}
+			if report.Order.Side != orders.OrderSideBuy {
+				continue
+			}
+			s.OnTrade(report.Order.Symbol, report.LastPrice, report.LastQuantity, report.Timestamp)
+		case <-ctx.Done():
+			return session, sequence
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package algo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/connectivity"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+)
+
+// Fill is an execution of a child order
+type Fill struct {
+	ChildID   string
+	Price     types.Decimal
+	Quantity  types.Decimal
+	Timestamp time.Time
+}
+
+// Venue executes child orders. SubmitChild returns the fills the child
+// received on arrival and leaves child.Status at New while the child keeps
+// working, in which case later fills are reported through Service.OnFill.
+// Venues are called with the service locked and must not call back into it
+// synchronously.
+type Venue interface {
+	SubmitChild(ctx context.Context, child *ChildOrder) ([]Fill, error)
+	CancelChild(ctx context.Context, child *ChildOrder) error
+}
+
+// OrderRouter places child orders through order entry; the order service
+// implements it
+type OrderRouter interface {
+	PlaceOrder(ctx context.Context, req *orders.OrderRequest) (order *orders.Order, replayed bool, err error)
+}
+
+// EngineVenue sends children to the internal matching engine through order
+// entry as immediate or cancel orders, so they pass the same checks as any
+// other order and never rest in the book
+type EngineVenue struct {
+	router OrderRouter
+}
+
+// NewEngineVenue creates a venue placing children through the order router
+func NewEngineVenue(router OrderRouter) *EngineVenue {
+	return &EngineVenue{router: router}
+}
+
+// SubmitChild places the child and cancels whatever does not fill
+func (v *EngineVenue) SubmitChild(ctx context.Context, child *ChildOrder) ([]Fill, error) {
+	orderType := orders.OrderTypeLimit
+	if child.Price.IsZero() {
+		orderType = orders.OrderTypeMarket
+	}
+
+	order, _, err := v.router.PlaceOrder(ctx, &orders.OrderRequest{
+		UserID:        child.UserID,
+		ClientOrderID: child.ID,
+		Symbol:        child.Symbol,
+		Side:          orders.OrderSide(child.Side),
+		Type:          orderType,
+		Price:         child.Price,
+		Quantity:      child.Quantity,
+		TimeInForce:   orders.TimeInForceIOC,
+		Tags:          map[string]string{"parent_order_id": child.ParentID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	child.VenueOrderID = order.ID
+	if order.Status == orders.OrderStatusRejected {
+		child.Status = types.OrderStatusRejected
+		return nil, nil
+	}
+
+	fills := make([]Fill, 0, len(order.Trades))
+	for _, trade := range order.Trades {
+		fills = append(fills, Fill{
+			ChildID:   child.ID,
+			Price:     trade.Price,
+			Quantity:  trade.Quantity,
+			Timestamp: trade.ExecutedAt,
+		})
+	}
+	child.Status = types.OrderStatusCancelled
+	return fills, nil
+}
+
+// CancelChild is a no-op; engine children never rest
+func (v *EngineVenue) CancelChild(ctx context.Context, child *ChildOrder) error {
+	return nil
+}
+
+// ExchangeVenue routes children to an external exchange. Fills arrive
+// asynchronously and are reported through Service.OnFill.
+type ExchangeVenue struct {
+	adapter connectivity.ExchangeAdapter
+}
+
+// NewExchangeVenue creates a venue backed by an exchange adapter
+func NewExchangeVenue(adapter connectivity.ExchangeAdapter) *ExchangeVenue {
+	return &ExchangeVenue{adapter: adapter}
+}
+
+// SubmitChild sends the child to the exchange
+func (v *ExchangeVenue) SubmitChild(ctx context.Context, child *ChildOrder) ([]Fill, error) {
+	response, err := v.adapter.SubmitOrder(childOrder(child))
+	if err != nil {
+		return nil, err
+	}
+
+	child.VenueOrderID = response.OrderID
+	if strings.EqualFold(response.Status, string(types.OrderStatusRejected)) {
+		child.Status = types.OrderStatusRejected
+	} else {
+		child.Status = types.OrderStatusNew
+	}
+	return nil, nil
+}
+
+// CancelChild cancels the child at the exchange
+func (v *ExchangeVenue) CancelChild(ctx context.Context, child *ChildOrder) error {
+	return v.adapter.CancelOrder(child.VenueOrderID)
+}
+
+// SimulatedVenue fills children in full at the last price it was given, as
+// long as that price is within the child's limit. It lets backtests run the
+// algorithms without an order book.
+type SimulatedVenue struct {
+	prices map[string]types.Decimal
+	times  map[string]time.Time
+	mu     sync.RWMutex
+}
+
+// NewSimulatedVenue creates an empty simulated venue
+func NewSimulatedVenue() *SimulatedVenue {
+	return &SimulatedVenue{
+		prices: make(map[string]types.Decimal),
+		times:  make(map[string]time.Time),
+	}
+}
+
+// SetPrice sets the price children of a symbol fill at
+func (v *SimulatedVenue) SetPrice(symbol string, price types.Decimal, at time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.prices[symbol] = price
+	v.times[symbol] = at
+}
+
+// SubmitChild fills the child at the last price or cancels it
+func (v *SimulatedVenue) SubmitChild(ctx context.Context, child *ChildOrder) ([]Fill, error) {
+	v.mu.RLock()
+	price, ok := v.prices[child.Symbol]
+	at := v.times[child.Symbol]
+	v.mu.RUnlock()
+
+	child.Status = types.OrderStatusCancelled
+	if !ok || !marketable(child, price) {
+		return nil, nil
+	}
+	return []Fill{{
+		ChildID:   child.ID,
+		Price:     price,
+		Quantity:  child.Quantity,
+		Timestamp: at,
+	}}, nil
+}
+
+// CancelChild is a no-op; simulated children never rest
+func (v *SimulatedVenue) CancelChild(ctx context.Context, child *ChildOrder) error {
+	return nil
+}
+
+// marketable reports whether a child may trade at the price
+func marketable(child *ChildOrder, price types.Decimal) bool {
+	if child.Price.IsZero() {
+		return true
+	}
+	if child.Side == types.OrderSideBuy {
+		return price.LessThanOrEqual(child.Price)
+	}
+	return price.GreaterThanOrEqual(child.Price)
+}
+
+// childOrder converts a child into an order for a venue
+func childOrder(child *ChildOrder) *types.Order {
+	orderType := types.OrderTypeLimit
+	if child.Price.IsZero() {
+		orderType = types.OrderTypeMarket
+	}
+	return &types.Order{
+		ID:            child.ID,
+		Symbol:        child.Symbol,
+		Side:          child.Side,
+		Type:          orderType,
+		Price:         child.Price,
+		Quantity:      child.Quantity,
+		Status:        types.OrderStatusNew,
+		CreatedAt:     child.SentAt,
+		UpdatedAt:     child.SentAt,
+		ClientOrderID: child.ID,
+		UserID:        child.UserID,
+		TimeInForce:   types.TimeInForceGTC,
+		ParentOrderID: child.ParentID,
+	}
+}
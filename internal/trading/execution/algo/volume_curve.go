@@ -0,0 +1,91 @@
+package algo
+
+import (
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+)
+
+const day = 24 * time.Hour
+
+// VolumeCurve is the share of daily volume traded in each time-of-day bucket
+// (UTC), used by VWAP to pace a parent across its window
+type VolumeCurve struct {
+	bucket  time.Duration
+	volumes []float64
+}
+
+// NewVolumeCurve creates an empty curve with buckets of the given width;
+// widths that do not divide a day fall back to one minute
+func NewVolumeCurve(bucket time.Duration) *VolumeCurve {
+	if bucket <= 0 || day%bucket != 0 {
+		bucket = time.Minute
+	}
+	return &VolumeCurve{
+		bucket:  bucket,
+		volumes: make([]float64, day/bucket),
+	}
+}
+
+// VolumeCurveFromOHLCV builds a curve from historical OHLCV bars
+func VolumeCurveFromOHLCV(bars []*db.MarketData, bucket time.Duration) *VolumeCurve {
+	curve := NewVolumeCurve(bucket)
+	for _, bar := range bars {
+		curve.AddVolume(bar.Timestamp, bar.Volume)
+	}
+	return curve
+}
+
+// AddVolume adds volume traded at the given time to its bucket
+func (c *VolumeCurve) AddVolume(at time.Time, volume float64) {
+	if volume > 0 {
+		c.volumes[c.slot(at)] += volume
+	}
+}
+
+// Total returns the volume across all buckets
+func (c *VolumeCurve) Total() float64 {
+	total := 0.0
+	for _, volume := range c.volumes {
+		total += volume
+	}
+	return total
+}
+
+// Fraction returns the share of the window's expected volume that trades
+// between start and now. Windows the curve has no volume for are paced
+// evenly.
+func (c *VolumeCurve) Fraction(start, end, now time.Time) float64 {
+	if !now.After(start) {
+		return 0
+	}
+	if !now.Before(end) {
+		return 1
+	}
+	total := c.volumeBetween(start, end)
+	if total <= 0 {
+		return float64(now.Sub(start)) / float64(end.Sub(start))
+	}
+	return c.volumeBetween(start, now) / total
+}
+
+// volumeBetween integrates the curve from one time to another, prorating
+// partial buckets
+func (c *VolumeCurve) volumeBetween(from, to time.Time) float64 {
+	total := 0.0
+	for t := from.UTC(); t.Before(to); {
+		next := t.Truncate(c.bucket).Add(c.bucket)
+		if next.After(to) {
+			next = to.UTC()
+		}
+		total += c.volumes[c.slot(t)] * float64(next.Sub(t)) / float64(c.bucket)
+		t = next
+	}
+	return total
+}
+
+// slot returns the bucket a time falls in
+func (c *VolumeCurve) slot(at time.Time) int {
+	at = at.UTC()
+	return int(at.Sub(at.Truncate(day)) / c.bucket)
+}
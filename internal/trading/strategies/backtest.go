@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/db/models"
+	"github.com/abdoElHodaky/tradSys/internal/trading/execution/algo"
 	"github.com/abdoElHodaky/tradSys/proto/marketdata"
 	"github.com/abdoElHodaky/tradSys/proto/orders"
 	"go.uber.org/zap"
@@ -204,6 +205,17 @@ func (e *BacktestEngine) RunBacktest(ctx context.Context, strategyName string, i
 	return result, nil
 }
 
+// SimulateExecution replays the loaded market data through an execution
+// algorithm and returns the parent order with its fills and slippage
+func (e *BacktestEngine) SimulateExecution(ctx context.Context, req *algo.ParentRequest, config algo.Config) (*algo.ParentOrder, error) {
+	e.mu.RLock()
+	marketData := make([]*marketdata.MarketDataResponse, len(e.marketData))
+	copy(marketData, e.marketData)
+	e.mu.RUnlock()
+
+	return algo.Backtest(ctx, marketData, req, config, e.logger)
+}
+
 // mockOrderService is a mock implementation of the OrderServiceClient for backtesting
 type mockOrderService struct {
 	logger      *zap.Logger
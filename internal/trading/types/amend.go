@@ -0,0 +1,39 @@
+package types
+
+// Amend returns the price and quantity an order would have after a
+// cancel/replace, treating zero values as unchanged
+func (o *Order) Amend(price, quantity Decimal) (Decimal, Decimal) {
+	if price.IsZero() {
+		price = o.Price
+	}
+	if quantity.IsZero() {
+		quantity = o.Quantity
+	}
+	return price, quantity
+}
+
+// ValidateAmend checks that a resting order may be amended to price and
+// quantity and still have quantity left to fill
+func (o *Order) ValidateAmend(price, quantity Decimal) error {
+	if o.Type == OrderTypeMarket {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"market orders cannot be amended")
+	}
+	if !price.IsPositive() || !quantity.IsPositive() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"amended price and quantity must be positive")
+	}
+	if !quantity.GreaterThan(o.FilledQuantity) {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"amended quantity %s does not exceed filled quantity %s", quantity, o.FilledQuantity)
+	}
+	return nil
+}
+
+// KeepsPriority returns true if amending to price and quantity keeps the
+// order's time priority. Only quantity reductions at the same price do;
+// price changes and quantity increases send the order to the back of the
+// queue.
+func (o *Order) KeepsPriority(price, quantity Decimal) bool {
+	return price.Equal(o.Price) && quantity.LessThanOrEqual(o.Quantity)
+}
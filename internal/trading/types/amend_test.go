@@ -0,0 +1,28 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_KeepsPriority(t *testing.T) {
+	order := &Order{Price: MustParseDecimal("10"), Quantity: MustParseDecimal("5")}
+
+	assert.True(t, order.KeepsPriority(order.Amend(Zero, MustParseDecimal("3"))))
+	assert.True(t, order.KeepsPriority(order.Amend(Zero, Zero)))
+	assert.False(t, order.KeepsPriority(order.Amend(Zero, MustParseDecimal("6"))))
+	assert.False(t, order.KeepsPriority(order.Amend(MustParseDecimal("10.5"), Zero)))
+}
+
+func TestOrder_ValidateAmend(t *testing.T) {
+	order := &Order{Type: OrderTypeLimit, Price: MustParseDecimal("10"), Quantity: MustParseDecimal("5"), FilledQuantity: MustParseDecimal("2")}
+
+	assert.NoError(t, order.ValidateAmend(order.Amend(Zero, MustParseDecimal("3"))))
+
+	err := order.ValidateAmend(order.Amend(Zero, MustParseDecimal("2")))
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(err))
+
+	err = order.ValidateAmend(order.Amend(MustParseDecimal("-1"), Zero))
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(err))
+}
@@ -0,0 +1,187 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// TradingPhase is the trading phase of an order book
+type TradingPhase string
+
+const (
+	// TradingPhaseContinuous matches orders as they arrive
+	TradingPhaseContinuous TradingPhase = "CONTINUOUS"
+	// TradingPhasePreOpen collects orders for the opening auction
+	TradingPhasePreOpen TradingPhase = "PRE_OPEN"
+	// TradingPhasePreClose collects orders for the closing auction
+	TradingPhasePreClose TradingPhase = "PRE_CLOSE"
+	// TradingPhaseClosed collects orders for the next opening auction
+	TradingPhaseClosed TradingPhase = "CLOSED"
+	// TradingPhaseHalted collects orders for the re-opening auction
+	TradingPhaseHalted TradingPhase = "HALTED"
+)
+
+// IsCall returns true if orders are collected without matching
+func (p TradingPhase) IsCall() bool {
+	switch p {
+	case TradingPhasePreOpen, TradingPhasePreClose, TradingPhaseClosed, TradingPhaseHalted:
+		return true
+	}
+	return false
+}
+
+// Uncrosses returns true if moving from phase p to next runs the auction:
+// leaving the opening or closing call, re-opening after a halt, or opening
+// straight from closed. A halt interrupts a call without running it.
+func (p TradingPhase) Uncrosses(next TradingPhase) bool {
+	if p == next || !p.IsCall() || next == TradingPhaseHalted {
+		return false
+	}
+	return next == TradingPhaseContinuous || p == TradingPhasePreClose
+}
+
+// PhaseFor returns the scheduled trading phase of a symbol at t; symbols
+// without a schedule trade continuously
+func PhaseFor(symbol string, t time.Time) TradingPhase {
+	if instrument, exists := Instruments.Get(symbol); exists && instrument.Schedule != nil {
+		return instrument.Schedule.Phase(t)
+	}
+	return TradingPhaseContinuous
+}
+
+// ValidateForPhase rejects orders an auction call cannot accept: market
+// orders, which have no price to take part in price formation, and
+// immediate orders, which cannot wait for the uncross. During a halt both
+// are rejected as TRADING_HALTED.
+func (o *Order) ValidateForPhase(phase TradingPhase) error {
+	if !phase.IsCall() {
+		return nil
+	}
+	if phase == TradingPhaseHalted && (o.Type == OrderTypeMarket || o.IsImmediate()) {
+		return NewOrderRejection(o.Symbol, RejectReasonTradingHalted,
+			"trading is halted; only resting limit orders are queued")
+	}
+	if o.Type == OrderTypeMarket {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"market orders are not accepted during the %s call", phase)
+	}
+	if o.IsImmediate() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidTimeInForce,
+			"%s orders are not accepted during the %s call", o.TimeInForce, phase)
+	}
+	return nil
+}
+
+// AuctionLevel is the quantity offered at one price in an auction book
+type AuctionLevel struct {
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
+}
+
+// AuctionResult is an auction's equilibrium price, the volume executable
+// there and the surplus left unmatched on one side
+type AuctionResult struct {
+	Price  Decimal `json:"price"`
+	Volume Decimal `json:"volume"`
+	// Surplus is buy volume minus sell volume at Price
+	Surplus Decimal `json:"surplus"`
+}
+
+// EquilibriumPrice finds the auction price from bids sorted highest first
+// and asks sorted lowest first. Among the limit prices it picks, in order:
+// the maximum executable volume, the minimum surplus, the highest price if
+// every remaining candidate has buy surplus or the lowest if every one has
+// sell surplus, the price closest to reference, and finally the lowest
+// price. It returns false if the book does not cross.
+func EquilibriumPrice(bids, asks []AuctionLevel, reference Decimal) (AuctionResult, bool) {
+	if len(bids) == 0 || len(asks) == 0 || bids[0].Price.LessThan(asks[0].Price) {
+		return AuctionResult{}, false
+	}
+
+	// Candidate prices in ascending order
+	prices := make([]Decimal, 0, len(bids)+len(asks))
+	for _, level := range bids {
+		prices = append(prices, level.Price)
+	}
+	for _, level := range asks {
+		prices = append(prices, level.Price)
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].LessThan(prices[j]) })
+
+	buyVolume := Zero
+	for _, level := range bids {
+		buyVolume = buyVolume.Add(level.Quantity)
+	}
+	sellVolume := Zero
+
+	var candidates []AuctionResult
+	bid := len(bids) - 1
+	ask := 0
+	for i, price := range prices {
+		if i > 0 && price.Equal(prices[i-1]) {
+			continue
+		}
+		// Bids below price no longer buy; asks at or below price sell
+		for bid >= 0 && bids[bid].Price.LessThan(price) {
+			buyVolume = buyVolume.Sub(bids[bid].Quantity)
+			bid--
+		}
+		for ask < len(asks) && asks[ask].Price.LessThanOrEqual(price) {
+			sellVolume = sellVolume.Add(asks[ask].Quantity)
+			ask++
+		}
+
+		volume := MinDecimal(buyVolume, sellVolume)
+		if volume.IsPositive() {
+			candidates = append(candidates, AuctionResult{
+				Price:   price,
+				Volume:  volume,
+				Surplus: buyVolume.Sub(sellVolume),
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return AuctionResult{}, false
+	}
+
+	candidates = keepBest(candidates, func(a, b AuctionResult) int { return a.Volume.Cmp(b.Volume) })
+	candidates = keepBest(candidates, func(a, b AuctionResult) int { return b.Surplus.Abs().Cmp(a.Surplus.Abs()) })
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+
+	buyPressure, sellPressure := true, true
+	for _, candidate := range candidates {
+		buyPressure = buyPressure && candidate.Surplus.IsPositive()
+		sellPressure = sellPressure && candidate.Surplus.IsNegative()
+	}
+	switch {
+	case buyPressure:
+		return candidates[len(candidates)-1], true
+	case sellPressure:
+		return candidates[0], true
+	case reference.IsPositive():
+		candidates = keepBest(candidates, func(a, b AuctionResult) int {
+			return b.Price.Sub(reference).Abs().Cmp(a.Price.Sub(reference).Abs())
+		})
+	}
+	return candidates[0], true
+}
+
+// keepBest returns the candidates that rank highest under cmp, in order
+func keepBest(candidates []AuctionResult, cmp func(a, b AuctionResult) int) []AuctionResult {
+	best := candidates[:0:0]
+	for _, candidate := range candidates {
+		if len(best) > 0 {
+			c := cmp(candidate, best[0])
+			if c < 0 {
+				continue
+			}
+			if c > 0 {
+				best = best[:0]
+			}
+		}
+		best = append(best, candidate)
+	}
+	return best
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquilibriumPrice(t *testing.T) {
+	level := func(price, quantity string) AuctionLevel {
+		return AuctionLevel{Price: MustParseDecimal(price), Quantity: MustParseDecimal(quantity)}
+	}
+
+	tests := []struct {
+		name      string
+		bids      []AuctionLevel
+		asks      []AuctionLevel
+		reference string
+		price     string
+		volume    string
+	}{
+		{
+			name:   "maximum volume",
+			bids:   []AuctionLevel{level("102", "10"), level("101", "20"), level("100", "30")},
+			asks:   []AuctionLevel{level("99", "15"), level("100", "10"), level("101", "40")},
+			price:  "101",
+			volume: "30",
+		},
+		{
+			name:   "minimum surplus",
+			bids:   []AuctionLevel{level("101", "10"), level("100", "5")},
+			asks:   []AuctionLevel{level("100", "10"), level("101", "3")},
+			price:  "101",
+			volume: "10",
+		},
+		{
+			name:   "buy pressure takes the highest price",
+			bids:   []AuctionLevel{level("101", "20")},
+			asks:   []AuctionLevel{level("99", "5"), level("100", "5")},
+			price:  "101",
+			volume: "10",
+		},
+		{
+			name:      "reference breaks a balanced tie",
+			bids:      []AuctionLevel{level("102", "10")},
+			asks:      []AuctionLevel{level("98", "10")},
+			reference: "99",
+			price:     "98",
+			volume:    "10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reference := Zero
+			if tt.reference != "" {
+				reference = MustParseDecimal(tt.reference)
+			}
+			result, ok := EquilibriumPrice(tt.bids, tt.asks, reference)
+			assert.True(t, ok)
+			assert.True(t, MustParseDecimal(tt.price).Equal(result.Price), result.Price.String())
+			assert.True(t, MustParseDecimal(tt.volume).Equal(result.Volume), result.Volume.String())
+		})
+	}
+
+	_, ok := EquilibriumPrice([]AuctionLevel{level("99", "1")}, []AuctionLevel{level("100", "1")}, Zero)
+	assert.False(t, ok)
+}
+
+func TestOrder_ValidateForPhase(t *testing.T) {
+	limit := &Order{Type: OrderTypeLimit, TimeInForce: TimeInForceGTC}
+	assert.NoError(t, limit.ValidateForPhase(TradingPhasePreOpen))
+
+	err := (&Order{Type: OrderTypeMarket}).ValidateForPhase(TradingPhasePreClose)
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(err))
+
+	ioc := &Order{Type: OrderTypeLimit, TimeInForce: TimeInForceIOC}
+	assert.Equal(t, RejectReasonInvalidTimeInForce, RejectReasonOf(ioc.ValidateForPhase(TradingPhasePreOpen)))
+	assert.NoError(t, ioc.ValidateForPhase(TradingPhaseContinuous))
+
+	assert.NoError(t, limit.ValidateForPhase(TradingPhaseHalted))
+	assert.Equal(t, RejectReasonTradingHalted, RejectReasonOf(ioc.ValidateForPhase(TradingPhaseHalted)))
+}
+
+func TestTradingPhase_Uncrosses(t *testing.T) {
+	assert.True(t, TradingPhasePreOpen.Uncrosses(TradingPhaseContinuous))
+	assert.True(t, TradingPhasePreClose.Uncrosses(TradingPhaseClosed))
+	assert.True(t, TradingPhaseHalted.Uncrosses(TradingPhaseContinuous))
+	assert.False(t, TradingPhasePreClose.Uncrosses(TradingPhaseHalted))
+	assert.False(t, TradingPhaseHalted.Uncrosses(TradingPhasePreClose))
+	assert.False(t, TradingPhaseContinuous.Uncrosses(TradingPhaseHalted))
+}
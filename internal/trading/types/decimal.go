@@ -0,0 +1,643 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxDecimalScale is the largest number of fractional digits a Decimal can carry
+const MaxDecimalScale = 18
+
+// DefaultPriceScale is the price scale used for symbols without a registered scale
+const DefaultPriceScale uint8 = 8
+
+// DefaultQuantityScale is the quantity scale used for symbols without a registered scale
+const DefaultQuantityScale uint8 = 8
+
+var pow10 = [MaxDecimalScale + 1]int64{
+	1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000,
+	1000000000, 10000000000, 100000000000, 1000000000000, 10000000000000,
+	100000000000000, 1000000000000000, 10000000000000000, 100000000000000000,
+	1000000000000000000,
+}
+
+// Decimal is a fixed-point decimal number stored as a scaled int64.
+// The represented value is value / 10^scale. Arithmetic between decimals
+// of different scales is performed at the larger of the two scales, so
+// equal prices always compare equal and fill accumulation never drifts.
+type Decimal struct {
+	value int64
+	scale uint8
+}
+
+// Zero is the zero decimal
+var Zero = Decimal{}
+
+// NewDecimal creates a decimal from an unscaled integer and a scale,
+// e.g. NewDecimal(12345, 2) is 123.45
+func NewDecimal(value int64, scale uint8) Decimal {
+	if scale > MaxDecimalScale {
+		scale = MaxDecimalScale
+	}
+	return Decimal{value: value, scale: scale}
+}
+
+// NewDecimalFromInt creates a decimal from a whole number
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{value: value}
+}
+
+// NewDecimalFromFloat creates a decimal from a float64, rounding half away
+// from zero to the given scale. The float is formatted with the shortest
+// representation that round-trips, so 0.1 becomes exactly 0.1.
+func NewDecimalFromFloat(f float64, scale uint8) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero
+	}
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Zero
+	}
+	return d.Rescale(scale)
+}
+
+// ParseDecimal parses a decimal string such as "-123.4500" or "1.5e2".
+// Exponents are applied exactly; a value too large for an int64 at any
+// scale returns ErrDecimalOverflow.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, ErrInvalidDecimal
+	}
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		return parseExponent(s[:e], s[e+1:])
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, fracPart = s[:dot], s[dot+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Zero, ErrInvalidDecimal
+	}
+	if len(fracPart) > MaxDecimalScale {
+		// Drop digits beyond the maximum scale, rounding half away from zero.
+		// The carry takes the sign of the input, as the kept digits may all
+		// be zero.
+		for _, c := range fracPart[MaxDecimalScale:] {
+			if c < '0' || c > '9' {
+				return Zero, ErrInvalidDecimal
+			}
+		}
+		roundUp := fracPart[MaxDecimalScale] >= '5'
+		fracPart = fracPart[:MaxDecimalScale]
+		d, err := ParseDecimal(signPrefix(negative) + intPart + "." + fracPart)
+		if err != nil || !roundUp {
+			return d, err
+		}
+		carry := int64(1)
+		if negative {
+			carry = -1
+		}
+		return d.Add(Decimal{value: carry, scale: MaxDecimalScale}), nil
+	}
+
+	digits := intPart + fracPart
+	if digits == "" {
+		digits = "0"
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Zero, ErrInvalidDecimal
+		}
+	}
+
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Zero, ErrDecimalOverflow
+	}
+	if negative {
+		value = -value
+	}
+
+	return Decimal{value: value, scale: uint8(len(fracPart))}, nil
+}
+
+// MustParseDecimal parses a decimal string and panics on error
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// parseExponent parses a mantissa scaled by a power of ten exponent
+func parseExponent(mantissa, exponent string) (Decimal, error) {
+	d, err := ParseDecimal(mantissa)
+	if err != nil {
+		return Zero, err
+	}
+	exp, err := strconv.Atoi(exponent)
+	if err != nil {
+		return Zero, ErrInvalidDecimal
+	}
+
+	// The value is d.value * 10^(exp - d.scale)
+	shift := exp - int(d.scale)
+	switch {
+	case shift >= 0:
+		if shift > MaxDecimalScale {
+			if d.value == 0 {
+				return Zero, nil
+			}
+			return Zero, ErrDecimalOverflow
+		}
+		value, ok := mulInt64(d.value, pow10[shift])
+		if !ok {
+			return Zero, ErrDecimalOverflow
+		}
+		return Decimal{value: value}, nil
+	case -shift <= MaxDecimalScale:
+		return Decimal{value: d.value, scale: uint8(-shift)}, nil
+	default:
+		// Round digits beyond the maximum scale away, half away from zero
+		drop := -shift - MaxDecimalScale
+		if drop > 2*MaxDecimalScale {
+			return Decimal{scale: MaxDecimalScale}, nil
+		}
+		value := roundQuo(big.NewInt(d.value), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(drop)), nil))
+		return Decimal{value: value.Int64(), scale: MaxDecimalScale}, nil
+	}
+}
+
+func signPrefix(negative bool) string {
+	if negative {
+		return "-"
+	}
+	return ""
+}
+
+// Unscaled returns the unscaled integer value
+func (d Decimal) Unscaled() int64 {
+	return d.value
+}
+
+// Scale returns the number of fractional digits
+func (d Decimal) Scale() uint8 {
+	return d.scale
+}
+
+// Float64 returns the nearest float64 to the decimal
+func (d Decimal) Float64() float64 {
+	if d.scale == 0 {
+		return float64(d.value)
+	}
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns the decimal formatted with exactly Scale fractional digits
+func (d Decimal) String() string {
+	if d.scale == 0 {
+		return strconv.FormatInt(d.value, 10)
+	}
+
+	negative := d.value < 0
+	abs := uint64(d.value)
+	if negative {
+		abs = uint64(-d.value)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	return signPrefix(negative) + digits[:point] + "." + digits[point:]
+}
+
+// IsZero returns true if the decimal is zero
+func (d Decimal) IsZero() bool {
+	return d.value == 0
+}
+
+// IsPositive returns true if the decimal is greater than zero
+func (d Decimal) IsPositive() bool {
+	return d.value > 0
+}
+
+// IsNegative returns true if the decimal is less than zero
+func (d Decimal) IsNegative() bool {
+	return d.value < 0
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the decimal
+func (d Decimal) Sign() int {
+	switch {
+	case d.value < 0:
+		return -1
+	case d.value > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Overflowed returns true if the decimal is the saturated result of an
+// operation whose exact value does not fit in an int64
+func (d Decimal) Overflowed() bool {
+	return d.value == math.MaxInt64 || d.value == -math.MaxInt64
+}
+
+// Cmp compares two decimals and returns -1, 0 or 1
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, ok := align(d, other)
+	if !ok {
+		scale := maxScale(d, other)
+		return d.big(scale).Cmp(other.big(scale))
+	}
+	switch {
+	case a.value < b.value:
+		return -1
+	case a.value > b.value:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Equal returns true if both decimals represent the same value
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan returns true if d < other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// LessThanOrEqual returns true if d <= other
+func (d Decimal) LessThanOrEqual(other Decimal) bool {
+	return d.Cmp(other) <= 0
+}
+
+// GreaterThan returns true if d > other
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// GreaterThanOrEqual returns true if d >= other
+func (d Decimal) GreaterThanOrEqual(other Decimal) bool {
+	return d.Cmp(other) >= 0
+}
+
+// Add returns d + other. The sum is exact when it fits at the larger of
+// the two scales; otherwise fractional digits are dropped, as in Mul, and a
+// sum too large for any scale saturates.
+func (d Decimal) Add(other Decimal) Decimal {
+	if a, b, ok := align(d, other); ok {
+		if sum, ok := addInt64(a.value, b.value); ok {
+			return Decimal{value: sum, scale: a.scale}
+		}
+	}
+	scale := maxScale(d, other)
+	return fit(new(big.Int).Add(d.big(scale), other.big(scale)), scale)
+}
+
+// Sub returns d - other, exact or rounded like Add
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d.Add(other.Neg())
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: -d.value, scale: d.scale}
+}
+
+// Abs returns the absolute value of d
+func (d Decimal) Abs() Decimal {
+	if d.value < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Mul returns d * other. The product is exact when it fits in the sum of
+// the two scales; otherwise fractional digits are dropped, rounding half
+// away from zero, until it fits.
+func (d Decimal) Mul(other Decimal) Decimal {
+	product := new(big.Int).Mul(big.NewInt(d.value), big.NewInt(other.value))
+	return fit(product, d.scale+other.scale)
+}
+
+// MulInt returns d * n, exact or rounded like Mul
+func (d Decimal) MulInt(n int64) Decimal {
+	if product, ok := mulInt64(d.value, n); ok {
+		return Decimal{value: product, scale: d.scale}
+	}
+	return fit(new(big.Int).Mul(big.NewInt(d.value), big.NewInt(n)), d.scale)
+}
+
+// Div returns d / other at the given scale, rounding half away from zero.
+// Division by zero returns ErrDivisionByZero.
+func (d Decimal) Div(other Decimal, scale uint8) (Decimal, error) {
+	if other.value == 0 {
+		return Zero, ErrDivisionByZero
+	}
+	if scale > MaxDecimalScale {
+		scale = MaxDecimalScale
+	}
+
+	// d/other = (d.value * 10^(scale + other.scale - d.scale)) / other.value at scale
+	num := big.NewInt(d.value)
+	shift := int(scale) + int(other.scale) - int(d.scale)
+	den := big.NewInt(other.value)
+	if shift >= 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+
+	return Decimal{value: saturate(roundQuo(num, den)), scale: scale}, nil
+}
+
+// Rescale returns the decimal expressed at the given scale, rounding half
+// away from zero when digits are dropped. A value too large for the finer
+// scale saturates, see Overflowed.
+func (d Decimal) Rescale(scale uint8) Decimal {
+	if scale > MaxDecimalScale {
+		scale = MaxDecimalScale
+	}
+	if scale == d.scale {
+		return d
+	}
+	return fromBig(big.NewInt(d.value), d.scale, scale)
+}
+
+// Truncate returns the decimal expressed at the given scale, dropping any
+// digits beyond it without rounding
+func (d Decimal) Truncate(scale uint8) Decimal {
+	if scale >= d.scale {
+		return d.Rescale(scale)
+	}
+	return Decimal{value: d.value / pow10[d.scale-scale], scale: scale}
+}
+
+// IsMultipleOf returns true if d is an exact multiple of step. A zero step
+// accepts every value.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.value == 0 {
+		return true
+	}
+	a, b, ok := align(d, step)
+	if !ok {
+		scale := maxScale(d, step)
+		return new(big.Int).Rem(d.big(scale), step.big(scale)).Sign() == 0
+	}
+	return a.value%b.value == 0
+}
+
+// MinDecimal returns the smaller of two decimals
+func MinDecimal(a, b Decimal) Decimal {
+	if a.LessThan(b) {
+		return a
+	}
+	return b
+}
+
+// MaxDecimal returns the larger of two decimals
+func MaxDecimal(a, b Decimal) Decimal {
+	if a.GreaterThan(b) {
+		return a
+	}
+	return b
+}
+
+// MarshalJSON encodes the decimal as a JSON number with its exact digits
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a decimal from a JSON number or quoted string
+// without passing through float64
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*d = Zero
+		return nil
+	}
+
+	// Exponent notation from clients that emit floats is applied exactly
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, s)
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText encodes the decimal as its exact digits
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a decimal from text such as a YAML scalar
+func (d *Decimal) UnmarshalText(text []byte) error {
+	return d.UnmarshalJSON(text)
+}
+
+// Value stores the decimal in a database column as its exact digits
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads a decimal from a database column. Numeric and text columns
+// are parsed exactly; float columns written before decimals were stored as
+// text are read at their shortest representation.
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Zero
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case int64:
+		*d = NewDecimalFromInt(v)
+		return nil
+	case float64:
+		return d.UnmarshalText([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	default:
+		return fmt.Errorf("cannot scan %T into a decimal", src)
+	}
+}
+
+// align expresses both decimals at the larger of their scales, or reports
+// false when one of them does not fit at that scale
+func align(a, b Decimal) (Decimal, Decimal, bool) {
+	switch {
+	case a.scale < b.scale:
+		value, ok := mulInt64(a.value, pow10[b.scale-a.scale])
+		return Decimal{value: value, scale: b.scale}, b, ok
+	case b.scale < a.scale:
+		value, ok := mulInt64(b.value, pow10[a.scale-b.scale])
+		return a, Decimal{value: value, scale: a.scale}, ok
+	default:
+		return a, b, true
+	}
+}
+
+// big returns the unscaled value of d at a scale at least its own
+func (d Decimal) big(scale uint8) *big.Int {
+	return new(big.Int).Mul(big.NewInt(d.value), bigPow10(scale-d.scale))
+}
+
+// fit converts an unscaled big integer at scale `from` to a decimal at the
+// largest scale, up to MaxDecimalScale, at which it fits in an int64,
+// rounding half away from zero. Values too large even for scale zero
+// saturate.
+func fit(v *big.Int, from uint8) Decimal {
+	scale := from
+	if scale > MaxDecimalScale {
+		scale = MaxDecimalScale
+	}
+	limit := big.NewInt(math.MaxInt64)
+	for scale > 0 {
+		if roundQuo(v, bigPow10(from-scale)).CmpAbs(limit) < 0 {
+			break
+		}
+		scale--
+	}
+	return fromBig(v, from, scale)
+}
+
+// fromBig converts an unscaled big integer at scale `from` to a decimal
+// at scale `to`, rounding half away from zero and saturating when it does
+// not fit
+func fromBig(v *big.Int, from, to uint8) Decimal {
+	if to >= from {
+		v = new(big.Int).Mul(v, bigPow10(to-from))
+		return Decimal{value: saturate(v), scale: to}
+	}
+	return Decimal{value: saturate(roundQuo(v, bigPow10(from-to))), scale: to}
+}
+
+// saturate returns v, or the int64 of its sign with the largest magnitude
+// short of math.MinInt64 when v does not fit
+func saturate(v *big.Int) int64 {
+	if v.CmpAbs(big.NewInt(math.MaxInt64)) < 0 {
+		return v.Int64()
+	}
+	if v.Sign() < 0 {
+		return -math.MaxInt64
+	}
+	return math.MaxInt64
+}
+
+// addInt64 returns a + b, or false when the sum overflows
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// mulInt64 returns a * b, or false when the product overflows
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// bigPow10 returns 10^n as a big integer
+func bigPow10(n uint8) *big.Int {
+	if int(n) < len(pow10) {
+		return big.NewInt(pow10[n])
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo returns num/den rounded half away from zero
+func roundQuo(num, den *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	rem.Abs(rem).Mul(rem, big.NewInt(2))
+	if rem.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
+
+// SymbolScale is the fixed-point precision used for a symbol's prices and quantities
+type SymbolScale struct {
+	Price    uint8
+	Quantity uint8
+}
+
+var (
+	symbolScalesMu sync.RWMutex
+	symbolScales   = make(map[string]SymbolScale)
+)
+
+// RegisterSymbolScale sets the price and quantity scale for a symbol
+func RegisterSymbolScale(symbol string, priceScale, quantityScale uint8) {
+	symbolScalesMu.Lock()
+	defer symbolScalesMu.Unlock()
+
+	symbolScales[symbol] = SymbolScale{Price: priceScale, Quantity: quantityScale}
+}
+
+// GetSymbolScale returns the registered scale for a symbol, or the defaults
+func GetSymbolScale(symbol string) SymbolScale {
+	symbolScalesMu.RLock()
+	defer symbolScalesMu.RUnlock()
+
+	if scale, exists := symbolScales[symbol]; exists {
+		return scale
+	}
+	return SymbolScale{Price: DefaultPriceScale, Quantity: DefaultQuantityScale}
+}
+
+// PriceFromFloat converts a boundary float64 price to a decimal at the symbol's price scale
+func PriceFromFloat(symbol string, price float64) Decimal {
+	return NewDecimalFromFloat(price, GetSymbolScale(symbol).Price)
+}
+
+// QuantityFromFloat converts a boundary float64 quantity to a decimal at the symbol's quantity scale
+func QuantityFromFloat(symbol string, quantity float64) Decimal {
+	return NewDecimalFromFloat(quantity, GetSymbolScale(symbol).Quantity)
+}
+
+// Decimal errors
+var (
+	ErrInvalidDecimal  = errors.New("invalid decimal")
+	ErrDecimalOverflow = errors.New("decimal overflow")
+	ErrDivisionByZero  = errors.New("decimal division by zero")
+)
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal_ParseAndString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"123.45", "123.45"},
+		{"-0.0001", "-0.0001"},
+		{"1.50", "1.50"},
+		{"+7", "7"},
+		{"1.0000000000000000004", "1.000000000000000000"},
+		{"0.0000000000000000005", "0.000000000000000001"},
+		{"-0.0000000000000000005", "-0.000000000000000001"},
+		{"0.9999999999999999995", "1.000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d.String())
+		})
+	}
+
+	_, err := ParseDecimal("1.2.3")
+	assert.ErrorIs(t, err, ErrInvalidDecimal)
+	_, err = ParseDecimal("0.0000000000000000001x")
+	assert.ErrorIs(t, err, ErrInvalidDecimal)
+}
+
+func TestDecimal_NoDriftOnAccumulation(t *testing.T) {
+	lot := MustParseDecimal("0.1")
+	filled := Zero
+	for i := 0; i < 10; i++ {
+		filled = filled.Add(lot)
+	}
+
+	assert.True(t, filled.Equal(NewDecimalFromInt(1)))
+	assert.True(t, NewDecimalFromFloat(0.1, 8).Equal(lot))
+}
+
+func TestDecimal_CompareAcrossScales(t *testing.T) {
+	a := MustParseDecimal("100.5")
+	b := MustParseDecimal("100.50000000")
+
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.True(t, a.LessThan(MustParseDecimal("100.50000001")))
+}
+
+func TestDecimal_MulDiv(t *testing.T) {
+	price := MustParseDecimal("50000.25")
+	qty := MustParseDecimal("0.003")
+
+	assert.Equal(t, "150.00075", price.Mul(qty).String())
+
+	quotient, err := NewDecimalFromInt(1).Div(NewDecimalFromInt(3), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "0.33", quotient.String())
+	quotient, err = NewDecimalFromInt(2).Div(NewDecimalFromInt(3), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "0.67", quotient.String())
+
+	_, err = NewDecimalFromInt(1).Div(Zero, 2)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+}
+
+func TestDecimal_JSONRoundTrip(t *testing.T) {
+	d := MustParseDecimal("0.30000000")
+
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, "0.30000000", string(data))
+
+	var decoded Decimal
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.Equal(d))
+
+	require.NoError(t, json.Unmarshal([]byte(`"12.5"`), &decoded))
+	assert.Equal(t, "12.5", decoded.String())
+}
+
+func TestDecimal_SQLRoundTrip(t *testing.T) {
+	d := MustParseDecimal("123456789.123456789")
+
+	value, err := d.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "123456789.123456789", value)
+
+	var scanned Decimal
+	for _, src := range []interface{}{value, []byte("123456789.123456789")} {
+		require.NoError(t, scanned.Scan(src))
+		assert.Equal(t, "123456789.123456789", scanned.String())
+	}
+
+	require.NoError(t, scanned.Scan(0.1))
+	assert.Equal(t, "0.1", scanned.String())
+	require.NoError(t, scanned.Scan(int64(42)))
+	assert.Equal(t, "42", scanned.String())
+	require.NoError(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsZero())
+	assert.Error(t, scanned.Scan(true))
+}
+
+func TestDecimal_SymbolScale(t *testing.T) {
+	RegisterSymbolScale("TEST-USD", 2, 4)
+
+	assert.Equal(t, "19.99", PriceFromFloat("TEST-USD", 19.989).String())
+	assert.Equal(t, "0.1235", QuantityFromFloat("TEST-USD", 0.12345).String())
+}
+
+func TestDecimal_Exponent(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.5e2", "150"},
+		{"1e1", "10"},
+		{"-2.5E-3", "-0.0025"},
+		{"12345e-2", "123.45"},
+		{"1e-20", "0.000000000000000000"},
+		{"0e30", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var d Decimal
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &d))
+			assert.Equal(t, tt.expected, d.String())
+		})
+	}
+
+	var d Decimal
+	assert.ErrorIs(t, json.Unmarshal([]byte("1e19"), &d), ErrDecimalOverflow)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"9.3e18"`), &d), ErrDecimalOverflow)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"1e"`), &d), ErrInvalidDecimal)
+}
+
+func TestDecimal_Overflow(t *testing.T) {
+	large := MustParseDecimal("900000000000")
+
+	// Aligning to a finer scale must not wrap
+	assert.True(t, large.Rescale(8).Overflowed())
+	assert.True(t, large.GreaterThan(MustParseDecimal("0.00000001")))
+	assert.True(t, MustParseDecimal("-0.00000001").GreaterThan(large.Neg()))
+
+	// Sums drop fractional digits until they fit
+	sum := large.Add(MustParseDecimal("0.12345678"))
+	assert.False(t, sum.Overflowed())
+	assert.Equal(t, "900000000000.1234568", sum.String())
+
+	max := NewDecimal(math.MaxInt64-1, 0)
+	assert.True(t, max.Add(max).Overflowed())
+	assert.True(t, max.Neg().Sub(max).Overflowed())
+	assert.True(t, max.MulInt(2).Overflowed())
+	assert.Equal(t, "1844674407370955161", NewDecimal(math.MaxInt64/5, 1).MulInt(10).String())
+	assert.True(t, max.IsMultipleOf(MustParseDecimal("0.5")))
+}
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// TradingStatus reports that trading in a symbol halted or resumed. An
+// empty Symbol means the whole market.
+type TradingStatus struct {
+	Symbol string `json:"symbol,omitempty"`
+	// Phase is the trading phase the book moved to; empty for the market
+	Phase  TradingPhase `json:"phase,omitempty"`
+	Halted bool         `json:"halted"`
+	// Reason explains a halt
+	Reason    string    `json:"reason,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
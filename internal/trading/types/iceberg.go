@@ -0,0 +1,54 @@
+package types
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// ValidateDisplay rejects display instructions the book cannot honour.
+// Iceberg and hidden orders must be limit orders, and an order cannot be
+// both.
+func (o *Order) ValidateDisplay() error {
+	if o.DisplayQuantity.IsNegative() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"display quantity must not be negative")
+	}
+	if !o.IsIceberg() && !o.IsHidden {
+		return nil
+	}
+	if o.Type != OrderTypeLimit {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"iceberg and hidden orders must be limit orders")
+	}
+	if o.IsIceberg() && o.IsHidden {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"an order cannot be both iceberg and hidden")
+	}
+	return nil
+}
+
+// IcebergPeak returns the size of the n-th displayed slice of an iceberg
+// order, given the quantity not yet displayed. The display quantity is
+// varied by up to the symbol's peak variance and rounded down to whole
+// lots, so the refills cannot be told apart by size. The variation is
+// derived from the order ID and slice number, so a replay shows the same
+// slices.
+func IcebergPeak(order *Order, slice int, remaining Decimal) Decimal {
+	peak := order.DisplayQuantity
+	if instrument, exists := Instruments.Get(order.Symbol); exists && instrument.IcebergPeakVariance.IsPositive() {
+		h := fnv.New64a()
+		h.Write([]byte(order.ID + "/" + strconv.Itoa(slice)))
+		variation := NewDecimal(int64(h.Sum64()%2001)-1000, 3)
+		peak = peak.Add(peak.Mul(instrument.IcebergPeakVariance).Mul(variation))
+
+		lot := instrument.LotSize
+		if !lot.IsPositive() {
+			lot = NewDecimal(1, order.DisplayQuantity.Scale())
+		}
+		peak = lot.MulInt(wholeLots(peak, lot))
+		if peak.LessThan(lot) {
+			peak = lot
+		}
+	}
+	return MinDecimal(peak, remaining)
+}
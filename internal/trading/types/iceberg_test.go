@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIcebergPeak(t *testing.T) {
+	registry := Instruments
+	Instruments = NewInstrumentRegistry()
+	defer func() { Instruments = registry }()
+
+	order := &Order{
+		ID:              "iceberg-1",
+		Symbol:          "AAPL",
+		Type:            OrderTypeLimit,
+		Quantity:        MustParseDecimal("1000"),
+		DisplayQuantity: MustParseDecimal("100"),
+	}
+
+	// Without a variance every slice shows the display quantity
+	assert.True(t, MustParseDecimal("100").Equal(IcebergPeak(order, 1, MustParseDecimal("1000"))))
+	assert.True(t, MustParseDecimal("40").Equal(IcebergPeak(order, 2, MustParseDecimal("40"))))
+
+	assert.NoError(t, Instruments.Register(&Instrument{
+		Symbol:              "AAPL",
+		TradingEnabled:      true,
+		LotSize:             MustParseDecimal("10"),
+		IcebergPeakVariance: MustParseDecimal("0.2"),
+	}))
+
+	sizes := make(map[string]bool)
+	for slice := 1; slice <= 20; slice++ {
+		peak := IcebergPeak(order, slice, MustParseDecimal("1000"))
+		assert.True(t, peak.GreaterThanOrEqual(MustParseDecimal("80")), "slice %d: %s", slice, peak)
+		assert.True(t, peak.LessThanOrEqual(MustParseDecimal("120")), "slice %d: %s", slice, peak)
+		assert.True(t, peak.IsMultipleOf(MustParseDecimal("10")), "slice %d: %s", slice, peak)
+		assert.True(t, peak.Equal(IcebergPeak(order, slice, MustParseDecimal("1000"))), "slices are reproducible")
+		sizes[peak.String()] = true
+	}
+	assert.Greater(t, len(sizes), 1, "slice sizes vary")
+
+	err := Instruments.Register(&Instrument{Symbol: "BAD", IcebergPeakVariance: MustParseDecimal("1")})
+	assert.ErrorIs(t, err, ErrInvalidInstrument)
+}
+
+func TestOrder_ValidateDisplay(t *testing.T) {
+	iceberg := &Order{
+		Symbol:          "AAPL",
+		Type:            OrderTypeLimit,
+		Quantity:        MustParseDecimal("100"),
+		DisplayQuantity: MustParseDecimal("10"),
+	}
+	assert.NoError(t, iceberg.ValidateDisplay())
+
+	iceberg.IsHidden = true
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(iceberg.ValidateDisplay()))
+
+	hiddenMarket := &Order{Symbol: "AAPL", Type: OrderTypeMarket, Quantity: MustParseDecimal("100"), IsHidden: true}
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(hiddenMarket.ValidateDisplay()))
+}
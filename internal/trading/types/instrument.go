@@ -0,0 +1,368 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RejectReason is a machine-readable code explaining why an order was rejected
+type RejectReason string
+
+const (
+	// RejectReasonNone indicates the order was not rejected
+	RejectReasonNone RejectReason = ""
+	// RejectReasonUnknownInstrument indicates the symbol is not listed
+	RejectReasonUnknownInstrument RejectReason = "UNKNOWN_INSTRUMENT"
+	// RejectReasonInstrumentDisabled indicates trading is disabled for the symbol
+	RejectReasonInstrumentDisabled RejectReason = "INSTRUMENT_DISABLED"
+	// RejectReasonInvalidTickSize indicates the price is not a multiple of the tick size
+	RejectReasonInvalidTickSize RejectReason = "INVALID_TICK_SIZE"
+	// RejectReasonInvalidLotSize indicates the quantity is not a multiple of the lot size
+	RejectReasonInvalidLotSize RejectReason = "INVALID_LOT_SIZE"
+	// RejectReasonQuantityBelowMinimum indicates the quantity is below the instrument minimum
+	RejectReasonQuantityBelowMinimum RejectReason = "QUANTITY_BELOW_MINIMUM"
+	// RejectReasonQuantityAboveMaximum indicates the quantity is above the instrument maximum
+	RejectReasonQuantityAboveMaximum RejectReason = "QUANTITY_ABOVE_MAXIMUM"
+	// RejectReasonNotionalBelowMinimum indicates price * quantity is below the minimum notional
+	RejectReasonNotionalBelowMinimum RejectReason = "NOTIONAL_BELOW_MINIMUM"
+	// RejectReasonNotionalAboveMaximum indicates price * quantity is above the maximum notional
+	RejectReasonNotionalAboveMaximum RejectReason = "NOTIONAL_ABOVE_MAXIMUM"
+	// RejectReasonPriceOutsideStaticBand indicates the price is outside the fixed price band
+	RejectReasonPriceOutsideStaticBand RejectReason = "PRICE_OUTSIDE_STATIC_BAND"
+	// RejectReasonPriceOutsideDynamicBand indicates the price deviates too far from the reference price
+	RejectReasonPriceOutsideDynamicBand RejectReason = "PRICE_OUTSIDE_DYNAMIC_BAND"
+	// RejectReasonInvalidTimeInForce indicates an unsupported or incomplete time in force
+	RejectReasonInvalidTimeInForce RejectReason = "INVALID_TIME_IN_FORCE"
+	// RejectReasonInvalidOrder indicates the order failed basic validation
+	RejectReasonInvalidOrder RejectReason = "INVALID_ORDER"
+	// RejectReasonTradingHalted indicates the order cannot wait out a trading halt
+	RejectReasonTradingHalted RejectReason = "TRADING_HALTED"
+)
+
+// OrderRejection is returned when an order breaks an instrument or market rule
+type OrderRejection struct {
+	// Reason is the rejection code
+	Reason RejectReason
+	// Symbol is the trading symbol
+	Symbol string
+	// Detail is a human-readable explanation
+	Detail string
+}
+
+// Error implements the error interface
+func (r *OrderRejection) Error() string {
+	if r.Detail == "" {
+		return fmt.Sprintf("order rejected for %s: %s", r.Symbol, r.Reason)
+	}
+	return fmt.Sprintf("order rejected for %s: %s: %s", r.Symbol, r.Reason, r.Detail)
+}
+
+// Is allows errors.Is(err, ErrOrderRejected) to match any rejection
+func (r *OrderRejection) Is(target error) bool {
+	return target == ErrOrderRejected
+}
+
+// NewOrderRejection creates an order rejection error
+func NewOrderRejection(symbol string, reason RejectReason, format string, args ...interface{}) *OrderRejection {
+	return &OrderRejection{
+		Reason: reason,
+		Symbol: symbol,
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// RejectReasonOf extracts the rejection code from an error, or RejectReasonNone
+func RejectReasonOf(err error) RejectReason {
+	var rejection *OrderRejection
+	if errors.As(err, &rejection) {
+		return rejection.Reason
+	}
+	return RejectReasonNone
+}
+
+// Instrument holds the reference data used to validate orders for a symbol.
+// Zero-valued limits are not enforced.
+type Instrument struct {
+	// Symbol is the trading symbol
+	Symbol string `json:"symbol"`
+	// AssetType is the type of asset
+	AssetType AssetType `json:"asset_type,omitempty"`
+	// Currency is the trading (quote) currency
+	Currency string `json:"currency,omitempty"`
+	// TradingEnabled indicates whether orders are accepted
+	TradingEnabled bool `json:"trading_enabled"`
+	// TickSize is the minimum price increment
+	TickSize Decimal `json:"tick_size"`
+	// LotSize is the minimum quantity increment
+	LotSize Decimal `json:"lot_size"`
+	// MinQuantity is the smallest accepted order quantity
+	MinQuantity Decimal `json:"min_quantity"`
+	// MaxQuantity is the largest accepted order quantity
+	MaxQuantity Decimal `json:"max_quantity"`
+	// MinNotional is the smallest accepted price * quantity
+	MinNotional Decimal `json:"min_notional"`
+	// MaxNotional is the largest accepted price * quantity
+	MaxNotional Decimal `json:"max_notional"`
+	// MinPrice is the lower static price band
+	MinPrice Decimal `json:"min_price"`
+	// MaxPrice is the upper static price band
+	MaxPrice Decimal `json:"max_price"`
+	// DynamicBand is the maximum relative deviation from the reference
+	// price, e.g. 0.1 for 10%
+	DynamicBand Decimal `json:"dynamic_band"`
+	// Schedule is the exchange trading schedule, nil for continuous markets
+	Schedule *TradingSchedule `json:"schedule,omitempty"`
+	// StopTrigger is the price that elects stop orders, LAST by default
+	StopTrigger StopTrigger `json:"stop_trigger,omitempty"`
+	// MatchingAlgorithm allocates fills at a price level, PRICE_TIME by default
+	MatchingAlgorithm MatchingAlgorithm `json:"matching_algorithm,omitempty"`
+	// MinAllocation is the smallest pro-rata share a resting order receives
+	MinAllocation Decimal `json:"min_allocation"`
+	// IcebergPeakVariance is the largest relative change made to each
+	// displayed iceberg slice, e.g. 0.2 for up to 20% either way
+	IcebergPeakVariance Decimal `json:"iceberg_peak_variance"`
+	// MidpointHalfTick lets midpoint pegs rest and trade at half-tick prices
+	MidpointHalfTick bool `json:"midpoint_half_tick,omitempty"`
+}
+
+// Scale returns the fixed-point scale implied by the tick and lot sizes
+func (i *Instrument) Scale() SymbolScale {
+	scale := SymbolScale{Price: DefaultPriceScale, Quantity: DefaultQuantityScale}
+	if i.TickSize.IsPositive() {
+		scale.Price = i.TickSize.Scale()
+	}
+	if i.LotSize.IsPositive() {
+		scale.Quantity = i.LotSize.Scale()
+	}
+	return scale
+}
+
+// Validate checks an order against the instrument rules. The reference
+// price, usually the last traded price, anchors the dynamic price band and
+// values market orders; a zero reference disables those checks.
+func (i *Instrument) Validate(order *Order, reference Decimal) error {
+	if !i.TradingEnabled {
+		return NewOrderRejection(i.Symbol, RejectReasonInstrumentDisabled, "trading is disabled")
+	}
+
+	if err := i.validateQuantity(order.Quantity); err != nil {
+		return err
+	}
+	if order.IsIceberg() && i.LotSize.IsPositive() && !order.DisplayQuantity.IsMultipleOf(i.LotSize) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidLotSize,
+			"display quantity %s is not a multiple of lot size %s", order.DisplayQuantity, i.LotSize)
+	}
+
+	// Pegged orders are priced by the book and checked once they are
+	priced := order.Type != OrderTypeMarket && order.Type != OrderTypeStopMarket && order.Type != OrderTypeStop
+	if order.IsPegged() {
+		priced = order.Price.IsPositive()
+		if err := i.validatePeg(order); err != nil {
+			return err
+		}
+	}
+	if priced {
+		if err := i.validatePrice(order.Price, i.PriceTick(order), reference); err != nil {
+			return err
+		}
+	}
+	if order.StopPrice.IsPositive() && i.TickSize.IsPositive() && !order.StopPrice.IsMultipleOf(i.TickSize) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidTickSize,
+			"stop price %s is not a multiple of tick size %s", order.StopPrice, i.TickSize)
+	}
+
+	price := order.Price
+	if !priced {
+		price = reference
+	}
+	if price.IsPositive() {
+		return i.validateNotional(price.Mul(order.Quantity))
+	}
+
+	return nil
+}
+
+// validateQuantity checks lot size and quantity limits
+func (i *Instrument) validateQuantity(quantity Decimal) error {
+	if i.LotSize.IsPositive() && !quantity.IsMultipleOf(i.LotSize) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidLotSize,
+			"quantity %s is not a multiple of lot size %s", quantity, i.LotSize)
+	}
+	if i.MinQuantity.IsPositive() && quantity.LessThan(i.MinQuantity) {
+		return NewOrderRejection(i.Symbol, RejectReasonQuantityBelowMinimum,
+			"quantity %s is below minimum %s", quantity, i.MinQuantity)
+	}
+	if i.MaxQuantity.IsPositive() && quantity.GreaterThan(i.MaxQuantity) {
+		return NewOrderRejection(i.Symbol, RejectReasonQuantityAboveMaximum,
+			"quantity %s is above maximum %s", quantity, i.MaxQuantity)
+	}
+	return nil
+}
+
+// validatePeg checks that the peg offset and limit are on the tick size
+func (i *Instrument) validatePeg(order *Order) error {
+	if !i.TickSize.IsPositive() {
+		return nil
+	}
+	if !order.PegOffset.IsMultipleOf(i.TickSize) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidTickSize,
+			"peg offset %s is not a multiple of tick size %s", order.PegOffset, i.TickSize)
+	}
+	if !order.PegLimit.IsMultipleOf(i.TickSize) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidTickSize,
+			"peg limit %s is not a multiple of tick size %s", order.PegLimit, i.TickSize)
+	}
+	return nil
+}
+
+// validatePrice checks the price increment, which is the tick size except
+// for half-tick midpoint pegs, and the static and dynamic price bands
+func (i *Instrument) validatePrice(price, tick, reference Decimal) error {
+	if tick.IsPositive() && !price.IsMultipleOf(tick) {
+		return NewOrderRejection(i.Symbol, RejectReasonInvalidTickSize,
+			"price %s is not a multiple of tick size %s", price, tick)
+	}
+	if i.MinPrice.IsPositive() && price.LessThan(i.MinPrice) {
+		return NewOrderRejection(i.Symbol, RejectReasonPriceOutsideStaticBand,
+			"price %s is below %s", price, i.MinPrice)
+	}
+	if i.MaxPrice.IsPositive() && price.GreaterThan(i.MaxPrice) {
+		return NewOrderRejection(i.Symbol, RejectReasonPriceOutsideStaticBand,
+			"price %s is above %s", price, i.MaxPrice)
+	}
+	if i.DynamicBand.IsPositive() && reference.IsPositive() {
+		maxDeviation := reference.Mul(i.DynamicBand)
+		if price.Sub(reference).Abs().GreaterThan(maxDeviation) {
+			return NewOrderRejection(i.Symbol, RejectReasonPriceOutsideDynamicBand,
+				"price %s deviates more than %s from reference %s", price, maxDeviation, reference)
+		}
+	}
+	return nil
+}
+
+// validateNotional checks the notional limits
+func (i *Instrument) validateNotional(notional Decimal) error {
+	if i.MinNotional.IsPositive() && notional.LessThan(i.MinNotional) {
+		return NewOrderRejection(i.Symbol, RejectReasonNotionalBelowMinimum,
+			"notional %s is below minimum %s", notional, i.MinNotional)
+	}
+	if i.MaxNotional.IsPositive() && notional.GreaterThan(i.MaxNotional) {
+		return NewOrderRejection(i.Symbol, RejectReasonNotionalAboveMaximum,
+			"notional %s is above maximum %s", notional, i.MaxNotional)
+	}
+	return nil
+}
+
+// InstrumentRegistry holds instrument reference data by symbol
+type InstrumentRegistry struct {
+	mu          sync.RWMutex
+	instruments map[string]*Instrument
+	// requireListed rejects orders for symbols that are not registered
+	requireListed bool
+}
+
+// NewInstrumentRegistry creates a new instrument registry
+func NewInstrumentRegistry() *InstrumentRegistry {
+	return &InstrumentRegistry{
+		instruments: make(map[string]*Instrument),
+	}
+}
+
+// Instruments is the process-wide registry consulted by the order
+// validator and the matching engines
+var Instruments = NewInstrumentRegistry()
+
+// Register adds or replaces an instrument and registers its symbol scale
+func (r *InstrumentRegistry) Register(instrument *Instrument) error {
+	if instrument == nil || instrument.Symbol == "" {
+		return ErrInvalidInstrument
+	}
+	if instrument.TickSize.IsNegative() || instrument.LotSize.IsNegative() {
+		return ErrInvalidInstrument
+	}
+	if !instrument.StopTrigger.Valid() {
+		return ErrInvalidInstrument
+	}
+	if !instrument.MatchingAlgorithm.Valid() || instrument.MinAllocation.IsNegative() {
+		return ErrInvalidInstrument
+	}
+	if instrument.IcebergPeakVariance.IsNegative() || instrument.IcebergPeakVariance.GreaterThanOrEqual(NewDecimal(1, 0)) {
+		return ErrInvalidInstrument
+	}
+
+	r.mu.Lock()
+	r.instruments[instrument.Symbol] = instrument
+	r.mu.Unlock()
+
+	scale := instrument.Scale()
+	RegisterSymbolScale(instrument.Symbol, scale.Price, scale.Quantity)
+	return nil
+}
+
+// Remove removes an instrument
+func (r *InstrumentRegistry) Remove(symbol string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.instruments, symbol)
+}
+
+// Get returns the instrument for a symbol
+func (r *InstrumentRegistry) Get(symbol string) (*Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	instrument, exists := r.instruments[symbol]
+	return instrument, exists
+}
+
+// Symbols returns the registered symbols in sorted order
+func (r *InstrumentRegistry) Symbols() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbols := make([]string, 0, len(r.instruments))
+	for symbol := range r.instruments {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// SetRequireListed controls whether orders for unregistered symbols are rejected
+func (r *InstrumentRegistry) SetRequireListed(require bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requireListed = require
+}
+
+// Validate checks an order against the rules of its instrument. Orders for
+// unregistered symbols pass unless the registry requires listing; orders
+// whose price or quantity overflowed the fixed-point range never do.
+func (r *InstrumentRegistry) Validate(order *Order, reference Decimal) error {
+	if order.Price.Overflowed() || order.StopPrice.Overflowed() || order.Quantity.Overflowed() {
+		return NewOrderRejection(order.Symbol, RejectReasonInvalidOrder, "price or quantity out of range")
+	}
+
+	r.mu.RLock()
+	instrument, exists := r.instruments[order.Symbol]
+	requireListed := r.requireListed
+	r.mu.RUnlock()
+
+	if !exists {
+		if requireListed {
+			return NewOrderRejection(order.Symbol, RejectReasonUnknownInstrument, "symbol is not listed")
+		}
+		return nil
+	}
+	return instrument.Validate(order, reference)
+}
+
+// Instrument errors
+var (
+	ErrOrderRejected     = errors.New("order rejected")
+	ErrInvalidInstrument = errors.New("invalid instrument")
+)
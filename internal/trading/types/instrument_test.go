@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrument_Validate(t *testing.T) {
+	instrument := &Instrument{
+		Symbol:         "TEST",
+		TradingEnabled: true,
+		TickSize:       MustParseDecimal("0.05"),
+		LotSize:        MustParseDecimal("10"),
+		MinNotional:    MustParseDecimal("1000"),
+		MinPrice:       MustParseDecimal("1"),
+		MaxPrice:       MustParseDecimal("1000"),
+		DynamicBand:    MustParseDecimal("0.1"),
+	}
+	reference := MustParseDecimal("50")
+
+	tests := []struct {
+		name     string
+		price    string
+		quantity string
+		expected RejectReason
+	}{
+		{"valid", "50.05", "20", RejectReasonNone},
+		{"off tick", "50.03", "10", RejectReasonInvalidTickSize},
+		{"off lot", "50", "15", RejectReasonInvalidLotSize},
+		{"below notional", "50", "10", RejectReasonNotionalBelowMinimum},
+		{"static band", "1500", "10", RejectReasonPriceOutsideStaticBand},
+		{"dynamic band", "60", "10", RejectReasonPriceOutsideDynamicBand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{
+				Symbol:   "TEST",
+				Type:     OrderTypeLimit,
+				Price:    MustParseDecimal(tt.price),
+				Quantity: MustParseDecimal(tt.quantity),
+			}
+			assert.Equal(t, tt.expected, RejectReasonOf(instrument.Validate(order, reference)))
+		})
+	}
+}
+
+func TestInstrumentRegistry_Validate(t *testing.T) {
+	registry := NewInstrumentRegistry()
+	order := &Order{Symbol: "UNLISTED", Type: OrderTypeMarket, Quantity: MustParseDecimal("1")}
+
+	assert.NoError(t, registry.Validate(order, Zero))
+
+	overflowed := &Order{Symbol: "UNLISTED", Type: OrderTypeLimit, Quantity: MustParseDecimal("1"),
+		Price: PriceFromFloat("UNLISTED", 1e12)}
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(registry.Validate(overflowed, Zero)))
+
+	registry.SetRequireListed(true)
+	err := registry.Validate(order, Zero)
+	assert.ErrorIs(t, err, ErrOrderRejected)
+	assert.Equal(t, RejectReasonUnknownInstrument, RejectReasonOf(err))
+
+	require.NoError(t, registry.Register(&Instrument{Symbol: "UNLISTED"}))
+	assert.Equal(t, RejectReasonInstrumentDisabled, RejectReasonOf(registry.Validate(order, Zero)))
+}
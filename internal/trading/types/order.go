@@ -28,6 +28,22 @@ const (
 	OrderTypeStopMarket OrderType = "stop_market"
 )
 
+// TimeInForce represents how long an order remains active
+type TimeInForce string
+
+const (
+	// TimeInForceGTC rests until filled or cancelled
+	TimeInForceGTC TimeInForce = "GTC"
+	// TimeInForceIOC fills what it can immediately and cancels the remainder
+	TimeInForceIOC TimeInForce = "IOC"
+	// TimeInForceFOK fills completely on arrival or not at all
+	TimeInForceFOK TimeInForce = "FOK"
+	// TimeInForceDay rests until the close of the trading session
+	TimeInForceDay TimeInForce = "DAY"
+	// TimeInForceGTD rests until ExpireTime
+	TimeInForceGTD TimeInForce = "GTD"
+)
+
 // OrderStatus represents the status of an order
 type OrderStatus string
 
@@ -61,31 +77,38 @@ type Order struct {
 	// Type is the type of the order
 	Type OrderType
 	// Price is the price of the order
-	Price float64
+	Price Decimal
 	// Quantity is the quantity of the order
-	Quantity float64
+	Quantity Decimal
 	// FilledQuantity is the filled quantity of the order
-	FilledQuantity float64
+	FilledQuantity Decimal
 	// Status is the status of the order
 	Status OrderStatus
 	// CreatedAt is the time the order was created
 	CreatedAt time.Time
 	// UpdatedAt is the time the order was last updated
 	UpdatedAt time.Time
+	// QueuedAt is the time the order joined its price level queue; amends
+	// that lose time priority reset it
+	QueuedAt time.Time
 	// ClientOrderID is the client order ID
 	ClientOrderID string
 	// UserID is the user ID
 	UserID string
+	// AccountGroup groups accounts that must not trade with each other
+	AccountGroup string
 	// StopPrice is the stop price for stop orders
-	StopPrice float64
+	StopPrice Decimal
 	// TimeInForce is the time in force for the order
-	TimeInForce string
+	TimeInForce TimeInForce
+	// SelfTradePrevention overrides the policy mode for this order
+	SelfTradePrevention SelfTradePrevention
 	// Index is the index in the heap
 	Index int
 
 	// Advanced order features
 	// DisplayQuantity is the visible quantity for iceberg orders
-	DisplayQuantity float64
+	DisplayQuantity Decimal
 	// IsHidden indicates if this is a hidden order
 	IsHidden bool
 	// IsPriceImproved indicates if price improvement was applied
@@ -99,11 +122,17 @@ type Order struct {
 	// Priority is the order priority for matching
 	Priority int64
 	// MinQuantity is the minimum quantity for execution
-	MinQuantity float64
+	MinQuantity Decimal
 	// MaxFloor is the maximum floor quantity
-	MaxFloor float64
+	MaxFloor Decimal
 	// ExpireTime is the expiration time for the order
 	ExpireTime time.Time
+	// PegType makes the book set and maintain the price from a reference
+	PegType PegType
+	// PegOffset is added to the peg reference price
+	PegOffset Decimal
+	// PegLimit caps a pegged price: buys never above it, sells never below
+	PegLimit Decimal
 	// Tags are custom tags for the order
 	Tags map[string]string
 }
@@ -115,50 +144,64 @@ func (o *Order) Reset() {
 	o.AssetType = ""
 	o.Side = ""
 	o.Type = ""
-	o.Price = 0
-	o.Quantity = 0
-	o.FilledQuantity = 0
+	o.Price = Zero
+	o.Quantity = Zero
+	o.FilledQuantity = Zero
 	o.Status = ""
 	o.CreatedAt = time.Time{}
 	o.UpdatedAt = time.Time{}
+	o.QueuedAt = time.Time{}
 	o.ClientOrderID = ""
 	o.UserID = ""
-	o.StopPrice = 0
+	o.AccountGroup = ""
+	o.StopPrice = Zero
 	o.TimeInForce = ""
+	o.SelfTradePrevention = SelfTradePreventionNone
 	o.Index = 0
 
 	// Reset advanced features
-	o.DisplayQuantity = 0
+	o.DisplayQuantity = Zero
 	o.IsHidden = false
 	o.IsPriceImproved = false
 	o.EstimatedImpact = 0
 	o.ParentOrderID = ""
 	o.IsIcebergChild = false
 	o.Priority = 0
-	o.MinQuantity = 0
-	o.MaxFloor = 0
+	o.MinQuantity = Zero
+	o.MaxFloor = Zero
 	o.ExpireTime = time.Time{}
+	o.PegType = PegTypeNone
+	o.PegOffset = Zero
+	o.PegLimit = Zero
 	o.Tags = nil
 }
 
 // IsIceberg returns true if this is an iceberg order
 func (o *Order) IsIceberg() bool {
-	return o.DisplayQuantity > 0 && o.DisplayQuantity < o.Quantity
+	return o.DisplayQuantity.IsPositive() && o.DisplayQuantity.LessThan(o.Quantity)
 }
 
 // RemainingQuantity returns the remaining quantity to be filled
-func (o *Order) RemainingQuantity() float64 {
-	return o.Quantity - o.FilledQuantity
+func (o *Order) RemainingQuantity() Decimal {
+	return o.Quantity.Sub(o.FilledQuantity)
 }
 
 // IsFilled returns true if the order is completely filled
 func (o *Order) IsFilled() bool {
-	return o.FilledQuantity >= o.Quantity
+	return o.FilledQuantity.GreaterThanOrEqual(o.Quantity)
 }
 
 // IsPartiallyFilled returns true if the order is partially filled
 func (o *Order) IsPartiallyFilled() bool {
-	return o.FilledQuantity > 0 && o.FilledQuantity < o.Quantity
+	return o.FilledQuantity.IsPositive() && o.FilledQuantity.LessThan(o.Quantity)
+}
+
+// QueueTime returns the time used for time priority within a price level
+func (o *Order) QueueTime() time.Time {
+	if !o.QueuedAt.IsZero() {
+		return o.QueuedAt
+	}
+	return o.CreatedAt
 }
 
 // IsExpired returns true if the order has expired
@@ -166,6 +209,38 @@ func (o *Order) IsExpired() bool {
 	return !o.ExpireTime.IsZero() && time.Now().After(o.ExpireTime)
 }
 
+// IsExpiredAt returns true if the order has expired at the given time
+func (o *Order) IsExpiredAt(now time.Time) bool {
+	return !o.ExpireTime.IsZero() && !now.Before(o.ExpireTime)
+}
+
+// IsImmediate returns true if the order must never rest in the book
+func (o *Order) IsImmediate() bool {
+	return o.TimeInForce == TimeInForceIOC || o.TimeInForce == TimeInForceFOK
+}
+
+// ValidateTimeInForce checks that the time in force is supported and that
+// GTD orders carry an expire time in the future
+func (o *Order) ValidateTimeInForce(now time.Time) error {
+	switch o.TimeInForce {
+	case "", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForceDay:
+		return nil
+	case TimeInForceGTD:
+		if o.ExpireTime.IsZero() {
+			return NewOrderRejection(o.Symbol, RejectReasonInvalidTimeInForce,
+				"GTD order requires an expire time")
+		}
+		if !o.ExpireTime.After(now) {
+			return NewOrderRejection(o.Symbol, RejectReasonInvalidTimeInForce,
+				"expire time %s is not in the future", o.ExpireTime.Format(time.RFC3339))
+		}
+		return nil
+	default:
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidTimeInForce,
+			"unsupported time in force %q", o.TimeInForce)
+	}
+}
+
 // CanMatch returns true if this order can match with another order
 func (o *Order) CanMatch(other *Order) bool {
 	if o.Symbol != other.Symbol {
@@ -180,17 +255,17 @@ func (o *Order) CanMatch(other *Order) bool {
 
 	// Price matching logic
 	if o.Side == OrderSideBuy && other.Side == OrderSideSell {
-		return o.Price >= other.Price
+		return o.Price.GreaterThanOrEqual(other.Price)
 	}
 	if o.Side == OrderSideSell && other.Side == OrderSideBuy {
-		return o.Price <= other.Price
+		return o.Price.LessThanOrEqual(other.Price)
 	}
 
 	return false
 }
 
 // GetEffectiveQuantity returns the effective quantity for matching
-func (o *Order) GetEffectiveQuantity() float64 {
+func (o *Order) GetEffectiveQuantity() Decimal {
 	if o.IsIceberg() {
 		return o.DisplayQuantity
 	}
@@ -0,0 +1,170 @@
+package types
+
+// PegType selects the reference price a pegged order tracks
+type PegType string
+
+const (
+	// PegTypeNone is an ordinary order with a fixed price
+	PegTypeNone PegType = ""
+	// PegTypePrimary tracks the same side of the book: buys peg to the best
+	// bid and sells to the best ask
+	PegTypePrimary PegType = "PRIMARY"
+	// PegTypeMarket tracks the opposite side of the book: buys peg to the
+	// best ask and sells to the best bid
+	PegTypeMarket PegType = "MARKET"
+	// PegTypeMidpoint tracks the midpoint of the best bid and ask
+	PegTypeMidpoint PegType = "MIDPOINT"
+)
+
+// half halves a price exactly, at one more fractional digit
+var half = NewDecimal(5, 1)
+
+// Valid returns true if the peg type is known; empty means not pegged
+func (p PegType) Valid() bool {
+	switch p {
+	case PegTypeNone, PegTypePrimary, PegTypeMarket, PegTypeMidpoint:
+		return true
+	}
+	return false
+}
+
+// IsPegged returns true for orders whose price follows a reference price
+func (o *Order) IsPegged() bool {
+	return o.PegType != PegTypeNone
+}
+
+// ValidatePeg rejects peg instructions the book cannot honour. Pegged
+// orders must be limit orders that are not icebergs, and the limit cap
+// must not be negative.
+func (o *Order) ValidatePeg() error {
+	if !o.PegType.Valid() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"unsupported peg type %q", o.PegType)
+	}
+	if !o.IsPegged() {
+		return nil
+	}
+	if o.Type != OrderTypeLimit {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"pegged orders must be limit orders")
+	}
+	if o.IsIceberg() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"pegged orders cannot be iceberg orders")
+	}
+	if o.PegLimit.IsNegative() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"peg limit must not be negative")
+	}
+	return nil
+}
+
+// PegPrice returns the price a pegged order takes given the best unpegged
+// bid and ask of the book: the reference price plus the peg offset,
+// capped at the peg limit and rounded away from the opposite side onto the
+// order's price grid. It returns false when the reference price is not
+// available, e.g. a midpoint peg facing a one-sided book.
+func (o *Order) PegPrice(bid, ask Decimal) (Decimal, bool) {
+	same, opposite := bid, ask
+	if o.Side == OrderSideSell {
+		same, opposite = ask, bid
+	}
+
+	var reference Decimal
+	switch o.PegType {
+	case PegTypePrimary:
+		reference = same
+	case PegTypeMarket:
+		reference = opposite
+	case PegTypeMidpoint:
+		if !bid.IsPositive() || !ask.IsPositive() {
+			return Zero, false
+		}
+		scale := maxScale(bid, ask)
+		reference = bid.Add(ask).Mul(half)
+		if exact := reference.Truncate(scale); exact.Equal(reference) {
+			reference = exact
+		}
+	default:
+		return Zero, false
+	}
+	if !reference.IsPositive() {
+		return Zero, false
+	}
+
+	price := reference.Add(o.PegOffset)
+	if o.PegLimit.IsPositive() {
+		if o.Side == OrderSideBuy {
+			price = MinDecimal(price, o.PegLimit)
+		} else {
+			price = MaxDecimal(price, o.PegLimit)
+		}
+	}
+
+	if tick := PegTick(o); !price.IsMultipleOf(tick) {
+		floor := tick.MulInt(wholeLots(price, tick))
+		if o.Side == OrderSideBuy {
+			price = floor
+		} else {
+			price = floor.Add(tick)
+		}
+	}
+	if !price.IsPositive() {
+		return Zero, false
+	}
+	return price, true
+}
+
+// PassivePegPrice keeps a repriced pegged order from reaching the best
+// opposite price: a buy moves to the highest price on its grid below it
+// and a sell to the lowest price above it. Prices short of the opposite
+// price, or a zero opposite price, leave the price unchanged.
+func (o *Order) PassivePegPrice(price, opposite Decimal) Decimal {
+	if !opposite.IsPositive() {
+		return price
+	}
+	if o.Side == OrderSideBuy && price.LessThan(opposite) || o.Side == OrderSideSell && price.GreaterThan(opposite) {
+		return price
+	}
+
+	tick := PegTick(o)
+	if !tick.IsPositive() {
+		tick = NewDecimal(1, maxScale(price, opposite))
+	}
+	floor := tick.MulInt(wholeLots(opposite, tick))
+	if o.Side == OrderSideSell {
+		return floor.Add(tick)
+	}
+	if floor.Equal(opposite) {
+		floor = floor.Sub(tick)
+	}
+	return floor
+}
+
+// PegTick returns the price increment of a pegged order under its
+// symbol's instrument, zero for symbols without reference data
+func PegTick(order *Order) Decimal {
+	instrument, exists := Instruments.Get(order.Symbol)
+	if !exists {
+		return Zero
+	}
+	return instrument.PriceTick(order)
+}
+
+// PriceTick returns the price increment of an order: half the tick size
+// for midpoint pegs when the instrument allows half-tick execution, the
+// tick size otherwise
+func (i *Instrument) PriceTick(order *Order) Decimal {
+	if order.PegType == PegTypeMidpoint && i.MidpointHalfTick {
+		return i.TickSize.Mul(half)
+	}
+	return i.TickSize
+}
+
+// maxScale returns the larger scale of two decimals
+func maxScale(a, b Decimal) uint8 {
+	if a.Scale() > b.Scale() {
+		return a.Scale()
+	}
+	return b.Scale()
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_PegPrice(t *testing.T) {
+	registry := Instruments
+	Instruments = NewInstrumentRegistry()
+	defer func() { Instruments = registry }()
+
+	require.NoError(t, Instruments.Register(&Instrument{Symbol: "AAPL", TradingEnabled: true, TickSize: MustParseDecimal("0.01"), MidpointHalfTick: true}))
+	require.NoError(t, Instruments.Register(&Instrument{Symbol: "MSFT", TradingEnabled: true, TickSize: MustParseDecimal("0.01")}))
+
+	bid, ask := MustParseDecimal("100.00"), MustParseDecimal("100.01")
+	tests := []struct {
+		name     string
+		symbol   string
+		side     OrderSide
+		peg      PegType
+		offset   string
+		limit    string
+		expected string
+	}{
+		{"primary buy", "AAPL", OrderSideBuy, PegTypePrimary, "0", "0", "100.00"},
+		{"primary buy offset", "AAPL", OrderSideBuy, PegTypePrimary, "-0.02", "0", "99.98"},
+		{"market sell", "AAPL", OrderSideSell, PegTypeMarket, "0.01", "0", "100.01"},
+		{"midpoint half tick", "AAPL", OrderSideBuy, PegTypeMidpoint, "0", "0", "100.005"},
+		{"midpoint buy rounds down", "MSFT", OrderSideBuy, PegTypeMidpoint, "0", "0", "100.00"},
+		{"midpoint sell rounds up", "MSFT", OrderSideSell, PegTypeMidpoint, "0", "0", "100.01"},
+		{"buy limit caps", "AAPL", OrderSideBuy, PegTypeMarket, "0", "100.00", "100.00"},
+		{"sell limit caps", "AAPL", OrderSideSell, PegTypeMarket, "0", "100.05", "100.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{
+				Symbol:    tt.symbol,
+				Side:      tt.side,
+				Type:      OrderTypeLimit,
+				PegType:   tt.peg,
+				PegOffset: MustParseDecimal(tt.offset),
+				PegLimit:  MustParseDecimal(tt.limit),
+			}
+			price, priced := order.PegPrice(bid, ask)
+			require.True(t, priced)
+			assert.Equal(t, tt.expected, price.String())
+		})
+	}
+
+	midpoint := &Order{Symbol: "AAPL", Side: OrderSideBuy, Type: OrderTypeLimit, PegType: PegTypeMidpoint}
+	_, priced := midpoint.PegPrice(bid, Zero)
+	assert.False(t, priced, "a midpoint peg needs both sides")
+
+	// Half-tick prices pass the tick check for midpoint pegs only
+	midpoint.Quantity = MustParseDecimal("10")
+	midpoint.Price = MustParseDecimal("100.005")
+	assert.NoError(t, Instruments.Validate(midpoint, Zero))
+	limit := &Order{Symbol: "AAPL", Side: OrderSideBuy, Type: OrderTypeLimit, Price: MustParseDecimal("100.005"), Quantity: MustParseDecimal("10")}
+	assert.Equal(t, RejectReasonInvalidTickSize, RejectReasonOf(Instruments.Validate(limit, Zero)))
+}
+
+func TestOrder_PassivePegPrice(t *testing.T) {
+	registry := Instruments
+	Instruments = NewInstrumentRegistry()
+	defer func() { Instruments = registry }()
+
+	require.NoError(t, Instruments.Register(&Instrument{Symbol: "AAPL", TradingEnabled: true, TickSize: MustParseDecimal("0.01"), MidpointHalfTick: true}))
+
+	buy := &Order{Symbol: "AAPL", Side: OrderSideBuy, PegType: PegTypeMarket}
+	assert.Equal(t, "100.09", buy.PassivePegPrice(MustParseDecimal("100.10"), MustParseDecimal("100.10")).String())
+	assert.Equal(t, "100.00", buy.PassivePegPrice(MustParseDecimal("100.00"), MustParseDecimal("100.10")).String())
+	assert.Equal(t, "100.00", buy.PassivePegPrice(MustParseDecimal("100.01"), MustParseDecimal("100.005")).String())
+
+	sell := &Order{Symbol: "AAPL", Side: OrderSideSell, PegType: PegTypeMidpoint}
+	assert.Equal(t, "100.010", sell.PassivePegPrice(MustParseDecimal("100.005"), MustParseDecimal("100.005")).String())
+	assert.Equal(t, "100.5", sell.PassivePegPrice(MustParseDecimal("100.5"), Zero).String())
+}
+
+func TestOrder_ValidatePeg(t *testing.T) {
+	assert.NoError(t, (&Order{Type: OrderTypeMarket}).ValidatePeg())
+	assert.NoError(t, (&Order{Type: OrderTypeLimit, PegType: PegTypeMidpoint, IsHidden: true}).ValidatePeg())
+
+	market := &Order{Type: OrderTypeMarket, PegType: PegTypePrimary}
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(market.ValidatePeg()))
+
+	iceberg := &Order{Type: OrderTypeLimit, PegType: PegTypePrimary, Quantity: MustParseDecimal("100"), DisplayQuantity: MustParseDecimal("10")}
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(iceberg.ValidatePeg()))
+
+	unknown := &Order{Type: OrderTypeLimit, PegType: "CLOSE"}
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(unknown.ValidatePeg()))
+}
@@ -0,0 +1,129 @@
+package types
+
+import "math/big"
+
+// MatchingAlgorithm selects how an incoming order is allocated among the
+// resting orders at the best price level of a symbol
+type MatchingAlgorithm string
+
+const (
+	// MatchingAlgorithmPriceTime fills resting orders in time priority
+	MatchingAlgorithmPriceTime MatchingAlgorithm = "PRICE_TIME"
+	// MatchingAlgorithmProRata allocates in proportion to resting quantity
+	MatchingAlgorithmProRata MatchingAlgorithm = "PRO_RATA"
+	// MatchingAlgorithmProRataTopOrder fills the oldest order at the level
+	// first and allocates the rest pro rata
+	MatchingAlgorithmProRataTopOrder MatchingAlgorithm = "PRO_RATA_TOP_ORDER"
+)
+
+// Valid returns true if the algorithm is known; empty means PRICE_TIME
+func (a MatchingAlgorithm) Valid() bool {
+	switch a {
+	case "", MatchingAlgorithmPriceTime, MatchingAlgorithmProRata, MatchingAlgorithmProRataTopOrder:
+		return true
+	}
+	return false
+}
+
+// IsProRata returns true for the pro-rata algorithms
+func (a MatchingAlgorithm) IsProRata() bool {
+	return a == MatchingAlgorithmProRata || a == MatchingAlgorithmProRataTopOrder
+}
+
+// AllocationRule is the pro-rata allocation rule of a symbol
+type AllocationRule struct {
+	// TopOrder fills the oldest resting order before allocating
+	TopOrder bool
+	// MinAllocation is the smallest pro-rata share an order receives;
+	// smaller shares are dropped and go to the leftover
+	MinAllocation Decimal
+	// LotSize is the allocation unit; shares are rounded down to it
+	LotSize Decimal
+}
+
+// AllocationRuleFor returns the pro-rata allocation rule of a symbol. It
+// returns false if the symbol matches in price-time priority.
+func AllocationRuleFor(symbol string) (AllocationRule, bool) {
+	instrument, exists := Instruments.Get(symbol)
+	if !exists || !instrument.MatchingAlgorithm.IsProRata() {
+		return AllocationRule{}, false
+	}
+	return AllocationRule{
+		TopOrder:      instrument.MatchingAlgorithm == MatchingAlgorithmProRataTopOrder,
+		MinAllocation: instrument.MinAllocation,
+		LotSize:       instrument.LotSize,
+	}, true
+}
+
+// Allocate splits quantity among resting quantities given in time
+// priority and returns each order's share. With TopOrder the first order
+// is filled first. The rest is shared in proportion to each order's
+// remaining quantity, rounded down to whole lots, dropping shares below
+// MinAllocation. The leftover is filled in time priority, so the shares
+// always add up to the smaller of quantity and the resting total.
+func (r AllocationRule) Allocate(quantity Decimal, resting []Decimal) []Decimal {
+	shares := make([]Decimal, len(resting))
+	capacity := make([]Decimal, len(resting))
+	total := Zero
+	for i, q := range resting {
+		shares[i] = Zero
+		capacity[i] = q
+		total = total.Add(q)
+	}
+	if !total.GreaterThan(quantity) {
+		copy(shares, resting)
+		return shares
+	}
+
+	remaining := quantity
+	if r.TopOrder && len(resting) > 0 {
+		shares[0] = MinDecimal(remaining, resting[0])
+		capacity[0] = capacity[0].Sub(shares[0])
+		total = total.Sub(shares[0])
+		remaining = remaining.Sub(shares[0])
+	}
+
+	lot := r.LotSize
+	if !lot.IsPositive() {
+		lot = NewDecimal(1, quantity.Scale())
+	}
+	if remaining.IsPositive() && total.IsPositive() {
+		lots := wholeLots(remaining, lot)
+		allocated := Zero
+		for i := range resting {
+			amount := lot.MulInt(proRataLots(lots, capacity[i], total))
+			if amount.IsPositive() && amount.LessThan(r.MinAllocation) {
+				amount = Zero
+			}
+			amount = MinDecimal(amount, capacity[i])
+			shares[i] = shares[i].Add(amount)
+			capacity[i] = capacity[i].Sub(amount)
+			allocated = allocated.Add(amount)
+		}
+		remaining = remaining.Sub(allocated)
+	}
+
+	// Leftover in time priority
+	for i := range resting {
+		if !remaining.IsPositive() {
+			break
+		}
+		amount := MinDecimal(remaining, capacity[i])
+		shares[i] = shares[i].Add(amount)
+		remaining = remaining.Sub(amount)
+	}
+	return shares
+}
+
+// wholeLots returns the number of whole lots in q
+func wholeLots(q, lot Decimal) int64 {
+	scale := maxScale(q, lot)
+	return new(big.Int).Quo(q.big(scale), lot.big(scale)).Int64()
+}
+
+// proRataLots returns floor(lots * part / total)
+func proRataLots(lots int64, part, total Decimal) int64 {
+	scale := maxScale(part, total)
+	share := new(big.Int).Mul(big.NewInt(lots), part.big(scale))
+	return share.Quo(share, total.big(scale)).Int64()
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationRule_Allocate(t *testing.T) {
+	decimals := func(values ...string) []Decimal {
+		result := make([]Decimal, len(values))
+		for i, value := range values {
+			result[i] = MustParseDecimal(value)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		rule     AllocationRule
+		quantity string
+		resting  []string
+		shares   []string
+	}{
+		{
+			name:     "proportional shares",
+			rule:     AllocationRule{LotSize: MustParseDecimal("1")},
+			quantity: "50",
+			resting:  []string{"20", "30", "50"},
+			shares:   []string{"10", "15", "25"},
+		},
+		{
+			name:     "leftover lots in time priority",
+			rule:     AllocationRule{LotSize: MustParseDecimal("1")},
+			quantity: "10",
+			resting:  []string{"10", "10", "10"},
+			shares:   []string{"4", "3", "3"},
+		},
+		{
+			name:     "minimum allocation drops small shares",
+			rule:     AllocationRule{LotSize: MustParseDecimal("1"), MinAllocation: MustParseDecimal("5")},
+			quantity: "20",
+			resting:  []string{"10", "80", "10"},
+			shares:   []string{"4", "16", "0"},
+		},
+		{
+			name:     "top order first",
+			rule:     AllocationRule{TopOrder: true, LotSize: MustParseDecimal("1")},
+			quantity: "40",
+			resting:  []string{"10", "60", "30"},
+			shares:   []string{"10", "20", "10"},
+		},
+		{
+			name:     "quantity covers the level",
+			rule:     AllocationRule{LotSize: MustParseDecimal("1")},
+			quantity: "80",
+			resting:  []string{"20", "30"},
+			shares:   []string{"20", "30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares := tt.rule.Allocate(MustParseDecimal(tt.quantity), decimals(tt.resting...))
+			expected := decimals(tt.shares...)
+			assert.Len(t, shares, len(expected))
+			for i := range expected {
+				assert.True(t, expected[i].Equal(shares[i]), "share %d: %s", i, shares[i])
+			}
+		})
+	}
+}
+
+func TestAllocationRuleFor(t *testing.T) {
+	registry := Instruments
+	Instruments = NewInstrumentRegistry()
+	defer func() { Instruments = registry }()
+
+	assert.NoError(t, Instruments.Register(&Instrument{
+		Symbol:            "SUKUK",
+		TradingEnabled:    true,
+		LotSize:           MustParseDecimal("10"),
+		MatchingAlgorithm: MatchingAlgorithmProRataTopOrder,
+		MinAllocation:     MustParseDecimal("20"),
+	}))
+
+	rule, ok := AllocationRuleFor("SUKUK")
+	assert.True(t, ok)
+	assert.True(t, rule.TopOrder)
+	assert.True(t, MustParseDecimal("20").Equal(rule.MinAllocation))
+
+	_, ok = AllocationRuleFor("AAPL")
+	assert.False(t, ok)
+
+	err := Instruments.Register(&Instrument{Symbol: "BAD", MatchingAlgorithm: "FIFO"})
+	assert.ErrorIs(t, err, ErrInvalidInstrument)
+}
@@ -0,0 +1,176 @@
+package types
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// TradingSchedule describes the daily trading session of an exchange: an
+// optional opening auction call from PreOpen to Open, continuous trading,
+// and an optional closing auction call from PreClose to Close. Times are
+// offsets from local midnight in Location.
+type TradingSchedule struct {
+	// Exchange is the exchange code
+	Exchange string `json:"exchange"`
+	// Location is the exchange time zone
+	Location *time.Location `json:"-"`
+	// PreOpen is the start of the opening auction call; zero means none
+	PreOpen time.Duration `json:"pre_open,omitempty"`
+	// Open is the start of continuous trading
+	Open time.Duration `json:"open"`
+	// PreClose is the start of the closing auction call; zero means none
+	PreClose time.Duration `json:"pre_close,omitempty"`
+	// Close is the end of the session
+	Close time.Duration `json:"close"`
+	// TradingDays are the weekdays the exchange is open; empty means every day
+	TradingDays []time.Weekday `json:"trading_days,omitempty"`
+	// Holidays are dates (in Location) the exchange is closed
+	Holidays []time.Time `json:"holidays,omitempty"`
+}
+
+// location returns the schedule time zone, defaulting to UTC
+func (s *TradingSchedule) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+// IsTradingDay returns true if the exchange is open on the day containing t
+func (s *TradingSchedule) IsTradingDay(t time.Time) bool {
+	local := t.In(s.location())
+
+	for _, holiday := range s.Holidays {
+		h := holiday.In(s.location())
+		if h.Year() == local.Year() && h.YearDay() == local.YearDay() {
+			return false
+		}
+	}
+
+	if len(s.TradingDays) == 0 {
+		return true
+	}
+	for _, day := range s.TradingDays {
+		if day == local.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// midnight returns local midnight of the day containing t
+func (s *TradingSchedule) midnight(t time.Time) time.Time {
+	local := t.In(s.location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location())
+}
+
+// IsOpen returns true if the market is open at t, from the start of
+// continuous trading to the close
+func (s *TradingSchedule) IsOpen(t time.Time) bool {
+	if !s.IsTradingDay(t) {
+		return false
+	}
+	midnight := s.midnight(t)
+	return !t.Before(midnight.Add(s.Open)) && t.Before(midnight.Add(s.Close))
+}
+
+// Phase returns the trading phase at t
+func (s *TradingSchedule) Phase(t time.Time) TradingPhase {
+	if !s.IsTradingDay(t) {
+		return TradingPhaseClosed
+	}
+
+	midnight := s.midnight(t)
+	switch {
+	case s.PreOpen > 0 && !t.Before(midnight.Add(s.PreOpen)) && t.Before(midnight.Add(s.Open)):
+		return TradingPhasePreOpen
+	case s.PreClose > 0 && !t.Before(midnight.Add(s.PreClose)) && t.Before(midnight.Add(s.Close)):
+		return TradingPhasePreClose
+	case s.IsOpen(t):
+		return TradingPhaseContinuous
+	default:
+		return TradingPhaseClosed
+	}
+}
+
+// SessionClose returns the close of the current session, or of the next
+// trading session if the market has already closed for the day
+func (s *TradingSchedule) SessionClose(t time.Time) time.Time {
+	day := s.midnight(t)
+	for i := 0; i < 14; i++ {
+		if s.IsTradingDay(day) {
+			if closeTime := day.Add(s.Close); t.Before(closeTime) {
+				return closeTime
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return s.midnight(t).AddDate(0, 0, 1)
+}
+
+// ScheduleRegistry holds the trading schedules of session-based exchanges,
+// keyed by exchange code
+type ScheduleRegistry struct {
+	mu        sync.RWMutex
+	schedules map[string]*TradingSchedule
+}
+
+// NewScheduleRegistry creates an empty schedule registry
+func NewScheduleRegistry() *ScheduleRegistry {
+	return &ScheduleRegistry{
+		schedules: make(map[string]*TradingSchedule),
+	}
+}
+
+// Register adds or replaces the schedule of an exchange
+func (r *ScheduleRegistry) Register(schedule *TradingSchedule) error {
+	if schedule == nil || schedule.Exchange == "" {
+		return ErrInvalidSchedule
+	}
+	if schedule.Open >= schedule.Close || schedule.PreOpen > schedule.Open {
+		return ErrInvalidSchedule
+	}
+	if schedule.PreClose > 0 && (schedule.PreClose < schedule.Open || schedule.PreClose > schedule.Close) {
+		return ErrInvalidSchedule
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedules[schedule.Exchange] = schedule
+	return nil
+}
+
+// Get returns the schedule of an exchange
+func (r *ScheduleRegistry) Get(exchange string) (*TradingSchedule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schedule, exists := r.schedules[exchange]
+	return schedule, exists
+}
+
+// ErrInvalidSchedule is returned when registering an unnamed schedule or
+// one whose session times are out of order
+var ErrInvalidSchedule = errors.New("invalid trading schedule")
+
+// Schedules is the process-wide schedule registry, loaded from the
+// exchange configuration at startup
+var Schedules = NewScheduleRegistry()
+
+// ScheduleForExchange returns the registered trading schedule of an
+// exchange code, or nil for continuous markets
+func ScheduleForExchange(exchange string) *TradingSchedule {
+	if schedule, exists := Schedules.Get(exchange); exists {
+		return schedule
+	}
+	return nil
+}
+
+// SessionCloseFor returns when DAY orders for a symbol expire: the session
+// close of the instrument's schedule, or local end of day without one
+func SessionCloseFor(symbol string, t time.Time) time.Time {
+	if instrument, exists := Instruments.Get(symbol); exists && instrument.Schedule != nil {
+		return instrument.Schedule.SessionClose(t)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// egxSchedule returns the EGX session as configured in config/tradsys.yaml
+func egxSchedule() *TradingSchedule {
+	return &TradingSchedule{
+		Exchange: "EGX",
+		Location: time.FixedZone("Africa/Cairo", 3*60*60),
+		PreOpen:  9*time.Hour + 30*time.Minute,
+		Open:     10 * time.Hour,
+		PreClose: 14*time.Hour + 20*time.Minute,
+		Close:    14*time.Hour + 30*time.Minute,
+		TradingDays: []time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+		},
+	}
+}
+
+func TestTradingSchedule_SessionClose(t *testing.T) {
+	schedule := egxSchedule()
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, schedule.Location)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+		open     bool
+	}{
+		{"during session", at(15, 12, 0), at(15, 14, 30), true},
+		{"before open", at(15, 9, 0), at(15, 14, 30), false},
+		{"after close rolls to next trading day", at(15, 15, 0), at(18, 14, 30), false},
+		{"weekend", at(16, 12, 0), at(18, 14, 30), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(schedule.SessionClose(tt.now)))
+			assert.Equal(t, tt.open, schedule.IsOpen(tt.now))
+		})
+	}
+}
+
+func TestOrder_ValidateTimeInForce(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, (&Order{TimeInForce: TimeInForceIOC}).ValidateTimeInForce(now))
+	assert.NoError(t, (&Order{TimeInForce: TimeInForceGTD, ExpireTime: now.Add(time.Hour)}).ValidateTimeInForce(now))
+
+	err := (&Order{TimeInForce: TimeInForceGTD}).ValidateTimeInForce(now)
+	assert.Equal(t, RejectReasonInvalidTimeInForce, RejectReasonOf(err))
+
+	err = (&Order{TimeInForce: TimeInForceGTD, ExpireTime: now.Add(-time.Hour)}).ValidateTimeInForce(now)
+	assert.Equal(t, RejectReasonInvalidTimeInForce, RejectReasonOf(err))
+
+	err = (&Order{TimeInForce: "GTX"}).ValidateTimeInForce(now)
+	assert.Equal(t, RejectReasonInvalidTimeInForce, RejectReasonOf(err))
+}
+
+func TestTradingSchedule_Phase(t *testing.T) {
+	schedule := egxSchedule()
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, schedule.Location)
+	}
+
+	assert.Equal(t, TradingPhaseClosed, schedule.Phase(at(15, 9, 0)))
+	assert.Equal(t, TradingPhasePreOpen, schedule.Phase(at(15, 9, 45)))
+	assert.Equal(t, TradingPhaseContinuous, schedule.Phase(at(15, 12, 0)))
+	assert.Equal(t, TradingPhasePreClose, schedule.Phase(at(15, 14, 25)))
+	assert.Equal(t, TradingPhaseClosed, schedule.Phase(at(15, 14, 30)))
+	assert.Equal(t, TradingPhaseClosed, schedule.Phase(at(16, 12, 0)))
+
+	assert.True(t, TradingPhasePreOpen.Uncrosses(TradingPhaseContinuous))
+	assert.True(t, TradingPhasePreClose.Uncrosses(TradingPhaseClosed))
+	assert.False(t, TradingPhaseClosed.Uncrosses(TradingPhasePreOpen))
+	assert.False(t, TradingPhaseContinuous.Uncrosses(TradingPhasePreClose))
+}
+
+func TestScheduleRegistry(t *testing.T) {
+	registry, schedules := NewScheduleRegistry(), Schedules
+	Schedules = registry
+	defer func() { Schedules = schedules }()
+
+	assert.Nil(t, ScheduleForExchange("EGX"))
+	assert.NoError(t, registry.Register(egxSchedule()))
+	assert.Equal(t, 10*time.Hour, ScheduleForExchange("EGX").Open)
+	assert.Nil(t, ScheduleForExchange("NASDAQ"))
+
+	unnamed := egxSchedule()
+	unnamed.Exchange = ""
+	assert.Equal(t, ErrInvalidSchedule, registry.Register(unnamed))
+
+	inverted := egxSchedule()
+	inverted.Exchange = "ADX"
+	inverted.Open, inverted.Close = inverted.Close, inverted.Open
+	assert.Equal(t, ErrInvalidSchedule, registry.Register(inverted))
+
+	late := egxSchedule()
+	late.Exchange = "ADX"
+	late.PreClose = 15 * time.Hour
+	assert.Equal(t, ErrInvalidSchedule, registry.Register(late))
+	_, exists := registry.Get("ADX")
+	assert.False(t, exists)
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SelfTradePrevention is the action taken when an order would match a
+// resting order from the same user or account group
+type SelfTradePrevention string
+
+const (
+	// SelfTradePreventionNone allows self-trades
+	SelfTradePreventionNone SelfTradePrevention = ""
+	// SelfTradePreventionCancelNewest cancels the incoming order
+	SelfTradePreventionCancelNewest SelfTradePrevention = "CANCEL_NEWEST"
+	// SelfTradePreventionCancelOldest cancels the resting order
+	SelfTradePreventionCancelOldest SelfTradePrevention = "CANCEL_OLDEST"
+	// SelfTradePreventionCancelBoth cancels both orders
+	SelfTradePreventionCancelBoth SelfTradePrevention = "CANCEL_BOTH"
+	// SelfTradePreventionDecrementAndCancel reduces both orders by the
+	// smaller remaining quantity and cancels whichever reaches zero
+	SelfTradePreventionDecrementAndCancel SelfTradePrevention = "DECREMENT_AND_CANCEL"
+)
+
+// Valid returns true if the mode is known
+func (m SelfTradePrevention) Valid() bool {
+	switch m {
+	case SelfTradePreventionNone, SelfTradePreventionCancelNewest, SelfTradePreventionCancelOldest,
+		SelfTradePreventionCancelBoth, SelfTradePreventionDecrementAndCancel:
+		return true
+	}
+	return false
+}
+
+// SelfTradeKey returns the identity compared for self-trade prevention: the
+// account group when set, otherwise the user
+func SelfTradeKey(userID, accountGroup string) string {
+	if accountGroup != "" {
+		return "group:" + accountGroup
+	}
+	if userID != "" {
+		return "user:" + userID
+	}
+	return ""
+}
+
+// SelfTradeKey returns the order's self-trade prevention identity
+func (o *Order) SelfTradeKey() string {
+	return SelfTradeKey(o.UserID, o.AccountGroup)
+}
+
+// IsSelfTrade returns true if both orders belong to the same user or account group
+func IsSelfTrade(taker, maker *Order) bool {
+	key := taker.SelfTradeKey()
+	return key != "" && key == maker.SelfTradeKey()
+}
+
+// SelfTradePolicy resolves the self-trade prevention mode for users and
+// account groups
+type SelfTradePolicy struct {
+	defaultMode SelfTradePrevention
+	groups      map[string]SelfTradePrevention
+	users       map[string]SelfTradePrevention
+	mu          sync.RWMutex
+}
+
+// NewSelfTradePolicy creates a policy applying defaultMode to everyone
+func NewSelfTradePolicy(defaultMode SelfTradePrevention) *SelfTradePolicy {
+	return &SelfTradePolicy{
+		defaultMode: defaultMode,
+		groups:      make(map[string]SelfTradePrevention),
+		users:       make(map[string]SelfTradePrevention),
+	}
+}
+
+// SetDefault sets the mode used when no user or group mode applies
+func (p *SelfTradePolicy) SetDefault(mode SelfTradePrevention) error {
+	if !mode.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSelfTradePrevention, mode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.defaultMode = mode
+	return nil
+}
+
+// SetGroupMode sets the mode for an account group
+func (p *SelfTradePolicy) SetGroupMode(accountGroup string, mode SelfTradePrevention) error {
+	if !mode.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSelfTradePrevention, mode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.groups[accountGroup] = mode
+	return nil
+}
+
+// SetUserMode sets the mode for a user
+func (p *SelfTradePolicy) SetUserMode(userID string, mode SelfTradePrevention) error {
+	if !mode.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSelfTradePrevention, mode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.users[userID] = mode
+	return nil
+}
+
+// Mode returns the mode for an order's user and account group. An explicit
+// order mode wins, then the user's mode, then the group's, then the default.
+func (p *SelfTradePolicy) Mode(userID, accountGroup string, override SelfTradePrevention) SelfTradePrevention {
+	if override != SelfTradePreventionNone {
+		return override
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if mode, exists := p.users[userID]; exists && userID != "" {
+		return mode
+	}
+	if mode, exists := p.groups[accountGroup]; exists && accountGroup != "" {
+		return mode
+	}
+	return p.defaultMode
+}
+
+// ModeFor returns the mode that applies to an incoming order
+func (p *SelfTradePolicy) ModeFor(order *Order) SelfTradePrevention {
+	return p.Mode(order.UserID, order.AccountGroup, order.SelfTradePrevention)
+}
+
+// SelfTradePolicies is the process-wide self-trade prevention policy
+var SelfTradePolicies = NewSelfTradePolicy(SelfTradePreventionNone)
+
+// ErrInvalidSelfTradePrevention is returned for unknown self-trade prevention modes
+var ErrInvalidSelfTradePrevention = errors.New("invalid self-trade prevention mode")
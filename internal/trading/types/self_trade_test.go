@@ -0,0 +1,29 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfTradePolicy_Mode(t *testing.T) {
+	policy := NewSelfTradePolicy(SelfTradePreventionCancelNewest)
+	assert.NoError(t, policy.SetGroupMode("mm", SelfTradePreventionCancelOldest))
+	assert.NoError(t, policy.SetUserMode("alice", SelfTradePreventionDecrementAndCancel))
+
+	assert.Equal(t, SelfTradePreventionCancelNewest, policy.Mode("bob", "", SelfTradePreventionNone))
+	assert.Equal(t, SelfTradePreventionCancelOldest, policy.Mode("bob", "mm", SelfTradePreventionNone))
+	assert.Equal(t, SelfTradePreventionDecrementAndCancel, policy.Mode("alice", "mm", SelfTradePreventionNone))
+	assert.Equal(t, SelfTradePreventionCancelBoth, policy.Mode("alice", "mm", SelfTradePreventionCancelBoth))
+
+	err := policy.SetDefault("CANCEL_ALL")
+	assert.True(t, errors.Is(err, ErrInvalidSelfTradePrevention))
+}
+
+func TestIsSelfTrade(t *testing.T) {
+	assert.True(t, IsSelfTrade(&Order{UserID: "alice"}, &Order{UserID: "alice"}))
+	assert.False(t, IsSelfTrade(&Order{UserID: "alice"}, &Order{UserID: "bob"}))
+	assert.True(t, IsSelfTrade(&Order{UserID: "alice", AccountGroup: "mm"}, &Order{UserID: "bob", AccountGroup: "mm"}))
+	assert.False(t, IsSelfTrade(&Order{}, &Order{}))
+}
@@ -0,0 +1,70 @@
+package types
+
+// StopTrigger selects the price that elects stop orders for a symbol
+type StopTrigger string
+
+const (
+	// StopTriggerLast elects stops on the last trade price
+	StopTriggerLast StopTrigger = "LAST"
+	// StopTriggerMark elects stops on the mark price
+	StopTriggerMark StopTrigger = "MARK"
+	// StopTriggerBidAsk elects buy stops on the best ask and sell stops on
+	// the best bid
+	StopTriggerBidAsk StopTrigger = "BID_ASK"
+)
+
+// Valid returns true if the trigger is known; empty means LAST
+func (t StopTrigger) Valid() bool {
+	switch t {
+	case "", StopTriggerLast, StopTriggerMark, StopTriggerBidAsk:
+		return true
+	}
+	return false
+}
+
+// StopTriggerFor returns the stop trigger configured for a symbol, LAST
+// when the instrument does not set one
+func StopTriggerFor(symbol string) StopTrigger {
+	if instrument, exists := Instruments.Get(symbol); exists && instrument.StopTrigger != "" {
+		return instrument.StopTrigger
+	}
+	return StopTriggerLast
+}
+
+// IsStop returns true for orders that rest until their stop price is reached
+func (o *Order) IsStop() bool {
+	return o.Type == OrderTypeStop || o.Type == OrderTypeStopLimit || o.Type == OrderTypeStopMarket
+}
+
+// ValidateStop rejects stop orders without a positive stop price
+func (o *Order) ValidateStop() error {
+	if o.IsStop() && !o.StopPrice.IsPositive() {
+		return NewOrderRejection(o.Symbol, RejectReasonInvalidOrder,
+			"stop price must be positive")
+	}
+	return nil
+}
+
+// StopElected returns true if a trigger price reaches the order's stop
+// price: at or above it for buy stops, at or below it for sell stops. A
+// zero trigger price never elects.
+func (o *Order) StopElected(trigger Decimal) bool {
+	if !trigger.IsPositive() {
+		return false
+	}
+	if o.Side == OrderSideBuy {
+		return trigger.GreaterThanOrEqual(o.StopPrice)
+	}
+	return trigger.LessThanOrEqual(o.StopPrice)
+}
+
+// Elect converts an elected stop order into the order it releases:
+// stop-limit orders become limit orders, stop and stop-market orders
+// become market orders
+func (o *Order) Elect() {
+	if o.Type == OrderTypeStopLimit {
+		o.Type = OrderTypeLimit
+	} else {
+		o.Type = OrderTypeMarket
+	}
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_StopElected(t *testing.T) {
+	buy := &Order{Side: OrderSideBuy, Type: OrderTypeStopLimit, StopPrice: MustParseDecimal("101")}
+	sell := &Order{Side: OrderSideSell, Type: OrderTypeStop, StopPrice: MustParseDecimal("99")}
+
+	assert.False(t, buy.StopElected(MustParseDecimal("100.5")))
+	assert.True(t, buy.StopElected(MustParseDecimal("101")))
+	assert.False(t, sell.StopElected(MustParseDecimal("99.5")))
+	assert.True(t, sell.StopElected(MustParseDecimal("98")))
+	assert.False(t, sell.StopElected(Zero))
+
+	buy.Elect()
+	sell.Elect()
+	assert.Equal(t, OrderTypeLimit, buy.Type)
+	assert.Equal(t, OrderTypeMarket, sell.Type)
+}
+
+func TestOrder_ValidateStop(t *testing.T) {
+	assert.NoError(t, (&Order{Type: OrderTypeLimit}).ValidateStop())
+	assert.NoError(t, (&Order{Type: OrderTypeStop, StopPrice: MustParseDecimal("10")}).ValidateStop())
+
+	err := (&Order{Type: OrderTypeStopMarket}).ValidateStop()
+	assert.Equal(t, RejectReasonInvalidOrder, RejectReasonOf(err))
+}
+
+func TestStopTriggerFor(t *testing.T) {
+	registry := Instruments
+	Instruments = NewInstrumentRegistry()
+	defer func() { Instruments = registry }()
+
+	assert.NoError(t, Instruments.Register(&Instrument{Symbol: "BTC-USD", TradingEnabled: true, StopTrigger: StopTriggerMark}))
+	assert.Equal(t, StopTriggerMark, StopTriggerFor("BTC-USD"))
+	assert.Equal(t, StopTriggerLast, StopTriggerFor("ETH-USD"))
+	assert.ErrorIs(t, Instruments.Register(&Instrument{Symbol: "ETH-USD", StopTrigger: "OPEN"}), ErrInvalidInstrument)
+}
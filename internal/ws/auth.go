@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
@@ -13,9 +14,10 @@ import (
 // AuthenticatedConnection represents a WebSocket connection with authentication
 type AuthenticatedConnection struct {
 	*websocket.Conn
-	UserID   string
-	Username string
-	Role     string
+	SessionID string
+	UserID    string
+	Username  string
+	Role      string
 }
 
 // AuthenticatedUpgrader upgrades HTTP connections to WebSocket connections with authentication
@@ -82,10 +84,11 @@ func (au *AuthenticatedUpgrader) Upgrade(w http.ResponseWriter, r *http.Request)
 		zap.String("role", claims.Role))
 
 	return &AuthenticatedConnection{
-		Conn:     conn,
-		UserID:   claims.UserID,
-		Username: claims.Username,
-		Role:     claims.Role,
+		Conn:      conn,
+		SessionID: uuid.New().String(),
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		Role:      claims.Role,
 	}, nil
 }
 
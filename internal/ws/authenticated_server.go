@@ -22,6 +22,7 @@ type AuthenticatedServer struct {
 	connectionsMutex sync.RWMutex
 	handlers         map[string]MessageHandler
 	handlersMutex    sync.RWMutex
+	disconnectHooks  []DisconnectHandler
 	closeCh          chan struct{}
 }
 
@@ -73,6 +74,13 @@ func (s *AuthenticatedServer) handleMessages(conn *AuthenticatedConnection) {
 		// Close connection
 		conn.Close()
 
+		s.handlersMutex.RLock()
+		hooks := s.disconnectHooks
+		s.handlersMutex.RUnlock()
+		for _, hook := range hooks {
+			hook(conn)
+		}
+
 		s.logger.Info("WebSocket connection closed",
 			zap.String("user_id", conn.UserID),
 			zap.String("username", conn.Username))
@@ -203,6 +211,14 @@ func (s *AuthenticatedServer) RegisterHandler(messageType string, handler Messag
 	s.handlers[messageType] = handler
 }
 
+// OnDisconnect registers a hook called after a connection closes
+func (s *AuthenticatedServer) OnDisconnect(hook DisconnectHandler) {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+
+	s.disconnectHooks = append(s.disconnectHooks, hook)
+}
+
 // BroadcastMessage broadcasts a message to all connections with the specified roles
 func (s *AuthenticatedServer) BroadcastMessage(message Message, roles ...string) {
 	// Marshal message
@@ -343,3 +359,6 @@ func (s *AuthenticatedServer) GetConnectionCountByRole() map[string]int {
 
 // MessageHandler is a function that handles a message
 type MessageHandler func(ctx context.Context, conn *AuthenticatedConnection, msg Message) error
+
+// DisconnectHandler is a function called when a connection closes
+type DisconnectHandler func(conn *AuthenticatedConnection)
@@ -0,0 +1,180 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"go.uber.org/zap"
+)
+
+// Order control message types
+const (
+	MessageTypeMassCancel         = "mass_cancel"
+	MessageTypeKillSwitch         = "kill_switch"
+	MessageTypeCancelOnDisconnect = "cancel_on_disconnect"
+)
+
+// ErrControlNotAuthorized is returned when a connection lacks an order control role
+var ErrControlNotAuthorized = errors.New("not authorized for order control")
+
+// MassCancelMessage is the data of a mass cancel message
+type MassCancelMessage struct {
+	UserID    string `json:"user_id"`
+	AccountID string `json:"account_id"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`
+	Strategy  string `json:"strategy"`
+	Reason    string `json:"reason"`
+}
+
+// KillSwitchMessage is the data of a kill switch message
+type KillSwitchMessage struct {
+	Scope            string `json:"scope"`
+	Value            string `json:"value"`
+	Enabled          bool   `json:"enabled"`
+	Reason           string `json:"reason"`
+	CancelOpenOrders bool   `json:"cancel_open_orders"`
+}
+
+// CancelOnDisconnectMessage is the data of a cancel on disconnect message
+type CancelOnDisconnectMessage struct {
+	Enabled bool `json:"enabled"`
+}
+
+// OrderControlHandler serves mass cancels, kill switches and cancel on
+// disconnect over the WebSocket control channel. Connections with a control
+// role may act on any entity; other connections may only mass cancel their
+// own orders.
+type OrderControlHandler struct {
+	service      *orders.OrderService
+	logger       *zap.Logger
+	controlRoles []string
+}
+
+// NewOrderControlHandler creates a control channel handler for the roles
+// allowed to act on any entity, by default auth.ControlRoles
+func NewOrderControlHandler(service *orders.OrderService, logger *zap.Logger, controlRoles ...string) *OrderControlHandler {
+	if len(controlRoles) == 0 {
+		controlRoles = auth.ControlRoles
+	}
+	return &OrderControlHandler{
+		service:      service,
+		logger:       logger,
+		controlRoles: controlRoles,
+	}
+}
+
+// Register registers the control messages and the disconnect hook on server
+func (h *OrderControlHandler) Register(server *AuthenticatedServer) {
+	server.RegisterHandler(MessageTypeMassCancel, h.handleMassCancel)
+	server.RegisterHandler(MessageTypeKillSwitch, h.handleKillSwitch)
+	server.RegisterHandler(MessageTypeCancelOnDisconnect, h.handleCancelOnDisconnect)
+	server.OnDisconnect(h.handleDisconnect)
+}
+
+// handleMassCancel cancels the open orders a message selects
+func (h *OrderControlHandler) handleMassCancel(ctx context.Context, conn *AuthenticatedConnection, msg Message) error {
+	var data MassCancelMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return reply(conn, msg, nil, err)
+	}
+	if !AuthorizeConnection(conn, h.controlRoles...) {
+		data.UserID = conn.UserID
+	}
+
+	cancelled, err := h.service.MassCancel(ctx, &orders.MassCancelRequest{
+		UserID:       data.UserID,
+		AccountGroup: data.AccountID,
+		Symbol:       data.Symbol,
+		Side:         orders.OrderSide(data.Side),
+		Strategy:     data.Strategy,
+		Actor:        conn.Username,
+		Reason:       data.Reason,
+	})
+	return reply(conn, msg, map[string]interface{}{"cancelled_order_ids": orderIDs(cancelled)}, err)
+}
+
+// handleKillSwitch enables or disables a kill switch
+func (h *OrderControlHandler) handleKillSwitch(ctx context.Context, conn *AuthenticatedConnection, msg Message) error {
+	if !AuthorizeConnection(conn, h.controlRoles...) {
+		return reply(conn, msg, nil, ErrControlNotAuthorized)
+	}
+	var data KillSwitchMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return reply(conn, msg, nil, err)
+	}
+
+	scope := orders.KillSwitchScope(data.Scope)
+	if !data.Enabled {
+		_, err := h.service.DisableKillSwitch(ctx, scope, data.Value, conn.Username, data.Reason)
+		return reply(conn, msg, map[string]interface{}{"enabled": false}, err)
+	}
+
+	_, cancelled, err := h.service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{
+		Scope:            scope,
+		Value:            data.Value,
+		Actor:            conn.Username,
+		Reason:           data.Reason,
+		CancelOpenOrders: data.CancelOpenOrders,
+	})
+	return reply(conn, msg, map[string]interface{}{
+		"enabled":             true,
+		"cancelled_order_ids": orderIDs(cancelled),
+	}, err)
+}
+
+// handleCancelOnDisconnect sets whether the connection's disconnect cancels
+// the user's open orders
+func (h *OrderControlHandler) handleCancelOnDisconnect(ctx context.Context, conn *AuthenticatedConnection, msg Message) error {
+	var data CancelOnDisconnectMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return reply(conn, msg, nil, err)
+	}
+
+	h.service.OpenSession(conn.SessionID, conn.UserID, data.Enabled)
+	return reply(conn, msg, map[string]interface{}{"enabled": data.Enabled}, nil)
+}
+
+// handleDisconnect ends the connection's order session
+func (h *OrderControlHandler) handleDisconnect(conn *AuthenticatedConnection) {
+	cancelled := h.service.CloseSession(context.Background(), conn.SessionID)
+	if len(cancelled) > 0 {
+		h.logger.Info("Cancelled orders on disconnect",
+			zap.String("user_id", conn.UserID),
+			zap.String("session_id", conn.SessionID),
+			zap.Int("orders", len(cancelled)))
+	}
+}
+
+// reply answers a message with its result, or with its error
+func reply(conn *AuthenticatedConnection, msg Message, result interface{}, err error) error {
+	if err != nil {
+		result = map[string]interface{}{"error": err.Error()}
+	}
+	data, marshalErr := json.Marshal(result)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if sendErr := conn.SendJSON(Message{
+		Type:      msg.Type + "_result",
+		ID:        msg.ID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}); sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+// orderIDs returns the IDs of orders
+func orderIDs(cancelled []*orders.Order) []string {
+	ids := make([]string, 0, len(cancelled))
+	for _, order := range cancelled {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
@@ -0,0 +1,106 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// MessageTypeOrder is the message type of order entry
+const MessageTypeOrder = "order"
+
+// OrderResultMessage is the data of the answer to an order message
+type OrderResultMessage struct {
+	OrderID        string    `json:"order_id"`
+	ClientOrderID  string    `json:"client_order_id,omitempty"`
+	Symbol         string    `json:"symbol"`
+	Side           string    `json:"side"`
+	OrderType      string    `json:"order_type"`
+	Price          float64   `json:"price"`
+	Quantity       float64   `json:"quantity"`
+	FilledQuantity float64   `json:"filled_quantity"`
+	Status         string    `json:"status"`
+	RejectReason   string    `json:"reject_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	Replayed       bool      `json:"replayed,omitempty"`
+}
+
+// OrderEntryHandler places orders sent over WebSocket for the connection's
+// user. Resending an order with the same client order ID answers with the
+// original order instead of placing a new one.
+type OrderEntryHandler struct {
+	service   *orders.OrderService
+	validator *MessageValidator
+	logger    *zap.Logger
+}
+
+// NewOrderEntryHandler creates an order entry handler
+func NewOrderEntryHandler(service *orders.OrderService, logger *zap.Logger) *OrderEntryHandler {
+	validator := NewMessageValidator(logger)
+	validator.RegisterDefaultSchemas()
+
+	return &OrderEntryHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// Register registers order entry on server
+func (h *OrderEntryHandler) Register(server *AuthenticatedServer) {
+	server.RegisterHandler(MessageTypeOrder, h.validator.ValidateMessageMiddleware()(h.handleOrder))
+}
+
+// handleOrder places the order a message carries
+func (h *OrderEntryHandler) handleOrder(ctx context.Context, conn *AuthenticatedConnection, msg Message) error {
+	var data OrderMessage
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return reply(conn, msg, nil, err)
+	}
+
+	orderType := orders.OrderType(data.OrderType)
+	if data.OrderType == "stop" {
+		orderType = orders.OrderTypeStopMarket
+	}
+
+	order, replayed, err := h.service.PlaceOrder(ctx, &orders.OrderRequest{
+		UserID:        conn.UserID,
+		ClientOrderID: data.ClientOrderID,
+		Symbol:        data.Symbol,
+		Side:          orders.OrderSide(data.Side),
+		Type:          orderType,
+		Price:         types.PriceFromFloat(data.Symbol, data.Price),
+		StopPrice:     types.PriceFromFloat(data.Symbol, data.StopPrice),
+		Quantity:      types.QuantityFromFloat(data.Symbol, data.Quantity),
+		TimeInForce:   orders.TimeInForce(data.TimeInForce),
+	})
+	if err != nil {
+		if !errors.Is(err, orders.ErrKillSwitchEngaged) {
+			h.logger.Warn("Failed to place order",
+				zap.String("user_id", conn.UserID),
+				zap.String("client_order_id", data.ClientOrderID),
+				zap.Error(err))
+		}
+		return reply(conn, msg, nil, err)
+	}
+
+	return reply(conn, msg, OrderResultMessage{
+		OrderID:        order.ID,
+		ClientOrderID:  order.ClientOrderID,
+		Symbol:         order.Symbol,
+		Side:           string(order.Side),
+		OrderType:      string(order.Type),
+		Price:          order.Price.Float64(),
+		Quantity:       order.Quantity.Float64(),
+		FilledQuantity: order.FilledQuantity.Float64(),
+		Status:         string(order.Status),
+		RejectReason:   string(order.RejectReason),
+		CreatedAt:      order.CreatedAt,
+		Replayed:       replayed,
+	}, nil)
+}
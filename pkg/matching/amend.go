@@ -0,0 +1,128 @@
+package matching
+
+import (
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// AmendOrder atomically replaces the price and quantity of a resting order;
+// zero values keep the current ones. Quantity reductions at the same price
+// keep time priority. Price changes and quantity increases lose it, and an
+// amended price that crosses the book trades immediately outside an
+// auction call. Pegged orders take their price from the book and can only
+// change quantity.
+func (ob *OrderBook) AmendOrder(orderID string, price, quantity Decimal) ([]*Trade, error) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	order, exists := ob.Orders[orderID]
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+	if _, iceberg := ob.icebergs[orderID]; iceberg {
+		return nil, types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"iceberg orders cannot be amended")
+	}
+	resting := ob.restingSide(order)
+	if _, queued := resting.Get(orderID); !queued {
+		return nil, ErrOrderNotFound
+	}
+
+	price, quantity = order.Amend(price, quantity)
+	if err := order.ValidateAmend(price, quantity); err != nil {
+		return nil, err
+	}
+	if order.IsPegged() && !price.Equal(order.Price) {
+		return nil, types.NewOrderRejection(ob.Symbol, types.RejectReasonInvalidOrder,
+			"pegged orders cannot change price")
+	}
+	amended := *order
+	amended.Price = price
+	amended.Quantity = quantity
+	if err := types.Instruments.Validate(&amended, ob.LastPrice); err != nil {
+		return nil, err
+	}
+
+	now := ob.now()
+	keepsPriority := order.KeepsPriority(price, quantity)
+
+	ob.logger.Debug("Amending order",
+		zap.String("order_id", orderID),
+		zap.Stringer("price", price),
+		zap.Stringer("quantity", quantity),
+		zap.Bool("keeps_priority", keepsPriority))
+
+	if keepsPriority {
+		resting.Reduce(order, order.Quantity.Sub(quantity))
+		order.Quantity = quantity
+		order.UpdatedAt = now
+		ob.emit(EventOrderAmended, order)
+		return nil, nil
+	}
+
+	// Requeue at the back of the new price level
+	resting.Remove(orderID)
+	order.Price = price
+	order.Quantity = quantity
+	order.QueuedAt = now
+	order.UpdatedAt = now
+	ob.emit(EventOrderAmended, order)
+
+	if order.Type != OrderTypeLimit || ob.Phase.IsCall() {
+		resting.Push(order)
+		if ob.Phase.IsCall() {
+			ob.publishIndicative()
+		}
+		return nil, nil
+	}
+	trades := ob.processLimitOrder(order)
+	trades = append(trades, ob.triggerStops()...)
+	ob.repricePegs()
+	return trades, nil
+}
+
+// restingSide returns the book side a resting order is queued on
+func (ob *OrderBook) restingSide(order *Order) *BookSide {
+	switch {
+	case order.IsStop() && order.Side == OrderSideBuy:
+		return ob.StopBids
+	case order.IsStop():
+		return ob.StopAsks
+	case order.IsHidden && order.Side == OrderSideBuy:
+		return ob.HiddenBids
+	case order.IsHidden:
+		return ob.HiddenAsks
+	case order.Side == OrderSideBuy:
+		return ob.Bids
+	default:
+		return ob.Asks
+	}
+}
+
+// AmendOrder amends a resting order and publishes any resulting trades
+func (me *MatchingEngine) AmendOrder(symbol, orderID string, price, quantity Decimal) ([]*Trade, error) {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	me.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	trades, err := orderBook.AmendOrder(orderID, price, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+
+	return trades, nil
+}
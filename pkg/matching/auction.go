@@ -0,0 +1,311 @@
+package matching
+
+import (
+	"context"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// collectAuctionOrder queues an order during an auction call without
+// matching it and publishes the new indicative price
+func (ob *OrderBook) collectAuctionOrder(order *Order) error {
+	if err := order.ValidateForPhase(ob.Phase); err != nil {
+		order.Status = OrderStatusRejected
+		return err
+	}
+
+	ob.Orders[order.ID] = order
+	if order.IsStop() {
+		ob.processStopOrder(order)
+		return nil
+	}
+
+	ob.rest(order)
+	order.Status = OrderStatusNew
+	ob.publishIndicative()
+	return nil
+}
+
+// IndicativePrice returns the price and volume the book would uncross at
+// now. It returns false if the book does not cross.
+func (ob *OrderBook) IndicativePrice() (types.AuctionResult, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.equilibrium()
+}
+
+// equilibrium computes the auction price of the resting limit orders
+func (ob *OrderBook) equilibrium() (types.AuctionResult, bool) {
+	return types.EquilibriumPrice(ob.auctionLevels(ob.Bids, ob.HiddenBids), ob.auctionLevels(ob.Asks, ob.HiddenAsks), ob.LastPrice)
+}
+
+// publishIndicative emits the indicative auction price and volume; both are
+// zero while the book does not cross
+func (ob *OrderBook) publishIndicative() {
+	if ob.eventHandler == nil {
+		return
+	}
+	result, _ := ob.equilibrium()
+	ob.eventHandler(&MatchingEvent{
+		Type:      EventIndicativePrice,
+		Symbol:    ob.Symbol,
+		Price:     result.Price,
+		Quantity:  result.Volume,
+		Timestamp: ob.now(),
+	})
+}
+
+// SetPhase moves the book to a trading phase. Leaving an auction call
+// uncrosses the book at its equilibrium price first, and entering
+// continuous trading releases the stops the auction price elected. A halted
+// book records the phase and enters it when trading resumes.
+func (ob *OrderBook) SetPhase(phase types.TradingPhase) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.schedulePhase = phase
+	return ob.updatePhase()
+}
+
+// scheduledPhase returns the phase the book's schedule last set, which a
+// halt may be holding it out of
+func (ob *OrderBook) scheduledPhase() types.TradingPhase {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.schedulePhase
+}
+
+// updatePhase moves the book to its scheduled phase, or to the halted phase
+// while a symbol or market-wide halt is in force
+func (ob *OrderBook) updatePhase() []*Trade {
+	phase := ob.schedulePhase
+	if ob.halted || ob.marketHalted {
+		phase = types.TradingPhaseHalted
+	}
+
+	previous := ob.Phase
+	if phase == previous {
+		return nil
+	}
+	ob.Phase = phase
+
+	ob.logger.Info("Trading phase changed",
+		zap.String("symbol", ob.Symbol),
+		zap.String("from", string(previous)),
+		zap.String("to", string(phase)))
+
+	switch {
+	case phase == types.TradingPhaseHalted:
+		ob.emitStatus(EventTradingHalted)
+	case previous == types.TradingPhaseHalted:
+		ob.emitStatus(EventTradingResumed)
+	}
+
+	var trades []*Trade
+	if previous.Uncrosses(phase) {
+		trades = ob.uncross()
+	}
+
+	if phase.IsCall() {
+		ob.publishIndicative()
+	} else {
+		trades = append(trades, ob.triggerStops()...)
+		ob.repricePegs()
+	}
+	return trades
+}
+
+// uncross executes the auction: the best bids and asks trade in priority
+// order at the single equilibrium price until its executable volume is
+// filled. Hidden orders trade after the displayed orders at their price
+// and iceberg slices are refreshed as they fill. Self-trade prevention and
+// expiry apply to continuous matching only.
+func (ob *OrderBook) uncross() []*Trade {
+	result, crossed := ob.equilibrium()
+	if !crossed {
+		return nil
+	}
+
+	var trades []*Trade
+	remaining := result.Volume
+	for remaining.IsPositive() {
+		bids, buy := ob.best(ob.Bids, ob.HiddenBids)
+		asks, sell := ob.best(ob.Asks, ob.HiddenAsks)
+		if buy == nil || sell == nil {
+			break
+		}
+
+		quantity := types.MinDecimal(remaining, types.MinDecimal(buy.RemainingQuantity(), sell.RemainingQuantity()))
+		trade := ob.executeAuctionTrade(buy, sell, result.Price, quantity)
+		trades = append(trades, trade)
+		bids.Reduce(buy, quantity)
+		asks.Reduce(sell, quantity)
+		remaining = remaining.Sub(quantity)
+
+		ob.settleAuctionOrder(bids, buy, trade)
+		ob.settleAuctionOrder(asks, sell, trade)
+	}
+	ob.LastPrice = result.Price
+
+	ob.logger.Info("Auction uncrossed",
+		zap.String("symbol", ob.Symbol),
+		zap.Stringer("price", result.Price),
+		zap.Stringer("volume", result.Volume),
+		zap.Stringer("surplus", result.Surplus))
+
+	if ob.eventHandler != nil {
+		ob.eventHandler(&MatchingEvent{
+			Type:      EventAuctionUncrossed,
+			Symbol:    ob.Symbol,
+			Price:     result.Price,
+			Quantity:  result.Volume,
+			Timestamp: ob.now(),
+		})
+	}
+
+	return trades
+}
+
+// settleAuctionOrder updates an order that traded in the uncross, removing
+// it from the book once filled. An iceberg slice passes the fill to its
+// parent and is refreshed.
+func (ob *OrderBook) settleAuctionOrder(side *BookSide, order *Order, trade *Trade) {
+	if order.IsIcebergChild {
+		ob.fillSlice(side, order, trade.Quantity)
+		if parent, exists := ob.Orders[order.ParentOrderID]; exists {
+			if parent.Status == OrderStatusFilled {
+				delete(ob.Orders, parent.ID)
+			}
+			ob.emitAuctionFill(parent, trade)
+		}
+		return
+	}
+
+	if order.RemainingQuantity().IsPositive() {
+		order.Status = OrderStatusPartiallyFilled
+	} else {
+		side.Remove(order.ID)
+		delete(ob.Orders, order.ID)
+		order.Status = OrderStatusFilled
+	}
+	ob.emitAuctionFill(order, trade)
+}
+
+// emitAuctionFill reports the execution of an order in the uncross with the
+// trade that filled it
+func (ob *OrderBook) emitAuctionFill(order *Order, trade *Trade) {
+	if ob.eventHandler == nil {
+		return
+	}
+	ob.eventHandler(&MatchingEvent{
+		Type:      EventOrderFilled,
+		Symbol:    ob.Symbol,
+		Order:     order,
+		Trade:     trade,
+		Quantity:  trade.Quantity,
+		Price:     trade.Price,
+		Timestamp: ob.now(),
+	})
+}
+
+// executeAuctionTrade records an auction execution. Neither side took
+// liquidity, so both pay the maker fee.
+func (ob *OrderBook) executeAuctionTrade(buy, sell *Order, price, quantity Decimal) *Trade {
+	notional := quantity.Mul(price)
+	trade := &Trade{
+		ID:          ob.tradeID(),
+		Symbol:      ob.Symbol,
+		Price:       price,
+		Quantity:    quantity,
+		BuyOrderID:  orderID(buy),
+		SellOrderID: orderID(sell),
+		Timestamp:   ob.now(),
+		TakerFee:    notional.Mul(MakerFeeRate),
+		MakerFee:    notional.Mul(MakerFeeRate),
+	}
+
+	buy.FilledQuantity = buy.FilledQuantity.Add(quantity)
+	sell.FilledQuantity = sell.FilledQuantity.Add(quantity)
+
+	ob.logger.Debug("Auction trade executed",
+		zap.String("trade_id", trade.ID),
+		zap.Stringer("price", price),
+		zap.Stringer("quantity", quantity),
+		zap.String("buy_order_id", buy.ID),
+		zap.String("sell_order_id", sell.ID))
+
+	return trade
+}
+
+// SetPhase moves a symbol's book to a trading phase and publishes the
+// auction trades
+func (me *MatchingEngine) SetPhase(symbol string, phase types.TradingPhase) ([]*Trade, error) {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	me.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	trades := orderBook.SetPhase(phase)
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+	return trades, nil
+}
+
+// UpdatePhases moves every book to the phase its exchange schedule gives
+// at now and returns the auction trades
+func (me *MatchingEngine) UpdatePhases(now time.Time) []*Trade {
+	me.mu.RLock()
+	books := make([]*OrderBook, 0, len(me.OrderBooks))
+	for _, orderBook := range me.OrderBooks {
+		books = append(books, orderBook)
+	}
+	me.mu.RUnlock()
+
+	var trades []*Trade
+	for _, orderBook := range books {
+		trades = append(trades, orderBook.SetPhase(types.PhaseFor(orderBook.Symbol, now))...)
+	}
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+	return trades
+}
+
+// RunPhases follows the exchange schedules every interval until the
+// context is done
+func (me *MatchingEngine) RunPhases(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if trades := me.UpdatePhases(now); len(trades) > 0 {
+				me.logger.Info("Auction trades executed",
+					zap.Int("count", len(trades)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
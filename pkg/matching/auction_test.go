@@ -0,0 +1,72 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchingEngine_Auction(t *testing.T) {
+	engine := newTestEngine(t)
+	engine.orderBook("AAPL")
+	_, err := engine.SetPhase("AAPL", types.TradingPhasePreOpen)
+	require.NoError(t, err)
+
+	// The call rejects orders that cannot wait for the uncross
+	market := newLimitOrder("market", OrderSideBuy, "0", "10")
+	market.Type = OrderTypeMarket
+	_, err = engine.AddOrder(market)
+	assert.Equal(t, types.RejectReasonInvalidOrder, types.RejectReasonOf(err))
+	ioc := newLimitOrder("ioc", OrderSideBuy, "100", "10")
+	ioc.TimeInForce = TimeInForceIOC
+	_, err = engine.AddOrder(ioc)
+	assert.Equal(t, types.RejectReasonInvalidTimeInForce, types.RejectReasonOf(err))
+
+	// Crossing orders queue without trading
+	orders := map[string]*Order{
+		"bid-101": newLimitOrder("bid-101", OrderSideBuy, "101", "100"),
+		"bid-100": newLimitOrder("bid-100", OrderSideBuy, "100", "50"),
+		"ask-99":  newLimitOrder("ask-99", OrderSideSell, "99", "80"),
+		"ask-100": newLimitOrder("ask-100", OrderSideSell, "100", "60"),
+	}
+	for _, id := range []string{"bid-101", "bid-100", "ask-99", "ask-100"} {
+		trades, err := engine.AddOrder(orders[id])
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+	}
+
+	// 100 executes the most volume, 140 of the 150 bid
+	result, crossed := engine.GetOrderBook("AAPL").IndicativePrice()
+	require.True(t, crossed)
+	assert.Equal(t, "100", result.Price.String())
+	assert.Equal(t, "140", result.Volume.String())
+	assert.Equal(t, "10", result.Surplus.String())
+	assert.Contains(t, drainEvents(engine), EventIndicativePrice)
+
+	// Opening uncrosses everything at the single price in priority order
+	trades, err := engine.SetPhase("AAPL", types.TradingPhaseContinuous)
+	require.NoError(t, err)
+	require.Len(t, trades, 3)
+	for i, expected := range []struct{ buyer, seller, quantity string }{
+		{"bid-101", "ask-99", "80"},
+		{"bid-101", "ask-100", "20"},
+		{"bid-100", "ask-100", "40"},
+	} {
+		assert.Equal(t, expected.buyer, trades[i].BuyOrderID)
+		assert.Equal(t, expected.seller, trades[i].SellOrderID)
+		assert.Equal(t, expected.quantity, trades[i].Quantity.String())
+		assert.Equal(t, "100", trades[i].Price.String())
+	}
+	assert.Contains(t, drainEvents(engine), EventAuctionUncrossed)
+	assert.Equal(t, "100", engine.GetOrderBook("AAPL").GetLastPrice().String())
+
+	// The surplus rests and trades continuously
+	assert.Equal(t, OrderStatusPartiallyFilled, orders["bid-100"].Status)
+	assert.Equal(t, "10", orders["bid-100"].RemainingQuantity().String())
+	trades, err = engine.AddOrder(newLimitOrder("seller", OrderSideSell, "100", "10"))
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	assert.Equal(t, "bid-100", trades[0].BuyOrderID)
+}
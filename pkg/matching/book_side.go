@@ -0,0 +1,235 @@
+package matching
+
+import (
+	"sort"
+	"time"
+)
+
+// BookSide is one side of an order book. Orders rest in price levels kept
+// sorted best first, each level is a FIFO queue, and an order ID index
+// finds any resting order in O(1), so cancels never scan the book and
+// level totals are maintained as orders arrive, trade and leave.
+type BookSide struct {
+	// Side is the side of the orders
+	Side OrderSide
+	// Stop keys the levels by stop price in the order the stops trigger
+	Stop bool
+	// levels are sorted worst to best so the best level is at the end
+	levels []*bookLevel
+	// index maps order ID to its queue entry
+	index map[string]*bookEntry
+}
+
+// bookLevel is the FIFO queue of the orders resting at one price
+type bookLevel struct {
+	price    Decimal
+	quantity Decimal // remaining quantity of every order in the level
+	count    int
+	head     *bookEntry
+	tail     *bookEntry
+}
+
+// bookEntry links a resting order into its level's queue
+type bookEntry struct {
+	order *Order
+	level *bookLevel
+	prev  *bookEntry
+	next  *bookEntry
+}
+
+// NewBookSide creates an empty book side. Buy orders and sell stops rank
+// the highest price first; sell orders and buy stops the lowest.
+func NewBookSide(side OrderSide, stop bool) *BookSide {
+	return &BookSide{
+		Side:  side,
+		Stop:  stop,
+		index: make(map[string]*bookEntry),
+	}
+}
+
+// Len returns the number of resting orders
+func (s *BookSide) Len() int { return len(s.index) }
+
+// Peek returns the first order of the best level without removing it
+func (s *BookSide) Peek() *Order {
+	if len(s.levels) == 0 {
+		return nil
+	}
+	return s.levels[len(s.levels)-1].head.order
+}
+
+// Front returns the orders of the best level in time priority
+func (s *BookSide) Front() []*Order {
+	if len(s.levels) == 0 {
+		return nil
+	}
+
+	level := s.levels[len(s.levels)-1]
+	orders := make([]*Order, 0, level.count)
+	for entry := level.head; entry != nil; entry = entry.next {
+		orders = append(orders, entry.order)
+	}
+	return orders
+}
+
+// Get returns a resting order by ID
+func (s *BookSide) Get(orderID string) (*Order, bool) {
+	entry, exists := s.index[orderID]
+	if !exists {
+		return nil, false
+	}
+	return entry.order, true
+}
+
+// Push queues an order at the back of its price level
+func (s *BookSide) Push(order *Order) {
+	level := s.level(s.key(order))
+	entry := &bookEntry{order: order, level: level, prev: level.tail}
+	if level.tail != nil {
+		level.tail.next = entry
+	} else {
+		level.head = entry
+	}
+	level.tail = entry
+	level.count++
+	level.quantity = level.quantity.Add(order.RemainingQuantity())
+	s.index[order.ID] = entry
+}
+
+// Pop removes and returns the first order of the best level
+func (s *BookSide) Pop() *Order {
+	order := s.Peek()
+	if order != nil {
+		s.unlink(s.index[order.ID])
+	}
+	return order
+}
+
+// Remove removes a resting order by ID
+func (s *BookSide) Remove(orderID string) *Order {
+	entry, exists := s.index[orderID]
+	if !exists {
+		return nil
+	}
+	s.unlink(entry)
+	return entry.order
+}
+
+// Reduce records that a resting order's remaining quantity dropped by
+// quantity after a fill or an in-place amend
+func (s *BookSide) Reduce(order *Order, quantity Decimal) {
+	if entry, exists := s.index[order.ID]; exists {
+		entry.level.quantity = entry.level.quantity.Sub(quantity)
+	}
+}
+
+// Each calls fn for every resting order in priority order until fn
+// returns false
+func (s *BookSide) Each(fn func(*Order) bool) {
+	for i := len(s.levels) - 1; i >= 0; i-- {
+		for entry := s.levels[i].head; entry != nil; entry = entry.next {
+			if !fn(entry.order) {
+				return
+			}
+		}
+	}
+}
+
+// Depth returns the price and total remaining quantity of up to levels
+// price levels, best first
+func (s *BookSide) Depth(levels int) []PriceLevel {
+	if levels > len(s.levels) {
+		levels = len(s.levels)
+	}
+
+	result := make([]PriceLevel, 0, levels)
+	for i := len(s.levels) - 1; i >= len(s.levels)-levels; i-- {
+		result = append(result, PriceLevel{Price: s.levels[i].price, Quantity: s.levels[i].quantity})
+	}
+	return result
+}
+
+// removeExpired drops every order whose expire time has passed
+func (s *BookSide) removeExpired(now time.Time) []*Order {
+	var expired []*Order
+	s.Each(func(order *Order) bool {
+		if order.IsExpiredAt(now) {
+			expired = append(expired, order)
+		}
+		return true
+	})
+
+	for _, order := range expired {
+		s.Remove(order.ID)
+	}
+	return expired
+}
+
+// key returns the price an order is ranked by on this side
+func (s *BookSide) key(order *Order) Decimal {
+	if s.Stop {
+		return order.StopPrice
+	}
+	return order.Price
+}
+
+// better returns true if price a ranks ahead of price b
+func (s *BookSide) better(a, b Decimal) bool {
+	if (s.Side == OrderSideBuy) != s.Stop {
+		return a.GreaterThan(b)
+	}
+	return a.LessThan(b)
+}
+
+// search returns the index of the level at price, or where it belongs
+func (s *BookSide) search(price Decimal) int {
+	return sort.Search(len(s.levels), func(i int) bool {
+		return !s.better(price, s.levels[i].price)
+	})
+}
+
+// level returns the level at price, creating it if needed
+func (s *BookSide) level(price Decimal) *bookLevel {
+	i := s.search(price)
+	if i < len(s.levels) && s.levels[i].price.Equal(price) {
+		return s.levels[i]
+	}
+
+	level := &bookLevel{price: price}
+	s.levels = append(s.levels, nil)
+	copy(s.levels[i+1:], s.levels[i:])
+	s.levels[i] = level
+	return level
+}
+
+// unlink removes an entry from its level and drops the level once empty
+func (s *BookSide) unlink(entry *bookEntry) {
+	level := entry.level
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		level.head = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		level.tail = entry.prev
+	}
+	level.count--
+	level.quantity = level.quantity.Sub(entry.order.RemainingQuantity())
+	delete(s.index, entry.order.ID)
+
+	if level.count > 0 {
+		return
+	}
+	last := len(s.levels) - 1
+	if s.levels[last] == level {
+		s.levels[last] = nil
+		s.levels = s.levels[:last]
+		return
+	}
+	i := s.search(level.price)
+	copy(s.levels[i:], s.levels[i+1:])
+	s.levels[last] = nil
+	s.levels = s.levels[:last]
+}
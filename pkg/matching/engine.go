@@ -1,12 +1,11 @@
 package matching
 
 import (
-	"container/heap"
+	"context"
 	"sync"
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/trading/types"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +14,7 @@ type OrderType = types.OrderType
 type OrderSide = types.OrderSide
 type OrderStatus = types.OrderStatus
 type Order = types.Order
+type Decimal = types.Decimal
 
 // Constants from types package
 const (
@@ -41,9 +41,9 @@ type Trade struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Price is the price of the trade
-	Price float64
+	Price Decimal
 	// Quantity is the quantity of the trade
-	Quantity float64
+	Quantity Decimal
 	// BuyOrderID is the buy order ID
 	BuyOrderID string
 	// SellOrderID is the sell order ID
@@ -55,128 +55,96 @@ type Trade struct {
 	// MakerSide is the side of the maker
 	MakerSide OrderSide
 	// TakerFee is the fee for the taker
-	TakerFee float64
+	TakerFee Decimal
 	// MakerFee is the fee for the maker
-	MakerFee float64
+	MakerFee Decimal
 }
 
+// Fee rates applied to executed trades
+var (
+	// TakerFeeRate is the taker fee rate (0.1%)
+	TakerFeeRate = types.NewDecimal(1, 3)
+	// MakerFeeRate is the maker fee rate (0.05%)
+	MakerFeeRate = types.NewDecimal(5, 4)
+)
+
 // OrderBook represents an order book for a symbol
 type OrderBook struct {
 	// Symbol is the trading symbol
 	Symbol string
 	// Bids is the buy orders
-	Bids *OrderHeap
+	Bids *BookSide
 	// Asks is the sell orders
-	Asks *OrderHeap
+	Asks *BookSide
 	// Orders is a map of order ID to order
 	Orders map[string]*Order
 	// StopBids is the stop buy orders
-	StopBids *OrderHeap
+	StopBids *BookSide
 	// StopAsks is the stop sell orders
-	StopAsks *OrderHeap
+	StopAsks *BookSide
+	// HiddenBids and HiddenAsks are the hidden orders, which trade after
+	// the displayed orders at their price and never appear in market data
+	HiddenBids *BookSide
+	HiddenAsks *BookSide
+	// icebergs maps an iceberg order ID to the slice it displays
+	icebergs map[string]*IcebergOrder
+	// pegs are the resting pegged orders by ID, repriced as the book moves
+	pegs map[string]*Order
 	// LastPrice is the last traded price
-	LastPrice float64
+	LastPrice Decimal
+	// MarkPrice is the reference price for stops triggered on MARK
+	MarkPrice Decimal
+	// Phase is the trading phase; call phases collect orders for an auction
+	Phase types.TradingPhase
+	// schedulePhase is the phase the exchange schedule gives; halts override it
+	schedulePhase types.TradingPhase
+	// halted and marketHalted record symbol and market-wide halts
+	halted       bool
+	marketHalted bool
+	haltReason   string
+	// eventHandler receives time in force and expiry events
+	eventHandler func(*MatchingEvent)
+	// clock and nextTradeID replace the wall clock and random trade IDs
+	// when a Sequencer drives the book
+	clock       func() time.Time
+	nextTradeID func() string
 	// Mutex for thread safety
 	mu sync.RWMutex
 	// Logger
 	logger *zap.Logger
 }
 
-// OrderHeap is a heap of orders
-type OrderHeap struct {
-	// Orders is the slice of orders
-	Orders []*Order
-	// Side is the side of the orders
-	Side OrderSide
-}
-
-// Len returns the length of the heap
-func (h OrderHeap) Len() int { return len(h.Orders) }
-
-// Less returns whether the order at index i is less than the order at index j
-func (h OrderHeap) Less(i, j int) bool {
-	if h.Side == OrderSideBuy {
-		// For buy orders, higher prices have higher priority
-		if h.Orders[i].Price == h.Orders[j].Price {
-			// If prices are equal, earlier orders have higher priority
-			return h.Orders[i].CreatedAt.Before(h.Orders[j].CreatedAt)
-		}
-		return h.Orders[i].Price > h.Orders[j].Price
-	} else {
-		// For sell orders, lower prices have higher priority
-		if h.Orders[i].Price == h.Orders[j].Price {
-			// If prices are equal, earlier orders have higher priority
-			return h.Orders[i].CreatedAt.Before(h.Orders[j].CreatedAt)
-		}
-		return h.Orders[i].Price < h.Orders[j].Price
-	}
-}
-
-// Swap swaps the orders at indices i and j
-func (h OrderHeap) Swap(i, j int) {
-	h.Orders[i], h.Orders[j] = h.Orders[j], h.Orders[i]
-}
-
-// Push adds an order to the heap
-func (h *OrderHeap) Push(x interface{}) {
-	h.Orders = append(h.Orders, x.(*Order))
-}
-
-// Pop removes and returns the top order from the heap
-func (h *OrderHeap) Pop() interface{} {
-	old := h.Orders
-	n := len(old)
-	order := old[n-1]
-	h.Orders = old[0 : n-1]
-	return order
-}
-
-// Peek returns the top order from the heap without removing it
-func (h *OrderHeap) Peek() *Order {
-	if len(h.Orders) == 0 {
-		return nil
-	}
-	return h.Orders[0]
-}
-
-// Remove removes an order from the heap
-func (h *OrderHeap) Remove(orderID string) *Order {
-	for i, order := range h.Orders {
-		if order.ID == orderID {
-			removedOrder := heap.Remove(h, i).(*Order)
-			return removedOrder
-		}
-	}
-	return nil
-}
-
 // NewOrderBook creates a new order book
 func NewOrderBook(symbol string, logger *zap.Logger) *OrderBook {
+	return newOrderBook(symbol, logger, time.Now())
+}
+
+// newOrderBook creates an order book in the scheduled phase at now
+func newOrderBook(symbol string, logger *zap.Logger, now time.Time) *OrderBook {
+	phase := types.PhaseFor(symbol, now)
 	return &OrderBook{
-		Symbol: symbol,
-		Bids: &OrderHeap{
-			Orders: make([]*Order, 0),
-			Side:   OrderSideBuy,
-		},
-		Asks: &OrderHeap{
-			Orders: make([]*Order, 0),
-			Side:   OrderSideSell,
-		},
-		Orders: make(map[string]*Order),
-		StopBids: &OrderHeap{
-			Orders: make([]*Order, 0),
-			Side:   OrderSideBuy,
-		},
-		StopAsks: &OrderHeap{
-			Orders: make([]*Order, 0),
-			Side:   OrderSideSell,
-		},
-		logger: logger,
+		Symbol:        symbol,
+		Bids:          NewBookSide(OrderSideBuy, false),
+		Asks:          NewBookSide(OrderSideSell, false),
+		Orders:        make(map[string]*Order),
+		StopBids:      NewBookSide(OrderSideBuy, true),
+		StopAsks:      NewBookSide(OrderSideSell, true),
+		HiddenBids:    NewBookSide(OrderSideBuy, false),
+		HiddenAsks:    NewBookSide(OrderSideSell, false),
+		icebergs:      make(map[string]*IcebergOrder),
+		pegs:          make(map[string]*Order),
+		Phase:         phase,
+		schedulePhase: phase,
+		logger:        logger,
 	}
 }
 
-// AddOrder adds an order to the order book
-func (ob *OrderBook) AddOrder(order *Order) []*Trade {
+// AddOrder adds an order to the order book. Orders that break the
+// instrument or time in force rules are rejected before they can match or
+// rest, and fill-or-kill orders the book cannot fill are killed without
+// trading. Pegged orders are priced from the book first. During an auction
+// call orders are queued for the uncross instead of matching.
+func (ob *OrderBook) AddOrder(order *Order) ([]*Trade, error) {
 	ob.mu.Lock()
 	defer ob.mu.Unlock()
 
@@ -185,8 +153,42 @@ func (ob *OrderBook) AddOrder(order *Order) []*Trade {
 		zap.String("symbol", order.Symbol),
 		zap.String("side", string(order.Side)),
 		zap.String("type", string(order.Type)),
-		zap.Float64("price", order.Price),
-		zap.Float64("quantity", order.Quantity))
+		zap.Stringer("price", order.Price),
+		zap.Stringer("quantity", order.Quantity))
+
+	if err := ob.pricePeg(order); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := types.Instruments.Validate(order, ob.LastPrice); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := order.ValidateStop(); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+	if err := order.ValidateDisplay(); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
+	now := ob.now()
+	if err := ob.applyTimeInForce(order, now); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
+	if ob.Phase.IsCall() {
+		return nil, ob.collectAuctionOrder(order)
+	}
+
+	if order.TimeInForce == TimeInForceFOK &&
+		(order.Type == OrderTypeMarket || order.Type == OrderTypeLimit) &&
+		!ob.canFill(order, now) {
+		ob.killOrder(order)
+		return nil, nil
+	}
 
 	// Store the order
 	ob.Orders[order.ID] = order
@@ -199,59 +201,28 @@ func (ob *OrderBook) AddOrder(order *Order) []*Trade {
 	case OrderTypeLimit:
 		trades = ob.processLimitOrder(order)
 	case OrderTypeStop, OrderTypeStopLimit, OrderTypeStopMarket:
-		trades = ob.processStopOrder(order)
+		ob.processStopOrder(order)
 	}
 
-	return trades
+	// Release the stops elected by this order and its trades and follow
+	// the new prices with the pegged orders
+	trades = append(trades, ob.triggerStops()...)
+	ob.repricePegs()
+
+	return trades, nil
 }
 
 // processMarketOrder processes a market order
 func (ob *OrderBook) processMarketOrder(order *Order) []*Trade {
-	var trades []*Trade
-	remainingQuantity := order.Quantity
-
-	if order.Side == OrderSideBuy {
-		// Match against asks (sell orders)
-		for ob.Asks.Len() > 0 && remainingQuantity > 0 {
-			bestAsk := ob.Asks.Peek()
-			if bestAsk == nil {
-				break
-			}
-
-			trade := ob.executeTrade(order, bestAsk, &remainingQuantity)
-			if trade != nil {
-				trades = append(trades, trade)
-			}
-
-			if bestAsk.RemainingQuantity() <= 0 {
-				heap.Pop(ob.Asks)
-				bestAsk.Status = OrderStatusFilled
-			}
-		}
-	} else {
-		// Match against bids (buy orders)
-		for ob.Bids.Len() > 0 && remainingQuantity > 0 {
-			bestBid := ob.Bids.Peek()
-			if bestBid == nil {
-				break
-			}
-
-			trade := ob.executeTrade(order, bestBid, &remainingQuantity)
-			if trade != nil {
-				trades = append(trades, trade)
-			}
-
-			if bestBid.RemainingQuantity() <= 0 {
-				heap.Pop(ob.Bids)
-				bestBid.Status = OrderStatusFilled
-			}
-		}
+	trades, remainingQuantity, cancelled := ob.match(order)
+	if cancelled {
+		return trades
 	}
 
 	// Update order status
-	if remainingQuantity <= 0 {
+	if !remainingQuantity.IsPositive() {
 		order.Status = OrderStatusFilled
-	} else if remainingQuantity < order.Quantity {
+	} else if remainingQuantity.LessThan(order.Quantity) {
 		order.Status = OrderStatusPartiallyFilled
 	} else {
 		order.Status = OrderStatusRejected
@@ -262,116 +233,126 @@ func (ob *OrderBook) processMarketOrder(order *Order) []*Trade {
 
 // processLimitOrder processes a limit order
 func (ob *OrderBook) processLimitOrder(order *Order) []*Trade {
-	var trades []*Trade
-	remainingQuantity := order.Quantity
-
-	if order.Side == OrderSideBuy {
-		// Try to match against asks
-		for ob.Asks.Len() > 0 && remainingQuantity > 0 {
-			bestAsk := ob.Asks.Peek()
-			if bestAsk == nil || bestAsk.Price > order.Price {
-				break
-			}
+	trades, remainingQuantity, cancelled := ob.match(order)
+	if cancelled {
+		return trades
+	}
 
-			trade := ob.executeTrade(order, bestAsk, &remainingQuantity)
-			if trade != nil {
-				trades = append(trades, trade)
-			}
+	// If there's remaining quantity, add to the book unless it must not rest
+	if remainingQuantity.IsPositive() && !order.IsImmediate() {
+		ob.rest(order)
+		order.Status = OrderStatusNew
+	}
 
-			if bestAsk.RemainingQuantity() <= 0 {
-				heap.Pop(ob.Asks)
-				bestAsk.Status = OrderStatusFilled
-			}
-		}
+	// Update order status
+	if !remainingQuantity.IsPositive() {
+		order.Status = OrderStatusFilled
+	} else if remainingQuantity.LessThan(order.Quantity) {
+		order.Status = OrderStatusPartiallyFilled
+	}
 
-		// If there's remaining quantity, add to bids
-		if remainingQuantity > 0 {
-			order.Quantity = remainingQuantity
-			heap.Push(ob.Bids, order)
-			order.Status = OrderStatusNew
-		}
-	} else {
-		// Try to match against bids
-		for ob.Bids.Len() > 0 && remainingQuantity > 0 {
-			bestBid := ob.Bids.Peek()
-			if bestBid == nil || bestBid.Price < order.Price {
-				break
-			}
+	// Cancel the unfilled remainder of IOC orders
+	if remainingQuantity.IsPositive() && order.IsImmediate() {
+		ob.cancelRemainder(order)
+	}
 
-			trade := ob.executeTrade(order, bestBid, &remainingQuantity)
-			if trade != nil {
-				trades = append(trades, trade)
-			}
+	return trades
+}
+
+// match trades an order against the opposite side while prices cross,
+// taking hidden orders after the displayed ones at each price. It returns
+// the trades, the unfilled quantity and whether self-trade prevention
+// cancelled the order.
+func (ob *OrderBook) match(order *Order) ([]*Trade, Decimal, bool) {
+	opposite, hidden := ob.Asks, ob.HiddenAsks
+	if order.Side == OrderSideSell {
+		opposite, hidden = ob.Bids, ob.HiddenBids
+	}
+
+	var trades []*Trade
+	remainingQuantity := order.RemainingQuantity()
+	mode := types.SelfTradePolicies.ModeFor(order)
+	rule, proRata := types.AllocationRuleFor(ob.Symbol)
 
-			if bestBid.RemainingQuantity() <= 0 {
-				heap.Pop(ob.Bids)
-				bestBid.Status = OrderStatusFilled
+	for remainingQuantity.IsPositive() {
+		side, maker := ob.best(opposite, hidden)
+		if maker == nil || !crosses(order, maker) {
+			break
+		}
+		if maker.IsExpiredAt(ob.now()) {
+			side.Pop()
+			ob.expireOrder(maker)
+			continue
+		}
+		if mode != types.SelfTradePreventionNone && types.IsSelfTrade(order, maker) {
+			if ob.preventSelfTrade(mode, order, maker, side, &remainingQuantity) {
+				return trades, remainingQuantity, true
 			}
+			continue
 		}
-
-		// If there's remaining quantity, add to asks
-		if remainingQuantity > 0 {
-			order.Quantity = remainingQuantity
-			heap.Push(ob.Asks, order)
-			order.Status = OrderStatusNew
+		if proRata && side == opposite {
+			trades = append(trades, ob.matchProRata(rule, mode, order, opposite, &remainingQuantity)...)
+			continue
 		}
-	}
 
-	// Update order status
-	if remainingQuantity <= 0 {
-		order.Status = OrderStatusFilled
-	} else if remainingQuantity < order.Quantity {
-		order.Status = OrderStatusPartiallyFilled
+		quantity := types.Zero
+		if trade := ob.executeTrade(order, maker, &remainingQuantity); trade != nil {
+			trades = append(trades, trade)
+			quantity = trade.Quantity
+		}
+		ob.settleMaker(side, maker, quantity)
 	}
 
-	return trades
+	return trades, remainingQuantity, false
 }
 
-// processStopOrder processes a stop order
-func (ob *OrderBook) processStopOrder(order *Order) []*Trade {
-	// For now, just add to the appropriate stop order heap
-	// In a full implementation, we would check if the stop price is triggered
-	if order.Side == OrderSideBuy {
-		heap.Push(ob.StopBids, order)
-	} else {
-		heap.Push(ob.StopAsks, order)
+// settleMaker records a maker's fill on its book side, removing it once
+// filled. Fills of an iceberg slice go to its parent.
+func (ob *OrderBook) settleMaker(side *BookSide, maker *Order, quantity Decimal) {
+	side.Reduce(maker, quantity)
+	if maker.IsIcebergChild {
+		ob.fillSlice(side, maker, quantity)
+		return
+	}
+	if !maker.RemainingQuantity().IsPositive() {
+		side.Remove(maker.ID)
+		maker.Status = OrderStatusFilled
 	}
-
-	order.Status = OrderStatusNew
-	return nil
 }
 
 // executeTrade executes a trade between two orders
-func (ob *OrderBook) executeTrade(takerOrder, makerOrder *Order, remainingQuantity *float64) *Trade {
-	tradeQuantity := min(*remainingQuantity, makerOrder.RemainingQuantity())
-	if tradeQuantity <= 0 {
+func (ob *OrderBook) executeTrade(takerOrder, makerOrder *Order, remainingQuantity *Decimal) *Trade {
+	tradeQuantity := types.MinDecimal(*remainingQuantity, makerOrder.RemainingQuantity())
+	if !tradeQuantity.IsPositive() {
 		return nil
 	}
 
+	notional := tradeQuantity.Mul(makerOrder.Price)
+
 	trade := &Trade{
-		ID:         uuid.New().String(),
-		Symbol:     ob.Symbol,
-		Price:      makerOrder.Price,
-		Quantity:   tradeQuantity,
-		Timestamp:  time.Now(),
-		TakerSide:  takerOrder.Side,
-		MakerSide:  makerOrder.Side,
-		TakerFee:   0.001 * tradeQuantity * makerOrder.Price, // 0.1% fee
-		MakerFee:   0.0005 * tradeQuantity * makerOrder.Price, // 0.05% fee
+		ID:        ob.tradeID(),
+		Symbol:    ob.Symbol,
+		Price:     makerOrder.Price,
+		Quantity:  tradeQuantity,
+		Timestamp: ob.now(),
+		TakerSide: takerOrder.Side,
+		MakerSide: makerOrder.Side,
+		TakerFee:  notional.Mul(TakerFeeRate),
+		MakerFee:  notional.Mul(MakerFeeRate),
 	}
 
 	if takerOrder.Side == OrderSideBuy {
 		trade.BuyOrderID = takerOrder.ID
-		trade.SellOrderID = makerOrder.ID
+		trade.SellOrderID = orderID(makerOrder)
 	} else {
-		trade.BuyOrderID = makerOrder.ID
+		trade.BuyOrderID = orderID(makerOrder)
 		trade.SellOrderID = takerOrder.ID
 	}
 
 	// Update order quantities
-	takerOrder.FilledQuantity += tradeQuantity
-	makerOrder.FilledQuantity += tradeQuantity
-	*remainingQuantity -= tradeQuantity
+	takerOrder.FilledQuantity = takerOrder.FilledQuantity.Add(tradeQuantity)
+	makerOrder.FilledQuantity = makerOrder.FilledQuantity.Add(tradeQuantity)
+	*remainingQuantity = remainingQuantity.Sub(tradeQuantity)
 
 	// Update last price
 	ob.LastPrice = makerOrder.Price
@@ -379,8 +360,8 @@ func (ob *OrderBook) executeTrade(takerOrder, makerOrder *Order, remainingQuanti
 	ob.logger.Debug("Trade executed",
 		zap.String("trade_id", trade.ID),
 		zap.String("symbol", trade.Symbol),
-		zap.Float64("price", trade.Price),
-		zap.Float64("quantity", trade.Quantity),
+		zap.Stringer("price", trade.Price),
+		zap.Stringer("quantity", trade.Quantity),
 		zap.String("taker_order_id", takerOrder.ID),
 		zap.String("maker_order_id", makerOrder.ID))
 
@@ -397,68 +378,80 @@ func (ob *OrderBook) CancelOrder(orderID string) bool {
 		return false
 	}
 
-	// Remove from the appropriate heap
-	var removed *Order
-	switch order.Side {
-	case OrderSideBuy:
-		if order.Type == OrderTypeStop || order.Type == OrderTypeStopLimit || order.Type == OrderTypeStopMarket {
-			removed = ob.StopBids.Remove(orderID)
-		} else {
-			removed = ob.Bids.Remove(orderID)
-		}
-	case OrderSideSell:
-		if order.Type == OrderTypeStop || order.Type == OrderTypeStopLimit || order.Type == OrderTypeStopMarket {
-			removed = ob.StopAsks.Remove(orderID)
-		} else {
-			removed = ob.Asks.Remove(orderID)
-		}
-	}
+	// Remove from the side the order rests on
+	removed := ob.unqueue(order)
 
 	if removed != nil {
 		removed.Status = OrderStatusCancelled
 		delete(ob.Orders, orderID)
-		
+
 		ob.logger.Debug("Order cancelled",
 			zap.String("order_id", orderID),
 			zap.String("symbol", ob.Symbol))
-		
+
+		if ob.Phase.IsCall() {
+			ob.publishIndicative()
+		}
+		ob.repricePegs()
+
 		return true
 	}
 
 	return false
 }
 
+// GetOrder returns a copy of an order the book holds
+func (ob *OrderBook) GetOrder(orderID string) (*Order, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	order, exists := ob.Orders[orderID]
+	if !exists {
+		return nil, false
+	}
+	copied := *order
+	return &copied, true
+}
+
+// GetLastPrice returns the last traded price
+func (ob *OrderBook) GetLastPrice() Decimal {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.LastPrice
+}
+
 // GetBestBid returns the best bid price
-func (ob *OrderBook) GetBestBid() float64 {
+func (ob *OrderBook) GetBestBid() Decimal {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
 	if ob.Bids.Len() > 0 {
 		return ob.Bids.Peek().Price
 	}
-	return 0
+	return types.Zero
 }
 
 // GetBestAsk returns the best ask price
-func (ob *OrderBook) GetBestAsk() float64 {
+func (ob *OrderBook) GetBestAsk() Decimal {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
 	if ob.Asks.Len() > 0 {
 		return ob.Asks.Peek().Price
 	}
-	return 0
+	return types.Zero
 }
 
 // GetSpread returns the bid-ask spread
-func (ob *OrderBook) GetSpread() float64 {
+func (ob *OrderBook) GetSpread() Decimal {
 	bestBid := ob.GetBestBid()
 	bestAsk := ob.GetBestAsk()
 
-	if bestBid > 0 && bestAsk > 0 {
-		return bestAsk - bestBid
+	if bestBid.IsPositive() && bestAsk.IsPositive() {
+		return bestAsk.Sub(bestBid)
 	}
-	return 0
+	return types.Zero
 }
 
 // GetDepth returns the market depth
@@ -466,44 +459,13 @@ func (ob *OrderBook) GetDepth(levels int) ([]PriceLevel, []PriceLevel) {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
-	bids := make([]PriceLevel, 0, levels)
-	asks := make([]PriceLevel, 0, levels)
-
-	// Get bid levels
-	bidMap := make(map[float64]float64)
-	for _, order := range ob.Bids.Orders {
-		bidMap[order.Price] += order.RemainingQuantity()
-	}
-
-	// Convert to sorted slice
-	for price, quantity := range bidMap {
-		bids = append(bids, PriceLevel{Price: price, Quantity: quantity})
-		if len(bids) >= levels {
-			break
-		}
-	}
-
-	// Get ask levels
-	askMap := make(map[float64]float64)
-	for _, order := range ob.Asks.Orders {
-		askMap[order.Price] += order.RemainingQuantity()
-	}
-
-	// Convert to sorted slice
-	for price, quantity := range askMap {
-		asks = append(asks, PriceLevel{Price: price, Quantity: quantity})
-		if len(asks) >= levels {
-			break
-		}
-	}
-
-	return bids, asks
+	return ob.Bids.Depth(levels), ob.Asks.Depth(levels)
 }
 
 // PriceLevel represents a price level in the order book
 type PriceLevel struct {
-	Price    float64 `json:"price"`
-	Quantity float64 `json:"quantity"`
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
 }
 
 // MatchingEngine represents the order matching engine
@@ -512,6 +474,16 @@ type MatchingEngine struct {
 	OrderBooks map[string]*OrderBook
 	// TradeChannel is the channel for trades
 	TradeChannel chan *Trade
+	// EventChannel is the channel for time in force and expiry events
+	EventChannel chan *MatchingEvent
+	// marketHalted and marketHaltReason record a market-wide halt
+	marketHalted     bool
+	marketHaltReason string
+	// statusListeners receive trading halts and resumptions
+	statusListeners []func(types.TradingStatus)
+	// clock and nextTradeID are passed to every order book
+	clock       func() time.Time
+	nextTradeID func() string
 	// Logger
 	logger *zap.Logger
 	// Mutex for thread safety
@@ -521,11 +493,32 @@ type MatchingEngine struct {
 // Engine is an alias for MatchingEngine for compatibility
 type Engine = MatchingEngine
 
+// OrderEngine executes the orders of the order service. A MatchingEngine
+// executes them as they arrive; a Sequencer sequences and journals them
+// first so the books can be recovered.
+type OrderEngine interface {
+	AddOrder(order *Order) ([]*Trade, error)
+	CancelOrder(symbol, orderID string) bool
+	AmendOrder(symbol, orderID string, price, quantity Decimal) ([]*Trade, error)
+	GetOrder(symbol, orderID string) (*Order, bool)
+	GetOrderBook(symbol string) *OrderBook
+	IsHalted(symbol string) bool
+	// Events receives the order and trading events of every book
+	Events() <-chan *MatchingEvent
+	// RunPhases follows the exchange schedules every interval until the
+	// context is done
+	RunPhases(ctx context.Context, interval time.Duration)
+	// RunExpiry expires DAY and GTD orders every interval until the
+	// context is done
+	RunExpiry(ctx context.Context, interval time.Duration)
+}
+
 // NewMatchingEngine creates a new matching engine
 func NewMatchingEngine(logger *zap.Logger) *MatchingEngine {
 	return &MatchingEngine{
 		OrderBooks:   make(map[string]*OrderBook),
 		TradeChannel: make(chan *Trade, 1000),
+		EventChannel: make(chan *MatchingEvent, 1000),
 		logger:       logger,
 	}
 }
@@ -536,16 +529,13 @@ func NewEngine(logger *zap.Logger) *Engine {
 }
 
 // AddOrder adds an order to the matching engine
-func (me *MatchingEngine) AddOrder(order *Order) []*Trade {
-	me.mu.Lock()
-	orderBook, exists := me.OrderBooks[order.Symbol]
-	if !exists {
-		orderBook = NewOrderBook(order.Symbol, me.logger)
-		me.OrderBooks[order.Symbol] = orderBook
-	}
-	me.mu.Unlock()
+func (me *MatchingEngine) AddOrder(order *Order) ([]*Trade, error) {
+	orderBook := me.orderBook(order.Symbol)
 
-	trades := orderBook.AddOrder(order)
+	trades, err := orderBook.AddOrder(order)
+	if err != nil {
+		return nil, err
+	}
 
 	// Send trades to channel
 	for _, trade := range trades {
@@ -557,7 +547,7 @@ func (me *MatchingEngine) AddOrder(order *Order) []*Trade {
 		}
 	}
 
-	return trades
+	return trades, nil
 }
 
 // CancelOrder cancels an order
@@ -573,6 +563,19 @@ func (me *MatchingEngine) CancelOrder(symbol, orderID string) bool {
 	return orderBook.CancelOrder(orderID)
 }
 
+// GetOrder returns a copy of an order held in the order book for a symbol
+func (me *MatchingEngine) GetOrder(symbol, orderID string) (*Order, bool) {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	me.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	return orderBook.GetOrder(orderID)
+}
+
 // GetOrderBook returns the order book for a symbol
 func (me *MatchingEngine) GetOrderBook(symbol string) *OrderBook {
 	me.mu.RLock()
@@ -581,10 +584,7 @@ func (me *MatchingEngine) GetOrderBook(symbol string) *OrderBook {
 	return me.OrderBooks[symbol]
 }
 
-// Helper function
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
+// Events returns the channel of order and trading events
+func (me *MatchingEngine) Events() <-chan *MatchingEvent {
+	return me.EventChannel
 }
@@ -0,0 +1,243 @@
+package matching
+
+import (
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// Halt halts trading in the book. Matching stops, resting limit orders
+// queue for the re-opening auction, and market and immediate orders are
+// rejected until trading resumes.
+func (ob *OrderBook) Halt(reason string) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.halted = true
+	ob.haltReason = reason
+	ob.updatePhase()
+}
+
+// Resume lifts a symbol halt. Unless a market-wide halt is still in force
+// the book re-opens into its scheduled phase, uncrossing the orders queued
+// during the halt when that phase is continuous trading.
+func (ob *OrderBook) Resume() []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.halted = false
+	return ob.updatePhase()
+}
+
+// setMarketHalt applies or lifts a market-wide halt
+func (ob *OrderBook) setMarketHalt(halted bool, reason string) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.marketHalted = halted
+	if halted && !ob.halted {
+		ob.haltReason = reason
+	}
+	return ob.updatePhase()
+}
+
+// IsHalted returns true while a symbol or market-wide halt is in force
+func (ob *OrderBook) IsHalted() bool {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.halted || ob.marketHalted
+}
+
+// CurrentPhase returns the book's trading phase
+func (ob *OrderBook) CurrentPhase() types.TradingPhase {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.Phase
+}
+
+// emitStatus reports a halt or resumption of the book
+func (ob *OrderBook) emitStatus(eventType MatchingEventType) {
+	if ob.eventHandler == nil {
+		return
+	}
+	event := &MatchingEvent{
+		Type:      eventType,
+		Symbol:    ob.Symbol,
+		Phase:     ob.Phase,
+		Timestamp: ob.now(),
+	}
+	if eventType == EventTradingHalted {
+		event.Reason = ob.haltReason
+	}
+	ob.eventHandler(event)
+}
+
+// orderBook returns the book for a symbol, creating it if needed. New books
+// join a market-wide halt in force.
+func (me *MatchingEngine) orderBook(symbol string) *OrderBook {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	orderBook, exists := me.OrderBooks[symbol]
+	if !exists {
+		orderBook = newOrderBook(symbol, me.logger, me.now())
+		orderBook.eventHandler = me.publishEvent
+		orderBook.clock = me.clock
+		orderBook.nextTradeID = me.nextTradeID
+		if me.marketHalted {
+			orderBook.setMarketHalt(true, me.marketHaltReason)
+		}
+		me.OrderBooks[symbol] = orderBook
+	}
+	return orderBook
+}
+
+// OnTradingStatus registers a listener for trading halts and resumptions,
+// such as the WebSocket and market data broadcasters
+func (me *MatchingEngine) OnTradingStatus(listener func(types.TradingStatus)) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.statusListeners = append(me.statusListeners, listener)
+}
+
+// notifyStatus passes a trading status change to every listener
+func (me *MatchingEngine) notifyStatus(status types.TradingStatus) {
+	me.mu.RLock()
+	listeners := me.statusListeners
+	me.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(status)
+	}
+}
+
+// Halt halts trading in a symbol; see OrderBook.Halt
+func (me *MatchingEngine) Halt(symbol, reason string) {
+	me.orderBook(symbol).Halt(reason)
+
+	me.logger.Warn("Trading halted",
+		zap.String("symbol", symbol),
+		zap.String("reason", reason))
+
+	me.notifyStatus(types.TradingStatus{
+		Symbol:    symbol,
+		Phase:     types.TradingPhaseHalted,
+		Halted:    true,
+		Reason:    reason,
+		Timestamp: me.now(),
+	})
+}
+
+// Resume lifts a symbol halt and publishes the trades of the re-opening
+// auction
+func (me *MatchingEngine) Resume(symbol string) ([]*Trade, error) {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	me.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	trades := orderBook.Resume()
+
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+
+	phase := orderBook.CurrentPhase()
+	me.logger.Info("Trading resumed",
+		zap.String("symbol", symbol),
+		zap.String("phase", string(phase)),
+		zap.Int("auction_trades", len(trades)))
+
+	me.notifyStatus(types.TradingStatus{
+		Symbol:    symbol,
+		Phase:     phase,
+		Halted:    phase == types.TradingPhaseHalted,
+		Timestamp: me.now(),
+	})
+
+	return trades, nil
+}
+
+// HaltMarket halts trading in every symbol, including books created
+// during the halt
+func (me *MatchingEngine) HaltMarket(reason string) {
+	for _, orderBook := range me.markMarketHalt(true, reason) {
+		orderBook.setMarketHalt(true, reason)
+	}
+
+	me.logger.Warn("Market-wide trading halt",
+		zap.String("reason", reason))
+
+	me.notifyStatus(types.TradingStatus{
+		Halted:    true,
+		Reason:    reason,
+		Timestamp: me.now(),
+	})
+}
+
+// ResumeMarket lifts a market-wide halt and publishes the trades of the
+// re-opening auctions. Symbols with their own halt stay halted.
+func (me *MatchingEngine) ResumeMarket() []*Trade {
+	var trades []*Trade
+	for _, orderBook := range me.markMarketHalt(false, "") {
+		trades = append(trades, orderBook.setMarketHalt(false, "")...)
+	}
+
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+
+	me.logger.Info("Market-wide trading resumed",
+		zap.Int("auction_trades", len(trades)))
+
+	me.notifyStatus(types.TradingStatus{
+		Timestamp: me.now(),
+	})
+
+	return trades
+}
+
+// markMarketHalt records a market-wide halt, or its lifting, for the books
+// created from now on and returns the existing books
+func (me *MatchingEngine) markMarketHalt(halted bool, reason string) []*OrderBook {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.marketHalted = halted
+	me.marketHaltReason = reason
+	books := make([]*OrderBook, 0, len(me.OrderBooks))
+	for _, orderBook := range me.OrderBooks {
+		books = append(books, orderBook)
+	}
+	return books
+}
+
+// IsHalted returns true if trading in a symbol is halted
+func (me *MatchingEngine) IsHalted(symbol string) bool {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	marketHalted := me.marketHalted
+	me.mu.RUnlock()
+
+	if !exists {
+		return marketHalted
+	}
+	return orderBook.IsHalted()
+}
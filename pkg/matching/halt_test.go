@@ -0,0 +1,89 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchingEngine_Halt(t *testing.T) {
+	t.Run("symbol halt", func(t *testing.T) {
+		engine := newTestEngine(t)
+		var statuses []types.TradingStatus
+		engine.OnTradingStatus(func(status types.TradingStatus) {
+			statuses = append(statuses, status)
+		})
+
+		_, err := engine.AddOrder(newLimitOrder("ask", OrderSideSell, "100", "10"))
+		require.NoError(t, err)
+		engine.Halt("AAPL", "volatility")
+		assert.True(t, engine.IsHalted("AAPL"))
+		assert.False(t, engine.IsHalted("MSFT"))
+		assert.Equal(t, types.TradingPhaseHalted, engine.GetOrderBook("AAPL").CurrentPhase())
+
+		// Market and immediate orders are rejected
+		market := newLimitOrder("market", OrderSideBuy, "0", "10")
+		market.Type = OrderTypeMarket
+		_, err = engine.AddOrder(market)
+		assert.Equal(t, types.RejectReasonTradingHalted, types.RejectReasonOf(err))
+		assert.Equal(t, OrderStatusRejected, market.Status)
+		ioc := newLimitOrder("ioc", OrderSideBuy, "100", "10")
+		ioc.TimeInForce = TimeInForceIOC
+		_, err = engine.AddOrder(ioc)
+		assert.Equal(t, types.RejectReasonTradingHalted, types.RejectReasonOf(err))
+
+		// A crossing limit order queues for the re-opening auction
+		bid := newLimitOrder("bid", OrderSideBuy, "100", "10")
+		trades, err := engine.AddOrder(bid)
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+		assert.Equal(t, OrderStatusNew, bid.Status)
+
+		trades, err = engine.Resume("AAPL")
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, "bid", trades[0].BuyOrderID)
+		assert.False(t, engine.IsHalted("AAPL"))
+
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].Halted)
+		assert.Equal(t, "volatility", statuses[0].Reason)
+		assert.Equal(t, types.TradingPhaseHalted, statuses[0].Phase)
+		assert.False(t, statuses[1].Halted)
+		assert.Equal(t, types.TradingPhaseContinuous, statuses[1].Phase)
+
+		events := drainEvents(engine)
+		assert.Contains(t, events, EventTradingHalted)
+		assert.Contains(t, events, EventTradingResumed)
+	})
+
+	t.Run("market-wide halt", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		_, err := engine.AddOrder(newLimitOrder("ask", OrderSideSell, "100", "10"))
+		require.NoError(t, err)
+		engine.Halt("AAPL", "news")
+		engine.HaltMarket("circuit breaker")
+
+		// Books created during the halt are halted too
+		msft := newLimitOrder("msft", OrderSideBuy, "100", "10")
+		msft.Symbol = "MSFT"
+		msft.Type = OrderTypeMarket
+		_, err = engine.AddOrder(msft)
+		assert.Equal(t, types.RejectReasonTradingHalted, types.RejectReasonOf(err))
+		assert.True(t, engine.IsHalted("MSFT"))
+
+		// Lifting the market halt leaves the symbol halt in force
+		_, err = engine.AddOrder(newLimitOrder("bid", OrderSideBuy, "100", "10"))
+		require.NoError(t, err)
+		assert.Empty(t, engine.ResumeMarket())
+		assert.True(t, engine.IsHalted("AAPL"))
+		assert.False(t, engine.IsHalted("MSFT"))
+
+		trades, err := engine.Resume("AAPL")
+		require.NoError(t, err)
+		assert.Len(t, trades, 1)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"unsafe"
 
 	"github.com/abdoElHodaky/tradSys/internal/common/pool"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -21,6 +22,9 @@ type HFTEngine struct {
 	// Trade channel with buffering for high throughput
 	TradeChannel chan *Trade
 
+	// Event channel for self-trade prevention events
+	EventChannel chan *MatchingEvent
+
 	// Order pools for zero-allocation order processing
 	fastOrderPool *pool.FastOrderPool
 	tradePool     *pool.TradePool
@@ -46,16 +50,17 @@ type HFTEngine struct {
 
 // EngineStats represents engine performance statistics
 type EngineStats struct {
-	OrdersProcessed   uint64
-	TradesExecuted    uint64
-	AvgLatencyNs      uint64
-	MaxLatencyNs      uint64
-	MinLatencyNs      uint64
-	TotalVolumeTraded uint64
-	ActiveOrders      uint64
-	CancelledOrders   uint64
-	RejectedOrders    uint64
-	LastUpdateTime    time.Time
+	OrdersProcessed     uint64
+	TradesExecuted      uint64
+	AvgLatencyNs        uint64
+	MaxLatencyNs        uint64
+	MinLatencyNs        uint64
+	TotalVolumeTraded   uint64
+	ActiveOrders        uint64
+	CancelledOrders     uint64
+	RejectedOrders      uint64
+	SelfTradesPrevented uint64
+	LastUpdateTime      time.Time
 }
 
 // HFTOrderBook represents a high-frequency trading optimized order book
@@ -74,12 +79,20 @@ type HFTOrderBook struct {
 	totalTrades   uint64
 	totalVolume   uint64
 	lastTradeTime time.Time
+	lastPrice     uint64 // atomic, fixed-point at scale.Price
+	markPrice     uint64 // atomic, fixed-point at scale.Price
+
+	// Fixed-point scale of the uint64 prices and quantities in this book
+	scale types.SymbolScale
 
 	// Spread tracking
 	bestBid  uint64 // atomic
 	bestAsk  uint64 // atomic
 	spread   uint64 // atomic
 
+	// Parked stop orders in arrival order, guarded by mu
+	stopOrders []*HFTOrder
+
 	// Lock for critical sections (minimal usage)
 	mu sync.RWMutex
 }
@@ -99,12 +112,20 @@ type HFTOrder struct {
 	Side      OrderSide
 	Type      OrderType
 	Price     uint64 // Fixed-point representation
+	StopPrice uint64 // Fixed-point representation
 	Quantity  uint64
 	Filled    uint64
 	Status    OrderStatus
 	Timestamp time.Time
 	UserID    string
 
+	// TimeInForce is honoured for IOC and FOK; other values rest as GTC
+	TimeInForce TimeInForce
+
+	// Self-trade prevention identity and mode override
+	AccountGroup        string
+	SelfTradePrevention types.SelfTradePrevention
+
 	// Linked list pointers for order book
 	Next *HFTOrder
 	Prev *HFTOrder
@@ -113,6 +134,30 @@ type HFTOrder struct {
 	LatencyNs uint64
 }
 
+// NewHFTOrder converts an order to the HFT engine's fixed-point representation
+// using the symbol's registered price and quantity scale
+func NewHFTOrder(order *Order) *HFTOrder {
+	scale := types.GetSymbolScale(order.Symbol)
+	return &HFTOrder{
+		ID:        order.ID,
+		Symbol:    order.Symbol,
+		Side:      order.Side,
+		Type:      order.Type,
+		Price:     uint64(order.Price.Rescale(scale.Price).Unscaled()),
+		StopPrice: uint64(order.StopPrice.Rescale(scale.Price).Unscaled()),
+		Quantity:  uint64(order.Quantity.Rescale(scale.Quantity).Unscaled()),
+		Filled:    uint64(order.FilledQuantity.Rescale(scale.Quantity).Unscaled()),
+		Status:    order.Status,
+		Timestamp: order.CreatedAt,
+		UserID:    order.UserID,
+
+		TimeInForce: order.TimeInForce,
+
+		AccountGroup:        order.AccountGroup,
+		SelfTradePrevention: order.SelfTradePrevention,
+	}
+}
+
 // Use types from engine.go to avoid duplication
 
 // Use OrderStatus constants from engine.go
@@ -126,6 +171,7 @@ func NewHFTEngine(logger *zap.Logger, workerCount int) *HFTEngine {
 	engine := &HFTEngine{
 		orderBooks:    unsafe.Pointer(&map[string]*HFTOrderBook{}),
 		TradeChannel:  make(chan *Trade, 10000), // High-capacity buffer
+		EventChannel:  make(chan *MatchingEvent, 1000),
 		fastOrderPool: pool.NewFastOrderPool(),
 		tradePool:     pool.NewTradePool(1000),
 		logger:        logger,
@@ -175,9 +221,17 @@ func (e *HFTEngine) AddOrder(order *HFTOrder) error {
 	
 	// Get or create order book
 	orderBook := e.getOrCreateOrderBook(order.Symbol)
+
+	// Reject orders that break the instrument rules
+	if err := orderBook.validate(order); err != nil {
+		order.Status = OrderStatusRejected
+		atomic.AddUint64(&e.stats.RejectedOrders, 1)
+		return err
+	}
 	
-	// Process order
+	// Process order and release the stops it elects
 	trades := e.processOrder(orderBook, order)
+	trades = append(trades, e.triggerStops(orderBook)...)
 	
 	// Send trades to channel
 	for _, trade := range trades {
@@ -211,8 +265,12 @@ func (e *HFTEngine) CancelOrder(orderID string, symbol string) error {
 		order.Status = OrderStatusCancelled
 		orderBook.orderMap.Delete(orderID)
 		
-		// Remove from order book levels
-		e.removeOrderFromBook(orderBook, order)
+		// Remove from the parked stops or the order book levels
+		if order.isStop() {
+			orderBook.removeStop(order)
+		} else {
+			e.removeOrderFromBook(orderBook, order)
+		}
 		
 		atomic.AddUint64(&e.stats.CancelledOrders, 1)
 		return nil
@@ -229,14 +287,15 @@ func (e *HFTEngine) GetOrderBook(symbol string) *HFTOrderBook {
 // GetStats returns engine statistics
 func (e *HFTEngine) GetStats() *EngineStats {
 	stats := &EngineStats{
-		OrdersProcessed:   atomic.LoadUint64(&e.ordersProcessed),
-		TradesExecuted:    atomic.LoadUint64(&e.tradesExecuted),
-		AvgLatencyNs:      atomic.LoadUint64(&e.avgLatency),
-		TotalVolumeTraded: atomic.LoadUint64(&e.stats.TotalVolumeTraded),
-		ActiveOrders:      atomic.LoadUint64(&e.stats.ActiveOrders),
-		CancelledOrders:   atomic.LoadUint64(&e.stats.CancelledOrders),
-		RejectedOrders:    atomic.LoadUint64(&e.stats.RejectedOrders),
-		LastUpdateTime:    time.Now(),
+		OrdersProcessed:     atomic.LoadUint64(&e.ordersProcessed),
+		TradesExecuted:      atomic.LoadUint64(&e.tradesExecuted),
+		AvgLatencyNs:        atomic.LoadUint64(&e.avgLatency),
+		TotalVolumeTraded:   atomic.LoadUint64(&e.stats.TotalVolumeTraded),
+		ActiveOrders:        atomic.LoadUint64(&e.stats.ActiveOrders),
+		CancelledOrders:     atomic.LoadUint64(&e.stats.CancelledOrders),
+		RejectedOrders:      atomic.LoadUint64(&e.stats.RejectedOrders),
+		SelfTradesPrevented: atomic.LoadUint64(&e.stats.SelfTradesPrevented),
+		LastUpdateTime:      time.Now(),
 	}
 	
 	if stats.OrdersProcessed > 0 {
@@ -258,6 +317,7 @@ func (e *HFTEngine) getOrCreateOrderBook(symbol string) *HFTOrderBook {
 	newOrderBook := &HFTOrderBook{
 		Symbol:        symbol,
 		lastTradeTime: time.Now(),
+		scale:         types.GetSymbolScale(symbol),
 	}
 	
 	// Update map atomically
@@ -279,18 +339,36 @@ func (e *HFTEngine) getOrderBook(symbol string) *HFTOrderBook {
 	return (*orderBooksMap)[symbol]
 }
 
+// validate checks an order against the instrument rules at the book's scale
+func (ob *HFTOrderBook) validate(order *HFTOrder) error {
+	return types.Instruments.Validate(&types.Order{
+		Symbol:   order.Symbol,
+		Side:     order.Side,
+		Type:     order.Type,
+		Price:    types.NewDecimal(int64(order.Price), ob.scale.Price),
+		Quantity: types.NewDecimal(int64(order.Quantity), ob.scale.Quantity),
+	}, types.NewDecimal(int64(atomic.LoadUint64(&ob.lastPrice)), ob.scale.Price))
+}
+
 // processOrder processes an order and returns resulting trades
 func (e *HFTEngine) processOrder(orderBook *HFTOrderBook, order *HFTOrder) []*Trade {
 	var trades []*Trade
+
+	// Kill fill-or-kill orders the book cannot fill completely
+	if order.TimeInForce == TimeInForceFOK && !order.isStop() &&
+		e.availableQuantity(orderBook, order) < order.Quantity {
+		order.Status = OrderStatusRejected
+		atomic.AddUint64(&e.stats.RejectedOrders, 1)
+		return nil
+	}
 	
 	switch order.Type {
 	case OrderTypeMarket:
 		trades = e.processMarketOrder(orderBook, order)
 	case OrderTypeLimit:
 		trades = e.processLimitOrder(orderBook, order)
-	case OrderTypeStopLimit, OrderTypeStopMarket:
-		// Handle stop orders (simplified for now)
-		trades = e.processStopOrder(orderBook, order)
+	case OrderTypeStop, OrderTypeStopLimit, OrderTypeStopMarket:
+		e.processStopOrder(orderBook, order)
 	}
 	
 	return trades
@@ -304,7 +382,7 @@ func (e *HFTEngine) processMarketOrder(orderBook *HFTOrderBook, order *HFTOrder)
 	if order.Side == OrderSideBuy {
 		// Match against sell orders (asks)
 		sellOrders := (*OrderLevel)(atomic.LoadPointer(&orderBook.sellOrders))
-		for sellOrders != nil && remainingQty > 0 {
+		for sellOrders != nil && remainingQty > 0 && order.Status != OrderStatusCancelled {
 			trade := e.executeTrade(orderBook, order, sellOrders, &remainingQty)
 			if trade != nil {
 				trades = append(trades, trade)
@@ -314,7 +392,7 @@ func (e *HFTEngine) processMarketOrder(orderBook *HFTOrderBook, order *HFTOrder)
 	} else {
 		// Match against buy orders (bids)
 		buyOrders := (*OrderLevel)(atomic.LoadPointer(&orderBook.buyOrders))
-		for buyOrders != nil && remainingQty > 0 {
+		for buyOrders != nil && remainingQty > 0 && order.Status != OrderStatusCancelled {
 			trade := e.executeTrade(orderBook, order, buyOrders, &remainingQty)
 			if trade != nil {
 				trades = append(trades, trade)
@@ -323,6 +401,12 @@ func (e *HFTEngine) processMarketOrder(orderBook *HFTOrderBook, order *HFTOrder)
 		}
 	}
 	
+	// Self-trade prevention cancelled the incoming order
+	if order.Status == OrderStatusCancelled {
+		order.Filled = order.Quantity - remainingQty
+		return trades
+	}
+
 	// Update order status
 	if remainingQty == 0 {
 		order.Status = OrderStatusFilled
@@ -345,7 +429,7 @@ func (e *HFTEngine) processLimitOrder(orderBook *HFTOrderBook, order *HFTOrder)
 	// First try to match against existing orders
 	if order.Side == OrderSideBuy {
 		sellOrders := (*OrderLevel)(atomic.LoadPointer(&orderBook.sellOrders))
-		for sellOrders != nil && remainingQty > 0 && sellOrders.Price <= order.Price {
+		for sellOrders != nil && remainingQty > 0 && sellOrders.Price <= order.Price && order.Status != OrderStatusCancelled {
 			trade := e.executeTrade(orderBook, order, sellOrders, &remainingQty)
 			if trade != nil {
 				trades = append(trades, trade)
@@ -354,7 +438,7 @@ func (e *HFTEngine) processLimitOrder(orderBook *HFTOrderBook, order *HFTOrder)
 		}
 	} else {
 		buyOrders := (*OrderLevel)(atomic.LoadPointer(&orderBook.buyOrders))
-		for buyOrders != nil && remainingQty > 0 && buyOrders.Price >= order.Price {
+		for buyOrders != nil && remainingQty > 0 && buyOrders.Price >= order.Price && order.Status != OrderStatusCancelled {
 			trade := e.executeTrade(orderBook, order, buyOrders, &remainingQty)
 			if trade != nil {
 				trades = append(trades, trade)
@@ -363,6 +447,20 @@ func (e *HFTEngine) processLimitOrder(orderBook *HFTOrderBook, order *HFTOrder)
 		}
 	}
 	
+	// Self-trade prevention cancelled the incoming order
+	if order.Status == OrderStatusCancelled {
+		order.Filled = order.Quantity - remainingQty
+		return trades
+	}
+
+	// Immediate-or-cancel remainders never rest
+	if remainingQty > 0 && order.TimeInForce == TimeInForceIOC {
+		order.Filled = order.Quantity - remainingQty
+		order.Status = OrderStatusCancelled
+		atomic.AddUint64(&e.stats.CancelledOrders, 1)
+		return trades
+	}
+
 	// If there's remaining quantity, add to order book
 	if remainingQty > 0 {
 		order.Quantity = remainingQty
@@ -377,11 +475,32 @@ func (e *HFTEngine) processLimitOrder(orderBook *HFTOrderBook, order *HFTOrder)
 	return trades
 }
 
-// processStopOrder processes a stop order (simplified implementation)
-func (e *HFTEngine) processStopOrder(orderBook *HFTOrderBook, order *HFTOrder) []*Trade {
-	// For now, treat as limit order
-	// In production, would implement proper stop logic
-	return e.processLimitOrder(orderBook, order)
+// availableQuantity sums the resting quantity an order could trade against,
+// stopping once the order's quantity is covered
+func (e *HFTEngine) availableQuantity(orderBook *HFTOrderBook, order *HFTOrder) uint64 {
+	var available uint64
+
+	if order.Side == OrderSideBuy {
+		level := (*OrderLevel)(atomic.LoadPointer(&orderBook.sellOrders))
+		for level != nil && available < order.Quantity {
+			if order.Type != OrderTypeMarket && level.Price > order.Price {
+				break
+			}
+			available += atomic.LoadUint64(&level.Quantity)
+			level = (*OrderLevel)(atomic.LoadPointer(&level.Next))
+		}
+	} else {
+		level := (*OrderLevel)(atomic.LoadPointer(&orderBook.buyOrders))
+		for level != nil && available < order.Quantity {
+			if order.Type != OrderTypeMarket && level.Price < order.Price {
+				break
+			}
+			available += atomic.LoadUint64(&level.Quantity)
+			level = (*OrderLevel)(atomic.LoadPointer(&level.Next))
+		}
+	}
+
+	return available
 }
 
 // executeTrade executes a trade between two orders
@@ -401,13 +520,19 @@ func (e *HFTEngine) executeTrade(orderBook *HFTOrderBook, incomingOrder *HFTOrde
 	if tradeQty == 0 {
 		return nil
 	}
+
+	// Never trade an order against the same user or account group
+	if e.isSelfTrade(incomingOrder, levelOrder) {
+		e.preventSelfTrade(orderBook, incomingOrder, level, levelOrder, tradeQty, remainingQty)
+		return nil
+	}
 	
 	// Create trade
 	trade := &Trade{
 		ID:        uuid.New().String(),
 		Symbol:    orderBook.Symbol,
-		Price:     float64(level.Price),
-		Quantity:  float64(tradeQty),
+		Price:     types.NewDecimal(int64(level.Price), orderBook.scale.Price),
+		Quantity:  types.NewDecimal(int64(tradeQty), orderBook.scale.Quantity),
 		Timestamp: time.Now(),
 	}
 	
@@ -422,6 +547,7 @@ func (e *HFTEngine) executeTrade(orderBook *HFTOrderBook, incomingOrder *HFTOrde
 	// Update orders
 	levelOrder.Filled += tradeQty
 	*remainingQty -= tradeQty
+	atomic.StoreUint64(&orderBook.lastPrice, level.Price)
 	
 	// Update statistics
 	atomic.AddUint64(&e.tradesExecuted, 1)
@@ -440,6 +566,93 @@ func (e *HFTEngine) executeTrade(orderBook *HFTOrderBook, incomingOrder *HFTOrde
 	return trade
 }
 
+// isSelfTrade returns true if both orders belong to the same user or account
+// group and self-trade prevention applies to the incoming order
+func (e *HFTEngine) isSelfTrade(incomingOrder, levelOrder *HFTOrder) bool {
+	key := types.SelfTradeKey(incomingOrder.UserID, incomingOrder.AccountGroup)
+	if key == "" || key != types.SelfTradeKey(levelOrder.UserID, levelOrder.AccountGroup) {
+		return false
+	}
+	return e.selfTradeMode(incomingOrder) != types.SelfTradePreventionNone
+}
+
+// selfTradeMode resolves the self-trade prevention mode of an incoming order
+func (e *HFTEngine) selfTradeMode(order *HFTOrder) types.SelfTradePrevention {
+	return types.SelfTradePolicies.Mode(order.UserID, order.AccountGroup, order.SelfTradePrevention)
+}
+
+// preventSelfTrade applies the incoming order's self-trade prevention mode
+// against the first order of a level
+func (e *HFTEngine) preventSelfTrade(orderBook *HFTOrderBook, incomingOrder *HFTOrder, level *OrderLevel, levelOrder *HFTOrder, quantity uint64, remainingQty *uint64) {
+	mode := e.selfTradeMode(incomingOrder)
+	cancelLevelOrder := func() {
+		levelOrder.Status = OrderStatusCancelled
+		e.removeOrderFromLevel(level, levelOrder)
+		orderBook.orderMap.Delete(levelOrder.ID)
+		atomic.AddUint64(&e.stats.ActiveOrders, ^uint64(0)) // Decrement
+		atomic.AddUint64(&e.stats.CancelledOrders, 1)
+	}
+
+	switch mode {
+	case types.SelfTradePreventionCancelOldest:
+		cancelLevelOrder()
+	case types.SelfTradePreventionCancelBoth:
+		cancelLevelOrder()
+		incomingOrder.Status = OrderStatusCancelled
+	case types.SelfTradePreventionDecrementAndCancel:
+		incomingOrder.Quantity -= quantity
+		*remainingQty -= quantity
+		levelOrder.Quantity -= quantity
+		level.Quantity -= quantity
+		if levelOrder.Filled >= levelOrder.Quantity {
+			cancelLevelOrder()
+		}
+		if *remainingQty == 0 {
+			incomingOrder.Status = OrderStatusCancelled
+		}
+	default:
+		incomingOrder.Status = OrderStatusCancelled
+	}
+	if incomingOrder.Status == OrderStatusCancelled {
+		atomic.AddUint64(&e.stats.CancelledOrders, 1)
+	}
+	atomic.AddUint64(&e.stats.SelfTradesPrevented, 1)
+
+	select {
+	case e.EventChannel <- &MatchingEvent{
+		Type:        EventSelfTradePrevented,
+		Symbol:      orderBook.Symbol,
+		Order:       incomingOrder.toOrder(orderBook.scale),
+		ContraOrder: levelOrder.toOrder(orderBook.scale),
+		Quantity:    types.NewDecimal(int64(quantity), orderBook.scale.Quantity),
+		Timestamp:   time.Now(),
+	}:
+	default:
+		e.logger.Warn("Event channel full, dropping event",
+			zap.String("event_type", string(EventSelfTradePrevented)),
+			zap.String("symbol", orderBook.Symbol))
+	}
+}
+
+// toOrder converts an HFT order back to the shared order representation
+func (o *HFTOrder) toOrder(scale types.SymbolScale) *Order {
+	return &Order{
+		ID:             o.ID,
+		Symbol:         o.Symbol,
+		Side:           o.Side,
+		Type:           o.Type,
+		Price:          types.NewDecimal(int64(o.Price), scale.Price),
+		StopPrice:      types.NewDecimal(int64(o.StopPrice), scale.Price),
+		Quantity:       types.NewDecimal(int64(o.Quantity), scale.Quantity),
+		FilledQuantity: types.NewDecimal(int64(o.Filled), scale.Quantity),
+		Status:         o.Status,
+		CreatedAt:      o.Timestamp,
+		UserID:         o.UserID,
+		AccountGroup:   o.AccountGroup,
+		TimeInForce:    o.TimeInForce,
+	}
+}
+
 // addOrderToBook adds an order to the order book
 func (e *HFTEngine) addOrderToBook(orderBook *HFTOrderBook, order *HFTOrder) {
 	// Store order in map for fast lookup
@@ -638,8 +851,8 @@ func (e *HFTEngine) tradeProcessor() {
 			e.logger.Debug("Trade executed",
 				zap.String("trade_id", trade.ID),
 				zap.String("symbol", trade.Symbol),
-				zap.Float64("price", trade.Price),
-				zap.Float64("quantity", trade.Quantity))
+				zap.Stringer("price", trade.Price),
+				zap.Stringer("quantity", trade.Quantity))
 
 		}
 	}
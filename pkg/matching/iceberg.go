@@ -0,0 +1,202 @@
+package matching
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// rest queues the unfilled remainder of an order on its side of the book.
+// Hidden orders rest on the hidden side, iceberg orders show their first
+// slice and pegged orders are tracked for repricing.
+func (ob *OrderBook) rest(order *Order) {
+	if order.IsPegged() {
+		ob.pegs[order.ID] = order
+	}
+	if !order.IsIceberg() {
+		ob.restingSide(order).Push(order)
+		return
+	}
+
+	iceberg := &IcebergOrder{
+		ParentOrder:   order,
+		DisplaySize:   order.DisplayQuantity,
+		TotalSize:     order.Quantity,
+		RemainingSize: order.RemainingQuantity(),
+	}
+	ob.icebergs[order.ID] = iceberg
+	ob.showSlice(iceberg)
+}
+
+// best returns the first order a taker meets on one side of the book and
+// the book side it rests on. Hidden orders trade after the displayed
+// orders at the same price but ahead of worse prices.
+func (ob *OrderBook) best(lit, hidden *BookSide) (*BookSide, *Order) {
+	order, hiddenOrder := lit.Peek(), hidden.Peek()
+	if hiddenOrder != nil && (order == nil || hidden.better(hiddenOrder.Price, order.Price)) {
+		return hidden, hiddenOrder
+	}
+	return lit, order
+}
+
+// showSlice queues the next slice of an iceberg order at the back of its
+// price level, so every refill takes new time priority. Slice IDs are
+// derived from the parent ID, so a replay shows the same slices.
+func (ob *OrderBook) showSlice(iceberg *IcebergOrder) {
+	parent := iceberg.ParentOrder
+	iceberg.Slices++
+	size := types.IcebergPeak(parent, iceberg.Slices, iceberg.RemainingSize)
+
+	slice := *parent
+	slice.ID = fmt.Sprintf("%s/%d", parent.ID, iceberg.Slices)
+	slice.Quantity = size
+	slice.FilledQuantity = types.Zero
+	slice.DisplayQuantity = types.Zero
+	slice.ParentOrderID = parent.ID
+	slice.IsIcebergChild = true
+	slice.Status = OrderStatusNew
+	slice.QueuedAt = ob.now()
+
+	iceberg.RemainingSize = iceberg.RemainingSize.Sub(size)
+	iceberg.RefreshSize = size
+	iceberg.CurrentOrder = &slice
+	ob.restingSide(&slice).Push(&slice)
+}
+
+// fillSlice passes a fill of an iceberg slice to its parent and shows the
+// next slice once the current one is filled. The caller has already
+// reduced the slice on its book side.
+func (ob *OrderBook) fillSlice(side *BookSide, slice *Order, quantity Decimal) {
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return
+	}
+	parent := iceberg.ParentOrder
+	parent.FilledQuantity = parent.FilledQuantity.Add(quantity)
+	parent.UpdatedAt = ob.now()
+	parent.Status = OrderStatusPartiallyFilled
+
+	if slice.RemainingQuantity().IsPositive() {
+		return
+	}
+	side.Remove(slice.ID)
+	slice.Status = OrderStatusFilled
+
+	if !iceberg.RemainingSize.IsPositive() {
+		delete(ob.icebergs, parent.ID)
+		parent.Status = OrderStatusFilled
+		return
+	}
+
+	ob.showSlice(iceberg)
+
+	ob.logger.Debug("Iceberg order refreshed",
+		zap.String("order_id", parent.ID),
+		zap.String("slice_id", iceberg.CurrentOrder.ID),
+		zap.Stringer("display_quantity", iceberg.RefreshSize),
+		zap.Stringer("reserve_quantity", iceberg.RemainingSize))
+
+	ob.emit(EventIcebergRefreshed, iceberg.CurrentOrder)
+}
+
+// decrementSlice shrinks an iceberg order by a quantity self-trade
+// prevention took from its slice. An exhausted slice is replaced by the
+// next one, and the iceberg is cancelled when nothing is left to show.
+func (ob *OrderBook) decrementSlice(side *BookSide, slice *Order, quantity Decimal) {
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return
+	}
+	parent := iceberg.ParentOrder
+	parent.Quantity = parent.Quantity.Sub(quantity)
+
+	if slice.RemainingQuantity().IsPositive() {
+		return
+	}
+	side.Remove(slice.ID)
+	slice.Status = OrderStatusCancelled
+
+	if !iceberg.RemainingSize.IsPositive() {
+		ob.cancelSelfTrade(slice)
+		return
+	}
+	ob.showSlice(iceberg)
+}
+
+// dropIceberg ends an iceberg order whose slice left the book unfilled and
+// returns the parent, which takes the slice's final status
+func (ob *OrderBook) dropIceberg(slice *Order, status OrderStatus) *Order {
+	slice.Status = status
+	iceberg, exists := ob.icebergs[slice.ParentOrderID]
+	if !exists {
+		return slice
+	}
+	delete(ob.icebergs, slice.ParentOrderID)
+	return iceberg.ParentOrder
+}
+
+// unqueue removes a resting order from the book, taking an iceberg's
+// displayed slice with it. It returns nil if the order is not resting.
+func (ob *OrderBook) unqueue(order *Order) *Order {
+	iceberg, exists := ob.icebergs[order.ID]
+	if !exists {
+		return ob.restingSide(order).Remove(order.ID)
+	}
+
+	slice := iceberg.CurrentOrder
+	ob.restingSide(slice).Remove(slice.ID)
+	ob.dropIceberg(slice, OrderStatusCancelled)
+	return order
+}
+
+// reserve returns the quantity an order adds to its price level beyond
+// what is displayed: the undisplayed rest of an iceberg order
+func (ob *OrderBook) reserve(order *Order) Decimal {
+	if !order.IsIcebergChild {
+		return types.Zero
+	}
+	if iceberg, exists := ob.icebergs[order.ParentOrderID]; exists {
+		return iceberg.RemainingSize
+	}
+	return types.Zero
+}
+
+// orderID returns the ID a trade reports for an order: the parent's for an
+// iceberg slice
+func orderID(order *Order) string {
+	if order.IsIcebergChild {
+		return order.ParentOrderID
+	}
+	return order.ID
+}
+
+// auctionLevels returns the price levels of one side of the book, best
+// first, including hidden orders and iceberg reserves, so the uncross
+// leaves no executable liquidity behind
+func (ob *OrderBook) auctionLevels(lit, hidden *BookSide) []types.AuctionLevel {
+	var levels []types.AuctionLevel
+	add := func(order *Order) bool {
+		quantity := order.RemainingQuantity().Add(ob.reserve(order))
+		levels = append(levels, types.AuctionLevel{Price: order.Price, Quantity: quantity})
+		return true
+	}
+	lit.Each(add)
+	hidden.Each(add)
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		return lit.better(levels[i].Price, levels[j].Price)
+	})
+
+	merged := levels[:0]
+	for _, level := range levels {
+		n := len(merged)
+		if n > 0 && merged[n-1].Price.Equal(level.Price) {
+			merged[n-1].Quantity = merged[n-1].Quantity.Add(level.Quantity)
+			continue
+		}
+		merged = append(merged, level)
+	}
+	return merged
+}
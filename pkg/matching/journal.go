@@ -0,0 +1,290 @@
+package matching
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// Journal receives journal entries in sequence order from a single goroutine
+type Journal interface {
+	Append(entry *JournalEntry) error
+}
+
+// CompactingJournal is a journal that can drop the entries a snapshot
+// covers. Compact may be called concurrently with Append.
+type CompactingJournal interface {
+	Journal
+	Compact(sequence uint64) error
+}
+
+// JSONJournal writes journal entries as JSON lines. Entries of the same
+// commands encode to the same bytes, so two journals can be compared
+// directly.
+type JSONJournal struct {
+	w io.Writer
+}
+
+// NewJSONJournal creates a journal that writes to w
+func NewJSONJournal(w io.Writer) *JSONJournal {
+	return &JSONJournal{w: w}
+}
+
+// Append writes an entry as one line
+func (j *JSONJournal) Append(entry *JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(append(data, '\n'))
+	return err
+}
+
+// journalHeaderSize is the size of a record header: the payload length and
+// its CRC-32C, both little endian
+const journalHeaderSize = 8
+
+// maxJournalRecord bounds the payload length read from a record header, so
+// a corrupt length cannot allocate unbounded memory
+const maxJournalRecord = 64 << 20
+
+var journalTable = crc32.MakeTable(crc32.Castagnoli)
+
+// FileJournal is an append-only journal file. Each record is a header
+// holding the payload length and CRC-32C followed by the JSON entry, so a
+// torn or corrupt record is detected on recovery instead of replayed.
+type FileJournal struct {
+	path string
+	file *os.File
+	w    *bufio.Writer
+	// sync fsyncs every record before Append returns
+	sync   bool
+	header [journalHeaderSize]byte
+	mu     sync.Mutex
+}
+
+// OpenFileJournal opens or creates a journal file for appending. A torn
+// record left at the end by a crash is truncated away.
+func OpenFileJournal(path string, sync bool) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := scanJournal(bufio.NewReader(file), nil)
+	if err == nil {
+		err = file.Truncate(valid)
+	}
+	if err == nil {
+		_, err = file.Seek(valid, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileJournal{
+		path: path,
+		file: file,
+		w:    bufio.NewWriterSize(file, 64*1024),
+		sync: sync,
+	}, nil
+}
+
+// Append writes an entry as one checksummed record
+func (j *FileJournal) Append(entry *JournalEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := writeRecord(j.w, &j.header, payload); err != nil {
+		return err
+	}
+	if err := j.w.Flush(); err != nil {
+		return err
+	}
+	if j.sync {
+		return j.file.Sync()
+	}
+	return nil
+}
+
+// Compact drops the records of the commands up to and including sequence.
+// The remaining records are copied to a new file that atomically replaces
+// the journal, so a crash during compaction leaves the old journal intact.
+func (j *FileJournal) Compact(sequence uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.w.Flush(); err != nil {
+		return err
+	}
+
+	in, err := os.Open(j.path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := j.path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(out, 64*1024)
+	var header [journalHeaderSize]byte
+	_, err = scanRecords(bufio.NewReader(in), func(payload []byte) (bool, error) {
+		var entry struct {
+			Command struct {
+				Sequence uint64 `json:"sequence"`
+			} `json:"command"`
+		}
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			return false, nil
+		}
+		if entry.Command.Sequence <= sequence {
+			return true, nil
+		}
+		return true, writeRecord(w, &header, payload)
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, j.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	// Append to the compacted file from now on
+	file, err := os.OpenFile(j.path, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return err
+	}
+	j.file.Close()
+	j.file = file
+	j.w.Reset(file)
+	return nil
+}
+
+// Close flushes, syncs and closes the journal file
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.w.Flush(); err != nil {
+		j.file.Close()
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		j.file.Close()
+		return err
+	}
+	return j.file.Close()
+}
+
+// ReadJournal calls fn for every intact entry of a journal file in order.
+// Reading stops at the first torn or corrupt record. A missing file reads
+// as an empty journal.
+func ReadJournal(path string, fn func(*JournalEntry) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = scanJournal(bufio.NewReader(file), fn)
+	return err
+}
+
+// writeRecord writes a payload behind its header
+func writeRecord(w io.Writer, header *[journalHeaderSize]byte, payload []byte) error {
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.Checksum(payload, journalTable))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// scanJournal reads records until the end of the journal or the first
+// invalid record and returns the length of the intact prefix. fn may be nil.
+func scanJournal(r io.Reader, fn func(*JournalEntry) error) (int64, error) {
+	return scanRecords(r, func(payload []byte) (bool, error) {
+		if fn == nil {
+			return true, nil
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			return false, nil
+		}
+		return true, fn(&entry)
+	})
+}
+
+// scanRecords passes the payload of every intact record to fn until the
+// end of the journal, the first invalid record or fn declining the record,
+// and returns the length of the records fn accepted
+func scanRecords(r io.Reader, fn func(payload []byte) (bool, error)) (int64, error) {
+	var valid int64
+	var header [journalHeaderSize]byte
+
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return valid, nil
+			}
+			return valid, err
+		}
+
+		length := binary.LittleEndian.Uint32(header[0:4])
+		if length > maxJournalRecord {
+			return valid, nil
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return valid, nil
+			}
+			return valid, err
+		}
+		if crc32.Checksum(payload, journalTable) != binary.LittleEndian.Uint32(header[4:8]) {
+			return valid, nil
+		}
+
+		ok, err := fn(payload)
+		if err != nil {
+			return valid, err
+		}
+		if !ok {
+			return valid, nil
+		}
+		valid += journalHeaderSize + int64(length)
+	}
+}
@@ -2,6 +2,9 @@ package matching
 
 import (
 	"context"
+	"path/filepath"
+
+	"github.com/abdoElHodaky/tradSys/internal/config"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -11,6 +14,13 @@ var OrderMatchingModule = fx.Options(
 	fx.Provide(NewEngine),
 )
 
+// SequencerModule provides the journaled sequencer as the engine behind
+// the order service
+var SequencerModule = fx.Options(
+	fx.Provide(NewFxSequencer),
+	fx.Provide(func(sequencer *Sequencer) OrderEngine { return sequencer }),
+)
+
 // NewFxEngine creates a new order matching engine for the fx application
 func NewFxEngine(
 	lifecycle fx.Lifecycle,
@@ -31,3 +41,43 @@ func NewFxEngine(
 
 	return engine
 }
+
+// SequencerParams contains the parameters for creating a sequencer
+type SequencerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *config.Config
+}
+
+// NewFxSequencer creates the sequencer in front of matching for the fx
+// application. On start it rebuilds the order books from the snapshot and
+// journal in the configured data directory, then snapshots them and
+// compacts the journal every snapshot interval until stopped.
+func NewFxSequencer(p SequencerParams) (*Sequencer, error) {
+	cfg := p.Config.Trading.Sequencer
+	sequencer, err := newFileSequencer(p.Logger, cfg.Partitions, cfg.DataDir, cfg.SyncJournal)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if err := sequencer.open(cfg.DataDir); err != nil {
+				return err
+			}
+			if cfg.SnapshotInterval > 0 {
+				go sequencer.RunSnapshots(ctx, cfg.SnapshotInterval, filepath.Join(cfg.DataDir, SnapshotFile))
+			}
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return sequencer.Stop()
+		},
+	})
+
+	return sequencer, nil
+}
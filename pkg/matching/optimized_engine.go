@@ -6,6 +6,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"go.uber.org/zap"
 )
 
@@ -42,7 +43,7 @@ type LockFreeOrderBook struct {
 	// Atomic counters
 	bidCount  int64
 	askCount  int64
-	lastPrice int64 // Price in fixed-point (multiply by 1e8)
+	lastPrice int64 // Price in fixed-point (scaled by 1e8)
 
 	// Memory pool for order nodes
 	nodePool sync.Pool
@@ -55,6 +56,9 @@ type OrderNode struct {
 	price int64          // Fixed-point price for atomic operations
 }
 
+// lockFreePriceScale is the fixed-point scale used for atomic price comparisons
+const lockFreePriceScale = 8
+
 // NewOptimizedEngine creates a new high-performance matching engine
 func NewOptimizedEngine(logger *zap.Logger) *OptimizedEngine {
 	engine := &OptimizedEngine{
@@ -87,6 +91,13 @@ func (e *OptimizedEngine) ProcessOrderFast(order *Order) ([]*Trade, error) {
 	bookInterface, _ := e.orderBooks.LoadOrStore(order.Symbol, e.createLockFreeOrderBook(order.Symbol))
 	book := bookInterface.(*LockFreeOrderBook)
 
+	// Reject orders that break the instrument rules
+	lastPrice := types.NewDecimal(atomic.LoadInt64(&book.lastPrice), lockFreePriceScale)
+	if err := types.Instruments.Validate(order, lastPrice); err != nil {
+		order.Status = OrderStatusRejected
+		return nil, err
+	}
+
 	var trades []*Trade
 
 	// Fast path for market orders (most common case)
@@ -155,7 +166,7 @@ func (e *OptimizedEngine) processLimitOrderFast(book *LockFreeOrderBook, order *
 		trades = e.matchAgainstSide(book, order, &book.askHead, false)
 
 		// If not fully filled, add to bid side
-		if order.Quantity > order.FilledQuantity {
+		if order.RemainingQuantity().IsPositive() {
 			e.addOrderToSide(book, order, &book.bidHead, &book.bidCount)
 		}
 	} else {
@@ -163,7 +174,7 @@ func (e *OptimizedEngine) processLimitOrderFast(book *LockFreeOrderBook, order *
 		trades = e.matchAgainstSide(book, order, &book.bidHead, true)
 
 		// If not fully filled, add to ask side
-		if order.Quantity > order.FilledQuantity {
+		if order.RemainingQuantity().IsPositive() {
 			e.addOrderToSide(book, order, &book.askHead, &book.askCount)
 		}
 	}
@@ -174,9 +185,9 @@ func (e *OptimizedEngine) processLimitOrderFast(book *LockFreeOrderBook, order *
 // matchAgainstSide matches an order against one side of the book using lock-free operations
 func (e *OptimizedEngine) matchAgainstSide(book *LockFreeOrderBook, incomingOrder *Order, headPtr *unsafe.Pointer, isBidSide bool) []*Trade {
 	var trades []*Trade
-	incomingPriceFixed := int64(incomingOrder.Price * 1e8) // Convert to fixed-point
+	incomingPriceFixed := toFixedPoint(incomingOrder.Price)
 
-	for incomingOrder.Quantity > incomingOrder.FilledQuantity {
+	for incomingOrder.RemainingQuantity().IsPositive() {
 		// Atomically load the head of the order list
 		head := (*OrderNode)(atomic.LoadPointer(headPtr))
 		if head == nil {
@@ -209,7 +220,7 @@ func (e *OptimizedEngine) matchAgainstSide(book *LockFreeOrderBook, incomingOrde
 			trades = append(trades, trade)
 
 			// Update last price atomically
-			atomic.StoreInt64(&book.lastPrice, int64(trade.Price*1e8))
+			atomic.StoreInt64(&book.lastPrice, toFixedPoint(trade.Price))
 		}
 
 		// Return the order node to the pool
@@ -245,27 +256,23 @@ func (e *OptimizedEngine) addOrderToSide(book *LockFreeOrderBook, order *Order,
 // executeTrade executes a trade between two orders with minimal allocations
 func (e *OptimizedEngine) executeTrade(incomingOrder, bookOrder *Order) *Trade {
 	// Calculate trade quantity (minimum of remaining quantities)
-	tradeQuantity := incomingOrder.Quantity - incomingOrder.FilledQuantity
-	bookRemaining := bookOrder.Quantity - bookOrder.FilledQuantity
-	if bookRemaining < tradeQuantity {
-		tradeQuantity = bookRemaining
-	}
+	tradeQuantity := types.MinDecimal(incomingOrder.RemainingQuantity(), bookOrder.RemainingQuantity())
 
 	// Use book order price (price-time priority)
 	tradePrice := bookOrder.Price
 
 	// Update order fill quantities
-	incomingOrder.FilledQuantity += tradeQuantity
-	bookOrder.FilledQuantity += tradeQuantity
+	incomingOrder.FilledQuantity = incomingOrder.FilledQuantity.Add(tradeQuantity)
+	bookOrder.FilledQuantity = bookOrder.FilledQuantity.Add(tradeQuantity)
 
 	// Update order statuses
-	if incomingOrder.FilledQuantity >= incomingOrder.Quantity {
+	if incomingOrder.IsFilled() {
 		incomingOrder.Status = OrderStatusFilled
 	} else {
 		incomingOrder.Status = OrderStatusPartiallyFilled
 	}
 
-	if bookOrder.FilledQuantity >= bookOrder.Quantity {
+	if bookOrder.IsFilled() {
 		bookOrder.Status = OrderStatusFilled
 	} else {
 		bookOrder.Status = OrderStatusPartiallyFilled
@@ -313,7 +320,7 @@ func (e *OptimizedEngine) createLockFreeOrderBook(symbol string) *LockFreeOrderB
 func (e *OptimizedEngine) getOrderNode(book *LockFreeOrderBook, order *Order) *OrderNode {
 	node := book.nodePool.Get().(*OrderNode)
 	node.order = order
-	node.price = int64(order.Price * 1e8) // Convert to fixed-point
+	node.price = toFixedPoint(order.Price)
 	atomic.StorePointer(&node.next, nil)
 	return node
 }
@@ -341,6 +348,11 @@ func (e *OptimizedEngine) GetPerformanceMetrics() map[string]interface{} {
 	}
 }
 
+// toFixedPoint converts a decimal price to the book's 1e8 fixed-point representation
+func toFixedPoint(price Decimal) int64 {
+	return price.Rescale(lockFreePriceScale).Unscaled()
+}
+
 // generateTradeID generates a unique trade ID (simplified)
 func generateTradeID() string {
 	return time.Now().Format("20060102150405.000000")
@@ -0,0 +1,45 @@
+package matching
+
+import (
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+)
+
+// matchProRata allocates the taker across every order of the best opposite
+// level by the symbol's allocation rule, trading each maker's share in time
+// priority. Expired makers are dropped first; makers that would self-trade
+// are left out of the allocation and handled by price-time matching once
+// they reach the front of the level.
+func (ob *OrderBook) matchProRata(rule types.AllocationRule, mode types.SelfTradePrevention, order *Order, opposite *BookSide, remainingQuantity *Decimal) []*Trade {
+	var makers []*Order
+	var resting []Decimal
+	for _, maker := range opposite.Front() {
+		if maker.IsExpiredAt(ob.now()) {
+			opposite.Remove(maker.ID)
+			ob.expireOrder(maker)
+			continue
+		}
+		if mode != types.SelfTradePreventionNone && types.IsSelfTrade(order, maker) {
+			continue
+		}
+		makers = append(makers, maker)
+		resting = append(resting, maker.RemainingQuantity())
+	}
+
+	var trades []*Trade
+	for i, share := range rule.Allocate(*remainingQuantity, resting) {
+		if !share.IsPositive() {
+			continue
+		}
+
+		maker := makers[i]
+		trade := ob.executeTrade(order, maker, &share)
+		if trade == nil {
+			continue
+		}
+		trades = append(trades, trade)
+		*remainingQuantity = remainingQuantity.Sub(trade.Quantity)
+		ob.settleMaker(opposite, maker, trade.Quantity)
+	}
+
+	return trades
+}
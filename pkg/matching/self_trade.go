@@ -0,0 +1,73 @@
+package matching
+
+import (
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// preventSelfTrade applies a self-trade prevention mode instead of trading
+// the taker against a maker from the same user or account group. The maker
+// must be the first order of the opposite side, displayed or hidden. It returns true if the taker was
+// cancelled and must stop matching.
+func (ob *OrderBook) preventSelfTrade(mode types.SelfTradePrevention, taker, maker *Order, opposite *BookSide, remainingQuantity *Decimal) bool {
+	takerCancelled := false
+	quantity := types.MinDecimal(*remainingQuantity, maker.RemainingQuantity())
+
+	switch mode {
+	case types.SelfTradePreventionCancelOldest:
+		opposite.Pop()
+		ob.cancelSelfTrade(maker)
+	case types.SelfTradePreventionCancelBoth:
+		opposite.Pop()
+		ob.cancelSelfTrade(maker)
+		ob.cancelSelfTrade(taker)
+		takerCancelled = true
+	case types.SelfTradePreventionDecrementAndCancel:
+		opposite.Reduce(maker, quantity)
+		taker.Quantity = taker.Quantity.Sub(quantity)
+		maker.Quantity = maker.Quantity.Sub(quantity)
+		*remainingQuantity = remainingQuantity.Sub(quantity)
+		if maker.IsIcebergChild {
+			ob.decrementSlice(opposite, maker, quantity)
+		} else if !maker.RemainingQuantity().IsPositive() {
+			opposite.Pop()
+			ob.cancelSelfTrade(maker)
+		}
+		if !remainingQuantity.IsPositive() {
+			ob.cancelSelfTrade(taker)
+			takerCancelled = true
+		}
+	default:
+		ob.cancelSelfTrade(taker)
+		takerCancelled = true
+	}
+
+	ob.logger.Debug("Self-trade prevented",
+		zap.String("mode", string(mode)),
+		zap.String("taker_order_id", taker.ID),
+		zap.String("maker_order_id", maker.ID),
+		zap.Stringer("quantity", quantity))
+
+	if ob.eventHandler != nil {
+		ob.eventHandler(&MatchingEvent{
+			Type:        EventSelfTradePrevented,
+			Symbol:      ob.Symbol,
+			Order:       taker,
+			ContraOrder: maker,
+			Quantity:    quantity,
+			Timestamp:   ob.now(),
+		})
+	}
+
+	return takerCancelled
+}
+
+// cancelSelfTrade cancels an order removed by self-trade prevention. An
+// iceberg slice cancels the whole iceberg order.
+func (ob *OrderBook) cancelSelfTrade(order *Order) {
+	if order.IsIcebergChild {
+		order = ob.dropIceberg(order, OrderStatusCancelled)
+	}
+	delete(ob.Orders, order.ID)
+	order.Status = OrderStatusCancelled
+}
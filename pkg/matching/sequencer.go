@@ -0,0 +1,712 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Sequencer errors
+var (
+	ErrSequencerNotRunning = errors.New("sequencer is not running")
+	ErrSequencerRunning    = errors.New("sequencer is already running")
+	ErrUnknownCommand      = errors.New("unknown command type")
+	ErrOutOfSequence       = errors.New("command sequence is not after the last sequence")
+	ErrJournalFailed       = errors.New("journal append failed")
+)
+
+// CommandType is the type of a sequenced command
+type CommandType string
+
+const (
+	// CommandNewOrder adds an order
+	CommandNewOrder CommandType = "NEW_ORDER"
+	// CommandCancelOrder cancels a resting order
+	CommandCancelOrder CommandType = "CANCEL_ORDER"
+	// CommandAmendOrder amends the price and quantity of a resting order
+	CommandAmendOrder CommandType = "AMEND_ORDER"
+	// CommandSetPhase moves a symbol to a trading phase. The HALTED phase
+	// halts the symbol, or every symbol when Symbol is empty, until a
+	// SET_PHASE with Resume lifts the halt.
+	CommandSetPhase CommandType = "SET_PHASE"
+	// CommandExpireOrders expires the DAY and GTD orders of a symbol at the
+	// command time
+	CommandExpireOrders CommandType = "EXPIRE_ORDERS"
+)
+
+// Command is an inbound request to the matching engine. The sequencer
+// fills in Sequence and Timestamp.
+type Command struct {
+	Sequence  uint64             `json:"sequence"`
+	Timestamp time.Time          `json:"timestamp"`
+	Type      CommandType        `json:"type"`
+	Symbol    string             `json:"symbol"`
+	Order     *Order             `json:"order,omitempty"`
+	OrderID   string             `json:"order_id,omitempty"`
+	Price     Decimal            `json:"price"`
+	Quantity  Decimal            `json:"quantity"`
+	Phase     types.TradingPhase `json:"phase,omitempty"`
+	// Reason is why a SET_PHASE command halts trading
+	Reason string `json:"reason,omitempty"`
+	// Resume lifts a halt instead of setting a phase
+	Resume bool `json:"resume,omitempty"`
+}
+
+// marketWide reports whether the command halts or resumes every symbol
+func (c *Command) marketWide() bool {
+	return c.Type == CommandSetPhase && c.Symbol == "" &&
+		(c.Resume || c.Phase == types.TradingPhaseHalted)
+}
+
+// JournalEntry is the sequenced output of one command
+type JournalEntry struct {
+	// Command is the command as it was sequenced, before it executed
+	Command Command `json:"command"`
+	// Order is the state of a new order after matching
+	Order *Order `json:"order,omitempty"`
+	// Trades are the trades the command produced
+	Trades []*Trade `json:"trades,omitempty"`
+	// Expired lists the orders the command expired
+	Expired []string `json:"expired,omitempty"`
+	// Error is why the command was rejected
+	Error string `json:"error,omitempty"`
+}
+
+// Sequencer is the single writer in front of the matching engine. It
+// stamps every inbound command with a monotonic sequence number and time
+// and routes it to the partition that owns its symbol. Each partition runs
+// its own MatchingEngine on one goroutine, and that engine takes its clock
+// and trade IDs from the command being executed, so the same commands
+// always produce the same trades and the same journal. Unlike HFTEngine,
+// whose callers race on shared books, the output can be replayed and
+// compared bit for bit.
+type Sequencer struct {
+	// TradeChannel receives the trades of every partition
+	TradeChannel chan *Trade
+	// EventChannel receives the order and trading events of every partition
+	EventChannel chan *MatchingEvent
+
+	partitions []*sequencerPartition
+	journal    Journal
+	// closer closes a journal the sequencer opened itself
+	closer io.Closer
+	// outputs queues commands in sequence order for the journal writer
+	outputs chan *sequencedCommand
+	written chan struct{}
+	// inflight counts the commands sequenced but not yet journaled
+	inflight sync.WaitGroup
+	// failMu guards the failures of the partitions; the journal writer
+	// cannot take mu, which dispatch holds while queueing to it
+	failMu sync.Mutex
+
+	sequence uint64
+	last     time.Time
+	clock    func() time.Time
+	started  bool
+	stopped  bool
+
+	logger *zap.Logger
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// sequencerPartition owns the books of the symbols hashed to it
+type sequencerPartition struct {
+	engine   *MatchingEngine
+	commands chan *sequencedCommand
+	// now, sequence and trades identify the command being executed
+	now      time.Time
+	sequence uint64
+	trades   int
+	// failed is why the partition stopped taking commands
+	failed error
+}
+
+// sequencedCommand carries a command to its partition and its output back
+type sequencedCommand struct {
+	command   *Command
+	entry     *JournalEntry
+	err       error
+	done      chan struct{}
+	journaled chan struct{}
+}
+
+// NewSequencer creates a sequencer with partitions single-threaded engines.
+// journal may be nil.
+func NewSequencer(logger *zap.Logger, partitions int, journal Journal) *Sequencer {
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	s := &Sequencer{
+		TradeChannel: make(chan *Trade, 1000),
+		EventChannel: make(chan *MatchingEvent, 1000),
+		journal:      journal,
+		outputs:      make(chan *sequencedCommand, 1000),
+		written:      make(chan struct{}),
+		clock:        time.Now,
+		logger:       logger,
+	}
+
+	for i := 0; i < partitions; i++ {
+		p := &sequencerPartition{
+			engine:   NewMatchingEngine(logger),
+			commands: make(chan *sequencedCommand, 1000),
+		}
+		p.engine.TradeChannel = s.TradeChannel
+		p.engine.EventChannel = s.EventChannel
+		p.engine.clock = func() time.Time { return p.now }
+		p.engine.nextTradeID = func() string {
+			p.trades++
+			return fmt.Sprintf("%d-%d", p.sequence, p.trades)
+		}
+		s.partitions = append(s.partitions, p)
+	}
+
+	return s
+}
+
+// Start starts the partitions and the journal writer
+func (s *Sequencer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	s.logger.Info("Starting sequencer",
+		zap.Int("partitions", len(s.partitions)),
+		zap.Uint64("sequence", s.sequence))
+
+	for _, p := range s.partitions {
+		s.wg.Add(1)
+		go s.run(p)
+	}
+	go s.writeJournal()
+
+	return nil
+}
+
+// Stop executes the commands already sequenced, flushes the journal and
+// stops the partitions
+func (s *Sequencer) Stop() error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	for _, p := range s.partitions {
+		close(p.commands)
+	}
+	close(s.outputs)
+	started := s.started
+	s.mu.Unlock()
+
+	if started {
+		s.wg.Wait()
+		<-s.written
+	}
+	if s.closer != nil {
+		if err := s.closer.Close(); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Stopped sequencer", zap.Uint64("sequence", s.sequence))
+	return nil
+}
+
+// Submit stamps a command with the next sequence number and the current
+// time, executes it and returns its journal entry once it is journaled.
+// Commands on the same symbol execute in the order they are submitted.
+func (s *Sequencer) Submit(command *Command) (*JournalEntry, error) {
+	s.mu.Lock()
+	now := s.clock().UTC()
+	if now.Before(s.last) {
+		now = s.last
+	}
+	command.Sequence = s.sequence + 1
+	command.Timestamp = now
+	return s.dispatch(command)
+}
+
+// Replay executes a command that was sequenced earlier, keeping its
+// sequence number and time. Commands must be replayed in sequence order.
+func (s *Sequencer) Replay(command *Command) (*JournalEntry, error) {
+	s.mu.Lock()
+	if command.Sequence <= s.sequence {
+		s.mu.Unlock()
+		return nil, ErrOutOfSequence
+	}
+	return s.dispatch(command)
+}
+
+// dispatch queues a stamped command to its partition and the journal
+// writer and waits until it is journaled. The caller must hold the lock,
+// which dispatch releases.
+func (s *Sequencer) dispatch(command *Command) (*JournalEntry, error) {
+	if s.stopped || !s.started {
+		s.mu.Unlock()
+		return nil, ErrSequencerNotRunning
+	}
+	if err := s.failure(s.partitionsOf(command)...); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	s.sequence = command.Sequence
+	if command.Timestamp.After(s.last) {
+		s.last = command.Timestamp
+	}
+
+	item := &sequencedCommand{
+		command:   command,
+		done:      make(chan struct{}),
+		journaled: make(chan struct{}),
+	}
+	if command.marketWide() {
+		// Every partition is idle once the commands before it are journaled
+		s.inflight.Wait()
+		item.entry, item.err = s.executeMarket(command)
+		close(item.done)
+	} else {
+		s.partition(command.Symbol).commands <- item
+	}
+	s.inflight.Add(1)
+	s.outputs <- item
+	s.mu.Unlock()
+
+	<-item.journaled
+	return item.entry, item.err
+}
+
+// AddOrder sequences a new order and returns its trades
+func (s *Sequencer) AddOrder(order *Order) ([]*Trade, error) {
+	entry, err := s.Submit(&Command{Type: CommandNewOrder, Symbol: order.Symbol, Order: order})
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Trades, err
+}
+
+// CancelOrder sequences a cancel and reports whether the order was cancelled
+func (s *Sequencer) CancelOrder(symbol, orderID string) bool {
+	_, err := s.Submit(&Command{Type: CommandCancelOrder, Symbol: symbol, OrderID: orderID})
+	return err == nil
+}
+
+// AmendOrder sequences an amend and returns its trades
+func (s *Sequencer) AmendOrder(symbol, orderID string, price, quantity Decimal) ([]*Trade, error) {
+	entry, err := s.Submit(&Command{
+		Type:     CommandAmendOrder,
+		Symbol:   symbol,
+		OrderID:  orderID,
+		Price:    price,
+		Quantity: quantity,
+	})
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Trades, err
+}
+
+// Halt sequences a symbol halt; see OrderBook.Halt
+func (s *Sequencer) Halt(symbol, reason string) error {
+	_, err := s.Submit(&Command{
+		Type:   CommandSetPhase,
+		Symbol: symbol,
+		Phase:  types.TradingPhaseHalted,
+		Reason: reason,
+	})
+	return err
+}
+
+// Resume sequences the lifting of a symbol halt and returns the trades of
+// the re-opening auction
+func (s *Sequencer) Resume(symbol string) ([]*Trade, error) {
+	entry, err := s.Submit(&Command{Type: CommandSetPhase, Symbol: symbol, Resume: true})
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Trades, err
+}
+
+// HaltMarket sequences a halt of every symbol, including books created
+// during the halt
+func (s *Sequencer) HaltMarket(reason string) error {
+	_, err := s.Submit(&Command{Type: CommandSetPhase, Phase: types.TradingPhaseHalted, Reason: reason})
+	return err
+}
+
+// ResumeMarket sequences the lifting of a market-wide halt and returns the
+// trades of the re-opening auctions
+func (s *Sequencer) ResumeMarket() ([]*Trade, error) {
+	entry, err := s.Submit(&Command{Type: CommandSetPhase, Resume: true})
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Trades, err
+}
+
+// UpdatePhases sequences a SET_PHASE for every book whose exchange schedule
+// gives another phase at now and returns the auction trades
+func (s *Sequencer) UpdatePhases(now time.Time) []*Trade {
+	var trades []*Trade
+	for _, orderBook := range s.books() {
+		phase := types.PhaseFor(orderBook.Symbol, now)
+		if orderBook.scheduledPhase() == phase {
+			continue
+		}
+
+		entry, err := s.Submit(&Command{Type: CommandSetPhase, Symbol: orderBook.Symbol, Phase: phase})
+		if err != nil {
+			s.logger.Error("Failed to sequence phase change",
+				zap.String("symbol", orderBook.Symbol),
+				zap.String("phase", string(phase)),
+				zap.Error(err))
+		}
+		if entry != nil {
+			trades = append(trades, entry.Trades...)
+		}
+	}
+	return trades
+}
+
+// RunPhases follows the exchange schedules every interval until the
+// context is done
+func (s *Sequencer) RunPhases(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if trades := s.UpdatePhases(now); len(trades) > 0 {
+				s.logger.Info("Auction trades executed",
+					zap.Int("count", len(trades)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ExpireOrders sequences an EXPIRE_ORDERS for every book holding orders
+// whose expire time has passed at now and returns the IDs of the orders
+// expired
+func (s *Sequencer) ExpireOrders(now time.Time) []string {
+	var expired []string
+	for _, orderBook := range s.books() {
+		if !orderBook.hasExpiredOrders(now) {
+			continue
+		}
+
+		entry, err := s.Submit(&Command{Type: CommandExpireOrders, Symbol: orderBook.Symbol})
+		if err != nil {
+			s.logger.Error("Failed to sequence order expiry",
+				zap.String("symbol", orderBook.Symbol),
+				zap.Error(err))
+		}
+		if entry != nil {
+			expired = append(expired, entry.Expired...)
+		}
+	}
+	return expired
+}
+
+// RunExpiry expires DAY and GTD orders every interval until the context is
+// done
+func (s *Sequencer) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if expired := s.ExpireOrders(now); len(expired) > 0 {
+				s.logger.Info("Expired orders",
+					zap.Int("count", len(expired)))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetOrder returns a copy of an order held in the order book for a symbol
+func (s *Sequencer) GetOrder(symbol, orderID string) (*Order, bool) {
+	return s.partition(symbol).engine.GetOrder(symbol, orderID)
+}
+
+// GetOrderBook returns the order book for a symbol
+func (s *Sequencer) GetOrderBook(symbol string) *OrderBook {
+	return s.partition(symbol).engine.GetOrderBook(symbol)
+}
+
+// IsHalted returns true if trading in a symbol is halted
+func (s *Sequencer) IsHalted(symbol string) bool {
+	return s.partition(symbol).engine.IsHalted(symbol)
+}
+
+// Events returns the channel of order and trading events
+func (s *Sequencer) Events() <-chan *MatchingEvent {
+	return s.EventChannel
+}
+
+// Sequence returns the last sequence number
+func (s *Sequencer) Sequence() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sequence
+}
+
+// partition returns the partition that owns a symbol
+func (s *Sequencer) partition(symbol string) *sequencerPartition {
+	h := fnv.New32a()
+	h.Write([]byte(symbol))
+	return s.partitions[h.Sum32()%uint32(len(s.partitions))]
+}
+
+// books returns the order books of every partition in symbol order
+func (s *Sequencer) books() []*OrderBook {
+	var books []*OrderBook
+	for _, p := range s.partitions {
+		p.engine.mu.RLock()
+		for _, orderBook := range p.engine.OrderBooks {
+			books = append(books, orderBook)
+		}
+		p.engine.mu.RUnlock()
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].Symbol < books[j].Symbol
+	})
+	return books
+}
+
+// execute runs a command that was sequenced earlier on the partitions it
+// concerns. The caller must hold the lock with no command in flight.
+func (s *Sequencer) execute(command *Command) (*JournalEntry, error) {
+	if command.marketWide() {
+		return s.executeMarket(command)
+	}
+	return s.partition(command.Symbol).execute(command)
+}
+
+// executeMarket halts or resumes every partition at the command time. The
+// books are visited in symbol order and their trades numbered in one
+// series, so the journal does not depend on the partitioning. The caller
+// must hold the lock with no command in flight.
+func (s *Sequencer) executeMarket(command *Command) (*JournalEntry, error) {
+	halted := !command.Resume
+	reason := ""
+	if halted {
+		reason = command.Reason
+	}
+
+	owners := make(map[*OrderBook]*sequencerPartition)
+	for _, p := range s.partitions {
+		p.now = command.Timestamp
+		p.sequence = command.Sequence
+		for _, orderBook := range p.engine.markMarketHalt(halted, reason) {
+			owners[orderBook] = p
+		}
+	}
+
+	entry := &JournalEntry{Command: *command}
+	trades := 0
+	for _, orderBook := range s.books() {
+		p := owners[orderBook]
+		p.trades = trades
+		entry.Trades = append(entry.Trades, orderBook.setMarketHalt(halted, reason)...)
+		trades = p.trades
+	}
+
+	// Send trades to channel
+	for _, trade := range entry.Trades {
+		select {
+		case s.TradeChannel <- trade:
+		default:
+			s.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+
+	if halted {
+		s.logger.Warn("Market-wide trading halt",
+			zap.Uint64("sequence", command.Sequence),
+			zap.String("reason", reason))
+	} else {
+		s.logger.Info("Market-wide trading resumed",
+			zap.Uint64("sequence", command.Sequence),
+			zap.Int("auction_trades", len(entry.Trades)))
+	}
+
+	return entry, nil
+}
+
+// run executes a partition's commands one at a time
+func (s *Sequencer) run(p *sequencerPartition) {
+	defer s.wg.Done()
+
+	for item := range p.commands {
+		item.entry, item.err = p.execute(item.command)
+		close(item.done)
+	}
+}
+
+// writeJournal appends the entries in sequence order, waiting for each
+// command to execute. A partition whose entry cannot be appended has run a
+// command the journal does not hold, so it is stopped: that command and
+// every later one on the partition fail, and the partition's books are
+// only trusted again once a restart rebuilds them from the journal.
+func (s *Sequencer) writeJournal() {
+	defer close(s.written)
+
+	for item := range s.outputs {
+		<-item.done
+		if err := s.failure(s.partitionsOf(item.command)...); err != nil {
+			item.err = err
+		} else if s.journal != nil {
+			if err := s.journal.Append(item.entry); err != nil {
+				item.err = fmt.Errorf("%w: %v", ErrJournalFailed, err)
+				s.fail(item.command, item.err)
+			}
+		}
+		close(item.journaled)
+		s.inflight.Done()
+	}
+}
+
+// failure returns why one of the partitions was stopped
+func (s *Sequencer) failure(partitions ...*sequencerPartition) error {
+	s.failMu.Lock()
+	defer s.failMu.Unlock()
+
+	for _, p := range partitions {
+		if p.failed != nil {
+			return p.failed
+		}
+	}
+	return nil
+}
+
+// fail stops the partitions a command ran on
+func (s *Sequencer) fail(command *Command, err error) {
+	s.failMu.Lock()
+	defer s.failMu.Unlock()
+
+	for _, p := range s.partitionsOf(command) {
+		if p.failed == nil {
+			p.failed = err
+		}
+	}
+
+	s.logger.Error("Failed to append journal entry, stopping partition",
+		zap.Uint64("sequence", command.Sequence),
+		zap.String("symbol", command.Symbol),
+		zap.Error(err))
+}
+
+// partitionsOf returns the partitions a command runs on
+func (s *Sequencer) partitionsOf(command *Command) []*sequencerPartition {
+	if command.marketWide() {
+		return s.partitions
+	}
+	return []*sequencerPartition{s.partition(command.Symbol)}
+}
+
+// execute runs a command on the partition's engine at the command time
+func (p *sequencerPartition) execute(command *Command) (*JournalEntry, error) {
+	p.now = command.Timestamp
+	p.sequence = command.Sequence
+	p.trades = 0
+
+	entry := &JournalEntry{Command: *command}
+	if command.Order != nil {
+		input := *command.Order
+		entry.Command.Order = &input
+	}
+
+	var err error
+	switch command.Type {
+	case CommandNewOrder:
+		if command.Order == nil {
+			err = ErrInvalidOrder
+			break
+		}
+		entry.Trades, err = p.engine.AddOrder(command.Order)
+		order := *command.Order
+		entry.Order = &order
+	case CommandCancelOrder:
+		if !p.engine.CancelOrder(command.Symbol, command.OrderID) {
+			err = ErrOrderNotFound
+		}
+	case CommandAmendOrder:
+		entry.Trades, err = p.engine.AmendOrder(command.Symbol, command.OrderID, command.Price, command.Quantity)
+	case CommandSetPhase:
+		switch {
+		case command.Resume:
+			entry.Trades, err = p.engine.Resume(command.Symbol)
+		case command.Phase == types.TradingPhaseHalted:
+			p.engine.Halt(command.Symbol, command.Reason)
+		default:
+			entry.Trades, err = p.engine.SetPhase(command.Symbol, command.Phase)
+		}
+	case CommandExpireOrders:
+		orderBook := p.engine.GetOrderBook(command.Symbol)
+		if orderBook == nil {
+			err = ErrOrderBookNotFound
+			break
+		}
+		for _, order := range orderBook.ExpireOrders(command.Timestamp) {
+			entry.Expired = append(entry.Expired, order.ID)
+		}
+	default:
+		err = ErrUnknownCommand
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry, err
+}
+
+// now returns the time of the command being executed when a Sequencer
+// drives the engine, otherwise the wall clock
+func (me *MatchingEngine) now() time.Time {
+	if me.clock != nil {
+		return me.clock()
+	}
+	return time.Now()
+}
+
+// now returns the time of the command being executed when a Sequencer
+// drives the book, otherwise the wall clock
+func (ob *OrderBook) now() time.Time {
+	if ob.clock != nil {
+		return ob.clock()
+	}
+	return time.Now()
+}
+
+// tradeID returns the ID of the next trade: the command sequence and the
+// trade's position in it when sequenced, otherwise a random UUID
+func (ob *OrderBook) tradeID() string {
+	if ob.nextTradeID != nil {
+		return ob.nextTradeID()
+	}
+	return uuid.New().String()
+}
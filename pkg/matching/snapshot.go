@@ -0,0 +1,341 @@
+package matching
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// File names of the journal and the snapshot in a sequencer's data directory
+const (
+	JournalFile  = "journal.log"
+	SnapshotFile = "snapshot.json"
+)
+
+// BookSnapshot is the state of one order book between two commands
+type BookSnapshot struct {
+	Symbol        string             `json:"symbol"`
+	Phase         types.TradingPhase `json:"phase"`
+	SchedulePhase types.TradingPhase `json:"schedule_phase"`
+	Halted        bool               `json:"halted"`
+	HaltReason    string             `json:"halt_reason,omitempty"`
+	LastPrice     Decimal            `json:"last_price"`
+	MarkPrice     Decimal            `json:"mark_price"`
+	// Orders are the resting orders in priority order, bids first, then the
+	// hidden orders. Iceberg orders appear as their displayed slice.
+	Orders []*Order `json:"orders"`
+	// Icebergs are the iceberg orders whose slices are in Orders
+	Icebergs []*IcebergOrder `json:"icebergs,omitempty"`
+	// Stops are the parked stop orders in trigger order, buys first
+	Stops []*Order `json:"stops"`
+}
+
+// Snapshot is the state of every order book after the command at Sequence
+type Snapshot struct {
+	Sequence  uint64          `json:"sequence"`
+	Timestamp time.Time       `json:"timestamp"`
+	Books     []*BookSnapshot `json:"books"`
+}
+
+// Snapshot captures every order book after the last sequenced command.
+// New commands wait while the snapshot is taken.
+func (s *Sequencer) Snapshot() *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inflight.Wait()
+
+	snapshot := &Snapshot{Sequence: s.sequence, Timestamp: s.last}
+	for _, p := range s.partitions {
+		p.engine.mu.RLock()
+		for _, orderBook := range p.engine.OrderBooks {
+			snapshot.Books = append(snapshot.Books, orderBook.snapshot())
+		}
+		p.engine.mu.RUnlock()
+	}
+	sort.Slice(snapshot.Books, func(i, j int) bool {
+		return snapshot.Books[i].Symbol < snapshot.Books[j].Symbol
+	})
+
+	return snapshot
+}
+
+// snapshot copies the book's resting orders and trading state
+func (ob *OrderBook) snapshot() *BookSnapshot {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	book := &BookSnapshot{
+		Symbol:        ob.Symbol,
+		Phase:         ob.Phase,
+		SchedulePhase: ob.schedulePhase,
+		Halted:        ob.halted,
+		HaltReason:    ob.haltReason,
+		LastPrice:     ob.LastPrice,
+		MarkPrice:     ob.MarkPrice,
+		Orders:        []*Order{},
+		Stops:         []*Order{},
+	}
+	copyOrders := func(orders *[]*Order) func(*Order) bool {
+		return func(order *Order) bool {
+			copied := *order
+			*orders = append(*orders, &copied)
+			return true
+		}
+	}
+	ob.Bids.Each(copyOrders(&book.Orders))
+	ob.Asks.Each(copyOrders(&book.Orders))
+	ob.HiddenBids.Each(copyOrders(&book.Orders))
+	ob.HiddenAsks.Each(copyOrders(&book.Orders))
+	ob.StopBids.Each(copyOrders(&book.Stops))
+	ob.StopAsks.Each(copyOrders(&book.Stops))
+
+	for _, order := range book.Orders {
+		if !order.IsIcebergChild {
+			continue
+		}
+		iceberg := *ob.icebergs[order.ParentOrderID]
+		parent := *iceberg.ParentOrder
+		iceberg.ParentOrder = &parent
+		iceberg.CurrentOrder = nil
+		book.Icebergs = append(book.Icebergs, &iceberg)
+	}
+
+	return book
+}
+
+// restore loads a book snapshot into the book, queueing the orders in the
+// order they were captured so time priority is kept
+func (ob *OrderBook) restore(book *BookSnapshot) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.Phase = book.Phase
+	ob.schedulePhase = book.SchedulePhase
+	ob.halted = book.Halted
+	ob.haltReason = book.HaltReason
+	ob.LastPrice = book.LastPrice
+	ob.MarkPrice = book.MarkPrice
+
+	for _, iceberg := range book.Icebergs {
+		ob.icebergs[iceberg.ParentOrder.ID] = iceberg
+		ob.Orders[iceberg.ParentOrder.ID] = iceberg.ParentOrder
+	}
+	for _, orders := range [][]*Order{book.Orders, book.Stops} {
+		for _, order := range orders {
+			ob.restingSide(order).Push(order)
+			if order.IsPegged() {
+				ob.pegs[order.ID] = order
+			}
+			if iceberg, exists := ob.icebergs[order.ParentOrderID]; exists && order.IsIcebergChild {
+				iceberg.CurrentOrder = order
+				continue
+			}
+			ob.Orders[order.ID] = order
+		}
+	}
+}
+
+// WriteSnapshot writes a snapshot to path, replacing the previous one
+// atomically
+func WriteSnapshot(path string, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Recover rebuilds the order books from the snapshot at snapshotPath and
+// the journal entries after it, then continues sequencing after the last
+// journaled command. Replayed commands are not journaled again. A missing
+// snapshot or journal is treated as empty. Recover must be called before
+// Start.
+func (s *Sequencer) Recover(snapshotPath, journalPath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrSequencerRunning
+	}
+
+	start := time.Now()
+	snapshot, err := ReadSnapshot(snapshotPath)
+	switch {
+	case err == nil:
+		for _, book := range snapshot.Books {
+			s.partition(book.Symbol).engine.orderBook(book.Symbol).restore(book)
+		}
+		s.sequence = snapshot.Sequence
+		s.last = snapshot.Timestamp
+	case errors.Is(err, fs.ErrNotExist):
+		snapshot = &Snapshot{}
+	default:
+		return err
+	}
+
+	replayed := 0
+	err = ReadJournal(journalPath, func(entry *JournalEntry) error {
+		if entry.Command.Sequence <= s.sequence {
+			return nil
+		}
+
+		command := entry.Command
+		result, _ := s.execute(&command)
+		if len(result.Trades) != len(entry.Trades) || result.Error != entry.Error {
+			s.logger.Warn("Replayed command diverged from the journal",
+				zap.Uint64("sequence", command.Sequence),
+				zap.Int("journaled_trades", len(entry.Trades)),
+				zap.Int("replayed_trades", len(result.Trades)))
+		}
+
+		s.sequence = command.Sequence
+		if command.Timestamp.After(s.last) {
+			s.last = command.Timestamp
+		}
+		replayed++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Recovered order books",
+		zap.Uint64("snapshot_sequence", snapshot.Sequence),
+		zap.Int("books", len(snapshot.Books)),
+		zap.Int("replayed", replayed),
+		zap.Uint64("sequence", s.sequence),
+		zap.Duration("duration", time.Since(start)))
+
+	return nil
+}
+
+// RunSnapshots writes a snapshot to path every interval until the context
+// is done. After each snapshot the journal entries it covers are dropped
+// when the journal can be compacted.
+func (s *Sequencer) RunSnapshots(ctx context.Context, interval time.Duration, path string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.WriteSnapshot(path); err != nil {
+				s.logger.Error("Failed to write snapshot",
+					zap.String("path", path),
+					zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// WriteSnapshot writes a snapshot of every order book to path, then
+// compacts the journal up to the snapshot's sequence. No snapshot is
+// written once a partition has stopped on a journal failure, as its books
+// hold commands the journal lost.
+func (s *Sequencer) WriteSnapshot(path string) error {
+	snapshot := s.Snapshot()
+	if err := s.failure(s.partitions...); err != nil {
+		return err
+	}
+	if err := WriteSnapshot(path, snapshot); err != nil {
+		return err
+	}
+
+	compacted := false
+	if journal, ok := s.journal.(CompactingJournal); ok {
+		if err := journal.Compact(snapshot.Sequence); err != nil {
+			return fmt.Errorf("compact journal: %w", err)
+		}
+		compacted = true
+	}
+
+	s.logger.Debug("Wrote snapshot",
+		zap.Uint64("sequence", snapshot.Sequence),
+		zap.Int("books", len(snapshot.Books)),
+		zap.Bool("compacted", compacted))
+	return nil
+}
+
+// OpenSequencer starts a sequencer that journals to dir, rebuilding its
+// order books from the snapshot and journal found there. sync fsyncs every
+// journal record before the command returns.
+func OpenSequencer(logger *zap.Logger, partitions int, dir string, sync bool) (*Sequencer, error) {
+	s, err := newFileSequencer(logger, partitions, dir, sync)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.open(dir); err != nil {
+		s.closer.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// newFileSequencer creates a sequencer that journals to dir without
+// recovering or starting it
+func newFileSequencer(logger *zap.Logger, partitions int, dir string, sync bool) (*Sequencer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	journal, err := OpenFileJournal(filepath.Join(dir, JournalFile), sync)
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewSequencer(logger, partitions, journal)
+	s.closer = journal
+	return s, nil
+}
+
+// open recovers the order books from the snapshot and journal in dir and
+// starts sequencing
+func (s *Sequencer) open(dir string) error {
+	if err := s.Recover(filepath.Join(dir, SnapshotFile), filepath.Join(dir, JournalFile)); err != nil {
+		return err
+	}
+	return s.Start()
+}
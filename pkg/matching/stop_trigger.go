@@ -0,0 +1,304 @@
+package matching
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"go.uber.org/zap"
+)
+
+// processStopOrder parks a stop order until its trigger price reaches the
+// stop price; triggerStops releases it once it does
+func (ob *OrderBook) processStopOrder(order *Order) {
+	if order.Side == OrderSideBuy {
+		ob.StopBids.Push(order)
+	} else {
+		ob.StopAsks.Push(order)
+	}
+
+	order.Status = OrderStatusNew
+}
+
+// triggerStops releases every stop order elected by the current trigger
+// prices. Elected orders re-enter matching one at a time, nearest stop price
+// first and then by queue time, and the trades of each one may elect further
+// stops, so a cascade resolves within a single call. Stops wait out auction
+// calls and are released when continuous trading resumes.
+func (ob *OrderBook) triggerStops() []*Trade {
+	if ob.Phase.IsCall() {
+		return nil
+	}
+
+	var trades []*Trade
+
+	for {
+		order := ob.nextElectedStop()
+		if order == nil {
+			return trades
+		}
+		trades = append(trades, ob.electStop(order)...)
+	}
+}
+
+// nextElectedStop pops the next elected stop order. When both sides have
+// one, the order queued first goes first.
+func (ob *OrderBook) nextElectedStop() *Order {
+	buy := ob.StopBids.Peek()
+	if buy != nil && !buy.StopElected(ob.stopTriggerPrice(OrderSideBuy)) {
+		buy = nil
+	}
+	sell := ob.StopAsks.Peek()
+	if sell != nil && !sell.StopElected(ob.stopTriggerPrice(OrderSideSell)) {
+		sell = nil
+	}
+
+	switch {
+	case buy == nil && sell == nil:
+		return nil
+	case sell == nil || (buy != nil && !sell.QueueTime().Before(buy.QueueTime())):
+		return ob.StopBids.Pop()
+	default:
+		return ob.StopAsks.Pop()
+	}
+}
+
+// electStop converts an elected stop order to a market or limit order and
+// matches it
+func (ob *OrderBook) electStop(order *Order) []*Trade {
+	if order.IsExpiredAt(ob.now()) {
+		ob.expireOrder(order)
+		return nil
+	}
+
+	now := ob.now()
+	order.Elect()
+	order.QueuedAt = now
+	order.UpdatedAt = now
+
+	ob.logger.Debug("Stop order triggered",
+		zap.String("order_id", order.ID),
+		zap.String("type", string(order.Type)),
+		zap.Stringer("stop_price", order.StopPrice))
+	ob.emit(EventStopTriggered, order)
+
+	if order.TimeInForce == TimeInForceFOK && !ob.canFill(order, now) {
+		ob.killOrder(order)
+		return nil
+	}
+	if order.Type == OrderTypeMarket {
+		return ob.processMarketOrder(order)
+	}
+	return ob.processLimitOrder(order)
+}
+
+// stopTriggerPrice returns the price that elects stop orders on a side
+// under the symbol's stop trigger: buy stops compare against the best ask
+// and sell stops against the best bid for BID_ASK
+func (ob *OrderBook) stopTriggerPrice(side OrderSide) Decimal {
+	switch types.StopTriggerFor(ob.Symbol) {
+	case types.StopTriggerMark:
+		return ob.MarkPrice
+	case types.StopTriggerBidAsk:
+		best := ob.Asks.Peek()
+		if side == OrderSideSell {
+			best = ob.Bids.Peek()
+		}
+		if best == nil {
+			return types.Zero
+		}
+		return best.Price
+	default:
+		return ob.LastPrice
+	}
+}
+
+// SetMarkPrice updates the mark price and releases the stops it elects
+func (ob *OrderBook) SetMarkPrice(price Decimal) []*Trade {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.MarkPrice = price
+	trades := ob.triggerStops()
+	ob.repricePegs()
+	return trades
+}
+
+// SetMarkPrice updates a symbol's mark price and publishes the trades of
+// the stops it elects
+func (me *MatchingEngine) SetMarkPrice(symbol string, price Decimal) ([]*Trade, error) {
+	me.mu.RLock()
+	orderBook, exists := me.OrderBooks[symbol]
+	me.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	trades := orderBook.SetMarkPrice(price)
+
+	// Send trades to channel
+	for _, trade := range trades {
+		select {
+		case me.TradeChannel <- trade:
+		default:
+			me.logger.Warn("Trade channel full, dropping trade",
+				zap.String("trade_id", trade.ID))
+		}
+	}
+
+	return trades, nil
+}
+
+// isStop returns true for orders that wait for their stop price
+func (o *HFTOrder) isStop() bool {
+	return o.Type == OrderTypeStop || o.Type == OrderTypeStopLimit || o.Type == OrderTypeStopMarket
+}
+
+// processStopOrder parks a stop order until its trigger price reaches the
+// stop price
+func (e *HFTEngine) processStopOrder(orderBook *HFTOrderBook, order *HFTOrder) {
+	orderBook.mu.Lock()
+	orderBook.stopOrders = append(orderBook.stopOrders, order)
+	orderBook.mu.Unlock()
+
+	orderBook.orderMap.Store(order.ID, order)
+	order.Status = OrderStatusNew
+}
+
+// triggerStops releases the elected stop orders of a book one at a time,
+// so the trades of each one may elect further stops within the same call
+func (e *HFTEngine) triggerStops(orderBook *HFTOrderBook) []*Trade {
+	var trades []*Trade
+
+	for {
+		order := orderBook.nextElectedStop()
+		if order == nil {
+			return trades
+		}
+
+		orderBook.orderMap.Delete(order.ID)
+		if order.Type == OrderTypeStopLimit {
+			order.Type = OrderTypeLimit
+		} else {
+			order.Type = OrderTypeMarket
+		}
+
+		select {
+		case e.EventChannel <- &MatchingEvent{
+			Type:      EventStopTriggered,
+			Symbol:    orderBook.Symbol,
+			Order:     order.toOrder(orderBook.scale),
+			Timestamp: time.Now(),
+		}:
+		default:
+			e.logger.Warn("Event channel full, dropping event",
+				zap.String("event_type", string(EventStopTriggered)),
+				zap.String("symbol", orderBook.Symbol))
+		}
+
+		trades = append(trades, e.processOrder(orderBook, order)...)
+	}
+}
+
+// nextElectedStop removes and returns the next elected stop order: on each
+// side the nearest stop price goes first, then the earliest order, and the
+// earlier of the two sides wins
+func (ob *HFTOrderBook) nextElectedStop() *HFTOrder {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	buyTrigger := ob.stopTriggerPrice(OrderSideBuy)
+	sellTrigger := ob.stopTriggerPrice(OrderSideSell)
+
+	buy, sell := -1, -1
+	for i, order := range ob.stopOrders {
+		if order.Side == OrderSideBuy {
+			if buyTrigger > 0 && buyTrigger >= order.StopPrice &&
+				(buy < 0 || stopBefore(order, ob.stopOrders[buy])) {
+				buy = i
+			}
+		} else if sellTrigger > 0 && sellTrigger <= order.StopPrice &&
+			(sell < 0 || stopBefore(order, ob.stopOrders[sell])) {
+			sell = i
+		}
+	}
+
+	next := buy
+	if next < 0 || (sell >= 0 && ob.stopOrders[sell].Timestamp.Before(ob.stopOrders[buy].Timestamp)) {
+		next = sell
+	}
+	if next < 0 {
+		return nil
+	}
+
+	order := ob.stopOrders[next]
+	ob.stopOrders = append(ob.stopOrders[:next], ob.stopOrders[next+1:]...)
+	return order
+}
+
+// stopBefore returns true if stop order a triggers before b on the same side
+func stopBefore(a, b *HFTOrder) bool {
+	if a.StopPrice != b.StopPrice {
+		if a.Side == OrderSideBuy {
+			return a.StopPrice < b.StopPrice
+		}
+		return a.StopPrice > b.StopPrice
+	}
+	return a.Timestamp.Before(b.Timestamp)
+}
+
+// stopTriggerPrice returns the fixed-point price that elects stop orders on
+// a side under the symbol's stop trigger
+func (ob *HFTOrderBook) stopTriggerPrice(side OrderSide) uint64 {
+	switch types.StopTriggerFor(ob.Symbol) {
+	case types.StopTriggerMark:
+		return atomic.LoadUint64(&ob.markPrice)
+	case types.StopTriggerBidAsk:
+		best := (*OrderLevel)(atomic.LoadPointer(&ob.sellOrders))
+		if side == OrderSideSell {
+			best = (*OrderLevel)(atomic.LoadPointer(&ob.buyOrders))
+		}
+		if best == nil {
+			return 0
+		}
+		return best.Price
+	default:
+		return atomic.LoadUint64(&ob.lastPrice)
+	}
+}
+
+// removeStop removes a parked stop order
+func (ob *HFTOrderBook) removeStop(order *HFTOrder) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	for i, stop := range ob.stopOrders {
+		if stop == order {
+			ob.stopOrders = append(ob.stopOrders[:i], ob.stopOrders[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetMarkPrice updates a symbol's mark price and publishes the trades of
+// the stops it elects
+func (e *HFTEngine) SetMarkPrice(symbol string, price Decimal) ([]*Trade, error) {
+	orderBook := e.getOrderBook(symbol)
+	if orderBook == nil {
+		return nil, ErrOrderBookNotFound
+	}
+
+	atomic.StoreUint64(&orderBook.markPrice, uint64(price.Rescale(orderBook.scale.Price).Unscaled()))
+	trades := e.triggerStops(orderBook)
+
+	for _, trade := range trades {
+		select {
+		case e.TradeChannel <- trade:
+		default:
+			e.logger.Warn("Trade channel full, dropping trade", zap.String("trade_id", trade.ID))
+		}
+	}
+
+	return trades, nil
+}
@@ -0,0 +1,119 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStopOrder returns a stop-market order for AAPL
+func newStopOrder(id string, side OrderSide, stopPrice, quantity string) *Order {
+	order := newLimitOrder(id, side, "0", quantity)
+	order.Type = OrderTypeStopMarket
+	order.StopPrice = types.MustParseDecimal(stopPrice)
+	return order
+}
+
+func TestMatchingEngine_StopOrders(t *testing.T) {
+	t.Run("cascade", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		orders := []*Order{
+			newLimitOrder("bid-99", OrderSideBuy, "99", "10"),
+			newLimitOrder("bid-98", OrderSideBuy, "98", "10"),
+			newLimitOrder("bid-97", OrderSideBuy, "97", "10"),
+			newStopOrder("stop-98", OrderSideSell, "98", "10"),
+			newStopOrder("stop-99", OrderSideSell, "99", "10"),
+		}
+		for _, order := range orders {
+			trades, err := engine.AddOrder(order)
+			require.NoError(t, err)
+			assert.Empty(t, trades)
+		}
+
+		// The sale at 99 elects the stop at 99, whose fill at 98 elects the
+		// stop at 98, all within one call
+		trades, err := engine.AddOrder(newLimitOrder("seller", OrderSideSell, "99", "10"))
+		require.NoError(t, err)
+		require.Len(t, trades, 3)
+		for i, expected := range []struct{ seller, buyer, price string }{
+			{"seller", "bid-99", "99"},
+			{"stop-99", "bid-98", "98"},
+			{"stop-98", "bid-97", "97"},
+		} {
+			assert.Equal(t, expected.seller, trades[i].SellOrderID)
+			assert.Equal(t, expected.buyer, trades[i].BuyOrderID)
+			assert.Equal(t, expected.price, trades[i].Price.String())
+		}
+		assert.Equal(t, OrderStatusFilled, orders[3].Status)
+		assert.Equal(t, OrderStatusFilled, orders[4].Status)
+
+		var triggered int
+		for _, event := range drainEvents(engine) {
+			if event == EventStopTriggered {
+				triggered++
+			}
+		}
+		assert.Equal(t, 2, triggered)
+	})
+
+	t.Run("stop-limit rests once elected", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		stop := newLimitOrder("stop-limit", OrderSideBuy, "101", "20")
+		stop.Type = OrderTypeStopLimit
+		stop.StopPrice = types.MustParseDecimal("100")
+		for _, order := range []*Order{
+			stop,
+			newLimitOrder("ask-100", OrderSideSell, "100", "10"),
+			newLimitOrder("ask-102", OrderSideSell, "102", "10"),
+		} {
+			_, err := engine.AddOrder(order)
+			require.NoError(t, err)
+		}
+
+		trades, err := engine.AddOrder(newLimitOrder("buyer", OrderSideBuy, "100", "5"))
+		require.NoError(t, err)
+		require.Len(t, trades, 2)
+		assert.Equal(t, "stop-limit", trades[1].BuyOrderID)
+		assert.Equal(t, "5", trades[1].Quantity.String())
+
+		// The remainder rests at its limit instead of reaching for 102
+		assert.Equal(t, OrderTypeLimit, stop.Type)
+		assert.Equal(t, "15", stop.RemainingQuantity().String())
+		assert.Equal(t, OrderStatusPartiallyFilled, stop.Status)
+	})
+
+	t.Run("mark price trigger", func(t *testing.T) {
+		engine := newTestEngine(t)
+		require.NoError(t, types.Instruments.Register(&types.Instrument{
+			Symbol:         "AAPL",
+			TradingEnabled: true,
+			StopTrigger:    types.StopTriggerMark,
+		}))
+
+		stop := newStopOrder("stop", OrderSideSell, "95", "10")
+		for _, order := range []*Order{newLimitOrder("bid", OrderSideBuy, "94", "10"), stop} {
+			_, err := engine.AddOrder(order)
+			require.NoError(t, err)
+		}
+
+		// Trades do not elect stops triggered on the mark price
+		trades, err := engine.AddOrder(newLimitOrder("seller", OrderSideSell, "94", "1"))
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, OrderStatusNew, stop.Status)
+
+		trades, err = engine.SetMarkPrice("AAPL", types.MustParseDecimal("96"))
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+
+		trades, err = engine.SetMarkPrice("AAPL", types.MustParseDecimal("95"))
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, "stop", trades[0].SellOrderID)
+		assert.Equal(t, "9", trades[0].Quantity.String())
+	})
+}
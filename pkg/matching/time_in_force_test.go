@@ -0,0 +1,162 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestEngine returns an engine with an empty instrument registry and no
+// self-trade prevention, restoring both when the test ends
+func newTestEngine(t *testing.T) *MatchingEngine {
+	t.Helper()
+
+	registry, policies := types.Instruments, types.SelfTradePolicies
+	types.Instruments = types.NewInstrumentRegistry()
+	types.SelfTradePolicies = types.NewSelfTradePolicy(types.SelfTradePreventionNone)
+	t.Cleanup(func() {
+		types.Instruments, types.SelfTradePolicies = registry, policies
+	})
+
+	return NewMatchingEngine(zap.NewNop())
+}
+
+// newLimitOrder returns a GTC limit order for AAPL from a user named after it
+func newLimitOrder(id string, side OrderSide, price, quantity string) *Order {
+	return &Order{
+		ID:          id,
+		UserID:      id,
+		Symbol:      "AAPL",
+		Side:        side,
+		Type:        OrderTypeLimit,
+		Price:       types.MustParseDecimal(price),
+		Quantity:    types.MustParseDecimal(quantity),
+		TimeInForce: TimeInForceGTC,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// drainEvents returns the types of the events published so far
+func drainEvents(engine *MatchingEngine) []MatchingEventType {
+	var events []MatchingEventType
+	for {
+		select {
+		case event := <-engine.EventChannel:
+			events = append(events, event.Type)
+		default:
+			return events
+		}
+	}
+}
+
+func TestMatchingEngine_TimeInForce(t *testing.T) {
+	t.Run("IOC cancels the remainder", func(t *testing.T) {
+		engine := newTestEngine(t)
+		_, err := engine.AddOrder(newLimitOrder("ask", OrderSideSell, "100", "50"))
+		require.NoError(t, err)
+
+		ioc := newLimitOrder("ioc", OrderSideBuy, "100", "80")
+		ioc.TimeInForce = TimeInForceIOC
+		trades, err := engine.AddOrder(ioc)
+		require.NoError(t, err)
+
+		require.Len(t, trades, 1)
+		assert.Equal(t, "50", trades[0].Quantity.String())
+		assert.Equal(t, OrderStatusCancelled, ioc.Status)
+		assert.Equal(t, "50", ioc.FilledQuantity.String())
+		_, resting := engine.GetOrder("AAPL", "ioc")
+		assert.False(t, resting)
+		assert.True(t, engine.GetOrderBook("AAPL").GetBestBid().IsZero())
+		assert.Contains(t, drainEvents(engine), EventOrderIOCCanceled)
+	})
+
+	t.Run("FOK is killed unless it fills completely", func(t *testing.T) {
+		engine := newTestEngine(t)
+		_, err := engine.AddOrder(newLimitOrder("ask", OrderSideSell, "100", "50"))
+		require.NoError(t, err)
+
+		fok := newLimitOrder("fok", OrderSideBuy, "100", "80")
+		fok.TimeInForce = TimeInForceFOK
+		trades, err := engine.AddOrder(fok)
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+		assert.Equal(t, OrderStatusRejected, fok.Status)
+		assert.True(t, fok.FilledQuantity.IsZero())
+		assert.Equal(t, []MatchingEventType{EventOrderFOKKilled}, drainEvents(engine))
+
+		// The resting ask is untouched and fills a FOK it covers
+		filled := newLimitOrder("filled", OrderSideBuy, "100", "50")
+		filled.TimeInForce = TimeInForceFOK
+		trades, err = engine.AddOrder(filled)
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, OrderStatusFilled, filled.Status)
+	})
+
+	t.Run("DAY and GTD orders expire", func(t *testing.T) {
+		engine := newTestEngine(t)
+		now := time.Now()
+
+		day := newLimitOrder("day", OrderSideBuy, "99", "10")
+		day.TimeInForce = TimeInForceDay
+		gtd := newLimitOrder("gtd", OrderSideBuy, "98", "10")
+		gtd.TimeInForce = TimeInForceGTD
+		gtd.ExpireTime = now.Add(time.Minute)
+		for _, order := range []*Order{day, gtd, newLimitOrder("gtc", OrderSideBuy, "97", "10")} {
+			_, err := engine.AddOrder(order)
+			require.NoError(t, err)
+		}
+
+		// DAY orders are stamped with the session close
+		assert.Equal(t, types.SessionCloseFor("AAPL", now), day.ExpireTime)
+
+		expired := engine.ExpireOrders(now.Add(2 * time.Minute))
+		require.Len(t, expired, 1)
+		assert.Equal(t, "gtd", expired[0].ID)
+		assert.Equal(t, OrderStatusExpired, gtd.Status)
+
+		expired = engine.ExpireOrders(day.ExpireTime)
+		require.Len(t, expired, 1)
+		assert.Equal(t, "day", expired[0].ID)
+		assert.Equal(t, OrderStatusExpired, day.Status)
+		assert.Equal(t, []MatchingEventType{EventOrderGTDExpired, EventOrderDayExpired}, drainEvents(engine))
+
+		_, resting := engine.GetOrder("AAPL", "gtc")
+		assert.True(t, resting)
+	})
+
+	t.Run("expired makers do not trade", func(t *testing.T) {
+		engine := newTestEngine(t)
+		now := time.Now()
+		engine.clock = func() time.Time { return now }
+
+		stale := newLimitOrder("stale", OrderSideSell, "100", "10")
+		stale.TimeInForce = TimeInForceGTD
+		stale.ExpireTime = now.Add(time.Minute)
+		_, err := engine.AddOrder(stale)
+		require.NoError(t, err)
+		_, err = engine.AddOrder(newLimitOrder("ask", OrderSideSell, "101", "10"))
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		trades, err := engine.AddOrder(newLimitOrder("bid", OrderSideBuy, "101", "10"))
+		require.NoError(t, err)
+		require.Len(t, trades, 1)
+		assert.Equal(t, "ask", trades[0].SellOrderID)
+		assert.Equal(t, OrderStatusExpired, stale.Status)
+	})
+
+	t.Run("GTD requires a future expire time", func(t *testing.T) {
+		engine := newTestEngine(t)
+
+		gtd := newLimitOrder("gtd", OrderSideBuy, "100", "10")
+		gtd.TimeInForce = TimeInForceGTD
+		_, err := engine.AddOrder(gtd)
+		assert.Equal(t, types.RejectReasonInvalidTimeInForce, types.RejectReasonOf(err))
+		assert.Equal(t, OrderStatusRejected, gtd.Status)
+	})
+}
@@ -20,6 +20,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// VaRMethod selects how Value at Risk is estimated
+type VaRMethod int32
+
+const (
+	VaRMethod_VAR_METHOD_UNSPECIFIED VaRMethod = 0
+	VaRMethod_VAR_PARAMETRIC         VaRMethod = 1
+	VaRMethod_VAR_HISTORICAL         VaRMethod = 2
+	VaRMethod_VAR_MONTE_CARLO        VaRMethod = 3
+)
+
+// Enum value maps for VaRMethod.
+var (
+	VaRMethod_name = map[int32]string{
+		0: "VAR_METHOD_UNSPECIFIED",
+		1: "VAR_PARAMETRIC",
+		2: "VAR_HISTORICAL",
+		3: "VAR_MONTE_CARLO",
+	}
+	VaRMethod_value = map[string]int32{
+		"VAR_METHOD_UNSPECIFIED": 0,
+		"VAR_PARAMETRIC":         1,
+		"VAR_HISTORICAL":         2,
+		"VAR_MONTE_CARLO":        3,
+	}
+)
+
+func (x VaRMethod) Enum() *VaRMethod {
+	p := new(VaRMethod)
+	*p = x
+	return p
+}
+
+func (x VaRMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VaRMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_risk_risk_proto_enumTypes[0].Descriptor()
+}
+
+func (VaRMethod) Type() protoreflect.EnumType {
+	return &file_proto_risk_risk_proto_enumTypes[0]
+}
+
+func (x VaRMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VaRMethod.Descriptor instead.
+func (VaRMethod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{0}
+}
+
 // RiskLevel represents the risk level
 type RiskLevel int32
 
@@ -57,11 +110,11 @@ func (x RiskLevel) String() string {
 }
 
 func (RiskLevel) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_risk_risk_proto_enumTypes[0].Descriptor()
+	return file_proto_risk_risk_proto_enumTypes[1].Descriptor()
 }
 
 func (RiskLevel) Type() protoreflect.EnumType {
-	return &file_proto_risk_risk_proto_enumTypes[0]
+	return &file_proto_risk_risk_proto_enumTypes[1]
 }
 
 func (x RiskLevel) Number() protoreflect.EnumNumber {
@@ -70,7 +123,7 @@ func (x RiskLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RiskLevel.Descriptor instead.
 func (RiskLevel) EnumDescriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{0}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{1}
 }
 
 // OrderSide represents the side of an order
@@ -104,11 +157,11 @@ func (x OrderSide) String() string {
 }
 
 func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_risk_risk_proto_enumTypes[1].Descriptor()
+	return file_proto_risk_risk_proto_enumTypes[2].Descriptor()
 }
 
 func (OrderSide) Type() protoreflect.EnumType {
-	return &file_proto_risk_risk_proto_enumTypes[1]
+	return &file_proto_risk_risk_proto_enumTypes[2]
 }
 
 func (x OrderSide) Number() protoreflect.EnumNumber {
@@ -117,7 +170,7 @@ func (x OrderSide) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderSide.Descriptor instead.
 func (OrderSide) EnumDescriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{1}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{2}
 }
 
 // OrderType represents the type of an order
@@ -157,11 +210,11 @@ func (x OrderType) String() string {
 }
 
 func (OrderType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_risk_risk_proto_enumTypes[2].Descriptor()
+	return file_proto_risk_risk_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderType) Type() protoreflect.EnumType {
-	return &file_proto_risk_risk_proto_enumTypes[2]
+	return &file_proto_risk_risk_proto_enumTypes[3]
 }
 
 func (x OrderType) Number() protoreflect.EnumNumber {
@@ -170,7 +223,7 @@ func (x OrderType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderType.Descriptor instead.
 func (OrderType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{2}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{3}
 }
 
 // AccountRiskRequest represents a request for account risk metrics
@@ -181,6 +234,12 @@ type AccountRiskRequest struct {
 
 	// Account ID to get risk metrics for
 	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// VaR method; the server's configured method when unspecified
+	VarMethod VaRMethod `protobuf:"varint,2,opt,name=var_method,json=varMethod,proto3,enum=risk.VaRMethod" json:"var_method,omitempty"`
+	// VaR confidence level, such as 0.99; the server's when zero
+	Confidence float64 `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// VaR holding period in days; the server's when zero
+	HorizonDays int32 `protobuf:"varint,4,opt,name=horizon_days,json=horizonDays,proto3" json:"horizon_days,omitempty"`
 }
 
 func (x *AccountRiskRequest) Reset() {
@@ -222,6 +281,27 @@ func (x *AccountRiskRequest) GetAccountId() string {
 	return ""
 }
 
+func (x *AccountRiskRequest) GetVarMethod() VaRMethod {
+	if x != nil {
+		return x.VarMethod
+	}
+	return VaRMethod_VAR_METHOD_UNSPECIFIED
+}
+
+func (x *AccountRiskRequest) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *AccountRiskRequest) GetHorizonDays() int32 {
+	if x != nil {
+		return x.HorizonDays
+	}
+	return 0
+}
+
 // AccountRiskResponse represents a response with account risk metrics
 type AccountRiskResponse struct {
 	state         protoimpl.MessageState
@@ -252,6 +332,28 @@ type AccountRiskResponse struct {
 	RiskLimits *RiskLimits `protobuf:"bytes,11,opt,name=risk_limits,json=riskLimits,proto3" json:"risk_limits,omitempty"`
 	// Positions of the account
 	Positions []*Position `protobuf:"bytes,12,rep,name=positions,proto3" json:"positions,omitempty"`
+	// Portfolio VaR at 95% confidence over the horizon
+	Var_95 float64 `protobuf:"fixed64,13,opt,name=var_95,json=var95,proto3" json:"var_95,omitempty"`
+	// Portfolio VaR at 99% confidence over the horizon
+	Var_99 float64 `protobuf:"fixed64,14,opt,name=var_99,json=var99,proto3" json:"var_99,omitempty"`
+	// Portfolio VaR at the requested confidence over the horizon
+	Var float64 `protobuf:"fixed64,15,opt,name=var,proto3" json:"var,omitempty"`
+	// Portfolio Expected Shortfall at the requested confidence over the horizon
+	ExpectedShortfall float64 `protobuf:"fixed64,16,opt,name=expected_shortfall,json=expectedShortfall,proto3" json:"expected_shortfall,omitempty"`
+	// Method the VaR was estimated with
+	VarMethod VaRMethod `protobuf:"varint,17,opt,name=var_method,json=varMethod,proto3,enum=risk.VaRMethod" json:"var_method,omitempty"`
+	// Confidence level of var and expected_shortfall
+	VarConfidence float64 `protobuf:"fixed64,18,opt,name=var_confidence,json=varConfidence,proto3" json:"var_confidence,omitempty"`
+	// Holding period of the VaR in days
+	VarHorizonDays int32 `protobuf:"varint,19,opt,name=var_horizon_days,json=varHorizonDays,proto3" json:"var_horizon_days,omitempty"`
+	// Net Greeks of the account per underlying
+	Greeks []*GreekExposure `protobuf:"bytes,20,rep,name=greeks,proto3" json:"greeks,omitempty"`
+	// Net delta of the account in currency, summed over underlyings
+	NetDollarDelta float64 `protobuf:"fixed64,21,opt,name=net_dollar_delta,json=netDollarDelta,proto3" json:"net_dollar_delta,omitempty"`
+	// Net theta of the account in currency per day
+	NetTheta float64 `protobuf:"fixed64,22,opt,name=net_theta,json=netTheta,proto3" json:"net_theta,omitempty"`
+	// Net vega of the account in currency per volatility point
+	NetVega float64 `protobuf:"fixed64,23,opt,name=net_vega,json=netVega,proto3" json:"net_vega,omitempty"`
 }
 
 func (x *AccountRiskResponse) Reset() {
@@ -370,6 +472,177 @@ func (x *AccountRiskResponse) GetPositions() []*Position {
 	return nil
 }
 
+func (x *AccountRiskResponse) GetVar_95() float64 {
+	if x != nil {
+		return x.Var_95
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetVar_99() float64 {
+	if x != nil {
+		return x.Var_99
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetVar() float64 {
+	if x != nil {
+		return x.Var
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetExpectedShortfall() float64 {
+	if x != nil {
+		return x.ExpectedShortfall
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetVarMethod() VaRMethod {
+	if x != nil {
+		return x.VarMethod
+	}
+	return VaRMethod_VAR_METHOD_UNSPECIFIED
+}
+
+func (x *AccountRiskResponse) GetVarConfidence() float64 {
+	if x != nil {
+		return x.VarConfidence
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetVarHorizonDays() int32 {
+	if x != nil {
+		return x.VarHorizonDays
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetGreeks() []*GreekExposure {
+	if x != nil {
+		return x.Greeks
+	}
+	return nil
+}
+
+func (x *AccountRiskResponse) GetNetDollarDelta() float64 {
+	if x != nil {
+		return x.NetDollarDelta
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetNetTheta() float64 {
+	if x != nil {
+		return x.NetTheta
+	}
+	return 0
+}
+
+func (x *AccountRiskResponse) GetNetVega() float64 {
+	if x != nil {
+		return x.NetVega
+	}
+	return 0
+}
+
+// GreekExposure represents the net Greeks of an account in one underlying
+type GreekExposure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Symbol of the underlying
+	Underlying string `protobuf:"bytes,1,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	// Units of the underlying the positions are equivalent to
+	Delta float64 `protobuf:"fixed64,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	// Delta valued at the underlying price
+	DollarDelta float64 `protobuf:"fixed64,3,opt,name=dollar_delta,json=dollarDelta,proto3" json:"dollar_delta,omitempty"`
+	// Change in delta per unit of the underlying price
+	Gamma float64 `protobuf:"fixed64,4,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	// Change in value per day
+	Theta float64 `protobuf:"fixed64,5,opt,name=theta,proto3" json:"theta,omitempty"`
+	// Change in value per volatility point
+	Vega float64 `protobuf:"fixed64,6,opt,name=vega,proto3" json:"vega,omitempty"`
+}
+
+func (x *GreekExposure) Reset() {
+	*x = GreekExposure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GreekExposure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GreekExposure) ProtoMessage() {}
+
+func (x *GreekExposure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GreekExposure.ProtoReflect.Descriptor instead.
+func (*GreekExposure) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GreekExposure) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *GreekExposure) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *GreekExposure) GetDollarDelta() float64 {
+	if x != nil {
+		return x.DollarDelta
+	}
+	return 0
+}
+
+func (x *GreekExposure) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *GreekExposure) GetTheta() float64 {
+	if x != nil {
+		return x.Theta
+	}
+	return 0
+}
+
+func (x *GreekExposure) GetVega() float64 {
+	if x != nil {
+		return x.Vega
+	}
+	return 0
+}
+
 // PositionRiskRequest represents a request for position risk metrics
 type PositionRiskRequest struct {
 	state         protoimpl.MessageState
@@ -385,7 +658,7 @@ type PositionRiskRequest struct {
 func (x *PositionRiskRequest) Reset() {
 	*x = PositionRiskRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[2]
+		mi := &file_proto_risk_risk_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -398,7 +671,7 @@ func (x *PositionRiskRequest) String() string {
 func (*PositionRiskRequest) ProtoMessage() {}
 
 func (x *PositionRiskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[2]
+	mi := &file_proto_risk_risk_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +684,7 @@ func (x *PositionRiskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PositionRiskRequest.ProtoReflect.Descriptor instead.
 func (*PositionRiskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{2}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PositionRiskRequest) GetAccountId() string {
@@ -461,7 +734,7 @@ type PositionRiskResponse struct {
 func (x *PositionRiskResponse) Reset() {
 	*x = PositionRiskResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[3]
+		mi := &file_proto_risk_risk_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -474,7 +747,7 @@ func (x *PositionRiskResponse) String() string {
 func (*PositionRiskResponse) ProtoMessage() {}
 
 func (x *PositionRiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[3]
+	mi := &file_proto_risk_risk_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +760,7 @@ func (x *PositionRiskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PositionRiskResponse.ProtoReflect.Descriptor instead.
 func (*PositionRiskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{3}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PositionRiskResponse) GetAccountId() string {
@@ -590,7 +863,7 @@ type OrderRiskRequest struct {
 func (x *OrderRiskRequest) Reset() {
 	*x = OrderRiskRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[4]
+		mi := &file_proto_risk_risk_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -603,7 +876,7 @@ func (x *OrderRiskRequest) String() string {
 func (*OrderRiskRequest) ProtoMessage() {}
 
 func (x *OrderRiskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[4]
+	mi := &file_proto_risk_risk_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -616,7 +889,7 @@ func (x *OrderRiskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderRiskRequest.ProtoReflect.Descriptor instead.
 func (*OrderRiskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{4}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OrderRiskRequest) GetAccountId() string {
@@ -696,7 +969,7 @@ type OrderRiskResponse struct {
 func (x *OrderRiskResponse) Reset() {
 	*x = OrderRiskResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[5]
+		mi := &file_proto_risk_risk_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -709,7 +982,7 @@ func (x *OrderRiskResponse) String() string {
 func (*OrderRiskResponse) ProtoMessage() {}
 
 func (x *OrderRiskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[5]
+	mi := &file_proto_risk_risk_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +995,7 @@ func (x *OrderRiskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderRiskResponse.ProtoReflect.Descriptor instead.
 func (*OrderRiskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{5}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OrderRiskResponse) GetAccountId() string {
@@ -832,7 +1105,7 @@ type ValidateOrderRequest struct {
 func (x *ValidateOrderRequest) Reset() {
 	*x = ValidateOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[6]
+		mi := &file_proto_risk_risk_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -845,7 +1118,7 @@ func (x *ValidateOrderRequest) String() string {
 func (*ValidateOrderRequest) ProtoMessage() {}
 
 func (x *ValidateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[6]
+	mi := &file_proto_risk_risk_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +1131,7 @@ func (x *ValidateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateOrderRequest.ProtoReflect.Descriptor instead.
 func (*ValidateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{6}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ValidateOrderRequest) GetAccountId() string {
@@ -920,7 +1193,7 @@ type ValidateOrderResponse struct {
 func (x *ValidateOrderResponse) Reset() {
 	*x = ValidateOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[7]
+		mi := &file_proto_risk_risk_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -933,7 +1206,7 @@ func (x *ValidateOrderResponse) String() string {
 func (*ValidateOrderResponse) ProtoMessage() {}
 
 func (x *ValidateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[7]
+	mi := &file_proto_risk_risk_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +1219,7 @@ func (x *ValidateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateOrderResponse.ProtoReflect.Descriptor instead.
 func (*ValidateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{7}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ValidateOrderResponse) GetIsValid() bool {
@@ -985,7 +1258,7 @@ type UpdateRiskLimitsRequest struct {
 func (x *UpdateRiskLimitsRequest) Reset() {
 	*x = UpdateRiskLimitsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[8]
+		mi := &file_proto_risk_risk_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +1271,7 @@ func (x *UpdateRiskLimitsRequest) String() string {
 func (*UpdateRiskLimitsRequest) ProtoMessage() {}
 
 func (x *UpdateRiskLimitsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[8]
+	mi := &file_proto_risk_risk_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1284,7 @@ func (x *UpdateRiskLimitsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRiskLimitsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRiskLimitsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{8}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateRiskLimitsRequest) GetAccountId() string {
@@ -1043,7 +1316,7 @@ type UpdateRiskLimitsResponse struct {
 func (x *UpdateRiskLimitsResponse) Reset() {
 	*x = UpdateRiskLimitsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[9]
+		mi := &file_proto_risk_risk_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1329,7 @@ func (x *UpdateRiskLimitsResponse) String() string {
 func (*UpdateRiskLimitsResponse) ProtoMessage() {}
 
 func (x *UpdateRiskLimitsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[9]
+	mi := &file_proto_risk_risk_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1342,7 @@ func (x *UpdateRiskLimitsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRiskLimitsResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRiskLimitsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{9}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateRiskLimitsResponse) GetAccountId() string {
@@ -1113,7 +1386,7 @@ type RiskLimits struct {
 func (x *RiskLimits) Reset() {
 	*x = RiskLimits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[10]
+		mi := &file_proto_risk_risk_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1126,7 +1399,7 @@ func (x *RiskLimits) String() string {
 func (*RiskLimits) ProtoMessage() {}
 
 func (x *RiskLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[10]
+	mi := &file_proto_risk_risk_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1139,7 +1412,7 @@ func (x *RiskLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RiskLimits.ProtoReflect.Descriptor instead.
 func (*RiskLimits) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{10}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RiskLimits) GetMaxPositionSize() float64 {
@@ -1218,12 +1491,32 @@ type Position struct {
 	UnrealizedPnl float64 `protobuf:"fixed64,6,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
 	// Realized profit and loss of the position
 	RealizedPnl float64 `protobuf:"fixed64,7,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	// VaR of the position held on its own, at the requested confidence
+	Var float64 `protobuf:"fixed64,8,opt,name=var,proto3" json:"var,omitempty"`
+	// Contribution of the position to the portfolio VaR
+	ComponentVar float64 `protobuf:"fixed64,9,opt,name=component_var,json=componentVar,proto3" json:"component_var,omitempty"`
+	// Change in portfolio VaR per unit of exposure added to the position
+	MarginalVar float64 `protobuf:"fixed64,10,opt,name=marginal_var,json=marginalVar,proto3" json:"marginal_var,omitempty"`
+	// Contribution of the position to the portfolio Expected Shortfall
+	ComponentEs float64 `protobuf:"fixed64,11,opt,name=component_es,json=componentEs,proto3" json:"component_es,omitempty"`
+	// Units of the underlying the position is equivalent to
+	Delta float64 `protobuf:"fixed64,12,opt,name=delta,proto3" json:"delta,omitempty"`
+	// Change in delta per unit of the underlying price
+	Gamma float64 `protobuf:"fixed64,13,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	// Change in value per day
+	Theta float64 `protobuf:"fixed64,14,opt,name=theta,proto3" json:"theta,omitempty"`
+	// Change in value per volatility point
+	Vega float64 `protobuf:"fixed64,15,opt,name=vega,proto3" json:"vega,omitempty"`
+	// Volatility implied by the price of an option position
+	ImpliedVolatility float64 `protobuf:"fixed64,16,opt,name=implied_volatility,json=impliedVolatility,proto3" json:"implied_volatility,omitempty"`
+	// Symbol of the underlying of an option position
+	Underlying string `protobuf:"bytes,17,opt,name=underlying,proto3" json:"underlying,omitempty"`
 }
 
 func (x *Position) Reset() {
 	*x = Position{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_risk_risk_proto_msgTypes[11]
+		mi := &file_proto_risk_risk_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1236,7 +1529,7 @@ func (x *Position) String() string {
 func (*Position) ProtoMessage() {}
 
 func (x *Position) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_risk_risk_proto_msgTypes[11]
+	mi := &file_proto_risk_risk_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1249,7 +1542,7 @@ func (x *Position) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Position.ProtoReflect.Descriptor instead.
 func (*Position) Descriptor() ([]byte, []int) {
-	return file_proto_risk_risk_proto_rawDescGZIP(), []int{11}
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Position) GetSymbol() string {
@@ -1301,234 +1594,921 @@ func (x *Position) GetRealizedPnl() float64 {
 	return 0
 }
 
-var File_proto_risk_risk_proto protoreflect.FileDescriptor
+func (x *Position) GetVar() float64 {
+	if x != nil {
+		return x.Var
+	}
+	return 0
+}
 
-var file_proto_risk_risk_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x69, 0x73, 0x6b, 0x2f, 0x72, 0x69, 0x73,
-	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x72, 0x69, 0x73, 0x6b, 0x22, 0x33, 0x0a,
-	0x12, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x49, 0x64, 0x22, 0xe8, 0x03, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69,
-	0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d,
-	0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x61,
-	0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x64,
-	0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e,
-	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61,
-	0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x72,
-	0x67, 0x69, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x43, 0x61, 0x6c, 0x6c,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x70, 0x6e, 0x6c, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x50, 0x6e, 0x6c, 0x12,
-	0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x08, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x6e, 0x6c, 0x12, 0x2e, 0x0a, 0x0a,
-	0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65,
-	0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x31, 0x0a, 0x0b,
-	0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
-	0x2c, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4c, 0x0a,
-	0x13, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0xa4, 0x03, 0x0a, 0x14,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
-	0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69,
-	0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
-	0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65,
-	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x0b, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x12, 0x25, 0x0a, 0x0e,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x4d, 0x61, 0x72,
-	0x67, 0x69, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x11, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x72, 0x67,
-	0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69,
-	0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x22, 0xc5, 0x01, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23,
-	0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72,
-	0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73,
-	0x69, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xcd, 0x03, 0x0a, 0x11, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73,
-	0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
-	0x69, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f,
-	0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x34, 0x0a, 0x16,
-	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e,
-	0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x61, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x41, 0x66, 0x74,
-	0x65, 0x72, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76,
-	0x65, 0x6c, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
-	0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x41, 0x66, 0x74, 0x65, 0x72,
-	0x12, 0x2e, 0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12,
-	0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xc9, 0x01, 0x0a, 0x14, 0x56,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x69,
-	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12,
-	0x23, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
-	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x15, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72,
-	0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0c, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72,
-	0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b, 0x72, 0x69, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x22, 0x6b, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0b,
-	0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22,
-	0x6c, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x69,
-	0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x73, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xd0, 0x02,
-	0x0a, 0x0a, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11,
-	0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
-	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x21,
-	0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67,
-	0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c,
-	0x6f, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x44, 0x61,
-	0x69, 0x6c, 0x79, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x0c, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x28, 0x0a,
-	0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65,
-	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x72, 0x67,
-	0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x72, 0x67, 0x69,
-	0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x4c, 0x65,
-	0x76, 0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
-	0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x22, 0xf3, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
-	0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
-	0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x69, 0x71, 0x75,
-	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e,
-	0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
-	0x50, 0x6e, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f,
-	0x70, 0x6e, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x6c, 0x69,
-	0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x2a, 0x38, 0x0a, 0x09, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x65,
-	0x76, 0x65, 0x6c, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
-	0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48,
-	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x03,
-	0x2a, 0x1e, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x12, 0x07, 0x0a,
-	0x03, 0x42, 0x55, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x45, 0x4c, 0x4c, 0x10, 0x01,
-	0x2a, 0x3c, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a,
-	0x06, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x49, 0x4d,
-	0x49, 0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02, 0x12, 0x0e,
-	0x0a, 0x0a, 0x53, 0x54, 0x4f, 0x50, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x32, 0xfc,
-	0x02, 0x0a, 0x0b, 0x52, 0x69, 0x73, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x45,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b,
-	0x12, 0x18, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
-	0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x69, 0x73,
-	0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x19, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x12,
-	0x16, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x48, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x12, 0x1a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1d,
-	0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
-	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c,
-	0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2c, 0x5a,
-	0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x62, 0x64, 0x6f,
-	0x45, 0x6c, 0x48, 0x6f, 0x64, 0x61, 0x6b, 0x79, 0x2f, 0x74, 0x72, 0x61, 0x64, 0x53, 0x79, 0x73,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x69, 0x73, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+func (x *Position) GetComponentVar() float64 {
+	if x != nil {
+		return x.ComponentVar
+	}
+	return 0
 }
 
-var (
-	file_proto_risk_risk_proto_rawDescOnce sync.Once
-	file_proto_risk_risk_proto_rawDescData = file_proto_risk_risk_proto_rawDesc
-)
+func (x *Position) GetMarginalVar() float64 {
+	if x != nil {
+		return x.MarginalVar
+	}
+	return 0
+}
+
+func (x *Position) GetComponentEs() float64 {
+	if x != nil {
+		return x.ComponentEs
+	}
+	return 0
+}
+
+func (x *Position) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *Position) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *Position) GetTheta() float64 {
+	if x != nil {
+		return x.Theta
+	}
+	return 0
+}
+
+func (x *Position) GetVega() float64 {
+	if x != nil {
+		return x.Vega
+	}
+	return 0
+}
+
+func (x *Position) GetImpliedVolatility() float64 {
+	if x != nil {
+		return x.ImpliedVolatility
+	}
+	return 0
+}
+
+func (x *Position) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+// StressTestRequest represents a request to run stress scenarios
+type StressTestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Accounts to stress; every account when empty
+	AccountIds []string `protobuf:"bytes,1,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+	// Names of the server's scenarios to run
+	ScenarioNames []string `protobuf:"bytes,2,rep,name=scenario_names,json=scenarioNames,proto3" json:"scenario_names,omitempty"`
+	// Scenarios defined by the caller to run
+	Scenarios []*StressScenario `protobuf:"bytes,3,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+}
+
+func (x *StressTestRequest) Reset() {
+	*x = StressTestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressTestRequest) ProtoMessage() {}
+
+func (x *StressTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressTestRequest.ProtoReflect.Descriptor instead.
+func (*StressTestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StressTestRequest) GetAccountIds() []string {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+func (x *StressTestRequest) GetScenarioNames() []string {
+	if x != nil {
+		return x.ScenarioNames
+	}
+	return nil
+}
+
+func (x *StressTestRequest) GetScenarios() []*StressScenario {
+	if x != nil {
+		return x.Scenarios
+	}
+	return nil
+}
+
+// StressScenario represents market moves to revalue positions under. Shocks
+// are keyed by symbol, asset class or "*" for any other symbol.
+type StressScenario struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the scenario
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Description of the scenario
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Estimated probability of the scenario
+	Probability float64 `protobuf:"fixed64,3,opt,name=probability,proto3" json:"probability,omitempty"`
+	// Relative price moves, such as -0.3 for a 30% fall
+	PriceShocks map[string]float64 `protobuf:"bytes,4,rep,name=price_shocks,json=priceShocks,proto3" json:"price_shocks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Multipliers of the volatility options are valued at
+	VolatilityShocks map[string]float64 `protobuf:"bytes,5,rep,name=volatility_shocks,json=volatilityShocks,proto3" json:"volatility_shocks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Relative moves of currencies against the reporting currency
+	FxShocks map[string]float64 `protobuf:"bytes,6,rep,name=fx_shocks,json=fxShocks,proto3" json:"fx_shocks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Move of correlations towards one when positive, towards zero when negative
+	CorrelationShock float64 `protobuf:"fixed64,7,opt,name=correlation_shock,json=correlationShock,proto3" json:"correlation_shock,omitempty"`
+	// Whether symbols without a shock move with the shocked ones
+	Propagate bool `protobuf:"varint,8,opt,name=propagate,proto3" json:"propagate,omitempty"`
+	// Start of a historical window to replay, in Unix milliseconds
+	ReplayStart int64 `protobuf:"varint,9,opt,name=replay_start,json=replayStart,proto3" json:"replay_start,omitempty"`
+	// End of a historical window to replay, in Unix milliseconds
+	ReplayEnd int64 `protobuf:"varint,10,opt,name=replay_end,json=replayEnd,proto3" json:"replay_end,omitempty"`
+}
+
+func (x *StressScenario) Reset() {
+	*x = StressScenario{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressScenario) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressScenario) ProtoMessage() {}
+
+func (x *StressScenario) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressScenario.ProtoReflect.Descriptor instead.
+func (*StressScenario) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StressScenario) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StressScenario) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StressScenario) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *StressScenario) GetPriceShocks() map[string]float64 {
+	if x != nil {
+		return x.PriceShocks
+	}
+	return nil
+}
+
+func (x *StressScenario) GetVolatilityShocks() map[string]float64 {
+	if x != nil {
+		return x.VolatilityShocks
+	}
+	return nil
+}
+
+func (x *StressScenario) GetFxShocks() map[string]float64 {
+	if x != nil {
+		return x.FxShocks
+	}
+	return nil
+}
+
+func (x *StressScenario) GetCorrelationShock() float64 {
+	if x != nil {
+		return x.CorrelationShock
+	}
+	return 0
+}
+
+func (x *StressScenario) GetPropagate() bool {
+	if x != nil {
+		return x.Propagate
+	}
+	return false
+}
+
+func (x *StressScenario) GetReplayStart() int64 {
+	if x != nil {
+		return x.ReplayStart
+	}
+	return 0
+}
+
+func (x *StressScenario) GetReplayEnd() int64 {
+	if x != nil {
+		return x.ReplayEnd
+	}
+	return 0
+}
+
+// StressTestResponse represents the results of stress scenarios
+type StressTestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Results in the order the scenarios were requested
+	Results []*StressResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *StressTestResponse) Reset() {
+	*x = StressTestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressTestResponse) ProtoMessage() {}
+
+func (x *StressTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressTestResponse.ProtoReflect.Descriptor instead.
+func (*StressTestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StressTestResponse) GetResults() []*StressResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// StressResult represents the P&L of positions under a scenario
+type StressResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the scenario
+	Scenario string `protobuf:"bytes,1,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	// Estimated probability of the scenario
+	Probability float64 `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+	// Total P&L in the reporting currency
+	Pnl float64 `protobuf:"fixed64,3,opt,name=pnl,proto3" json:"pnl,omitempty"`
+	// P&L by account
+	PnlByAccount map[string]float64 `protobuf:"bytes,4,rep,name=pnl_by_account,json=pnlByAccount,proto3" json:"pnl_by_account,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// P&L by symbol
+	PnlBySymbol map[string]float64 `protobuf:"bytes,5,rep,name=pnl_by_symbol,json=pnlBySymbol,proto3" json:"pnl_by_symbol,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// P&L by asset class
+	PnlByAssetClass map[string]float64 `protobuf:"bytes,6,rep,name=pnl_by_asset_class,json=pnlByAssetClass,proto3" json:"pnl_by_asset_class,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// P&L of each position
+	Positions []*StressPnL `protobuf:"bytes,7,rep,name=positions,proto3" json:"positions,omitempty"`
+}
+
+func (x *StressResult) Reset() {
+	*x = StressResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressResult) ProtoMessage() {}
+
+func (x *StressResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressResult.ProtoReflect.Descriptor instead.
+func (*StressResult) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StressResult) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *StressResult) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *StressResult) GetPnl() float64 {
+	if x != nil {
+		return x.Pnl
+	}
+	return 0
+}
+
+func (x *StressResult) GetPnlByAccount() map[string]float64 {
+	if x != nil {
+		return x.PnlByAccount
+	}
+	return nil
+}
+
+func (x *StressResult) GetPnlBySymbol() map[string]float64 {
+	if x != nil {
+		return x.PnlBySymbol
+	}
+	return nil
+}
+
+func (x *StressResult) GetPnlByAssetClass() map[string]float64 {
+	if x != nil {
+		return x.PnlByAssetClass
+	}
+	return nil
+}
+
+func (x *StressResult) GetPositions() []*StressPnL {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// StressPnL represents the P&L of one position under a scenario
+type StressPnL struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Account holding the position
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Symbol of the position
+	Symbol string `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Asset class of the position
+	AssetClass string `protobuf:"bytes,3,opt,name=asset_class,json=assetClass,proto3" json:"asset_class,omitempty"`
+	// Value of the position before the scenario
+	Value float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Value of the position under the scenario
+	StressedValue float64 `protobuf:"fixed64,5,opt,name=stressed_value,json=stressedValue,proto3" json:"stressed_value,omitempty"`
+	// Stressed value less value
+	Pnl float64 `protobuf:"fixed64,6,opt,name=pnl,proto3" json:"pnl,omitempty"`
+}
+
+func (x *StressPnL) Reset() {
+	*x = StressPnL{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_risk_risk_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressPnL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressPnL) ProtoMessage() {}
+
+func (x *StressPnL) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_risk_risk_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressPnL.ProtoReflect.Descriptor instead.
+func (*StressPnL) Descriptor() ([]byte, []int) {
+	return file_proto_risk_risk_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StressPnL) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *StressPnL) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StressPnL) GetAssetClass() string {
+	if x != nil {
+		return x.AssetClass
+	}
+	return ""
+}
+
+func (x *StressPnL) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *StressPnL) GetStressedValue() float64 {
+	if x != nil {
+		return x.StressedValue
+	}
+	return 0
+}
+
+func (x *StressPnL) GetPnl() float64 {
+	if x != nil {
+		return x.Pnl
+	}
+	return 0
+}
+
+var File_proto_risk_risk_proto protoreflect.FileDescriptor
+
+var file_proto_risk_risk_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x69, 0x73, 0x6b, 0x2f, 0x72, 0x69, 0x73,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x72, 0x69, 0x73, 0x6b, 0x22, 0xa6, 0x01,
+	0x0a, 0x12, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x0a, 0x76, 0x61, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x56,
+	0x61, 0x52, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x09, 0x76, 0x61, 0x72, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x5f, 0x64,
+	0x61, 0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x6f, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x22, 0xe7, 0x06, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29,
+	0x0a, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65,
+	0x64, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x75, 0x73, 0x65, 0x64, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x43, 0x61, 0x6c, 0x6c, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f,
+	0x70, 0x6e, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x61, 0x69, 0x6c, 0x79,
+	0x50, 0x6e, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x6e, 0x6c,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x6e, 0x6c,
+	0x12, 0x2e, 0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x31, 0x0a, 0x0b, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73,
+	0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x15, 0x0a, 0x06, 0x76, 0x61, 0x72, 0x5f, 0x39, 0x35, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x72, 0x39, 0x35, 0x12, 0x15, 0x0a, 0x06, 0x76, 0x61, 0x72, 0x5f,
+	0x39, 0x39, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x72, 0x39, 0x39, 0x12,
+	0x10, 0x0a, 0x03, 0x76, 0x61, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x76, 0x61,
+	0x72, 0x12, 0x2d, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x66, 0x61, 0x6c, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x66, 0x61, 0x6c, 0x6c,
+	0x12, 0x2e, 0x0a, 0x0a, 0x76, 0x61, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x56, 0x61, 0x52, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x09, 0x76, 0x61, 0x72, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x76, 0x61, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x76, 0x61, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x76, 0x61, 0x72, 0x5f, 0x68,
+	0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x76, 0x61, 0x72, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x44, 0x61, 0x79,
+	0x73, 0x12, 0x2b, 0x0a, 0x06, 0x67, 0x72, 0x65, 0x65, 0x6b, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x6b, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x52, 0x06, 0x67, 0x72, 0x65, 0x65, 0x6b, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x6e, 0x65, 0x74, 0x5f, 0x64, 0x6f, 0x6c, 0x6c, 0x61, 0x72, 0x5f, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x44, 0x6f, 0x6c,
+	0x6c, 0x61, 0x72, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x74, 0x5f,
+	0x74, 0x68, 0x65, 0x74, 0x61, 0x18, 0x16, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6e, 0x65, 0x74,
+	0x54, 0x68, 0x65, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x67,
+	0x61, 0x18, 0x17, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x56, 0x65, 0x67, 0x61,
+	0x22, 0xa8, 0x01, 0x0a, 0x0d, 0x47, 0x72, 0x65, 0x65, 0x6b, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69,
+	0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x6c, 0x6c,
+	0x61, 0x72, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x64, 0x6f, 0x6c, 0x6c, 0x61, 0x72, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x67,
+	0x61, 0x6d, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x67, 0x61, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x76, 0x65, 0x67, 0x61, 0x22, 0x4c, 0x0a, 0x13, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0xa4, 0x03, 0x0a, 0x14, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x70,
+	0x6e, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x64, 0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72,
+	0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x4d, 0x61, 0x72, 0x67, 0x69,
+	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x12, 0x2e, 0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x22, 0xc5, 0x01, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x04,
+	0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73,
+	0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64,
+	0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xcd, 0x03, 0x0a, 0x11, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x34, 0x0a, 0x16, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x2e,
+	0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x09, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x29, 0x0a,
+	0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xc9, 0x01, 0x0a, 0x14, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x23, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x72, 0x69,
+	0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x15, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0c, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x69, 0x73,
+	0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x0b, 0x72, 0x69, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x22, 0x6b, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x69,
+	0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x6c, 0x0a,
+	0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x69, 0x73, 0x6b,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x0a, 0x72, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xd0, 0x02, 0x0a, 0x0a,
+	0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61,
+	0x78, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x6f, 0x73,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x44, 0x61, 0x69, 0x6c,
+	0x79, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d,
+	0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d,
+	0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f,
+	0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x69,
+	0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x95,
+	0x04, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e,
+	0x74, 0x72, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e,
+	0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x70, 0x6e, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e,
+	0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x70, 0x6e,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x50, 0x6e, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x76, 0x61, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x61, 0x72, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x56, 0x61, 0x72, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x45,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x68,
+	0x65, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x67, 0x61, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x76, 0x65, 0x67, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6d, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x11, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c,
+	0x79, 0x69, 0x6e, 0x67, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x6e, 0x64, 0x65,
+	0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x09, 0x73,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x22, 0x9b, 0x05, 0x0a, 0x0e, 0x53, 0x74, 0x72,
+	0x65, 0x73, 0x73, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x48, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x68, 0x6f,
+	0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x69, 0x73, 0x6b,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x2e,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x57, 0x0a,
+	0x11, 0x76, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x68, 0x6f, 0x63,
+	0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x2e, 0x56,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x76, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x3f, 0x0a, 0x09, 0x66, 0x78, 0x5f, 0x73, 0x68, 0x6f,
+	0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x72, 0x69, 0x73, 0x6b,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x2e,
+	0x46, 0x78, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x66,
+	0x78, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x72, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x68, 0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x10, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x68, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61,
+	0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x65, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x61,
+	0x79, 0x45, 0x6e, 0x64, 0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x68, 0x6f,
+	0x63, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x56, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x53, 0x68, 0x6f, 0x63, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x46, 0x78, 0x53,
+	0x68, 0x6f, 0x63, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x42, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73,
+	0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xbd, 0x04, 0x0a, 0x0c, 0x53,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72,
+	0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6e, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x70, 0x6e, 0x6c, 0x12, 0x4a, 0x0a, 0x0e, 0x70,
+	0x6e, 0x6c, 0x5f, 0x62, 0x79, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x70, 0x6e, 0x6c, 0x42, 0x79,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x0d, 0x70, 0x6e, 0x6c, 0x5f, 0x62,
+	0x79, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x2e, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0b, 0x70, 0x6e, 0x6c, 0x42, 0x79, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x12, 0x54, 0x0a, 0x12, 0x70, 0x6e, 0x6c, 0x5f, 0x62, 0x79, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x72,
+	0x69, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x70, 0x6e, 0x6c, 0x42, 0x79, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x69, 0x73, 0x6b,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6e, 0x4c, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a, 0x14, 0x50, 0x6e, 0x6c, 0x42, 0x79, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb2, 0x01, 0x0a, 0x09, 0x53,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6e, 0x4c, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d,
+	0x73, 0x74, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x70, 0x6e, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x70, 0x6e, 0x6c, 0x2a,
+	0x64, 0x0a, 0x09, 0x56, 0x61, 0x52, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x16,
+	0x56, 0x41, 0x52, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x56, 0x41, 0x52, 0x5f,
+	0x50, 0x41, 0x52, 0x41, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
+	0x56, 0x41, 0x52, 0x5f, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x52, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x56, 0x41, 0x52, 0x5f, 0x4d, 0x4f, 0x4e, 0x54, 0x45, 0x5f, 0x43, 0x41,
+	0x52, 0x4c, 0x4f, 0x10, 0x03, 0x2a, 0x38, 0x0a, 0x09, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d,
+	0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10,
+	0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x03, 0x2a,
+	0x1e, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x12, 0x07, 0x0a, 0x03,
+	0x42, 0x55, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x45, 0x4c, 0x4c, 0x10, 0x01, 0x2a,
+	0x3c, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06,
+	0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02, 0x12, 0x0e, 0x0a,
+	0x0a, 0x53, 0x54, 0x4f, 0x50, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x32, 0xbd, 0x03,
+	0x0a, 0x0b, 0x52, 0x69, 0x73, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x12,
+	0x18, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69,
+	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x69, 0x73, 0x6b,
+	0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x19, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f,
+	0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x16,
+	0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x1a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72,
+	0x69, 0x73, 0x6b, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x2e,
+	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72,
+	0x69, 0x73, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a,
+	0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x72, 0x69, 0x73,
+	0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2c, 0x5a,
+	0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x62, 0x64, 0x6f,
+	0x45, 0x6c, 0x48, 0x6f, 0x64, 0x61, 0x6b, 0x79, 0x2f, 0x74, 0x72, 0x61, 0x64, 0x53, 0x79, 0x73,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x69, 0x73, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_risk_risk_proto_rawDescOnce sync.Once
+	file_proto_risk_risk_proto_rawDescData = file_proto_risk_risk_proto_rawDesc
+)
 
 func file_proto_risk_risk_proto_rawDescGZIP() []byte {
 	file_proto_risk_risk_proto_rawDescOnce.Do(func() {
@@ -1537,55 +2517,82 @@ func file_proto_risk_risk_proto_rawDescGZIP() []byte {
 	return file_proto_risk_risk_proto_rawDescData
 }
 
-var file_proto_risk_risk_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_risk_risk_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_risk_risk_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_risk_risk_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_risk_risk_proto_goTypes = []interface{}{
-	(RiskLevel)(0),                   // 0: risk.RiskLevel
-	(OrderSide)(0),                   // 1: risk.OrderSide
-	(OrderType)(0),                   // 2: risk.OrderType
-	(*AccountRiskRequest)(nil),       // 3: risk.AccountRiskRequest
-	(*AccountRiskResponse)(nil),      // 4: risk.AccountRiskResponse
-	(*PositionRiskRequest)(nil),      // 5: risk.PositionRiskRequest
-	(*PositionRiskResponse)(nil),     // 6: risk.PositionRiskResponse
-	(*OrderRiskRequest)(nil),         // 7: risk.OrderRiskRequest
-	(*OrderRiskResponse)(nil),        // 8: risk.OrderRiskResponse
-	(*ValidateOrderRequest)(nil),     // 9: risk.ValidateOrderRequest
-	(*ValidateOrderResponse)(nil),    // 10: risk.ValidateOrderResponse
-	(*UpdateRiskLimitsRequest)(nil),  // 11: risk.UpdateRiskLimitsRequest
-	(*UpdateRiskLimitsResponse)(nil), // 12: risk.UpdateRiskLimitsResponse
-	(*RiskLimits)(nil),               // 13: risk.RiskLimits
-	(*Position)(nil),                 // 14: risk.Position
+	(VaRMethod)(0),                   // 0: risk.VaRMethod
+	(RiskLevel)(0),                   // 1: risk.RiskLevel
+	(OrderSide)(0),                   // 2: risk.OrderSide
+	(OrderType)(0),                   // 3: risk.OrderType
+	(*AccountRiskRequest)(nil),       // 4: risk.AccountRiskRequest
+	(*AccountRiskResponse)(nil),      // 5: risk.AccountRiskResponse
+	(*GreekExposure)(nil),            // 6: risk.GreekExposure
+	(*PositionRiskRequest)(nil),      // 7: risk.PositionRiskRequest
+	(*PositionRiskResponse)(nil),     // 8: risk.PositionRiskResponse
+	(*OrderRiskRequest)(nil),         // 9: risk.OrderRiskRequest
+	(*OrderRiskResponse)(nil),        // 10: risk.OrderRiskResponse
+	(*ValidateOrderRequest)(nil),     // 11: risk.ValidateOrderRequest
+	(*ValidateOrderResponse)(nil),    // 12: risk.ValidateOrderResponse
+	(*UpdateRiskLimitsRequest)(nil),  // 13: risk.UpdateRiskLimitsRequest
+	(*UpdateRiskLimitsResponse)(nil), // 14: risk.UpdateRiskLimitsResponse
+	(*RiskLimits)(nil),               // 15: risk.RiskLimits
+	(*Position)(nil),                 // 16: risk.Position
+	(*StressTestRequest)(nil),        // 17: risk.StressTestRequest
+	(*StressScenario)(nil),           // 18: risk.StressScenario
+	(*StressTestResponse)(nil),       // 19: risk.StressTestResponse
+	(*StressResult)(nil),             // 20: risk.StressResult
+	(*StressPnL)(nil),                // 21: risk.StressPnL
+	nil,                              // 22: risk.StressScenario.PriceShocksEntry
+	nil,                              // 23: risk.StressScenario.VolatilityShocksEntry
+	nil,                              // 24: risk.StressScenario.FxShocksEntry
+	nil,                              // 25: risk.StressResult.PnlByAccountEntry
+	nil,                              // 26: risk.StressResult.PnlBySymbolEntry
+	nil,                              // 27: risk.StressResult.PnlByAssetClassEntry
 }
 var file_proto_risk_risk_proto_depIdxs = []int32{
-	0,  // 0: risk.AccountRiskResponse.risk_level:type_name -> risk.RiskLevel
-	13, // 1: risk.AccountRiskResponse.risk_limits:type_name -> risk.RiskLimits
-	14, // 2: risk.AccountRiskResponse.positions:type_name -> risk.Position
-	0,  // 3: risk.PositionRiskResponse.risk_level:type_name -> risk.RiskLevel
-	1,  // 4: risk.OrderRiskRequest.side:type_name -> risk.OrderSide
-	2,  // 5: risk.OrderRiskRequest.type:type_name -> risk.OrderType
-	1,  // 6: risk.OrderRiskResponse.side:type_name -> risk.OrderSide
-	2,  // 7: risk.OrderRiskResponse.type:type_name -> risk.OrderType
-	0,  // 8: risk.OrderRiskResponse.risk_level:type_name -> risk.RiskLevel
-	1,  // 9: risk.ValidateOrderRequest.side:type_name -> risk.OrderSide
-	2,  // 10: risk.ValidateOrderRequest.type:type_name -> risk.OrderType
-	8,  // 11: risk.ValidateOrderResponse.risk_metrics:type_name -> risk.OrderRiskResponse
-	13, // 12: risk.UpdateRiskLimitsRequest.risk_limits:type_name -> risk.RiskLimits
-	13, // 13: risk.UpdateRiskLimitsResponse.risk_limits:type_name -> risk.RiskLimits
-	3,  // 14: risk.RiskService.GetAccountRisk:input_type -> risk.AccountRiskRequest
-	5,  // 15: risk.RiskService.GetPositionRisk:input_type -> risk.PositionRiskRequest
-	7,  // 16: risk.RiskService.GetOrderRisk:input_type -> risk.OrderRiskRequest
-	9,  // 17: risk.RiskService.ValidateOrder:input_type -> risk.ValidateOrderRequest
-	11, // 18: risk.RiskService.UpdateRiskLimits:input_type -> risk.UpdateRiskLimitsRequest
-	4,  // 19: risk.RiskService.GetAccountRisk:output_type -> risk.AccountRiskResponse
-	6,  // 20: risk.RiskService.GetPositionRisk:output_type -> risk.PositionRiskResponse
-	8,  // 21: risk.RiskService.GetOrderRisk:output_type -> risk.OrderRiskResponse
-	10, // 22: risk.RiskService.ValidateOrder:output_type -> risk.ValidateOrderResponse
-	12, // 23: risk.RiskService.UpdateRiskLimits:output_type -> risk.UpdateRiskLimitsResponse
-	19, // [19:24] is the sub-list for method output_type
-	14, // [14:19] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	0,  // 0: risk.AccountRiskRequest.var_method:type_name -> risk.VaRMethod
+	1,  // 1: risk.AccountRiskResponse.risk_level:type_name -> risk.RiskLevel
+	15, // 2: risk.AccountRiskResponse.risk_limits:type_name -> risk.RiskLimits
+	16, // 3: risk.AccountRiskResponse.positions:type_name -> risk.Position
+	0,  // 4: risk.AccountRiskResponse.var_method:type_name -> risk.VaRMethod
+	6,  // 5: risk.AccountRiskResponse.greeks:type_name -> risk.GreekExposure
+	1,  // 6: risk.PositionRiskResponse.risk_level:type_name -> risk.RiskLevel
+	2,  // 7: risk.OrderRiskRequest.side:type_name -> risk.OrderSide
+	3,  // 8: risk.OrderRiskRequest.type:type_name -> risk.OrderType
+	2,  // 9: risk.OrderRiskResponse.side:type_name -> risk.OrderSide
+	3,  // 10: risk.OrderRiskResponse.type:type_name -> risk.OrderType
+	1,  // 11: risk.OrderRiskResponse.risk_level:type_name -> risk.RiskLevel
+	2,  // 12: risk.ValidateOrderRequest.side:type_name -> risk.OrderSide
+	3,  // 13: risk.ValidateOrderRequest.type:type_name -> risk.OrderType
+	10, // 14: risk.ValidateOrderResponse.risk_metrics:type_name -> risk.OrderRiskResponse
+	15, // 15: risk.UpdateRiskLimitsRequest.risk_limits:type_name -> risk.RiskLimits
+	15, // 16: risk.UpdateRiskLimitsResponse.risk_limits:type_name -> risk.RiskLimits
+	18, // 17: risk.StressTestRequest.scenarios:type_name -> risk.StressScenario
+	22, // 18: risk.StressScenario.price_shocks:type_name -> risk.StressScenario.PriceShocksEntry
+	23, // 19: risk.StressScenario.volatility_shocks:type_name -> risk.StressScenario.VolatilityShocksEntry
+	24, // 20: risk.StressScenario.fx_shocks:type_name -> risk.StressScenario.FxShocksEntry
+	20, // 21: risk.StressTestResponse.results:type_name -> risk.StressResult
+	25, // 22: risk.StressResult.pnl_by_account:type_name -> risk.StressResult.PnlByAccountEntry
+	26, // 23: risk.StressResult.pnl_by_symbol:type_name -> risk.StressResult.PnlBySymbolEntry
+	27, // 24: risk.StressResult.pnl_by_asset_class:type_name -> risk.StressResult.PnlByAssetClassEntry
+	21, // 25: risk.StressResult.positions:type_name -> risk.StressPnL
+	4,  // 26: risk.RiskService.GetAccountRisk:input_type -> risk.AccountRiskRequest
+	7,  // 27: risk.RiskService.GetPositionRisk:input_type -> risk.PositionRiskRequest
+	9,  // 28: risk.RiskService.GetOrderRisk:input_type -> risk.OrderRiskRequest
+	11, // 29: risk.RiskService.ValidateOrder:input_type -> risk.ValidateOrderRequest
+	13, // 30: risk.RiskService.UpdateRiskLimits:input_type -> risk.UpdateRiskLimitsRequest
+	17, // 31: risk.RiskService.StressTest:input_type -> risk.StressTestRequest
+	5,  // 32: risk.RiskService.GetAccountRisk:output_type -> risk.AccountRiskResponse
+	8,  // 33: risk.RiskService.GetPositionRisk:output_type -> risk.PositionRiskResponse
+	10, // 34: risk.RiskService.GetOrderRisk:output_type -> risk.OrderRiskResponse
+	12, // 35: risk.RiskService.ValidateOrder:output_type -> risk.ValidateOrderResponse
+	14, // 36: risk.RiskService.UpdateRiskLimits:output_type -> risk.UpdateRiskLimitsResponse
+	19, // 37: risk.RiskService.StressTest:output_type -> risk.StressTestResponse
+	32, // [32:38] is the sub-list for method output_type
+	26, // [26:32] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_risk_risk_proto_init() }
@@ -1619,7 +2626,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PositionRiskRequest); i {
+			switch v := v.(*GreekExposure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1631,7 +2638,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PositionRiskResponse); i {
+			switch v := v.(*PositionRiskRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1643,7 +2650,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderRiskRequest); i {
+			switch v := v.(*PositionRiskResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1655,7 +2662,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderRiskResponse); i {
+			switch v := v.(*OrderRiskRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1667,7 +2674,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateOrderRequest); i {
+			switch v := v.(*OrderRiskResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1679,7 +2686,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateOrderResponse); i {
+			switch v := v.(*ValidateOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1691,7 +2698,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRiskLimitsRequest); i {
+			switch v := v.(*ValidateOrderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1703,7 +2710,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRiskLimitsResponse); i {
+			switch v := v.(*UpdateRiskLimitsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1715,7 +2722,7 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RiskLimits); i {
+			switch v := v.(*UpdateRiskLimitsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1727,6 +2734,18 @@ func file_proto_risk_risk_proto_init() {
 			}
 		}
 		file_proto_risk_risk_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RiskLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_risk_risk_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Position); i {
 			case 0:
 				return &v.state
@@ -1738,14 +2757,74 @@ func file_proto_risk_risk_proto_init() {
 				return nil
 			}
 		}
+		file_proto_risk_risk_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StressTestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_risk_risk_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StressScenario); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_risk_risk_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StressTestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_risk_risk_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StressResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_risk_risk_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StressPnL); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_risk_risk_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   12,
+			NumEnums:      4,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	RiskService_GetOrderRisk_FullMethodName     = "/risk.RiskService/GetOrderRisk"
 	RiskService_ValidateOrder_FullMethodName    = "/risk.RiskService/ValidateOrder"
 	RiskService_UpdateRiskLimits_FullMethodName = "/risk.RiskService/UpdateRiskLimits"
+	RiskService_StressTest_FullMethodName       = "/risk.RiskService/StressTest"
 )
 
 // RiskServiceClient is the client API for RiskService service.
@@ -42,6 +43,8 @@ type RiskServiceClient interface {
 	ValidateOrder(ctx context.Context, in *ValidateOrderRequest, opts ...grpc.CallOption) (*ValidateOrderResponse, error)
 	// UpdateRiskLimits updates risk limits for an account
 	UpdateRiskLimits(ctx context.Context, in *UpdateRiskLimitsRequest, opts ...grpc.CallOption) (*UpdateRiskLimitsResponse, error)
+	// StressTest revalues live positions under stress scenarios
+	StressTest(ctx context.Context, in *StressTestRequest, opts ...grpc.CallOption) (*StressTestResponse, error)
 }
 
 type riskServiceClient struct {
@@ -102,6 +105,16 @@ func (c *riskServiceClient) UpdateRiskLimits(ctx context.Context, in *UpdateRisk
 	return out, nil
 }
 
+func (c *riskServiceClient) StressTest(ctx context.Context, in *StressTestRequest, opts ...grpc.CallOption) (*StressTestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StressTestResponse)
+	err := c.cc.Invoke(ctx, RiskService_StressTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RiskServiceServer is the server API for RiskService service.
 // All implementations must embed UnimplementedRiskServiceServer
 // for forward compatibility.
@@ -118,6 +131,8 @@ type RiskServiceServer interface {
 	ValidateOrder(context.Context, *ValidateOrderRequest) (*ValidateOrderResponse, error)
 	// UpdateRiskLimits updates risk limits for an account
 	UpdateRiskLimits(context.Context, *UpdateRiskLimitsRequest) (*UpdateRiskLimitsResponse, error)
+	// StressTest revalues live positions under stress scenarios
+	StressTest(context.Context, *StressTestRequest) (*StressTestResponse, error)
 	mustEmbedUnimplementedRiskServiceServer()
 }
 
@@ -143,6 +158,9 @@ func (UnimplementedRiskServiceServer) ValidateOrder(context.Context, *ValidateOr
 func (UnimplementedRiskServiceServer) UpdateRiskLimits(context.Context, *UpdateRiskLimitsRequest) (*UpdateRiskLimitsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateRiskLimits not implemented")
 }
+func (UnimplementedRiskServiceServer) StressTest(context.Context, *StressTestRequest) (*StressTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StressTest not implemented")
+}
 func (UnimplementedRiskServiceServer) mustEmbedUnimplementedRiskServiceServer() {}
 func (UnimplementedRiskServiceServer) testEmbeddedByValue()                     {}
 
@@ -254,6 +272,24 @@ func _RiskService_UpdateRiskLimits_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RiskService_StressTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StressTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskServiceServer).StressTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskService_StressTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskServiceServer).StressTest(ctx, req.(*StressTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RiskService_ServiceDesc is the grpc.ServiceDesc for RiskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +317,10 @@ var RiskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateRiskLimits",
 			Handler:    _RiskService_UpdateRiskLimits_Handler,
 		},
+		{
+			MethodName: "StressTest",
+			Handler:    _RiskService_StressTest_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/risk/risk.proto",
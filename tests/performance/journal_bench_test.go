@@ -0,0 +1,115 @@
+package performance
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"go.uber.org/zap"
+)
+
+// journalOrder returns the i-th order of a flow that alternately rests and
+// trades on AAPL
+func journalOrder(i int) *matching.Order {
+	side := matching.OrderSideBuy
+	if i%2 == 1 {
+		side = matching.OrderSideSell
+	}
+	return &matching.Order{
+		ID:          fmt.Sprintf("order-%d", i),
+		UserID:      fmt.Sprintf("user-%d", i%10),
+		Symbol:      "AAPL",
+		Side:        side,
+		Type:        matching.OrderTypeLimit,
+		Price:       types.NewDecimal(int64(15000+i%50), 2),
+		Quantity:    types.NewDecimal(100, 0),
+		TimeInForce: matching.TimeInForceGTC,
+	}
+}
+
+// BenchmarkFileJournal_Append measures the latency of one checksummed
+// journal record, with and without an fsync per record
+func BenchmarkFileJournal_Append(b *testing.B) {
+	for _, sync := range []bool{false, true} {
+		b.Run(fmt.Sprintf("sync=%t", sync), func(b *testing.B) {
+			journal, err := matching.OpenFileJournal(filepath.Join(b.TempDir(), matching.JournalFile), sync)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer journal.Close()
+
+			order := journalOrder(0)
+			entry := &matching.JournalEntry{
+				Command: matching.Command{Type: matching.CommandNewOrder, Symbol: "AAPL", Order: order},
+				Order:   order,
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				entry.Command.Sequence = uint64(i + 1)
+				entry.Command.Timestamp = time.Now()
+				if err := journal.Append(entry); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSequencer_AddOrderJournaled measures an order's round trip
+// through the sequencer, matching and the journal file
+func BenchmarkSequencer_AddOrderJournaled(b *testing.B) {
+	sequencer, err := matching.OpenSequencer(zap.NewNop(), 4, b.TempDir(), false)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer sequencer.Stop()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := sequencer.AddOrder(journalOrder(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSequencer_Recover measures rebuilding the order books from a
+// snapshot and a journal tail of the given length
+func BenchmarkSequencer_Recover(b *testing.B) {
+	for _, tail := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("tail=%d", tail), func(b *testing.B) {
+			dir := b.TempDir()
+			sequencer, err := matching.OpenSequencer(zap.NewNop(), 4, dir, false)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for i := 0; i < 1000; i++ {
+				sequencer.AddOrder(journalOrder(i))
+			}
+			if err := matching.WriteSnapshot(filepath.Join(dir, matching.SnapshotFile), sequencer.Snapshot()); err != nil {
+				b.Fatal(err)
+			}
+			for i := 1000; i < 1000+tail; i++ {
+				sequencer.AddOrder(journalOrder(i))
+			}
+			sequencer.Stop()
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				recovered, err := matching.OpenSequencer(zap.NewNop(), 4, dir, false)
+				if err != nil {
+					b.Fatal(err)
+				}
+				recovered.Stop()
+			}
+		})
+	}
+}
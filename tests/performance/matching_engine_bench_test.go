@@ -1,48 +1,49 @@
 package performance
 
 import (
-	"context"
 	"fmt"
 	"runtime"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/abdoElHodaky/tradSys/pkg/matching"
-	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
+// benchOrder returns a 100 share AAPL order priced in cents; market orders
+// are left unpriced and fill immediately or not at all
+func benchOrder(id, user string, side matching.OrderSide, orderType matching.OrderType, cents int64) *matching.Order {
+	order := &matching.Order{
+		ID:          id,
+		UserID:      user,
+		Symbol:      "AAPL",
+		Side:        side,
+		Type:        orderType,
+		Quantity:    types.NewDecimal(100, 0),
+		TimeInForce: matching.TimeInForceGTC,
+		CreatedAt:   time.Now(),
+	}
+	if orderType == matching.OrderTypeMarket {
+		order.TimeInForce = matching.TimeInForceIOC
+	} else {
+		order.Price = types.NewDecimal(cents, 2)
+	}
+	return order
+}
+
 // BenchmarkMatchingEngine_SingleThreaded tests single-threaded performance
 func BenchmarkMatchingEngine_SingleThreaded(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000, // 100μs target
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false, // Disable for consistent benchmarking
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		order := &matching.Order{
-			ID:       fmt.Sprintf("order-%d", i),
-			UserID:   "user-001",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("order-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i%1000))
 
-		_, err := engine.ProcessOrder(ctx, order)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -50,31 +51,13 @@ func BenchmarkMatchingEngine_SingleThreaded(b *testing.B) {
 
 // BenchmarkMatchingEngine_WithMatching tests performance with actual matching
 func BenchmarkMatchingEngine_WithMatching(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
 	// Pre-populate with buy orders
 	for i := 0; i < 1000; i++ {
-		buyOrder := &matching.Order{
-			ID:       fmt.Sprintf("buy-%d", i),
-			UserID:   "user-buy",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
+		if _, err := engine.AddOrder(benchOrder(fmt.Sprintf("buy-%d", i), "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i))); err != nil {
+			b.Fatal(err)
 		}
-		engine.ProcessOrder(ctx, buyOrder)
 	}
 
 	b.ResetTimer()
@@ -82,20 +65,9 @@ func BenchmarkMatchingEngine_WithMatching(b *testing.B) {
 
 	// Benchmark sell orders that will match
 	for i := 0; i < b.N; i++ {
-		sellOrder := &matching.Order{
-			ID:       fmt.Sprintf("sell-%d", i),
-			UserID:   "user-sell",
-			Symbol:   "AAPL",
-			Side:     matching.SideSell,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("sell-%d", i), "user-sell", matching.OrderSideSell, matching.OrderTypeLimit, int64(15000+i%1000))
 
-		_, err := engine.ProcessOrder(ctx, sellOrder)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -103,31 +75,13 @@ func BenchmarkMatchingEngine_WithMatching(b *testing.B) {
 
 // BenchmarkMatchingEngine_MarketOrders tests market order performance
 func BenchmarkMatchingEngine_MarketOrders(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
 	// Pre-populate with sell orders at different prices
 	for i := 0; i < 1000; i++ {
-		sellOrder := &matching.Order{
-			ID:       fmt.Sprintf("sell-%d", i),
-			UserID:   "user-sell",
-			Symbol:   "AAPL",
-			Side:     matching.SideSell,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
+		if _, err := engine.AddOrder(benchOrder(fmt.Sprintf("sell-%d", i), "user-sell", matching.OrderSideSell, matching.OrderTypeLimit, int64(15000+i))); err != nil {
+			b.Fatal(err)
 		}
-		engine.ProcessOrder(ctx, sellOrder)
 	}
 
 	b.ResetTimer()
@@ -135,19 +89,9 @@ func BenchmarkMatchingEngine_MarketOrders(b *testing.B) {
 
 	// Benchmark market buy orders
 	for i := 0; i < b.N; i++ {
-		marketOrder := &matching.Order{
-			ID:       fmt.Sprintf("market-%d", i),
-			UserID:   "user-market",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeMarket,
-			Quantity: 100,
-			TimeInForce: matching.TimeInForceIOC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("market-%d", i), "user-market", matching.OrderSideBuy, matching.OrderTypeMarket, 0)
 
-		_, err := engine.ProcessOrder(ctx, marketOrder)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -155,90 +99,59 @@ func BenchmarkMatchingEngine_MarketOrders(b *testing.B) {
 
 // BenchmarkMatchingEngine_Concurrent tests concurrent order processing
 func BenchmarkMatchingEngine_Concurrent(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
-	ctx := context.Background()
-	numGoroutines := runtime.NumCPU()
+	var workers sync.WaitGroup
+	var nextWorker int64
+	var mu sync.Mutex
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	b.RunParallel(func(pb *testing.PB) {
+		workers.Add(1)
+		defer workers.Done()
+
+		mu.Lock()
+		worker := nextWorker
+		nextWorker++
+		mu.Unlock()
+
 		orderID := 0
 		for pb.Next() {
-			order := &matching.Order{
-				ID:       fmt.Sprintf("concurrent-%d-%d", runtime.NumGoroutine(), orderID),
-				UserID:   fmt.Sprintf("user-%d", runtime.NumGoroutine()),
-				Symbol:   "AAPL",
-				Side:     matching.SideBuy,
-				Type:     matching.TypeLimit,
-				Quantity: 100,
-				Price:    150.00 + float64(orderID%1000)*0.01,
-				TimeInForce: matching.TimeInForceGTC,
-				Timestamp:   time.Now(),
-			}
+			order := benchOrder(fmt.Sprintf("concurrent-%d-%d", worker, orderID), fmt.Sprintf("user-%d", worker), matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+orderID%1000))
 
-			_, err := engine.ProcessOrder(ctx, order)
-			if err != nil {
-				b.Fatal(err)
+			if _, err := engine.AddOrder(order); err != nil {
+				b.Error(err)
+				return
 			}
 			orderID++
 		}
 	})
 
-	b.Logf("Concurrent benchmark with %d goroutines", numGoroutines)
+	workers.Wait()
+	b.Logf("Concurrent benchmark with %d goroutines", nextWorker)
 }
 
 // BenchmarkMatchingEngine_HighVolume tests high-volume scenarios
 func BenchmarkMatchingEngine_HighVolume(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  1000000, // 1M orders/sec target
-		OrderBookDepth:   10000,   // Larger order book
-		EnableHFTMode:    true,
-		NUMAOptimization: true, // Enable for high volume
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	// Test with alternating buy/sell orders to generate matches
 	for i := 0; i < b.N; i++ {
-		var side matching.Side
-		var price float64
-
-		if i%2 == 0 {
-			side = matching.SideBuy
-			price = 150.00 + float64(i%100)*0.01
-		} else {
-			side = matching.SideSell
-			price = 150.00 + float64((i-1)%100)*0.01 // Match with previous buy
+		side := matching.OrderSideBuy
+		cents := int64(15000 + i%100)
+		if i%2 == 1 {
+			side = matching.OrderSideSell
+			cents = int64(15000 + (i-1)%100) // Match with previous buy
 		}
 
-		order := &matching.Order{
-			ID:       fmt.Sprintf("hv-%d", i),
-			UserID:   fmt.Sprintf("user-%d", i%1000),
-			Symbol:   "AAPL",
-			Side:     side,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    price,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("hv-%d", i), fmt.Sprintf("user-%d", i%1000), side, matching.OrderTypeLimit, cents)
 
-		_, err := engine.ProcessOrder(ctx, order)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -246,35 +159,16 @@ func BenchmarkMatchingEngine_HighVolume(b *testing.B) {
 
 // BenchmarkMatchingEngine_Latency measures order processing latency
 func BenchmarkMatchingEngine_Latency(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000, // 100μs target
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 	latencies := make([]time.Duration, b.N)
 
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		order := &matching.Order{
-			ID:       fmt.Sprintf("latency-%d", i),
-			UserID:   "user-001",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("latency-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i%1000))
 
 		start := time.Now()
-		_, err := engine.ProcessOrder(ctx, order)
+		_, err := engine.AddOrder(order)
 		latency := time.Since(start)
 
 		if err != nil {
@@ -317,36 +211,16 @@ func BenchmarkMatchingEngine_Latency(b *testing.B) {
 
 // BenchmarkMatchingEngine_Throughput measures order throughput
 func BenchmarkMatchingEngine_Throughput(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 	orderCount := 100000 // Test with 100k orders
 
 	b.ResetTimer()
 	start := time.Now()
 
 	for i := 0; i < orderCount; i++ {
-		order := &matching.Order{
-			ID:       fmt.Sprintf("throughput-%d", i),
-			UserID:   "user-001",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("throughput-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i%1000))
 
-		_, err := engine.ProcessOrder(ctx, order)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -366,16 +240,7 @@ func BenchmarkMatchingEngine_Throughput(b *testing.B) {
 
 // BenchmarkMatchingEngine_MemoryUsage tests memory efficiency
 func BenchmarkMatchingEngine_MemoryUsage(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 
 	// Force garbage collection before starting
 	runtime.GC()
@@ -386,20 +251,9 @@ func BenchmarkMatchingEngine_MemoryUsage(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		order := &matching.Order{
-			ID:       fmt.Sprintf("memory-%d", i),
-			UserID:   "user-001",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := benchOrder(fmt.Sprintf("memory-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i%1000))
 
-		_, err := engine.ProcessOrder(ctx, order)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -411,7 +265,7 @@ func BenchmarkMatchingEngine_MemoryUsage(b *testing.B) {
 	var m2 runtime.MemStats
 	runtime.ReadMemStats(&m2)
 
-	memoryUsed := m2.Alloc - m1.Alloc
+	memoryUsed := int64(m2.Alloc) - int64(m1.Alloc)
 	memoryPerOrder := float64(memoryUsed) / float64(b.N)
 
 	b.Logf("Memory Usage:")
@@ -422,16 +276,7 @@ func BenchmarkMatchingEngine_MemoryUsage(b *testing.B) {
 
 // BenchmarkMatchingEngine_ConcurrentMatching tests concurrent matching performance
 func BenchmarkMatchingEngine_ConcurrentMatching(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false,
-	})
-
-	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
 	numWorkers := runtime.NumCPU()
 	ordersPerWorker := b.N / numWorkers
 
@@ -447,27 +292,14 @@ func BenchmarkMatchingEngine_ConcurrentMatching(b *testing.B) {
 			defer wg.Done()
 
 			for i := 0; i < ordersPerWorker; i++ {
-				var side matching.Side
-				if (workerID+i)%2 == 0 {
-					side = matching.SideBuy
-				} else {
-					side = matching.SideSell
+				side := matching.OrderSideBuy
+				if (workerID+i)%2 == 1 {
+					side = matching.OrderSideSell
 				}
 
-				order := &matching.Order{
-					ID:       fmt.Sprintf("worker-%d-%d", workerID, i),
-					UserID:   fmt.Sprintf("user-%d", workerID),
-					Symbol:   "AAPL",
-					Side:     side,
-					Type:     matching.TypeLimit,
-					Quantity: 100,
-					Price:    150.00 + float64(i%100)*0.01,
-					TimeInForce: matching.TimeInForceGTC,
-					Timestamp:   time.Now(),
-				}
+				order := benchOrder(fmt.Sprintf("worker-%d-%d", workerID, i), fmt.Sprintf("user-%d", workerID), side, matching.OrderTypeLimit, int64(15000+i%100))
 
-				_, err := engine.ProcessOrder(ctx, order)
-				if err != nil {
+				if _, err := engine.AddOrder(order); err != nil {
 					b.Error(err)
 					return
 				}
@@ -493,44 +325,16 @@ func BenchmarkMatchingEngine_OrderBookDepth(b *testing.B) {
 
 	for _, depth := range depths {
 		b.Run(fmt.Sprintf("Depth-%d", depth), func(b *testing.B) {
-			engine := matching.NewEngine(&matching.Config{
-				Symbol:           "AAPL",
-				LatencyTargetNS:  100000,
-				MaxOrdersPerSec:  100000,
-				OrderBookDepth:   depth,
-				EnableHFTMode:    true,
-				NUMAOptimization: false,
-			})
-
-			ctx := context.Background()
+			engine := matching.NewMatchingEngine(zap.NewNop())
 
 			// Pre-populate order book to specified depth
 			for i := 0; i < depth/2; i++ {
-				buyOrder := &matching.Order{
-					ID:       fmt.Sprintf("buy-depth-%d", i),
-					UserID:   "user-buy",
-					Symbol:   "AAPL",
-					Side:     matching.SideBuy,
-					Type:     matching.TypeLimit,
-					Quantity: 100,
-					Price:    150.00 - float64(i)*0.01,
-					TimeInForce: matching.TimeInForceGTC,
-					Timestamp:   time.Now(),
+				if _, err := engine.AddOrder(benchOrder(fmt.Sprintf("buy-depth-%d", i), "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, int64(14999-i))); err != nil {
+					b.Fatal(err)
 				}
-				engine.ProcessOrder(ctx, buyOrder)
-
-				sellOrder := &matching.Order{
-					ID:       fmt.Sprintf("sell-depth-%d", i),
-					UserID:   "user-sell",
-					Symbol:   "AAPL",
-					Side:     matching.SideSell,
-					Type:     matching.TypeLimit,
-					Quantity: 100,
-					Price:    150.00 + float64(i)*0.01,
-					TimeInForce: matching.TimeInForceGTC,
-					Timestamp:   time.Now(),
+				if _, err := engine.AddOrder(benchOrder(fmt.Sprintf("sell-depth-%d", i), "user-sell", matching.OrderSideSell, matching.OrderTypeLimit, int64(15001+i))); err != nil {
+					b.Fatal(err)
 				}
-				engine.ProcessOrder(ctx, sellOrder)
 			}
 
 			b.ResetTimer()
@@ -538,23 +342,78 @@ func BenchmarkMatchingEngine_OrderBookDepth(b *testing.B) {
 
 			// Benchmark order processing with populated book
 			for i := 0; i < b.N; i++ {
-				order := &matching.Order{
-					ID:       fmt.Sprintf("test-%d", i),
-					UserID:   "user-test",
-					Symbol:   "AAPL",
-					Side:     matching.SideBuy,
-					Type:     matching.TypeLimit,
-					Quantity: 100,
-					Price:    150.00 + float64(i%100)*0.01,
-					TimeInForce: matching.TimeInForceGTC,
-					Timestamp:   time.Now(),
+				order := benchOrder(fmt.Sprintf("test-%d", i), "user-test", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000+i%100))
+
+				if _, err := engine.AddOrder(order); err != nil {
+					b.Fatal(err)
 				}
+			}
+		})
+	}
+}
 
-				_, err := engine.ProcessOrder(ctx, order)
-				if err != nil {
+// BenchmarkOrderBook_CancelHeavy tests cancel/replace churn against deep
+// resting books, where every cancel has to locate its order by ID
+func BenchmarkOrderBook_CancelHeavy(b *testing.B) {
+	depths := []int{1000, 10000, 100000}
+
+	for _, depth := range depths {
+		b.Run(fmt.Sprintf("Depth-%d", depth), func(b *testing.B) {
+			book := matching.NewOrderBook("AAPL", zap.NewNop())
+
+			// Spread resting bids over 100 price levels
+			ids := make([]string, depth)
+			for i := 0; i < depth; i++ {
+				ids[i] = fmt.Sprintf("buy-%d", i)
+				if _, err := book.AddOrder(benchOrder(ids[i], "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000-i%100))); err != nil {
 					b.Fatal(err)
 				}
 			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			// Cancel an order from the middle of the book and replace it
+			for i := 0; i < b.N; i++ {
+				slot := (i * 7919) % depth
+				if !book.CancelOrder(ids[slot]) {
+					b.Fatalf("order %s not found", ids[slot])
+				}
+				ids[slot] = fmt.Sprintf("replace-%d", i)
+				if _, err := book.AddOrder(benchOrder(ids[slot], "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000-slot%100))); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkBookSide_CancelHeavy tests removing orders from the middle of a
+// deep book side, without the book's matching and bookkeeping around it
+func BenchmarkBookSide_CancelHeavy(b *testing.B) {
+	depths := []int{1000, 10000, 100000}
+
+	for _, depth := range depths {
+		b.Run(fmt.Sprintf("Depth-%d", depth), func(b *testing.B) {
+			side := matching.NewBookSide(matching.OrderSideBuy, false)
+
+			orders := make([]*matching.Order, depth)
+			for i := 0; i < depth; i++ {
+				orders[i] = benchOrder(fmt.Sprintf("buy-%d", i), "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, int64(15000-i%100))
+				side.Push(orders[i])
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			// Remove an order from the middle of its level and requeue it
+			for i := 0; i < b.N; i++ {
+				order := orders[(i*7919)%depth]
+				if side.Remove(order.ID) == nil {
+					b.Fatalf("order %s not found", order.ID)
+				}
+				side.Push(order)
+			}
 		})
 	}
 }
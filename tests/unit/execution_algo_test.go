@@ -0,0 +1,261 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/execution/algo"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/abdoElHodaky/tradSys/proto/marketdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExecutionAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	open := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	config := algo.DefaultConfig()
+	config.SliceInterval = time.Minute
+
+	t.Run("twap pause resume cancel", func(t *testing.T) {
+		venue := algo.NewSimulatedVenue()
+		service := algo.NewService(venue, nil, config, zap.NewNop())
+		service.OnTrade("AAPL", types.MustParseDecimal("100"), types.MustParseDecimal("1"), open)
+		venue.SetPrice("AAPL", types.MustParseDecimal("100"), open)
+
+		parent, err := service.Submit(ctx, &algo.ParentRequest{
+			UserID:    "alice",
+			Symbol:    "AAPL",
+			Side:      types.OrderSideBuy,
+			Quantity:  types.MustParseDecimal("100"),
+			Algorithm: algo.AlgorithmTWAP,
+			StartTime: open,
+			EndTime:   open.Add(10 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "100", parent.ArrivalPrice.String())
+
+		for minute := 1; minute <= 5; minute++ {
+			service.Step(ctx, open.Add(time.Duration(minute)*time.Minute))
+		}
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "50", parent.FilledQuantity.String())
+		assert.Len(t, parent.Children, 5)
+
+		_, err = service.Pause(ctx, parent.ID)
+		require.NoError(t, err)
+		service.Step(ctx, open.Add(6*time.Minute))
+
+		// Resuming catches up with the schedule in one slice
+		venue.SetPrice("AAPL", types.MustParseDecimal("101"), open.Add(7*time.Minute))
+		_, err = service.Resume(ctx, parent.ID)
+		require.NoError(t, err)
+		service.Step(ctx, open.Add(7*time.Minute))
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		require.Len(t, parent.Children, 6)
+		assert.Equal(t, "20", parent.Children[5].Quantity.String())
+		assert.Equal(t, "70", parent.FilledQuantity.String())
+		assert.InDelta(t, 28.57, parent.SlippageBps(), 0.01)
+
+		parent, err = service.Cancel(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, algo.ParentStatusCancelled, parent.Status)
+		service.Step(ctx, open.Add(8*time.Minute))
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "70", parent.FilledQuantity.String())
+
+		_, err = service.Cancel(ctx, parent.ID)
+		assert.Equal(t, algo.ErrParentClosed, err)
+	})
+
+	t.Run("vwap follows the volume curve", func(t *testing.T) {
+		venue := algo.NewSimulatedVenue()
+		venue.SetPrice("AAPL", types.MustParseDecimal("100"), open)
+		service := algo.NewService(venue, nil, config, zap.NewNop())
+
+		_, err := service.Submit(ctx, &algo.ParentRequest{
+			Symbol:    "AAPL",
+			Side:      types.OrderSideSell,
+			Quantity:  types.MustParseDecimal("100"),
+			Algorithm: algo.AlgorithmVWAP,
+			StartTime: open,
+			EndTime:   open.Add(2 * time.Hour),
+		})
+		assert.Equal(t, algo.ErrNoVolumeCurve, err)
+
+		curve := algo.NewVolumeCurve(time.Hour)
+		curve.AddVolume(open.AddDate(0, 0, -1), 300)
+		curve.AddVolume(open.AddDate(0, 0, -1).Add(time.Hour), 100)
+		parent, err := service.Submit(ctx, &algo.ParentRequest{
+			Symbol:      "AAPL",
+			Side:        types.OrderSideSell,
+			Quantity:    types.MustParseDecimal("100"),
+			Algorithm:   algo.AlgorithmVWAP,
+			StartTime:   open,
+			EndTime:     open.Add(2 * time.Hour),
+			VolumeCurve: curve,
+		})
+		require.NoError(t, err)
+
+		service.Step(ctx, open.Add(time.Hour))
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "75", parent.FilledQuantity.String())
+
+		service.Step(ctx, open.Add(2*time.Hour))
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, algo.ParentStatusCompleted, parent.Status)
+	})
+
+	t.Run("backtest replays market data", func(t *testing.T) {
+		// A print before the open sets the arrival price; the price steps up
+		// halfway through the window
+		marketData := []*marketdata.MarketDataResponse{
+			{Symbol: "AAPL", Price: 100, Volume: 500, Timestamp: open.Add(-time.Minute).UnixMilli()},
+			{Symbol: "MSFT", Price: 400, Volume: 500, Timestamp: open.UnixMilli()},
+		}
+		for minute := 0; minute <= 10; minute++ {
+			price := 100.0
+			if minute > 4 {
+				price = 102
+			}
+			marketData = append(marketData, &marketdata.MarketDataResponse{
+				Symbol:    "AAPL",
+				Price:     price,
+				Volume:    100,
+				Timestamp: open.Add(time.Duration(minute) * time.Minute).UnixMilli(),
+			})
+		}
+
+		parent, err := algo.Backtest(ctx, marketData, &algo.ParentRequest{
+			UserID:    "alice",
+			Symbol:    "AAPL",
+			Side:      types.OrderSideBuy,
+			Quantity:  types.MustParseDecimal("100"),
+			Algorithm: algo.AlgorithmTWAP,
+			StartTime: open,
+			EndTime:   open.Add(10 * time.Minute),
+		}, config, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, algo.ParentStatusCompleted, parent.Status)
+		assert.True(t, types.MustParseDecimal("100").Equal(parent.ArrivalPrice))
+		assert.Equal(t, "100", parent.FilledQuantity.String())
+		assert.Len(t, parent.Children, 10)
+		assert.True(t, types.MustParseDecimal("101.2").Equal(parent.AveragePrice))
+		assert.InDelta(t, 120, parent.SlippageBps(), 1e-9)
+
+		_, err = algo.Backtest(ctx, marketData, &algo.ParentRequest{
+			Symbol:    "IBM",
+			Side:      types.OrderSideBuy,
+			Quantity:  types.MustParseDecimal("100"),
+			Algorithm: algo.AlgorithmTWAP,
+		}, config, zap.NewNop())
+		assert.Error(t, err)
+	})
+
+	t.Run("pov against the matching engine", func(t *testing.T) {
+		registry := types.Instruments
+		types.Instruments = types.NewInstrumentRegistry()
+		defer func() { types.Instruments = registry }()
+		require.NoError(t, types.Instruments.Register(&types.Instrument{
+			Symbol:         "AAPL",
+			TradingEnabled: true,
+			TickSize:       types.MustParseDecimal("0.01"),
+		}))
+
+		orderService := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+		ask := limitOrderRequest("bob", "ask", "AAPL", "20", "100.10")
+		ask.Side = orders.OrderSideSell
+		askOrder, _, err := orderService.PlaceOrder(ctx, ask)
+		require.NoError(t, err)
+
+		service := algo.NewService(algo.NewEngineVenue(orderService), nil, config, zap.NewNop())
+		parent, err := service.Submit(ctx, &algo.ParentRequest{
+			UserID:            "alice",
+			Symbol:            "AAPL",
+			Side:              types.OrderSideBuy,
+			Quantity:          types.MustParseDecimal("50"),
+			LimitPrice:        types.MustParseDecimal("101.00"),
+			Algorithm:         algo.AlgorithmPOV,
+			ParticipationRate: 0.1,
+			StartTime:         time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		// Trades printed through order entry count towards the volume
+		feed := &subscribedFeed{OrderService: orderService, subscribed: make(chan struct{})}
+		feedCtx, stopFeed := context.WithCancel(ctx)
+		feedDone := make(chan error, 1)
+		go func() { feedDone <- service.FollowTrades(feedCtx, feed) }()
+		defer func() {
+			stopFeed()
+			assert.NoError(t, <-feedDone)
+		}()
+		<-feed.subscribed
+
+		sell := limitOrderRequest("carol", "sell", "AAPL", "300", "100.00")
+		sell.Side = orders.OrderSideSell
+		_, _, err = orderService.PlaceOrder(ctx, sell)
+		require.NoError(t, err)
+		_, _, err = orderService.PlaceOrder(ctx, limitOrderRequest("dave", "buy", "AAPL", "300", "100.00"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			parent, err = service.GetParent(parent.ID)
+			require.NoError(t, err)
+			return parent.MarketVolume.IsPositive()
+		}, time.Second, time.Millisecond)
+		assert.True(t, types.MustParseDecimal("100.00").Equal(parent.ArrivalPrice))
+		require.True(t, types.MustParseDecimal("300").Equal(parent.MarketVolume), "each trade counts once")
+
+		service.Step(ctx, time.Now())
+
+		// The child is immediate or cancel, so what the book cannot fill is
+		// dropped and retried on later volume
+		parent, err = service.GetParent(parent.ID)
+		require.NoError(t, err)
+		require.Len(t, parent.Children, 1)
+		assert.Equal(t, "30", parent.Children[0].Quantity.String())
+		assert.Equal(t, types.OrderStatusCancelled, parent.Children[0].Status)
+		assert.Equal(t, "20", parent.FilledQuantity.String())
+		assert.True(t, types.MustParseDecimal("100.10").Equal(parent.AveragePrice))
+		assert.InDelta(t, 10, parent.SlippageBps(), 1e-9)
+		assert.Equal(t, algo.ParentStatusRunning, parent.Status)
+
+		// The child went through order entry
+		child, err := orderService.GetOrder(ctx, parent.Children[0].VenueOrderID)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", child.UserID)
+		assert.Equal(t, parent.Children[0].ID, child.ClientOrderID)
+		assert.Equal(t, parent.ID, child.Tags["parent_order_id"])
+		assert.Equal(t, orders.OrderStatusCancelled, child.Status)
+
+		askOrder, err = orderService.GetOrder(ctx, askOrder.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderStatusFilled, askOrder.Status)
+	})
+}
+
+// subscribedFeed is an order service feed that signals its first
+// subscription, so tests know when trades will be followed
+type subscribedFeed struct {
+	*orders.OrderService
+	subscribed chan struct{}
+	once       sync.Once
+}
+
+// SubscribeExecutions subscribes to the order service and signals it
+func (f *subscribedFeed) SubscribeExecutions(filter orders.ExecutionFilter, session string, fromSequence uint64) (*orders.ExecutionSubscription, error) {
+	subscription, err := f.OrderService.SubscribeExecutions(filter, session, fromSequence)
+	f.once.Do(func() { close(f.subscribed) })
+	return subscription, err
+}
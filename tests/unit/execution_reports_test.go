@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// received takes the reports waiting on a subscription
+func received(subscription *orders.ExecutionSubscription) []*orders.ExecutionReport {
+	var reports []*orders.ExecutionReport
+	for {
+		select {
+		case report, ok := <-subscription.Reports():
+			if !ok {
+				return reports
+			}
+			reports = append(reports, report)
+		default:
+			return reports
+		}
+	}
+}
+
+// execTypes lists the types of reports
+func execTypes(reports []*orders.ExecutionReport) []orders.ExecType {
+	var execs []orders.ExecType
+	for _, report := range reports {
+		execs = append(execs, report.ExecType)
+	}
+	return execs
+}
+
+func TestOrderService_ExecutionReports(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		require.NoError(t, types.Instruments.Register(&types.Instrument{
+			Symbol:         symbol,
+			TradingEnabled: true,
+			TickSize:       types.MustParseDecimal("0.01"),
+		}))
+	}
+
+	ctx := context.Background()
+	service := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+
+	place := func(user, symbol string, side orders.OrderSide, price, quantity string) *orders.Order {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      symbol,
+			Side:        side,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal(price),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+
+	subscription, err := service.SubscribeExecutions(orders.ExecutionFilter{UserID: "alice", Symbol: "AAPL"}, "", 0)
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	order := place("alice", "AAPL", orders.OrderSideBuy, "100", "2")
+	place("alice", "MSFT", orders.OrderSideBuy, "50", "1")
+	place("bob", "AAPL", orders.OrderSideSell, "100", "1")
+	_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{UserID: "alice", OrderID: order.ID})
+	require.NoError(t, err)
+
+	reports := received(subscription)
+	require.Equal(t, []orders.ExecType{
+		orders.ExecPendingNew,
+		orders.ExecNew,
+		orders.ExecPartialFill,
+		orders.ExecPendingCancel,
+		orders.ExecCancelled,
+	}, execTypes(reports))
+	session := reports[0].Session
+	require.NotEmpty(t, session)
+	for i, report := range reports {
+		assert.Equal(t, order.ID, report.Order.ID)
+		assert.Equal(t, session, report.Session)
+		if i > 0 {
+			assert.Greater(t, report.Sequence, reports[i-1].Sequence)
+		}
+	}
+
+	fill := reports[2]
+	assert.Equal(t, orders.OrderStatusPartiallyFilled, fill.Order.Status)
+	assert.Equal(t, orders.ReasonPartiallyFilled, fill.Reason)
+	assert.NotEmpty(t, fill.TradeID)
+	assert.True(t, fill.LastQuantity.Equal(types.MustParseDecimal("1")))
+	assert.True(t, fill.LastPrice.Equal(types.MustParseDecimal("100")))
+	assert.True(t, fill.Order.FilledQuantity.Equal(types.MustParseDecimal("1")))
+	assert.Equal(t, orders.ReasonUserCancelled, reports[4].Reason)
+
+	t.Run("resume from sequence", func(t *testing.T) {
+		resumed, err := service.SubscribeExecutions(orders.ExecutionFilter{UserID: "alice", Symbol: "AAPL"}, session, reports[1].Sequence)
+		require.NoError(t, err)
+		defer resumed.Close()
+
+		assert.Equal(t, []orders.ExecType{
+			orders.ExecPartialFill,
+			orders.ExecPendingCancel,
+			orders.ExecCancelled,
+		}, execTypes(received(resumed)))
+	})
+
+	t.Run("unknown sequence", func(t *testing.T) {
+		_, err := service.SubscribeExecutions(orders.ExecutionFilter{}, session, reports[4].Sequence+100)
+		assert.Equal(t, orders.ErrSequenceUnavailable, err)
+	})
+
+	t.Run("sequence from before a restart", func(t *testing.T) {
+		// The restarted service numbers its reports from one again
+		restarted := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+		_, err := restarted.SubscribeExecutions(orders.ExecutionFilter{}, session, reports[1].Sequence)
+		assert.Equal(t, orders.ErrSequenceUnavailable, err)
+	})
+
+	t.Run("closed subscription", func(t *testing.T) {
+		closed, err := service.SubscribeExecutions(orders.ExecutionFilter{UserID: "bob"}, "", 0)
+		require.NoError(t, err)
+		closed.Close()
+
+		_, ok := <-closed.Reports()
+		assert.False(t, ok)
+	})
+}
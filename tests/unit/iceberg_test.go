@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAdvancedEngine_IcebergAndHiddenOrders(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+
+	engine := matching.NewAdvancedOrderMatchingEngine(&matching.EngineConfig{
+		EnableIcebergOrders: true,
+		EnableHiddenOrders:  true,
+	}, zap.NewNop())
+	require.NoError(t, engine.Start())
+	defer engine.Stop()
+
+	newOrder := func(id string, side matching.OrderSide, quantity string) *matching.Order {
+		return &matching.Order{
+			ID:          id,
+			UserID:      id,
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        matching.OrderTypeLimit,
+			Price:       types.MustParseDecimal("100"),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: matching.TimeInForceGTC,
+		}
+	}
+	fills := func(trades []*matching.Trade) map[string]string {
+		filled := make(map[string]types.Decimal)
+		for _, trade := range trades {
+			filled[trade.SellOrderID] = filled[trade.SellOrderID].Add(trade.Quantity)
+		}
+		out := make(map[string]string)
+		for id, quantity := range filled {
+			out[id] = quantity.String()
+		}
+		return out
+	}
+
+	iceberg := newOrder("iceberg", matching.OrderSideSell, "300")
+	iceberg.DisplayQuantity = types.MustParseDecimal("100")
+	hidden := newOrder("hidden", matching.OrderSideSell, "100")
+	hidden.IsHidden = true
+	for _, order := range []*matching.Order{iceberg, newOrder("lit", matching.OrderSideSell, "50"), hidden} {
+		_, err := engine.AddOrder(order)
+		require.NoError(t, err)
+	}
+
+	// Depth shows the iceberg slice and the lit order, never the hidden order
+	_, asks := engine.GetOrderBook("AAPL").GetDepth(5)
+	require.Len(t, asks, 1)
+	assert.Equal(t, "150", asks[0].Quantity.String())
+
+	// The refreshed slice queues behind the lit order
+	trades, err := engine.AddOrder(newOrder("buy-1", matching.OrderSideBuy, "120"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"iceberg": "100", "lit": "20"}, fills(trades))
+
+	// The iceberg keeps refreshing ahead of the hidden order at its price
+	trades, err = engine.AddOrder(newOrder("buy-2", matching.OrderSideBuy, "200"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"iceberg": "170", "lit": "30"}, fills(trades))
+
+	// Hidden liquidity trades once the displayed liquidity is gone
+	trades, err = engine.AddOrder(newOrder("buy-3", matching.OrderSideBuy, "80"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"iceberg": "30", "hidden": "50"}, fills(trades))
+	assert.Equal(t, matching.OrderStatusFilled, iceberg.Status)
+	assert.Equal(t, "300", iceberg.FilledQuantity.String())
+
+	_, asks = engine.GetOrderBook("AAPL").GetDepth(5)
+	assert.Empty(t, asks, "the hidden remainder is not displayed")
+	assert.True(t, engine.GetOrderBook("AAPL").GetBestAsk().IsZero())
+}
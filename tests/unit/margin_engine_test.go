@@ -0,0 +1,387 @@
+package unit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	order_matching "github.com/abdoElHodaky/tradSys/internal/core/matching"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/risk"
+	riskengine "github.com/abdoElHodaky/tradSys/internal/risk/engine"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// orderRouter accepts every order and keeps the requests in order
+type orderRouter struct {
+	requests []*orders.OrderRequest
+}
+
+func (r *orderRouter) PlaceOrder(ctx context.Context, req *orders.OrderRequest) (*orders.Order, bool, error) {
+	r.requests = append(r.requests, req)
+	return &orders.Order{
+		ID:       "liquidation-" + strconv.Itoa(len(r.requests)),
+		UserID:   req.UserID,
+		Symbol:   req.Symbol,
+		Side:     req.Side,
+		Quantity: req.Quantity,
+		Status:   orders.OrderStatusFilled,
+	}, false, nil
+}
+
+// netPositions serves fixed net positions by user and symbol
+type netPositions map[string]float64
+
+func (p netPositions) NetPosition(userID, symbol string) float64 {
+	return p[userID+"/"+symbol]
+}
+
+func TestMarginEngine_Margin(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	for _, instrument := range []*types.Instrument{
+		{Symbol: "AAPL", AssetType: types.AssetTypeStock, Currency: "USD", LotSize: types.MustParseDecimal("1")},
+		{Symbol: "SPY", AssetType: types.AssetTypeETF, Currency: "USD"},
+		{Symbol: "IVV", AssetType: types.AssetTypeETF, Currency: "USD"},
+		{Symbol: "BTC", AssetType: types.AssetTypeCrypto, Currency: "USD"},
+	} {
+		require.NoError(t, types.Instruments.Register(instrument))
+	}
+
+	ctx := context.Background()
+	call := &options.Contract{
+		Underlying: "AAPL",
+		Type:       options.Call,
+		Style:      options.European,
+		Strike:     100,
+		Expiry:     time.Now().Add(90 * 24 * time.Hour),
+		Multiplier: 100,
+	}
+	book := &stressBook{
+		positions: map[string][]*riskengine.Position{
+			"alice": {{Symbol: "AAPL", Quantity: 100, AveragePrice: 100}},
+			"bob": {
+				{Symbol: "SPY", Quantity: 100, AveragePrice: 100},
+				{Symbol: "IVV", Quantity: -50, AveragePrice: 100},
+			},
+			"carol": {
+				{Symbol: "AAPL", Quantity: -100, AveragePrice: 100},
+				{Symbol: "AAPL 100C", Quantity: 2, AveragePrice: 6, Option: call, ImpliedVolatility: 0.25},
+			},
+			"dave": {{Symbol: "BTC", Quantity: 1, AveragePrice: 30000}},
+		},
+		prices: map[string]float64{"AAPL": 100, "AAPL 100C": 6, "SPY": 100, "IVV": 100, "BTC": 30000},
+	}
+	router := &orderRouter{}
+	audit := &auditTrail{}
+	engine := risk.NewMarginEngine(book, router, zap.NewNop())
+	engine.SetAuditRepository(audit)
+
+	t.Run("haircuts and hedge offsets", func(t *testing.T) {
+		alice := engine.AccountMargin("alice")
+		assert.InDelta(t, 5000, alice.InitialMargin, 1e-6)
+		assert.InDelta(t, 2500, alice.MaintenanceMargin, 1e-6)
+		assert.Equal(t, "STOCK", alice.Positions[0].AssetClass)
+
+		dave := engine.AccountMargin("dave")
+		assert.InDelta(t, 15000, dave.InitialMargin, 1e-6)
+		assert.InDelta(t, 10500, dave.MaintenanceMargin, 1e-6)
+
+		// SPY and IVV offset only once they share a hedge group
+		bob := engine.AccountMargin("bob")
+		assert.InDelta(t, 7500, bob.InitialMargin, 1e-6)
+		assert.Zero(t, bob.HedgeOffset)
+
+		config := risk.DefaultMarginConfig()
+		config.HedgeGroups = map[string]string{"SPY": "SPX", "IVV": "SPX"}
+		require.NoError(t, engine.SetConfig(config))
+		bob = engine.AccountMargin("bob")
+		assert.InDelta(t, 5000, bob.InitialMargin, 1e-6)
+		assert.InDelta(t, 2500, bob.HedgeOffset, 1e-6)
+
+		// Long calls hedge the short stock at their delta
+		greeks, err := options.Value(call, options.Market{Spot: 100, Rate: 0.05, Volatility: 0.25}, time.Now())
+		require.NoError(t, err)
+		carol := engine.AccountMargin("carol")
+		require.Len(t, carol.Positions, 2)
+		option := carol.Positions[1]
+		assert.Equal(t, "AAPL 100C", option.Symbol)
+		assert.Equal(t, "AAPL", option.Underlying)
+		assert.InDelta(t, greeks.Delta*200*100, option.Exposure, 1e-6)
+		assert.InDelta(t, 0, option.UnrealizedPnL, 1e-9)
+		hedged := math.Min(5000, 0.5*option.Exposure)
+		assert.InDelta(t, 5000+0.5*option.Exposure-hedged, carol.InitialMargin, 1e-6)
+		assert.InDelta(t, hedged, carol.HedgeOffset, 1e-6)
+
+		assert.Equal(t, risk.ErrInvalidMarginConfig, engine.SetConfig(risk.MarginConfig{}))
+	})
+
+	engine.SetCollateral("alice", 6000)
+
+	t.Run("margin call", func(t *testing.T) {
+		margins := engine.Check(ctx, "alice")
+		require.Len(t, margins, 1)
+		assert.Equal(t, risk.MarginStatusHealthy, margins[0].Status)
+		assert.InDelta(t, 1000, margins[0].Excess, 1e-6)
+
+		book.prices["AAPL"] = 70
+		engine.OnPrice(ctx, "AAPL", 70)
+		margin := engine.AccountMargin("alice")
+		assert.Equal(t, risk.MarginStatusCall, margin.Status)
+		assert.InDelta(t, 3000, margin.Equity, 1e-6)
+		assert.InDelta(t, 3500, margin.InitialMargin, 1e-6)
+
+		marginCall, open := engine.MarginCall("alice")
+		require.True(t, open)
+		assert.InDelta(t, 500, marginCall.Deficit, 1e-6)
+		assert.Empty(t, router.requests)
+		require.Len(t, audit.records, 1)
+		assert.Equal(t, risk.AuditActionMarginCall, audit.records[0].Action)
+		assert.Equal(t, risk.MarginEngineActor, audit.records[0].Actor)
+		assert.Equal(t, "alice", audit.records[0].Value)
+
+		// Calls are raised once
+		engine.OnPrice(ctx, "AAPL", 70)
+		assert.Len(t, audit.records, 1)
+		assert.Len(t, engine.MarginCalls(), 1)
+	})
+
+	t.Run("liquidation", func(t *testing.T) {
+		book.prices["AAPL"] = 50
+		engine.OnPrice(ctx, "AAPL", 50)
+
+		// Equity of 1000 against 2500 initial margin: closing 60% of the
+		// position releases the 1500 missing
+		require.Len(t, router.requests, 1)
+		req := router.requests[0]
+		assert.Equal(t, "alice", req.UserID)
+		assert.Equal(t, "AAPL", req.Symbol)
+		assert.Equal(t, orders.OrderSideSell, req.Side)
+		assert.Equal(t, orders.OrderTypeMarket, req.Type)
+		assert.True(t, req.ReduceOnly)
+		assert.Equal(t, "60", req.Quantity.String())
+		assert.Equal(t, risk.LiquidationStrategy, req.Tags[orders.StrategyTag])
+
+		marginCall, open := engine.MarginCall("alice")
+		require.True(t, open)
+		assert.Equal(t, marginCall.ID, req.Tags[risk.MarginCallTag])
+		assert.Equal(t, 1, marginCall.Liquidations)
+		assert.Equal(t, []string{"liquidation-1"}, marginCall.OrderIDs)
+		require.Len(t, audit.records, 2)
+		assert.Equal(t, risk.AuditActionLiquidationOrder, audit.records[1].Action)
+		assert.Contains(t, audit.records[1].Details, "liquidation-1")
+
+		// The next round waits for the interval
+		engine.OnPrice(ctx, "AAPL", 50)
+		assert.Len(t, router.requests, 1)
+
+		// Once the fill is booked equity covers initial margin again
+		book.positions["alice"][0].Quantity = 40
+		engine.SetCollateral("alice", 4000)
+		margins := engine.Check(ctx)
+		assert.Len(t, margins, 4)
+		_, open = engine.MarginCall("alice")
+		assert.False(t, open)
+		assert.Equal(t, risk.AuditActionMarginCallMet, audit.records[len(audit.records)-1].Action)
+	})
+
+	t.Run("hedges are kept", func(t *testing.T) {
+		// A rising IVV leaves bob short of margin. Margin is on the larger
+		// leg, SPY, and only its 35 unhedged shares release any; closing
+		// more of either leg would lose the offset.
+		engine.SetCollateral("bob", 2000)
+		book.prices["IVV"] = 130
+		requests := len(router.requests)
+		engine.OnPrice(ctx, "IVV", 130)
+
+		margin := engine.AccountMargin("bob")
+		assert.Equal(t, risk.MarginStatusLiquidation, margin.Status)
+		assert.InDelta(t, 5000, margin.InitialMargin, 1e-6)
+		assert.InDelta(t, 500, margin.Equity, 1e-6)
+		require.Len(t, router.requests, requests+1)
+		req := router.requests[requests]
+		assert.Equal(t, "SPY", req.Symbol)
+		assert.Equal(t, orders.OrderSideSell, req.Side)
+		assert.InDelta(t, 35, req.Quantity.Float64(), 1e-6)
+	})
+
+	t.Run("grace period and unknown collateral", func(t *testing.T) {
+		config := risk.DefaultMarginConfig()
+		config.Liquidation.GracePeriod = time.Hour
+		require.NoError(t, engine.SetConfig(config))
+
+		// dave has no collateral set and is never called
+		book.prices["BTC"] = 10000
+		engine.OnPrice(ctx, "BTC", 10000)
+		_, open := engine.MarginCall("dave")
+		assert.False(t, open)
+
+		requests := len(router.requests)
+		engine.SetCollateral("dave", 1000)
+		engine.OnPrice(ctx, "BTC", 10000)
+		marginCall, open := engine.MarginCall("dave")
+		require.True(t, open)
+		assert.Equal(t, risk.MarginStatusLiquidation, engine.AccountMargin("dave").Status)
+		assert.Zero(t, marginCall.Liquidations)
+		assert.Len(t, router.requests, requests)
+	})
+}
+
+func TestMarginEngine_LiquidatesThroughTheOrderService(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		AssetType:      types.AssetTypeStock,
+		Currency:       "USD",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+		LotSize:        types.MustParseDecimal("1"),
+	}))
+
+	// The risk service marks positions from trades on the core engine; the
+	// margin engine liquidates through the order service
+	ctx := context.Background()
+	coreEngine := order_matching.NewEngine(zap.NewNop())
+	var service *orders.OrderService
+	var positions *risk.Service
+	var margin *risk.MarginEngine
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(zap.NewNop(), coreEngine),
+		fx.Provide(func(logger *zap.Logger) matching.OrderEngine {
+			return matching.NewMatchingEngine(logger)
+		}),
+		fx.Provide(orders.NewFxOrderService),
+		risk.RiskManagementModule,
+		risk.RiskModule,
+		fx.Populate(&service, &positions, &margin),
+	)
+	require.NoError(t, app.Err())
+	require.NotNil(t, margin)
+	require.NoError(t, app.Start(ctx))
+	defer app.Stop(ctx)
+
+	trade := func(buyer, seller, price, quantity string) {
+		t.Helper()
+		for _, order := range []*order_matching.Order{
+			{ID: seller + "-" + price, UserID: seller, Side: order_matching.OrderSideSell},
+			{ID: buyer + "-" + price, UserID: buyer, Side: order_matching.OrderSideBuy},
+		} {
+			order.Symbol = "AAPL"
+			order.Type = order_matching.OrderTypeLimit
+			order.Price = types.MustParseDecimal(price)
+			order.Quantity = types.MustParseDecimal(quantity)
+			order.TimeInForce = order_matching.TimeInForceGTC
+			_, err := coreEngine.PlaceOrder(order)
+			require.NoError(t, err)
+		}
+	}
+
+	// alice buys 100 AAPL at 100 with 6000 of collateral
+	margin.SetCollateral("alice", 6000)
+	trade("alice", "bob", "100", "100")
+	require.Eventually(t, func() bool {
+		return positions.NetPosition("alice", "AAPL") == 100
+	}, time.Second, 10*time.Millisecond)
+
+	// A bid for the liquidation to hit
+	_, err := service.CreateOrder(ctx, &orders.OrderRequest{
+		UserID:      "carol",
+		Symbol:      "AAPL",
+		Side:        orders.OrderSideBuy,
+		Type:        orders.OrderTypeLimit,
+		Price:       types.MustParseDecimal("50"),
+		Quantity:    types.MustParseDecimal("100"),
+		TimeInForce: orders.TimeInForceGTC,
+	})
+	require.NoError(t, err)
+
+	// A trade at 50 leaves alice below maintenance margin; the call records
+	// the order once the order service has placed it
+	trade("dave", "erin", "50", "1")
+	require.Eventually(t, func() bool {
+		marginCall, open := margin.MarginCall("alice")
+		return open && len(marginCall.OrderIDs) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	marginCall, _ := margin.MarginCall("alice")
+	liquidations, err := service.GetOrdersByUser(ctx, "alice", nil)
+	require.NoError(t, err)
+	require.Len(t, liquidations, 1)
+	order := liquidations[0]
+	assert.Equal(t, []string{order.ID}, marginCall.OrderIDs)
+	assert.Equal(t, orders.OrderSideSell, order.Side)
+	assert.Equal(t, orders.OrderTypeMarket, order.Type)
+	assert.True(t, order.ReduceOnly)
+	assert.Equal(t, "60", order.Quantity.String())
+	assert.Equal(t, risk.LiquidationStrategy, order.Tags[orders.StrategyTag])
+	assert.Equal(t, marginCall.ID, order.Tags[risk.MarginCallTag])
+}
+
+func TestOrderService_ReduceOnly(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	service := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+	request := func(side orders.OrderSide, quantity string, reduceOnly bool) *orders.OrderRequest {
+		return &orders.OrderRequest{
+			UserID:      "alice",
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal("150"),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: orders.TimeInForceGTC,
+			ReduceOnly:  reduceOnly,
+		}
+	}
+
+	// Without positions reduce-only orders are not checked
+	order, err := service.CreateOrder(ctx, request(orders.OrderSideBuy, "10", true))
+	require.NoError(t, err)
+	assert.True(t, order.ReduceOnly)
+	_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{UserID: "alice", OrderID: order.ID})
+	require.NoError(t, err)
+
+	service.SetPositionProvider(netPositions{"alice/AAPL": 100})
+
+	_, err = service.CreateOrder(ctx, request(orders.OrderSideBuy, "10", true))
+	assert.Equal(t, orders.ErrReduceOnlyExceedsPosition, err)
+
+	closing, err := service.CreateOrder(ctx, request(orders.OrderSideSell, "60", true))
+	require.NoError(t, err)
+
+	// Open reduce-only orders count against the position
+	_, err = service.CreateOrder(ctx, request(orders.OrderSideSell, "50", true))
+	assert.Equal(t, orders.ErrReduceOnlyExceedsPosition, err)
+	_, err = service.CreateOrder(ctx, request(orders.OrderSideSell, "40", true))
+	require.NoError(t, err)
+
+	_, err = service.UpdateOrder(ctx, &orders.OrderUpdateRequest{
+		UserID:   "alice",
+		OrderID:  closing.ID,
+		Quantity: types.MustParseDecimal("70"),
+	})
+	assert.Equal(t, orders.ErrReduceOnlyExceedsPosition, err)
+
+	_, err = service.CreateOrder(ctx, request(orders.OrderSideSell, "500", false))
+	assert.NoError(t, err)
+}
@@ -1,442 +1,355 @@
 package unit
 
 import (
-	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
 	"github.com/abdoElHodaky/tradSys/pkg/matching"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
-func TestMatchingEngine_BasicMatching(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000, // 100μs
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-		NUMAOptimization: false, // Disable for testing
-	})
+// newMatchingOrder returns an AAPL order; an empty price leaves it unset
+func newMatchingOrder(id, user string, side matching.OrderSide, orderType matching.OrderType, quantity, price string, tif matching.TimeInForce) *matching.Order {
+	order := &matching.Order{
+		ID:          id,
+		UserID:      user,
+		Symbol:      "AAPL",
+		Side:        side,
+		Type:        orderType,
+		Quantity:    types.MustParseDecimal(quantity),
+		TimeInForce: tif,
+		CreatedAt:   time.Now(),
+	}
+	if price != "" {
+		order.Price = types.MustParseDecimal(price)
+	}
+	return order
+}
 
-	ctx := context.Background()
+// newOrderEngine returns a matching engine behind the interface the order
+// service drives
+func newOrderEngine() matching.OrderEngine {
+	return matching.NewMatchingEngine(zap.NewNop())
+}
 
-	// Test buy order
-	buyOrder := &matching.Order{
-		ID:       "buy-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
+func TestMatchingEngine_BasicMatching(t *testing.T) {
+	engine := newOrderEngine()
 
-	trades, err := engine.ProcessOrder(ctx, buyOrder)
+	// Test buy order
+	trades, err := engine.AddOrder(newMatchingOrder("buy-001", "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
 	assert.Empty(t, trades, "No trades should occur with single buy order")
 
 	// Test sell order that should match
-	sellOrder := &matching.Order{
-		ID:       "sell-001",
-		UserID:   "user-002",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 50,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	trades, err = engine.ProcessOrder(ctx, sellOrder)
+	trades, err = engine.AddOrder(newMatchingOrder("sell-001", "user-002", matching.OrderSideSell, matching.OrderTypeLimit, "50", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
-	assert.Len(t, trades, 1, "Should generate one trade")
+	require.Len(t, trades, 1, "Should generate one trade")
 
 	trade := trades[0]
 	assert.Equal(t, "buy-001", trade.BuyOrderID)
 	assert.Equal(t, "sell-001", trade.SellOrderID)
-	assert.Equal(t, float64(50), trade.Quantity)
-	assert.Equal(t, 150.50, trade.Price)
+	assert.True(t, types.MustParseDecimal("50").Equal(trade.Quantity))
+	assert.True(t, types.MustParseDecimal("150.50").Equal(trade.Price))
 }
 
 func TestMatchingEngine_PriceTimePriority(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	// Add first buy order at lower price
-	buyOrder1 := &matching.Order{
-		ID:       "buy-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.00,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	_, err := engine.ProcessOrder(ctx, buyOrder1)
+	_, err := engine.AddOrder(newMatchingOrder("buy-001", "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.00", matching.TimeInForceGTC))
 	require.NoError(t, err)
 
 	// Add second buy order at higher price (should have priority)
-	buyOrder2 := &matching.Order{
-		ID:       "buy-002",
-		UserID:   "user-002",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
+	_, err = engine.AddOrder(newMatchingOrder("buy-002", "user-002", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
+	require.NoError(t, err)
 
-	_, err = engine.ProcessOrder(ctx, buyOrder2)
+	// A third buy at the best price queues behind the second
+	_, err = engine.AddOrder(newMatchingOrder("buy-003", "user-004", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
 
 	// Add sell order that should match with higher price buy order
-	sellOrder := &matching.Order{
-		ID:       "sell-001",
-		UserID:   "user-003",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 50,
-		Price:    150.25,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	trades, err := engine.ProcessOrder(ctx, sellOrder)
+	trades, err := engine.AddOrder(newMatchingOrder("sell-001", "user-003", matching.OrderSideSell, matching.OrderTypeLimit, "150", "150.25", matching.TimeInForceGTC))
 	require.NoError(t, err)
-	assert.Len(t, trades, 1)
+	require.Len(t, trades, 2)
 
-	trade := trades[0]
-	assert.Equal(t, "buy-002", trade.BuyOrderID, "Should match with higher price buy order")
-	assert.Equal(t, 150.50, trade.Price, "Should execute at buy order price")
+	assert.Equal(t, "buy-002", trades[0].BuyOrderID, "Should match with higher price buy order first")
+	assert.True(t, types.MustParseDecimal("150.50").Equal(trades[0].Price), "Should execute at buy order price")
+	assert.True(t, types.MustParseDecimal("100").Equal(trades[0].Quantity))
+	assert.Equal(t, "buy-003", trades[1].BuyOrderID, "Should match the later order at the same price second")
+	assert.True(t, types.MustParseDecimal("50").Equal(trades[1].Quantity))
 }
 
 func TestMatchingEngine_PartialFill(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	// Large buy order
-	buyOrder := &matching.Order{
-		ID:       "buy-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 1000,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	_, err := engine.ProcessOrder(ctx, buyOrder)
+	_, err := engine.AddOrder(newMatchingOrder("buy-001", "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "1000", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
 
 	// Smaller sell order (partial fill)
-	sellOrder := &matching.Order{
-		ID:       "sell-001",
-		UserID:   "user-002",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 300,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	trades, err := engine.ProcessOrder(ctx, sellOrder)
+	trades, err := engine.AddOrder(newMatchingOrder("sell-001", "user-002", matching.OrderSideSell, matching.OrderTypeLimit, "300", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
-	assert.Len(t, trades, 1)
-
-	trade := trades[0]
-	assert.Equal(t, float64(300), trade.Quantity)
+	require.Len(t, trades, 1)
+	assert.True(t, types.MustParseDecimal("300").Equal(trades[0].Quantity))
 
-	// Check remaining quantity in order book
-	orderBook := engine.GetOrderBook()
-	assert.NotNil(t, orderBook)
-	
 	// The buy order should still have 700 remaining
-	buyLevels := orderBook.GetBuyLevels()
-	assert.NotEmpty(t, buyLevels)
-	
-	topBuyLevel := buyLevels[0]
-	assert.Equal(t, 150.50, topBuyLevel.Price)
-	assert.Equal(t, float64(700), topBuyLevel.Quantity)
+	orderBook := engine.GetOrderBook("AAPL")
+	require.NotNil(t, orderBook)
+	bids, asks := orderBook.GetDepth(10)
+	require.Len(t, bids, 1)
+	assert.Empty(t, asks)
+	assert.True(t, types.MustParseDecimal("150.50").Equal(bids[0].Price))
+	assert.True(t, types.MustParseDecimal("700").Equal(bids[0].Quantity))
+
+	order, exists := engine.GetOrder("AAPL", "buy-001")
+	require.True(t, exists)
+	assert.True(t, types.MustParseDecimal("300").Equal(order.FilledQuantity))
 }
 
 func TestMatchingEngine_MarketOrder(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	// Add limit sell orders at different prices
-	sellOrder1 := &matching.Order{
-		ID:       "sell-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	_, err := engine.ProcessOrder(ctx, sellOrder1)
+	_, err := engine.AddOrder(newMatchingOrder("sell-001", "user-001", matching.OrderSideSell, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
-
-	sellOrder2 := &matching.Order{
-		ID:       "sell-002",
-		UserID:   "user-002",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.75,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	_, err = engine.ProcessOrder(ctx, sellOrder2)
+	_, err = engine.AddOrder(newMatchingOrder("sell-002", "user-002", matching.OrderSideSell, matching.OrderTypeLimit, "100", "150.75", matching.TimeInForceGTC))
 	require.NoError(t, err)
 
 	// Market buy order should match with best ask price
-	marketBuyOrder := &matching.Order{
-		ID:       "buy-market-001",
-		UserID:   "user-003",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeMarket,
-		Quantity: 150,
-		TimeInForce: matching.TimeInForceIOC,
-		Timestamp:   time.Now(),
-	}
-
-	trades, err := engine.ProcessOrder(ctx, marketBuyOrder)
+	trades, err := engine.AddOrder(newMatchingOrder("buy-market-001", "user-003", matching.OrderSideBuy, matching.OrderTypeMarket, "150", "", matching.TimeInForceIOC))
 	require.NoError(t, err)
-	assert.Len(t, trades, 2, "Should generate two trades")
+	require.Len(t, trades, 2, "Should generate two trades")
 
 	// First trade should be at 150.50 for 100 shares
-	trade1 := trades[0]
-	assert.Equal(t, "sell-001", trade1.SellOrderID)
-	assert.Equal(t, float64(100), trade1.Quantity)
-	assert.Equal(t, 150.50, trade1.Price)
+	assert.Equal(t, "sell-001", trades[0].SellOrderID)
+	assert.True(t, types.MustParseDecimal("100").Equal(trades[0].Quantity))
+	assert.True(t, types.MustParseDecimal("150.50").Equal(trades[0].Price))
 
 	// Second trade should be at 150.75 for 50 shares
-	trade2 := trades[1]
-	assert.Equal(t, "sell-002", trade2.SellOrderID)
-	assert.Equal(t, float64(50), trade2.Quantity)
-	assert.Equal(t, 150.75, trade2.Price)
+	assert.Equal(t, "sell-002", trades[1].SellOrderID)
+	assert.True(t, types.MustParseDecimal("50").Equal(trades[1].Quantity))
+	assert.True(t, types.MustParseDecimal("150.75").Equal(trades[1].Price))
 }
 
 func TestMatchingEngine_OrderCancellation(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	// Add buy order
-	buyOrder := &matching.Order{
-		ID:       "buy-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	_, err := engine.ProcessOrder(ctx, buyOrder)
+	_, err := engine.AddOrder(newMatchingOrder("buy-001", "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
 
 	// Cancel the order
-	err = engine.CancelOrder(ctx, "buy-001", "user-001")
-	require.NoError(t, err)
+	assert.True(t, engine.CancelOrder("AAPL", "buy-001"))
+	assert.False(t, engine.CancelOrder("AAPL", "buy-001"), "A cancelled order is no longer in the book")
 
 	// Try to match with sell order - should not match
-	sellOrder := &matching.Order{
-		ID:       "sell-001",
-		UserID:   "user-002",
-		Symbol:   "AAPL",
-		Side:     matching.SideSell,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
-	}
-
-	trades, err := engine.ProcessOrder(ctx, sellOrder)
+	trades, err := engine.AddOrder(newMatchingOrder("sell-001", "user-002", matching.OrderSideSell, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC))
 	require.NoError(t, err)
 	assert.Empty(t, trades, "No trades should occur after cancellation")
 }
 
 func TestMatchingEngine_TimeInForceIOC(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
-
-	// Add IOC order that cannot be fully filled
-	iocOrder := &matching.Order{
-		ID:       "ioc-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 1000,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceIOC,
-		Timestamp:   time.Now(),
-	}
+	engine := newOrderEngine()
 
-	trades, err := engine.ProcessOrder(ctx, iocOrder)
+	// Add IOC order that cannot be filled
+	trades, err := engine.AddOrder(newMatchingOrder("ioc-001", "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "1000", "150.50", matching.TimeInForceIOC))
 	require.NoError(t, err)
 	assert.Empty(t, trades, "IOC order should not generate trades when no matching orders")
 
 	// Order should not remain in book
-	orderBook := engine.GetOrderBook()
-	buyLevels := orderBook.GetBuyLevels()
-	assert.Empty(t, buyLevels, "IOC order should not remain in order book")
+	bids, _ := engine.GetOrderBook("AAPL").GetDepth(10)
+	assert.Empty(t, bids, "IOC order should not remain in order book")
 }
 
-func TestMatchingEngine_Performance(t *testing.T) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000, // 100μs target
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
+func TestBookSide_PriceLevels(t *testing.T) {
+	bids := matching.NewBookSide(matching.OrderSideBuy, false)
+	for i, price := range []string{"150.00", "150.50", "150.50", "149.75"} {
+		bids.Push(newMatchingOrder(fmt.Sprintf("buy-%03d", i+1), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", price, matching.TimeInForceGTC))
+	}
+	require.Equal(t, 4, bids.Len())
+
+	// The best level is the highest bid, in time priority
+	assert.Equal(t, "buy-002", bids.Peek().ID)
+	front := bids.Front()
+	require.Len(t, front, 2)
+	assert.Equal(t, "buy-003", front[1].ID)
+
+	depth := bids.Depth(10)
+	require.Len(t, depth, 3)
+	assert.True(t, types.MustParseDecimal("150.50").Equal(depth[0].Price))
+	assert.True(t, types.MustParseDecimal("200").Equal(depth[0].Quantity))
+	assert.True(t, types.MustParseDecimal("149.75").Equal(depth[2].Price))
+
+	// Cancels find orders by ID anywhere in a level and keep its total
+	removed := bids.Remove("buy-002")
+	require.NotNil(t, removed)
+	assert.Nil(t, bids.Remove("buy-002"))
+	_, exists := bids.Get("buy-002")
+	assert.False(t, exists)
+	assert.Equal(t, "buy-003", bids.Peek().ID)
+	assert.True(t, types.MustParseDecimal("100").Equal(bids.Depth(1)[0].Quantity))
+
+	// Removing the last order of a level drops the level
+	bids.Remove("buy-003")
+	assert.True(t, types.MustParseDecimal("150.00").Equal(bids.Depth(1)[0].Price))
+
+	var ids []string
+	bids.Each(func(order *matching.Order) bool {
+		ids = append(ids, order.ID)
+		return true
 	})
+	assert.Equal(t, []string{"buy-001", "buy-004"}, ids)
+	assert.Equal(t, "buy-001", bids.Pop().ID)
+	assert.Equal(t, 1, bids.Len())
+
+	// Sell stops trigger highest stop price first
+	stops := matching.NewBookSide(matching.OrderSideSell, true)
+	for i, stopPrice := range []string{"140", "145"} {
+		order := newMatchingOrder(fmt.Sprintf("stop-%03d", i+1), "user-001", matching.OrderSideSell, matching.OrderTypeStopMarket, "10", "", matching.TimeInForceGTC)
+		order.StopPrice = types.MustParseDecimal(stopPrice)
+		stops.Push(order)
+	}
+	assert.Equal(t, "stop-002", stops.Peek().ID)
+}
 
-	ctx := context.Background()
+func TestMatchingEngine_ProRataAllocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm types.MatchingAlgorithm
+		lotSize   string
+		minAlloc  string
+		resting   []string
+		quantity  string
+		fills     []string
+	}{
+		{
+			name:      "pro rata",
+			algorithm: types.MatchingAlgorithmProRata,
+			lotSize:   "1",
+			minAlloc:  "0",
+			resting:   []string{"20", "30", "50"},
+			quantity:  "50",
+			fills:     []string{"10", "15", "25"},
+		},
+		{
+			name:      "leftover lots go in time priority",
+			algorithm: types.MatchingAlgorithmProRata,
+			lotSize:   "1",
+			minAlloc:  "0",
+			resting:   []string{"10", "10", "10"},
+			quantity:  "10",
+			fills:     []string{"4", "3", "3"},
+		},
+		{
+			name:      "minimum allocation",
+			algorithm: types.MatchingAlgorithmProRata,
+			lotSize:   "1",
+			minAlloc:  "5",
+			resting:   []string{"10", "80", "10"},
+			quantity:  "20",
+			fills:     []string{"4", "16", "0"},
+		},
+		{
+			name:      "top order then pro rata in lots",
+			algorithm: types.MatchingAlgorithmProRataTopOrder,
+			lotSize:   "10",
+			minAlloc:  "20",
+			resting:   []string{"100", "300", "100"},
+			quantity:  "200",
+			fills:     []string{"100", "80", "20"},
+		},
+	}
 
-	// Measure order processing latency
-	start := time.Now()
-	
-	order := &matching.Order{
-		ID:       "perf-001",
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     matching.SideBuy,
-		Type:     matching.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-		TimeInForce: matching.TimeInForceGTC,
-		Timestamp:   time.Now(),
+	registry := types.Instruments
+	defer func() { types.Instruments = registry }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types.Instruments = types.NewInstrumentRegistry()
+			require.NoError(t, types.Instruments.Register(&types.Instrument{
+				Symbol:            "SUKUK",
+				TradingEnabled:    true,
+				LotSize:           types.MustParseDecimal(tt.lotSize),
+				MatchingAlgorithm: tt.algorithm,
+				MinAllocation:     types.MustParseDecimal(tt.minAlloc),
+			}))
+
+			engine := newOrderEngine()
+			newOrder := func(id string, side matching.OrderSide, quantity string) *matching.Order {
+				order := newMatchingOrder(id, id, side, matching.OrderTypeLimit, quantity, "100", matching.TimeInForceGTC)
+				order.Symbol = "SUKUK"
+				return order
+			}
+
+			for i, quantity := range tt.resting {
+				_, err := engine.AddOrder(newOrder(fmt.Sprintf("sell-%03d", i+1), matching.OrderSideSell, quantity))
+				require.NoError(t, err)
+			}
+
+			trades, err := engine.AddOrder(newOrder("buy-001", matching.OrderSideBuy, tt.quantity))
+			require.NoError(t, err)
+
+			filled := make(map[string]types.Decimal)
+			for _, trade := range trades {
+				assert.Equal(t, "buy-001", trade.BuyOrderID)
+				filled[trade.SellOrderID] = filled[trade.SellOrderID].Add(trade.Quantity)
+			}
+			for i, fill := range tt.fills {
+				id := fmt.Sprintf("sell-%03d", i+1)
+				assert.True(t, types.MustParseDecimal(fill).Equal(filled[id]), "%s filled %s, want %s", id, filled[id], fill)
+			}
+		})
 	}
+}
 
-	_, err := engine.ProcessOrder(ctx, order)
-	require.NoError(t, err)
+func TestMatchingEngine_Performance(t *testing.T) {
+	engine := newOrderEngine()
+
+	// Measure the average order processing latency over a warm book
+	const orders = 1000
+	start := time.Now()
+	for i := 0; i < orders; i++ {
+		price := fmt.Sprintf("%.2f", 150.00+float64(i%100)*0.01)
+		_, err := engine.AddOrder(newMatchingOrder(fmt.Sprintf("perf-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", price, matching.TimeInForceGTC))
+		require.NoError(t, err)
+	}
+	latency := time.Since(start) / orders
 
-	latency := time.Since(start)
-	
 	// Assert latency is under target (100μs = 100,000ns)
-	assert.Less(t, latency.Nanoseconds(), int64(100000), 
+	assert.Less(t, latency.Nanoseconds(), int64(100000),
 		"Order processing should be under 100μs, got %v", latency)
 }
 
 func BenchmarkMatchingEngine_ProcessOrder(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		order := &matching.Order{
-			ID:       "bench-" + string(rune(i)),
-			UserID:   "user-001",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.50 + float64(i%100)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := newMatchingOrder(fmt.Sprintf("bench-%d", i), "user-001", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.50", matching.TimeInForceGTC)
+		order.Price = types.NewDecimal(int64(15050+i%100), 2)
 
-		_, err := engine.ProcessOrder(ctx, order)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
 }
 
 func BenchmarkMatchingEngine_MatchingThroughput(b *testing.B) {
-	engine := matching.NewEngine(&matching.Config{
-		Symbol:           "AAPL",
-		LatencyTargetNS:  100000,
-		MaxOrdersPerSec:  100000,
-		OrderBookDepth:   1000,
-		EnableHFTMode:    true,
-	})
-
-	ctx := context.Background()
+	engine := newOrderEngine()
 
 	// Pre-populate order book with buy orders
 	for i := 0; i < 1000; i++ {
-		buyOrder := &matching.Order{
-			ID:       "buy-" + string(rune(i)),
-			UserID:   "user-buy",
-			Symbol:   "AAPL",
-			Side:     matching.SideBuy,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
+		order := newMatchingOrder(fmt.Sprintf("buy-%d", i), "user-buy", matching.OrderSideBuy, matching.OrderTypeLimit, "100", "150.00", matching.TimeInForceGTC)
+		order.Price = types.NewDecimal(int64(15000+i), 2)
+		if _, err := engine.AddOrder(order); err != nil {
+			b.Fatal(err)
 		}
-		engine.ProcessOrder(ctx, buyOrder)
 	}
 
 	b.ResetTimer()
@@ -444,20 +357,10 @@ func BenchmarkMatchingEngine_MatchingThroughput(b *testing.B) {
 
 	// Benchmark matching with sell orders
 	for i := 0; i < b.N; i++ {
-		sellOrder := &matching.Order{
-			ID:       "sell-" + string(rune(i)),
-			UserID:   "user-sell",
-			Symbol:   "AAPL",
-			Side:     matching.SideSell,
-			Type:     matching.TypeLimit,
-			Quantity: 100,
-			Price:    150.00 + float64(i%1000)*0.01,
-			TimeInForce: matching.TimeInForceGTC,
-			Timestamp:   time.Now(),
-		}
+		order := newMatchingOrder(fmt.Sprintf("sell-%d", i), "user-sell", matching.OrderSideSell, matching.OrderTypeLimit, "100", "150.00", matching.TimeInForceGTC)
+		order.Price = types.NewDecimal(int64(15000+i%1000), 2)
 
-		_, err := engine.ProcessOrder(ctx, sellOrder)
-		if err != nil {
+		if _, err := engine.AddOrder(order); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/risk"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOptions_Pricing(t *testing.T) {
+	now := time.Now()
+	expiry := now.Add(365 * 24 * time.Hour)
+	market := options.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+	call := &options.Contract{Underlying: "AAPL", Type: options.Call, Style: options.European, Strike: 100, Expiry: expiry}
+	put := &options.Contract{Underlying: "AAPL", Type: options.Put, Style: options.European, Strike: 100, Expiry: expiry}
+
+	t.Run("black scholes", func(t *testing.T) {
+		greeks := options.BlackScholes(call, market, 1)
+		assert.InDelta(t, 10.4506, greeks.Price, 1e-4)
+		assert.InDelta(t, 0.6368, greeks.Delta, 1e-4)
+		assert.InDelta(t, 0.018762, greeks.Gamma, 1e-6)
+		assert.InDelta(t, 0.3752, greeks.Vega, 1e-4)
+		assert.InDelta(t, -6.4140/365, greeks.Theta, 1e-5)
+		assert.InDelta(t, 0.5323, greeks.Rho, 1e-4)
+
+		// Put-call parity
+		putGreeks := options.BlackScholes(put, market, 1)
+		assert.InDelta(t, 5.5735, putGreeks.Price, 1e-4)
+		assert.InDelta(t, greeks.Price-putGreeks.Price, 100-100*0.951229, 1e-4)
+		assert.InDelta(t, greeks.Delta-1, putGreeks.Delta, 1e-9)
+	})
+
+	t.Run("binomial", func(t *testing.T) {
+		european, err := options.Binomial(put, market, 1, 500)
+		require.NoError(t, err)
+		bs := options.BlackScholes(put, market, 1)
+		assert.InDelta(t, bs.Price, european.Price, 0.01)
+		assert.InDelta(t, bs.Delta, european.Delta, 0.005)
+		assert.InDelta(t, bs.Gamma, european.Gamma, 0.001)
+		assert.InDelta(t, bs.Vega, european.Vega, 0.005)
+		assert.InDelta(t, bs.Theta, european.Theta, 0.001)
+
+		americanPut := *put
+		americanPut.Style = options.American
+		american, err := options.Binomial(&americanPut, market, 1, 500)
+		require.NoError(t, err)
+		assert.InDelta(t, 6.09, american.Price, 0.01)
+		assert.Greater(t, american.Price, european.Price)
+		assert.Less(t, american.Delta, european.Delta)
+
+		// Early exercise of a call on an underlying without dividends is
+		// worth nothing
+		americanCall := *call
+		americanCall.Style = options.American
+		valued, err := options.Value(&americanCall, market, now)
+		require.NoError(t, err)
+		assert.InDelta(t, options.BlackScholes(call, market, americanCall.YearsToExpiry(now)).Price, valued.Price, 1e-9)
+	})
+
+	t.Run("implied volatility", func(t *testing.T) {
+		quoted := market
+		quoted.Volatility = 0.35
+		for _, style := range []options.ExerciseStyle{options.European, options.American} {
+			contract := *put
+			contract.Style = style
+			price, err := options.Value(&contract, quoted, now)
+			require.NoError(t, err)
+
+			volatility, err := options.ImpliedVolatility(&contract, market, price.Price, now)
+			require.NoError(t, err)
+			assert.InDelta(t, 0.35, volatility, 1e-4, string(style))
+		}
+
+		deep := *put
+		deep.Strike = 150
+		_, err := options.ImpliedVolatility(&deep, market, 40, now)
+		assert.Equal(t, options.ErrNoImpliedVolatility, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := *call
+		expired.Expiry = now.Add(-time.Hour)
+		greeks, err := options.Value(&expired, options.Market{Spot: 110, Volatility: 0.2}, now)
+		require.NoError(t, err)
+		assert.Equal(t, options.Greeks{Price: 10, Delta: 1}, greeks)
+	})
+
+	t.Run("invalid contract", func(t *testing.T) {
+		_, err := options.Value(&options.Contract{Underlying: "AAPL", Type: options.Call, Strike: 100, Expiry: expiry}, market, now)
+		assert.Equal(t, options.ErrInvalidContract, err)
+	})
+}
+
+func TestCalculator_OptionGreeks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	contract := &options.Contract{
+		Underlying: "AAPL",
+		Type:       options.Call,
+		Style:      options.American,
+		Strike:     100,
+		Expiry:     now.Add(90 * 24 * time.Hour),
+		Multiplier: 100,
+	}
+	quoted, err := options.Value(contract, options.Market{Spot: 100, Rate: 0.05, Volatility: 0.25}, now)
+	require.NoError(t, err)
+
+	calculator := risk.NewCalculator(zap.NewNop())
+	positions := []*risk.Position{
+		{UserID: "alice", Symbol: "AAPL 100C", Quantity: 10, AveragePrice: quoted.Price, InstrumentType: "option", Option: contract},
+		{UserID: "alice", Symbol: "AAPL", Quantity: -500, AveragePrice: 100},
+		{UserID: "alice", Symbol: "MSFT", Quantity: 20, AveragePrice: 300},
+	}
+	prices := map[string]float64{"AAPL 100C": quoted.Price, "AAPL": 100, "MSFT": 300}
+
+	metrics, err := calculator.CalculateAccountRisk(ctx, "alice", positions, prices)
+	require.NoError(t, err)
+	require.Len(t, metrics.Positions, 3)
+
+	option := metrics.Positions[0]
+	assert.Equal(t, "AAPL", option.Underlying)
+	assert.InDelta(t, 0.25, option.ImpliedVolatility, 1e-3)
+	assert.InDelta(t, quoted.Delta*1000, option.Delta, 1)
+	assert.InDelta(t, quoted.Gamma*1000, option.Gamma, 0.1)
+	assert.Less(t, option.Theta, 0.0)
+	assert.Greater(t, option.Vega, 0.0)
+	assert.Equal(t, -500.0, metrics.Positions[1].Delta)
+
+	require.Len(t, metrics.Greeks, 2)
+	aapl := metrics.Greeks[0]
+	assert.Equal(t, "AAPL", aapl.Underlying)
+	assert.InDelta(t, option.Delta-500, aapl.Delta, 1e-9)
+	assert.InDelta(t, aapl.Delta*100, aapl.DollarDelta, 1e-6)
+	assert.Equal(t, option.Vega, aapl.Vega)
+	assert.Equal(t, "MSFT", metrics.Greeks[1].Underlying)
+	assert.InDelta(t, aapl.DollarDelta+20*300, metrics.NetDollarDelta, 1e-6)
+	assert.Equal(t, option.Theta, metrics.NetTheta)
+
+	t.Run("without underlying price", func(t *testing.T) {
+		risky, err := calculator.CalculatePositionRisk(ctx, positions[0], quoted.Price)
+		require.NoError(t, err)
+		assert.Zero(t, risky.Delta)
+
+		priced := *positions[0]
+		priced.UnderlyingPrice = 100
+		risky, err = calculator.CalculatePositionRisk(ctx, &priced, quoted.Price)
+		require.NoError(t, err)
+		assert.InDelta(t, option.Delta, risky.Delta, 1e-6)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		assert.Equal(t, risk.ErrInvalidOptionsConfig, calculator.SetOptionsConfig(risk.OptionsConfig{}))
+	})
+}
@@ -0,0 +1,165 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	orderspb "github.com/abdoElHodaky/tradSys/proto/orders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_AmendOrder(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+	handler := orders.NewHandler(orders.HandlerParams{Logger: zap.NewNop(), Service: service})
+
+	place := func(user string, side orders.OrderSide, price string) *orders.Order {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal(price),
+			Quantity:    types.MustParseDecimal("5"),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+	bid := place("alice", orders.OrderSideBuy, "99")
+	place("bob", orders.OrderSideSell, "101")
+
+	t.Run("amends the resting order", func(t *testing.T) {
+		rsp, err := handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: bid.ID, UserId: "alice", Price: 99.5, Quantity: 4})
+		require.NoError(t, err)
+		assert.Equal(t, 99.5, rsp.Price)
+		assert.Equal(t, 4.0, rsp.Quantity)
+
+		book := engine.GetOrderBook("AAPL")
+		assert.Equal(t, "99.5", book.GetBestBid().String())
+	})
+
+	t.Run("a marketable amend trades", func(t *testing.T) {
+		rsp, err := handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: bid.ID, UserId: "alice", Price: 101})
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, rsp.FilledQty)
+		assert.Equal(t, orderspb.OrderStatus_FILLED, rsp.Status)
+	})
+
+	t.Run("maps errors to status codes", func(t *testing.T) {
+		ask := place("carol", orders.OrderSideSell, "102")
+
+		_, err := handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: "missing", Price: 100})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: ask.ID, UserId: "mallory", Price: 103})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: ask.ID, UserId: "carol", Price: 102.005})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "102", ask.Price.String())
+
+		_, err = handler.AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: ask.ID})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = orders.NewHandler(orders.HandlerParams{Logger: zap.NewNop()}).
+			AmendOrder(ctx, &orderspb.AmendOrderRequest{Id: ask.ID, Price: 103})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestOrderService_AmendOrderMissingFromTheBook(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+
+	place := func() *orders.Order {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      "alice",
+			Symbol:      "AAPL",
+			Side:        orders.OrderSideBuy,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal("99"),
+			Quantity:    types.MustParseDecimal("5"),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+	amend := func(order *orders.Order) error {
+		_, err := service.UpdateOrder(ctx, &orders.OrderUpdateRequest{
+			UserID:  "alice",
+			OrderID: order.ID,
+			Symbol:  "AAPL",
+			Price:   types.MustParseDecimal("98"),
+		})
+		return err
+	}
+
+	t.Run("rejects an order that left the book", func(t *testing.T) {
+		order := place()
+		require.True(t, engine.CancelOrder("AAPL", order.ID))
+
+		err := amend(order)
+		assert.ErrorIs(t, err, orders.ErrOrderNotFound)
+		assert.ErrorIs(t, err, matching.ErrOrderNotFound)
+		assert.Equal(t, orders.OrderStatusPending, order.Status)
+		assert.Equal(t, "99", order.Price.String())
+	})
+
+	t.Run("rejects an order whose book is gone", func(t *testing.T) {
+		order := place()
+		service.MatchingEngine = matching.NewMatchingEngine(zap.NewNop())
+		defer func() { service.MatchingEngine = engine }()
+
+		err := amend(order)
+		assert.ErrorIs(t, err, orders.ErrOrderNotFound)
+		assert.ErrorIs(t, err, matching.ErrOrderBookNotFound)
+		assert.Equal(t, orders.OrderStatusPending, order.Status)
+		assert.Equal(t, "99", order.Price.String())
+	})
+
+	t.Run("amends an order not yet submitted", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      "alice",
+			Symbol:      "AAPL",
+			Side:        orders.OrderSideBuy,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal("99"),
+			Quantity:    types.MustParseDecimal("5"),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, amend(order))
+		assert.Equal(t, "98", order.Price.String())
+	})
+}
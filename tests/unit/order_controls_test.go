@@ -0,0 +1,384 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/api/handlers"
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	orderspb "github.com/abdoElHodaky/tradSys/proto/orders"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// auditTrail keeps the recorded order control actions in order
+type auditTrail struct {
+	records []*db.AuditRecord
+}
+
+func (a *auditTrail) CreateAuditRecord(ctx context.Context, record *db.AuditRecord) error {
+	a.records = append(a.records, record)
+	return nil
+}
+
+// killSwitchTable keeps the persisted kill switches by ID
+type killSwitchTable struct {
+	rows map[string]*db.KillSwitch
+}
+
+func newKillSwitchTable() *killSwitchTable {
+	return &killSwitchTable{rows: make(map[string]*db.KillSwitch)}
+}
+
+func (k *killSwitchTable) SaveKillSwitch(ctx context.Context, killSwitch *db.KillSwitch) error {
+	k.rows[killSwitch.ID] = killSwitch
+	return nil
+}
+
+func (k *killSwitchTable) DeleteKillSwitch(ctx context.Context, id string) error {
+	delete(k.rows, id)
+	return nil
+}
+
+func (k *killSwitchTable) GetKillSwitches(ctx context.Context) ([]*db.KillSwitch, error) {
+	killSwitches := make([]*db.KillSwitch, 0, len(k.rows))
+	for _, killSwitch := range k.rows {
+		killSwitches = append(killSwitches, killSwitch)
+	}
+	return killSwitches, nil
+}
+
+func TestOrderService_OrderControls(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+	audit := &auditTrail{}
+	service.SetAuditRepository(audit)
+
+	request := func(user string, side orders.OrderSide, strategy string) *orders.OrderRequest {
+		price := "99"
+		if side == orders.OrderSideSell {
+			price = "101"
+		}
+		req := &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal(price),
+			Quantity:    types.MustParseDecimal("1"),
+			TimeInForce: orders.TimeInForceGTC,
+		}
+		if strategy != "" {
+			req.Tags = map[string]string{orders.StrategyTag: strategy}
+		}
+		return req
+	}
+	place := func(user string, side orders.OrderSide, strategy string) *orders.Order {
+		order, err := service.CreateOrder(ctx, request(user, side, strategy))
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+
+	aliceBuy := place("alice", orders.OrderSideBuy, "momentum")
+	aliceSell := place("alice", orders.OrderSideSell, "")
+	bobBuy := place("bob", orders.OrderSideBuy, "momentum")
+	bobSell := place("bob", orders.OrderSideSell, "")
+
+	t.Run("mass cancel", func(t *testing.T) {
+		_, err := service.MassCancel(ctx, &orders.MassCancelRequest{Side: orders.OrderSideBuy})
+		assert.Equal(t, orders.ErrInvalidMassCancel, err)
+
+		cancelled, err := service.MassCancel(ctx, &orders.MassCancelRequest{
+			UserID: "alice",
+			Side:   orders.OrderSideBuy,
+			Actor:  "risk",
+		})
+		require.NoError(t, err)
+		require.Len(t, cancelled, 1)
+		assert.Same(t, aliceBuy, cancelled[0])
+		assert.Equal(t, orders.OrderStatusCancelled, aliceBuy.Status)
+		assert.Equal(t, orders.OrderStatusPending, aliceSell.Status)
+		_, resting := engine.GetOrder("AAPL", aliceBuy.ID)
+		assert.False(t, resting)
+
+		cancelled, err = service.MassCancel(ctx, &orders.MassCancelRequest{Strategy: "momentum"})
+		require.NoError(t, err)
+		require.Len(t, cancelled, 1)
+		assert.Same(t, bobBuy, cancelled[0])
+	})
+
+	t.Run("kill switch", func(t *testing.T) {
+		_, _, err := service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{Scope: "desk", Value: "bob"})
+		assert.Equal(t, orders.ErrInvalidKillSwitch, err)
+
+		killSwitch, cancelled, err := service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{
+			Scope:            orders.KillSwitchUser,
+			Value:            "bob",
+			Actor:            "risk",
+			Reason:           "runaway quoting",
+			CancelOpenOrders: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "risk", killSwitch.Actor)
+		require.Len(t, cancelled, 1)
+		assert.Same(t, bobSell, cancelled[0])
+
+		_, err = service.CreateOrder(ctx, request("bob", orders.OrderSideBuy, ""))
+		assert.Equal(t, orders.ErrKillSwitchEngaged, err)
+		_, err = service.CreateOrderGroup(ctx, &orders.OrderGroupRequest{
+			UserID: "bob",
+			Type:   orders.OrderGroupOCO,
+			Legs: []*orders.OrderRequest{
+				request("bob", orders.OrderSideBuy, ""),
+				request("bob", orders.OrderSideSell, ""),
+			},
+		})
+		assert.Equal(t, orders.ErrKillSwitchEngaged, err)
+		place("alice", orders.OrderSideBuy, "")
+
+		_, _, err = service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{
+			Scope: orders.KillSwitchStrategy,
+			Value: "momentum",
+			Actor: "risk",
+		})
+		require.NoError(t, err)
+		_, err = service.CreateOrder(ctx, request("alice", orders.OrderSideBuy, "momentum"))
+		assert.Equal(t, orders.ErrKillSwitchEngaged, err)
+
+		engaged := service.GetKillSwitches()
+		require.Len(t, engaged, 2)
+		assert.Equal(t, orders.KillSwitchUser, engaged[0].Scope)
+		assert.Equal(t, orders.KillSwitchStrategy, engaged[1].Scope)
+
+		_, err = service.DisableKillSwitch(ctx, orders.KillSwitchUser, "bob", "risk", "fixed")
+		require.NoError(t, err)
+		_, err = service.DisableKillSwitch(ctx, orders.KillSwitchUser, "bob", "risk", "fixed")
+		assert.Equal(t, orders.ErrKillSwitchNotFound, err)
+		place("bob", orders.OrderSideBuy, "")
+	})
+
+	t.Run("cancel on disconnect", func(t *testing.T) {
+		resting := place("carol", orders.OrderSideBuy, "")
+		service.OpenSession("ws-1", "carol", true)
+		service.OpenSession("grpc-1", "carol", true)
+		service.OpenSession("ws-2", "carol", false)
+
+		assert.Empty(t, service.CloseSession(ctx, "ws-1"), "another session still asks to cancel")
+		assert.Empty(t, service.CloseSession(ctx, "ws-2"))
+		cancelled := service.CloseSession(ctx, "grpc-1")
+		require.Len(t, cancelled, 1)
+		assert.Same(t, resting, cancelled[0])
+		assert.Empty(t, service.CloseSession(ctx, "grpc-1"))
+	})
+
+	var actions []string
+	for _, record := range audit.records {
+		actions = append(actions, record.Action)
+	}
+	assert.Equal(t, []string{
+		orders.AuditActionMassCancel,
+		orders.AuditActionMassCancel,
+		orders.AuditActionKillSwitchEnabled,
+		orders.AuditActionKillSwitchEnabled,
+		orders.AuditActionKillSwitchDisabled,
+		orders.AuditActionCancelOnDisconnect,
+	}, actions)
+	assert.Equal(t, "user", audit.records[2].Scope)
+	assert.Equal(t, "bob", audit.records[2].Value)
+	assert.Contains(t, audit.records[2].Details, bobSell.ID)
+}
+
+func TestOrderService_RecoversKillSwitches(t *testing.T) {
+	ctx := context.Background()
+	table := newKillSwitchTable()
+
+	service := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+	service.SetKillSwitchRepository(table)
+	_, _, err := service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{
+		Scope: orders.KillSwitchUser,
+		Value: "bob",
+		Actor: "risk",
+	})
+	require.NoError(t, err)
+	_, _, err = service.EnableKillSwitch(ctx, &orders.KillSwitchRequest{
+		Scope: orders.KillSwitchSymbol,
+		Value: "AAPL",
+		Actor: "risk",
+	})
+	require.NoError(t, err)
+	_, err = service.DisableKillSwitch(ctx, orders.KillSwitchSymbol, "AAPL", "risk", "")
+	require.NoError(t, err)
+	require.Len(t, table.rows, 1)
+
+	restarted := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+	restarted.SetKillSwitchRepository(table)
+	_, err = restarted.Recover(ctx)
+	require.NoError(t, err)
+
+	killSwitches := restarted.GetKillSwitches()
+	require.Len(t, killSwitches, 1)
+	assert.Equal(t, orders.KillSwitchUser, killSwitches[0].Scope)
+	assert.Equal(t, "bob", killSwitches[0].Value)
+	assert.Equal(t, "risk", killSwitches[0].Actor)
+
+	_, _, err = restarted.PlaceOrder(ctx, &orders.OrderRequest{
+		UserID:   "bob",
+		Symbol:   "AAPL",
+		Side:     orders.OrderSideBuy,
+		Type:     orders.OrderTypeLimit,
+		Price:    types.MustParseDecimal("99"),
+		Quantity: types.MustParseDecimal("1"),
+	})
+	assert.ErrorIs(t, err, orders.ErrKillSwitchEngaged)
+}
+
+func TestHandler_OrderControlRoles(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	service := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+	audit := &auditTrail{}
+	service.SetAuditRepository(audit)
+	handler := orders.NewHandler(orders.HandlerParams{Logger: zap.NewNop(), Service: service})
+
+	place := func(user string) *orders.Order {
+		order, _, err := service.PlaceOrder(context.Background(), &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      "AAPL",
+			Side:        orders.OrderSideBuy,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal("99"),
+			Quantity:    types.MustParseDecimal("1"),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		return order
+	}
+	caller := func(user, role string) context.Context {
+		return auth.ContextWithClaims(context.Background(), &auth.JWTClaims{
+			UserID:   user,
+			Username: user + "-login",
+			Role:     role,
+		})
+	}
+
+	t.Run("gRPC", func(t *testing.T) {
+		alice := place("alice")
+		bob := place("bob")
+
+		_, err := handler.MassCancel(context.Background(), &orderspb.MassCancelRequest{Symbol: "AAPL"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		// A trader asking for every AAPL order only cancels their own
+		rsp, err := handler.MassCancel(caller("alice", "trader"), &orderspb.MassCancelRequest{
+			Symbol: "AAPL",
+			Actor:  "someone-else",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{alice.ID}, rsp.CancelledOrderIds)
+		assert.Equal(t, "alice-login", audit.records[len(audit.records)-1].Actor)
+
+		_, err = handler.SetKillSwitch(caller("alice", "trader"), &orderspb.SetKillSwitchRequest{
+			Scope:   orderspb.KillSwitchScope_SCOPE_USER,
+			Value:   "bob",
+			Enabled: true,
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, service.GetKillSwitches())
+
+		killSwitch, err := handler.SetKillSwitch(caller("carol", "risk"), &orderspb.SetKillSwitchRequest{
+			Scope:            orderspb.KillSwitchScope_SCOPE_USER,
+			Value:            "bob",
+			Enabled:          true,
+			CancelOpenOrders: true,
+			Actor:            "someone-else",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "carol-login", killSwitch.Actor)
+		assert.Equal(t, []string{bob.ID}, killSwitch.CancelledOrderIds)
+
+		_, err = handler.SetKillSwitch(caller("carol", "risk"), &orderspb.SetKillSwitchRequest{
+			Scope: orderspb.KillSwitchScope_SCOPE_USER,
+			Value: "bob",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, service.GetKillSwitches())
+	})
+
+	t.Run("REST", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		alice := place("alice")
+		bob := place("bob")
+
+		router := func(user, role string) *gin.Engine {
+			engine := gin.New()
+			group := engine.Group("/api/v1", func(c *gin.Context) {
+				c.Set("userID", user)
+				c.Set("username", user+"-login")
+				c.Set("role", role)
+			})
+			handlers.NewOrderControlHandlers(service, zap.NewNop()).RegisterRoutes(group)
+			return engine
+		}
+		serve := func(engine *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		trader := router("alice", "trader")
+		rsp := serve(trader, http.MethodPost, "/api/v1/kill-switches", `{"scope":"user","value":"bob"}`)
+		assert.Equal(t, http.StatusForbidden, rsp.Code)
+		rsp = serve(trader, http.MethodDelete, "/api/v1/kill-switches/user/bob", "")
+		assert.Equal(t, http.StatusForbidden, rsp.Code)
+		assert.Empty(t, service.GetKillSwitches())
+
+		rsp = serve(trader, http.MethodPost, "/api/v1/orders/mass-cancel", `{"user_id":"bob"}`)
+		require.Equal(t, http.StatusOK, rsp.Code)
+		assert.Contains(t, rsp.Body.String(), alice.ID)
+		assert.NotContains(t, rsp.Body.String(), bob.ID)
+		assert.Equal(t, "alice-login", audit.records[len(audit.records)-1].Actor)
+
+		officer := router("carol", "risk")
+		rsp = serve(officer, http.MethodPost, "/api/v1/orders/mass-cancel", `{"user_id":"bob"}`)
+		require.Equal(t, http.StatusOK, rsp.Code)
+		assert.Contains(t, rsp.Body.String(), bob.ID)
+
+		rsp = serve(officer, http.MethodPost, "/api/v1/kill-switches", `{"scope":"user","value":"bob"}`)
+		require.Equal(t, http.StatusOK, rsp.Code)
+		assert.Contains(t, rsp.Body.String(), `"actor":"carol-login"`)
+		rsp = serve(officer, http.MethodDelete, "/api/v1/kill-switches/user/bob", "")
+		require.Equal(t, http.StatusOK, rsp.Code)
+		assert.Empty(t, service.GetKillSwitches())
+	})
+}
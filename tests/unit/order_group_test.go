@@ -0,0 +1,232 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	orderspb "github.com/abdoElHodaky/tradSys/proto/orders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// groupRecorder keeps the last saved state of each order group
+type groupRecorder map[string]*db.OrderGroup
+
+func (r groupRecorder) SaveOrderGroup(ctx context.Context, group *db.OrderGroup) error {
+	r[group.ID] = group
+	return nil
+}
+
+func TestOrderService_ContingentGroups(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+	saved := groupRecorder{}
+	service.SetGroupRepository(saved)
+
+	request := func(side orders.OrderSide, orderType orders.OrderType, price, stop, quantity string) *orders.OrderRequest {
+		return &orders.OrderRequest{
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        orderType,
+			Price:       types.MustParseDecimal(price),
+			StopPrice:   types.MustParseDecimal(stop),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: orders.TimeInForceGTC,
+		}
+	}
+	place := func(user string, req *orders.OrderRequest) *orders.Order {
+		req.UserID = user
+		order, err := service.CreateOrder(ctx, req)
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+	get := func(orderID string) *orders.Order {
+		order, err := service.GetOrder(ctx, orderID)
+		require.NoError(t, err)
+		return order
+	}
+
+	t.Run("bracket", func(t *testing.T) {
+		group, err := service.CreateOrderGroup(ctx, &orders.OrderGroupRequest{
+			UserID:  "alice",
+			Type:    orders.OrderGroupBracket,
+			Primary: request(orders.OrderSideBuy, orders.OrderTypeLimit, "100", "0", "10"),
+			Legs: []*orders.OrderRequest{
+				request(orders.OrderSideSell, orders.OrderTypeLimit, "110", "0", "10"),
+				request(orders.OrderSideSell, orders.OrderTypeStopMarket, "0", "95", "10"),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderGroupStatusPending, group.Status)
+		takeProfit, stopLoss := get(group.LegOrderIDs[0]), get(group.LegOrderIDs[1])
+		assert.Equal(t, orders.OrderStatusNew, takeProfit.Status, "exits wait for the entry")
+		assert.Equal(t, group.PrimaryOrderID, stopLoss.ParentOrderID)
+
+		// Filling the entry against a resting seller releases both exits
+		place("bob", request(orders.OrderSideSell, orders.OrderTypeLimit, "100", "0", "10"))
+		assert.Equal(t, orders.OrderStatusFilled, get(group.PrimaryOrderID).Status)
+		assert.Equal(t, orders.OrderStatusPending, takeProfit.Status)
+		assert.Equal(t, orders.OrderStatusPending, stopLoss.Status)
+		group, err = service.GetOrderGroup(ctx, group.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderGroupStatusActive, group.Status)
+
+		// A trade through the stop elects the stop-loss, which cancels the
+		// take-profit
+		place("carol", request(orders.OrderSideBuy, orders.OrderTypeLimit, "94", "0", "20"))
+		place("dave", request(orders.OrderSideSell, orders.OrderTypeLimit, "94", "0", "1"))
+		assert.Equal(t, orders.OrderStatusFilled, stopLoss.Status)
+		assert.Equal(t, orders.OrderStatusCancelled, takeProfit.Status)
+		_, asks := engine.GetOrderBook("AAPL").GetDepth(5)
+		assert.Empty(t, asks, "the take-profit left the book")
+
+		group, err = service.GetOrderGroup(ctx, group.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderGroupStatusCompleted, group.Status)
+		assert.Equal(t, string(orders.OrderGroupStatusCompleted), saved[group.ID].Status)
+	})
+
+	t.Run("oco", func(t *testing.T) {
+		group, err := service.CreateOrderGroup(ctx, &orders.OrderGroupRequest{
+			UserID: "alice",
+			Type:   orders.OrderGroupOCO,
+			Legs: []*orders.OrderRequest{
+				request(orders.OrderSideSell, orders.OrderTypeLimit, "120", "0", "5"),
+				request(orders.OrderSideSell, orders.OrderTypeLimit, "125", "0", "5"),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderGroupStatusActive, group.Status)
+
+		// A partial fill on one leg cancels the other
+		place("bob", request(orders.OrderSideBuy, orders.OrderTypeLimit, "120", "0", "2"))
+		assert.Equal(t, orders.OrderStatusPartiallyFilled, get(group.LegOrderIDs[0]).Status)
+		assert.Equal(t, orders.OrderStatusCancelled, get(group.LegOrderIDs[1]).Status)
+		_, asks := engine.GetOrderBook("AAPL").GetDepth(5)
+		require.Len(t, asks, 1)
+		assert.Equal(t, "3", asks[0].Quantity.String())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		group, err := service.CreateOrderGroup(ctx, &orders.OrderGroupRequest{
+			UserID:  "alice",
+			Type:    orders.OrderGroupOTO,
+			Primary: request(orders.OrderSideBuy, orders.OrderTypeLimit, "90", "0", "5"),
+			Legs: []*orders.OrderRequest{
+				request(orders.OrderSideSell, orders.OrderTypeLimit, "130", "0", "5"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = service.CancelOrderGroup(ctx, "bob", group.ID)
+		assert.Equal(t, orders.ErrUnauthorizedOrderAccess, err)
+
+		group, err = service.CancelOrderGroup(ctx, "alice", group.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderGroupStatusCancelled, group.Status)
+		assert.Equal(t, orders.OrderStatusCancelled, get(group.PrimaryOrderID).Status)
+		assert.Equal(t, orders.OrderStatusCancelled, get(group.LegOrderIDs[0]).Status)
+
+		_, err = service.CancelOrderGroup(ctx, "alice", group.ID)
+		assert.Equal(t, orders.ErrOrderGroupClosed, err)
+	})
+
+	t.Run("invalid bracket", func(t *testing.T) {
+		_, err := service.CreateOrderGroup(ctx, &orders.OrderGroupRequest{
+			UserID:  "alice",
+			Type:    orders.OrderGroupBracket,
+			Primary: request(orders.OrderSideBuy, orders.OrderTypeLimit, "100", "0", "10"),
+			Legs: []*orders.OrderRequest{
+				request(orders.OrderSideSell, orders.OrderTypeLimit, "90", "0", "10"),
+				request(orders.OrderSideSell, orders.OrderTypeStopMarket, "0", "95", "10"),
+			},
+		})
+		assert.Equal(t, orders.ErrInvalidBracket, err)
+	})
+}
+
+func TestHandler_OrderGroups(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+	handler := orders.NewHandler(orders.HandlerParams{Logger: zap.NewNop(), Service: service})
+	leg := func(price float64) *orderspb.CreateOrderRequest {
+		return &orderspb.CreateOrderRequest{
+			Symbol:      "AAPL",
+			Side:        orderspb.OrderSide_SELL,
+			Type:        orderspb.OrderType_LIMIT,
+			Quantity:    5,
+			Price:       price,
+			TimeInForce: orderspb.TimeInForce_GTC,
+		}
+	}
+
+	rsp, err := handler.CreateOrderGroup(ctx, &orderspb.CreateOrderGroupRequest{
+		UserId: "alice",
+		Type:   orderspb.OrderGroupType_GROUP_OCO,
+		Legs:   []*orderspb.CreateOrderRequest{leg(120), leg(125)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, orderspb.OrderGroupStatus_GROUP_ACTIVE, rsp.Status)
+	require.Len(t, rsp.LegOrderIds, 2)
+
+	// The legs rest in the book
+	_, asks := engine.GetOrderBook("AAPL").GetDepth(5)
+	assert.Len(t, asks, 2)
+	for _, id := range rsp.LegOrderIds {
+		order, err := service.GetOrder(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderStatusPending, order.Status)
+	}
+
+	found, err := handler.GetOrderGroup(ctx, &orderspb.GetOrderGroupRequest{Id: rsp.Id, UserId: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, rsp.LegOrderIds, found.LegOrderIds)
+
+	_, err = handler.CancelOrderGroup(ctx, &orderspb.CancelOrderGroupRequest{Id: rsp.Id, UserId: "bob"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Cancelling the group takes every leg off the book
+	cancelled, err := handler.CancelOrderGroup(ctx, &orderspb.CancelOrderGroupRequest{Id: rsp.Id, UserId: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, orderspb.OrderGroupStatus_GROUP_CANCELLED, cancelled.Status)
+	_, asks = engine.GetOrderBook("AAPL").GetDepth(5)
+	assert.Empty(t, asks)
+	for _, id := range rsp.LegOrderIds {
+		order, err := service.GetOrder(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderStatusCancelled, order.Status)
+	}
+
+	_, err = handler.CancelOrderGroup(ctx, &orderspb.CancelOrderGroupRequest{Id: rsp.Id, UserId: "alice"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = handler.GetOrderGroup(ctx, &orderspb.GetOrderGroupRequest{Id: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
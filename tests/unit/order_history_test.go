@@ -0,0 +1,269 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/auth"
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	orderspb "github.com/abdoElHodaky/tradSys/proto/orders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transitionLog keeps the recorded order status changes in order
+type transitionLog struct {
+	mu          sync.Mutex
+	transitions []*db.OrderTransition
+}
+
+func (l *transitionLog) CreateOrderTransition(ctx context.Context, transition *db.OrderTransition) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.transitions = append(l.transitions, transition)
+	return nil
+}
+
+func (l *transitionLog) GetOrderTransitions(ctx context.Context, orderID string) ([]*db.OrderTransition, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var transitions []*db.OrderTransition
+	for _, transition := range l.transitions {
+		if transition.OrderID == orderID {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// step is the status an order moved to and the reason it moved
+type step struct {
+	status orders.OrderStatus
+	reason orders.TransitionReason
+}
+
+func TestOrderService_OrderHistory(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	service := orders.NewOrderService(engine, zap.NewNop())
+	saved := &transitionLog{}
+	service.SetHistoryRepository(saved)
+
+	place := func(user string, orderType orders.OrderType, price string) *orders.Order {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      "AAPL",
+			Side:        orders.OrderSideBuy,
+			Type:        orderType,
+			Price:       types.MustParseDecimal(price),
+			Quantity:    types.MustParseDecimal("1"),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		_ = service.SubmitOrder(ctx, order)
+		return order
+	}
+	steps := func(orderID string) []step {
+		history, err := service.GetOrderHistory(ctx, "", orderID)
+		require.NoError(t, err)
+		var taken []step
+		for _, change := range history {
+			taken = append(taken, step{change.ToStatus, change.Reason})
+		}
+		return taken
+	}
+	last := func(orderID string) step {
+		taken := steps(orderID)
+		return taken[len(taken)-1]
+	}
+
+	t.Run("amend and cancel", func(t *testing.T) {
+		order := place("alice", orders.OrderTypeLimit, "99")
+
+		_, err := service.UpdateOrder(ctx, &orders.OrderUpdateRequest{
+			UserID:  "alice",
+			OrderID: order.ID,
+			Symbol:  "AAPL",
+			Price:   types.MustParseDecimal("98"),
+		})
+		require.NoError(t, err)
+		_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{UserID: "alice", OrderID: order.ID})
+		require.NoError(t, err)
+
+		assert.Equal(t, []step{
+			{orders.OrderStatusNew, orders.ReasonOrderCreated},
+			{orders.OrderStatusPendingNew, orders.ReasonSubmitted},
+			{orders.OrderStatusPending, orders.ReasonAccepted},
+			{orders.OrderStatusPendingReplace, orders.ReasonReplaceRequested},
+			{orders.OrderStatusPending, orders.ReasonReplaced},
+			{orders.OrderStatusPendingCancel, orders.ReasonCancelRequested},
+			{orders.OrderStatusCancelled, orders.ReasonUserCancelled},
+		}, steps(order.ID))
+
+		_, err = service.GetOrderHistory(ctx, "mallory", order.ID)
+		assert.Equal(t, orders.ErrOrderNotFound, err)
+		history, err := service.GetOrderHistory(ctx, "alice", order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderStatusPendingCancel, history[6].FromStatus)
+		assert.Equal(t, 6, history[6].Sequence)
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		order := place("erin", orders.OrderTypeLimit, "93")
+		handler := orders.NewHandler(orders.HandlerParams{Logger: zap.NewNop(), Service: service})
+		lookup := func(ctx context.Context, userID string) (*orderspb.GetOrderHistoryResponse, error) {
+			return handler.GetOrderHistory(ctx, &orderspb.GetOrderHistoryRequest{OrderId: order.ID, UserId: userID})
+		}
+		caller := func(role string) context.Context {
+			return auth.ContextWithClaims(ctx, &auth.JWTClaims{UserID: "frank", Username: "frank", Role: role})
+		}
+
+		rsp, err := lookup(ctx, "erin")
+		require.NoError(t, err)
+		assert.Len(t, rsp.Transitions, 3)
+		_, err = lookup(ctx, "frank")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// Looking up any user's order needs a support role
+		_, err = lookup(ctx, "")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = lookup(caller("trader"), "")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		rsp, err = lookup(caller("support"), "")
+		require.NoError(t, err)
+		assert.Len(t, rsp.Transitions, 3)
+
+		_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{UserID: "erin", OrderID: order.ID})
+		require.NoError(t, err)
+	})
+
+	t.Run("trading day", func(t *testing.T) {
+		order := place("bob", orders.OrderTypeLimit, "97")
+
+		closed := service.CloseTradingDay(ctx, "AAPL")
+		require.Len(t, closed, 1)
+		assert.Same(t, order, closed[0])
+		assert.Equal(t, step{orders.OrderStatusDoneForDay, orders.ReasonTradingDayClosed}, last(order.ID))
+
+		opened := service.OpenTradingDay(ctx, "")
+		require.Len(t, opened, 1)
+		assert.Equal(t, step{orders.OrderStatusPending, orders.ReasonTradingDayOpened}, last(order.ID))
+	})
+
+	t.Run("history repository", func(t *testing.T) {
+		order := place("dave", orders.OrderTypeLimit, "94")
+
+		restarted := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+		restarted.SetHistoryRepository(saved)
+		history, err := restarted.GetOrderHistory(ctx, "dave", order.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, orders.OrderStatusPending, history[2].ToStatus)
+		assert.Equal(t, orders.ReasonAccepted, history[2].Reason)
+
+		_, err = restarted.GetOrderHistory(ctx, "", "unknown")
+		assert.Equal(t, orders.ErrOrderNotFound, err)
+	})
+
+	t.Run("trading halt", func(t *testing.T) {
+		require.NoError(t, service.Start())
+		defer service.Stop()
+
+		resting := place("carol", orders.OrderTypeLimit, "96")
+		engine.Halt("AAPL", "news pending")
+		assert.Eventually(t, func() bool {
+			return last(resting.ID) == step{orders.OrderStatusSuspended, orders.ReasonTradingHalted}
+		}, time.Second, 10*time.Millisecond)
+
+		queued := place("carol", orders.OrderTypeLimit, "95")
+		assert.Equal(t, step{orders.OrderStatusSuspended, orders.ReasonTradingHalted}, last(queued.ID))
+
+		rejected := place("carol", orders.OrderTypeMarket, "0")
+		history, err := service.GetOrderHistory(ctx, "carol", rejected.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orders.OrderStatusRejected, history[len(history)-1].ToStatus)
+		assert.Equal(t, orders.ReasonEngineRejected, history[len(history)-1].Reason)
+		assert.NotEqual(t, types.RejectReasonNone, history[len(history)-1].RejectReason)
+
+		_, err = engine.Resume("AAPL")
+		require.NoError(t, err)
+		for _, order := range []*orders.Order{resting, queued} {
+			orderID := order.ID
+			assert.Eventually(t, func() bool {
+				return last(orderID) == step{orders.OrderStatusPending, orders.ReasonTradingResumed}
+			}, time.Second, 10*time.Millisecond)
+		}
+	})
+}
+
+func TestOrderService_ExpiresOrdersThroughTheSequencer(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	sequencer := matching.NewSequencer(zap.NewNop(), 2, nil)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop()
+	service := orders.NewOrderService(sequencer, zap.NewNop())
+	require.NoError(t, service.Start())
+	defer service.Stop()
+
+	order, _, err := service.PlaceOrder(ctx, &orders.OrderRequest{
+		UserID:      "alice",
+		Symbol:      "AAPL",
+		Side:        orders.OrderSideBuy,
+		Type:        orders.OrderTypeLimit,
+		Price:       types.MustParseDecimal("100"),
+		Quantity:    types.MustParseDecimal("1"),
+		TimeInForce: orders.TimeInForceGTD,
+		ExpiresAt:   time.Now().Add(200 * time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	// The expiry ticker removes the order from the book and the lifecycle
+	// records a single expiry
+	require.Eventually(t, func() bool {
+		_, resting := sequencer.GetOrder("AAPL", order.ID)
+		return !resting
+	}, 3*time.Second, 50*time.Millisecond)
+	require.Eventually(t, func() bool {
+		history, err := service.GetOrderHistory(ctx, "alice", order.ID)
+		return err == nil && history[len(history)-1].ToStatus == orders.OrderStatusExpired
+	}, time.Second, 10*time.Millisecond)
+
+	history, err := service.GetOrderHistory(ctx, "alice", order.ID)
+	require.NoError(t, err)
+	var expiries int
+	for _, change := range history {
+		if change.ToStatus == orders.OrderStatusExpired {
+			expiries++
+			assert.Equal(t, orders.ReasonExpired, change.Reason)
+		}
+	}
+	assert.Equal(t, 1, expiries)
+}
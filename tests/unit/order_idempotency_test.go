@@ -0,0 +1,163 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOrderService_ClientOrderIDReplay(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	table := &orderTable{records: make(map[string]*db.Order)}
+	service := orders.NewOrderService(engine, zap.NewNop())
+	service.SetOrderRepository(table)
+
+	request := func(user, clientOrderID string, side orders.OrderSide) *orders.OrderRequest {
+		return &orders.OrderRequest{
+			UserID:        user,
+			ClientOrderID: clientOrderID,
+			Symbol:        "AAPL",
+			Side:          side,
+			Type:          orders.OrderTypeLimit,
+			Price:         types.MustParseDecimal("100"),
+			Quantity:      types.MustParseDecimal("5"),
+			TimeInForce:   orders.TimeInForceGTC,
+		}
+	}
+
+	original, replayed, err := service.PlaceOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	// A retry after a timeout gets the original order back
+	retried, replayed, err := service.PlaceOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Same(t, original, retried)
+	_, err = service.CreateOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	assert.Equal(t, orders.ErrDuplicateClientOrderID, err)
+
+	// Client order IDs are scoped to the user
+	other, replayed, err := service.PlaceOrder(ctx, request("bob", "c-1", orders.OrderSideSell))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, original.ID, other.ID)
+	assert.Equal(t, orders.OrderStatusFilled, original.Status)
+
+	placed, err := service.GetOrdersByUser(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.Len(t, placed, 1)
+
+	// The dedup state survives a restart through the repository
+	restarted := orders.NewOrderService(engine, zap.NewNop())
+	restarted.SetOrderRepository(table)
+	replay, replayed, err := restarted.PlaceOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, original.ID, replay.ID)
+	assert.Equal(t, orders.OrderStatusFilled, replay.Status)
+
+	// Outside the window the client order ID may be reused
+	restarted.SetDedupWindow(time.Hour)
+	table.records[original.ID].CreatedAt = time.Now().Add(-2 * time.Hour)
+	reused, replayed, err := restarted.PlaceOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, original.ID, reused.ID)
+
+	reused.CreatedAt = time.Now().Add(-2 * time.Hour)
+	_, replayed, err = restarted.PlaceOrder(ctx, request("alice", "c-1", orders.OrderSideBuy))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+}
+
+// slowTable blocks client order ID lookups until released
+type slowTable struct {
+	*orderTable
+	looking chan struct{}
+	release chan struct{}
+}
+
+func (t *slowTable) FindOrders(ctx context.Context, query *repositories.OrderQuery) ([]*db.Order, error) {
+	if query.ClientOrderID != "" {
+		t.looking <- struct{}{}
+		<-t.release
+	}
+	return t.orderTable.FindOrders(ctx, query)
+}
+
+func TestOrderService_ClientOrderIDLookupDoesNotBlock(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+
+	ctx := context.Background()
+	table := &slowTable{
+		orderTable: &orderTable{records: make(map[string]*db.Order)},
+		looking:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	service := orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+	service.SetOrderRepository(table)
+
+	request := func(user, clientOrderID string) *orders.OrderRequest {
+		return &orders.OrderRequest{
+			UserID:        user,
+			ClientOrderID: clientOrderID,
+			Symbol:        "AAPL",
+			Side:          orders.OrderSideBuy,
+			Type:          orders.OrderTypeLimit,
+			Price:         types.MustParseDecimal("100"),
+			Quantity:      types.MustParseDecimal("5"),
+			TimeInForce:   orders.TimeInForceGTC,
+		}
+	}
+
+	type result struct {
+		order *orders.Order
+		err   error
+	}
+	retried := make(chan result)
+	go func() {
+		order, err := service.CreateOrder(ctx, request("alice", "c-1"))
+		retried <- result{order, err}
+	}()
+	<-table.looking
+
+	// Other orders are placed while the lookup is outstanding
+	placed := make(chan error)
+	go func() {
+		_, err := service.CreateOrder(ctx, request("bob", ""))
+		placed <- err
+	}()
+	select {
+	case err := <-placed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("order creation blocked behind the repository lookup")
+	}
+
+	close(table.release)
+	created := <-retried
+	require.NoError(t, created.err)
+	assert.Equal(t, "c-1", created.order.ClientOrderID)
+}
@@ -2,537 +2,334 @@ package unit
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
-func TestOrderService_CreateOrder(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
+// newManagedOrderService returns an order service over a fresh matching
+// engine with no listed instruments, so the default order limits apply
+func newManagedOrderService(t testing.TB) *orders.OrderService {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	t.Cleanup(func() { types.Instruments = registry })
 
-	ctx := context.Background()
+	return orders.NewOrderService(matching.NewMatchingEngine(zap.NewNop()), zap.NewNop())
+}
 
-	// Test valid order creation
-	orderReq := &orders.CreateOrderRequest{
-		UserID:      "user-001",
-		ClientOrderID: "client-001",
-		Symbol:      "AAPL",
-		Side:        orders.SideBuy,
-		Type:        orders.TypeLimit,
-		Quantity:    100,
-		Price:       150.50,
-		TimeInForce: orders.TimeInForceGTC,
+// limitOrderRequest returns a GTC limit buy request
+func limitOrderRequest(user, clientOrderID, symbol, quantity, price string) *orders.OrderRequest {
+	return &orders.OrderRequest{
+		UserID:        user,
+		ClientOrderID: clientOrderID,
+		Symbol:        symbol,
+		Side:          orders.OrderSideBuy,
+		Type:          orders.OrderTypeLimit,
+		Quantity:      types.MustParseDecimal(quantity),
+		Price:         types.MustParseDecimal(price),
+		TimeInForce:   orders.TimeInForceGTC,
 	}
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	service := newManagedOrderService(t)
+	ctx := context.Background()
 
-	order, err := service.CreateOrder(ctx, orderReq)
+	// Test valid order creation
+	order, err := service.CreateOrder(ctx, limitOrderRequest("user-001", "client-001", "AAPL", "100", "150.50"))
 	require.NoError(t, err)
-	assert.NotNil(t, order)
+	require.NotNil(t, order)
 
 	// Verify order fields
 	assert.NotEmpty(t, order.ID)
 	assert.Equal(t, "user-001", order.UserID)
 	assert.Equal(t, "client-001", order.ClientOrderID)
 	assert.Equal(t, "AAPL", order.Symbol)
-	assert.Equal(t, orders.SideBuy, order.Side)
-	assert.Equal(t, orders.TypeLimit, order.Type)
-	assert.Equal(t, float64(100), order.Quantity)
-	assert.Equal(t, 150.50, order.Price)
-	assert.Equal(t, orders.StatusNew, order.Status)
+	assert.Equal(t, orders.OrderSideBuy, order.Side)
+	assert.Equal(t, orders.OrderTypeLimit, order.Type)
+	assert.True(t, types.MustParseDecimal("100").Equal(order.Quantity))
+	assert.True(t, types.MustParseDecimal("150.50").Equal(order.Price))
+	assert.Equal(t, orders.OrderStatusNew, order.Status)
 	assert.WithinDuration(t, time.Now(), order.CreatedAt, time.Minute)
 }
 
 func TestOrderService_ValidateOrder(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(t)
 	ctx := context.Background()
 
 	// Test invalid order - missing required fields
-	invalidOrder := &orders.CreateOrderRequest{
-		UserID: "user-001",
-		// Missing Symbol, Side, Type, Quantity
-	}
-
-	_, err := service.CreateOrder(ctx, invalidOrder)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "symbol is required")
+	_, err := service.CreateOrder(ctx, &orders.OrderRequest{UserID: "user-001"})
+	assert.ErrorIs(t, err, orders.ErrMissingSymbol)
 
 	// Test invalid order - negative quantity
-	negativeQtyOrder := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeLimit,
-		Quantity: -100, // Invalid
-		Price:    150.50,
-	}
-
-	_, err = service.CreateOrder(ctx, negativeQtyOrder)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "quantity must be positive")
+	_, err = service.CreateOrder(ctx, limitOrderRequest("user-001", "", "AAPL", "-100", "150.50"))
+	assert.ErrorIs(t, err, orders.ErrInvalidQuantity)
 
-	// Test invalid order - market order with price
-	marketOrderWithPrice := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeMarket,
-		Quantity: 100,
-		Price:    150.50, // Invalid for market order
-	}
-
-	_, err = service.CreateOrder(ctx, marketOrderWithPrice)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "market orders cannot have price")
+	// Test invalid order - stop limit order without stop price
+	stopLimit := limitOrderRequest("user-001", "", "AAPL", "100", "150.50")
+	stopLimit.Type = orders.OrderTypeStopLimit
+	_, err = service.CreateOrder(ctx, stopLimit)
+	assert.ErrorIs(t, err, orders.ErrMissingStopPrice)
 
 	// Test invalid order - limit order without price
-	limitOrderWithoutPrice := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeLimit,
-		Quantity: 100,
-		// Missing Price
-	}
-
-	_, err = service.CreateOrder(ctx, limitOrderWithoutPrice)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "limit orders must have price")
+	noPrice := limitOrderRequest("user-001", "", "AAPL", "100", "0")
+	_, err = service.CreateOrder(ctx, noPrice)
+	assert.ErrorIs(t, err, orders.ErrMissingPrice)
 }
 
 func TestOrderService_UpdateOrder(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(t)
 	ctx := context.Background()
 
-	// Create initial order
-	orderReq := &orders.CreateOrderRequest{
-		UserID:      "user-001",
-		ClientOrderID: "client-001",
-		Symbol:      "AAPL",
-		Side:        orders.SideBuy,
-		Type:        orders.TypeLimit,
-		Quantity:    100,
-		Price:       150.50,
-		TimeInForce: orders.TimeInForceGTC,
-	}
-
-	order, err := service.CreateOrder(ctx, orderReq)
+	// Place the initial order in the book
+	order, _, err := service.PlaceOrder(ctx, limitOrderRequest("user-001", "client-001", "AAPL", "100", "150.50"))
 	require.NoError(t, err)
+	createdAt := order.UpdatedAt
 
 	// Update order
-	updateReq := &orders.UpdateOrderRequest{
+	updatedOrder, err := service.UpdateOrder(ctx, &orders.OrderUpdateRequest{
 		OrderID:  order.ID,
 		UserID:   "user-001",
-		Quantity: 200,
-		Price:    151.00,
-	}
-
-	updatedOrder, err := service.UpdateOrder(ctx, updateReq)
+		Symbol:   "AAPL",
+		Quantity: types.MustParseDecimal("200"),
+		Price:    types.MustParseDecimal("151.00"),
+	})
 	require.NoError(t, err)
-	assert.NotNil(t, updatedOrder)
+	require.NotNil(t, updatedOrder)
 
-	// Verify updates
-	assert.Equal(t, float64(200), updatedOrder.Quantity)
-	assert.Equal(t, 151.00, updatedOrder.Price)
-	assert.Equal(t, orders.StatusPendingReplace, updatedOrder.Status)
-	assert.After(t, updatedOrder.UpdatedAt, order.UpdatedAt)
+	// Verify updates; the book has applied the amend
+	assert.True(t, types.MustParseDecimal("200").Equal(updatedOrder.Quantity))
+	assert.True(t, types.MustParseDecimal("151.00").Equal(updatedOrder.Price))
+	assert.Equal(t, orders.OrderStatusPending, updatedOrder.Status)
+	assert.False(t, updatedOrder.UpdatedAt.Before(createdAt))
+
+	resting, err := service.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.True(t, types.MustParseDecimal("151.00").Equal(resting.Price))
 }
 
 func TestOrderService_CancelOrder(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(t)
 	ctx := context.Background()
 
-	// Create order
-	orderReq := &orders.CreateOrderRequest{
-		UserID:      "user-001",
-		ClientOrderID: "client-001",
-		Symbol:      "AAPL",
-		Side:        orders.SideBuy,
-		Type:        orders.TypeLimit,
-		Quantity:    100,
-		Price:       150.50,
-		TimeInForce: orders.TimeInForceGTC,
-	}
-
-	order, err := service.CreateOrder(ctx, orderReq)
+	order, _, err := service.PlaceOrder(ctx, limitOrderRequest("user-001", "client-001", "AAPL", "100", "150.50"))
 	require.NoError(t, err)
+	createdAt := order.UpdatedAt
 
-	// Cancel order
-	cancelReq := &orders.CancelOrderRequest{
-		OrderID: order.ID,
-		UserID:  "user-001",
-		Reason:  "User requested cancellation",
-	}
+	// Only the owner may cancel
+	_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{OrderID: order.ID, UserID: "wrong-user"})
+	assert.ErrorIs(t, err, orders.ErrUnauthorizedOrderAccess)
 
-	cancelledOrder, err := service.CancelOrder(ctx, cancelReq)
+	// Cancel order
+	cancelledOrder, err := service.CancelOrder(ctx, &orders.OrderCancelRequest{OrderID: order.ID, UserID: "user-001"})
 	require.NoError(t, err)
-	assert.NotNil(t, cancelledOrder)
+	require.NotNil(t, cancelledOrder)
 
 	// Verify cancellation
-	assert.Equal(t, orders.StatusCancelled, cancelledOrder.Status)
-	assert.Equal(t, "User requested cancellation", cancelledOrder.CancelReason)
-	assert.After(t, cancelledOrder.UpdatedAt, order.UpdatedAt)
+	assert.Equal(t, orders.OrderStatusCancelled, cancelledOrder.Status)
+	assert.False(t, cancelledOrder.UpdatedAt.Before(createdAt))
+
+	// A cancelled order cannot be cancelled again
+	_, err = service.CancelOrder(ctx, &orders.OrderCancelRequest{OrderID: order.ID, UserID: "user-001"})
+	assert.ErrorIs(t, err, orders.ErrOrderCannotBeCancelled)
 }
 
 func TestOrderService_GetOrder(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(t)
 	ctx := context.Background()
 
-	// Create order
-	orderReq := &orders.CreateOrderRequest{
-		UserID:      "user-001",
-		ClientOrderID: "client-001",
-		Symbol:      "AAPL",
-		Side:        orders.SideBuy,
-		Type:        orders.TypeLimit,
-		Quantity:    100,
-		Price:       150.50,
-		TimeInForce: orders.TimeInForceGTC,
-	}
-
-	createdOrder, err := service.CreateOrder(ctx, orderReq)
+	createdOrder, err := service.CreateOrder(ctx, limitOrderRequest("user-001", "client-001", "AAPL", "100", "150.50"))
 	require.NoError(t, err)
 
 	// Get order by ID
-	retrievedOrder, err := service.GetOrder(ctx, createdOrder.ID, "user-001")
+	retrievedOrder, err := service.GetOrder(ctx, createdOrder.ID)
 	require.NoError(t, err)
-	assert.NotNil(t, retrievedOrder)
+	require.NotNil(t, retrievedOrder)
 
 	// Verify order details
 	assert.Equal(t, createdOrder.ID, retrievedOrder.ID)
 	assert.Equal(t, createdOrder.UserID, retrievedOrder.UserID)
 	assert.Equal(t, createdOrder.Symbol, retrievedOrder.Symbol)
-	assert.Equal(t, createdOrder.Quantity, retrievedOrder.Quantity)
-	assert.Equal(t, createdOrder.Price, retrievedOrder.Price)
+	assert.True(t, createdOrder.Quantity.Equal(retrievedOrder.Quantity))
+	assert.True(t, createdOrder.Price.Equal(retrievedOrder.Price))
 
 	// Test get non-existent order
-	_, err = service.GetOrder(ctx, "non-existent-id", "user-001")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "order not found")
-
-	// Test get order with wrong user
-	_, err = service.GetOrder(ctx, createdOrder.ID, "wrong-user")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "unauthorized")
+	_, err = service.GetOrder(ctx, "non-existent-id")
+	assert.ErrorIs(t, err, orders.ErrOrderNotFound)
+
+	// Another user's orders do not include it
+	others, err := service.GetOrdersByUser(ctx, "wrong-user", nil)
+	require.NoError(t, err)
+	assert.Empty(t, others)
 }
 
 func TestOrderService_ListOrders(t *testing.T) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    true,
-		EnableCompliance:    true,
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(t)
 	ctx := context.Background()
 
 	// Create multiple orders
-	symbols := []string{"AAPL", "GOOGL", "MSFT"}
-	createdOrders := make([]*orders.Order, 0, len(symbols))
-
-	for _, symbol := range symbols {
-		orderReq := &orders.CreateOrderRequest{
-			UserID:      "user-001",
-			ClientOrderID: "client-" + symbol,
-			Symbol:      symbol,
-			Side:        orders.SideBuy,
-			Type:        orders.TypeLimit,
-			Quantity:    100,
-			Price:       150.50,
-			TimeInForce: orders.TimeInForceGTC,
-		}
-
-		order, err := service.CreateOrder(ctx, orderReq)
+	for _, symbol := range []string{"AAPL", "GOOGL", "MSFT"} {
+		_, err := service.CreateOrder(ctx, limitOrderRequest("user-001", "client-"+symbol, symbol, "100", "150.50"))
 		require.NoError(t, err)
-		createdOrders = append(createdOrders, order)
 	}
 
 	// List all orders for user
-	listReq := &orders.ListOrdersRequest{
-		UserID: "user-001",
-		Limit:  10,
-		Offset: 0,
-	}
-
-	orderList, err := service.ListOrders(ctx, listReq)
+	orderList, err := service.GetOrdersByUser(ctx, "user-001", &orders.OrderFilter{Limit: 10})
 	require.NoError(t, err)
-	assert.NotNil(t, orderList)
-	assert.Len(t, orderList.Orders, 3)
-	assert.Equal(t, int64(3), orderList.Total)
+	assert.Len(t, orderList, 3)
 
 	// List orders with symbol filter
-	listReqFiltered := &orders.ListOrdersRequest{
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Limit:  10,
-		Offset: 0,
-	}
-
-	filteredList, err := service.ListOrders(ctx, listReqFiltered)
+	filteredList, err := service.GetOrdersByUser(ctx, "user-001", &orders.OrderFilter{Symbol: "AAPL", Limit: 10})
 	require.NoError(t, err)
-	assert.NotNil(t, filteredList)
-	assert.Len(t, filteredList.Orders, 1)
-	assert.Equal(t, "AAPL", filteredList.Orders[0].Symbol)
+	require.Len(t, filteredList, 1)
+	assert.Equal(t, "AAPL", filteredList[0].Symbol)
 
 	// List orders with pagination
-	listReqPaginated := &orders.ListOrdersRequest{
-		UserID: "user-001",
-		Limit:  2,
-		Offset: 0,
-	}
+	paginatedList, err := service.GetOrdersByUser(ctx, "user-001", &orders.OrderFilter{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, paginatedList, 2)
 
-	paginatedList, err := service.ListOrders(ctx, listReqPaginated)
+	nextPage, err := service.GetOrdersByUser(ctx, "user-001", &orders.OrderFilter{Limit: 2, Offset: 2})
 	require.NoError(t, err)
-	assert.NotNil(t, paginatedList)
-	assert.Len(t, paginatedList.Orders, 2)
-	assert.Equal(t, int64(3), paginatedList.Total)
+	assert.Len(t, nextPage, 1)
 }
 
-func TestOrderLifecycle_StateTransitions(t *testing.T) {
-	lifecycle := orders.NewLifecycle(&orders.LifecycleConfig{
-		EnableStateValidation: true,
-		EnableAuditTrail:     true,
-		MaxRetries:           3,
-	})
+// newLifecycleOrder returns an order of 100 AAPL the lifecycle tracks from
+// status
+func newLifecycleOrder(t testing.TB, lifecycle *orders.OrderLifecycle, id string, status orders.OrderStatus) *orders.Order {
+	order := &orders.Order{
+		ID:       id,
+		UserID:   "user-001",
+		Symbol:   "AAPL",
+		Quantity: types.MustParseDecimal("100"),
+		Status:   status,
+	}
+	require.NoError(t, lifecycle.InitializeOrder(context.Background(), order))
+	return order
+}
 
+func TestOrderLifecycle_StateTransitions(t *testing.T) {
+	lifecycle := orders.NewOrderLifecycle(newManagedOrderService(t), zap.NewNop())
 	ctx := context.Background()
 
 	// Create order in NEW state
-	order := &orders.Order{
-		ID:     "order-001",
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Status: orders.StatusNew,
-	}
+	order := newLifecycleOrder(t, lifecycle, "order-001", orders.OrderStatusNew)
 
-	// Test valid transition: NEW -> PENDING
-	err := lifecycle.TransitionTo(ctx, order, orders.StatusPending, "Order submitted to exchange")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusPending, order.Status)
+	// Test valid transition: NEW -> PENDING_NEW
+	require.NoError(t, lifecycle.SubmitOrder(ctx, order))
+	assert.Equal(t, orders.OrderStatusPendingNew, order.Status)
 
-	// Test valid transition: PENDING -> PARTIALLY_FILLED
-	err = lifecycle.TransitionTo(ctx, order, orders.StatusPartiallyFilled, "Partial execution")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusPartiallyFilled, order.Status)
+	// Test valid transition: PENDING_NEW -> PARTIALLY_FILLED
+	order.FilledQuantity = types.MustParseDecimal("40")
+	require.NoError(t, lifecycle.UpdateOrderAfterExecution(ctx, order))
+	assert.Equal(t, orders.OrderStatusPartiallyFilled, order.Status)
 
 	// Test valid transition: PARTIALLY_FILLED -> FILLED
-	err = lifecycle.TransitionTo(ctx, order, orders.StatusFilled, "Order fully executed")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusFilled, order.Status)
+	order.FilledQuantity = types.MustParseDecimal("100")
+	require.NoError(t, lifecycle.UpdateOrderAfterExecution(ctx, order))
+	assert.Equal(t, orders.OrderStatusFilled, order.Status)
+
+	// Test invalid transition: FILLED -> PENDING_NEW (should fail)
+	err := lifecycle.SubmitOrder(ctx, order)
+	assert.ErrorIs(t, err, orders.ErrInvalidStatusTransition)
+	assert.Equal(t, orders.OrderStatusFilled, order.Status) // Status should remain unchanged
 
-	// Test invalid transition: FILLED -> PENDING (should fail)
-	err = lifecycle.TransitionTo(ctx, order, orders.StatusPending, "Invalid transition")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid state transition")
-	assert.Equal(t, orders.StatusFilled, order.Status) // Status should remain unchanged
+	state, err := lifecycle.GetOrderState(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, orders.OrderStatusFilled, state.CurrentStatus)
+	assert.Equal(t, orders.OrderStatusPartiallyFilled, state.PreviousStatus)
 }
 
 func TestOrderLifecycle_CancellationStates(t *testing.T) {
-	lifecycle := orders.NewLifecycle(&orders.LifecycleConfig{
-		EnableStateValidation: true,
-		EnableAuditTrail:     true,
-		MaxRetries:           3,
-	})
-
+	lifecycle := orders.NewOrderLifecycle(newManagedOrderService(t), zap.NewNop())
 	ctx := context.Background()
 
-	// Test cancellation from NEW state
-	newOrder := &orders.Order{
-		ID:     "order-001",
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Status: orders.StatusNew,
-	}
-
-	err := lifecycle.TransitionTo(ctx, newOrder, orders.StatusCancelled, "User cancellation")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusCancelled, newOrder.Status)
-
-	// Test cancellation from PENDING state
-	pendingOrder := &orders.Order{
-		ID:     "order-002",
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Status: orders.StatusPending,
-	}
-
-	err = lifecycle.TransitionTo(ctx, pendingOrder, orders.StatusCancelled, "User cancellation")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusCancelled, pendingOrder.Status)
-
-	// Test cancellation from PARTIALLY_FILLED state
-	partialOrder := &orders.Order{
-		ID:     "order-003",
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Status: orders.StatusPartiallyFilled,
+	// Orders that are not done can be cancelled
+	for i, status := range []orders.OrderStatus{
+		orders.OrderStatusNew,
+		orders.OrderStatusPending,
+		orders.OrderStatusPartiallyFilled,
+	} {
+		order := newLifecycleOrder(t, lifecycle, fmt.Sprintf("order-%03d", i+1), status)
+		require.NoError(t, lifecycle.CancelOrder(ctx, order), "cancel from %s", status)
+		assert.Equal(t, orders.OrderStatusCancelled, order.Status)
 	}
 
-	err = lifecycle.TransitionTo(ctx, partialOrder, orders.StatusCancelled, "User cancellation")
-	require.NoError(t, err)
-	assert.Equal(t, orders.StatusCancelled, partialOrder.Status)
-
 	// Test invalid cancellation from FILLED state
-	filledOrder := &orders.Order{
-		ID:     "order-004",
-		UserID: "user-001",
-		Symbol: "AAPL",
-		Status: orders.StatusFilled,
-	}
-
-	err = lifecycle.TransitionTo(ctx, filledOrder, orders.StatusCancelled, "Invalid cancellation")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "cannot cancel filled order")
+	filledOrder := newLifecycleOrder(t, lifecycle, "order-004", orders.OrderStatusFilled)
+	err := lifecycle.CancelOrder(ctx, filledOrder)
+	assert.ErrorIs(t, err, orders.ErrInvalidStatusTransition)
+	assert.Equal(t, orders.OrderStatusFilled, filledOrder.Status)
 }
 
 func TestOrderValidator_BusinessRules(t *testing.T) {
-	validator := orders.NewValidator(&orders.ValidatorConfig{
-		MaxOrderValue:       1000000,
-		MaxQuantity:         10000,
-		MinPrice:           0.01,
-		MaxPrice:           10000.00,
-		EnableMarketHours:   true,
-		EnableSymbolCheck:   true,
-	})
-
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+		MinPrice:       types.MustParseDecimal("1"),
+		MaxPrice:       types.MustParseDecimal("10000"),
+	}))
+
+	validator := orders.NewOrderValidator(zap.NewNop())
 	ctx := context.Background()
 
 	// Test valid order
-	validOrder := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeLimit,
-		Quantity: 100,
-		Price:    150.50,
-	}
+	assert.NoError(t, validator.ValidateOrderRequest(ctx, limitOrderRequest("user-001", "", "AAPL", "100", "150.50")))
 
-	violations, err := validator.ValidateOrder(ctx, validOrder)
-	require.NoError(t, err)
-	assert.Empty(t, violations)
+	// Test order exceeding the default value limit of 10,000,000
+	highValue := limitOrderRequest("user-001", "", "AAPL", "100000", "150.50")
+	assert.ErrorIs(t, validator.ValidateOrderRequest(ctx, highValue), orders.ErrOrderValueExceedsLimit)
 
-	// Test order exceeding value limit
-	highValueOrder := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeLimit,
-		Quantity: 10000,
-		Price:    150.50, // 10000 * 150.50 = 1,505,000 > 1,000,000 limit
-	}
+	// Test order with a price off the tick size
+	assert.ErrorIs(t, validator.ValidateOrderRequest(ctx, limitOrderRequest("user-001", "", "AAPL", "100", "150.505")), orders.ErrInvalidPricePrecision)
 
-	violations, err = validator.ValidateOrder(ctx, highValueOrder)
-	require.NoError(t, err)
-	assert.NotEmpty(t, violations)
-	assert.Contains(t, violations[0].Message, "order value exceeds maximum")
+	// Test order with a price below the instrument's static band
+	err := validator.ValidateOrderRequest(ctx, limitOrderRequest("user-001", "", "AAPL", "100", "0.50"))
+	assert.Equal(t, types.RejectReasonPriceOutsideStaticBand, types.RejectReasonOf(err))
 
-	// Test order with invalid price
-	invalidPriceOrder := &orders.CreateOrderRequest{
-		UserID:   "user-001",
-		Symbol:   "AAPL",
-		Side:     orders.SideBuy,
-		Type:     orders.TypeLimit,
-		Quantity: 100,
-		Price:    0.005, // Below minimum price
-	}
-
-	violations, err = validator.ValidateOrder(ctx, invalidPriceOrder)
-	require.NoError(t, err)
-	assert.NotEmpty(t, violations)
-	assert.Contains(t, violations[0].Message, "price below minimum")
+	// An unlisted symbol falls back to the default minimum
+	assert.ErrorIs(t, validator.ValidateOrderRequest(ctx, limitOrderRequest("user-001", "", "MSFT", "100", "0.00005")), orders.ErrPriceBelowMinimum)
 }
 
 func BenchmarkOrderService_CreateOrder(b *testing.B) {
-	service := orders.NewService(&orders.Config{
-		MaxOrdersPerUser:    1000,
-		MaxOrderValue:       1000000,
-		EnableRiskChecks:    false, // Disable for benchmarking
-		EnableCompliance:    false, // Disable for benchmarking
-		OrderTimeout:        30 * time.Minute,
-	})
-
+	service := newManagedOrderService(b)
 	ctx := context.Background()
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		orderReq := &orders.CreateOrderRequest{
-			UserID:      "user-001",
-			ClientOrderID: "client-" + string(rune(i)),
-			Symbol:      "AAPL",
-			Side:        orders.SideBuy,
-			Type:        orders.TypeLimit,
-			Quantity:    100,
-			Price:       150.50 + float64(i%100)*0.01,
-			TimeInForce: orders.TimeInForceGTC,
-		}
+		req := limitOrderRequest("user-001", fmt.Sprintf("client-%d", i), "AAPL", "100", "150.50")
+		req.Price = types.NewDecimal(int64(15050+i%100), 2)
 
-		_, err := service.CreateOrder(ctx, orderReq)
-		if err != nil {
+		if _, err := service.CreateOrder(ctx, req); err != nil {
 			b.Fatal(err)
 		}
 	}
 }
 
 func BenchmarkOrderLifecycle_StateTransition(b *testing.B) {
-	lifecycle := orders.NewLifecycle(&orders.LifecycleConfig{
-		EnableStateValidation: true,
-		EnableAuditTrail:     false, // Disable for benchmarking
-		MaxRetries:           3,
-	})
-
+	lifecycle := orders.NewOrderLifecycle(newManagedOrderService(b), zap.NewNop())
 	ctx := context.Background()
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		order := &orders.Order{
-			ID:     "order-" + string(rune(i)),
-			UserID: "user-001",
-			Symbol: "AAPL",
-			Status: orders.StatusNew,
-		}
+		order := newLifecycleOrder(b, lifecycle, fmt.Sprintf("order-%d", i), orders.OrderStatusNew)
 
-		err := lifecycle.TransitionTo(ctx, order, orders.StatusPending, "Benchmark transition")
-		if err != nil {
+		if err := lifecycle.SubmitOrder(ctx, order); err != nil {
 			b.Fatal(err)
 		}
 	}
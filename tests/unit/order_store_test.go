@@ -0,0 +1,215 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/config"
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/db/repositories"
+	"github.com/abdoElHodaky/tradSys/internal/orders"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// orderTable keeps the last saved state of each order in insertion order
+type orderTable struct {
+	ids     []string
+	records map[string]*db.Order
+}
+
+func (t *orderTable) Update(ctx context.Context, order *db.Order) error {
+	if _, exists := t.records[order.ID]; !exists {
+		t.ids = append(t.ids, order.ID)
+	}
+	t.records[order.ID] = order
+	return nil
+}
+
+func (t *orderTable) FindOrders(ctx context.Context, query *repositories.OrderQuery) ([]*db.Order, error) {
+	var found []*db.Order
+	for i := len(t.ids) - 1; i >= 0; i-- {
+		record := t.records[t.ids[i]]
+		if (query.UserID != "" && record.UserID != query.UserID) ||
+			(query.ClientOrderID != "" && record.ClientOrderID != query.ClientOrderID) ||
+			(query.Symbol != "" && record.Symbol != query.Symbol) ||
+			(query.Status != "" && record.Status != query.Status) ||
+			record.CreatedAt.Before(query.StartTime) {
+			continue
+		}
+		found = append(found, record)
+	}
+	if query.Offset >= len(found) {
+		return nil, nil
+	}
+	found = found[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(found) {
+		found = found[:query.Limit]
+	}
+	return found, nil
+}
+
+func (t *orderTable) GetActiveOrders(ctx context.Context) ([]*db.Order, error) {
+	var active []*db.Order
+	for _, id := range t.ids {
+		switch record := t.records[id]; record.Status {
+		case "new", "pending", "partially_filled":
+			active = append(active, record)
+		}
+	}
+	return active, nil
+}
+
+func TestOrderService_PersistentStore(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	table := &orderTable{records: make(map[string]*db.Order)}
+	service := orders.NewOrderService(engine, zap.NewNop())
+	service.SetOrderRepository(table)
+
+	place := func(service *orders.OrderService, user string, side orders.OrderSide, quantity string) *orders.Order {
+		order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+			UserID:      user,
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        orders.OrderTypeLimit,
+			Price:       types.MustParseDecimal("100"),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: orders.TimeInForceGTC,
+		})
+		require.NoError(t, err)
+		require.NoError(t, service.SubmitOrder(ctx, order))
+		return order
+	}
+
+	first := place(service, "alice", orders.OrderSideBuy, "1")
+	place(service, "bob", orders.OrderSideSell, "1")
+	resting := place(service, "alice", orders.OrderSideSell, "10")
+	place(service, "bob", orders.OrderSideBuy, "4")
+	assert.Equal(t, "filled", table.records[first.ID].Status)
+	assert.Equal(t, "partially_filled", table.records[resting.ID].Status)
+
+	// A second partial fill keeps the status but is still written through
+	place(service, "carol", orders.OrderSideBuy, "2")
+	assert.Equal(t, "6", table.records[resting.ID].FilledQuantity.String())
+
+	page, err := service.GetOrdersByUser(ctx, "alice", &orders.OrderFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Same(t, resting, page[0], "newest first, as the live order")
+	page, err = service.GetOrdersByUser(ctx, "alice", &orders.OrderFilter{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first.ID, page[0].ID)
+
+	// An order the store thinks is live but no book holds
+	stale := *table.records[first.ID]
+	stale.ID = "stale"
+	stale.Status = "pending"
+	require.NoError(t, table.Update(ctx, &stale))
+
+	// Quantities beyond float64 precision are stored exactly
+	precise := stale
+	precise.ID = "precise"
+	precise.Quantity = types.MustParseDecimal("123456789.123456789")
+	require.NoError(t, table.Update(ctx, &precise))
+
+	// A new service over the same books reconciles the stored orders
+	restarted := orders.NewOrderService(engine, zap.NewNop())
+	restarted.SetOrderRepository(table)
+	restored, err := restarted.Recover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored)
+	assert.Equal(t, "cancelled", table.records["stale"].Status)
+	cancelled, err := restarted.GetOrder(ctx, "precise")
+	require.NoError(t, err)
+	assert.Equal(t, "123456789.123456789", cancelled.Quantity.String())
+	assert.Equal(t, "123456789.123456789", table.records["precise"].Quantity.String())
+
+	recovered, err := restarted.GetOrder(ctx, resting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, orders.OrderStatusPartiallyFilled, recovered.Status)
+	assert.Equal(t, "6", recovered.FilledQuantity.String())
+
+	// Fills after the restart reach the recovered order
+	place(restarted, "dave", orders.OrderSideBuy, "4")
+	assert.Equal(t, orders.OrderStatusFilled, recovered.Status)
+	assert.Equal(t, "filled", table.records[resting.ID].Status)
+
+	bySymbol, err := restarted.GetOrdersBySymbol(ctx, "AAPL", &orders.OrderFilter{Status: orders.OrderStatusFilled})
+	require.NoError(t, err)
+	assert.Len(t, bySymbol, 6)
+}
+
+func TestOrderService_RecoversAfterTheSequencer(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:         "AAPL",
+		TradingEnabled: true,
+		TickSize:       types.MustParseDecimal("0.01"),
+	}))
+
+	ctx := context.Background()
+	cfg, err := config.LoadConfig("")
+	require.NoError(t, err)
+	cfg.Trading.Sequencer.DataDir = t.TempDir()
+	cfg.Trading.Sequencer.SnapshotInterval = 0
+	table := &orderTable{records: make(map[string]*db.Order)}
+
+	// Rest an order through a sequencer journaling to the data directory
+	sequencer, err := matching.OpenSequencer(zap.NewNop(), 2, cfg.Trading.Sequencer.DataDir, false)
+	require.NoError(t, err)
+	service := orders.NewOrderService(sequencer, zap.NewNop())
+	service.SetOrderRepository(table)
+	order, err := service.CreateOrder(ctx, &orders.OrderRequest{
+		UserID:      "alice",
+		Symbol:      "AAPL",
+		Side:        orders.OrderSideBuy,
+		Type:        orders.OrderTypeLimit,
+		Price:       types.MustParseDecimal("100"),
+		Quantity:    types.MustParseDecimal("10"),
+		TimeInForce: orders.TimeInForceGTC,
+	})
+	require.NoError(t, err)
+	require.NoError(t, service.SubmitOrder(ctx, order))
+	require.NoError(t, sequencer.Stop())
+
+	// On restart the books are rebuilt before the stored orders are
+	// reconciled, so the resting order is restored rather than cancelled
+	var restarted *orders.OrderService
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(cfg, zap.NewNop()),
+		matching.SequencerModule,
+		fx.Provide(orders.NewFxOrderService),
+		fx.Invoke(func(service *orders.OrderService) {
+			service.SetOrderRepository(table)
+		}),
+		fx.Populate(&restarted),
+	)
+	require.NoError(t, app.Err())
+	require.NoError(t, app.Start(ctx))
+	defer app.Stop(ctx)
+
+	recovered, err := restarted.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, orders.OrderStatusPending, recovered.Status)
+	assert.Equal(t, "pending", table.records[order.ID].Status)
+	_, resting := restarted.MatchingEngine.GetOrder("AAPL", order.ID)
+	assert.True(t, resting)
+}
@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMatchingEngine_PeggedOrders(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol:           "AAPL",
+		TradingEnabled:   true,
+		TickSize:         types.MustParseDecimal("0.01"),
+		MidpointHalfTick: true,
+	}))
+
+	engine := matching.NewMatchingEngine(zap.NewNop())
+	newOrder := func(id string, side matching.OrderSide, price, quantity string) *matching.Order {
+		return &matching.Order{
+			ID:          id,
+			UserID:      id,
+			Symbol:      "AAPL",
+			Side:        side,
+			Type:        matching.OrderTypeLimit,
+			Price:       types.MustParseDecimal(price),
+			Quantity:    types.MustParseDecimal(quantity),
+			TimeInForce: matching.TimeInForceGTC,
+		}
+	}
+	add := func(order *matching.Order) []*matching.Trade {
+		trades, err := engine.AddOrder(order)
+		require.NoError(t, err)
+		return trades
+	}
+
+	add(newOrder("ask", matching.OrderSideSell, "100.10", "10"))
+	add(newOrder("bid", matching.OrderSideBuy, "100.00", "10"))
+
+	primary := newOrder("primary", matching.OrderSideBuy, "0", "10")
+	primary.PegType = types.PegTypePrimary
+	add(primary)
+	assert.Equal(t, "100.00", primary.Price.String())
+
+	midpoint := newOrder("midpoint", matching.OrderSideSell, "0", "10")
+	midpoint.PegType = types.PegTypeMidpoint
+	midpoint.IsHidden = true
+	add(midpoint)
+	assert.Equal(t, "100.05", midpoint.Price.String())
+
+	// A better bid moves both pegs; the primary peg queues behind it and
+	// the midpoint peg rests at a half tick
+	add(newOrder("better-bid", matching.OrderSideBuy, "100.03", "10"))
+	assert.Equal(t, "100.03", primary.Price.String())
+	assert.Equal(t, "100.065", midpoint.Price.String())
+
+	book := engine.GetOrderBook("AAPL")
+	bids, asks := book.GetDepth(5)
+	require.Len(t, bids, 2)
+	assert.Equal(t, "20", bids[0].Quantity.String())
+	require.Len(t, asks, 1, "the hidden midpoint peg is not displayed")
+	assert.Equal(t, "100.10", asks[0].Price.String())
+
+	trades := add(&matching.Order{
+		ID:       "sweep",
+		UserID:   "sweep",
+		Symbol:   "AAPL",
+		Side:     matching.OrderSideSell,
+		Type:     matching.OrderTypeMarket,
+		Quantity: types.MustParseDecimal("15"),
+	})
+	require.Len(t, trades, 2)
+	assert.Equal(t, "better-bid", trades[0].BuyOrderID)
+	assert.Equal(t, "primary", trades[1].BuyOrderID)
+
+	// The pegs follow the bid back down once it trades away
+	assert.Equal(t, "100.00", primary.Price.String())
+	assert.Equal(t, "100.05", midpoint.Price.String())
+
+	trades = add(newOrder("lift", matching.OrderSideBuy, "100.07", "4"))
+	require.Len(t, trades, 1)
+	assert.Equal(t, "midpoint", trades[0].SellOrderID)
+	assert.Equal(t, "100.05", trades[0].Price.String())
+
+	_, err := engine.AmendOrder("AAPL", "primary", types.MustParseDecimal("99"), types.Zero)
+	assert.Equal(t, types.RejectReasonInvalidOrder, types.RejectReasonOf(err))
+}
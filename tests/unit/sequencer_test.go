@@ -0,0 +1,427 @@
+package unit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/abdoElHodaky/tradSys/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// sequencedRun submits a fixed order flow on two symbols and returns the
+// journal it produced
+func sequencedRun(t *testing.T, partitions int) []byte {
+	var journal bytes.Buffer
+	sequencer := matching.NewSequencer(zap.NewNop(), partitions, matching.NewJSONJournal(&journal))
+	require.NoError(t, sequencer.Start())
+
+	for i := 0; i < 20; i++ {
+		for _, symbol := range []string{"AAPL", "MSFT"} {
+			side := matching.OrderSideBuy
+			if i%2 == 1 {
+				side = matching.OrderSideSell
+			}
+			_, err := sequencer.AddOrder(&matching.Order{
+				ID:          fmt.Sprintf("%s-%03d", symbol, i),
+				UserID:      fmt.Sprintf("user-%03d", i%3),
+				Symbol:      symbol,
+				Side:        side,
+				Type:        matching.OrderTypeLimit,
+				Price:       types.NewDecimal(int64(15000+i%5), 2),
+				Quantity:    types.NewDecimal(int64(10+i), 0),
+				TimeInForce: matching.TimeInForceGTC,
+			})
+			require.NoError(t, err)
+		}
+	}
+	sequencer.CancelOrder("AAPL", "AAPL-018")
+
+	require.NoError(t, sequencer.Stop())
+	return journal.Bytes()
+}
+
+// replay feeds a journal's commands to a new sequencer and returns the
+// journal it produced
+func replay(t *testing.T, journal []byte, partitions int) []byte {
+	var output bytes.Buffer
+	sequencer := matching.NewSequencer(zap.NewNop(), partitions, matching.NewJSONJournal(&output))
+	require.NoError(t, sequencer.Start())
+
+	scanner := bufio.NewScanner(bytes.NewReader(journal))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry matching.JournalEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		_, _ = sequencer.Replay(&entry.Command)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.NoError(t, sequencer.Stop())
+	return output.Bytes()
+}
+
+func TestSequencer_ReplayIsDeterministic(t *testing.T) {
+	journal := sequencedRun(t, 4)
+	require.NotEmpty(t, journal)
+	assert.Equal(t, 41, bytes.Count(journal, []byte("\n")), "one journal entry per command")
+	assert.Contains(t, string(journal), `"ID":"5-1"`, "trade IDs derive from the command sequence")
+
+	// The same commands give the same journal whatever the partitioning
+	assert.Equal(t, string(journal), string(replay(t, journal, 1)))
+	assert.Equal(t, string(journal), string(replay(t, journal, 8)))
+}
+
+func TestSequencer_SequenceIsMonotonic(t *testing.T) {
+	sequencer := matching.NewSequencer(zap.NewNop(), 2, nil)
+	_, err := sequencer.Submit(&matching.Command{Type: matching.CommandCancelOrder, Symbol: "AAPL"})
+	assert.ErrorIs(t, err, matching.ErrSequencerNotRunning)
+
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop()
+
+	var last uint64
+	for i := 0; i < 10; i++ {
+		entry, _ := sequencer.Submit(&matching.Command{Type: matching.CommandCancelOrder, Symbol: "AAPL", OrderID: "missing"})
+		require.NotNil(t, entry)
+		assert.Greater(t, entry.Command.Sequence, last)
+		assert.Equal(t, matching.ErrOrderNotFound.Error(), entry.Error)
+		last = entry.Command.Sequence
+	}
+
+	_, err = sequencer.Replay(&matching.Command{Sequence: last, Type: matching.CommandCancelOrder, Symbol: "AAPL"})
+	assert.ErrorIs(t, err, matching.ErrOutOfSequence)
+}
+
+func TestSequencer_RecoverFromSnapshotAndJournal(t *testing.T) {
+	dir := t.TempDir()
+	addOrders := func(sequencer *matching.Sequencer, from, to int) {
+		for i := from; i < to; i++ {
+			side := matching.OrderSideBuy
+			if i%3 == 0 {
+				side = matching.OrderSideSell
+			}
+			_, err := sequencer.AddOrder(&matching.Order{
+				ID:          fmt.Sprintf("order-%03d", i),
+				UserID:      fmt.Sprintf("user-%03d", i%4),
+				Symbol:      "AAPL",
+				Side:        side,
+				Type:        matching.OrderTypeLimit,
+				Price:       types.NewDecimal(int64(15000+i%7), 2),
+				Quantity:    types.NewDecimal(int64(5+i%11), 0),
+				TimeInForce: matching.TimeInForceGTC,
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	sequencer, err := matching.OpenSequencer(zap.NewNop(), 2, dir, false)
+	require.NoError(t, err)
+	addOrders(sequencer, 0, 30)
+	require.NoError(t, matching.WriteSnapshot(filepath.Join(dir, matching.SnapshotFile), sequencer.Snapshot()))
+	addOrders(sequencer, 30, 60)
+	sequencer.CancelOrder("AAPL", "order-058")
+	before, err := json.Marshal(sequencer.Snapshot())
+	require.NoError(t, err)
+	require.NoError(t, sequencer.Stop())
+
+	// A record torn by a crash is dropped on recovery
+	journal, err := os.OpenFile(filepath.Join(dir, matching.JournalFile), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = journal.Write([]byte{0x40, 0x00, 0x00, 0x00, 0x01})
+	require.NoError(t, err)
+	require.NoError(t, journal.Close())
+
+	recovered, err := matching.OpenSequencer(zap.NewNop(), 4, dir, false)
+	require.NoError(t, err)
+	defer recovered.Stop()
+
+	after, err := json.Marshal(recovered.Snapshot())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+	assert.Equal(t, uint64(61), recovered.Sequence())
+
+	entry, err := recovered.Submit(&matching.Command{Type: matching.CommandCancelOrder, Symbol: "AAPL", OrderID: "order-059"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(62), entry.Command.Sequence)
+}
+
+// crossingOrders returns a bid and an ask on symbol that trade with each
+// other at 100
+func crossingOrders(symbol string) []*matching.Order {
+	var orders []*matching.Order
+	for i, side := range []matching.OrderSide{matching.OrderSideBuy, matching.OrderSideSell} {
+		orders = append(orders, &matching.Order{
+			ID:          fmt.Sprintf("%s-%d", symbol, i),
+			UserID:      fmt.Sprintf("user-%d", i),
+			Symbol:      symbol,
+			Side:        side,
+			Type:        matching.OrderTypeLimit,
+			Price:       types.NewDecimal(100, 0),
+			Quantity:    types.NewDecimal(10, 0),
+			TimeInForce: matching.TimeInForceGTC,
+		})
+	}
+	return orders
+}
+
+func TestSequencer_HaltsAreSequenced(t *testing.T) {
+	var journal bytes.Buffer
+	sequencer := matching.NewSequencer(zap.NewNop(), 4, matching.NewJSONJournal(&journal))
+	require.NoError(t, sequencer.Start())
+
+	// A symbol halt queues the crossing orders of that symbol only
+	require.NoError(t, sequencer.Halt("AAPL", "volatility"))
+	assert.True(t, sequencer.IsHalted("AAPL"))
+	assert.False(t, sequencer.IsHalted("MSFT"))
+	for _, order := range crossingOrders("AAPL") {
+		trades, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+	}
+	trades, err := sequencer.Resume("AAPL")
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	assert.False(t, sequencer.IsHalted("AAPL"))
+
+	// A market-wide halt reaches every partition, and the re-opening
+	// auctions number their trades in one series
+	require.NoError(t, sequencer.HaltMarket("market"))
+	for _, symbol := range []string{"GOOG", "MSFT", "TSLA"} {
+		assert.True(t, sequencer.IsHalted(symbol))
+		for _, order := range crossingOrders(symbol) {
+			trades, err := sequencer.AddOrder(order)
+			require.NoError(t, err)
+			assert.Empty(t, trades)
+		}
+	}
+	trades, err = sequencer.ResumeMarket()
+	require.NoError(t, err)
+	require.Len(t, trades, 3)
+	ids := map[string]bool{}
+	for _, trade := range trades {
+		ids[trade.ID] = true
+	}
+	assert.Len(t, ids, 3)
+	assert.False(t, sequencer.IsHalted("MSFT"))
+	require.NoError(t, sequencer.Stop())
+
+	// Halts replay to the same journal whatever the partitioning
+	assert.Contains(t, journal.String(), `"reason":"market"`)
+	assert.Equal(t, journal.String(), string(replay(t, journal.Bytes(), 1)))
+	assert.Equal(t, journal.String(), string(replay(t, journal.Bytes(), 8)))
+}
+
+func TestSequencer_RecoverReplaysHalts(t *testing.T) {
+	dir := t.TempDir()
+
+	sequencer, err := matching.OpenSequencer(zap.NewNop(), 2, dir, false)
+	require.NoError(t, err)
+	require.NoError(t, sequencer.HaltMarket("market"))
+	for _, order := range crossingOrders("AAPL") {
+		_, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+	}
+	require.NoError(t, sequencer.Stop())
+
+	recovered, err := matching.OpenSequencer(zap.NewNop(), 4, dir, false)
+	require.NoError(t, err)
+	defer recovered.Stop()
+
+	assert.True(t, recovered.IsHalted("AAPL"))
+	assert.True(t, recovered.IsHalted("MSFT"), "books created during the halt join it")
+	trades, err := recovered.ResumeMarket()
+	require.NoError(t, err)
+	assert.Len(t, trades, 1)
+}
+
+func TestSequencer_SnapshotCompactsJournal(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, matching.JournalFile)
+	countEntries := func() int {
+		count := 0
+		require.NoError(t, matching.ReadJournal(journalPath, func(*matching.JournalEntry) error {
+			count++
+			return nil
+		}))
+		return count
+	}
+
+	sequencer, err := matching.OpenSequencer(zap.NewNop(), 2, dir, false)
+	require.NoError(t, err)
+	for _, order := range crossingOrders("AAPL")[:1] {
+		_, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+	}
+	require.NoError(t, sequencer.Halt("MSFT", "news"))
+	require.Equal(t, 2, countEntries())
+
+	// The snapshot covers every entry so far; later ones stay journaled
+	require.NoError(t, sequencer.WriteSnapshot(filepath.Join(dir, matching.SnapshotFile)))
+	assert.Equal(t, 0, countEntries())
+	for _, order := range crossingOrders("MSFT") {
+		_, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, countEntries())
+	before, err := json.Marshal(sequencer.Snapshot())
+	require.NoError(t, err)
+	require.NoError(t, sequencer.Stop())
+
+	recovered, err := matching.OpenSequencer(zap.NewNop(), 2, dir, false)
+	require.NoError(t, err)
+	defer recovered.Stop()
+
+	after, err := json.Marshal(recovered.Snapshot())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+	assert.Equal(t, uint64(4), recovered.Sequence())
+	assert.True(t, recovered.IsHalted("MSFT"))
+}
+
+func TestSequencer_UpdatePhasesSequencesScheduleChanges(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+
+	var journal bytes.Buffer
+	sequencer := matching.NewSequencer(zap.NewNop(), 2, matching.NewJSONJournal(&journal))
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop()
+
+	for _, order := range crossingOrders("PHASE")[:1] {
+		_, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+	}
+	// Unchanged phases are not sequenced
+	sequencer.UpdatePhases(time.Now())
+	assert.Equal(t, uint64(1), sequencer.Sequence())
+
+	require.NoError(t, types.Instruments.Register(&types.Instrument{
+		Symbol: "PHASE",
+		Schedule: &types.TradingSchedule{
+			Exchange: "TEST",
+			PreOpen:  9 * time.Hour,
+			Open:     22 * time.Hour,
+			Close:    23 * time.Hour,
+		},
+	}))
+	sequencer.UpdatePhases(time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, uint64(2), sequencer.Sequence())
+	assert.Equal(t, types.TradingPhasePreOpen, sequencer.GetOrderBook("PHASE").CurrentPhase())
+	assert.Contains(t, journal.String(), `"type":"SET_PHASE","symbol":"PHASE"`)
+}
+
+// failingJournal is a JSON journal whose appends fail while fail is set
+type failingJournal struct {
+	buffer bytes.Buffer
+	fail   bool
+}
+
+// Append writes the entry unless the journal is failing
+func (j *failingJournal) Append(entry *matching.JournalEntry) error {
+	if j.fail {
+		return errors.New("disk full")
+	}
+	return matching.NewJSONJournal(&j.buffer).Append(entry)
+}
+
+func TestSequencer_JournalFailureStopsPartition(t *testing.T) {
+	journal := &failingJournal{}
+	sequencer := matching.NewSequencer(zap.NewNop(), 4, journal)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop()
+
+	aapl := crossingOrders("AAPL")
+	_, err := sequencer.AddOrder(aapl[0])
+	require.NoError(t, err)
+
+	// The submitter learns the command was not journaled
+	journal.fail = true
+	_, err = sequencer.AddOrder(aapl[1])
+	assert.ErrorIs(t, err, matching.ErrJournalFailed)
+	journal.fail = false
+
+	// The partition takes no more commands, and nothing is sequenced
+	sequence := sequencer.Sequence()
+	assert.False(t, sequencer.CancelOrder("AAPL", "AAPL-0"))
+	_, err = sequencer.AddOrder(&matching.Order{
+		ID:          "AAPL-2",
+		UserID:      "user-2",
+		Symbol:      "AAPL",
+		Side:        matching.OrderSideBuy,
+		Type:        matching.OrderTypeLimit,
+		Price:       types.NewDecimal(100, 0),
+		Quantity:    types.NewDecimal(10, 0),
+		TimeInForce: matching.TimeInForceGTC,
+	})
+	assert.ErrorIs(t, err, matching.ErrJournalFailed)
+	assert.Equal(t, sequence, sequencer.Sequence())
+
+	// Symbols of other partitions keep trading; market-wide commands and
+	// snapshots would cover the stopped partition
+	for _, order := range crossingOrders("IBM") {
+		_, err := sequencer.AddOrder(order)
+		require.NoError(t, err)
+	}
+	assert.ErrorIs(t, sequencer.HaltMarket("news"), matching.ErrJournalFailed)
+	assert.ErrorIs(t, sequencer.WriteSnapshot(filepath.Join(t.TempDir(), matching.SnapshotFile)), matching.ErrJournalFailed)
+
+	// Replaying the journal leaves out the failed command
+	recovered := matching.NewSequencer(zap.NewNop(), 4, nil)
+	require.NoError(t, recovered.Start())
+	defer recovered.Stop()
+	scanner := bufio.NewScanner(bytes.NewReader(journal.buffer.Bytes()))
+	entries := 0
+	for scanner.Scan() {
+		var entry matching.JournalEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		_, err := recovered.Replay(&entry.Command)
+		require.NoError(t, err)
+		entries++
+	}
+	assert.Equal(t, 3, entries)
+
+	_, resting := recovered.GetOrder("AAPL", "AAPL-0")
+	assert.True(t, resting)
+	_, resting = recovered.GetOrder("AAPL", "AAPL-1")
+	assert.False(t, resting)
+}
+
+func TestSequencer_ExpireOrdersIsSequenced(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+
+	var journal bytes.Buffer
+	sequencer := matching.NewSequencer(zap.NewNop(), 2, matching.NewJSONJournal(&journal))
+	require.NoError(t, sequencer.Start())
+
+	orders := crossingOrders("EXPIRY")[:1]
+	orders[0].TimeInForce = matching.TimeInForceGTD
+	orders[0].ExpireTime = time.Now().Add(50 * time.Millisecond)
+	_, err := sequencer.AddOrder(orders[0])
+	require.NoError(t, err)
+
+	// Books without expired orders are not sequenced
+	assert.Empty(t, sequencer.ExpireOrders(time.Now()))
+	assert.Equal(t, uint64(1), sequencer.Sequence())
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{"EXPIRY-0"}, sequencer.ExpireOrders(time.Now()))
+	assert.Equal(t, uint64(2), sequencer.Sequence())
+	_, resting := sequencer.GetOrder("EXPIRY", "EXPIRY-0")
+	assert.False(t, resting)
+
+	require.NoError(t, sequencer.Stop())
+	assert.Contains(t, journal.String(), `"type":"EXPIRE_ORDERS","symbol":"EXPIRY"`)
+}
@@ -0,0 +1,197 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/risk"
+	riskengine "github.com/abdoElHodaky/tradSys/internal/risk/engine"
+	"github.com/abdoElHodaky/tradSys/internal/risk/options"
+	"github.com/abdoElHodaky/tradSys/internal/trading/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stressBook holds fixed positions by account
+type stressBook struct {
+	positions map[string][]*riskengine.Position
+	prices    map[string]float64
+}
+
+func (b *stressBook) AccountPositions(accounts ...string) map[string][]*riskengine.Position {
+	if len(accounts) == 0 {
+		return b.positions
+	}
+	positions := make(map[string][]*riskengine.Position)
+	for _, account := range accounts {
+		positions[account] = b.positions[account]
+	}
+	return positions
+}
+
+func (b *stressBook) LastPrices() map[string]float64 {
+	return b.prices
+}
+
+func TestStressEngine_StressTest(t *testing.T) {
+	registry := types.Instruments
+	types.Instruments = types.NewInstrumentRegistry()
+	defer func() { types.Instruments = registry }()
+	for _, instrument := range []*types.Instrument{
+		{Symbol: "AAPL", AssetType: types.AssetTypeStock, Currency: "USD"},
+		{Symbol: "MSFT", AssetType: types.AssetTypeStock, Currency: "USD"},
+		{Symbol: "COMI", AssetType: types.AssetTypeStock, Currency: "EGP"},
+		{Symbol: "BTC", AssetType: types.AssetTypeCrypto, Currency: "USD"},
+	} {
+		require.NoError(t, types.Instruments.Register(instrument))
+	}
+
+	ctx := context.Background()
+	call := &options.Contract{
+		Underlying: "AAPL",
+		Type:       options.Call,
+		Style:      options.European,
+		Strike:     100,
+		Expiry:     time.Now().Add(90 * 24 * time.Hour),
+		Multiplier: 100,
+	}
+	book := &stressBook{
+		positions: map[string][]*riskengine.Position{
+			"alice": {
+				{Symbol: "AAPL", Quantity: 100},
+				{Symbol: "COMI", Quantity: 1000},
+				{Symbol: "AAPL 100C", Quantity: 5, Option: call, ImpliedVolatility: 0.25},
+			},
+			"bob": {
+				{Symbol: "BTC", Quantity: 2},
+				{Symbol: "MSFT", Quantity: 50},
+			},
+		},
+		prices: map[string]float64{"AAPL": 100, "MSFT": 100, "COMI": 50, "BTC": 30000},
+	}
+
+	engine := risk.NewStressEngine(book, zap.NewNop())
+	engine.SetFXRates(map[string]float64{"EGP": 0.02})
+
+	t.Run("price shocks", func(t *testing.T) {
+		results, err := engine.StressTest(ctx, []risk.StressScenario{{
+			Name:             "crash",
+			Probability:      0.01,
+			PriceShocks:      map[string]float64{"STOCK": -0.3, "BTC": -0.5},
+			VolatilityShocks: map[string]float64{"*": 2},
+		}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		result := results[0]
+
+		assert.Equal(t, "crash", result.Scenario)
+		assert.Equal(t, 0.01, result.Probability)
+		assert.InDelta(t, -3000, result.BySymbol["AAPL"], 1e-6)
+		assert.InDelta(t, -300, result.BySymbol["COMI"], 1e-6)
+		assert.InDelta(t, -30000, result.BySymbol["BTC"], 1e-6)
+		assert.InDelta(t, -1500, result.BySymbol["MSFT"], 1e-6)
+		assert.InDelta(t, -4800, result.ByAssetClass["STOCK"], 1e-6)
+		assert.InDelta(t, -30000, result.ByAssetClass["CRYPTO"], 1e-6)
+
+		option := result.BySymbol["AAPL 100C"]
+		assert.Less(t, option, 0.0)
+		assert.Equal(t, option, result.ByAssetClass[risk.AssetClassOption])
+		assert.InDelta(t, -3300+option, result.ByAccount["alice"], 1e-6)
+		assert.InDelta(t, -31500, result.ByAccount["bob"], 1e-6)
+		assert.InDelta(t, result.ByAccount["alice"]+result.ByAccount["bob"], result.PnL, 1e-6)
+		assert.Len(t, result.Positions, 5)
+	})
+
+	t.Run("fx shock", func(t *testing.T) {
+		results, err := engine.StressTest(ctx, []risk.StressScenario{{
+			Name:     "devaluation",
+			FXShocks: map[string]float64{"EGP": -0.4},
+		}}, "alice")
+		require.NoError(t, err)
+
+		result := results[0]
+		assert.InDelta(t, -400, result.PnL, 1e-6)
+		assert.InDelta(t, -400, result.BySymbol["COMI"], 1e-6)
+		assert.NotContains(t, result.ByAccount, "bob")
+		for _, position := range result.Positions {
+			if position.Symbol == "COMI" {
+				assert.InDelta(t, 1000, position.Value, 1e-6)
+				assert.InDelta(t, 600, position.StressedValue, 1e-6)
+			}
+		}
+	})
+
+	bars := correlatedBars("AAPL", "MSFT", 0.02, 0.8)
+	for i, price := range []float64{100, 90, 80, 70} {
+		bars["AAPL"] = append(bars["AAPL"], &db.MarketData{
+			Symbol:    "AAPL",
+			Close:     price,
+			Timestamp: time.Date(2008, 9, 15+7*i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	engine.SetPriceHistory(bars)
+
+	t.Run("replay", func(t *testing.T) {
+		results, err := engine.StressTest(ctx, []risk.StressScenario{{
+			Name: "gfc",
+			Replay: &risk.ReplayWindow{
+				Start: time.Date(2008, 9, 12, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2008, 10, 10, 0, 0, 0, 0, time.UTC),
+			},
+		}}, "alice")
+		require.NoError(t, err)
+
+		result := results[0]
+		assert.InDelta(t, -3000, result.BySymbol["AAPL"], 1e-6)
+		assert.Less(t, result.BySymbol["AAPL 100C"], 0.0)
+		assert.Zero(t, result.BySymbol["COMI"])
+	})
+
+	t.Run("propagation", func(t *testing.T) {
+		results, err := engine.StressTest(ctx, []risk.StressScenario{
+			{Name: "aapl", PriceShocks: map[string]float64{"AAPL": -0.1}, Propagate: true},
+			{Name: "aapl correlated", PriceShocks: map[string]float64{"AAPL": -0.1}, Propagate: true, CorrelationShock: 1},
+			{Name: "aapl alone", PriceShocks: map[string]float64{"AAPL": -0.1}},
+		}, "bob", "alice")
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		// MSFT follows AAPL by its beta, about the 0.8 correlation of two
+		// equally volatile symbols, and one-for-one once correlations are one
+		assert.InDelta(t, -400, results[0].BySymbol["MSFT"], 100)
+		assert.Greater(t, results[0].BySymbol["MSFT"], results[1].BySymbol["MSFT"])
+		assert.InDelta(t, -500, results[1].BySymbol["MSFT"], 50)
+		assert.Zero(t, results[2].BySymbol["MSFT"])
+		assert.Zero(t, results[0].BySymbol["BTC"])
+	})
+
+	t.Run("scenarios", func(t *testing.T) {
+		scenarios, err := risk.LoadStressScenarios("../../config/stress_scenarios.yaml")
+		require.NoError(t, err)
+		require.NoError(t, engine.AddScenarios(scenarios...))
+		assert.Len(t, engine.Scenarios(), len(scenarios))
+
+		results, err := engine.RunScenarios(ctx, []string{"flash_crash", "egp_devaluation"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.InDelta(t, -30000, results[0].ByAssetClass["CRYPTO"], 1e-6)
+		assert.InDelta(t, 50*0.02*1000*(1.15*0.6-1), results[1].BySymbol["COMI"], 1e-6)
+
+		_, err = engine.RunScenarios(ctx, []string{"missing"})
+		assert.Equal(t, risk.ErrStressScenarioNotFound, err)
+	})
+
+	t.Run("invalid scenarios", func(t *testing.T) {
+		_, err := risk.ParseStressScenarios([]byte("scenarios:\n  - name: typo\n    price_shock:\n      \"*\": -0.1\n"))
+		assert.Error(t, err)
+
+		_, err = risk.ParseStressScenarios([]byte("scenarios:\n  - name: wipeout\n    price_shocks:\n      \"*\": -1\n"))
+		assert.Equal(t, risk.ErrInvalidStressScenario, err)
+
+		_, err = engine.StressTest(ctx, []risk.StressScenario{{Name: "bad", CorrelationShock: 2}})
+		assert.Equal(t, risk.ErrInvalidStressScenario, err)
+	})
+}
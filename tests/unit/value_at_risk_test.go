@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/abdoElHodaky/tradSys/internal/db"
+	"github.com/abdoElHodaky/tradSys/internal/risk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// priceBars serves daily closes by symbol
+type priceBars map[string][]*db.MarketData
+
+func (p priceBars) GetOHLCVBySymbolAndTimeRange(ctx context.Context, symbol string, interval string, start, end time.Time) ([]*db.MarketData, error) {
+	var bars []*db.MarketData
+	for _, bar := range p[symbol] {
+		if !bar.Timestamp.Before(start) && !bar.Timestamp.After(end) {
+			bars = append(bars, bar)
+		}
+	}
+	return bars, nil
+}
+
+// correlatedBars builds a year of daily closes for two symbols whose
+// returns have the given volatility and correlation
+func correlatedBars(first, second string, volatility, correlation float64) priceBars {
+	random := rand.New(rand.NewSource(7))
+	bars := priceBars{}
+	prices := []float64{100, 100}
+	day := time.Now().Truncate(24 * time.Hour).Add(-250 * 24 * time.Hour)
+	for i := 0; i < 250; i++ {
+		a, b := random.NormFloat64(), random.NormFloat64()
+		prices[0] *= 1 + volatility*a
+		prices[1] *= 1 + volatility*(correlation*a+math.Sqrt(1-correlation*correlation)*b)
+		day = day.Add(24 * time.Hour)
+		bars[first] = append(bars[first], &db.MarketData{Symbol: first, Type: "ohlcv", Close: prices[0], Timestamp: day})
+		bars[second] = append(bars[second], &db.MarketData{Symbol: second, Type: "ohlcv", Close: prices[1], Timestamp: day})
+	}
+	return bars
+}
+
+func TestCalculator_ValueAtRisk(t *testing.T) {
+	ctx := context.Background()
+	bars := correlatedBars("AAPL", "MSFT", 0.02, 0.8)
+	prices := map[string]float64{"AAPL": 100, "MSFT": 200}
+	positions := []*risk.Position{
+		{UserID: "alice", Symbol: "AAPL", Quantity: 100, AveragePrice: 100},
+		{UserID: "alice", Symbol: "MSFT", Quantity: 50, AveragePrice: 200},
+	}
+
+	calculator := risk.NewCalculator(zap.NewNop())
+	calculator.SetPriceHistory(bars)
+
+	t.Run("historical", func(t *testing.T) {
+		metrics, err := calculator.CalculateAccountRisk(ctx, "alice", positions, prices)
+		require.NoError(t, err)
+
+		assert.Equal(t, risk.VaRMethodHistorical, metrics.VaRMethod)
+		assert.Equal(t, 0.99, metrics.VaRConfidence)
+		assert.Greater(t, metrics.PortfolioVaR95, 0.0)
+		assert.GreaterOrEqual(t, metrics.PortfolioVaR99, metrics.PortfolioVaR95)
+		assert.Equal(t, metrics.PortfolioVaR99, metrics.PortfolioVaR)
+		assert.GreaterOrEqual(t, metrics.PortfolioES, metrics.PortfolioVaR)
+		assert.InDelta(t, 0.8, metrics.CorrelationRisk, 0.1)
+
+		var components, standalone float64
+		for _, position := range metrics.Positions {
+			components += position.ComponentVaR
+			standalone += position.VaR
+			assert.InDelta(t, position.ComponentVaR, position.MarginalVaR*position.Quantity*position.CurrentPrice, 1e-6)
+		}
+		assert.InDelta(t, metrics.PortfolioVaR, components, 1e-6)
+		assert.LessOrEqual(t, metrics.PortfolioVaR, standalone)
+	})
+
+	t.Run("hedged positions", func(t *testing.T) {
+		hedged := []*risk.Position{
+			{UserID: "bob", Symbol: "AAPL", Quantity: 100, AveragePrice: 100},
+			{UserID: "bob", Symbol: "MSFT", Quantity: -50, AveragePrice: 200},
+		}
+		config := calculator.VaRConfig()
+		config.Method = risk.VaRMethodParametric
+		metrics, err := calculator.CalculateAccountRiskWith(ctx, config, "bob", hedged, prices)
+		require.NoError(t, err)
+
+		var standalone float64
+		for _, position := range metrics.Positions {
+			standalone += position.VaR
+		}
+		assert.Less(t, metrics.PortfolioVaR, 0.6*standalone)
+		assert.Greater(t, metrics.Positions[0].ComponentVaR, 0.0)
+		assert.Less(t, metrics.Positions[1].MarginalVaR, 0.0)
+	})
+
+	t.Run("monte carlo", func(t *testing.T) {
+		config := calculator.VaRConfig()
+		config.Method = risk.VaRMethodParametric
+		config.HorizonDays = 10
+		parametric, err := calculator.CalculateAccountRiskWith(ctx, config, "alice", positions, prices)
+		require.NoError(t, err)
+
+		config.Method = risk.VaRMethodMonteCarlo
+		simulated, err := calculator.CalculateAccountRiskWith(ctx, config, "alice", positions, prices)
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, simulated.VaRHorizonDays)
+		assert.InEpsilon(t, parametric.PortfolioVaR, simulated.PortfolioVaR, 0.05)
+		assert.InEpsilon(t, parametric.PortfolioES, simulated.PortfolioES, 0.05)
+
+		again, err := calculator.CalculateAccountRiskWith(ctx, config, "alice", positions, prices)
+		require.NoError(t, err)
+		assert.Equal(t, simulated.PortfolioVaR, again.PortfolioVaR)
+	})
+
+	t.Run("without history", func(t *testing.T) {
+		config := calculator.VaRConfig()
+		config.MinObservations = 1000
+		metrics, err := calculator.CalculateAccountRiskWith(ctx, config, "alice", positions, prices)
+		require.NoError(t, err)
+
+		assert.Equal(t, risk.VaRMethodParametric, metrics.VaRMethod)
+		assert.InDelta(t, metrics.Positions[0].VaR+metrics.Positions[1].VaR, metrics.PortfolioVaR, 1e-6)
+		assert.Zero(t, metrics.Positions[0].ComponentVaR)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		config := calculator.VaRConfig()
+		config.Confidence = 1
+		_, err := calculator.CalculateAccountRiskWith(ctx, config, "alice", positions, prices)
+		assert.Equal(t, risk.ErrInvalidVaRConfig, err)
+		assert.Equal(t, risk.ErrInvalidVaRConfig, calculator.SetVaRConfig(config))
+	})
+}